@@ -13,5 +13,8 @@ echo
 echo "export GIT_MACHINE_TOKEN=$git_token" >> localenv
 read -p "Enter tracking repo: " tracking_repo
 echo "export TRACKING_REPOSITORY=$tracking_repo" >> localenv
+read -p "Enter tracking repo owner: " repo_owner
+echo "export REPOSITORY_OWNER=$repo_owner" >> localenv
 echo "export IS_LOCAL=true" >> localenv
+echo "export ENVIRONMENT=local" >> localenv
 echo "\n\nLocal run configuration written to localenv.\nUse 'source localenv' to apply them. Don't forget to 'rm localenv' when finished!"
@@ -0,0 +1,315 @@
+// This holds the conversions between the messages of the gRPC API and the models of the REST API, so the gRPC server
+// calls the same controllers as the REST routes
+package api
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"harmonia-example.io/src/models"
+)
+
+// Model returns the RFC as a models.RFC, nil if the RFC is nil
+func (x *RFC) Model() *models.RFC {
+	if x == nil {
+		return nil
+	}
+
+	rfc := &models.RFC{
+		EmbargoUntil: asTime(x.EmbargoUntil),
+		LoadTargets:  x.LoadTargets,
+		Priority:     models.Priority(x.Priority),
+		Domain:       x.Domain,
+	}
+	if x.Actions != nil {
+		rfc.Actions = models.Actions{}
+	}
+	for _, action := range x.Actions {
+		rfc.Actions = append(rfc.Actions, action.Model())
+	}
+	return rfc
+}
+
+// Model returns the action as a models.Action
+func (x *Action) Model() *models.Action {
+	action := &models.Action{
+		ActionType: models.ActionType(x.GetActionType()),
+		Target: models.Target{
+			TargetType:       models.TargetType(x.GetTarget().GetTargetType()),
+			TargetDescriptor: x.GetTarget().GetTargetDescriptor(),
+			LookupKey:        x.GetTarget().GetLookupKey(),
+			LookupValue:      x.GetTarget().GetLookupValue(),
+		},
+		Signature: x.GetSignature(),
+	}
+	if x.GetData() != nil {
+		action.Data = x.GetData().AsMap()
+	}
+	return action
+}
+
+// Model returns the RFC to submit as a models.RFC, an empty one if none is given
+func (x *Submit) Model() *models.RFC {
+	if rfc := x.GetRfc().Model(); rfc != nil {
+		return rfc
+	}
+	return &models.RFC{}
+}
+
+// Model returns the update as a models.Update
+func (x *Update) Model() *models.Update {
+	return &models.Update{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFC:            x.GetRfc().Model(),
+		RFCIdentifier:  x.GetRfcIdentifier(),
+	}
+}
+
+// Model returns the review as a models.Review
+func (x *Review) Model() *models.Review {
+	review := &models.Review{
+		DomainSelector:  models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:   x.GetRfcIdentifier(),
+		Type:            x.GetType(),
+		TopLevelComment: x.GetTopLevelComment(),
+	}
+	if len(x.GetComments()) > 0 {
+		review.Comments = map[string][]string{}
+	}
+	for signature, comments := range x.GetComments() {
+		review.Comments[signature] = comments.GetComments()
+	}
+	return review
+}
+
+// Model returns the merge as a models.Merge
+func (x *Merge) Model() *models.Merge {
+	return &models.Merge{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:  x.GetRfcIdentifier(),
+	}
+}
+
+// Model returns the load as a models.Load
+func (x *Load) Model() *models.Load {
+	return &models.Load{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:  x.GetRfcIdentifier(),
+	}
+}
+
+// Model returns the status request as a models.Status
+func (x *Status) Model() *models.Status {
+	return &models.Status{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:  x.GetRfcIdentifier(),
+	}
+}
+
+// Model returns the query as a models.GetRfcs
+func (x *GetRfcs) Model() *models.GetRfcs {
+	query := &models.GetRfcs{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		Count:          int(x.GetCount()),
+		State:          x.GetState(),
+		Owner:          x.Owner,
+		Merged:         x.Merged,
+		Label:          x.Label,
+		CreatedAfter:   asTime(x.GetCreatedAfter()),
+		HeadPrefix:     x.HeadPrefix,
+		Filter:         x.GetFilter().Model(),
+	}
+	for _, field := range x.GetFields() {
+		query.Fields = append(query.Fields, models.RFCField(field))
+	}
+	return query
+}
+
+// Model returns the filter as a models.RFCFilter, nil if the filter is nil
+func (x *RFCFilter) Model() *models.RFCFilter {
+	if x == nil {
+		return nil
+	}
+
+	filter := &models.RFCFilter{Name: x.Name, Argument: x.Argument, Not: x.Not.Model()}
+	for _, and := range x.And {
+		filter.And = append(filter.And, *and.Model())
+	}
+	for _, or := range x.Or {
+		filter.Or = append(filter.Or, *or.Model())
+	}
+	return filter
+}
+
+// Model returns the request as a models.GetRfcContents
+func (x *GetRfcContents) Model() *models.GetRfcContents {
+	return &models.GetRfcContents{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:  x.GetRfcIdentifier(),
+		Ref:            x.GetRef(),
+	}
+}
+
+// Model returns the request as a models.GetReviews
+func (x *GetReviews) Model() *models.GetReviews {
+	return &models.GetReviews{
+		DomainSelector: models.DomainSelector{Domain: x.GetDomain()},
+		RFCIdentifier:  x.GetRfcIdentifier(),
+	}
+}
+
+// NewLinks returns the given links as a Links message, nil if they are nil
+func NewLinks(links *models.Links) *Links {
+	if links == nil {
+		return nil
+	}
+	return &Links{PullRequest: links.PullRequest, File: links.File, Tag: links.Tag}
+}
+
+// NewRFCIdentifier returns an RFCIdentifier message of the given RFC identifier and links
+func NewRFCIdentifier(rfcIdentifier string, links *models.Links) *RFCIdentifier {
+	return &RFCIdentifier{RfcIdentifier: rfcIdentifier, Links: NewLinks(links)}
+}
+
+// NewSuccess returns a Success message of the given message and links
+func NewSuccess(message string, links *models.Links) *Success {
+	return &Success{Success: message, Links: NewLinks(links)}
+}
+
+// NewJob returns the given job as a Job message, nil if it is nil
+func NewJob(job *models.Job) *Job {
+	if job == nil {
+		return nil
+	}
+	return &Job{
+		Id:            job.ID,
+		Kind:          string(job.Kind),
+		RfcIdentifier: job.RFCIdentifier,
+		State:         string(job.State),
+		Priority:      string(job.Priority),
+		Attempts:      int32(job.Attempts),
+		MaxAttempts:   int32(job.MaxAttempts),
+		Error:         job.Error,
+		NextAttemptAt: asTimestamp(job.NextAttemptAt),
+		CreatedAt:     timestamppb.New(job.CreatedAt),
+		UpdatedAt:     timestamppb.New(job.UpdatedAt),
+	}
+}
+
+// NewStatusResponse returns the given load status as a StatusResponse message
+func NewStatusResponse(status *models.StatusResponse) *StatusResponse {
+	response := &StatusResponse{
+		Status:       status.Status,
+		Targets:      status.Targets,
+		EmbargoUntil: asTimestamp(status.EmbargoUntil),
+		Embargoed:    status.Embargoed,
+		Job:          NewJob(status.Job),
+	}
+	if gate := status.Gate; gate != nil {
+		response.Gate = &LoadGate{
+			Type:         string(gate.Type),
+			State:        string(gate.State),
+			Environment:  gate.Environment,
+			DeploymentId: gate.DeploymentID,
+			Approver:     gate.Approver,
+			MergeOnLoad:  gate.MergeOnLoad,
+		}
+	}
+	return response
+}
+
+// NewRFCs returns the given RFCs as an RFCs message, the titles of each entry of the given RFCs are sorted by RFC
+// identifier
+func NewRFCs(rfcs *models.RFCs) *RFCs {
+	response := &RFCs{}
+	for _, entry := range rfcs.RFCs {
+		identifiers := make([]string, 0, len(entry))
+		for identifier := range entry {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+		for _, identifier := range identifiers {
+			response.Rfcs = append(response.Rfcs, &RFCTitle{RfcIdentifier: identifier, Title: entry[identifier]})
+		}
+	}
+	if rfcs.Count != nil {
+		count := int32(*rfcs.Count)
+		response.Count = &count
+	}
+	if rfcs.Links != nil {
+		response.Links = map[string]*Links{}
+	}
+	for identifier, links := range rfcs.Links {
+		response.Links[identifier] = NewLinks(links)
+	}
+	if rfcs.Summaries != nil {
+		response.Summaries = map[string]*RFCSummary{}
+	}
+	for identifier, summary := range rfcs.Summaries {
+		response.Summaries[identifier] = newRFCSummary(summary)
+	}
+	return response
+}
+
+// newRFCSummary returns the given summary as an RFCSummary message
+func newRFCSummary(summary *models.RFCSummary) *RFCSummary {
+	response := &RFCSummary{
+		State:            summary.State,
+		Approvals:        asInt32(summary.Approvals),
+		ChangesRequested: asInt32(summary.ChangesRequested),
+		Comments:         asInt32(summary.Comments),
+		Mergeable:        summary.Mergeable,
+		LoadStatus:       summary.LoadStatus,
+	}
+	if mergeability := summary.Mergeability; mergeability != nil {
+		response.Mergeability = &Mergeability{
+			Mergeable:        mergeability.Mergeable,
+			Reasons:          mergeability.Reasons,
+			RequiredContexts: mergeability.RequiredContexts,
+			Contexts:         mergeability.Contexts,
+		}
+	}
+	return response
+}
+
+// NewRFCReviews returns the given reviews as an RFCReviews message
+func NewRFCReviews(reviews *models.RFCReviews) *RFCReviews {
+	response := &RFCReviews{RfcIdentifier: reviews.RFCIdentifier}
+	for _, review := range reviews.Reviews {
+		response.Reviews = append(response.Reviews, &RFCReview{
+			Reviewer:    review.Reviewer,
+			Type:        review.Type,
+			State:       review.State,
+			SubmittedAt: timestamppb.New(review.SubmittedAt),
+			Dismissed:   review.Dismissed,
+		})
+	}
+	return response
+}
+
+// asTime returns the given timestamp as a time, nil if it is nil
+func asTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t := timestamp.AsTime()
+	return &t
+}
+
+// asTimestamp returns the given time as a timestamp, nil if it is nil
+func asTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// asInt32 returns the given count as an int32, nil if it is nil
+func asInt32(count *int) *int32 {
+	if count == nil {
+		return nil
+	}
+	value := int32(*count)
+	return &value
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"harmonia-example.io/src/models"
+)
+
+func TestRFCModel(t *testing.T) {
+	// arrange
+	embargo := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	data, _ := structpb.NewStruct(map[string]interface{}{"id": "MyData", "fields": []interface{}{"name"}})
+	submit := &Submit{Rfc: &RFC{
+		Actions: []*Action{{
+			ActionType: "add",
+			Target:     &Target{TargetType: "item", TargetDescriptor: "Event"},
+			Data:       data,
+		}},
+		EmbargoUntil: timestamppb.New(embargo),
+		Priority:     "high",
+		Domain:       "catalog",
+	}}
+
+	// act
+	rfc := submit.Model()
+	empty := (&Submit{}).Model()
+	update := (&Update{RfcIdentifier: "123456"}).Model()
+
+	// assert
+	assert.Equal(t, &models.RFC{
+		Actions: models.Actions{{
+			ActionType: models.AddAction,
+			Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+			Data:       map[string]interface{}{"id": "MyData", "fields": []interface{}{"name"}},
+		}},
+		EmbargoUntil: &embargo,
+		Priority:     models.HighPriority,
+		Domain:       "catalog",
+	}, rfc)
+	assert.Equal(t, &models.RFC{}, empty)
+	assert.Nil(t, update.RFC, "expected an update without an RFC to be missing it")
+}
+
+func TestGetRfcsModel(t *testing.T) {
+	// arrange
+	owner := "tstark"
+	query := &GetRfcs{
+		Count: -1,
+		Owner: &owner,
+		Filter: &RFCFilter{
+			Or:  []*RFCFilter{{Name: "label", Argument: "breaking-change"}, {Name: "headPrefix", Argument: "1662"}},
+			Not: &RFCFilter{Name: "merged", Argument: "true"},
+		},
+		Fields: []string{"links", "loadStatus"},
+	}
+
+	// act
+	model := query.Model()
+
+	// assert
+	assert.Equal(t, &models.GetRfcs{
+		Count: -1,
+		Owner: &owner,
+		Filter: &models.RFCFilter{
+			Or: []models.RFCFilter{
+				{Name: "label", Argument: "breaking-change"},
+				{Name: "headPrefix", Argument: "1662"},
+			},
+			Not: &models.RFCFilter{Name: "merged", Argument: "true"},
+		},
+		Fields: []models.RFCField{models.LinksField, models.LoadStatusField},
+	}, model)
+}
+
+func TestNewRFCs(t *testing.T) {
+	// arrange
+	count := 2
+	approvals := 1
+	mergeable := false
+	rfcs := &models.RFCs{
+		RFCs:  []map[string]string{{"1234": "Add an event", "1233": "Rename an event"}},
+		Count: &count,
+		Links: map[string]*models.Links{"1234": {PullRequest: "https://github.com/owner/repo/pull/1"}},
+		Summaries: map[string]*models.RFCSummary{"1234": {
+			State:        "open",
+			Approvals:    &approvals,
+			Mergeable:    &mergeable,
+			Mergeability: &models.Mergeability{Reasons: []string{"status check ci/build is failure"}},
+		}},
+	}
+
+	// act
+	response := NewRFCs(rfcs)
+
+	// assert
+	assert.Equal(t, []*RFCTitle{
+		{RfcIdentifier: "1233", Title: "Rename an event"},
+		{RfcIdentifier: "1234", Title: "Add an event"},
+	}, response.Rfcs, "expected the titles sorted by RFC identifier")
+	assert.Equal(t, int32(2), response.GetCount())
+	assert.Equal(t, "https://github.com/owner/repo/pull/1", response.Links["1234"].GetPullRequest())
+	summary := response.Summaries["1234"]
+	assert.Equal(t, int32(1), summary.GetApprovals())
+	assert.Nil(t, summary.ChangesRequested, "expected unselected summary fields to be unset")
+	assert.False(t, summary.GetMergeable())
+	assert.NotNil(t, summary.Mergeable)
+	assert.Equal(t, []string{"status check ci/build is failure"}, summary.GetMergeability().GetReasons())
+}
@@ -0,0 +1,500 @@
+// This is the gRPC API of Harmonia, it mirrors the REST routes so internal services can submit and query RFCs without
+// the overhead of JSON over HTTP. Messages mirror the models of the REST API, see the swagger documentation for the
+// details of each field
+// Run `make proto` after changing this file to regenerate the Go code of the package
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: harmonia.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Harmonia_SubmitRequest_FullMethodName  = "/harmonia.v1.Harmonia/SubmitRequest"
+	Harmonia_UpdateRequest_FullMethodName  = "/harmonia.v1.Harmonia/UpdateRequest"
+	Harmonia_ReviewRequest_FullMethodName  = "/harmonia.v1.Harmonia/ReviewRequest"
+	Harmonia_MergeRequest_FullMethodName   = "/harmonia.v1.Harmonia/MergeRequest"
+	Harmonia_LoadRequest_FullMethodName    = "/harmonia.v1.Harmonia/LoadRequest"
+	Harmonia_Status_FullMethodName         = "/harmonia.v1.Harmonia/Status"
+	Harmonia_GetJob_FullMethodName         = "/harmonia.v1.Harmonia/GetJob"
+	Harmonia_GetRfcs_FullMethodName        = "/harmonia.v1.Harmonia/GetRfcs"
+	Harmonia_GetRfcContents_FullMethodName = "/harmonia.v1.Harmonia/GetRfcContents"
+	Harmonia_GetReviews_FullMethodName     = "/harmonia.v1.Harmonia/GetReviews"
+)
+
+// HarmoniaClient is the client API for Harmonia service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Harmonia processes and accepts requests for schema changes
+// Mutating methods are rejected while maintenance mode is enabled and, when request signing is enabled, must carry
+// the x-harmonia-timestamp, x-harmonia-nonce and x-harmonia-signature metadata, the signature being computed over the
+// deterministic binary encoding of the request message
+// Requests named after their method are referenced by their full name, their bare name resolves to the method
+type HarmoniaClient interface {
+	// SubmitRequest submits an initial schema change request, mirrors /submitRequest
+	SubmitRequest(ctx context.Context, in *Submit, opts ...grpc.CallOption) (*RFCIdentifier, error)
+	// UpdateRequest updates an existing schema change request, mirrors /updateRequest
+	UpdateRequest(ctx context.Context, in *Update, opts ...grpc.CallOption) (*RFCIdentifier, error)
+	// ReviewRequest approves, requests changes to or comments on an RFC, mirrors /reviewRequest
+	ReviewRequest(ctx context.Context, in *Review, opts ...grpc.CallOption) (*Success, error)
+	// MergeRequest merges an RFC and tags it for tracking, mirrors /mergeRequest
+	MergeRequest(ctx context.Context, in *Merge, opts ...grpc.CallOption) (*Success, error)
+	// LoadRequest loads an RFC into the underlying datastores asynchronously, mirrors /loadRequest
+	LoadRequest(ctx context.Context, in *Load, opts ...grpc.CallOption) (*Success, error)
+	// Status returns the load status of an RFC, mirrors /status
+	Status(ctx context.Context, in *Status, opts ...grpc.CallOption) (*StatusResponse, error)
+	// GetJob returns the progress of an asynchronous job started by a request, mirrors /jobs/{id}
+	GetJob(ctx context.Context, in *GetJob, opts ...grpc.CallOption) (*Job, error)
+	// GetRfcs returns the submitted RFCs, mirrors /getRfcs
+	GetRfcs(ctx context.Context, in *GetRfcs, opts ...grpc.CallOption) (*RFCs, error)
+	// GetRfcContents returns the body of an RFC, mirrors /getRfcContents
+	GetRfcContents(ctx context.Context, in *GetRfcContents, opts ...grpc.CallOption) (*RFCContents, error)
+	// GetReviews returns every review submitted on an RFC, oldest first, mirrors /getReviews
+	GetReviews(ctx context.Context, in *GetReviews, opts ...grpc.CallOption) (*RFCReviews, error)
+}
+
+type harmoniaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHarmoniaClient(cc grpc.ClientConnInterface) HarmoniaClient {
+	return &harmoniaClient{cc}
+}
+
+func (c *harmoniaClient) SubmitRequest(ctx context.Context, in *Submit, opts ...grpc.CallOption) (*RFCIdentifier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RFCIdentifier)
+	err := c.cc.Invoke(ctx, Harmonia_SubmitRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) UpdateRequest(ctx context.Context, in *Update, opts ...grpc.CallOption) (*RFCIdentifier, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RFCIdentifier)
+	err := c.cc.Invoke(ctx, Harmonia_UpdateRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) ReviewRequest(ctx context.Context, in *Review, opts ...grpc.CallOption) (*Success, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Success)
+	err := c.cc.Invoke(ctx, Harmonia_ReviewRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) MergeRequest(ctx context.Context, in *Merge, opts ...grpc.CallOption) (*Success, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Success)
+	err := c.cc.Invoke(ctx, Harmonia_MergeRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) LoadRequest(ctx context.Context, in *Load, opts ...grpc.CallOption) (*Success, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Success)
+	err := c.cc.Invoke(ctx, Harmonia_LoadRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) Status(ctx context.Context, in *Status, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, Harmonia_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) GetJob(ctx context.Context, in *GetJob, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Harmonia_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) GetRfcs(ctx context.Context, in *GetRfcs, opts ...grpc.CallOption) (*RFCs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RFCs)
+	err := c.cc.Invoke(ctx, Harmonia_GetRfcs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) GetRfcContents(ctx context.Context, in *GetRfcContents, opts ...grpc.CallOption) (*RFCContents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RFCContents)
+	err := c.cc.Invoke(ctx, Harmonia_GetRfcContents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *harmoniaClient) GetReviews(ctx context.Context, in *GetReviews, opts ...grpc.CallOption) (*RFCReviews, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RFCReviews)
+	err := c.cc.Invoke(ctx, Harmonia_GetReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HarmoniaServer is the server API for Harmonia service.
+// All implementations must embed UnimplementedHarmoniaServer
+// for forward compatibility.
+//
+// Harmonia processes and accepts requests for schema changes
+// Mutating methods are rejected while maintenance mode is enabled and, when request signing is enabled, must carry
+// the x-harmonia-timestamp, x-harmonia-nonce and x-harmonia-signature metadata, the signature being computed over the
+// deterministic binary encoding of the request message
+// Requests named after their method are referenced by their full name, their bare name resolves to the method
+type HarmoniaServer interface {
+	// SubmitRequest submits an initial schema change request, mirrors /submitRequest
+	SubmitRequest(context.Context, *Submit) (*RFCIdentifier, error)
+	// UpdateRequest updates an existing schema change request, mirrors /updateRequest
+	UpdateRequest(context.Context, *Update) (*RFCIdentifier, error)
+	// ReviewRequest approves, requests changes to or comments on an RFC, mirrors /reviewRequest
+	ReviewRequest(context.Context, *Review) (*Success, error)
+	// MergeRequest merges an RFC and tags it for tracking, mirrors /mergeRequest
+	MergeRequest(context.Context, *Merge) (*Success, error)
+	// LoadRequest loads an RFC into the underlying datastores asynchronously, mirrors /loadRequest
+	LoadRequest(context.Context, *Load) (*Success, error)
+	// Status returns the load status of an RFC, mirrors /status
+	Status(context.Context, *Status) (*StatusResponse, error)
+	// GetJob returns the progress of an asynchronous job started by a request, mirrors /jobs/{id}
+	GetJob(context.Context, *GetJob) (*Job, error)
+	// GetRfcs returns the submitted RFCs, mirrors /getRfcs
+	GetRfcs(context.Context, *GetRfcs) (*RFCs, error)
+	// GetRfcContents returns the body of an RFC, mirrors /getRfcContents
+	GetRfcContents(context.Context, *GetRfcContents) (*RFCContents, error)
+	// GetReviews returns every review submitted on an RFC, oldest first, mirrors /getReviews
+	GetReviews(context.Context, *GetReviews) (*RFCReviews, error)
+	mustEmbedUnimplementedHarmoniaServer()
+}
+
+// UnimplementedHarmoniaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHarmoniaServer struct{}
+
+func (UnimplementedHarmoniaServer) SubmitRequest(context.Context, *Submit) (*RFCIdentifier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRequest not implemented")
+}
+func (UnimplementedHarmoniaServer) UpdateRequest(context.Context, *Update) (*RFCIdentifier, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRequest not implemented")
+}
+func (UnimplementedHarmoniaServer) ReviewRequest(context.Context, *Review) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewRequest not implemented")
+}
+func (UnimplementedHarmoniaServer) MergeRequest(context.Context, *Merge) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeRequest not implemented")
+}
+func (UnimplementedHarmoniaServer) LoadRequest(context.Context, *Load) (*Success, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadRequest not implemented")
+}
+func (UnimplementedHarmoniaServer) Status(context.Context, *Status) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedHarmoniaServer) GetJob(context.Context, *GetJob) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedHarmoniaServer) GetRfcs(context.Context, *GetRfcs) (*RFCs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRfcs not implemented")
+}
+func (UnimplementedHarmoniaServer) GetRfcContents(context.Context, *GetRfcContents) (*RFCContents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRfcContents not implemented")
+}
+func (UnimplementedHarmoniaServer) GetReviews(context.Context, *GetReviews) (*RFCReviews, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReviews not implemented")
+}
+func (UnimplementedHarmoniaServer) mustEmbedUnimplementedHarmoniaServer() {}
+func (UnimplementedHarmoniaServer) testEmbeddedByValue()                  {}
+
+// UnsafeHarmoniaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HarmoniaServer will
+// result in compilation errors.
+type UnsafeHarmoniaServer interface {
+	mustEmbedUnimplementedHarmoniaServer()
+}
+
+func RegisterHarmoniaServer(s grpc.ServiceRegistrar, srv HarmoniaServer) {
+	// If the following call pancis, it indicates UnimplementedHarmoniaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Harmonia_ServiceDesc, srv)
+}
+
+func _Harmonia_SubmitRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Submit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).SubmitRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_SubmitRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).SubmitRequest(ctx, req.(*Submit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_UpdateRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Update)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).UpdateRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_UpdateRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).UpdateRequest(ctx, req.(*Update))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_ReviewRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Review)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).ReviewRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_ReviewRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).ReviewRequest(ctx, req.(*Review))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_MergeRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Merge)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).MergeRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_MergeRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).MergeRequest(ctx, req.(*Merge))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_LoadRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Load)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).LoadRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_LoadRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).LoadRequest(ctx, req.(*Load))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Status)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).Status(ctx, req.(*Status))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJob)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).GetJob(ctx, req.(*GetJob))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_GetRfcs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRfcs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).GetRfcs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_GetRfcs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).GetRfcs(ctx, req.(*GetRfcs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_GetRfcContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRfcContents)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).GetRfcContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_GetRfcContents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).GetRfcContents(ctx, req.(*GetRfcContents))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Harmonia_GetReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviews)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HarmoniaServer).GetReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Harmonia_GetReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HarmoniaServer).GetReviews(ctx, req.(*GetReviews))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Harmonia_ServiceDesc is the grpc.ServiceDesc for Harmonia service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Harmonia_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "harmonia.v1.Harmonia",
+	HandlerType: (*HarmoniaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitRequest",
+			Handler:    _Harmonia_SubmitRequest_Handler,
+		},
+		{
+			MethodName: "UpdateRequest",
+			Handler:    _Harmonia_UpdateRequest_Handler,
+		},
+		{
+			MethodName: "ReviewRequest",
+			Handler:    _Harmonia_ReviewRequest_Handler,
+		},
+		{
+			MethodName: "MergeRequest",
+			Handler:    _Harmonia_MergeRequest_Handler,
+		},
+		{
+			MethodName: "LoadRequest",
+			Handler:    _Harmonia_LoadRequest_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Harmonia_Status_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _Harmonia_GetJob_Handler,
+		},
+		{
+			MethodName: "GetRfcs",
+			Handler:    _Harmonia_GetRfcs_Handler,
+		},
+		{
+			MethodName: "GetRfcContents",
+			Handler:    _Harmonia_GetRfcContents_Handler,
+		},
+		{
+			MethodName: "GetReviews",
+			Handler:    _Harmonia_GetReviews_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "harmonia.proto",
+}
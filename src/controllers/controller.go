@@ -5,15 +5,48 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/assignment"
+	"harmonia-example.io/src/services/authz"
+	"harmonia-example.io/src/services/cache"
+	"harmonia-example.io/src/services/codegen"
+	"harmonia-example.io/src/services/description"
+	"harmonia-example.io/src/services/events"
 	exGit "harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/loader"
+	"harmonia-example.io/src/services/loadstatus"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/metadata"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/moderation"
+	"harmonia-example.io/src/services/naming"
+	"harmonia-example.io/src/services/notify"
+	"harmonia-example.io/src/services/oidc"
+	"harmonia-example.io/src/services/ownership"
+	"harmonia-example.io/src/services/quorum"
+	"harmonia-example.io/src/services/rendering"
+	"harmonia-example.io/src/services/reservations"
+	"harmonia-example.io/src/services/reviewrequests"
+	"harmonia-example.io/src/services/schemas"
+	"harmonia-example.io/src/services/set"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tenants"
+	"harmonia-example.io/src/services/tracing"
+	"harmonia-example.io/src/services/triage"
 )
 
 const (
@@ -23,29 +56,192 @@ const (
 	LOADING_STATUS        = "loading"
 	SUCCESSFUL_STATUS     = "successful"
 	FAILED_STATUS         = "failed"
+	// an RFC loaded into several targets is partially loaded if only some of them succeeded
+	PARTIAL_STATUS = "partial"
+
+	// statuses for gated RFC loads, which wait for an approval before the load step executes
+	AWAITING_APPROVAL_STATUS = "awaiting_approval"
+	REJECTED_STATUS          = "rejected"
+
+	// how often deployment gates are polled for a decision, and how long before polling is abandoned
+	LOAD_GATE_POLL_INTERVAL = time.Minute
+	LOAD_GATE_TIMEOUT       = 72 * time.Hour
+
+	// number of events returned by the activity feed when no limit is requested
+	DEFAULT_ACTIVITY_LIMIT = 50
+
+	// how often an idle event stream is sent a heartbeat so proxies and clients keep the connection open
+	EVENT_STREAM_HEARTBEAT = 15 * time.Second
+
+	// how long pull request and review data used for work summaries may be served from cache
+	WORK_CACHE_TTL = time.Minute
+
+	// how long getRfcs responses may be served from cache, list queries are frequent and RFCs change slowly
+	RFCS_CACHE_TTL = 30 * time.Second
+
+	// how long token permission checks may be served from cache, readiness probes are frequent
+	TOKEN_CHECK_TTL = time.Minute
+
+	// how long branch protection checks may be served from cache, and how often they are repeated in the background
+	PROTECTION_CHECK_TTL              = 5 * time.Minute
+	DEFAULT_PROTECTION_CHECK_INTERVAL = time.Hour
+
+	// RFC identifier used by sample events when none is requested
+	SAMPLE_RFC_IDENTIFIER = "000000"
+
+	// period covered by each digest and the number of most recent closed RFCs scanned for newly merged ones
+	DIGEST_PERIOD            = 24 * time.Hour
+	DIGEST_MERGED_SCAN_COUNT = 100
+
+	// number of RFC summaries computed concurrently, each may wait on the Git provider to compute mergeability
+	SUMMARY_CONCURRENCY = 8
+
+	// number of RFCs of a batch submission submitted concurrently, each creates a branch, a file and a pull request
+	BATCH_SUBMIT_CONCURRENCY = 4
+
+	// number of RFCs archived per commit, so a first compaction of years of RFCs is spread over several runs
+	ARCHIVE_BATCH_SIZE = 200
+
+	// number of unchanged lines shown around each change of an RFC history diff
+	HISTORY_DIFF_CONTEXT = 3
+	// number of unchanged attribute lines shown around each change of an item diff
+	ITEM_DIFF_CONTEXT = 3
 )
 
+// caches of pull request data used to compute work summaries
+// open pull requests are keyed by tracking repository, review and content entries are keyed by RFC identifier and last
+// update time, so any change to the pull request naturally bypasses stale entries
+var openPullRequestCache = cache.NewNamed[string, exGit.PullRequests]("open_pull_requests", WORK_CACHE_TTL)
+var reviewDetailsCache = cache.NewNamed[string, []exGit.ReviewDetails]("review_details", WORK_CACHE_TTL)
+var loadStatusCache = cache.NewNamed[string, string]("load_status", WORK_CACHE_TTL)
+var mergeabilityCache = cache.NewNamed[string, *models.Mergeability]("mergeability", WORK_CACHE_TTL)
+var contentSignatureCache = cache.NewNamed[string, string]("content_signatures", WORK_CACHE_TTL)
+var dependenciesCache = cache.NewNamed[string, []string]("dependencies", WORK_CACHE_TTL)
+var submitterCache = cache.NewNamed[string, string]("submitters", WORK_CACHE_TTL)
+var sealedReviewsCache = cache.NewNamed[string, map[string]models.ReviewType]("sealed_reviews", WORK_CACHE_TTL)
+
+// cache of getRfcs responses keyed by client and normalized filter set, dropped whenever an RFC may have changed
+var rfcsCache = cache.NewNamed[rfcsKey, cachedRFCs]("rfcs", RFCS_CACHE_TTL)
+
+// rfcsKey identifies a cached getRfcs response, clients are built once per token and tracking repository so responses
+// are never shared between tokens
+type rfcsKey struct {
+	git     exGit.Git
+	filters string
+}
+
+// cachedRFCs holds a getRfcs response along with the time it was retrieved from the Git provider at
+type cachedRFCs struct {
+	rfcs        *models.RFCs
+	retrievedAt time.Time
+}
+
+// cache of token permission checks keyed by token name
+var tokenCheckCache = cache.NewNamed[string, models.TokenCheck]("token_checks", TOKEN_CHECK_TTL)
+
+// cache of the teams of the user of each Git client, checked against the authorization policy on every request
+// requiring a permission. Clients are built once per token and tracking repository
+var userTeamsCache = cache.NewNamed[exGit.Git, set.Set[string]]("user_teams", WORK_CACHE_TTL)
+
+// cache of the teams of the authorization policy each user authenticated through SSO is a member of, keyed by login
+var ssoTeamsCache = cache.NewNamed[string, set.Set[string]]("sso_teams", WORK_CACHE_TTL)
+
+// cache of the member teams of a tenant each user authenticated through SSO is a member of, keyed by schema domain and
+// login
+var memberTeamsCache = cache.NewNamed[string, set.Set[string]]("member_teams", WORK_CACHE_TTL)
+
+// cache of branch protection checks keyed by schema domain
+var protectionCheckCache = cache.NewNamed[string, models.ProtectionCheck]("protection_checks", PROTECTION_CHECK_TTL)
+
+// schemaRegistryCache holds the registries of the action data schemas of the tracking repositories, keyed by domain
+var schemaRegistryCache = cache.NewNamed[string, *schemas.Registry]("schema_registries", WORK_CACHE_TTL)
+
+// warmingUp is set while the startup warm-up is in progress, the service is not ready until it completes
+var warmingUp atomic.Bool
+
+// lastRFCIdentifier is the last RFC identifier created by CreateRFCIdentifier
+var lastRFCIdentifier atomic.Int64
+
 // CreateRFCIdentifier creates a unique identifier for a new RFC
 var CreateRFCIdentifier models.RFCIdentifierCreator = func() *string {
-	// Creates identifier based on current time
+	// Creates identifier based on current time, moved past the last identifier created so RFCs submitted within the
+	// same second, e.g. by a batch submission, do not share a branch
 	epoch := time.Now().Unix()
-	identifier := strconv.FormatInt(epoch, 10)
-	return &identifier
+	for {
+		last := lastRFCIdentifier.Load()
+		next := max(epoch, last+1)
+		if lastRFCIdentifier.CompareAndSwap(last, next) {
+			identifier := strconv.FormatInt(next, 10)
+			return &identifier
+		}
+	}
 }
 
 // SubmitRequest orchestrates creating a new RFC branch, making the first commit with the given RFC data and
 // opening a pull request. The corresponding branch name is returned.
+// Unless allowDuplicate is set, a *models.DuplicateError is returned instead if an open RFC proposes the same change
 // Parameters:
+//
 //	ctx - standard context
 //	git - Git service implementation used to drive interactions
 // 	data - RFC to populate
-func SubmitRequest(ctx context.Context, git exGit.Git, data *models.RFC) (*string, error) {
-	// add hash signatures to incoming data
-	rfcSignature, err := data.ToSha()
+//	allowDuplicate - whether to submit the RFC even if an open RFC proposes the same change
+func SubmitRequest(ctx context.Context, git exGit.Git, data *models.RFC, allowDuplicate bool) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.SubmitRequest")
+	defer span.End()
+
+	// RFCs of a tenant can only be tracked in the tracking repository of the tenant
+	if err := checkTenant(ctx, git, data); err != nil {
+		return nil, err
+	}
+
+	// actions must meet the requirements of their action type
+	if err := checkActions(ctx, data); err != nil {
+		return nil, err
+	}
+
+	// target descriptors reserved by another team cannot be added to or reserved
+	if err := checkReservations(ctx, git, data, ""); err != nil {
+		return nil, err
+	}
+
+	// RFCs can only depend on RFCs that exist and may still be merged
+	if err := validateDependencies(ctx, git, data, ""); err != nil {
+		return nil, err
+	}
+
+	// RFCs can only be loaded into configured load targets
+	if err := validateLoadTargets(ctx, data); err != nil {
+		return nil, err
+	}
+
+	// action data must conform to the schemas of the tracking repository
+	if err := validateActionData(ctx, git, data); err != nil {
+		return nil, err
+	}
+
+	// the same change should not be proposed twice
+	if !allowDuplicate {
+		if err := checkDuplicate(ctx, git, data); err != nil {
+			return nil, err
+		}
+	}
+
+	// RFCs signed by their author must be signed by the user submitting them, with a key registered to them
+	keyID, err := verifyAuthorSignature(ctx, git, data, true)
 	if err != nil {
 		return nil, err
 	}
-	data.Signature = *rfcSignature
+
+	// the submitter is recorded in the RFC, the pull request is authored by the account of the token it is opened with,
+	// which every user shares under SSO or with a shared token
+	author := currentUser(ctx, git)
+	data.Submitter = author
+
+	// add hash signatures to incoming data
+	if err = data.Sign(); err != nil {
+		return nil, err
+	}
 	for _, action := range data.Actions {
 		actionSha, err := action.ToSha()
 		if err != nil {
@@ -54,66 +250,258 @@ func SubmitRequest(ctx context.Context, git exGit.Git, data *models.RFC) (*strin
 		action.Signature = *actionSha
 	}
 
+	// the key the author signed the RFC with is kept on record
+	if keyID != "" {
+		if err = data.Attest(keyID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	// create new branch identifier
 	branch := *CreateRFCIdentifier()
 
-	// <this is a good place to add RFC metadata to logger> //
+	metadata.SetRFCIdentifier(ctx, branch)
+
+	// under SSO the submitter is sealed, as the pull request is authored by the shared account
+	if err = sealSubmitter(ctx, data, branch); err != nil {
+		return nil, err
+	}
 
 	if err = git.CreateBranch(ctx, branch, exGit.BASE_BRANCH); err != nil {
-		errStr := "Failed to create branch for RFC: %s, please try again"
-		fmt.Printf(errStr, branch)
+		logging.FromContext(ctx).Error("failed to create branch for RFC, please try again", logging.ERROR_KEY, err)
 		return nil, err
 	}
 
 	// create new RFC file
 	if err = git.CreateFile(ctx, branch, branch, data); err != nil {
-		errStr := "Failed to write file for RFC: %s to datastore, starting revoke process..."
-		fmt.Printf(errStr, branch)
+		logging.FromContext(ctx).Error("failed to write file for RFC to datastore, starting revoke process...",
+			logging.ERROR_KEY, err)
 		if revErr := git.DeleteBranch(ctx, branch); revErr == nil {
-			infoStr := "Successfully revoked RFC: %s"
-			fmt.Printf(infoStr, branch)
+			logging.FromContext(ctx).Info("successfully revoked RFC")
 		}
 		return nil, err
 	}
 
 	// open PR
-	if err = git.CreatePullRequest(ctx, branch, exGit.BASE_BRANCH); err != nil {
-		errStr := "Failed to open Pull Request for RFC: %s, starting revoke process..."
-		fmt.Printf(errStr, branch)
+	options := pullRequestOptions(ctx, git, branch, data)
+	if err = git.CreatePullRequest(ctx, branch, exGit.BASE_BRANCH, options); err != nil {
+		logging.FromContext(ctx).Error("failed to open pull request for RFC, starting revoke process...",
+			logging.ERROR_KEY, err)
 		if revErr := git.DeleteBranch(ctx, branch); revErr == nil {
-			infoStr := "Successfully revoked RFC: %s"
-			fmt.Printf(infoStr, branch)
+			logging.FromContext(ctx).Info("successfully revoked RFC")
 		}
 		return nil, err
 	}
 
+	// the reviews requested by the triage policy count towards the review SLA
+	recordReviewRequests(ctx, git, branch, options.Reviewers, options.TeamReviewers)
+
+	// request a review from a member of each owning team, a failed assignment does not fail the submission
+	assignReviewers(ctx, git, branch, data, author)
+
+	publishEvent(models.SubmitEvent, branch, author, "", data)
+
 	return &branch, nil
 }
 
+// SubmitWithReceipt submits the given RFC like SubmitRequest and returns its identifier and links along with a receipt
+// echoing the canonical JSON the RFC was accepted and signed as, signed by the server if a receipt key is configured
+// Parameters:
+//
+//	ctx - standard context
+//	git - Git service implementation used to drive interactions
+//	data - RFC to populate
+//	allowDuplicate - whether to submit the RFC even if an open RFC proposes the same change
+func SubmitWithReceipt(ctx context.Context, git exGit.Git, data *models.RFC, allowDuplicate bool) (
+	*models.RFCIdentifier, error) {
+	ctx, span := tracing.Start(ctx, "controllers.SubmitWithReceipt")
+	defer span.End()
+
+	// the signature of the RFC is the hash of its canonical form, captured before the RFC and its actions are signed
+	canonical, err := data.Canonical()
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := SubmitRequest(ctx, git, data, allowDuplicate)
+	if err != nil {
+		return nil, err
+	}
+
+	links := GetLinks(ctx, git, *branch, false)
+	receipt := &models.Receipt{
+		RFCIdentifier:  *branch,
+		RFC:            canonical,
+		Signature:      data.Signature,
+		Branch:         *branch,
+		PullRequestURL: links.PullRequest,
+		AcceptedAt:     time.Now().UTC(),
+	}
+	if signing.Receipts != nil {
+		receipt.ServerSignature = signing.Receipts.Sign(receipt.SignedContent())
+	}
+
+	return &models.RFCIdentifier{RFCIdentifier: *branch, Links: links, Receipt: receipt}, nil
+}
+
+// SubmitBatch submits each RFC of the given batch like SubmitWithReceipt, BATCH_SUBMIT_CONCURRENCY at a time, and
+// returns the identifier of each RFC submitted and the error of each RFC that failed, in the order of the batch
+// RFCs declaring no domain are submitted to the domain of the batch, those declaring another domain fail with
+// models.ErrInvalidBatch and, unless allowDuplicate is set, those proposing the same change as an earlier RFC of the
+// batch fail with models.ErrDuplicateInBatch. models.ErrInvalidBatch is returned (wrapped) if the batch holds no RFC
+// or more than models.MAX_BATCH_SIZE, nothing is submitted then
+// Parameters:
+//
+//	ctx - standard context
+//	git - Git service implementation of the tracking repository of the domain of the batch
+//	batch - RFCs to submit
+//	allowDuplicate - whether to submit the RFCs even if an open RFC proposes the same change
+func SubmitBatch(ctx context.Context, git exGit.Git, batch *models.SubmitBatch, allowDuplicate bool) (
+	[]*models.RFCIdentifier, []error, error) {
+	ctx, span := tracing.Start(ctx, "controllers.SubmitBatch")
+	defer span.End()
+
+	data := batch.RFCs
+	if len(data) == 0 || len(data) > models.MAX_BATCH_SIZE {
+		return nil, nil, fmt.Errorf("%w: a batch holds between 1 and %d RFCs, got %d", models.ErrInvalidBatch,
+			models.MAX_BATCH_SIZE, len(data))
+	}
+
+	identifiers := make([]*models.RFCIdentifier, len(data))
+	failures := make([]error, len(data))
+
+	// every RFC of the batch is tracked in the tracking repository of the domain of the batch
+	for i, rfc := range data {
+		if rfc.Domain == "" {
+			rfc.Domain = batch.Domain
+		} else if rfc.Domain != batch.Domain {
+			failures[i] = fmt.Errorf("%w: RFC of domain %s in a batch of domain %s", models.ErrInvalidBatch,
+				rfc.Domain, batch.Domain)
+		}
+	}
+
+	// RFCs proposing the same change as an earlier RFC of the batch fail, as they are not open yet when the
+	// others are checked for duplicates
+	if !allowDuplicate {
+		proposed := map[string]int{}
+		for i, rfc := range data {
+			if failures[i] != nil {
+				continue
+			}
+			signature, err := rfc.ContentSignature()
+			if err != nil {
+				failures[i] = err
+			} else if earlier, ok := proposed[*signature]; ok {
+				failures[i] = fmt.Errorf("%w: RFC %d of the batch", models.ErrDuplicateInBatch, earlier)
+			} else {
+				proposed[*signature] = i
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, BATCH_SUBMIT_CONCURRENCY)
+	for i, rfc := range data {
+		if failures[i] != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, rfc *models.RFC) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			// each RFC records its own identifier, which must not leak into the others
+			identifier, err := SubmitWithReceipt(metadata.Fork(ctx), git, rfc, allowDuplicate)
+			if err != nil {
+				logging.FromContext(ctx).Warn("failed to submit RFC of batch", "index", i, logging.ERROR_KEY, err)
+				failures[i] = err
+				return
+			}
+			identifiers[i] = identifier
+		}(i, rfc)
+	}
+	wg.Wait()
+
+	return identifiers, failures, nil
+}
+
+// ValidateRequest runs the checks a submission of the given RFC goes through without creating its branch or pull
+// request: the RFC and its actions are validated, its load targets must be configured and, unless allowDuplicate is
+// set, no open RFC may propose the same change. Failed checks are reported in the returned validation, errors are
+// only returned if the checks could not be run
+func ValidateRequest(ctx context.Context, git exGit.Git, data *models.RFC, allowDuplicate bool) (*models.Validation,
+	error) {
+	ctx, span := tracing.Start(ctx, "controllers.ValidateRequest")
+	defer span.End()
+
+	// RFCs of a tenant can only be tracked in the tracking repository of the tenant
+	if err := checkTenant(ctx, git, data); err != nil {
+		return nil, err
+	}
+
+	validation := data.Validate()
+
+	// RFCs can only be loaded into configured load targets
+	if err := validateLoadTargets(ctx, data); err != nil {
+		validation.Errors = append(validation.Errors, models.ValidationError{Field: "loadTargets",
+			Message: err.Error()})
+	}
+
+	// action data must conform to the schemas of the tracking repository
+	var schemaErr *models.SchemaError
+	if err := validateActionData(ctx, git, data); errors.As(err, &schemaErr) {
+		validation.Errors = append(validation.Errors, schemaErr.Errors...)
+	} else if err != nil {
+		return nil, err
+	}
+
+	// the same change should not be proposed twice
+	if !allowDuplicate {
+		var duplicateErr *models.DuplicateError
+		if err := checkDuplicate(ctx, git, data); errors.As(err, &duplicateErr) {
+			validation.Errors = append(validation.Errors, models.ValidationError{Field: "actions",
+				Message: duplicateErr.Error()})
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return validation, nil
+}
+
 // UpdateRequest orchestrates the update RFC process, which includes updating an existing RFC, persisting existing
 // actions and clearing out existing approvals. The branch name is returned.
 // Parameters:
+//
 // 	ctx - standard context
 // 	git - Git service implementation used to drive interactions
 //	data - RFC new data
 func UpdateRequest(ctx context.Context, git exGit.Git, data *models.Update) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.UpdateRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
 	// retrieve pull request
 	pr, err := git.GetPullRequest(ctx, data.RFCIdentifier)
 	if err != nil {
 		return nil, err
 	}
 
-	// retrieve existing RFC content
-	content, _, err := git.GetRFCContents(ctx, data.RFCIdentifier)
+	// retrieve existing RFC
+	existingRFC, err := readRFC(ctx, git, data.RFCIdentifier)
 	if err != nil {
 		return nil, err
 	}
 
-	// format existing RFC into model
-	existingRFC := &models.RFC{}
-	if err = json.Unmarshal([]byte(*content), existingRFC); err != nil {
-		errStr := "unable to unmarshal existing RFC content"
-		fmt.Print(errStr)
+	// an RFC file tampered with is not built upon
+	if err = verifyRFC(ctx, data.RFCIdentifier, existingRFC); err != nil {
+		return nil, err
+	}
+
+	// the update must be made from the existing RFC, it would otherwise overwrite updates made since it was read
+	if err = existingRFC.CheckUpdatedFrom(data.RFCIdentifier, data.Signature); err != nil {
 		return nil, err
 	}
 
@@ -126,15 +514,55 @@ func UpdateRequest(ctx context.Context, git exGit.Git, data *models.Update) (*st
 		action.Signature = *actionSha
 	}
 
-	// persist actions from existing RFC to new RFC
+	// persist actions from existing RFC to new RFC, an RFC stays in the domain it was submitted in and keeps its
+	// submitter
 	data.RFC.AddPersistentActions(existingRFC)
+	data.RFC.Domain = existingRFC.Domain
+	data.RFC.Submitter = existingRFC.Submitter
+	data.RFC.SubmitterSeal = existingRFC.SubmitterSeal
+
+	// actions must meet the requirements of their action type
+	if err = checkActions(ctx, data.RFC); err != nil {
+		return nil, err
+	}
+
+	// target descriptors reserved by another team cannot be added to or reserved
+	if err = checkReservations(ctx, git, data.RFC, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// RFCs can only depend on RFCs that exist, may still be merged and do not depend on them in turn
+	if err = validateDependencies(ctx, git, data.RFC, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// RFCs can only be loaded into configured load targets, which the domain of the RFC decides in the multi-tenant mode
+	if err = validateLoadTargets(ctx, data.RFC); err != nil {
+		return nil, err
+	}
+
+	// action data must conform to the schemas of the tracking repository
+	if err = validateActionData(ctx, git, data.RFC); err != nil {
+		return nil, err
+	}
+
+	// RFCs signed by their author must be signed by the user updating them, with a key registered to them
+	var keyID string
+	if keyID, err = verifyAuthorSignature(ctx, git, data.RFC, true); err != nil {
+		return nil, err
+	}
 
 	// add rfc hash signature
-	rfcSignature, err := data.RFC.ToSha()
-	if err != nil {
+	if err = data.RFC.Sign(); err != nil {
 		return nil, err
 	}
-	data.RFC.Signature = *rfcSignature
+
+	// the key the author signed the RFC with is kept on record
+	if keyID != "" {
+		if err = data.RFC.Attest(keyID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
 
 	// update existing RFC in repo
 	if err = git.UpdateFile(ctx, pr, data.RFC); err != nil {
@@ -149,16 +577,38 @@ func UpdateRequest(ctx context.Context, git exGit.Git, data *models.Update) (*st
 		return nil, err
 	}
 
+	publishEvent(models.UpdateEvent, data.RFCIdentifier, currentUser(ctx, git), "", data.RFC)
+
 	return &data.RFCIdentifier, nil
 }
 
 // ReviewRequest orchestrates submitting a review based on the given data
+// Custom review intents are recorded as-is in the RFC, but submitted to the Git provider as their base review type
 func ReviewRequest(ctx context.Context, git exGit.Git, gitMachine exGit.Git, data *models.Review) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.ReviewRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// resolve the provider review type
+	intent := models.ReviewType(data.Type)
+	base, err := intent.Base()
+	if err != nil {
+		logging.FromContext(ctx).Warn("unknown review type", "reviewType", data.Type, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	// approving takes a permission of its own on top of the review permission of the route
+	if base == models.ApproveReview {
+		if err = Authorize(ctx, git, models.ApprovePermission); err != nil {
+			return nil, err
+		}
+	}
+
 	// if the review type is a comment or requesting changes there needs to be some sort of comments associated
-	if data.Type == exGit.COMMENT_REVIEW_TYPE || data.Type == exGit.REQUEST_CHANGES_REVIEW_TYPE {
+	// custom intents are exempt because their intent is always included in the review body
+	if !intent.IsCustom() && (base == models.CommentReview || base == models.RequestChangesReview) {
 		if data.TopLevelComment == "" && len(data.Comments) == 0 {
 			errStr := fmt.Sprintf("Review of type %s must include a top level comment or inline comments", data.Type)
-			fmt.Println(errStr)
+			logging.FromContext(ctx).Warn(errStr)
 			return nil, fmt.Errorf(errStr)
 		}
 	}
@@ -170,35 +620,53 @@ func ReviewRequest(ctx context.Context, git exGit.Git, gitMachine exGit.Git, dat
 	}
 
 	// retrieve current user
-	login, err := git.GetUserLogin(ctx)
+	login, err := userLogin(ctx, git)
 	if err != nil {
 		return nil, err
 	}
+	metadata.SetUser(ctx, *login)
 
-	// retrieve existing RFC content
-	content, _, err := git.GetRFCContents(ctx, data.RFCIdentifier)
+	// retrieve existing RFC
+	rfc, err := readRFC(ctx, git, data.RFCIdentifier)
 	if err != nil {
 		return nil, err
 	}
 
-	// format existing RFC into model
-	rfc := &models.RFC{}
-	if err = json.Unmarshal([]byte(*content), rfc); err != nil {
-		errStr := "unable to unmarshal existing RFC content"
-		fmt.Print(errStr)
+	// an RFC file tampered with is not reviewed
+	if err = verifyRFC(ctx, data.RFCIdentifier, rfc); err != nil {
+		return nil, err
+	}
+
+	// check comments with the comment filters, objectionable comments are rejected, flagged or held for moderation
+	flagged, held, err := moderateComments(ctx, data, *login, rfc.Signature)
+	if err != nil {
 		return nil, err
 	}
+	if held > 0 && !intent.IsCustom() && base == models.CommentReview && data.TopLevelComment == "" &&
+		len(data.Comments) == 0 {
+		message := fmt.Sprintf("Comments on RFC %s are held for moderation", data.RFCIdentifier)
+		return &message, nil
+	}
 
-	// add comments to RFC
+	// add comments to RFC, flagging those the comment filters flagged
+	added := len(rfc.Actions)
 	if err = rfc.AddComments(data.Comments, *login); err != nil {
 		return nil, err
 	}
+	for _, action := range rfc.Actions[added:] {
+		if reasons, ok := flagged[fmt.Sprint(action.Data[string(models.CommentData)])]; ok {
+			if err = action.Flag(reasons); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	// we only want to create a review action if this is an approval or request for changes OR there are top level comments
-	if data.Type != exGit.COMMENT_REVIEW_TYPE || data.TopLevelComment != "" {
+	// we only want to create a review action if this is an approval, request for changes or custom intent OR there are
+	// top level comments
+	if base != models.CommentReview || intent.IsCustom() || data.TopLevelComment != "" {
 		// our identifier = reviewer, unless this is a comment, then we want commenter
 		identifier := models.ReviewerData
-		if data.Type == exGit.COMMENT_REVIEW_TYPE {
+		if intent == models.CommentReview {
 			identifier = models.CommentData
 		}
 		action := models.Action{
@@ -215,6 +683,15 @@ func ReviewRequest(ctx context.Context, git exGit.Git, gitMachine exGit.Git, dat
 		// add review comment if necessary
 		if data.TopLevelComment != "" {
 			action.Data["comment"] = data.TopLevelComment
+			if reasons, ok := flagged[data.TopLevelComment]; ok {
+				action.Data[string(models.FlaggedData)] = strings.Join(reasons, "; ")
+			}
+		}
+		// under SSO the review is sealed, it counts towards the quorum in place of the review of the shared account
+		if oidc.FromContext(ctx) != nil && signing.Records != nil && identifier == models.ReviewerData {
+			if err = rfc.SealReview(data.RFCIdentifier, &action, signing.Records.Seal); err != nil {
+				return nil, err
+			}
 		}
 		// add the review action to the RFC
 		if err = rfc.AddAction(action); err != nil {
@@ -227,279 +704,4128 @@ func ReviewRequest(ctx context.Context, git exGit.Git, gitMachine exGit.Git, dat
 		return nil, err
 	}
 
-	// create PR review
-	if err = git.CreateReview(ctx, pr, data); err != nil {
+	// create PR review as the provider review type, labelling the body with the intent if it is a custom one
+	providerReview := *data
+	providerReview.Type = string(base)
+	if intent.IsCustom() {
+		providerReview.TopLevelComment = string(intent)
+		if data.TopLevelComment != "" {
+			providerReview.TopLevelComment = fmt.Sprintf("%s: %s", intent, data.TopLevelComment)
+		}
+	}
+	// under SSO the shared account authored the pull request, which it can neither approve nor request changes on,
+	// so the review is submitted as a comment labelled with the intent and the user
+	if oidc.FromContext(ctx) != nil {
+		providerReview.Type = string(models.CommentReview)
+		providerReview.TopLevelComment = fmt.Sprintf("%s by @%s", intent, *login)
+		if data.TopLevelComment != "" {
+			providerReview.TopLevelComment = fmt.Sprintf("%s by @%s: %s", intent, *login, data.TopLevelComment)
+		}
+	}
+	if err = git.CreateReview(ctx, pr, &providerReview); err != nil {
 		return nil, err
 	}
 
+	// the review responds to the review requests of the reviewer and their teams
+	recordReviewResponse(ctx, git, data.RFCIdentifier, *login, time.Now())
+
 	var message string
 	// if this was an approval and the user wishes to initiate a load request, then attempt the load and merge process
-	if data.Type == exGit.APPROVE_REVIEW_TYPE && data.LoadOnApproval {
+	// unless the RFC is embargoed or depends on RFCs that are not merged, in which case it has to be loaded later
+	if base == models.ApproveReview && data.LoadOnApproval && rfc.Embargoed(time.Now()) {
+		message = fmt.Sprintf("Successfully approved RFC %s. It is embargoed until %s so it was not loaded.",
+			data.RFCIdentifier, rfc.EmbargoUntil.UTC().Format(time.RFC3339))
+	} else if base == models.ApproveReview && data.LoadOnApproval &&
+		errors.Is(checkDependencies(ctx, git, rfc, data.RFCIdentifier), models.ErrUnmetDependencies) {
+		message = fmt.Sprintf("Successfully approved RFC %s. It depends on RFCs that are not merged so it was not loaded.",
+			data.RFCIdentifier)
+	} else if base == models.ApproveReview && data.LoadOnApproval {
 		/*
 			all admin work to be performed by machine client
 
-			attempt to load and merge request asynchronously
-			a new unattached context needs to be created prior to the call because the go routine is not waited on
+			attempt to load and merge request asynchronously, as a job so it is retried should the provider fail
+			a new unattached context needs to be created prior to the call because the job is not waited on
 			and any cancellation will invalidate the child
 		*/
-		go attemptLoadAndMerge(context.Background(), gitMachine, pr, rfc, data.RFCIdentifier)
+		jobs.Default.Enqueue(metadata.Detach(ctx), models.LoadAndMergeJob, data.RFCIdentifier, rfc.Priority,
+			func(ctx context.Context) error {
+				return attemptLoadAndMerge(ctx, gitMachine, pr, rfc, data.RFCIdentifier)
+			})
 		message = fmt.Sprintf(`Successfully approved RFC %s. A load request was submitted. You may query the load status
 		through the /status endpoint.`, data.RFCIdentifier)
 	} else {
 		message = fmt.Sprintf("Successfully reviewed RFC %s with type of '%s'", data.RFCIdentifier, data.Type)
 	}
+	if held > 0 {
+		message = fmt.Sprintf("%s. %d comments are held for moderation", message, held)
+	}
+
+	publishEvent(models.ReviewEvent, data.RFCIdentifier, *login, fmt.Sprintf("review of type '%s'", data.Type), rfc)
 
 	return &message, nil
 }
 
-// MergeRequest orchestrates merging the given RFC and tagging it for tracking, returns a message if successful
-func MergeRequest(ctx context.Context, git exGit.Git, data *models.Merge) (*string, error) {
-	// init. vars to maintain state beyond "if" statements
-	var err error
-	var pr exGit.PullRequest
+// moderateComments checks the comments of the given review, made by the given commenter, with the comment filters if
+// any are configured. Depending on the configured action, models.ErrCommentRejected is returned (wrapped) if any is
+// objectionable, or the objectionable comments are returned by text along with the reasons they were flagged for, or
+// they are held for moderation and removed from the review, their number being returned. The top level comment of the
+// review targets the RFC, whose signature is given
+func moderateComments(ctx context.Context, data *models.Review, commenter string, rfcSignature string) (
+	map[string][]string, int, error) {
+	flagged := map[string][]string{}
+	if moderation.Default == nil {
+		return flagged, 0, nil
+	}
 
-	// get corresponding pr
-	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
-		return nil, err
+	// targets are checked in order so that rate limits apply to the same comments whatever the order of the map
+	targets := make([]string, 0, len(data.Comments))
+	for target := range data.Comments {
+		targets = append(targets, target)
 	}
+	sort.Strings(targets)
 
-	// merge request and create tag with the rfc identifier name
-	if err = mergeRequest(ctx, git, pr, data.RFCIdentifier); err != nil {
-		return nil, err
+	held := 0
+	hold := func(target string, comment string, reasons []string) error {
+		id, err := moderation.Default.Hold(models.HeldComment{RFCIdentifier: data.RFCIdentifier, Domain: data.Domain,
+			Target: target, Commenter: commenter, Comment: comment, Reasons: reasons})
+		if err != nil {
+			return err
+		}
+		logging.FromContext(ctx).Info("comment held for moderation", "id", id, "reasons", reasons)
+		held++
+		return nil
+	}
+	check := func(target string, comment string) (bool, error) {
+		reasons := moderation.Default.Check(commenter, comment)
+		if len(reasons) == 0 {
+			return true, nil
+		}
+		switch moderation.Default.Action {
+		case moderation.REJECT_ACTION:
+			return false, fmt.Errorf("%w: %s", models.ErrCommentRejected, strings.Join(reasons, "; "))
+		case moderation.FLAG_ACTION:
+			flagged[comment] = reasons
+			return true, nil
+		default:
+			return false, hold(target, comment, reasons)
+		}
 	}
 
-	message := fmt.Sprintf("Successfully merged and tagged RFC %s", data.RFCIdentifier)
-	return &message, nil
+	for _, target := range targets {
+		kept := []string{}
+		for _, comment := range data.Comments[target] {
+			keep, err := check(target, comment)
+			if err != nil {
+				return nil, 0, err
+			}
+			if keep {
+				kept = append(kept, comment)
+			}
+		}
+		if len(kept) == 0 {
+			delete(data.Comments, target)
+		} else {
+			data.Comments[target] = kept
+		}
+	}
+	if data.TopLevelComment != "" {
+		keep, err := check(rfcSignature, data.TopLevelComment)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !keep {
+			data.TopLevelComment = ""
+		}
+	}
+
+	return flagged, held, nil
 }
 
-// LoadRequest orchestrates loading the given RFC data into the backing datastore asynchronously - load status will
-// be populated in the RFC file
-func LoadRequest(ctx context.Context, git exGit.Git, data *models.Load) error {
-	// init. vars to maintain state beyond "if" statements
-	var err error
-	var pr exGit.PullRequest
-	var content *string
-	var user *string
+// HandleWebhookEvent reacts to the given pull request event delivered by a Git provider webhook: mergeability checks
+// waiting on the RFCs of the event are woken, the cached state of the RFCs is dropped and reviews are recorded as
+// responses to the review requests of the RFCs. If loadOnApproval is set, an RFC approved through the provider is then
+// loaded and merged asynchronously, as the machine, like an approval submitted through ReviewRequest, unless a load of
+// the RFC is already requested. Returns a message if successful
+func HandleWebhookEvent(ctx context.Context, gitMachine exGit.Git, event *exGit.WebhookEvent,
+	loadOnApproval bool) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.HandleWebhookEvent")
+	defer span.End()
 
-	// Get user login for load status update
-	if user, err = git.GetUserLogin(ctx); err != nil {
-		return err
+	for _, rfcIdentifier := range event.RFCIdentifiers {
+		exGit.NotifyChange(rfcIdentifier)
+		forgetCachedState(rfcIdentifier)
+	}
+	rfcsCache.Clear()
+	// opened, closed and merged pull requests change the open pull requests of the repository
+	if event.Type == exGit.PULL_REQUEST_WEBHOOK_EVENT {
+		openPullRequestCache.Clear()
 	}
 
-	// get corresponding pr so content can be fetched
-	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
-		return err
+	// reviews submitted through the provider respond to review requests too
+	if event.Type == exGit.REVIEW_WEBHOOK_EVENT && !strings.EqualFold(event.Action, exGit.DISMISSED_STATE) &&
+		event.Actor != "" {
+		for _, rfcIdentifier := range event.RFCIdentifiers {
+			recordReviewResponse(ctx, gitMachine, rfcIdentifier, event.Actor, time.Now())
+		}
 	}
 
-	// retrieve corresponding raw RFC content that will be loaded
-	if content, _, err = git.GetRFCContents(ctx, data.RFCIdentifier); err != nil {
-		return err
+	message := fmt.Sprintf("Received %s event of RFCs [%s]", event.Type, strings.Join(event.RFCIdentifiers, ", "))
+	if event.Type != exGit.REVIEW_WEBHOOK_EVENT || !strings.EqualFold(event.Action, exGit.APPROVED_STATE) ||
+		!loadOnApproval || len(event.RFCIdentifiers) == 0 {
+		return &message, nil
 	}
 
-	// format existing content into RFC model so the load status can be manipulated
-	rfc := &models.RFC{}
-	if err = json.Unmarshal([]byte(*content), rfc); err != nil {
-		errStr := "unable to unmarshal existing RFC content in preparation for load, RFC: %s"
-		fmt.Printf(errStr, data.RFCIdentifier)
-		return err
+	rfcIdentifier := event.RFCIdentifiers[0]
+	metadata.SetRFCIdentifier(ctx, rfcIdentifier)
+	metadata.SetUser(ctx, event.Actor)
+
+	// a load already requested, e.g. by an approval submitted through Harmonia, is not requested again
+	if job := jobs.Default.Latest(rfcIdentifier); job != nil &&
+		(job.State == models.QueuedJob || job.State == models.RunningJob) {
+		message = fmt.Sprintf("RFC %s was approved by %s. A load of it is already queued.", rfcIdentifier, event.Actor)
+		return &message, nil
 	}
 
-	// update load status to LOAD_REQUESTED_STATUS so that there is a record of this request
-	if err = rfc.UpdateLoadStatus(LOAD_REQUESTED_STATUS, *user); err != nil {
-		return err
+	pr, err := gitMachine.GetPullRequest(ctx, rfcIdentifier)
+	if err != nil {
+		return nil, err
 	}
-	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
-		return err
+	rfc, err := readRFC(ctx, gitMachine, rfcIdentifier)
+	if err != nil {
+		return nil, err
 	}
 
-	/*
-		attempt to load request asynchronously
-		a new unattached context needs to be created prior to the call because the go routine is not waited on
-		and any cancellation will invalidate the child
-	*/
-	go loadRequest(context.Background(), git, pr, rfc)
+	status := ""
+	if record, err := loadstatus.Default.Get(ctx, rfcIdentifier); err != nil {
+		return nil, err
+	} else if record != nil {
+		status = record.Status
+	} else if loadStatus := rfc.GetLoadStatus(); loadStatus != nil {
+		status = *loadStatus
+	}
 
-	return err
+	// RFCs that could not be loaded yet, e.g. because they lacked approvals, are loaded once approved
+	if status != "" && status != NOT_APPLICABLE_STATUS && status != FAILED_STATUS {
+		message = fmt.Sprintf("RFC %s was approved by %s. It has a load status of %s so it was not loaded.",
+			rfcIdentifier, event.Actor, status)
+	} else if rfc.Embargoed(time.Now()) {
+		message = fmt.Sprintf("RFC %s was approved by %s. It is embargoed until %s so it was not loaded.",
+			rfcIdentifier, event.Actor, rfc.EmbargoUntil.UTC().Format(time.RFC3339))
+	} else if errors.Is(checkDependencies(ctx, gitMachine, rfc, rfcIdentifier), models.ErrUnmetDependencies) {
+		message = fmt.Sprintf("RFC %s was approved by %s. It depends on RFCs that are not merged so it was not loaded.",
+			rfcIdentifier, event.Actor)
+	} else {
+		jobs.Default.Enqueue(metadata.Detach(ctx), models.LoadAndMergeJob, rfcIdentifier, rfc.Priority,
+			func(ctx context.Context) error {
+				return attemptLoadAndMerge(ctx, gitMachine, pr, rfc, rfcIdentifier)
+			})
+		message = fmt.Sprintf("RFC %s was approved by %s. A load request was submitted.", rfcIdentifier, event.Actor)
+	}
+
+	return &message, nil
 }
 
-// Status returns the current load status of the given RFC, if any
-func Status(ctx context.Context, git exGit.Git, data *models.Status) (*string, error) {
-	// init. vars to maintain scope beyond "if" statements
+// forgetCachedState drops the cached review details, load status, mergeability, content signature, dependencies,
+// submitter and sealed reviews of the given RFC, so they are read from the Git provider again even if the update time
+// of its pull request did not change
+func forgetCachedState(rfcIdentifier string) {
+	ofRFC := func(key string) bool {
+		return strings.HasPrefix(key, rfcIdentifier+"@")
+	}
+	reviewDetailsCache.DeleteMatching(ofRFC)
+	loadStatusCache.DeleteMatching(ofRFC)
+	mergeabilityCache.DeleteMatching(ofRFC)
+	contentSignatureCache.DeleteMatching(ofRFC)
+	dependenciesCache.DeleteMatching(ofRFC)
+	submitterCache.DeleteMatching(ofRFC)
+	sealedReviewsCache.DeleteMatching(ofRFC)
+}
+
+// MergeRequest orchestrates merging the given RFC and tagging it for tracking, returns a message if successful
+func MergeRequest(ctx context.Context, git exGit.Git, data *models.Merge) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.MergeRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
 	var err error
-	var content *string
+	var pr exGit.PullRequest
+	var rfc *models.RFC
 
-	// retrieve corresponding raw RFC content that can be parsed
-	if content, _, err = git.GetRFCContents(ctx, data.RFCIdentifier); err != nil {
+	// get corresponding pr
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
 		return nil, err
 	}
 
-	// format existing content into RFC model so the load status can be searched for
-	rfc := &models.RFC{}
-	if err = json.Unmarshal([]byte(*content), rfc); err != nil {
-		errStr := "unable to unmarshal existing RFC content in preparation for status retrieval, RFC: %s"
-		fmt.Printf(errStr, data.RFCIdentifier)
+	// embargoed RFCs must not go live before their embargo ends
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if err = rfc.CheckEmbargo(data.RFCIdentifier, time.Now()); err != nil {
 		return nil, err
 	}
 
-	return rfc.GetLoadStatus(), nil
-}
+	// RFCs must not go live before the RFCs they depend on
+	if err = checkDependencies(ctx, git, rfc, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
 
-// GetRfcs returns all submitted RFCs based on given data filtering
-func GetRfcs(ctx context.Context, git exGit.Git, data *models.GetRfcs) ([]map[string]string, error) {
-	// init. vars to maintain scope beyond "if" statements
-	var err error
-	var prs exGit.PullRequests
-	filters := []exGit.FilterOption{git.WithOwner(data.Owner), git.IsMerged(data.Merged)}
+	// RFCs signed by their author must still verify with the key of their author, which may have been revoked since
+	if _, err = verifyAuthorSignature(ctx, git, rfc, false); err != nil {
+		return nil, err
+	}
 
-	// query for PRs
-	if prs, err = git.GetPullRequests(ctx, data.State, data.Count, filters...); err != nil {
+	// RFCs must meet the quorum of approvals the approval policy requires of them
+	if err = checkQuorum(ctx, git, pr, rfc); err != nil {
 		return nil, err
 	}
 
-	// retrieve RFC ID and Title map
-	return git.GetIdsAndTitles(prs)
+	// merge request and create tag with the rfc identifier name
+	if err = mergeRequest(ctx, git, pr, rfc, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Successfully merged and tagged RFC %s", data.RFCIdentifier)
+	return &message, nil
 }
 
-// GetRfcContents returns the contents of the target RFC
-func GetRfcContents(ctx context.Context, git exGit.Git, data *models.GetRfcContents) (*string, error) {
-	// init. vars to maintain scope beyond "if" statements
+// WithdrawRequest retracts an RFC on behalf of its author: the withdrawal is recorded in the RFC file, then its pull
+// request is closed and its branch deleted. Returns a message if successful
+func WithdrawRequest(ctx context.Context, git exGit.Git, data *models.Withdraw) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.WithdrawRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
 	var err error
-	var content *string
+	var pr exGit.PullRequest
+	var details *exGit.PullRequestDetails
+	var login *string
+	var rfc *models.RFC
 
-	// retrieve corresponding raw RFC content that can be parsed
-	if content, _, err = git.GetRFCContents(ctx, data.RFCIdentifier); err != nil {
+	// retrieve PR, current user and RFC
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if details, err = git.GetPullRequestDetails(pr); err != nil {
+		return nil, err
+	}
+	if login, err = userLogin(ctx, git); err != nil {
 		return nil, err
 	}
+	metadata.SetUser(ctx, *login)
 
-	return content, nil
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// only the author of an RFC may withdraw it, see submitterOf
+	submitter := submitterOf(rfc, details)
+	if submitter != *login {
+		logging.FromContext(ctx).Warn("RFC cannot be withdrawn by anyone but its author", "author", submitter)
+		return nil, fmt.Errorf("%w: RFC %s was submitted by %s", models.ErrNotRFCAuthor, data.RFCIdentifier,
+			submitter)
+	}
+
+	// record the withdrawal before the branch holding the RFC file is deleted, the commit remains part of the PR
+	if err = rfc.Withdraw(*login, data.Reason, time.Now()); err != nil {
+		return nil, err
+	}
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
+	}
+
+	// close PR and clean up its branch
+	if err = git.ClosePullRequest(ctx, pr); err != nil {
+		return nil, err
+	}
+	if err = git.DeleteBranch(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	publishEvent(models.WithdrawEvent, data.RFCIdentifier, *login, data.Reason, rfc)
+
+	message := fmt.Sprintf("Successfully withdrew RFC %s", data.RFCIdentifier)
+	return &message, nil
 }
 
-// the below methods (not capitalized) exist strictly to be called by other functions within this module, which have
-// already performed the boilerplate retrieval of rfc entities like the pull request and rfc content
+// LoadRequest orchestrates loading the given RFC data into the backing datastore asynchronously - load status will
+// be populated in the RFC file
+func LoadRequest(ctx context.Context, git exGit.Git, data *models.Load) error {
+	ctx, span := tracing.Start(ctx, "controllers.LoadRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
 
-// attemptLoadAndMerge attempts to load and then merge the given RFC data and corresponding pull request
-func attemptLoadAndMerge(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
-	rfcIdentifier string) error {
 	// init. vars to maintain state beyond "if" statements
 	var err error
-	var mergeable *bool
+	var pr exGit.PullRequest
+	var rfc *models.RFC
 	var user *string
 
 	// Get user login for load status update
-	if user, err = git.GetUserLogin(ctx); err != nil {
+	if user, err = userLogin(ctx, git); err != nil {
 		return err
 	}
+	metadata.SetUser(ctx, *user)
 
-	// update load status to LOAD_REQUESTED_STATUS
-	if err = rfc.UpdateLoadStatus(LOAD_REQUESTED_STATUS, *user); err != nil {
+	// get corresponding pr so content can be fetched
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
 		return err
 	}
-	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+
+	// retrieve corresponding RFC that will be loaded so the load status can be manipulated
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
 		return err
 	}
 
-	// determine if the pr can be merged, this is 1:1 with loadability (can't load if we can't merge)
-	if mergeable, err = git.GetMergeability(ctx, pr); err != nil {
+	// an RFC file tampered with is not loaded
+	if err = verifyRFC(ctx, data.RFCIdentifier, rfc); err != nil {
 		return err
 	}
-	if !*mergeable {
-		infoStr := "Attempted to load and merge RFC %s, but it is not mergeable."
-		fmt.Printf(infoStr, rfcIdentifier)
 
-		// update load status to NOT_APPLICABLE_STATUS
-		if err = rfc.UpdateLoadStatus(NOT_APPLICABLE_STATUS, *user); err != nil {
+	// embargoed RFCs must not go live before their embargo ends
+	if err = rfc.CheckEmbargo(data.RFCIdentifier, time.Now()); err != nil {
+		return err
+	}
+
+	// RFCs must not go live before the RFCs they depend on
+	if err = checkDependencies(ctx, git, rfc, data.RFCIdentifier); err != nil {
+		return err
+	}
+
+	// update load status to LOAD_REQUESTED_STATUS so that there is a record of this request
+	if err = recordLoadStatus(ctx, git, pr, rfc, data.RFCIdentifier, LOAD_REQUESTED_STATUS, *user, nil); err != nil {
+		return err
+	}
+	publishEvent(models.LoadEvent, data.RFCIdentifier, *user, LOAD_REQUESTED_STATUS, rfc)
+
+	// gated loads wait for an approval before the load step executes
+	if gate := models.NewLoadGate(); gate != nil {
+		return openLoadGate(ctx, git, pr, rfc, data.RFCIdentifier, *user, gate)
+	}
+
+	/*
+		attempt to load request asynchronously, as a job so it is retried should the provider fail
+		a new unattached context needs to be created prior to the call because the job is not waited on
+		and any cancellation will invalidate the child
+	*/
+	jobs.Default.Enqueue(metadata.Detach(ctx), models.LoadJob, data.RFCIdentifier, rfc.Priority,
+		func(ctx context.Context) error {
+			_, err := loadRequest(ctx, git, pr, rfc, data.RFCIdentifier)
 			return err
+		})
+
+	return err
+}
+
+// Status returns the current load status of the given RFC, "none" if it was never loaded, along with its load gate
+// if the load is gated and its embargo if it has one
+// The load status is read from the load status store, or from the RFC file if the store has none for the RFC
+func Status(ctx context.Context, git exGit.Git, data *models.Status) (*models.StatusResponse, error) {
+	ctx, span := tracing.Start(ctx, "controllers.Status", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// retrieve corresponding RFC so the load status can be searched for
+	rfc, err := readRFC(ctx, git, data.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := loadstatus.Default.Get(ctx, data.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.StatusResponse{
+		Status:       "none",
+		Gate:         rfc.GetLoadGate(),
+		EmbargoUntil: rfc.EmbargoUntil,
+		Embargoed:    rfc.Embargoed(time.Now()),
+		Targets:      rfc.GetTargetLoadStatuses(),
+		Job:          jobs.Default.Latest(data.RFCIdentifier),
+	}
+	if len(rfc.DependsOn) > 0 {
+		if response.Dependencies, err = dependencyStates(ctx, git, rfc); err != nil {
+			return nil, err
 		}
-		if err = git.UpdateFile(ctx, pr, rfc); err != nil {
-			return err
+	}
+	if data.Detail {
+		response.Timings = rfc.GetLoadTimings()
+	}
+	if record != nil {
+		response.Status = record.Status
+		response.Targets = record.Targets
+		if data.Detail {
+			response.Timings = record.Timings
 		}
+	} else if loadStatus := rfc.GetLoadStatus(); loadStatus != nil {
+		response.Status = *loadStatus
+	}
 
-		return nil
+	return response, nil
+}
+
+// ApproveLoad records the decision of the authenticated user on the pending manual load gate of the given RFC
+// Approved loads are executed asynchronously, as the machine, in the same way as load requests
+func ApproveLoad(ctx context.Context, git exGit.Git, gitMachine exGit.Git, data *models.ApproveLoad) (*string,
+	error) {
+	ctx, span := tracing.Start(ctx, "controllers.ApproveLoad", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// the approver is the authenticated user
+	approver, err := userLogin(ctx, git)
+	if err != nil {
+		return nil, err
 	}
+	metadata.SetUser(ctx, *approver)
 
-	// attempt load
-	if err = loadRequest(ctx, git, pr, rfc); err != nil {
-		return err
+	// deployment gates are decided by their environment protection rules, so only manual gates are decided here
+	pr, rfc, gate, err := decideLoadGate(ctx, gitMachine, data.RFCIdentifier, models.ManualGate, "", *data.Approve,
+		*approver)
+	if err != nil {
+		return nil, err
+	}
+	if gate.State == models.RejectedGate {
+		message := fmt.Sprintf("Rejected load of RFC %s", data.RFCIdentifier)
+		return &message, nil
 	}
 
-	// mergeability needs to be recalculated here because loadRequest updates the RFC file - CI check
-	if mergeable, err = git.GetMergeability(ctx, pr); err != nil {
-		return err
+	// a new unattached context is needed because the job is not waited on, see LoadRequest
+	jobs.Default.Enqueue(metadata.Detach(ctx), models.LoadAfterGateJob, data.RFCIdentifier, rfc.Priority,
+		func(ctx context.Context) error {
+			return loadAfterGate(ctx, gitMachine, pr, rfc, data.RFCIdentifier, gate.MergeOnLoad)
+		})
+
+	message := fmt.Sprintf("Approved load of RFC %s, you may query the load status through the /status endpoint",
+		data.RFCIdentifier)
+	return &message, nil
+}
+
+// BreakGlass forces the given RFC live on behalf of a break-glass admin during an incident, bypassing policy: the
+// justification is recorded in the RFC file and announced, then the RFC is loaded and merged regardless of its reviews,
+// embargo, load gate and the merge policy. Returns a message if the load and merge were started
+func BreakGlass(ctx context.Context, git exGit.Git, gitMachine exGit.Git, data *models.BreakGlass) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.BreakGlass", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var rfc *models.RFC
+	var admin *string
+
+	// the admin is the authenticated user, while the RFC is changed by the machine since reviews are bypassed
+	if admin, err = userLogin(ctx, git); err != nil {
+		return nil, err
+	}
+	metadata.SetUser(ctx, *admin)
+	if !models.IsBreakGlassAdmin(*admin) {
+		logging.FromContext(ctx).Warn("attempted to break glass but is not a break-glass admin")
+		return nil, fmt.Errorf("%w: %s", models.ErrNotBreakGlassAdmin, *admin)
 	}
-	if !*mergeable {
-		errStr := "Attempted to merge RFC %s, but it is not mergeable - NOTE: LOADED BUT NOT MERGED."
-		fmt.Printf(errStr, rfcIdentifier)
-		return fmt.Errorf(errStr, rfcIdentifier)
+	if pr, err = gitMachine.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if rfc, err = readRFC(ctx, gitMachine, data.RFCIdentifier); err != nil {
+		return nil, err
 	}
 
-	// attempt merge
-	if err = mergeRequest(ctx, git, pr, rfcIdentifier); err != nil {
-		return err
+	// record and announce the break-glass before anything goes live, so it is audited even if the load fails
+	if err = rfc.BreakGlass(*admin, data.Justification, time.Now()); err != nil {
+		return nil, err
+	}
+	if err = gitMachine.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
 	}
+	logging.FromContext(ctx).Warn("BREAK-GLASS: RFC forced live bypassing policy", "justification", data.Justification)
+	publishEvent(models.BreakGlassEvent, data.RFCIdentifier, *admin, data.Justification, rfc)
 
-	return nil
+	// a new unattached context is needed because the job is not waited on, see LoadRequest
+	jobs.Default.Enqueue(metadata.Detach(ctx), models.BreakGlassJob, data.RFCIdentifier, rfc.Priority,
+		func(ctx context.Context) error {
+			return forceLoadAndMerge(ctx, gitMachine, pr, rfc, data.RFCIdentifier)
+		})
+
+	message := fmt.Sprintf("Broke glass on RFC %s, you may query the load status through the /status endpoint",
+		data.RFCIdentifier)
+	return &message, nil
 }
 
-// loadRequest loads the given rfc content into the backing data store
-// The pull request param. seems unnecessary, but it is needed to update the load status periodically
-func loadRequest(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC) error {
-	// init. vars to maintain scope beyond "if" statements
+// RecordExternalVerdict records the verdict of the given external system, e.g. a change advisory board tool, on an RFC
+// and returns a message if successful. The approval policy counts approvals of external systems alongside the reviews
+// of the Git provider, and rejections block the merge of the RFC until the system approves it, see checkQuorum
+// The RFC is updated by the machine, external systems have no Git identity. models.ErrStaleVerdict is returned
+// (wrapped) if the system decided on a signature the RFC no longer has
+func RecordExternalVerdict(ctx context.Context, git exGit.Git, system string, data *models.ExternalApproval) (*string,
+	error) {
+	ctx, span := tracing.Start(ctx, "controllers.RecordExternalVerdict",
+		tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
 	var err error
-	var content []byte
-	var user *string
+	var pr exGit.PullRequest
+	var rfc *models.RFC
 
-	// Get user login for load status update
-	if user, err = git.GetUserLogin(ctx); err != nil {
-		return err
+	metadata.SetUser(ctx, system)
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
 	}
 
-	// update load status to LOADING_STATUS
-	if err = rfc.UpdateLoadStatus(LOADING_STATUS, *user); err != nil {
-		return err
+	// verdicts apply to the content the system decided on, which must still be what the RFC proposes
+	if data.Signature != "" && data.Signature != rfc.Signature {
+		logging.FromContext(ctx).Warn("external system decided on a previous version of the RFC",
+			"signature", data.Signature)
+		return nil, fmt.Errorf("%w: RFC %s is now signed %s", models.ErrStaleVerdict, data.RFCIdentifier,
+			rfc.Signature)
+	}
+	if signing.Systems == nil {
+		return nil, fmt.Errorf("%w: '%s'", signing.ErrUnknownSystem, system)
+	}
+	err = rfc.RecordVerdict(system, data.Verdict, data.Reference, data.Reason, time.Now(), signing.Systems.Seal)
+	if err != nil {
+		return nil, err
 	}
 	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
-		return err
+		return nil, err
 	}
 
-	// format rfc for loading
-	if content, err = json.Marshal(rfc); err != nil {
-		errStr := "unable to marshal existing RFC content in preparation for load."
-		fmt.Printf(errStr)
-		return err
+	publishEvent(models.ReviewEvent, data.RFCIdentifier, system, fmt.Sprintf("external verdict '%s'", data.Verdict),
+		rfc)
+
+	message := fmt.Sprintf("Successfully recorded the %s verdict of %s on RFC %s", data.Verdict, system,
+		data.RFCIdentifier)
+	return &message, nil
+}
+
+// GetRfcs returns all submitted RFCs based on given data filtering, along with their provider URLs keyed by RFC ID
+// When filtering by owner, a summary of the reviews, mergeability and load status of each RFC is also returned, so an
+// author can follow all of their RFCs in a single call
+// If fields are selected, only the selected ones are returned and only the selected summary fields are computed,
+// whether filtering by owner or not. models.ErrInvalidField is returned (wrapped) if a field is not supported
+// Responses are served from cache for up to RFCS_CACHE_TTL, see CachedRfcs
+func GetRfcs(ctx context.Context, git exGit.Git, data *models.GetRfcs) (*models.RFCs, error) {
+	rfcs, _, err := CachedRfcs(ctx, git, data, false)
+	return rfcs, err
+}
+
+// CachedRfcs returns the RFCs GetRfcs returns for the given data, along with the time they were retrieved from the Git
+// provider at. Responses are cached for up to RFCS_CACHE_TTL per client and normalized filter set, and dropped as soon
+// as an RFC changes through Harmonia or a webhook event is received. If refresh is set, the cached response is bypassed
+// and replaced
+func CachedRfcs(ctx context.Context, git exGit.Git, data *models.GetRfcs, refresh bool) (*models.RFCs, time.Time,
+	error) {
+	key, err := rfcsCacheKey(data)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if cached, ok := rfcsCache.Get(rfcsKey{git: git, filters: key}); ok && !refresh {
+		return cached.rfcs, cached.retrievedAt, nil
 	}
 
-	// call database service with the RFC content to load
-	// ...
-	fmt.Println(content)
-	// ...
-	// update file with failed status if there was a load error
+	retrievedAt := time.Now().UTC()
+	rfcs, err := getRfcs(ctx, git, data)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	rfcsCache.Set(rfcsKey{git: git, filters: key}, cachedRFCs{rfcs: rfcs, retrievedAt: retrievedAt})
 
-	// update load status to SUCCESSFUL_STATUS
-	if err = rfc.UpdateLoadStatus(SUCCESSFUL_STATUS, *user); err != nil {
-		return err
+	return rfcs, retrievedAt, nil
+}
+
+// rfcsCacheKey returns the normalized filter set of the given data, so equivalent queries share their cached response
+func rfcsCacheKey(data *models.GetRfcs) (string, error) {
+	normalized := *data
+	normalized.State = strings.ToLower(normalized.State)
+	if normalized.State == "" {
+		normalized.State = exGit.ALL_PR_FILTER
 	}
-	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
-		return err
+	if normalized.CreatedAfter != nil {
+		createdAfter := normalized.CreatedAfter.UTC()
+		normalized.CreatedAfter = &createdAfter
 	}
+	fields := set.NewSetOf(data.Fields...).Values()
+	sort.Slice(fields, func(i, j int) bool { return fields[i] < fields[j] })
+	normalized.Fields = fields
 
-	return nil
+	key, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
 }
 
-// mergeRequest merges the given pr and creates a tag with the given tag name
-func mergeRequest(ctx context.Context, git exGit.Git, pr exGit.PullRequest, tag string) error {
+// getRfcs returns all submitted RFCs based on given data filtering, see GetRfcs
+func getRfcs(ctx context.Context, git exGit.Git, data *models.GetRfcs) (*models.RFCs, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRfcs")
+	defer span.End()
+
 	// init. vars to maintain scope beyond "if" statements
 	var err error
-	var sha *string
+	var prs exGit.PullRequests
+	if err = models.ValidateFields(data.Fields); err != nil {
+		return nil, err
+	}
+	filters := []exGit.FilterOption{git.WithOwner(data.Owner), git.IsMerged(data.Merged)}
+	if data.Label != nil {
+		filter, err := exGit.NewFilter(git, exGit.LABEL_FILTER, *data.Label)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if data.CreatedAfter != nil {
+		filters = append(filters, exGit.CreatedAfter(git, *data.CreatedAfter))
+	}
+	if data.HeadPrefix != nil {
+		filters = append(filters, exGit.WithHeadPrefix(git, *data.HeadPrefix))
+	}
+	if data.Filter != nil {
+		filter, err := buildFilter(git, data.Filter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
 
-	// merge pr and retrieve resulting sha
-	if sha, err = git.MergePullRequest(ctx, pr); err != nil {
-		return err
+	// query for PRs
+	if prs, err = git.GetPullRequests(ctx, data.State, data.Count, filters...); err != nil {
+		return nil, err
 	}
 
-	// create a tag of sha and name it after tag name
-	if err = git.CreateTag(ctx, *sha, tag); err != nil {
-		return err
+	// retrieve RFC ID and Title map
+	idsAndTitles, err := git.GetIdsAndTitles(prs)
+	if err != nil {
+		return nil, err
 	}
+	if idsAndTitles == nil {
+		idsAndTitles = []map[string]string{}
+	}
+	count := len(idsAndTitles)
+	rfcs := &models.RFCs{RFCs: idsAndTitles, Count: &count}
 
-	return nil
+	// every field is returned unless some are selected, summaries only when filtering by owner
+	selected := set.NewSetOf(data.Fields...)
+	summaryFields := set.NewSet[models.RFCField]()
+	for _, field := range []models.RFCField{models.StateField, models.ReviewsField, models.MergeabilityField,
+		models.LoadStatusField, models.DependenciesField} {
+		if selected.Contains(field) || (selected.Size() == 0 && data.Owner != nil) {
+			summaryFields.Add(field)
+		}
+	}
+
+	// build provider URLs for each RFC, merged RFCs are tagged
+	if selected.Size() == 0 || selected.Contains(models.LinksField) {
+		rfcs.Links = map[string]*models.Links{}
+		for _, pr := range prs {
+			details, err := git.GetPullRequestDetails(pr)
+			if err != nil {
+				return nil, err
+			}
+			rfcs.Links[details.RFCIdentifier] = git.BuildLinks(details.RFCIdentifier, pr, details.Merged)
+		}
+	}
+
+	if summaryFields.Size() > 0 {
+		rfcs.Summaries = summarizeRFCs(ctx, git, prs, summaryFields)
+	}
+
+	return rfcs, nil
+}
+
+// GetRfcContents returns the contents of the target RFC, as of the requested revision if any
+func GetRfcContents(ctx context.Context, git exGit.Git, data *models.GetRfcContents) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRfcContents", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var content *string
+
+	// retrieve corresponding raw RFC content that can be parsed, as of the requested revision if any
+	if data.Ref != "" {
+		if content, err = git.GetRFCContentsAt(ctx, data.RFCIdentifier, data.Ref); err != nil {
+			return nil, err
+		}
+	} else if content, _, err = git.GetRFCContents(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// VerifyRfc checks the signatures recorded in the RFC file of the target RFC, as of the requested revision if any,
+// against its content so auditors can tell whether it was tampered with, see models.RFC.Verify
+// A *models.IntegrityError is returned if the file is missing, empty or cannot be decoded
+func VerifyRfc(ctx context.Context, git exGit.Git, data *models.VerifyRfc) (*models.Verification, error) {
+	ctx, span := tracing.Start(ctx, "controllers.VerifyRfc", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	content, err := GetRfcContents(ctx, git, &models.GetRfcContents{RFCIdentifier: data.RFCIdentifier, Ref: data.Ref})
+	if err != nil {
+		if errors.Is(err, exGit.ErrRFCFileNotFound) {
+			return nil, models.NewIntegrityError(data.RFCIdentifier, models.MissingRFC, err)
+		}
+		return nil, err
+	}
+	rfc, err := decodeRFC(ctx, data.RFCIdentifier, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return rfc.Verify(data.RFCIdentifier)
+}
+
+// GetRfcRendered returns the RFC, as of the requested revision if any, rendered in the requested format for human
+// review rather than as raw JSON
+func GetRfcRendered(ctx context.Context, git exGit.Git, data *models.GetRfcRendered) (*models.RFCRendered, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRfcRendered", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var content *string
+	var rfc *models.RFC
+	var body string
+
+	format := data.Format
+	if format == "" {
+		format = rendering.MARKDOWN_FORMAT
+	}
+
+	// retrieve and parse the RFC, as of the requested revision if any
+	if content, err = GetRfcContents(ctx, git, &models.GetRfcContents{RFCIdentifier: data.RFCIdentifier,
+		Ref: data.Ref}); err != nil {
+		return nil, err
+	}
+	if rfc, err = decodeRFC(ctx, data.RFCIdentifier, content); err != nil {
+		return nil, err
+	}
+
+	if body, err = rendering.Render(data.RFCIdentifier, rfc, format); err != nil {
+		logging.FromContext(ctx).Error("unable to render RFC", logging.RFC_IDENTIFIER_KEY, data.RFCIdentifier,
+			logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return &models.RFCRendered{RFCIdentifier: data.RFCIdentifier, Format: format, Body: body, Ref: data.Ref}, nil
+}
+
+// GetReviews returns every review submitted on the RFC, oldest first, along with the review type each was submitted
+// as and whether it was dismissed
+func GetReviews(ctx context.Context, git exGit.Git, data *models.GetReviews) (*models.RFCReviews, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetReviews", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var reviews exGit.PullRequestReviews
+	var details []exGit.ReviewDetails
+
+	// retrieve PR and its reviews
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if reviews, err = git.GetReviews(ctx, pr); err != nil {
+		return nil, err
+	}
+	if details, err = git.GetReviewDetails(reviews); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].SubmittedAt.Before(details[j].SubmittedAt)
+	})
+
+	// the provider review states map back to the base review types they were submitted as
+	types := map[string]models.ReviewType{
+		exGit.APPROVED_STATE:          models.ApproveReview,
+		exGit.CHANGES_REQUESTED_STATE: models.RequestChangesReview,
+		exGit.COMMENTED_STATE:         models.CommentReview,
+	}
+	rfcReviews := &models.RFCReviews{RFCIdentifier: data.RFCIdentifier, Reviews: []models.RFCReview{}}
+	for _, review := range details {
+		rfcReviews.Reviews = append(rfcReviews.Reviews, models.RFCReview{
+			Reviewer:    review.Reviewer,
+			Type:        string(types[review.State]),
+			State:       review.State,
+			SubmittedAt: review.SubmittedAt,
+			Dismissed:   review.State == exGit.DISMISSED_STATE,
+		})
+	}
+
+	return rfcReviews, nil
+}
+
+// GetRfcHistory returns the commits that modified the RFC file, newest first, along with the diff each made to the RFC
+// JSON. Revisions that are not valid JSON are diffed as-is so that corruptions show up in the history too
+func GetRfcHistory(ctx context.Context, git exGit.Git, data *models.GetRfcHistory) (*models.RFCHistory, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRfcHistory", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	revisions, err := git.GetRFCHistory(ctx, data.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// diff oldest first, each revision against the one before it
+	history := make([]models.Revision, len(revisions))
+	previous, previousSha := "", ""
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		current := ""
+		content, err := git.GetRFCContentsAt(ctx, data.RFCIdentifier, revision.Sha)
+		if err == nil {
+			current = indentRFC(*content)
+		} else if !errors.Is(err, exGit.ErrRFCFileNotFound) {
+			// the revision that deleted the file has no content, any other failure is fatal
+			return nil, err
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(previous),
+			B:        difflib.SplitLines(current),
+			FromFile: previousSha,
+			ToFile:   revision.Sha,
+			Context:  HISTORY_DIFF_CONTEXT,
+		})
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to diff revision of RFC", "revision", revision.Sha, logging.ERROR_KEY, err)
+			return nil, err
+		}
+
+		history[i] = models.Revision{
+			Sha:       revision.Sha,
+			Author:    revision.Author,
+			Message:   revision.Message,
+			Timestamp: revision.Timestamp,
+			Diff:      diff,
+		}
+		previous, previousSha = current, revision.Sha
+	}
+
+	return &models.RFCHistory{RFCIdentifier: data.RFCIdentifier, Revisions: history}, nil
+}
+
+// DiffRequest previews the changes the target RFC would make: the item targeted by each of its proposal actions is
+// resolved in the datastore of each target the RFC is loaded into, and diffed against the item once the action is
+// applied. Changes that cannot be computed, because the load target cannot resolve items or the effect of the action
+// type is not known, are reported with a note instead of a diff
+func DiffRequest(ctx context.Context, git exGit.Git, data *models.Diff) (*models.RFCDiff, error) {
+	ctx, span := tracing.Start(ctx, "controllers.DiffRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	rfc, err := readRFC(ctx, git, data.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []models.ActionDiff{}
+	for _, action := range rfc.Actions {
+		if !action.IsProposal() {
+			continue
+		}
+		for _, loadTarget := range loadTargets(rfc) {
+			diff := models.ActionDiff{
+				Signature:  action.Signature,
+				ActionType: string(action.ActionType),
+				Target:     action.Target,
+				LoadTarget: loadTarget,
+			}
+
+			// resolve the item as it currently is
+			before, err := loader.Default.Resolve(ctx, loadTarget, action.Target)
+			if errors.Is(err, loader.ErrCannotResolve) || errors.Is(err, models.ErrUnknownLoadTarget) {
+				diff.Note = err.Error()
+				diffs = append(diffs, diff)
+				continue
+			} else if err != nil {
+				logging.FromContext(ctx).Error("unable to resolve target of action of RFC in load target",
+					"action", action.Signature, "loadTarget", loadTarget, logging.ERROR_KEY, err)
+				return nil, err
+			}
+			diff.Before = before
+
+			// apply the action and diff the item
+			after, known := action.Apply(before)
+			if !known {
+				diff.Note = fmt.Sprintf("the effect of %s actions is not known until they are loaded", action.ActionType)
+				diffs = append(diffs, diff)
+				continue
+			}
+			diff.After = after
+			if diff.Diff, err = diffItems(before, after); err != nil {
+				logging.FromContext(ctx).Error("unable to diff action of RFC", "action", action.Signature, logging.ERROR_KEY, err)
+				return nil, err
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return &models.RFCDiff{RFCIdentifier: data.RFCIdentifier, Actions: diffs}, nil
+}
+
+// diffItems returns a unified diff of the JSON attributes of the given item before and after a change, either of which
+// may be nil if the item does not exist
+func diffItems(before map[string]interface{}, after map[string]interface{}) (string, error) {
+	lines := [2][]string{}
+	for i, item := range []map[string]interface{}{before, after} {
+		if item == nil {
+			continue
+		}
+		indented, err := json.MarshalIndent(item, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		lines[i] = difflib.SplitLines(string(indented) + "\n")
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        lines[0],
+		B:        lines[1],
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  ITEM_DIFF_CONTEXT,
+	})
+}
+
+// indentRFC returns the given RFC file content indented one JSON value per line so that diffs are readable, content
+// that is not valid JSON is returned unchanged
+func indentRFC(content string) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(content), "", "  "); err != nil {
+		return content
+	}
+
+	return indented.String() + "\n"
+}
+
+// GetAction returns the action of the target RFC with the given signature along with its comment thread
+func GetAction(ctx context.Context, git exGit.Git, data *models.GetAction) (*models.ActionThread, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetAction", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// retrieve the RFC so its actions can be searched
+	rfc, err := readRFC(ctx, git, data.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	action := rfc.GetAction(data.Signature)
+	if action == nil {
+		logging.FromContext(ctx).Warn("no action with signature in RFC", "action", data.Signature)
+		return nil, fmt.Errorf("%w: signature %s in RFC %s", models.ErrActionNotFound, data.Signature,
+			data.RFCIdentifier)
+	}
+
+	return &models.ActionThread{
+		Action:      action,
+		Comments:    rfc.GetCommentThread(data.Signature),
+		Annotations: rfc.GetAnnotations(data.Signature),
+	}, nil
+}
+
+// GetRFC returns the decoded RFC file of the target RFC
+// A *models.IntegrityError is returned if the file is missing or its content cannot be decoded
+func GetRFC(ctx context.Context, git exGit.Git, rfcIdentifier string) (*models.RFC, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRFC", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	return readRFC(ctx, git, rfcIdentifier)
+}
+
+// GetRFCReference returns a reference to the target RFC, titled after its pull request and linked to its provider URLs
+func GetRFCReference(ctx context.Context, git exGit.Git, rfcIdentifier string) (*models.RFCReference, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetRFCReference", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	pr, err := git.GetPullRequest(ctx, rfcIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	details, err := git.GetPullRequestDetails(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RFCReference{
+		RFCIdentifier: rfcIdentifier,
+		Title:         details.Title,
+		Links:         git.BuildLinks(rfcIdentifier, pr, details.Merged),
+	}, nil
+}
+
+// Annotate attaches the annotations of a registered analyzer to the actions of an RFC, replacing those it previously
+// attached
+func Annotate(ctx context.Context, git exGit.Git, analyzer string, data *models.Annotate) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.Annotate", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var rfc *models.RFC
+
+	// retrieve PR and RFC
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// attach the annotations, only registered analyzers may, and propagate updated RFC to the repo
+	if err = rfc.Annotate(analyzer, data.Annotations); err != nil {
+		logging.FromContext(ctx).Warn("unable to annotate RFC", "analyzer", analyzer, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
+	}
+
+	publishEvent(models.AnnotateEvent, data.RFCIdentifier, analyzer,
+		fmt.Sprintf("%d annotation(s)", len(data.Annotations)), rfc)
+
+	message := fmt.Sprintf("Successfully attached %d annotation(s) from %s to RFC %s", len(data.Annotations),
+		analyzer, data.RFCIdentifier)
+	return &message, nil
+}
+
+// EditComment replaces the text of a comment the authenticated user made on an RFC, both in the RFC, where the previous
+// text is kept in the comment's edit history, and in the corresponding provider review comment
+func EditComment(ctx context.Context, git exGit.Git, data *models.EditComment) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.EditComment", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var login *string
+	var rfc *models.RFC
+	var previous *string
+
+	// retrieve PR, current user and RFC
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if login, err = userLogin(ctx, git); err != nil {
+		return nil, err
+	}
+	metadata.SetUser(ctx, *login)
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// edit the comment action, only its author may
+	if previous, err = rfc.EditComment(data.Signature, data.Comment, *login, time.Now()); err != nil {
+		logging.FromContext(ctx).Warn("unable to edit comment of RFC", "action", data.Signature, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	reviewComment, err := findReviewComment(ctx, git, pr, data.RFCIdentifier, *login, *previous)
+	if err != nil {
+		return nil, err
+	}
+
+	// propagate updated RFC to the repo, then the provider comment
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
+	}
+	if reviewComment != nil {
+		if err = git.EditReviewComment(ctx, pr, reviewComment.ID, data.Comment); err != nil {
+			return nil, err
+		}
+	}
+
+	publishEvent(models.CommentEvent, data.RFCIdentifier, *login, "edited a comment", rfc)
+
+	message := fmt.Sprintf("Successfully edited comment %s of RFC %s", data.Signature, data.RFCIdentifier)
+	return &message, nil
+}
+
+// DeleteComment deletes a comment the authenticated user made on an RFC, both from the RFC and from the provider
+// review comments
+func DeleteComment(ctx context.Context, git exGit.Git, data *models.DeleteComment) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.DeleteComment", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var login *string
+	var rfc *models.RFC
+	var text *string
+
+	// retrieve PR, current user and RFC
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	if login, err = userLogin(ctx, git); err != nil {
+		return nil, err
+	}
+	metadata.SetUser(ctx, *login)
+	if rfc, err = readRFC(ctx, git, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// delete the comment action, only its author may
+	if text, err = rfc.DeleteComment(data.Signature, *login); err != nil {
+		logging.FromContext(ctx).Warn("unable to delete comment of RFC", "action", data.Signature, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	reviewComment, err := findReviewComment(ctx, git, pr, data.RFCIdentifier, *login, *text)
+	if err != nil {
+		return nil, err
+	}
+
+	// propagate updated RFC to the repo, then the provider comment
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
+	}
+	if reviewComment != nil {
+		if err = git.DeleteReviewComment(ctx, pr, reviewComment.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	publishEvent(models.CommentEvent, data.RFCIdentifier, *login, "deleted a comment", rfc)
+
+	message := fmt.Sprintf("Successfully deleted comment %s of RFC %s", data.Signature, data.RFCIdentifier)
+	return &message, nil
+}
+
+// findReviewComment returns the provider review comment the given author made on the RFC file with the given text, nil
+// is returned if there is none, e.g. because it was removed through the provider
+func findReviewComment(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfcIdentifier string, author string,
+	text string) (*exGit.ReviewComment, error) {
+	comments, err := git.GetReviewComments(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", exGit.BASE_RFC_DIRECTORY_NAME, rfcIdentifier, exGit.RFC_FILE_NAME)
+	for _, comment := range comments {
+		if comment.Author == author && comment.Path == path && comment.Body == text {
+			return &comment, nil
+		}
+	}
+
+	logging.FromContext(ctx).Info("no review comment of RFC matches the comment, only the RFC is changed",
+		"author", author)
+	return nil, nil
+}
+
+// Authorize returns models.ErrNotPermitted (wrapped) unless the user of the given client is granted the given
+// permission by the authorization policy, through one of their teams. Every permission is granted if no policy is
+// configured
+func Authorize(ctx context.Context, git exGit.Git, permission models.Permission) error {
+	ctx, span := tracing.Start(ctx, "controllers.Authorize", tracing.PERMISSION_KEY.String(string(permission)))
+	defer span.End()
+
+	policy := authz.Default
+	if policy == nil {
+		return nil
+	}
+
+	teams, err := userTeams(ctx, git, policy)
+	if err != nil {
+		return err
+	}
+
+	if err := policy.Authorize(teams, permission); err != nil {
+		logging.FromContext(ctx).Warn("request not permitted", "permission", permission, logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// userTeams returns the teams of the user making the request of the given context. The client of a user authenticated
+// through SSO is shared by every user, so their teams are the teams of the given policy listing their login as a
+// member, none if there is no policy
+func userTeams(ctx context.Context, git exGit.Git, policy *authz.Policy) (set.Set[string], error) {
+	if identity := oidc.FromContext(ctx); identity != nil {
+		if policy == nil {
+			return set.NewSet[string](), nil
+		}
+		teams, ok := ssoTeamsCache.Get(identity.Login)
+		if !ok {
+			teams = set.NewSet[string]()
+			for team := range policy.Teams {
+				members, err := git.GetTeamMembers(ctx, team)
+				if err != nil {
+					return nil, err
+				}
+				if members.Contains(identity.Login) {
+					teams.Add(team)
+				}
+			}
+			ssoTeamsCache.Set(identity.Login, teams)
+		}
+		return teams, nil
+	}
+
+	teams, ok := userTeamsCache.Get(git)
+	if !ok {
+		var err error
+		if teams, err = git.GetUserTeams(ctx); err != nil {
+			return nil, err
+		}
+		userTeamsCache.Set(git, teams)
+	}
+	return teams, nil
+}
+
+// CheckTenantMember returns tenants.ErrCrossTenant (wrapped) unless the user making the request of the given context
+// is a member of one of the member teams of the tenant of the given schema domain, whose members are read through the
+// given client, so the shared token of a tenant is not used for users of another tenant naming its schema domain. Only
+// users authenticated through SSO are known without a token of their own, so other users are never members
+func CheckTenantMember(ctx context.Context, git exGit.Git, domain string) error {
+	if tenants.Default == nil {
+		return nil
+	}
+	tenant, ok := tenants.Default.Get(domain)
+	if !ok {
+		return nil
+	}
+
+	teams := set.NewSet[string]()
+	if identity := oidc.FromContext(ctx); identity != nil {
+		key := fmt.Sprintf("%s/%s", domain, identity.Login)
+		cached, ok := memberTeamsCache.Get(key)
+		if !ok {
+			cached = set.NewSet[string]()
+			for _, team := range tenant.Members {
+				members, err := git.GetTeamMembers(ctx, team)
+				if err != nil {
+					return err
+				}
+				if members.Contains(identity.Login) {
+					cached.Add(team)
+				}
+			}
+			memberTeamsCache.Set(key, cached)
+		}
+		teams = cached
+	}
+
+	if err := tenants.Default.CheckMember(domain, teams); err != nil {
+		logging.FromContext(ctx).Warn("rejected shared token of another tenant", "domain", domain, logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// CheckReadiness validates that each of the given git clients, keyed by token name, has the permissions Harmonia
+// requires. Tokens that could not be configured are reported with the given setup errors. The service is only ready if
+// every token is configured and sufficient
+func CheckReadiness(ctx context.Context, clients map[string]exGit.Git, setupErrors map[string]error) *models.Readiness {
+	ctx, span := tracing.Start(ctx, "controllers.CheckReadiness")
+	defer span.End()
+
+	readiness := &models.Readiness{Ready: true, Tokens: []models.TokenCheck{}}
+
+	for token, err := range setupErrors {
+		readiness.Tokens = append(readiness.Tokens, models.TokenCheck{Token: token, Error: err.Error()})
+	}
+	for token, git := range clients {
+		check, ok := tokenCheckCache.Get(token)
+		if !ok {
+			check = models.TokenCheck{Token: token}
+			if missing, err := git.GetMissingPermissions(ctx); err != nil {
+				check.Error = err.Error()
+			} else {
+				check.Missing = missing
+				// only conclusive results are cached so transient errors are retried
+				tokenCheckCache.Set(token, check)
+			}
+		}
+		readiness.Tokens = append(readiness.Tokens, check)
+	}
+
+	sort.Slice(readiness.Tokens, func(i, j int) bool { return readiness.Tokens[i].Token < readiness.Tokens[j].Token })
+	for _, check := range readiness.Tokens {
+		readiness.Ready = readiness.Ready && check.Error == "" && len(check.Missing) == 0
+	}
+	readiness.WarmingUp = warmingUp.Load()
+	readiness.Ready = readiness.Ready && !readiness.WarmingUp
+
+	return readiness
+}
+
+// CheckBranchProtection compares the protection of the base branch of the tracking repository of the given schema
+// domain, read through the given client, to the protection Harmonia relies on: the given status contexts and number
+// of approvals must be required before merging. Drift weakens the guarantees of reviews and status checks without
+// preventing Harmonia from working, so it is reported rather than enforced
+func CheckBranchProtection(ctx context.Context, git exGit.Git, domain string, requiredContexts []string,
+	requiredApprovals int) models.ProtectionCheck {
+	ctx, span := tracing.Start(ctx, "controllers.CheckBranchProtection")
+	defer span.End()
+
+	if check, ok := protectionCheckCache.Get(domain); ok {
+		return check
+	}
+
+	check := models.ProtectionCheck{Domain: domain}
+	if protection, err := git.GetBranchProtection(ctx); err != nil {
+		check.Error = err.Error()
+	} else {
+		check.Branch = protection.Branch
+		check.Drift = protection.Drift(requiredContexts, requiredApprovals)
+		// only conclusive results are cached so transient errors are retried
+		protectionCheckCache.Set(domain, check)
+	}
+
+	return check
+}
+
+// StartWarmUp marks the startup warm-up as in progress, the service does not report ready until the returned function
+// is called to mark it complete, see CheckReadiness
+func StartWarmUp() func() {
+	warmingUp.Store(true)
+	return func() { warmingUp.Store(false) }
+}
+
+// WarmUp primes the caches of the tracking repository of the given client, so the first requests do not pay for it
+// An error is returned if the open RFCs of the repository cannot be listed, e.g. it does not exist or cannot be read
+func WarmUp(ctx context.Context, git exGit.Git) error {
+	ctx, span := tracing.Start(ctx, "controllers.WarmUp")
+	defer span.End()
+
+	_, err := cachedOpenPullRequests(ctx, git)
+	return err
+}
+
+// GetLinks returns the provider URLs of the given RFC, the tag is only linked if the RFC was tagged
+// The pull request is looked up on a best-effort basis, because links are supplementary to the calling operation
+func GetLinks(ctx context.Context, git exGit.Git, rfcIdentifier string, tagged bool) *models.Links {
+	ctx, span := tracing.Start(ctx, "controllers.GetLinks", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	pr, err := git.GetPullRequest(ctx, rfcIdentifier)
+	if err != nil {
+		logging.FromContext(ctx).Info("unable to retrieve pull request for RFC links",
+			logging.RFC_IDENTIFIER_KEY, rfcIdentifier, logging.ERROR_KEY, err)
+		pr = nil
+	}
+
+	return git.BuildLinks(rfcIdentifier, pr, tagged)
+}
+
+// TestNotification renders a sample event of the requested type and delivers it on the requested channel so that
+// templates and channel configuration can be verified, the delivered text is returned
+func TestNotification(ctx context.Context, git exGit.Git, data *models.TestNotification) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.TestNotification")
+	defer span.End()
+
+	rfcIdentifier := data.RFCIdentifier
+	if rfcIdentifier == "" {
+		rfcIdentifier = SAMPLE_RFC_IDENTIFIER
+	}
+	event := models.Event{
+		Type:          data.Type,
+		RFCIdentifier: rfcIdentifier,
+		Actor:         currentUser(ctx, git),
+		Message:       "test notification",
+		Timestamp:     time.Now().UTC(),
+	}
+
+	notification, err := notify.Default.Send(ctx, data.Channel, event)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to send test notification", "channel", data.Channel, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return &notification.Body, nil
+}
+
+// GetActivity returns a feed of recent RFC lifecycle events, newest first, based on given data filtering
+func GetActivity(ctx context.Context, git exGit.Git, data *models.Activity) ([]models.Event, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetActivity")
+	defer span.End()
+
+	filters := []events.Filter{events.WithActor(data.User), events.WithTypes(data.Types)}
+
+	// restrict to members of the requested team
+	if data.Team != nil {
+		members, err := git.GetTeamMembers(ctx, *data.Team)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, events.WithActorIn(members))
+	}
+
+	limit := data.Limit
+	if limit == 0 {
+		limit = DEFAULT_ACTIVITY_LIMIT
+	}
+
+	return events.Default.Recent(limit, filters...), nil
+}
+
+// StreamEvents returns a channel receiving the RFC lifecycle events published from now on about the given RFC and of
+// any of the given types, every RFC and type if none are given. The channel is closed once the given context is done
+func StreamEvents(ctx context.Context, rfcIdentifier *string, types []models.EventType) <-chan models.Event {
+	return events.Stream(ctx, events.Default, events.DEFAULT_STREAM_BUFFER, events.WithRFCIdentifier(rfcIdentifier),
+		events.WithTypes(types))
+}
+
+// MyWork returns the open RFCs that require the attention of the authenticated user: RFCs they authored that need
+// changes, RFCs awaiting their review and RFCs they authored whose load failed
+func MyWork(ctx context.Context, git exGit.Git) (*models.MyWork, error) {
+	ctx, span := tracing.Start(ctx, "controllers.MyWork")
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var login *string
+	var prs exGit.PullRequests
+	work := &models.MyWork{
+		NeedsChanges:   []models.RFCReference{},
+		AwaitingReview: []models.RFCReference{},
+		FailedLoads:    []models.RFCReference{},
+	}
+
+	// retrieve the user and the teams they can review on behalf of
+	if login, err = userLogin(ctx, git); err != nil {
+		return nil, err
+	}
+	metadata.SetUser(ctx, *login)
+	teams, err := userTeams(ctx, git, authz.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve all open RFCs
+	if prs, err = cachedOpenPullRequests(ctx, git); err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		reference := models.RFCReference{RFCIdentifier: details.RFCIdentifier, Title: details.Title}
+
+		// RFCs authored by someone else only matter if the user or one of their teams was asked to review
+		submitter, err := cachedSubmitter(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		if submitter != *login {
+			awaiting := false
+			for _, reviewer := range details.RequestedReviewers {
+				awaiting = awaiting || reviewer == *login
+			}
+			for _, team := range details.RequestedTeams {
+				awaiting = awaiting || teams.Contains(team)
+			}
+			if awaiting {
+				work.AwaitingReview = append(work.AwaitingReview, reference)
+			}
+			continue
+		}
+
+		// RFCs authored by the user need changes if any reviewer's latest review requested them
+		reviews, err := cachedReviewDetails(ctx, git, pr, details)
+		if err != nil {
+			return nil, err
+		}
+		sealed, err := cachedSealedReviews(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		changesRequested := latestReviewStates(reviews).Contains(exGit.CHANGES_REQUESTED_STATE)
+		for _, base := range sealed {
+			changesRequested = changesRequested || base == models.RequestChangesReview
+		}
+		if changesRequested {
+			work.NeedsChanges = append(work.NeedsChanges, reference)
+		}
+
+		// RFCs authored by the user whose last load failed, on any of its targets
+		status, err := cachedLoadStatus(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		if status == FAILED_STATUS || status == PARTIAL_STATUS {
+			work.FailedLoads = append(work.FailedLoads, reference)
+		}
+	}
+
+	return work, nil
+}
+
+// GetHeldComments returns the comments held for moderation, oldest first
+func GetHeldComments() *models.HeldComments {
+	if moderation.Default == nil {
+		return &models.HeldComments{Comments: []models.HeldComment{}}
+	}
+	return &models.HeldComments{Comments: moderation.Default.Held()}
+}
+
+// GetDeadLetters returns the notifications whose delivery was given up on, oldest first
+func GetDeadLetters(ctx context.Context) (*models.DeadLetters, error) {
+	letters, err := notify.Default.DeadLetters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DeadLetters{DeadLetters: letters}, nil
+}
+
+// RedeliverDeadLetter delivers the dead letter with the given ID again on the channel it was given up on, or discards
+// it. The dead letter is dropped once delivered or discarded. A message describing the outcome is returned
+// models.ErrDeadLetterNotFound is returned (wrapped) if there is no dead letter with the given ID
+func RedeliverDeadLetter(ctx context.Context, data *models.RedeliverDeadLetter) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.RedeliverDeadLetter")
+	defer span.End()
+
+	if data.Discard {
+		if err := notify.Default.Discard(ctx, data.ID); err != nil {
+			return nil, err
+		}
+		message := fmt.Sprintf("Dead letter %s discarded", data.ID)
+		return &message, nil
+	}
+
+	if err := notify.Default.Redeliver(ctx, data.ID); err != nil {
+		logging.FromContext(ctx).Error("unable to redeliver dead letter", "id", data.ID, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	message := fmt.Sprintf("Dead letter %s redelivered", data.ID)
+	return &message, nil
+}
+
+// GetReservations returns the reservations of target descriptors that have not expired, oldest first
+func GetReservations(ctx context.Context) (*models.Reservations, error) {
+	held, err := reservations.Default.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := []models.Reservation{}
+	for _, reservation := range held {
+		if !reservation.Expired(now) {
+			active = append(active, reservation)
+		}
+	}
+	return &models.Reservations{Reservations: active}, nil
+}
+
+// ReleaseReservation releases the reservation of the given target descriptor of the given schema domain, so that any
+// team can add to it again. Only members of the team holding the reservation can release it
+// models.ErrReservationNotFound is returned (wrapped) if the descriptor is not reserved, and
+// models.ErrNotReservationTeam (wrapped) if the user making the request is not a member of the team
+func ReleaseReservation(ctx context.Context, git exGit.Git, data *models.ReleaseReservation) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.ReleaseReservation")
+	defer span.End()
+
+	held, err := reservations.Default.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var reservation *models.Reservation
+	for i := range held {
+		if held[i].Domain == data.Domain && held[i].TargetDescriptor == data.TargetDescriptor &&
+			!held[i].Expired(time.Now()) {
+			reservation = &held[i]
+		}
+	}
+	if reservation == nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrReservationNotFound, data.TargetDescriptor)
+	}
+
+	member, err := isTeamMember(ctx, git, reservation.Team)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		logging.FromContext(ctx).Warn("reservation released by a user outside of its team", "targetDescriptor",
+			data.TargetDescriptor, "team", reservation.Team)
+		return nil, fmt.Errorf("%w: %s", models.ErrNotReservationTeam, reservation.Team)
+	}
+
+	if err = reservations.Default.Delete(ctx, data.Domain, data.TargetDescriptor); err != nil {
+		logging.FromContext(ctx).Error("unable to release reservation", "targetDescriptor", data.TargetDescriptor,
+			logging.ERROR_KEY, err)
+		return nil, err
+	}
+	message := fmt.Sprintf("Reservation of %s released", data.TargetDescriptor)
+	return &message, nil
+}
+
+// GetHeldComment returns the comment held for moderation with the given ID
+// models.ErrHeldCommentNotFound is returned (wrapped) if no comment is held with it
+func GetHeldComment(id string) (*models.HeldComment, error) {
+	if moderation.Default == nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrHeldCommentNotFound, id)
+	}
+	return moderation.Default.Get(id)
+}
+
+// ModerateComment approves or discards the comment held for moderation with the given ID. An approved comment is
+// added to its RFC, attributed to its commenter, through the given Git client of the domain of the RFC. The comment
+// stops being held once it is added or discarded. A message describing the outcome is returned
+func ModerateComment(ctx context.Context, git exGit.Git, data *models.ModerateComment) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.ModerateComment")
+	defer span.End()
+
+	held, err := GetHeldComment(data.ID)
+	if err != nil {
+		return nil, err
+	}
+	metadata.SetRFCIdentifier(ctx, held.RFCIdentifier)
+
+	if !data.Approve {
+		moderation.Default.Release(held.ID)
+		message := fmt.Sprintf("Discarded comment %s of %s on RFC %s", held.ID, held.Commenter, held.RFCIdentifier)
+		return &message, nil
+	}
+
+	pr, err := git.GetPullRequest(ctx, held.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	rfc, err := readRFC(ctx, git, held.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	if err = rfc.AddComments(map[string][]string{held.Target: {held.Comment}}, held.Commenter); err != nil {
+		return nil, err
+	}
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, err
+	}
+	moderation.Default.Release(held.ID)
+
+	message := fmt.Sprintf("Added comment %s of %s to RFC %s", held.ID, held.Commenter, held.RFCIdentifier)
+	return &message, nil
+}
+
+// RebuildRequest restores the RFC file of the given RFC from the most recent revision in its pull request's commit
+// history that can be decoded and whose signatures match its content. This repairs RFC files that were deleted,
+// corrupted or tampered with by hand in the tracking repository. A message describing the outcome is returned
+func RebuildRequest(ctx context.Context, git exGit.Git, data *models.Rebuild) (*string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.RebuildRequest", tracing.RFC_IDENTIFIER_KEY.String(data.RFCIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var revisions []exGit.RFCRevision
+
+	// get corresponding pr so the file can be restored on its branch
+	if pr, err = git.GetPullRequest(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+
+	// nothing to do if the current file is intact
+	rfc, err := readRFC(ctx, git, data.RFCIdentifier)
+	if err == nil {
+		err = verifyRFC(ctx, data.RFCIdentifier, rfc)
+	}
+	var integrityErr *models.IntegrityError
+	if err == nil {
+		message := fmt.Sprintf("RFC %s file is intact, no rebuild necessary", data.RFCIdentifier)
+		return &message, nil
+	} else if !errors.As(err, &integrityErr) {
+		return nil, err
+	}
+
+	// walk the history of the file, newest first, for the latest revision that decodes and verifies
+	if revisions, err = git.GetRFCHistory(ctx, data.RFCIdentifier); err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		content, err := git.GetRFCContentsAt(ctx, data.RFCIdentifier, revision.Sha)
+		if err != nil {
+			// the revision that deleted the file has no content
+			if errors.Is(err, exGit.ErrRFCFileNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		restored, err := decodeRFC(ctx, data.RFCIdentifier, content)
+		if err == nil {
+			err = verifyRFC(ctx, data.RFCIdentifier, restored)
+		}
+		if err != nil {
+			logging.FromContext(ctx).Info("skipping revision of RFC during rebuild",
+				"revision", revision.Sha, logging.ERROR_KEY, err)
+			continue
+		}
+
+		// restore the revision
+		commitMessage := fmt.Sprintf("rebuild from %s.", revision.Sha)
+		if err = git.RestoreFile(ctx, pr, *content, commitMessage); err != nil {
+			return nil, err
+		}
+		publishEvent(models.RebuildEvent, data.RFCIdentifier, currentUser(ctx, git),
+			fmt.Sprintf("restored %s file from revision %s", integrityErr.Reason, revision.Sha), restored)
+
+		message := fmt.Sprintf("Successfully rebuilt RFC %s file from revision %s", data.RFCIdentifier, revision.Sha)
+		return &message, nil
+	}
+
+	errStr := "no valid revision of RFC %s found in its commit history, it cannot be rebuilt"
+	logging.FromContext(ctx).Error(fmt.Sprintf(errStr, data.RFCIdentifier))
+	return nil, fmt.Errorf(errStr, data.RFCIdentifier)
+}
+
+// MigrateSignatures checks the signature of every open RFC against the signature of its canonical form, which leaves
+// volatile fields out. RFCs signed before signature versions were introduced are signed again at the current version
+// unless this is a dry run, RFCs whose signature matches none of the forms they may have been signed as are reported
+// rather than signed again
+// Merged RFCs are not migrated, as their files are no longer changed through pull requests
+func MigrateSignatures(ctx context.Context, git exGit.Git, data *models.MigrateSignatures) (*models.SignatureMigration,
+	error) {
+	ctx, span := tracing.Start(ctx, "controllers.MigrateSignatures")
+	defer span.End()
+
+	prs, err := git.GetPullRequests(ctx, exGit.OPEN_STATE, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	migration := &models.SignatureMigration{Valid: []string{}, Migrated: []string{}, Invalid: []string{},
+		Unreadable: []string{}, DryRun: data.DryRun}
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+		if err != nil {
+			var integrityErr *models.IntegrityError
+			if !errors.As(err, &integrityErr) {
+				return nil, err
+			}
+			migration.Unreadable = append(migration.Unreadable, details.RFCIdentifier)
+			continue
+		}
+
+		state, err := rfc.CheckSignature()
+		if err != nil {
+			return nil, err
+		}
+		switch state {
+		case models.ValidSignature:
+			migration.Valid = append(migration.Valid, details.RFCIdentifier)
+		case models.InvalidSignature:
+			logging.FromContext(ctx).Warn("RFC signature matches none of the forms it may have been signed as",
+				logging.RFC_IDENTIFIER_KEY, details.RFCIdentifier)
+			migration.Invalid = append(migration.Invalid, details.RFCIdentifier)
+		case models.LegacySignature:
+			if !data.DryRun {
+				if err = rfc.Sign(); err != nil {
+					return nil, err
+				}
+				if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+					return nil, err
+				}
+			}
+			migration.Migrated = append(migration.Migrated, details.RFCIdentifier)
+		}
+	}
+
+	return migration, nil
+}
+
+// Backup exports the state of the tracking repository of the given client needed to rebuild its open RFCs should it be
+// lost: the RFC file and pull request of each open RFC, the action data schemas and the archive index of its base
+// branch. Open RFCs whose file is missing are left out, merged RFCs are kept by the tags they were merged under
+func Backup(ctx context.Context, git exGit.Git) (*models.Backup, error) {
+	ctx, span := tracing.Start(ctx, "controllers.Backup")
+	defer span.End()
+
+	repository := git.Repository()
+	domain, _ := exGit.ClientDomain(git)
+	backup := &models.Backup{
+		Version:    models.BACKUP_VERSION,
+		CreatedAt:  time.Now().UTC(),
+		Domain:     domain,
+		Repository: fmt.Sprintf("%s/%s", repository.Owner, repository.Name),
+		RFCs:       []models.BackupRFC{},
+		Schemas:    map[string]string{},
+	}
+
+	prs, err := git.GetPullRequests(ctx, exGit.OPEN_STATE, -1)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		content, _, err := git.GetRFCContents(ctx, details.RFCIdentifier)
+		if errors.Is(err, exGit.ErrRFCFileNotFound) {
+			logging.FromContext(ctx).Warn("RFC file is missing, it is left out of the backup",
+				logging.RFC_IDENTIFIER_KEY, details.RFCIdentifier)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		rfc := models.BackupRFC{
+			RFCIdentifier: details.RFCIdentifier,
+			PullRequest: models.BackupPullRequest{
+				Number:             details.Number,
+				Title:              details.Title,
+				Author:             details.Author,
+				CreatedAt:          details.CreatedAt,
+				Labels:             details.Labels,
+				RequestedReviewers: details.RequestedReviewers,
+				RequestedTeams:     details.RequestedTeams,
+			},
+		}
+		if content != nil {
+			rfc.Content = *content
+		}
+		backup.RFCs = append(backup.RFCs, rfc)
+	}
+	sort.Slice(backup.RFCs, func(i, j int) bool {
+		return backup.RFCs[i].RFCIdentifier < backup.RFCs[j].RFCIdentifier
+	})
+
+	if schemas.Directory != "" {
+		if backup.Schemas, err = git.GetSchemas(ctx, schemas.Directory); err != nil {
+			return nil, err
+		}
+	}
+	if index, err := git.GetArchiveIndex(ctx); err != nil {
+		return nil, err
+	} else if index != nil {
+		backup.ArchiveIndex = *index
+	}
+
+	return backup, nil
+}
+
+// Restore rebuilds the open RFCs of the given backup in the tracking repository of the given client, typically a new
+// one replacing a lost tracking repository: each RFC gets its branch, RFC file and pull request back, the pull request
+// keeping its title, labels and requested reviewers and noting the pull request it replaces. RFCs whose branch exists
+// are skipped so a restore can be run again, and RFCs that cannot be restored are reported rather than failing the
+// others. Nothing is written on a dry run. The schemas and archive index of the backup are not restored
+func Restore(ctx context.Context, git exGit.Git, data *models.RestoreBackup) (*models.Restoration, error) {
+	ctx, span := tracing.Start(ctx, "controllers.Restore")
+	defer span.End()
+
+	if data.Backup.Version != models.BACKUP_VERSION {
+		return nil, fmt.Errorf("%w: version %d, expected version %d", models.ErrUnsupportedBackup,
+			data.Backup.Version, models.BACKUP_VERSION)
+	}
+
+	restoration := &models.Restoration{Restored: []string{}, Skipped: []string{}, Failed: []models.RestoreFailure{},
+		DryRun: data.DryRun}
+	fail := func(rfcIdentifier string, err error) {
+		logging.FromContext(ctx).Error("unable to restore RFC", logging.RFC_IDENTIFIER_KEY, rfcIdentifier,
+			logging.ERROR_KEY, err)
+		restoration.Failed = append(restoration.Failed, models.RestoreFailure{RFCIdentifier: rfcIdentifier,
+			Error: err.Error()})
+	}
+	for _, backedUp := range data.Backup.RFCs {
+		rfc, err := decodeRFC(ctx, backedUp.RFCIdentifier, &backedUp.Content)
+		if err == nil {
+			// RFCs of a tenant can only be tracked in the tracking repository of the tenant
+			err = checkTenant(ctx, git, rfc)
+		}
+		if err != nil {
+			fail(backedUp.RFCIdentifier, err)
+			continue
+		}
+		if data.DryRun {
+			restoration.Restored = append(restoration.Restored, backedUp.RFCIdentifier)
+			continue
+		}
+
+		if err = git.CreateBranch(ctx, backedUp.RFCIdentifier, exGit.BASE_BRANCH); errors.Is(err, exGit.ErrConflict) {
+			restoration.Skipped = append(restoration.Skipped, backedUp.RFCIdentifier)
+			continue
+		} else if err != nil {
+			fail(backedUp.RFCIdentifier, err)
+			continue
+		}
+		if err = git.CreateFile(ctx, backedUp.RFCIdentifier, backedUp.RFCIdentifier, rfc); err != nil {
+			fail(backedUp.RFCIdentifier, err)
+			if revErr := git.DeleteBranch(ctx, backedUp.RFCIdentifier); revErr == nil {
+				logging.FromContext(ctx).Info("successfully revoked RFC")
+			}
+			continue
+		}
+
+		options := pullRequestOptions(ctx, git, backedUp.RFCIdentifier, rfc)
+		if backedUp.PullRequest.Title != "" {
+			options.Title = backedUp.PullRequest.Title
+		}
+		options.Labels = backedUp.PullRequest.Labels
+		options.Reviewers = backedUp.PullRequest.RequestedReviewers
+		options.TeamReviewers = backedUp.PullRequest.RequestedTeams
+		options.Description = strings.TrimSpace(fmt.Sprintf("%s\n\nRestored from pull request #%d of %s, opened by %s "+
+			"on %s.", options.Description, backedUp.PullRequest.Number, data.Backup.Repository,
+			backedUp.PullRequest.Author, backedUp.PullRequest.CreatedAt.UTC().Format(time.RFC3339)))
+		if err = git.CreatePullRequest(ctx, backedUp.RFCIdentifier, exGit.BASE_BRANCH, options); err != nil {
+			fail(backedUp.RFCIdentifier, err)
+			if revErr := git.DeleteBranch(ctx, backedUp.RFCIdentifier); revErr == nil {
+				logging.FromContext(ctx).Info("successfully revoked RFC")
+			}
+			continue
+		}
+		restoration.Restored = append(restoration.Restored, backedUp.RFCIdentifier)
+	}
+
+	// the restored RFCs are listed anew
+	rfcsCache.Clear()
+
+	return restoration, nil
+}
+
+// GenerateTypes renders the action data schemas of the tracking repository of the given client into Go structs and
+// TypeScript interfaces, see codegen.Render, and commits them to the base branch of the given repository, in the
+// configured directory or in a directory named after the schema domain of the client within it. Schema files that
+// cannot be rendered are reported rather than failing the others. Nothing is committed on a dry run, the generated
+// files being returned instead, or if the files are unchanged
+func GenerateTypes(ctx context.Context, git exGit.Git, output exGit.Git, data *models.GenerateTypes) (
+	*models.GeneratedTypes, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GenerateTypes")
+	defer span.End()
+
+	if schemas.Directory == "" {
+		return nil, fmt.Errorf("types cannot be generated, no schema directory is configured")
+	}
+	files, err := git.GetSchemas(ctx, schemas.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, _ := exGit.ClientDomain(git)
+	repository := output.Repository()
+	generated := &models.GeneratedTypes{Domain: domain, Repository: fmt.Sprintf("%s/%s", repository.Owner,
+		repository.Name), Files: []string{}, Failures: map[string]string{}, DryRun: data.DryRun}
+	rendered, failures := codegen.Render(files, codegen.GoPackage)
+	for name, err := range failures {
+		logging.FromContext(ctx).Warn("unable to render schema file", "file", name, logging.ERROR_KEY, err)
+		generated.Failures[name] = err.Error()
+	}
+
+	directory := strings.Trim(strings.Join([]string{codegen.Directory, domain}, "/"), "/")
+	contents := map[string]string{}
+	for name, content := range rendered {
+		path := name
+		if directory != "" {
+			path = directory + "/" + name
+		}
+		contents[path] = content
+		generated.Files = append(generated.Files, path)
+	}
+	sort.Strings(generated.Files)
+	if data.DryRun {
+		generated.Contents = contents
+		return generated, nil
+	}
+
+	source := git.Repository()
+	message := fmt.Sprintf("Generate types from the action data schemas of %s/%s", source.Owner, source.Name)
+	if generated.Committed, err = output.CommitFiles(ctx, contents, message); err != nil {
+		return nil, err
+	}
+
+	return generated, nil
+}
+
+// BuildDigests summarizes, for each team, the open RFCs awaiting the team's review, the open RFCs authored by team
+// members whose load failed and the RFCs merged since the given time that change targets the team owns
+// Teams with nothing to report are omitted, the digests are sorted by team
+func BuildDigests(ctx context.Context, git exGit.Git, since time.Time) ([]models.Digest, error) {
+	ctx, span := tracing.Start(ctx, "controllers.BuildDigests")
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var prs exGit.PullRequests
+	digests := map[string]*models.Digest{}
+	digestFor := func(team string) *models.Digest {
+		if _, ok := digests[team]; !ok {
+			digests[team] = &models.Digest{
+				Team:           team,
+				Since:          since,
+				AwaitingReview: []models.RFCReference{},
+				FailedLoads:    []models.RFCReference{},
+				NewlyMerged:    []models.RFCReference{},
+			}
+		}
+		return digests[team]
+	}
+
+	// open RFCs awaiting review and failed loads
+	if prs, err = cachedOpenPullRequests(ctx, git); err != nil {
+		return nil, err
+	}
+	failedAuthors := map[string][]models.RFCReference{}
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		reference := models.RFCReference{RFCIdentifier: details.RFCIdentifier, Title: details.Title}
+
+		for _, team := range details.RequestedTeams {
+			digest := digestFor(team)
+			digest.AwaitingReview = append(digest.AwaitingReview, reference)
+		}
+
+		status, err := cachedLoadStatus(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		if status == FAILED_STATUS || status == PARTIAL_STATUS {
+			submitter, err := cachedSubmitter(ctx, git, details)
+			if err != nil {
+				return nil, err
+			}
+			failedAuthors[submitter] = append(failedAuthors[submitter], reference)
+		}
+	}
+
+	// newly merged RFCs in each team's ownership area
+	merged := true
+	filters := []exGit.FilterOption{git.IsMerged(&merged)}
+	if prs, err = git.GetPullRequests(ctx, exGit.CLOSED_STATE, DIGEST_MERGED_SCAN_COUNT, filters...); err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		if details.MergedAt.Before(since) {
+			continue
+		}
+
+		// an RFC whose file cannot be read is skipped rather than failing every team's digest
+		rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+		if err != nil {
+			logging.FromContext(ctx).Info("skipping merged RFC in digests", logging.RFC_IDENTIFIER_KEY, details.RFCIdentifier,
+				logging.ERROR_KEY, err)
+			continue
+		}
+		reference := models.RFCReference{RFCIdentifier: details.RFCIdentifier, Title: details.Title}
+		for _, team := range ownership.Default.OwnersOf(rfc).Values() {
+			digest := digestFor(team)
+			digest.NewlyMerged = append(digest.NewlyMerged, reference)
+		}
+	}
+
+	// failed loads are attributed to every known team the author is a member of
+	if len(failedAuthors) > 0 {
+		teams := ownership.Default.Teams()
+		for team := range digests {
+			teams.Add(team)
+		}
+		for _, team := range teams.Values() {
+			members, err := git.GetTeamMembers(ctx, team)
+			if err != nil {
+				return nil, err
+			}
+			for author, references := range failedAuthors {
+				if members.Contains(author) {
+					digest := digestFor(team)
+					digest.FailedLoads = append(digest.FailedLoads, references...)
+				}
+			}
+		}
+	}
+
+	results := []models.Digest{}
+	for _, digest := range digests {
+		if !digest.IsEmpty() {
+			results = append(results, *digest)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Team < results[j].Team })
+
+	return results, nil
+}
+
+// SendDigests builds the digests covering the last DIGEST_PERIOD and sends them on the configured notification
+// channels the schema domain of the tracking repository may use, a failure to deliver one team's digest does not
+// prevent delivery of the others
+func SendDigests(ctx context.Context, git exGit.Git) error {
+	ctx, span := tracing.Start(ctx, "controllers.SendDigests")
+	defer span.End()
+
+	digests, err := BuildDigests(ctx, git, time.Now().Add(-DIGEST_PERIOD))
+	if err != nil {
+		return err
+	}
+
+	domain, _ := exGit.ClientDomain(git)
+	for _, digest := range digests {
+		digest.Domain = domain
+		if err = notify.Default.SendDigest(ctx, digest); err != nil {
+			logging.FromContext(ctx).Error("unable to send digest", logging.ERROR_KEY, err)
+		}
+	}
+
+	return nil
+}
+
+// GetReviewStats returns the review workload of each reviewer and team of the tracking repository of the given client,
+// how quickly they first respond to review requests and the pending requests breaching the review SLA
+func GetReviewStats(ctx context.Context, git exGit.Git) (*models.ReviewStats, error) {
+	ctx, span := tracing.Start(ctx, "controllers.GetReviewStats")
+	defer span.End()
+
+	requests, err := reviewRequests(ctx, git)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := models.NewReviewStats(requests, time.Now(), reviewrequests.SLA, reviewrequests.WINDOW)
+	return &stats, nil
+}
+
+// SendReviewReminders reminds each reviewer and team of the tracking repository of the given client of their pending
+// review requests awaited past the review SLA, on the configured notification channels its schema domain may use
+// Requests awaited for reviewrequests.ESCALATION_FACTOR times the SLA are escalated. A failure to deliver one reminder
+// does not prevent delivery of the others
+func SendReviewReminders(ctx context.Context, git exGit.Git) error {
+	ctx, span := tracing.Start(ctx, "controllers.SendReviewReminders")
+	defer span.End()
+
+	requests, err := reviewRequests(ctx, git)
+	if err != nil {
+		return err
+	}
+
+	// requests are listed oldest first, so each reminder lists the longest awaited first
+	now := time.Now()
+	domain, _ := exGit.ClientDomain(git)
+	var order []string
+	reminders := map[string]*models.ReviewReminder{}
+	for _, request := range requests {
+		if request.RespondedAt != nil || !request.Breached(now, reviewrequests.SLA) {
+			continue
+		}
+		k := reviewerKey(request.Reviewer, request.Team)
+		reminder, ok := reminders[k]
+		if !ok {
+			reminder = &models.ReviewReminder{Domain: domain, Reviewer: request.Reviewer, Team: request.Team,
+				SLA: reviewrequests.SLA.String(), Overdue: []models.ReviewRequest{}, Escalated: []models.ReviewRequest{}}
+			reminders[k] = reminder
+			order = append(order, k)
+		}
+		if request.ResponseTime(now) >= reviewrequests.ESCALATION_FACTOR*reviewrequests.SLA {
+			reminder.Escalated = append(reminder.Escalated, request)
+		} else {
+			reminder.Overdue = append(reminder.Overdue, request)
+		}
+	}
+
+	sort.Strings(order)
+	for _, k := range order {
+		if err = notify.Default.SendReviewReminder(ctx, *reminders[k]); err != nil {
+			logging.FromContext(ctx).Error("unable to send review reminder", logging.ERROR_KEY, err)
+		}
+	}
+
+	return nil
+}
+
+// reviewRequests returns the review requests of the tracking repository of the given client, oldest first. The review
+// requests pending on its open RFCs are reconciled with the provider first: requests Harmonia did not make, e.g. made
+// through the provider, are recorded, timed from when they are first seen, and requests no longer pending are recorded
+// as responded to by the first review answering them, or dropped if none does as they were withdrawn. Requests of RFCs
+// that are no longer open and requests responded to before reviewrequests.WINDOW are dropped
+func reviewRequests(ctx context.Context, git exGit.Git) ([]models.ReviewRequest, error) {
+	prs, err := cachedOpenPullRequests(ctx, git)
+	if err != nil {
+		return nil, err
+	}
+
+	open := set.NewSet[string]()
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		open.Add(details.RFCIdentifier)
+		recordReviewRequests(ctx, git, details.RFCIdentifier, details.RequestedReviewers, details.RequestedTeams)
+		if err = reconcileReviewRequests(ctx, git, pr, details); err != nil {
+			return nil, err
+		}
+	}
+
+	recorded, err := reviewrequests.Default.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain, _ := exGit.ClientDomain(git)
+	since := time.Now().Add(-reviewrequests.WINDOW)
+	requests := []models.ReviewRequest{}
+	for _, request := range recorded {
+		if request.Domain != domain {
+			continue
+		}
+		if (request.RespondedAt == nil && !open.Contains(request.RFCIdentifier)) ||
+			(request.RespondedAt != nil && request.RequestedAt.Before(since)) {
+			if err = reviewrequests.Default.Delete(ctx, request); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// reconcileReviewRequests records the recorded review requests pending on the given pull request that the provider no
+// longer lists as pending as responded to by the first review answering them, submitted since they were made, or drops
+// them if no review answers them
+func reconcileReviewRequests(ctx context.Context, git exGit.Git, pr exGit.PullRequest,
+	details *exGit.PullRequestDetails) error {
+	recorded, err := reviewrequests.Default.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	domain, _ := exGit.ClientDomain(git)
+	pending := set.NewSet[string]()
+	for _, reviewer := range details.RequestedReviewers {
+		pending.Add(reviewerKey(reviewer, false))
+	}
+	for _, team := range details.RequestedTeams {
+		pending.Add(reviewerKey(team, true))
+	}
+	var settled []models.ReviewRequest
+	for _, request := range recorded {
+		if request.Domain == domain && request.RFCIdentifier == details.RFCIdentifier && request.RespondedAt == nil &&
+			!pending.Contains(reviewerKey(request.Reviewer, request.Team)) {
+			settled = append(settled, request)
+		}
+	}
+	if len(settled) == 0 {
+		return nil
+	}
+
+	reviews, err := git.GetReviews(ctx, pr)
+	if err != nil {
+		return err
+	}
+	submitted, err := git.GetReviewDetails(reviews)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(submitted, func(i, j int) bool {
+		return submitted[i].SubmittedAt.Before(submitted[j].SubmittedAt)
+	})
+
+	for _, request := range settled {
+		for _, review := range submitted {
+			if review.SubmittedAt.Before(request.RequestedAt) || !answers(ctx, git, request, review.Reviewer) {
+				continue
+			}
+			respondedAt := review.SubmittedAt
+			request.RespondedAt, request.Responder = &respondedAt, review.Reviewer
+			break
+		}
+		if request.RespondedAt != nil {
+			err = reviewrequests.Default.Save(ctx, request)
+		} else {
+			err = reviewrequests.Default.Delete(ctx, request)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordReviewRequests records the review requests of the RFC with the given identifier made to the given reviewers
+// and teams, timed from now. Only the first request of each reviewer and team of an RFC is tracked, requests already
+// recorded are left as they are. This is best effort, failures are logged rather than failing the calling operation
+func recordReviewRequests(ctx context.Context, git exGit.Git, rfcIdentifier string, reviewers []string,
+	teams []string) {
+	if len(reviewers) == 0 && len(teams) == 0 {
+		return
+	}
+	recorded, err := reviewrequests.Default.List(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Info("unable to record review requests of RFC", logging.ERROR_KEY, err)
+		return
+	}
+
+	domain, _ := exGit.ClientDomain(git)
+	known := set.NewSet[string]()
+	for _, request := range recorded {
+		if request.Domain == domain && request.RFCIdentifier == rfcIdentifier {
+			known.Add(reviewerKey(request.Reviewer, request.Team))
+		}
+	}
+
+	now := time.Now().UTC()
+	record := func(reviewer string, team bool) {
+		if known.Contains(reviewerKey(reviewer, team)) {
+			return
+		}
+		request := models.ReviewRequest{Domain: domain, RFCIdentifier: rfcIdentifier, Reviewer: reviewer, Team: team,
+			RequestedAt: now}
+		if err := reviewrequests.Default.Save(ctx, request); err != nil {
+			logging.FromContext(ctx).Info("unable to record review request of RFC", "reviewer", reviewer,
+				logging.ERROR_KEY, err)
+		}
+	}
+	for _, reviewer := range reviewers {
+		record(reviewer, false)
+	}
+	for _, team := range teams {
+		record(team, true)
+	}
+}
+
+// recordReviewResponse records the review the given reviewer submitted at the given time on the RFC with the given
+// identifier as the first response to the pending review requests it answers, those made to the reviewer and to the
+// teams they are a member of. This is best effort, failures are logged rather than failing the calling operation
+func recordReviewResponse(ctx context.Context, git exGit.Git, rfcIdentifier string, reviewer string, at time.Time) {
+	recorded, err := reviewrequests.Default.List(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Info("unable to record review response to RFC", logging.ERROR_KEY, err)
+		return
+	}
+
+	domain, _ := exGit.ClientDomain(git)
+	respondedAt := at.UTC()
+	for _, request := range recorded {
+		if request.Domain != domain || request.RFCIdentifier != rfcIdentifier || request.RespondedAt != nil ||
+			!answers(ctx, git, request, reviewer) {
+			continue
+		}
+		request.RespondedAt, request.Responder = &respondedAt, reviewer
+		if err = reviewrequests.Default.Save(ctx, request); err != nil {
+			logging.FromContext(ctx).Info("unable to record review response to RFC", logging.ERROR_KEY, err)
+		}
+	}
+}
+
+// answers returns whether a review of the given reviewer answers the given review request, made to the reviewer or to
+// a team they are a member of. Reviews are assumed not to answer team requests if the team members cannot be listed
+func answers(ctx context.Context, git exGit.Git, request models.ReviewRequest, reviewer string) bool {
+	if !request.Team {
+		return request.Reviewer == reviewer
+	}
+	members, err := git.GetTeamMembers(ctx, request.Reviewer)
+	if err != nil {
+		logging.FromContext(ctx).Info("unable to list the members of team", "team", request.Reviewer,
+			logging.ERROR_KEY, err)
+		return false
+	}
+	return members.Contains(reviewer)
+}
+
+// reviewerKey returns a key identifying the given reviewer or team, reviewers and teams may share a name
+func reviewerKey(reviewer string, team bool) string {
+	if team {
+		return "team/" + reviewer
+	}
+	return reviewer
+}
+
+// ArchiveRFCs compacts the tracking repository of the given client: the RFC files of RFCs merged before the given
+// time are removed from the base branch and listed in its archive index instead, at most ARCHIVE_BATCH_SIZE at a time,
+// oldest merged first. The tag each RFC was merged under keeps its RFC file. Returns the RFCs archived
+func ArchiveRFCs(ctx context.Context, git exGit.Git, mergedBefore time.Time) ([]models.ArchivedRFC, error) {
+	ctx, span := tracing.Start(ctx, "controllers.ArchiveRFCs")
+	defer span.End()
+
+	// RFCs archived by previous runs are not archived again
+	index := &models.ArchiveIndex{RFCs: []models.ArchivedRFC{}}
+	if content, err := git.GetArchiveIndex(ctx); err != nil {
+		return nil, err
+	} else if content != nil {
+		if err = json.Unmarshal([]byte(*content), index); err != nil {
+			errStr := fmt.Sprintf("malformed archive index: %s", err.Error())
+			logging.FromContext(ctx).Error(errStr)
+			return nil, fmt.Errorf(errStr)
+		}
+	}
+
+	merged := true
+	prs, err := git.GetPullRequests(ctx, exGit.CLOSED_STATE, -1, git.IsMerged(&merged))
+	if err != nil {
+		return nil, err
+	}
+
+	archived := []models.ArchivedRFC{}
+	archivedAt := time.Now().UTC()
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		if !details.Merged || !details.MergedAt.Before(mergedBefore) || index.Contains(details.RFCIdentifier) {
+			continue
+		}
+		archived = append(archived, models.ArchivedRFC{
+			RFCIdentifier: details.RFCIdentifier,
+			Title:         details.Title,
+			Author:        details.Author,
+			Tag:           details.RFCIdentifier,
+			MergedAt:      details.MergedAt.UTC(),
+			ArchivedAt:    archivedAt,
+		})
+	}
+	if len(archived) == 0 {
+		return archived, nil
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].MergedAt.Before(archived[j].MergedAt) })
+	if len(archived) > ARCHIVE_BATCH_SIZE {
+		archived = archived[:ARCHIVE_BATCH_SIZE]
+	}
+
+	index.RFCs = append(index.RFCs, archived...)
+	content, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
+		return nil, err
+	}
+	rfcIdentifiers := make([]string, len(archived))
+	for i, rfc := range archived {
+		rfcIdentifiers[i] = rfc.RFCIdentifier
+	}
+	message := fmt.Sprintf("archive %d RFCs merged before %s", len(archived), mergedBefore.UTC().Format(time.RFC3339))
+	if err = git.ArchiveRFCs(ctx, rfcIdentifiers, string(content), message); err != nil {
+		return nil, err
+	}
+
+	return archived, nil
+}
+
+// Seed submits one sample RFC in each of the requested states, every seed state if none are, to the tracking repository
+// of the given client, so local stacks start out with RFCs to work with. Approvals are submitted by the given reviewer,
+// which must not be the machine as nobody may approve their own pull request, and failed loads are recorded without
+// loading anything. Returns the seeded RFCs by their state
+func Seed(ctx context.Context, git exGit.Git, gitReviewer exGit.Git, data *models.Seed) (*models.Seeded, error) {
+	ctx, span := tracing.Start(ctx, "controllers.Seed")
+	defer span.End()
+
+	states := data.States
+	if len(states) == 0 {
+		states = models.SeedStates
+	}
+
+	// build every sample first, so unknown states are rejected before anything is seeded
+	samples := make([]*models.RFC, len(states))
+	for i, state := range states {
+		sample, err := models.SampleRFC(state, data.Domain)
+		if err != nil {
+			return nil, err
+		}
+		samples[i] = sample
+	}
+
+	seeded := &models.Seeded{RFCs: map[string]string{}, Links: map[string]*models.Links{}}
+	for i, state := range states {
+		// samples are seeded again on every request, they are never duplicates
+		rfcIdentifier, err := SubmitRequest(ctx, git, samples[i], true)
+		if err != nil {
+			return nil, err
+		}
+		if err = seedState(ctx, git, gitReviewer, data.Domain, *rfcIdentifier, state); err != nil {
+			logging.FromContext(ctx).Error("failed to seed RFC", "state", state, logging.ERROR_KEY, err)
+			return nil, err
+		}
+		seeded.RFCs[string(state)] = *rfcIdentifier
+		seeded.Links[*rfcIdentifier] = GetLinks(ctx, git, *rfcIdentifier, state == models.MergedSeed)
+	}
+
+	return seeded, nil
+}
+
+// the below methods (not capitalized) exist strictly to be called by other functions within this module, which have
+// already performed the boilerplate retrieval of rfc entities like the pull request and rfc content
+
+// attemptLoadAndMerge attempts to load and then merge the given RFC data and corresponding pull request
+func attemptLoadAndMerge(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
+	rfcIdentifier string) error {
+	ctx, span := tracing.Start(ctx, "controllers.attemptLoadAndMerge", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var mergeability *models.Mergeability
+	var user *string
+
+	// Get user login for load status update
+	if user, err = userLogin(ctx, git); err != nil {
+		return err
+	}
+
+	// RFCs signed by their author must still verify with the key of their author, which may have been revoked since
+	if _, err = verifyAuthorSignature(ctx, git, rfc, false); err != nil {
+		return err
+	}
+
+	// update load status to LOAD_REQUESTED_STATUS
+	if err = recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, LOAD_REQUESTED_STATUS, *user, nil); err != nil {
+		return err
+	}
+
+	// determine if the pr can be merged, this is 1:1 with loadability (can't load if we can't merge)
+	if mergeability, err = git.ExplainMergeability(ctx, pr); err != nil {
+		return err
+	}
+	if !mergeability.Mergeable {
+		logging.FromContext(ctx).Info("attempted to load and merge RFC, but it is not mergeable",
+			"reasons", strings.Join(mergeability.Reasons, ", "))
+
+		// update load status to NOT_APPLICABLE_STATUS
+		if err = recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, NOT_APPLICABLE_STATUS, *user, nil); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	// RFCs short of the quorum of approvals the approval policy requires are neither loaded nor merged yet, a later
+	// approval attempts again
+	if err = checkQuorum(ctx, git, pr, rfc); errors.Is(err, models.ErrQuorumNotMet) {
+		logging.FromContext(ctx).Info("attempted to load and merge RFC, but its approval quorum is not met",
+			logging.ERROR_KEY, err)
+
+		// update load status to NOT_APPLICABLE_STATUS
+		if err = recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, NOT_APPLICABLE_STATUS, *user, nil); err != nil {
+			return err
+		}
+
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// gated loads wait for an approval, the RFC is merged once it is approved and loaded
+	if gate := models.NewLoadGate(); gate != nil {
+		gate.MergeOnLoad = true
+		return openLoadGate(ctx, git, pr, rfc, rfcIdentifier, *user, gate)
+	}
+
+	return loadAndMerge(ctx, git, pr, rfc, rfcIdentifier)
+}
+
+// loadAndMerge loads and then merges the given RFC data and corresponding pull request
+func loadAndMerge(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
+	rfcIdentifier string) error {
+	ctx, span := tracing.Start(ctx, "controllers.loadAndMerge", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var mergeability *models.Mergeability
+
+	// attempt load, failed loads are never merged and partial loads only if the merge policy allows it
+	status, err := loadRequest(ctx, git, pr, rfc, rfcIdentifier)
+	if err != nil {
+		return err
+	}
+	if !mergeAllowed(status) {
+		errStr := "Attempted to merge RFC %s, but its load is %s - NOTE: NOT MERGED."
+		logging.FromContext(ctx).Error(fmt.Sprintf(errStr, rfcIdentifier, status))
+		return fmt.Errorf(errStr, rfcIdentifier, status)
+	}
+
+	// mergeability needs to be recalculated here because loadRequest may update the RFC file - CI check
+	if mergeability, err = git.ExplainMergeability(ctx, pr); err != nil {
+		return err
+	}
+	if !mergeability.Mergeable {
+		errStr := "Attempted to merge RFC %s, but it is not mergeable (%s) - NOTE: LOADED BUT NOT MERGED."
+		reasons := strings.Join(mergeability.Reasons, ", ")
+		logging.FromContext(ctx).Error(fmt.Sprintf(errStr, rfcIdentifier, reasons))
+		return fmt.Errorf(errStr, rfcIdentifier, reasons)
+	}
+
+	// attempt merge
+	if err = mergeRequest(ctx, git, pr, rfc, rfcIdentifier); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// forceLoadAndMerge loads and then merges the given RFC data and corresponding pull request bypassing policy, only an
+// RFC that could not be loaded into any of its targets is left unmerged
+func forceLoadAndMerge(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
+	rfcIdentifier string) error {
+	ctx, span := tracing.Start(ctx, "controllers.forceLoadAndMerge", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	status, err := loadRequest(ctx, git, pr, rfc, rfcIdentifier)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to load RFC after breaking glass", logging.ERROR_KEY, err)
+		return err
+	}
+	if status == FAILED_STATUS {
+		errStr := "Broke glass on RFC %s, but its load is %s - NOTE: NOT MERGED."
+		logging.FromContext(ctx).Error(fmt.Sprintf(errStr, rfcIdentifier, status))
+		return fmt.Errorf(errStr, rfcIdentifier, status)
+	}
+
+	if err = mergeRequest(ctx, git, pr, rfc, rfcIdentifier); err != nil {
+		logging.FromContext(ctx).Error("unable to merge RFC after breaking glass", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// loadRequest loads the given rfc content into each of its load targets concurrently, recording the status of each
+// target as it completes, and returns the composite status of the load: successful if every target succeeded, partial
+// if only some of them did and failed otherwise. A failed target does not prevent loading the others
+// The pull request param. seems unnecessary, but it is needed to update the load status in the RFC file periodically
+func loadRequest(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
+	rfcIdentifier string) (string, error) {
+	ctx, span := tracing.Start(ctx, "controllers.loadRequest", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var content []byte
+	var user *string
+
+	// Get user login for load status update
+	if user, err = userLogin(ctx, git); err != nil {
+		return "", err
+	}
+
+	// expand the template variables of the RFC, the RFC file keeps its placeholders so it remains a template
+	loaded := rfc
+	if rfc.IsTemplate() {
+		if loaded, err = expandRFC(ctx, git, pr, rfc, rfcIdentifier); err != nil {
+			logging.FromContext(ctx).Error("unable to expand the template variables of RFC", logging.ERROR_KEY, err)
+			if statusErr := recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, FAILED_STATUS, *user,
+				nil); statusErr != nil {
+				logging.FromContext(ctx).Info("unable to record failed load of RFC", logging.ERROR_KEY, statusErr)
+			}
+			return "", err
+		}
+	}
+
+	// RFCs must not go live before the RFCs they depend on, which may have been reverted since the load was requested
+	if err = checkDependencies(ctx, git, rfc, rfcIdentifier); err != nil {
+		return "", err
+	}
+
+	// RFCs submitted before their action type was known may still hold actions that do not meet its requirements
+	if err = checkActions(ctx, loaded); err != nil {
+		return "", err
+	}
+
+	// RFCs submitted before their domain became a tenant may still declare the load targets of another domain
+	targets := loadTargets(rfc)
+	if err = checkTenantLoadTargets(ctx, rfc, targets); err != nil {
+		return "", err
+	}
+
+	// update load status to LOADING_STATUS, with every target loading, the action timings of a previous load no longer
+	// apply
+	statuses := map[string]string{}
+	for _, target := range targets {
+		statuses[target] = LOADING_STATUS
+	}
+	if err = rfc.ClearLoadTimings(); err != nil {
+		return "", err
+	}
+	if err = recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, LOADING_STATUS, *user, statuses); err != nil {
+		return "", err
+	}
+
+	// format rfc for loading
+	if content, err = json.Marshal(loaded); err != nil {
+		logging.FromContext(ctx).Error("unable to marshal existing RFC content in preparation for load",
+			logging.ERROR_KEY, err)
+		return "", err
+	}
+
+	// run the load pipeline of every target, recording each outcome, along with the time each action took to apply, as
+	// it is known. A failure to record progress is not fatal as the final statuses are recorded once every target is done
+	failed := 0
+	loadStart := time.Now()
+	timings := map[string][]models.ActionTiming{}
+	loader.Default.LoadAll(ctx, targets, content, func(target string, loadErr error,
+		actionTimings []models.ActionTiming) {
+		if loadErr != nil {
+			logging.FromContext(ctx).Error("unable to load RFC into target", "loadTarget", target, logging.ERROR_KEY, loadErr)
+			statuses[target] = FAILED_STATUS
+			failed++
+		} else {
+			statuses[target] = SUCCESSFUL_STATUS
+		}
+		metrics.Loads.Inc(target, statuses[target])
+		if len(actionTimings) > 0 {
+			timings[target] = actionTimings
+			for _, timing := range actionTimings {
+				metrics.ActionLoadDuration.Observe(timing.Seconds, target, string(timing.ActionType))
+			}
+			if timingErr := rfc.SetLoadTimings(timings); timingErr != nil {
+				logging.FromContext(ctx).Info("unable to record action timings of RFC", logging.ERROR_KEY, timingErr)
+			}
+		}
+		if progressErr := recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, LOADING_STATUS, *user,
+			statuses); progressErr != nil {
+			logging.FromContext(ctx).Info("unable to record load progress of RFC", logging.ERROR_KEY, progressErr)
+		}
+	})
+
+	// update load status to the composite status of every target
+	status := SUCCESSFUL_STATUS
+	if failed == len(targets) && failed > 0 {
+		status = FAILED_STATUS
+	} else if failed > 0 {
+		status = PARTIAL_STATUS
+	}
+	metrics.LoadDuration.Observe(time.Since(loadStart).Seconds(), status)
+	if err = recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, status, *user, statuses); err != nil {
+		return "", err
+	}
+
+	// the reserve actions of an RFC claim their target descriptors once it is loaded anywhere
+	if status != FAILED_STATUS {
+		recordReservations(ctx, loaded, rfcIdentifier)
+	}
+	publishEvent(models.LoadEvent, rfcIdentifier, *user, status, rfc)
+
+	return status, nil
+}
+
+// mergeAllowed returns whether an RFC whose load has the given composite status can be merged under the configured
+// merge policy
+func mergeAllowed(status string) bool {
+	return status == SUCCESSFUL_STATUS ||
+		(status == PARTIAL_STATUS && loader.Default.MergePolicy() == loader.AllowPartial)
+}
+
+// saveLoadStatus records the given load status of the given RFC, along with the given status of each of its targets
+// if any, on the RFC and in the load status store. Updating the RFC file is left to the caller
+func saveLoadStatus(ctx context.Context, rfc *models.RFC, rfcIdentifier string, status string, user string,
+	targets map[string]string) error {
+	if err := rfc.UpdateLoadStatus(status, user); err != nil {
+		return err
+	}
+	if targets != nil {
+		if err := rfc.SetTargetLoadStatuses(targets); err != nil {
+			return err
+		}
+	}
+
+	return loadstatus.Default.Save(ctx, models.LoadRecord{
+		RFCIdentifier: rfcIdentifier,
+		Status:        status,
+		Targets:       rfc.GetTargetLoadStatuses(),
+		Requester:     user,
+		UpdatedAt:     time.Now().UTC(),
+		Timings:       rfc.GetLoadTimings(),
+	})
+}
+
+// recordLoadStatus saves the given load status of the given RFC, see saveLoadStatus, and updates the RFC file with it
+// unless load statuses are only recorded in the load status store
+func recordLoadStatus(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC, rfcIdentifier string,
+	status string, user string, targets map[string]string) error {
+	if err := saveLoadStatus(ctx, rfc, rfcIdentifier, status, user, targets); err != nil {
+		return err
+	}
+	if !loadstatus.InRFCFile() {
+		return nil
+	}
+
+	return git.UpdateFile(ctx, pr, rfc)
+}
+
+// expandRFC returns a copy of the given RFC with the template variables of its actions expanded, see models.Expand
+// The submitter is the one of its pull request, see submitterOf, and the submission date that of its pull request
+func expandRFC(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC,
+	rfcIdentifier string) (*models.RFC, error) {
+	details, err := git.GetPullRequestDetails(pr)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[models.TemplateVariable]string{
+		models.SubmittedAtVariable:   details.CreatedAt.UTC().Format("2006-01-02"),
+		models.SubmitterVariable:     submitterOf(rfc, details),
+		models.RFCIdentifierVariable: rfcIdentifier,
+	}
+	if environment := models.Environment(); environment != "" {
+		variables[models.EnvironmentVariable] = environment
+	}
+
+	return rfc.Expand(variables)
+}
+
+// loadTargets returns the load targets of the given RFC, every configured target if it does not declare any, or every
+// configured target its schema domain may load into in the multi-tenant mode
+func loadTargets(rfc *models.RFC) []string {
+	if len(rfc.LoadTargets) == 0 {
+		if tenants.Default != nil {
+			return tenants.Default.LoadTargets(rfc.Domain, loader.Default.Targets())
+		}
+		return loader.Default.Targets()
+	}
+
+	return rfc.LoadTargets
+}
+
+// validateLoadTargets returns an error wrapping models.ErrUnknownLoadTarget if the given RFC declares a load target
+// that is not configured
+func validateLoadTargets(ctx context.Context, rfc *models.RFC) error {
+	for _, target := range rfc.LoadTargets {
+		if _, ok := loader.Default.Get(target); !ok {
+			logging.FromContext(ctx).Warn("RFC declares an unknown load target", "loadTarget", target,
+				"configured", strings.Join(loader.Default.Targets(), ", "))
+			return fmt.Errorf("%w: %s", models.ErrUnknownLoadTarget, target)
+		}
+	}
+
+	return checkTenantLoadTargets(ctx, rfc, rfc.LoadTargets)
+}
+
+// checkTenant returns tenants.ErrCrossTenant (wrapped) if the given RFC belongs to another schema domain than the
+// tracking repository of the given git client, and either is a tenant in the multi-tenant mode. Clients that were not
+// built for a schema domain are not checked
+func checkTenant(ctx context.Context, git exGit.Git, rfc *models.RFC) error {
+	if tenants.Default == nil {
+		return nil
+	}
+	domain, ok := exGit.ClientDomain(git)
+	if !ok {
+		return nil
+	}
+
+	if err := tenants.Default.CheckDomain(rfc.Domain, domain); err != nil {
+		logging.FromContext(ctx).Warn("rejected cross-tenant access", "domain", rfc.Domain, "repositoryDomain", domain,
+			logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// checkTenantLoadTargets returns tenants.ErrCrossTenant (wrapped) if the given RFC cannot be loaded into any of the
+// given load targets in the multi-tenant mode, because they belong to another schema domain
+func checkTenantLoadTargets(ctx context.Context, rfc *models.RFC, targets []string) error {
+	if tenants.Default == nil {
+		return nil
+	}
+
+	for _, target := range targets {
+		if err := tenants.Default.CheckLoadTarget(rfc.Domain, target); err != nil {
+			logging.FromContext(ctx).Warn("rejected cross-tenant load target", "domain", rfc.Domain,
+				"loadTarget", target, logging.ERROR_KEY, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// checkActions returns models.ErrInvalidAction (wrapped) if a proposal action of the given RFC does not meet the
+// requirements of its action type, such as looking up the item it changes
+func checkActions(ctx context.Context, rfc *models.RFC) error {
+	if err := rfc.CheckActions(); err != nil {
+		logging.FromContext(ctx).Warn("RFC holds an invalid action", logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// checkReservations returns models.ErrTargetReserved (wrapped) if an action of the given RFC adds to or reserves a
+// target descriptor reserved by a team the user making the request is not a member of. Expired reservations and those
+// of the RFC with the given identifier, empty if it is not submitted yet, are ignored
+func checkReservations(ctx context.Context, git exGit.Git, rfc *models.RFC, rfcIdentifier string) error {
+	held, err := reservations.Default.List(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list reservations", logging.ERROR_KEY, err)
+		return err
+	}
+
+	now := time.Now()
+	for _, reservation := range held {
+		if reservation.Domain != rfc.Domain || reservation.Expired(now) ||
+			(rfcIdentifier != "" && reservation.RFCIdentifier == rfcIdentifier) {
+			continue
+		}
+		for _, action := range rfc.Actions {
+			if !reservation.Conflicts(action) {
+				continue
+			}
+			member, err := isTeamMember(ctx, git, reservation.Team)
+			if err != nil {
+				return err
+			}
+			if !member {
+				err = reservation.Conflict()
+				logging.FromContext(ctx).Warn("RFC reaches into the reservation of another team",
+					"targetDescriptor", action.Target.TargetDescriptor, "team", reservation.Team, logging.ERROR_KEY, err)
+				return err
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// recordReservations records the reservations claimed by the reserve actions of the given loaded RFC, replacing those
+// previously recorded for the same target descriptors. Failures are logged, the RFC is loaded regardless
+func recordReservations(ctx context.Context, rfc *models.RFC, rfcIdentifier string) {
+	for _, reservation := range rfc.Reservations(rfcIdentifier, time.Now().UTC(), reservations.TTL) {
+		if err := reservations.Default.Save(ctx, reservation); err != nil {
+			logging.FromContext(ctx).Error("unable to record reservation of RFC", "targetDescriptor",
+				reservation.TargetDescriptor, "team", reservation.Team, logging.ERROR_KEY, err)
+		}
+	}
+}
+
+// isTeamMember returns whether the user making the request is a member of the given team
+func isTeamMember(ctx context.Context, git exGit.Git, team string) (bool, error) {
+	login := currentUser(ctx, git)
+	if login == "" {
+		return false, nil
+	}
+	members, err := git.GetTeamMembers(ctx, team)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list the members of team", "team", team, logging.ERROR_KEY, err)
+		return false, err
+	}
+
+	return members.Contains(login), nil
+}
+
+// validateActionData validates the data of the proposed actions of the given RFC against the schemas of the tracking
+// repository of its domain, if a schema directory is configured. A *models.SchemaError holding an error for each
+// offending field is returned if any does not conform
+func validateActionData(ctx context.Context, git exGit.Git, rfc *models.RFC) error {
+	if schemas.Directory == "" {
+		return nil
+	}
+
+	registry, ok := schemaRegistryCache.Get(rfc.Domain)
+	if !ok {
+		files, err := git.GetSchemas(ctx, schemas.Directory)
+		if err != nil {
+			return err
+		}
+		var failures map[string]error
+		registry, failures = schemas.Compile(files)
+		for name, failure := range failures {
+			logging.FromContext(ctx).Error("unable to compile action data schema, it is ignored", "schema", name,
+				logging.ERROR_KEY, failure)
+		}
+		schemaRegistryCache.Set(rfc.Domain, registry)
+	}
+
+	if errs := registry.Validate(rfc); len(errs) > 0 {
+		return &models.SchemaError{Errors: errs}
+	}
+	return nil
+}
+
+// openLoadGate records that the load of the given RFC is awaiting the given gate's approval
+// Deployment gates request a deployment so the environment protection rules decide, and are polled for that decision
+// in the background, while manual gates wait for an ApproveLoad request
+func openLoadGate(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC, rfcIdentifier string,
+	user string, gate *models.LoadGate) error {
+	if gate.Type == models.DeploymentGate {
+		deploymentID, err := git.CreateDeployment(ctx, pr, gate.Environment)
+		if err != nil {
+			return err
+		}
+		gate.DeploymentID = *deploymentID
+	}
+
+	// update load status to AWAITING_APPROVAL_STATUS so the gate is visible through the status endpoint
+	// the gate is only recorded in the RFC file, so it is always updated
+	if err := saveLoadStatus(ctx, rfc, rfcIdentifier, AWAITING_APPROVAL_STATUS, user, nil); err != nil {
+		return err
+	}
+	if err := rfc.UpdateLoadGate(gate); err != nil {
+		return err
+	}
+	if err := git.UpdateFile(ctx, pr, rfc); err != nil {
+		return err
+	}
+	publishEvent(models.LoadEvent, rfcIdentifier, user, AWAITING_APPROVAL_STATUS, rfc)
+
+	// a new unattached context is needed because the go routine is not waited on, see LoadRequest
+	if gate.Type == models.DeploymentGate {
+		go awaitDeploymentGate(metadata.Detach(ctx), git, rfcIdentifier, gate.DeploymentID)
+	}
+
+	return nil
+}
+
+// awaitDeploymentGate polls the given deployment until its environment protection rules approve or reject it, loading
+// the given RFC if approved
+// Polling is abandoned after LOAD_GATE_TIMEOUT, the load can then be requested again
+func awaitDeploymentGate(ctx context.Context, git exGit.Git, rfcIdentifier string, deploymentID string) {
+	ctx, span := tracing.Start(ctx, "controllers.awaitDeploymentGate", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	ticker := time.NewTicker(LOAD_GATE_POLL_INTERVAL)
+	defer ticker.Stop()
+	deadline := time.Now().Add(LOAD_GATE_TIMEOUT)
+
+	for time.Now().Before(deadline) {
+		<-ticker.C
+		decided, err := checkDeploymentGate(ctx, git, rfcIdentifier, deploymentID)
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to check deployment gate of RFC", logging.ERROR_KEY, err)
+			// a gate that is no longer pending has been superseded, and a deployment that is gone or can no longer be
+			// read will never be decided, there is nothing left to wait on. Other errors, e.g. rate limits, are retried
+			if errors.Is(err, models.ErrNoPendingGate) || errors.Is(err, exGit.ErrNotFound) ||
+				errors.Is(err, exGit.ErrPermission) {
+				return
+			}
+		}
+		if decided {
+			return
+		}
+	}
+
+	logging.FromContext(ctx).Warn("deployment gate of RFC was not decided in time, the load must be requested again",
+		"timeout", LOAD_GATE_TIMEOUT)
+}
+
+// checkDeploymentGate checks the given deployment once, recording the decision and loading the given RFC if its
+// environment protection rules approved it
+// Whether a decision has been made is returned
+func checkDeploymentGate(ctx context.Context, git exGit.Git, rfcIdentifier string, deploymentID string) (bool,
+	error) {
+	status, err := git.GetDeploymentStatus(ctx, deploymentID)
+	if err != nil {
+		return false, err
+	}
+	if status.State == exGit.DEPLOYMENT_PENDING_STATE {
+		return false, nil
+	}
+
+	approved := status.State == exGit.DEPLOYMENT_APPROVED_STATE
+	pr, rfc, gate, err := decideLoadGate(ctx, git, rfcIdentifier, models.DeploymentGate, deploymentID, approved,
+		status.Actor)
+	if err != nil {
+		return true, err
+	}
+	if gate.State == models.RejectedGate {
+		return true, nil
+	}
+
+	return true, loadAfterGate(ctx, git, pr, rfc, rfcIdentifier, gate.MergeOnLoad)
+}
+
+// decideLoadGate records the given decision on the pending load gate of the given RFC, which must be of the given type
+// and, for deployment gates, track the given deployment
+// Rejected loads are given the REJECTED_STATUS, while approved loads keep awaiting approval until the load step
+// executes. The pull request, RFC and decided gate are returned so an approved load can proceed
+func decideLoadGate(ctx context.Context, git exGit.Git, rfcIdentifier string, gateType models.GateType,
+	deploymentID string, approved bool, approver string) (exGit.PullRequest, *models.RFC, *models.LoadGate, error) {
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var pr exGit.PullRequest
+	var rfc *models.RFC
+
+	// get corresponding pr so the RFC file can be updated
+	if pr, err = git.GetPullRequest(ctx, rfcIdentifier); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// retrieve the RFC so the current gate is used, it may have been decided or superseded since it was opened
+	if rfc, err = readRFC(ctx, git, rfcIdentifier); err != nil {
+		return nil, nil, nil, err
+	}
+	gate := rfc.GetLoadGate()
+	if gate == nil || gate.State != models.PendingGate || gate.Type != gateType || gate.DeploymentID != deploymentID {
+		return nil, nil, nil, fmt.Errorf("%w: RFC %s has no pending %s load gate", models.ErrNoPendingGate,
+			rfcIdentifier, gateType)
+	}
+
+	// record the decision
+	gate.Approver = approver
+	gate.State = models.ApprovedGate
+	if !approved {
+		gate.State = models.RejectedGate
+		if err = saveLoadStatus(ctx, rfc, rfcIdentifier, REJECTED_STATUS, currentUser(ctx, git), nil); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if err = rfc.UpdateLoadGate(gate); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = git.UpdateFile(ctx, pr, rfc); err != nil {
+		return nil, nil, nil, err
+	}
+	if !approved {
+		publishEvent(models.LoadEvent, rfcIdentifier, approver, REJECTED_STATUS, rfc)
+	}
+
+	return pr, rfc, gate, nil
+}
+
+// loadAfterGate loads the given RFC once its load gate has been approved, merging it too if the load was requested
+// on approval
+func loadAfterGate(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC, rfcIdentifier string,
+	merge bool) error {
+	ctx, span := tracing.Start(ctx, "controllers.loadAfterGate", tracing.RFC_IDENTIFIER_KEY.String(rfcIdentifier))
+	defer span.End()
+
+	if merge {
+		return loadAndMerge(ctx, git, pr, rfc, rfcIdentifier)
+	}
+
+	_, err := loadRequest(ctx, git, pr, rfc, rfcIdentifier)
+	return err
+}
+
+// mergeRequest merges the given pr of the given RFC and creates a tag with the given tag name
+func mergeRequest(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC, tag string) error {
+	ctx, span := tracing.Start(ctx, "controllers.mergeRequest", tracing.RFC_IDENTIFIER_KEY.String(tag))
+	defer span.End()
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var sha *string
+
+	// merge pr and retrieve resulting sha
+	if sha, err = git.MergePullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	// create a tag of sha and name it after tag name
+	if err = git.CreateTag(ctx, *sha, tag); err != nil {
+		return err
+	}
+
+	publishEvent(models.MergeEvent, tag, currentUser(ctx, git), "", rfc)
+
+	return nil
+}
+
+// summarizeRFCs returns the summary of each RFC behind the given pull requests keyed by RFC ID, holding the given
+// fields only. Summaries are computed concurrently since each requires several calls to the Git provider
+// Summaries are best effort, an RFC whose summary cannot be computed is logged and left out
+func summarizeRFCs(ctx context.Context, git exGit.Git, prs exGit.PullRequests,
+	fields set.Set[models.RFCField]) map[string]*models.RFCSummary {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	summaries := map[string]*models.RFCSummary{}
+	slots := make(chan struct{}, SUMMARY_CONCURRENCY)
+
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to summarize RFC", logging.ERROR_KEY, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(pr exGit.PullRequest, details *exGit.PullRequestDetails) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			summary, err := summarizeRFC(ctx, git, pr, details, fields)
+			if err != nil {
+				logging.FromContext(ctx).Error("unable to summarize RFC", logging.RFC_IDENTIFIER_KEY, details.RFCIdentifier,
+					logging.ERROR_KEY, err)
+				return
+			}
+			mu.Lock()
+			summaries[details.RFCIdentifier] = summary
+			mu.Unlock()
+		}(pr, details)
+	}
+	wg.Wait()
+
+	return summaries
+}
+
+// summarizeRFC returns the state, review counts, mergeability (open RFCs only) and load status of the RFC behind the
+// given pull request, served from cache when possible. Only the given fields are looked up
+func summarizeRFC(ctx context.Context, git exGit.Git, pr exGit.PullRequest, details *exGit.PullRequestDetails,
+	fields set.Set[models.RFCField]) (*models.RFCSummary, error) {
+	summary := &models.RFCSummary{}
+	if fields.Contains(models.StateField) {
+		summary.State = details.State
+	}
+
+	if fields.Contains(models.ReviewsField) {
+		reviews, err := cachedReviewDetails(ctx, git, pr, details)
+		if err != nil {
+			return nil, err
+		}
+		comments, approvals, changesRequested := 0, 0, 0
+		for _, review := range reviews {
+			if review.State == exGit.COMMENTED_STATE {
+				comments++
+			}
+		}
+		for _, review := range latestReviews(reviews) {
+			switch review.State {
+			case exGit.APPROVED_STATE:
+				approvals++
+			case exGit.CHANGES_REQUESTED_STATE:
+				changesRequested++
+			}
+		}
+		summary.Comments, summary.Approvals, summary.ChangesRequested = &comments, &approvals, &changesRequested
+	}
+
+	if fields.Contains(models.MergeabilityField) && details.State == exGit.OPEN_STATE {
+		mergeability, err := cachedMergeability(ctx, git, pr, details)
+		if err != nil {
+			return nil, err
+		}
+		summary.Mergeable = &mergeability.Mergeable
+		if !mergeability.Mergeable {
+			summary.Mergeability = mergeability
+		}
+	}
+
+	if fields.Contains(models.LoadStatusField) {
+		status, err := cachedLoadStatus(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		summary.LoadStatus = "none"
+		if status != "" {
+			summary.LoadStatus = status
+		}
+	}
+
+	if fields.Contains(models.DependenciesField) {
+		dependsOn, err := cachedDependencies(ctx, git, details)
+		if err != nil {
+			return nil, err
+		}
+		if len(dependsOn) > 0 {
+			if summary.Dependencies, err = dependencyStates(ctx, git, &models.RFC{DependsOn: dependsOn}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// cachedOpenPullRequests returns all open pull requests of the tracking repository, served from cache when possible
+func cachedOpenPullRequests(ctx context.Context, git exGit.Git) (exGit.PullRequests, error) {
+	repository := git.Repository()
+	key := fmt.Sprintf("%s/%s@%s", repository.Owner, repository.Name, exGit.OPEN_STATE)
+	if prs, ok := openPullRequestCache.Get(key); ok {
+		return prs, nil
+	}
+
+	prs, err := git.GetPullRequests(ctx, exGit.OPEN_STATE, -1)
+	if err != nil {
+		return nil, err
+	}
+	openPullRequestCache.Set(key, prs)
+
+	return prs, nil
+}
+
+// cachedReviewDetails returns the review details of the given pull request, served from cache when possible
+func cachedReviewDetails(ctx context.Context, git exGit.Git, pr exGit.PullRequest,
+	details *exGit.PullRequestDetails) ([]exGit.ReviewDetails, error) {
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if reviewDetails, ok := reviewDetailsCache.Get(key); ok {
+		return reviewDetails, nil
+	}
+
+	reviews, err := git.GetReviews(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	reviewDetails, err := git.GetReviewDetails(reviews)
+	if err != nil {
+		return nil, err
+	}
+	reviewDetailsCache.Set(key, reviewDetails)
+
+	return reviewDetails, nil
+}
+
+// cachedLoadStatus returns the load status of the RFC behind the given pull request, read from the load status store
+// or, if the store has none for the RFC, from the RFC file served from cache when possible
+// An empty string is returned if the RFC was never loaded
+func cachedLoadStatus(ctx context.Context, git exGit.Git, details *exGit.PullRequestDetails) (string, error) {
+	if record, err := loadstatus.Default.Get(ctx, details.RFCIdentifier); err != nil {
+		return "", err
+	} else if record != nil {
+		return record.Status, nil
+	}
+
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if status, ok := loadStatusCache.Get(key); ok {
+		return status, nil
+	}
+
+	rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+	if err != nil {
+		return "", err
+	}
+
+	status := ""
+	if loadStatus := rfc.GetLoadStatus(); loadStatus != nil {
+		status = *loadStatus
+	}
+	loadStatusCache.Set(key, status)
+
+	return status, nil
+}
+
+// cachedMergeability returns whether the given pull request is mergeable and why not, served from cache when possible
+func cachedMergeability(ctx context.Context, git exGit.Git, pr exGit.PullRequest,
+	details *exGit.PullRequestDetails) (*models.Mergeability, error) {
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if mergeability, ok := mergeabilityCache.Get(key); ok {
+		return mergeability, nil
+	}
+
+	mergeability, err := git.ExplainMergeability(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	mergeabilityCache.Set(key, mergeability)
+
+	return mergeability, nil
+}
+
+// cachedContentSignature returns the content signature of the given pull request's RFC, served from cache when possible
+func cachedContentSignature(ctx context.Context, git exGit.Git, details *exGit.PullRequestDetails) (string, error) {
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if signature, ok := contentSignatureCache.Get(key); ok {
+		return signature, nil
+	}
+
+	rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := rfc.ContentSignature()
+	if err != nil {
+		return "", err
+	}
+	contentSignatureCache.Set(key, *signature)
+
+	return *signature, nil
+}
+
+// checkDuplicate returns a *models.DuplicateError if an open RFC proposes the same change as the given RFC
+// Open RFCs are listed afresh rather than from cache so that a submission repeated in quick succession is detected,
+// RFCs whose file cannot be read are skipped
+func checkDuplicate(ctx context.Context, git exGit.Git, data *models.RFC) error {
+	signature, err := data.ContentSignature()
+	if err != nil {
+		return err
+	}
+
+	prs, err := git.GetPullRequests(ctx, exGit.OPEN_STATE, -1)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list open RFCs to detect duplicate submissions", logging.ERROR_KEY, err)
+		return err
+	}
+
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return err
+		}
+		existing, err := cachedContentSignature(ctx, git, details)
+		if err != nil {
+			logging.FromContext(ctx).Info("unable to read RFC to detect duplicate submissions",
+				logging.RFC_IDENTIFIER_KEY, details.RFCIdentifier, logging.ERROR_KEY, err)
+			continue
+		}
+		if existing == *signature {
+			return &models.DuplicateError{RFCIdentifier: details.RFCIdentifier}
+		}
+	}
+
+	return nil
+}
+
+// dependencyStates returns the state of each RFC the given RFC depends on, in the order they are declared. RFCs
+// without a pull request are missing, provider failures are returned
+func dependencyStates(ctx context.Context, git exGit.Git, rfc *models.RFC) ([]models.Dependency, error) {
+	dependencies := make([]models.Dependency, 0, len(rfc.DependsOn))
+	for _, rfcIdentifier := range rfc.DependsOn {
+		dependency := models.Dependency{RFCIdentifier: rfcIdentifier, State: models.MissingDependency}
+		pr, err := git.GetPullRequest(ctx, rfcIdentifier)
+		var providerErr *models.ProviderError
+		if err != nil && errors.As(err, &providerErr) && !errors.Is(err, exGit.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			details, err := git.GetPullRequestDetails(pr)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case details.Merged:
+				dependency.State = models.MergedDependency
+			case details.State == exGit.OPEN_STATE:
+				dependency.State = models.OpenDependency
+			default:
+				dependency.State = models.ClosedDependency
+			}
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies, nil
+}
+
+// checkDependencies returns models.ErrUnmetDependencies (wrapped) if an RFC the given RFC depends on is not merged
+func checkDependencies(ctx context.Context, git exGit.Git, rfc *models.RFC, rfcIdentifier string) error {
+	if len(rfc.DependsOn) == 0 {
+		return nil
+	}
+	dependencies, err := dependencyStates(ctx, git, rfc)
+	if err != nil {
+		return err
+	}
+
+	if err = models.CheckDependencies(rfcIdentifier, dependencies); err != nil {
+		logging.FromContext(ctx).Warn("RFC depends on RFCs that are not merged", logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// validateDependencies returns models.ErrInvalidDependency (wrapped) if the given RFC, with the given identifier
+// (empty if it is not submitted yet), declares a dependency that does not exist, was closed without being merged or
+// depends on the RFC itself, directly or through the open RFCs it depends on
+func validateDependencies(ctx context.Context, git exGit.Git, rfc *models.RFC, rfcIdentifier string) error {
+	if err := rfc.CheckDependencyDeclarations(); err != nil {
+		return err
+	}
+
+	visited := set.NewSet[string]()
+	pending := []*models.RFC{rfc}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		dependencies, err := dependencyStates(ctx, git, current)
+		if err != nil {
+			return err
+		}
+		for _, dependency := range dependencies {
+			var invalid string
+			switch {
+			case rfcIdentifier != "" && dependency.RFCIdentifier == rfcIdentifier:
+				invalid = fmt.Sprintf("RFC %s depends on itself", rfcIdentifier)
+			case current == rfc && dependency.State == models.MissingDependency:
+				invalid = fmt.Sprintf("RFC %s does not exist", dependency.RFCIdentifier)
+			case current == rfc && dependency.State == models.ClosedDependency:
+				invalid = fmt.Sprintf("RFC %s was closed without being merged", dependency.RFCIdentifier)
+			}
+			if invalid != "" {
+				logging.FromContext(ctx).Warn("RFC declares an invalid dependency", "dependency",
+					dependency.RFCIdentifier, "reason", invalid)
+				return fmt.Errorf("%w: %s", models.ErrInvalidDependency, invalid)
+			}
+
+			// only open RFCs can still come to depend on the RFC
+			if rfcIdentifier == "" || dependency.State != models.OpenDependency ||
+				visited.Contains(dependency.RFCIdentifier) {
+				continue
+			}
+			visited.Add(dependency.RFCIdentifier)
+			next, err := readRFC(ctx, git, dependency.RFCIdentifier)
+			if err != nil {
+				return err
+			}
+			pending = append(pending, next)
+		}
+	}
+
+	return nil
+}
+
+// cachedDependencies returns the RFCs the RFC of the given pull request depends on, served from cache when possible
+func cachedDependencies(ctx context.Context, git exGit.Git, details *exGit.PullRequestDetails) ([]string, error) {
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if dependsOn, ok := dependenciesCache.Get(key); ok {
+		return dependsOn, nil
+	}
+
+	rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	dependenciesCache.Set(key, rfc.DependsOn)
+
+	return rfc.DependsOn, nil
+}
+
+// cachedSubmitter returns the submitter of the RFC of the given pull request, see submitterOf, served from cache when
+// possible. The RFC file is only read under SSO, the submitter being the author of the pull request otherwise
+func cachedSubmitter(ctx context.Context, git exGit.Git, details *exGit.PullRequestDetails) (string, error) {
+	if signing.Records == nil {
+		return details.Author, nil
+	}
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if submitter, ok := submitterCache.Get(key); ok {
+		return submitter, nil
+	}
+
+	rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+	if err != nil {
+		return "", err
+	}
+	submitter := submitterOf(rfc, details)
+	submitterCache.Set(key, submitter)
+
+	return submitter, nil
+}
+
+// cachedSealedReviews returns the reviews sealed in the RFC of the given pull request on behalf of users authenticated
+// through SSO, see models.RFC.SealedReviews, served from cache when possible. There are none unless SSO is enabled
+func cachedSealedReviews(ctx context.Context, git exGit.Git, details *exGit.PullRequestDetails) (
+	map[string]models.ReviewType, error) {
+	if signing.Records == nil {
+		return map[string]models.ReviewType{}, nil
+	}
+	key := fmt.Sprintf("%s@%s", details.RFCIdentifier, details.UpdatedAt)
+	if reviews, ok := sealedReviewsCache.Get(key); ok {
+		return reviews, nil
+	}
+
+	rfc, err := readRFC(ctx, git, details.RFCIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	reviews := rfc.SealedReviews(details.RFCIdentifier, signing.Records.CheckSeal)
+	sealedReviewsCache.Set(key, reviews)
+
+	return reviews, nil
+}
+
+// readRFC retrieves and decodes the current RFC file of the given RFC
+// A *models.IntegrityError is returned if the file is missing or its content cannot be decoded, and
+// tenants.ErrCrossTenant (wrapped) if the RFC belongs to another tenant, see checkTenant
+func readRFC(ctx context.Context, git exGit.Git, rfcIdentifier string) (*models.RFC, error) {
+	content, _, err := git.GetRFCContents(ctx, rfcIdentifier)
+	if err != nil {
+		if errors.Is(err, exGit.ErrRFCFileNotFound) {
+			logging.FromContext(ctx).Error("RFC file is missing", logging.RFC_IDENTIFIER_KEY, rfcIdentifier)
+			return nil, models.NewIntegrityError(rfcIdentifier, models.MissingRFC, err)
+		}
+		return nil, err
+	}
+
+	rfc, err := decodeRFC(ctx, rfcIdentifier, content)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkTenant(ctx, git, rfc); err != nil {
+		return nil, err
+	}
+
+	return rfc, nil
+}
+
+// verifyRFC checks the signatures recorded in the given RFC against its content, see models.RFC.Verify
+// A *models.IntegrityError is returned if they do not match, i.e. its file was tampered with
+func verifyRFC(ctx context.Context, rfcIdentifier string, rfc *models.RFC) error {
+	verification, err := rfc.Verify(rfcIdentifier)
+	if err != nil {
+		return err
+	}
+	if err = verification.Err(); err != nil {
+		logging.FromContext(ctx).Error("RFC signatures do not match its content", logging.RFC_IDENTIFIER_KEY,
+			rfcIdentifier, logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// verifyAuthorSignature returns the ID of the key the given RFC was signed with by its author, empty if the RFC is not
+// signed by its author and author signatures are not required. The signature must be made over the canonical form of
+// the RFC with a key registered to its author, who must be the user making the request if checkAuthor is set
+func verifyAuthorSignature(ctx context.Context, git exGit.Git, rfc *models.RFC, checkAuthor bool) (string, error) {
+	signature := rfc.AuthorSignature
+	if signature == nil {
+		if signing.AuthorSignaturesRequired {
+			return "", models.ErrAuthorSignatureRequired
+		}
+		return "", nil
+	}
+	if signature.Algorithm != "" && signature.Algorithm != models.AUTHOR_SIGNATURE_ALGORITHM {
+		return "", fmt.Errorf("%w: unsupported algorithm %s, expected %s", models.ErrInvalidAuthorSignature,
+			signature.Algorithm, models.AUTHOR_SIGNATURE_ALGORITHM)
+	}
+	if checkAuthor {
+		login, err := userLogin(ctx, git)
+		if err != nil {
+			return "", err
+		}
+		if !strings.EqualFold(*login, signature.Author) {
+			return "", fmt.Errorf("%w: signed by %s, not by %s", models.ErrInvalidAuthorSignature, signature.Author,
+				*login)
+		}
+	}
+	if signing.Authors == nil {
+		return "", fmt.Errorf("%w: no author keys are registered", models.ErrInvalidAuthorSignature)
+	}
+
+	canonical, err := rfc.Canonical()
+	if err != nil {
+		return "", err
+	}
+	keyID, err := signing.Authors.Verify(signature.Author, signature.KeyID, canonical, signature.Signature)
+	if err != nil {
+		logging.FromContext(ctx).Warn("RFC author signature cannot be verified", "author", signature.Author,
+			logging.ERROR_KEY, err)
+		return "", fmt.Errorf("%w: %v", models.ErrInvalidAuthorSignature, err)
+	}
+
+	return keyID, nil
+}
+
+// decodeRFC decodes the given raw RFC file content of the given RFC
+// A *models.IntegrityError is returned if the content is empty or cannot be decoded
+func decodeRFC(ctx context.Context, rfcIdentifier string, content *string) (*models.RFC, error) {
+	if content == nil || strings.TrimSpace(*content) == "" {
+		logging.FromContext(ctx).Error("RFC file is empty", logging.RFC_IDENTIFIER_KEY, rfcIdentifier)
+		return nil, models.NewIntegrityError(rfcIdentifier, models.EmptyRFC, nil)
+	}
+
+	rfc := &models.RFC{}
+	if err := models.Unmarshal([]byte(*content), rfc); err != nil {
+		logging.FromContext(ctx).Error("unable to unmarshal existing RFC content", logging.RFC_IDENTIFIER_KEY, rfcIdentifier,
+			logging.ERROR_KEY, err)
+		return nil, models.NewIntegrityError(rfcIdentifier, models.CorruptRFC, err)
+	}
+
+	return rfc, nil
+}
+
+// latestReviewStates returns the set of states of the most recent review submitted by each reviewer
+func latestReviewStates(reviews []exGit.ReviewDetails) set.Set[string] {
+	states := set.NewSet[string]()
+	for _, review := range latestReviews(reviews) {
+		states.Add(review.State)
+	}
+
+	return states
+}
+
+// latestReviews returns the most recent approval or request for changes submitted by each reviewer, keyed by reviewer
+func latestReviews(reviews []exGit.ReviewDetails) map[string]exGit.ReviewDetails {
+	latest := map[string]exGit.ReviewDetails{}
+	for _, review := range reviews {
+		// comments never override a reviewer's approval or request for changes
+		if review.State == exGit.COMMENTED_STATE {
+			continue
+		}
+		if existing, ok := latest[review.Reviewer]; !ok || !review.SubmittedAt.Before(existing.SubmittedAt) {
+			latest[review.Reviewer] = review
+		}
+	}
+
+	return latest
+}
+
+// checkQuorum returns ErrQuorumNotMet (wrapped) unless the approvals of the given pull request meet the quorum the
+// approval policy requires of the given RFC. Approvals are the latest reviews of each reviewer, its submitter excluded,
+// along with the latest verdicts of the registered external systems. An RFC an external system rejected never meets it
+func checkQuorum(ctx context.Context, git exGit.Git, pr exGit.PullRequest, rfc *models.RFC) error {
+	systems, err := externalApprovals(rfc)
+	if err != nil {
+		logging.FromContext(ctx).Warn("RFC is rejected by an external system", logging.ERROR_KEY, err)
+		return err
+	}
+
+	policy := quorum.Default
+	if policy == nil {
+		return nil
+	}
+	rules := policy.RulesOf(rfc)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	details, err := git.GetPullRequestDetails(pr)
+	if err != nil {
+		return err
+	}
+	reviews, err := git.GetReviews(ctx, pr)
+	if err != nil {
+		return err
+	}
+	reviewDetails, err := git.GetReviewDetails(reviews)
+	if err != nil {
+		return err
+	}
+	submitter := submitterOf(rfc, details)
+	approvers := set.NewSet[string]()
+	for reviewer, review := range latestReviews(reviewDetails) {
+		if review.State == exGit.APPROVED_STATE && reviewer != details.Author && reviewer != submitter {
+			approvers.Add(reviewer)
+		}
+	}
+	// under SSO every review is made on the Git provider by the shared account, the reviews sealed on behalf of each
+	// user in the RFC count instead
+	if signing.Records != nil {
+		for reviewer, base := range rfc.SealedReviews(details.RFCIdentifier, signing.Records.CheckSeal) {
+			if base == models.ApproveReview && reviewer != submitter {
+				approvers.Add(reviewer)
+			}
+		}
+	}
+
+	members := map[string]set.Set[string]{}
+	for _, team := range quorum.Teams(rules).Values() {
+		if members[team], err = git.GetTeamMembers(ctx, team); err != nil {
+			return err
+		}
+	}
+
+	if err = quorum.Evaluate(rules, approvers, systems, members); err != nil {
+		logging.FromContext(ctx).Warn("approval quorum is not met", logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// externalApprovals returns the registered external systems whose latest verdict approves the given RFC as it is
+// signed, ErrQuorumNotMet (wrapped) naming the systems whose latest verdict rejects it if any does. Verdicts of systems
+// that are no longer registered are ignored, as are verdicts not sealed with the secret of their system: they were
+// not recorded by Harmonia but written to the RFC file by someone else
+func externalApprovals(rfc *models.RFC) (set.Set[string], error) {
+	approvals := set.NewSet[string]()
+	rejections := []string{}
+	if signing.Systems == nil {
+		return approvals, nil
+	}
+	for system, verdict := range rfc.ExternalVerdicts(signing.Systems.CheckSeal) {
+		if !models.IsExternalApprover(system) {
+			continue
+		}
+		if verdict.Verdict == models.ApprovedVerdict {
+			approvals.Add(system)
+		} else if verdict.Verdict == models.RejectedVerdict {
+			rejections = append(rejections, system)
+		}
+	}
+	if len(rejections) > 0 {
+		sort.Strings(rejections)
+		return nil, fmt.Errorf("%w: rejected by external system %s", models.ErrQuorumNotMet,
+			strings.Join(rejections, ", "))
+	}
+
+	return approvals, nil
+}
+
+// pullRequestTitle returns the title of the pull request of the given RFC as named by the naming strategy, the
+// default title if the strategy fails so that a submission never fails over its title
+func pullRequestTitle(ctx context.Context, branch string, rfc *models.RFC) string {
+	teams := ownership.Default.OwnersOf(rfc).Values()
+	sort.Strings(teams)
+	subject := naming.NewSubject(branch, rfc, teams)
+
+	title, err := naming.Default.Title(subject)
+	if err != nil {
+		logging.FromContext(ctx).Warn("unable to name pull request of RFC, using the default title",
+			logging.ERROR_KEY, err)
+		return fmt.Sprintf("RFC: %s", branch)
+	}
+	return title
+}
+
+// pullRequestOptions returns the options the pull request of the RFC of the given branch is opened with, its title and
+// the triage the configured triage policy derives from the RFC, if any. The author is never requested to review
+// The description is rendered from the RFC, the provider falling back on a default one if it cannot be rendered
+func pullRequestOptions(ctx context.Context, git exGit.Git, branch string, rfc *models.RFC) exGit.PullRequestOptions {
+	options := exGit.PullRequestOptions{Title: pullRequestTitle(ctx, branch, rfc)}
+	if body, err := description.Default.Render(branch, rfc); err != nil {
+		logging.FromContext(ctx).Warn("unable to render PR description", logging.ERROR_KEY, err)
+	} else {
+		options.Description = body
+	}
+	if triage.Default == nil {
+		return options
+	}
+
+	derived := triage.Default.Of(rfc)
+	options.Labels, options.Assignees, options.TeamReviewers = derived.Labels, derived.Assignees, derived.TeamReviewers
+	if len(derived.Reviewers) == 0 {
+		return options
+	}
+	author := currentUser(ctx, git)
+	for _, reviewer := range derived.Reviewers {
+		if reviewer != author {
+			options.Reviewers = append(options.Reviewers, reviewer)
+		}
+	}
+	return options
+}
+
+// assignReviewers requests a review of the RFC of the given branch from each team that owns it. If an assignment
+// strategy is configured, a single member of each team is picked by it rather than pinging the whole team. The author
+// is never picked, and a team is considered covered if a member was already picked on behalf of another team
+// This is best effort, failures are logged rather than failing the calling operation
+func assignReviewers(ctx context.Context, git exGit.Git, branch string, rfc *models.RFC, author string) {
+	teams := ownership.Default.OwnersOf(rfc).Values()
+	if len(teams) == 0 {
+		return
+	}
+	sort.Strings(teams)
+
+	// without an assignment strategy the owning teams are requested as a whole
+	if assignment.Default == nil {
+		pr, err := git.GetPullRequest(ctx, branch)
+		if err != nil {
+			logging.FromContext(ctx).Info("unable to request team reviewers for RFC", logging.ERROR_KEY, err)
+			return
+		}
+		if err = git.RequestTeamReviewers(ctx, pr, teams); err != nil {
+			logging.FromContext(ctx).Info("unable to request team reviewers for RFC", logging.ERROR_KEY, err)
+			return
+		}
+		recordReviewRequests(ctx, git, branch, nil, teams)
+		return
+	}
+
+	// open review requests are only needed to find the least loaded members
+	var load map[string]int
+	if assignment.Default.Strategy() == assignment.LeastLoaded {
+		var err error
+		if load, err = openReviewRequests(ctx, git); err != nil {
+			logging.FromContext(ctx).Info("unable to determine review load for RFC, assigning in turn", logging.ERROR_KEY, err)
+		}
+	}
+
+	reviewers := set.NewSet[string]()
+	for _, team := range teams {
+		members, err := git.GetTeamMembers(ctx, team)
+		if err != nil {
+			logging.FromContext(ctx).Info("unable to assign a reviewer from team to RFC", "team", team, logging.ERROR_KEY, err)
+			continue
+		}
+
+		covered := false
+		candidates := []string{}
+		for _, member := range members.Values() {
+			covered = covered || reviewers.Contains(member)
+			if member != author {
+				candidates = append(candidates, member)
+			}
+		}
+		if covered {
+			continue
+		}
+		if reviewer, ok := assignment.Default.Assign(team, candidates, load); ok {
+			reviewers.Add(reviewer)
+		}
+	}
+	if reviewers.Size() == 0 {
+		return
+	}
+
+	pr, err := git.GetPullRequest(ctx, branch)
+	if err != nil {
+		logging.FromContext(ctx).Info("unable to request reviewers for RFC", logging.ERROR_KEY, err)
+		return
+	}
+	logins := reviewers.Values()
+	sort.Strings(logins)
+	if err = git.RequestReviewers(ctx, pr, logins); err != nil {
+		logging.FromContext(ctx).Info("unable to request reviewers for RFC", logging.ERROR_KEY, err)
+		return
+	}
+	recordReviewRequests(ctx, git, branch, logins, nil)
+}
+
+// openReviewRequests returns the number of open RFCs each login has been asked to review
+func openReviewRequests(ctx context.Context, git exGit.Git) (map[string]int, error) {
+	prs, err := cachedOpenPullRequests(ctx, git)
+	if err != nil {
+		return nil, err
+	}
+
+	load := map[string]int{}
+	for _, pr := range prs {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewer := range details.RequestedReviewers {
+			load[reviewer]++
+		}
+	}
+
+	return load, nil
+}
+
+// publishEvent broadcasts an RFC lifecycle event of the given type on the event bus, described by the given RFC so
+// notifications can be routed on it. Cached list query responses are dropped as they may no longer reflect the RFC
+func publishEvent(eventType models.EventType, rfcIdentifier string, actor string, message string, rfc *models.RFC) {
+	rfcsCache.Clear()
+	events.Default.Publish(models.Event{
+		Type:          eventType,
+		RFCIdentifier: rfcIdentifier,
+		Actor:         actor,
+		Message:       message,
+		Subject:       eventSubject(rfc),
+	})
+}
+
+// eventSubject returns the action types, target descriptors, owning teams, priority and schema domain of the given RFC,
+// nil if it is nil
+func eventSubject(rfc *models.RFC) *models.EventSubject {
+	if rfc == nil {
+		return nil
+	}
+
+	actionTypes := set.NewSet[models.ActionType]()
+	descriptors := set.NewSet[string]()
+	for _, action := range rfc.Actions {
+		actionTypes.Add(action.ActionType)
+		descriptors.Add(action.Target.TargetDescriptor)
+	}
+	subject := &models.EventSubject{
+		ActionTypes:       actionTypes.Values(),
+		TargetDescriptors: descriptors.Values(),
+		Teams:             ownership.Default.OwnersOf(rfc).Values(),
+		Priority:          string(rfc.Priority),
+		Domain:            rfc.Domain,
+	}
+	sort.Slice(subject.ActionTypes, func(i, j int) bool { return subject.ActionTypes[i] < subject.ActionTypes[j] })
+	sort.Strings(subject.TargetDescriptors)
+	sort.Strings(subject.Teams)
+
+	return subject
+}
+
+// buildFilter builds the given filter of RFCs into a filter of the pull requests of the given git client
+// exGit.ErrInvalidFilter is returned (wrapped) unless exactly one of its name, and, or and not is set at every level,
+// or if a named filter is unknown or given a malformed argument
+func buildFilter(git exGit.Git, filter *models.RFCFilter) (exGit.FilterOption, error) {
+	set := 0
+	for _, isSet := range []bool{filter.Name != "", filter.And != nil, filter.Or != nil, filter.Not != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one of name, and, or and not", exGit.ErrInvalidFilter)
+	}
+
+	switch {
+	case filter.Name != "":
+		return exGit.NewFilter(git, filter.Name, filter.Argument)
+	case filter.Not != nil:
+		negated, err := buildFilter(git, filter.Not)
+		if err != nil {
+			return nil, err
+		}
+		return exGit.Not(negated), nil
+	}
+
+	operands, combine := filter.And, exGit.And
+	if filter.Or != nil {
+		operands, combine = filter.Or, exGit.Or
+	}
+	built := make([]exGit.FilterOption, len(operands))
+	for i := range operands {
+		operand, err := buildFilter(git, &operands[i])
+		if err != nil {
+			return nil, err
+		}
+		built[i] = operand
+	}
+
+	return combine(built...), nil
+}
+
+// seedState brings the given freshly submitted sample RFC to the given seed state, see Seed
+func seedState(ctx context.Context, git exGit.Git, gitReviewer exGit.Git, domain string, rfcIdentifier string,
+	state models.SeedState) error {
+	switch state {
+	case models.ApprovedSeed, models.MergedSeed:
+		review := &models.Review{
+			DomainSelector:  models.DomainSelector{Domain: domain},
+			RFCIdentifier:   rfcIdentifier,
+			Type:            string(models.ApproveReview),
+			TopLevelComment: "Approved by the seed tool",
+		}
+		if _, err := ReviewRequest(ctx, gitReviewer, git, review); err != nil {
+			return err
+		}
+		if state == models.MergedSeed {
+			merge := &models.Merge{DomainSelector: models.DomainSelector{Domain: domain}, RFCIdentifier: rfcIdentifier}
+			if _, err := MergeRequest(ctx, git, merge); err != nil {
+				return err
+			}
+		}
+	case models.FailedLoadSeed:
+		pr, err := git.GetPullRequest(ctx, rfcIdentifier)
+		if err != nil {
+			return err
+		}
+		rfc, err := readRFC(ctx, git, rfcIdentifier)
+		if err != nil {
+			return err
+		}
+		return recordLoadStatus(ctx, git, pr, rfc, rfcIdentifier, FAILED_STATUS, currentUser(ctx, git), nil)
+	}
+
+	return nil
+}
+
+// userLogin returns the login of the user making the request of the given context: the Git login of the identity they
+// authenticated through SSO with, or the login of the given git client otherwise
+func userLogin(ctx context.Context, git exGit.Git) (*string, error) {
+	if identity := oidc.FromContext(ctx); identity != nil {
+		login := identity.Login
+		return &login, nil
+	}
+	return git.GetUserLogin(ctx)
+}
+
+// currentUser returns the login of the user making the request, see userLogin, or an empty string if it cannot be
+// determined
+// This is only meant for attribution purposes where a failed lookup should not fail the calling operation
+func currentUser(ctx context.Context, git exGit.Git) string {
+	login, err := userLogin(ctx, git)
+	if err != nil || login == nil {
+		return ""
+	}
+	return *login
+}
+
+// sealSubmitter seals the submitter recorded in the given RFC of the given identifier if the user submitting it is
+// authenticated through SSO, see models.RFC.SealSubmitter. Any seal the RFC came with is dropped
+func sealSubmitter(ctx context.Context, rfc *models.RFC, rfcIdentifier string) error {
+	rfc.SubmitterSeal = ""
+	if oidc.FromContext(ctx) == nil || signing.Records == nil {
+		return nil
+	}
+	return rfc.SealSubmitter(rfcIdentifier, signing.Records.Seal)
+}
+
+// submitterOf returns the login of the user who submitted the given RFC, of the given pull request. Pull requests are
+// authored by the account of the token they are opened with, which under SSO is shared by every user, so the submitter
+// sealed in the RFC is preferred. The author of the pull request is returned for RFCs without one, e.g. those not
+// submitted through SSO
+func submitterOf(rfc *models.RFC, details *exGit.PullRequestDetails) string {
+	if signing.Records != nil {
+		if submitter := rfc.SealedSubmitter(details.RFCIdentifier, signing.Records.CheckSeal); submitter != "" {
+			return submitter
+		}
+	}
+	return details.Author
 }
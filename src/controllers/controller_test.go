@@ -3,14 +3,43 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/assignment"
+	"harmonia-example.io/src/services/authz"
+	"harmonia-example.io/src/services/codegen"
+	"harmonia-example.io/src/services/events"
 	exGit "harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/loader"
+	"harmonia-example.io/src/services/loadstatus"
+	"harmonia-example.io/src/services/moderation"
+	"harmonia-example.io/src/services/naming"
+	"harmonia-example.io/src/services/oidc"
+	"harmonia-example.io/src/services/ownership"
+	"harmonia-example.io/src/services/quorum"
+	"harmonia-example.io/src/services/reservations"
+	"harmonia-example.io/src/services/reviewrequests"
+	"harmonia-example.io/src/services/schemas"
 	"harmonia-example.io/src/services/set"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tenants"
+	"harmonia-example.io/src/services/triage"
 )
 
 // gitMockCreator is used to create mocks that implement exGit.Git
@@ -30,20 +59,43 @@ type mockGit struct {
 	createFile        func(ctx context.Context, branch string, directory string, data *models.RFC) error
 	createPullRequest func(ctx context.Context, branch string, baseBranch string) error
 	getRFCContents    func(ctx context.Context, branch string) (*string, *string, error)
+	getRFCContentsAt  func(ctx context.Context, branch string, ref string) (*string, error)
+	getRFCHistory     func(ctx context.Context, branch string) ([]exGit.RFCRevision, error)
+	restoreFile       func(ctx context.Context, pr exGit.PullRequest, content string, message string) error
 	updateFile        func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error
 	getPullRequest    func(ctx context.Context, branch string) (exGit.PullRequest, error)
 	getPullRequests   func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
 		exGit.PullRequests, error)
 	getMergeability        func(ctx context.Context, pr exGit.PullRequest) (*bool, error)
+	explainMergeability    func(ctx context.Context, pr exGit.PullRequest) (*models.Mergeability, error)
+	closePullRequest       func(ctx context.Context, pr exGit.PullRequest) error
 	mergePullRequest       func(ctx context.Context, pr exGit.PullRequest) (*string, error)
 	getReviews             func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error)
 	createReview           func(ctx context.Context, pr exGit.PullRequest, data *models.Review) error
 	dismissApprovalReviews func(ctx context.Context, reviews exGit.PullRequestReviews, pr exGit.PullRequest) error
+	getReviewComments      func(ctx context.Context, pr exGit.PullRequest) ([]exGit.ReviewComment, error)
+	editReviewComment      func(ctx context.Context, pr exGit.PullRequest, id string, body string) error
+	deleteReviewComment    func(ctx context.Context, pr exGit.PullRequest, id string) error
 	getUserLogin           func(ctx context.Context) (*string, error)
 	getUserTeams           func(ctx context.Context) (set.Set[string], error)
+	getTeamMembers         func(ctx context.Context, team string) (set.Set[string], error)
+	requestReviewers       func(ctx context.Context, pr exGit.PullRequest, reviewers []string) error
+	requestTeamReviewers   func(ctx context.Context, pr exGit.PullRequest, teams []string) error
 	createTag              func(ctx context.Context, sha string, name string) error
+	createDeployment       func(ctx context.Context, pr exGit.PullRequest, environment string) (*string, error)
+	getDeploymentStatus    func(ctx context.Context, deploymentID string) (*exGit.DeploymentStatus, error)
+	getMissingPermissions  func(ctx context.Context) ([]string, error)
+	getBranchProtection    func(ctx context.Context) (*exGit.BranchProtection, error)
+	getArchiveIndex        func(ctx context.Context) (*string, error)
+	getSchemas             func(ctx context.Context, directory string) (map[string]string, error)
+	archiveRFCs            func(ctx context.Context, rfcIdentifiers []string, index string, message string) error
+	commitFiles            func(ctx context.Context, files map[string]string, message string) (bool, error)
 
-	getIdsAndTitles func(prs exGit.PullRequests) (exGit.IdsAndTitles, error)
+	capabilities          func() models.Capabilities
+	getIdsAndTitles       func(prs exGit.PullRequests) (exGit.IdsAndTitles, error)
+	getPullRequestDetails func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error)
+	getReviewDetails      func(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error)
+	buildLinks            func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links
 
 	withOwner func(owner *string) exGit.FilterOption
 	isMerged  func(merged *bool) exGit.FilterOption
@@ -83,7 +135,8 @@ func (mg *mockGit) CreateFile(ctx context.Context, branch string, directory stri
 }
 
 // CreatePullRequest calls mg.createPullRequest
-func (mg *mockGit) CreatePullRequest(ctx context.Context, branch string, baseBranch string) error {
+func (mg *mockGit) CreatePullRequest(ctx context.Context, branch string, baseBranch string,
+	options exGit.PullRequestOptions) error {
 	// ignore ctx for mocking purposes
 	// we are ignoring ctx because it is altered by the underlying method and we would have to build one to match
 	mg.On("CreatePullRequest", branch, baseBranch).Return()
@@ -102,6 +155,26 @@ func (mg *mockGit) GetRFCContents(ctx context.Context, branch string) (*string,
 	return mg.getRFCContents(ctx, branch)
 }
 
+// GetRFCContentsAt calls mg.getRFCContentsAt
+func (mg *mockGit) GetRFCContentsAt(ctx context.Context, branch string, ref string) (*string, error) {
+	return mg.getRFCContentsAt(ctx, branch, ref)
+}
+
+// GetRFCHistory calls mg.getRFCHistory
+func (mg *mockGit) GetRFCHistory(ctx context.Context, branch string) ([]exGit.RFCRevision, error) {
+	return mg.getRFCHistory(ctx, branch)
+}
+
+// RestoreFile calls mg.restoreFile
+func (mg *mockGit) RestoreFile(ctx context.Context, pr exGit.PullRequest, content string, message string) error {
+	// ignore ctx for mocking purposes
+	// we are ignoring ctx because it is altered by the underlying method and we would have to build one to match
+	mg.On("RestoreFile", pr, content, message).Return()
+	mg.Called(pr, content, message)
+
+	return mg.restoreFile(ctx, pr, content, message)
+}
+
 // UpdateFile calls mg.updateFile
 func (mg *mockGit) UpdateFile(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
 	// ignore ctx for mocking purposes
@@ -134,6 +207,27 @@ func (mg *mockGit) GetMergeability(ctx context.Context, pr exGit.PullRequest) (*
 	return mg.getMergeability(ctx, pr)
 }
 
+// ExplainMergeability calls mg.explainMergeability, or explains the result of mg.getMergeability if it is not set
+func (mg *mockGit) ExplainMergeability(ctx context.Context, pr exGit.PullRequest) (*models.Mergeability, error) {
+	if mg.explainMergeability != nil {
+		return mg.explainMergeability(ctx, pr)
+	}
+	mergeable, err := mg.getMergeability(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Mergeability{Mergeable: *mergeable}, nil
+}
+
+// ClosePullRequest calls mg.closePullRequest
+func (mg *mockGit) ClosePullRequest(ctx context.Context, pr exGit.PullRequest) error {
+	// ignore ctx for mocking purposes
+	mg.On("ClosePullRequest", pr).Return()
+	mg.Called(pr)
+
+	return mg.closePullRequest(ctx, pr)
+}
+
 // MergePullRequest calls mg.mergePullRequest
 func (mg *mockGit) MergePullRequest(ctx context.Context, pr exGit.PullRequest) (*string, error) {
 	return mg.mergePullRequest(ctx, pr)
@@ -155,6 +249,43 @@ func (mg *mockGit) DismissApprovalReviews(ctx context.Context, reviews exGit.Pul
 	return mg.dismissApprovalReviews(ctx, reviews, pr)
 }
 
+// Repository returns the zero Repository, mocks do not operate on a tracking repository
+func (mg *mockGit) Repository() exGit.Repository {
+	return exGit.Repository{}
+}
+
+// Capabilities calls mg.capabilities, or returns every capability if it is not set
+func (mg *mockGit) Capabilities() models.Capabilities {
+	if mg.capabilities != nil {
+		return mg.capabilities()
+	}
+	return models.Capabilities{Provider: "mock", Labels: true, DraftPullRequests: true, TeamReviewers: true,
+		RequiredApprovals: true, CheckRuns: true, RequiredContexts: true, DeploymentGates: true, Webhooks: true}
+}
+
+// GetReviewComments calls mg.getReviewComments
+func (mg *mockGit) GetReviewComments(ctx context.Context, pr exGit.PullRequest) ([]exGit.ReviewComment, error) {
+	return mg.getReviewComments(ctx, pr)
+}
+
+// EditReviewComment calls mg.editReviewComment
+func (mg *mockGit) EditReviewComment(ctx context.Context, pr exGit.PullRequest, id string, body string) error {
+	// ignore ctx for mocking purposes
+	mg.On("EditReviewComment", pr, id, body).Return()
+	mg.Called(pr, id, body)
+
+	return mg.editReviewComment(ctx, pr, id, body)
+}
+
+// DeleteReviewComment calls mg.deleteReviewComment
+func (mg *mockGit) DeleteReviewComment(ctx context.Context, pr exGit.PullRequest, id string) error {
+	// ignore ctx for mocking purposes
+	mg.On("DeleteReviewComment", pr, id).Return()
+	mg.Called(pr, id)
+
+	return mg.deleteReviewComment(ctx, pr, id)
+}
+
 // GetUserLogin calls mg.getUserLogin
 func (mg *mockGit) GetUserLogin(ctx context.Context) (*string, error) {
 	return mg.getUserLogin(ctx)
@@ -165,16 +296,98 @@ func (mg *mockGit) GetUserTeams(ctx context.Context) (set.Set[string], error) {
 	return mg.getUserTeams(ctx)
 }
 
+// GetTeamMembers calls mg.getTeamMembers
+func (mg *mockGit) GetTeamMembers(ctx context.Context, team string) (set.Set[string], error) {
+	return mg.getTeamMembers(ctx, team)
+}
+
+// RequestReviewers calls mg.requestReviewers
+func (mg *mockGit) RequestReviewers(ctx context.Context, pr exGit.PullRequest, reviewers []string) error {
+	// ignore ctx for mocking purposes
+	mg.On("RequestReviewers", pr, reviewers).Return()
+	mg.Called(pr, reviewers)
+
+	return mg.requestReviewers(ctx, pr, reviewers)
+}
+
+// RequestTeamReviewers calls mg.requestTeamReviewers
+func (mg *mockGit) RequestTeamReviewers(ctx context.Context, pr exGit.PullRequest, teams []string) error {
+	// ignore ctx for mocking purposes
+	mg.On("RequestTeamReviewers", pr, teams).Return()
+	mg.Called(pr, teams)
+
+	return mg.requestTeamReviewers(ctx, pr, teams)
+}
+
 // CreateTag calls mg.createTag
 func (mg *mockGit) CreateTag(ctx context.Context, sha string, name string) error {
 	return mg.createTag(ctx, sha, name)
 }
 
+// CreateDeployment calls mg.createDeployment
+func (mg *mockGit) CreateDeployment(ctx context.Context, pr exGit.PullRequest, environment string) (*string, error) {
+	// ignore ctx for mocking purposes
+	mg.On("CreateDeployment", pr, environment).Return()
+	mg.Called(pr, environment)
+
+	return mg.createDeployment(ctx, pr, environment)
+}
+
+// GetDeploymentStatus calls mg.getDeploymentStatus
+func (mg *mockGit) GetDeploymentStatus(ctx context.Context, deploymentID string) (*exGit.DeploymentStatus, error) {
+	return mg.getDeploymentStatus(ctx, deploymentID)
+}
+
+// GetMissingPermissions calls mg.getMissingPermissions
+func (mg *mockGit) GetMissingPermissions(ctx context.Context) ([]string, error) {
+	return mg.getMissingPermissions(ctx)
+}
+
+// GetBranchProtection calls mg.getBranchProtection
+func (mg *mockGit) GetBranchProtection(ctx context.Context) (*exGit.BranchProtection, error) {
+	return mg.getBranchProtection(ctx)
+}
+
+// GetArchiveIndex calls mg.getArchiveIndex
+func (mg *mockGit) GetArchiveIndex(ctx context.Context) (*string, error) {
+	return mg.getArchiveIndex(ctx)
+}
+
+// GetSchemas calls mg.getSchemas
+func (mg *mockGit) GetSchemas(ctx context.Context, directory string) (map[string]string, error) {
+	return mg.getSchemas(ctx, directory)
+}
+
+// ArchiveRFCs calls mg.archiveRFCs
+func (mg *mockGit) ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) error {
+	return mg.archiveRFCs(ctx, rfcIdentifiers, index, message)
+}
+
+// CommitFiles calls mg.commitFiles
+func (mg *mockGit) CommitFiles(ctx context.Context, files map[string]string, message string) (bool, error) {
+	return mg.commitFiles(ctx, files, message)
+}
+
 // GetIdsAndTitles calls mg.getIdsAndTitles
 func (mg *mockGit) GetIdsAndTitles(prs exGit.PullRequests) (exGit.IdsAndTitles, error) {
 	return mg.getIdsAndTitles(prs)
 }
 
+// GetPullRequestDetails calls mg.getPullRequestDetails
+func (mg *mockGit) GetPullRequestDetails(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+	return mg.getPullRequestDetails(pr)
+}
+
+// GetReviewDetails calls mg.getReviewDetails
+func (mg *mockGit) GetReviewDetails(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+	return mg.getReviewDetails(reviews)
+}
+
+// BuildLinks calls mg.buildLinks
+func (mg *mockGit) BuildLinks(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+	return mg.buildLinks(rfcIdentifier, pr, tagged)
+}
+
 // WithOwner calls mg.withOwner
 func (mg *mockGit) WithOwner(owner *string) exGit.FilterOption {
 	return mg.withOwner(owner)
@@ -197,6 +410,35 @@ func getStringPointer(target string) *string {
 	return &target
 }
 
+// signed returns the given RFC file content with the signatures of the RFC and of its actions that are not volatile
+// recorded, as Harmonia records them when the RFC is submitted
+func signed(content string) string {
+	rfc := &models.RFC{}
+	if err := json.Unmarshal([]byte(content), rfc); err != nil {
+		panic(err)
+	}
+	for _, action := range rfc.Actions {
+		if action.IsVolatile() {
+			continue
+		}
+		signature, _ := action.ToSha()
+		action.Signature = *signature
+	}
+	_ = rfc.Sign()
+
+	signedContent, _ := json.Marshal(rfc)
+	return string(signedContent)
+}
+
+// signatureOf returns the signature recorded in the given RFC file content
+func signatureOf(content string) string {
+	rfc := &models.RFC{}
+	if err := json.Unmarshal([]byte(content), rfc); err != nil {
+		panic(err)
+	}
+	return rfc.Signature
+}
+
 // setup returns common variables used across many tests
 // returns an identifier and a RFCIdentifierCreator
 func setup() (string, models.RFCIdentifierCreator) {
@@ -234,6 +476,9 @@ func TestSubmitRequest(t *testing.T) {
 	// initialize
 	identifier, createRFCIdentifier := setup()
 	CreateRFCIdentifier = createRFCIdentifier
+	gul := func(ctx context.Context) (*string, error) {
+		return getStringPointer("tstark"), nil
+	}
 
 	// initialize test cases
 	testCases := []struct {
@@ -249,7 +494,7 @@ func TestSubmitRequest(t *testing.T) {
 				cb := func(ctx context.Context, branch string, baseBranch string) error {
 					return fmt.Errorf("create branch error")
 				}
-				return &mockGit{createBranch: cb}
+				return &mockGit{createBranch: cb, getUserLogin: gul}
 			},
 			data:        &models.RFC{},
 			expected:    nil,
@@ -273,7 +518,7 @@ func TestSubmitRequest(t *testing.T) {
 				db := func(ctx context.Context, branch string) error {
 					return nil
 				}
-				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db}
+				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db, getUserLogin: gul}
 			},
 			data: &models.RFC{
 				Actions: models.Actions{
@@ -308,10 +553,12 @@ func TestSubmitRequest(t *testing.T) {
 									Data: map[string]interface{}{
 										"id": "123",
 									},
-									Signature: "49991c32fc001d99b9c5908005509686aff6ba7d16a14cd3ecaebc5d6d916cf0",
+									Signature: "11fc6041e46e6bc53d812385c72953cbeedd1f61d816af9b4cf509be3093eff6",
 								},
 							},
-							Signature: "7fe5c325b99df102515c1f8d5e1cdde084dc9beabec4a346f07dcd90d4ddb4b1",
+							Signature:        "9883f54ac630c6a756868d35f365d403b4431809709011db81d5235ead8863b3",
+							SignatureVersion: models.SIGNATURE_VERSION,
+							Submitter:        "tstark",
 						},
 					},
 				},
@@ -329,7 +576,7 @@ func TestSubmitRequest(t *testing.T) {
 				db := func(ctx context.Context, branch string) error {
 					return fmt.Errorf("delete branch error")
 				}
-				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db}
+				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db, getUserLogin: gul}
 			},
 			// already asserted call in test case above
 			data:        &models.RFC{},
@@ -357,7 +604,7 @@ func TestSubmitRequest(t *testing.T) {
 				cpr := func(ctx context.Context, branch string, baseBranch string) error {
 					return fmt.Errorf("create pull request error")
 				}
-				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db, createPullRequest: cpr}
+				return &mockGit{createBranch: cb, createFile: cf, deleteBranch: db, createPullRequest: cpr, getUserLogin: gul}
 			},
 			data:        &models.RFC{},
 			expected:    nil,
@@ -384,7 +631,7 @@ func TestSubmitRequest(t *testing.T) {
 				cpr := func(ctx context.Context, branch string, baseBranch string) error {
 					return fmt.Errorf("create pull request error")
 				}
-				return &mockGit{createBranch: cb, deleteBranch: db, createFile: cf, createPullRequest: cpr}
+				return &mockGit{createBranch: cb, deleteBranch: db, createFile: cf, createPullRequest: cpr, getUserLogin: gul}
 			},
 			data:        &models.RFC{},
 			expected:    nil,
@@ -407,7 +654,13 @@ func TestSubmitRequest(t *testing.T) {
 				cpr := func(ctx context.Context, branch string, baseBranch string) error {
 					return nil
 				}
-				return &mockGit{createBranch: cb, deleteBranch: db, createFile: cf, createPullRequest: cpr}
+				return &mockGit{
+					createBranch:      cb,
+					deleteBranch:      db,
+					createFile:        cf,
+					createPullRequest: cpr,
+					getUserLogin:      gul,
+				}
 			},
 			data:          &models.RFC{},
 			expected:      &identifier,
@@ -420,7 +673,7 @@ func TestSubmitRequest(t *testing.T) {
 	for _, testCase := range testCases {
 		gitInstance := testCase.mockCreator()
 
-		actual, actualErr := SubmitRequest(context.Background(), gitInstance, testCase.data)
+		actual, actualErr := SubmitRequest(context.Background(), gitInstance, testCase.data, true)
 
 		commonAsserter(t, testCase.expected, actual, testCase.expectedErr, actualErr)
 		if len(testCase.expectedCalls) > 0 {
@@ -436,11 +689,298 @@ func TestSubmitRequest(t *testing.T) {
 	}
 }
 
+// TestSubmitRequestDuplicate tests that an RFC proposing the same change as an open RFC is only submitted if duplicates
+// are allowed
+func TestSubmitRequestDuplicate(t *testing.T) {
+	// initialize
+	_, createRFCIdentifier := setup()
+	CreateRFCIdentifier = createRFCIdentifier
+	contents := map[string]string{
+		"different": `{"actions": [{"actionType": "add", "target": {"targetType": "item", "targetDescriptor": "Event"},
+			"data": {"id": "Other"}}]}`,
+		// the same change, reviewed and with load targets listed in another order
+		"identical": `{"signature": "abc", "loadTargets": ["search", "primary"], "actions": [
+			{"actionType": "add", "target": {"targetType": "item", "targetDescriptor": "Event"}, "signature": "def",
+				"data": {"id": "MyEvent", "version": 2}},
+			{"actionType": "approve", "target": {"targetType": "rfc", "lookupKey": "signature", "lookupValue": "abc"},
+				"data": {"reviewer": "tstark"}}]}`,
+		"corrupt": `{`,
+	}
+	mockCreator := func() *mockGit {
+		gprs := func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (exGit.PullRequests,
+			error) {
+			return exGit.PullRequests{"corrupt", "different", "identical"}, nil
+		}
+		gprd := func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return &exGit.PullRequestDetails{RFCIdentifier: fmt.Sprintf("duplicate-%s", pr)}, nil
+		}
+		grc := func(ctx context.Context, branch string) (*string, *string, error) {
+			content := contents[strings.TrimPrefix(branch, "duplicate-")]
+			return &content, nil, nil
+		}
+		cb := func(ctx context.Context, branch string, baseBranch string) error {
+			return fmt.Errorf("create branch error")
+		}
+		gul := func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil }
+		return &mockGit{getPullRequests: gprs, getPullRequestDetails: gprd, getRFCContents: grc, createBranch: cb,
+			getUserLogin: gul}
+	}
+	data := func() *models.RFC {
+		return &models.RFC{
+			LoadTargets: []string{"primary", "search"},
+			Actions: models.Actions{{
+				ActionType: models.AddAction,
+				Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+				Data:       map[string]interface{}{"version": 2, "id": "MyEvent"},
+			}},
+		}
+	}
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	loader.Default.Register("search", loader.Placeholder("search"))
+	defer func() { loader.Default = defaultLoaders }()
+
+	// act
+	duplicateGit := mockCreator()
+	_, duplicateErr := SubmitRequest(context.Background(), duplicateGit, data(), false)
+	_, allowedErr := SubmitRequest(context.Background(), mockCreator(), data(), true)
+
+	// assert
+	var duplicate *models.DuplicateError
+	if !errors.As(duplicateErr, &duplicate) || duplicate.RFCIdentifier != "duplicate-identical" {
+		t.Errorf("expected a duplicate of duplicate-identical, got %v", duplicateErr)
+	}
+	duplicateGit.AssertNotCalled(t, "CreateBranch", mock.Anything, mock.Anything)
+	if allowedErr == nil || allowedErr.Error() != "create branch error" {
+		t.Errorf("expected the allowed duplicate to be submitted, got %v", allowedErr)
+	}
+}
+
+// TestSubmitWithReceipt tests that receipts echo the content the RFC was signed as and are signed by the server
+func TestSubmitWithReceipt(t *testing.T) {
+	// initialize
+	identifier, createRFCIdentifier := setup()
+	CreateRFCIdentifier = createRFCIdentifier
+	succeed := func(ctx context.Context, branch string, baseBranch string) error { return nil }
+	mg := &mockGit{
+		createBranch: succeed,
+		createFile: func(ctx context.Context, branch string, directory string, data *models.RFC) error {
+			return nil
+		},
+		createPullRequest: succeed,
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return branch, nil
+		},
+		getUserLogin: func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			return &models.Links{PullRequest: "https://github.com/owner/repo/pull/1"}
+		},
+	}
+	data := &models.RFC{Actions: models.Actions{{
+		ActionType: models.AddAction,
+		Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+		Data:       map[string]interface{}{"id": "MyEvent"},
+	}}}
+	signer, err := signing.NewReceiptSigner(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defaultReceipts := signing.Receipts
+	signing.Receipts = signer
+	defer func() { signing.Receipts = defaultReceipts }()
+
+	// act
+	actual, err := SubmitWithReceipt(context.Background(), mg, data, true)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	receipt := actual.Receipt
+	if actual.RFCIdentifier != identifier || receipt.Branch != identifier ||
+		receipt.PullRequestURL != actual.Links.PullRequest {
+		t.Errorf("unexpected receipt: %+v", receipt)
+	}
+	hash := sha256.Sum256(receipt.RFC)
+	if hex.EncodeToString(hash[:]) != receipt.Signature || receipt.Signature != data.Signature {
+		t.Errorf("expected the signature %s to be the hash of the echoed RFC %s", receipt.Signature, receipt.RFC)
+	}
+	if err = signing.VerifyReceipt(signer.PublicKey(), receipt.SignedContent(), receipt.ServerSignature); err != nil {
+		t.Errorf("expected the receipt to be signed by the server: %v", err)
+	}
+}
+
+// TestSubmitBatch tests that each RFC of a batch is submitted on its own and reported on in the order of the batch
+func TestSubmitBatch(t *testing.T) {
+	// initialize
+	var created atomic.Int64
+	CreateRFCIdentifier = func() *string {
+		identifier := fmt.Sprintf("batch-%d", created.Add(1))
+		return &identifier
+	}
+	succeed := func(ctx context.Context, branch string, baseBranch string) error { return nil }
+	mg := &mockGit{
+		createBranch: succeed,
+		createFile: func(ctx context.Context, branch string, directory string, data *models.RFC) error {
+			if data.Actions[0].Data["id"] == "Broken" {
+				return fmt.Errorf("create file error")
+			}
+			return nil
+		},
+		createPullRequest: succeed,
+		deleteBranch:      func(ctx context.Context, branch string) error { return nil },
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return branch, nil
+		},
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return exGit.PullRequests{}, nil
+		},
+		getUserLogin: func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			return &models.Links{PullRequest: "https://github.com/owner/repo/pull/" + rfcIdentifier}
+		},
+	}
+	rfc := func(domain string, id string) *models.RFC {
+		return &models.RFC{Domain: domain, Actions: models.Actions{{
+			ActionType: models.AddAction,
+			Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+			Data:       map[string]interface{}{"id": id},
+		}}}
+	}
+	batch := &models.SubmitBatch{RFCs: []*models.RFC{
+		rfc("", "First"), rfc("", "Broken"), rfc("", "First"), rfc("catalog", "Second"), rfc("", "Second"),
+	}}
+
+	// act
+	identifiers, failures, err := SubmitBatch(context.Background(), mg, batch, false)
+	_, _, emptyErr := SubmitBatch(context.Background(), mg, &models.SubmitBatch{}, false)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identifiers[0] == nil || identifiers[4] == nil || identifiers[0].RFCIdentifier == identifiers[4].RFCIdentifier ||
+		identifiers[4].Receipt == nil {
+		t.Errorf("expected the valid RFCs to be submitted on branches of their own, got %v and %v", identifiers[0],
+			identifiers[4])
+	}
+	if failures[0] != nil || failures[4] != nil {
+		t.Errorf("unexpected errors: %v, %v", failures[0], failures[4])
+	}
+	if identifiers[1] != nil || failures[1] == nil || failures[1].Error() != "create file error" {
+		t.Errorf("expected the failure of the RFC to be reported, got %v", failures[1])
+	}
+	if identifiers[2] != nil || !errors.Is(failures[2], models.ErrDuplicateInBatch) {
+		t.Errorf("expected the duplicate within the batch to fail, got %v", failures[2])
+	}
+	if identifiers[3] != nil || !errors.Is(failures[3], models.ErrInvalidBatch) {
+		t.Errorf("expected the RFC of another domain to fail, got %v", failures[3])
+	}
+	if !errors.Is(emptyErr, models.ErrInvalidBatch) {
+		t.Errorf("expected empty batches to be rejected, got %v", emptyErr)
+	}
+}
+
+// TestPullRequestTitle tests that pull requests are named by the naming strategy, with the default title if it fails
+func TestPullRequestTitle(t *testing.T) {
+	// initialize
+	strategy, err := naming.NewTemplate(`RFC-{{.Identifier}}: {{.Title}} ({{.Team}})`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	failing, err := naming.NewTemplate(`{{.Unknown}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defaultOwnership, defaultNaming := ownership.Default, naming.Default
+	ownership.Default = ownership.New(map[string][]string{"EventType": {"team-data"}})
+	defer func() { ownership.Default, naming.Default = defaultOwnership, defaultNaming }()
+	rfc := &models.RFC{Title: "Add EventType X", Actions: models.Actions{{
+		ActionType: models.AddAction,
+		Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "EventType"},
+	}}}
+
+	// act
+	naming.Default = strategy
+	named := pullRequestTitle(context.Background(), "1234", rfc)
+	naming.Default = failing
+	fallback := pullRequestTitle(context.Background(), "1234", rfc)
+
+	// assert
+	if named != "RFC-1234: Add EventType X (team-data)" {
+		t.Errorf("unexpected title: %s", named)
+	}
+	if fallback != "RFC: 1234" {
+		t.Errorf("expected the default title, got %s", fallback)
+	}
+}
+
+// TestValidateRequest tests that the submission checks are reported without creating anything
+func TestValidateRequest(t *testing.T) {
+	// initialize
+	content := `{"loadTargets": ["archive"], "actions": [{"actionType": "add",
+		"target": {"targetType": "item", "targetDescriptor": "Event"}, "data": {"id": "MyEvent"}}]}`
+	mockCreator := func() *mockGit {
+		gprs := func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (exGit.PullRequests,
+			error) {
+			return exGit.PullRequests{"open"}, nil
+		}
+		gprd := func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return &exGit.PullRequestDetails{RFCIdentifier: "validate-open"}, nil
+		}
+		grc := func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, nil, nil
+		}
+		return &mockGit{getPullRequests: gprs, getPullRequestDetails: gprd, getRFCContents: grc}
+	}
+	data := func() *models.RFC {
+		return &models.RFC{
+			LoadTargets: []string{"archive"},
+			Actions: models.Actions{{
+				ActionType: models.AddAction,
+				Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+				Data:       map[string]interface{}{"id": "MyEvent"},
+			}},
+		}
+	}
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	defer func() { loader.Default = defaultLoaders }()
+
+	// act
+	validateGit := mockCreator()
+	duplicate, duplicateErr := ValidateRequest(context.Background(), validateGit, data(), false)
+	allowed, allowedErr := ValidateRequest(context.Background(), mockCreator(), data(), true)
+
+	// assert
+	if duplicateErr != nil || allowedErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", duplicateErr, allowedErr)
+	}
+	if duplicate.Valid || len(duplicate.Errors) != 2 || duplicate.Errors[0].Field != "loadTargets" ||
+		duplicate.Errors[1].Field != "actions" {
+		t.Errorf("expected load target and duplicate errors, got %+v", duplicate)
+	}
+	if allowed.Valid || len(allowed.Errors) != 1 || allowed.Errors[0].Field != "loadTargets" {
+		t.Errorf("expected only a load target error, got %+v", allowed)
+	}
+	validateGit.AssertNotCalled(t, "CreateBranch", mock.Anything, mock.Anything)
+}
+
 // TestUpdateRequest tests the UpdateRequest function
 func TestUpdateRequest(t *testing.T) {
 	// initialize
 	identifier, createRFCIdentifier := setup()
 	CreateRFCIdentifier = createRFCIdentifier
+	existing := signed(`{}`)
+	existingWithComment := signed(`{
+		"actions": [
+			{"actionType": "comment", "data": {"test": true}},
+			{"actionType": "add", "data": {"test": true}}
+		]
+	}`)
 
 	// initialize test cases
 	testCases := []struct {
@@ -496,9 +1036,40 @@ func TestUpdateRequest(t *testing.T) {
 				}
 				return &mockGit{getPullRequest: gpr, getRFCContents: grfc}
 			},
+			data:     &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier},
+			expected: nil,
+			expectedErr: getStringPointer("RFC test-identifier file is corrupt: " +
+				"invalid character 'j' looking for beginning of value at $ (offset 1, near `junk-data`)"),
+			expectedCalls: []call{},
+		},
+		// signature of the RFC the update was made from missing
+		{
+			mockCreator: func() exGit.Git {
+				gpr := func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return &existing, getStringPointer("junk-sha"), nil
+				}
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc}
+			},
 			data:          &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier},
 			expected:      nil,
-			expectedErr:   getStringPointer("invalid character 'j' looking for beginning of value"),
+			expectedErr:   getStringPointer("signature of the updated RFC required: RFC test-identifier"),
+			expectedCalls: []call{},
+		},
+		// RFC updated since the update was made from it
+		{
+			mockCreator: func() exGit.Git {
+				gpr := func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return &existing, getStringPointer("junk-sha"), nil
+				}
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc}
+			},
+			data: &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier,
+				Signature: "stale-signature"},
+			expected: nil,
+			expectedErr: getStringPointer("RFC test-identifier was updated since it was read, its signature is now " +
+				signatureOf(existing)),
 			expectedCalls: []call{},
 		},
 		// failed to update file
@@ -506,20 +1077,15 @@ func TestUpdateRequest(t *testing.T) {
 			mockCreator: func() exGit.Git {
 				gpr := func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
 				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
-					existingRfc := `{
-						"actions": [
-							{"actionType": "comment", "data": {"test": true}},
-							{"actionType": "add", "data": {"test": true}}
-						]
-					}`
-					return &existingRfc, getStringPointer("junk-sha"), nil
+					return &existingWithComment, getStringPointer("junk-sha"), nil
 				}
 				uf := func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
 					return fmt.Errorf("error updating file")
 				}
 				return &mockGit{getPullRequest: gpr, getRFCContents: grfc, updateFile: uf}
 			},
-			data:        &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier},
+			data: &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier,
+				Signature: signatureOf(existingWithComment)},
 			expected:    nil,
 			expectedErr: getStringPointer("error updating file"),
 			expectedCalls: []call{
@@ -537,7 +1103,8 @@ func TestUpdateRequest(t *testing.T) {
 									Signature: "",
 								},
 							},
-							Signature: "a02e316df3bc6f8b3da979fd5cdb5c070962fc03c8fbd46345a7eac682a26f0a",
+							Signature:        "7d357b0ef1f85ba71c5ccebb6671b0c34f4b3950f5b21d2af7b4a3d4e9dcd570",
+							SignatureVersion: models.SIGNATURE_VERSION,
 						},
 					},
 				},
@@ -548,8 +1115,7 @@ func TestUpdateRequest(t *testing.T) {
 			mockCreator: func() exGit.Git {
 				gpr := func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
 				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
-					existingRfc := `{}`
-					return &existingRfc, getStringPointer("junk-sha"), nil
+					return &existing, getStringPointer("junk-sha"), nil
 				}
 				uf := func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error { return nil }
 				gr := func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
@@ -558,15 +1124,20 @@ func TestUpdateRequest(t *testing.T) {
 				dar := func(ctx context.Context, reviews exGit.PullRequestReviews, pr exGit.PullRequest) error {
 					return nil
 				}
+				gul := func(ctx context.Context) (*string, error) {
+					return getStringPointer("tstark"), nil
+				}
 				return &mockGit{
 					getPullRequest:         gpr,
 					getRFCContents:         grfc,
 					updateFile:             uf,
 					getReviews:             gr,
 					dismissApprovalReviews: dar,
+					getUserLogin:           gul,
 				}
 			},
-			data:          &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier},
+			data: &models.Update{RFC: &models.RFC{}, RFCIdentifier: identifier,
+				Signature: signatureOf(existing)},
 			expected:      &identifier,
 			expectedErr:   nil,
 			expectedCalls: []call{},
@@ -592,3 +1163,3103 @@ func TestUpdateRequest(t *testing.T) {
 		}
 	}
 }
+
+// TestEventSubject tests that published events describe their RFC so notifications can be routed on it
+func TestEventSubject(t *testing.T) {
+	// initialize
+	ownership.Default = ownership.New(map[string][]string{"EntityType": {"avengers"}, "FieldType": {"shield"}})
+	defer func() { ownership.Default = ownership.New(nil) }()
+	rfc := &models.RFC{
+		Priority: "high",
+		Actions: models.Actions{
+			{ActionType: models.UpdateAction, Target: models.Target{TargetDescriptor: "FieldType"}},
+			{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+			{ActionType: models.CommentAction, Target: models.Target{TargetDescriptor: "FieldType"}},
+		},
+	}
+	expected := &models.EventSubject{
+		ActionTypes:       []models.ActionType{models.AddAction, models.CommentAction, models.UpdateAction},
+		TargetDescriptors: []string{"EntityType", "FieldType"},
+		Teams:             []string{"avengers", "shield"},
+		Priority:          "high",
+	}
+
+	// run test
+	subject := eventSubject(rfc)
+
+	// assert
+	if !reflect.DeepEqual(subject, expected) {
+		t.Errorf("unexpected event subject. wanted %+v, got %+v", expected, subject)
+	}
+	if eventSubject(nil) != nil {
+		t.Errorf("expected events without an RFC to have no subject")
+	}
+}
+
+// TestGetActivity tests the GetActivity function
+func TestGetActivity(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	team := "avengers"
+	events.Default = events.NewMemoryBus(events.DEFAULT_HISTORY_SIZE)
+	publishEvent(models.SubmitEvent, identifier, "tstark", "", nil)
+	publishEvent(models.ReviewEvent, identifier, "bbanner", "", nil)
+	publishEvent(models.MergeEvent, identifier, "nromanoff", "", nil)
+
+	// initialize test cases
+	testCases := []struct {
+		mockCreator gitMockCreator
+		data        *models.Activity
+		expected    []string
+		expectedErr *string
+	}{
+		// no filters
+		{
+			mockCreator: func() exGit.Git { return &mockGit{} },
+			data:        &models.Activity{},
+			expected:    []string{"nromanoff", "bbanner", "tstark"},
+		},
+		// user and type filters
+		{
+			mockCreator: func() exGit.Git { return &mockGit{} },
+			data: &models.Activity{
+				User:  getStringPointer("tstark"),
+				Types: []models.EventType{models.SubmitEvent, models.ReviewEvent},
+			},
+			expected: []string{"tstark"},
+		},
+		// limit
+		{
+			mockCreator: func() exGit.Git { return &mockGit{} },
+			data:        &models.Activity{Limit: 1},
+			expected:    []string{"nromanoff"},
+		},
+		// team filter
+		{
+			mockCreator: func() exGit.Git {
+				gtm := func(ctx context.Context, team string) (set.Set[string], error) {
+					return set.NewSetOf("tstark", "nromanoff"), nil
+				}
+				return &mockGit{getTeamMembers: gtm}
+			},
+			data:     &models.Activity{Team: &team},
+			expected: []string{"nromanoff", "tstark"},
+		},
+		// failed to get team members
+		{
+			mockCreator: func() exGit.Git {
+				gtm := func(ctx context.Context, team string) (set.Set[string], error) {
+					return nil, fmt.Errorf("get team members error")
+				}
+				return &mockGit{getTeamMembers: gtm}
+			},
+			data:        &models.Activity{Team: &team},
+			expectedErr: getStringPointer("get team members error"),
+		},
+	}
+
+	// assert
+	for _, testCase := range testCases {
+		actual, actualErr := GetActivity(context.Background(), testCase.mockCreator(), testCase.data)
+
+		commonAsserter(t, nil, nil, testCase.expectedErr, actualErr)
+		actors := []string{}
+		for _, event := range actual {
+			actors = append(actors, event.Actor)
+		}
+		if testCase.expectedErr == nil && fmt.Sprint(actors) != fmt.Sprint(testCase.expected) {
+			t.Errorf("expected != actual. expected: %v\n actual: %v", testCase.expected, actors)
+		}
+	}
+}
+
+// TestStreamEvents tests the StreamEvents function
+func TestStreamEvents(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	events.Default = events.NewMemoryBus(events.DEFAULT_HISTORY_SIZE)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// act
+	stream := StreamEvents(ctx, &identifier, []models.EventType{models.LoadEvent, models.MergeEvent})
+	publishEvent(models.ReviewEvent, identifier, "bbanner", "", nil)
+	publishEvent(models.MergeEvent, "999999", "nromanoff", "", nil)
+	publishEvent(models.MergeEvent, identifier, "tstark", "", nil)
+
+	// assert
+	select {
+	case event := <-stream:
+		if event.Type != models.MergeEvent || event.RFCIdentifier != identifier || event.Actor != "tstark" {
+			t.Errorf("unexpected event streamed: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the merge event of RFC %s to be streamed", identifier)
+	}
+	select {
+	case event := <-stream:
+		t.Errorf("unexpected event streamed: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestMyWork tests the MyWork function
+func TestMyWork(t *testing.T) {
+	// initialize
+	now := time.Now()
+	openPullRequestCache.Clear()
+	prs := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "authored-changes", Author: "tstark", UpdatedAt: now},
+		&exGit.PullRequestDetails{RFCIdentifier: "authored-failed", Author: "tstark", UpdatedAt: now},
+		&exGit.PullRequestDetails{RFCIdentifier: "requested-user", Author: "bbanner", RequestedReviewers: []string{"tstark"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "requested-team", Author: "bbanner", RequestedTeams: []string{"avengers"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "unrelated", Author: "bbanner", RequestedTeams: []string{"shield"}},
+	}
+	reviews := map[string][]exGit.ReviewDetails{
+		"authored-changes": {
+			{Reviewer: "bbanner", State: exGit.APPROVED_STATE, SubmittedAt: now.Add(-time.Hour)},
+			{Reviewer: "nromanoff", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: now.Add(-time.Hour)},
+			{Reviewer: "nromanoff", State: exGit.COMMENTED_STATE, SubmittedAt: now},
+		},
+		"authored-failed": {
+			{Reviewer: "nromanoff", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: now.Add(-time.Hour)},
+			{Reviewer: "nromanoff", State: exGit.APPROVED_STATE, SubmittedAt: now},
+		},
+	}
+	contents := map[string]string{
+		"authored-changes": `{"actions": []}`,
+		"authored-failed":  `{"actions": [{"actionType": "load", "data": {"status": "failed"}}]}`,
+	}
+	mg := &mockGit{
+		getUserLogin: func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		getUserTeams: func(ctx context.Context) (set.Set[string], error) { return set.NewSetOf("avengers"), nil },
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return prs, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return reviews[pr.(*exGit.PullRequestDetails).RFCIdentifier], nil
+		},
+		getReviewDetails: func(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+			return reviews.([]exGit.ReviewDetails), nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			content := contents[branch]
+			return &content, getStringPointer("junk-sha"), nil
+		},
+	}
+
+	// act
+	actual, err := MyWork(context.Background(), mg)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	identifiers := func(references []models.RFCReference) string {
+		ids := []string{}
+		for _, reference := range references {
+			ids = append(ids, reference.RFCIdentifier)
+		}
+		return fmt.Sprint(ids)
+	}
+	if ids := identifiers(actual.NeedsChanges); ids != "[authored-changes]" {
+		t.Errorf("unexpected needs changes. expected: [authored-changes]\n actual: %s", ids)
+	}
+	if ids := identifiers(actual.FailedLoads); ids != "[authored-failed]" {
+		t.Errorf("unexpected failed loads. expected: [authored-failed]\n actual: %s", ids)
+	}
+	if ids := identifiers(actual.AwaitingReview); ids != "[requested-user requested-team]" {
+		t.Errorf("unexpected awaiting review. expected: [requested-user requested-team]\n actual: %s", ids)
+	}
+}
+
+// TestMyWorkSSO tests that the work of a user authenticated through SSO is found from the submitters and reviews sealed
+// in RFCs, every pull request being authored and reviewed by the shared account
+func TestMyWorkSSO(t *testing.T) {
+	// initialize
+	now := time.Now()
+	openPullRequestCache.Clear()
+	signing.Records = signing.NewRecordSealer("record-secret")
+	defer func() { signing.Records = nil }()
+	prs := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "authored-changes", Author: "harmonia", UpdatedAt: now},
+		&exGit.PullRequestDetails{RFCIdentifier: "requested-user", Author: "harmonia", UpdatedAt: now,
+			RequestedReviewers: []string{"tstark"}},
+	}
+	contents := map[string]string{}
+	for _, submission := range []struct{ identifier, submitter, reviewer string }{
+		{"authored-changes", "tstark", "bbanner"},
+		{"requested-user", "bbanner", ""},
+	} {
+		rfc := &models.RFC{Signature: "rfc-sha", Submitter: submission.submitter}
+		if err := rfc.SealSubmitter(submission.identifier, signing.Records.Seal); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if submission.reviewer != "" {
+			action := &models.Action{ActionType: "request_changes", Target: models.Target{TargetType: models.RfcTarget,
+				LookupKey: models.SignatureLookupKey, LookupValue: rfc.Signature},
+				Data: map[string]interface{}{string(models.ReviewerData): submission.reviewer}}
+			if err := rfc.SealReview(submission.identifier, action, signing.Records.Seal); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			rfc.Actions = append(rfc.Actions, action)
+		}
+		content, _ := json.Marshal(rfc)
+		contents[submission.identifier] = string(content)
+	}
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return prs, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return []exGit.ReviewDetails{{Reviewer: "harmonia", State: exGit.COMMENTED_STATE, SubmittedAt: now}}, nil
+		},
+		getReviewDetails: func(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+			return reviews.([]exGit.ReviewDetails), nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			content := contents[branch]
+			return &content, getStringPointer("junk-sha"), nil
+		},
+	}
+
+	// act
+	actual, err := MyWork(oidc.NewContext(context.Background(), &oidc.Identity{Login: "tstark"}), mg)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(actual.NeedsChanges) != 1 || actual.NeedsChanges[0].RFCIdentifier != "authored-changes" {
+		t.Errorf("unexpected needs changes. expected: [authored-changes]\n actual: %v", actual.NeedsChanges)
+	}
+	if len(actual.AwaitingReview) != 1 || actual.AwaitingReview[0].RFCIdentifier != "requested-user" {
+		t.Errorf("unexpected awaiting review. expected: [requested-user]\n actual: %v", actual.AwaitingReview)
+	}
+}
+
+// TestRebuildRequest tests the RebuildRequest function
+func TestRebuildRequest(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	validContent := signed(`{"actions": []}`)
+	tamperedContent := `{"actions": [], "signature": "forged-signature"}`
+	revisions := map[string]string{
+		"corrupt-sha":  `{"actions": [`,
+		"tampered-sha": tamperedContent,
+		"valid-sha":    validContent,
+	}
+	history := []exGit.RFCRevision{{Sha: "deleted-sha"}, {Sha: "corrupt-sha"}, {Sha: "tampered-sha"}, {Sha: "valid-sha"}}
+	gpr := func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
+	grfca := func(ctx context.Context, branch string, ref string) (*string, error) {
+		if content, ok := revisions[ref]; ok {
+			return &content, nil
+		}
+		return nil, fmt.Errorf("%w: %s", exGit.ErrRFCFileNotFound, ref)
+	}
+	rf := func(ctx context.Context, pr exGit.PullRequest, content string, message string) error { return nil }
+	gul := func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil }
+
+	// initialize test cases
+	testCases := []struct {
+		mockCreator   gitMockCreator
+		expected      *string
+		expectedErr   *string
+		expectedCalls []call
+	}{
+		// file is intact
+		{
+			mockCreator: func() exGit.Git {
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return &validContent, getStringPointer("junk-sha"), nil
+				}
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc}
+			},
+			expected:      getStringPointer("RFC test-identifier file is intact, no rebuild necessary"),
+			expectedErr:   nil,
+			expectedCalls: []call{},
+		},
+		// file is missing and is restored from the latest valid revision
+		{
+			mockCreator: func() exGit.Git {
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return nil, nil, fmt.Errorf("%w: %s", exGit.ErrRFCFileNotFound, branch)
+				}
+				grh := func(ctx context.Context, branch string) ([]exGit.RFCRevision, error) { return history, nil }
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc, getRFCHistory: grh,
+					getRFCContentsAt: grfca, restoreFile: rf, getUserLogin: gul}
+			},
+			expected:    getStringPointer("Successfully rebuilt RFC test-identifier file from revision valid-sha"),
+			expectedErr: nil,
+			expectedCalls: []call{
+				{
+					name:      "RestoreFile",
+					arguments: []interface{}{nil, validContent, "rebuild from valid-sha."},
+				},
+			},
+		},
+		// file is tampered with and is restored from the latest revision whose signatures match its content
+		{
+			mockCreator: func() exGit.Git {
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return &tamperedContent, getStringPointer("junk-sha"), nil
+				}
+				grh := func(ctx context.Context, branch string) ([]exGit.RFCRevision, error) { return history, nil }
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc, getRFCHistory: grh,
+					getRFCContentsAt: grfca, restoreFile: rf, getUserLogin: gul}
+			},
+			expected:    getStringPointer("Successfully rebuilt RFC test-identifier file from revision valid-sha"),
+			expectedErr: nil,
+			expectedCalls: []call{
+				{
+					name:      "RestoreFile",
+					arguments: []interface{}{nil, validContent, "rebuild from valid-sha."},
+				},
+			},
+		},
+		// file is corrupt and there is no valid revision
+		{
+			mockCreator: func() exGit.Git {
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return getStringPointer("junk-data"), getStringPointer("junk-sha"), nil
+				}
+				grh := func(ctx context.Context, branch string) ([]exGit.RFCRevision, error) {
+					return history[:3], nil
+				}
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc, getRFCHistory: grh,
+					getRFCContentsAt: grfca}
+			},
+			expected: nil,
+			expectedErr: getStringPointer(
+				"no valid revision of RFC test-identifier found in its commit history, it cannot be rebuilt"),
+			expectedCalls: []call{},
+		},
+		// retrieval errors are not integrity failures
+		{
+			mockCreator: func() exGit.Git {
+				grfc := func(ctx context.Context, branch string) (*string, *string, error) {
+					return nil, nil, fmt.Errorf("get rfc contents error")
+				}
+				return &mockGit{getPullRequest: gpr, getRFCContents: grfc}
+			},
+			expected:      nil,
+			expectedErr:   getStringPointer("get rfc contents error"),
+			expectedCalls: []call{},
+		},
+	}
+
+	// assert
+	for _, testCase := range testCases {
+		gitInstance := testCase.mockCreator()
+
+		actual, actualErr := RebuildRequest(context.Background(), gitInstance,
+			&models.Rebuild{RFCIdentifier: identifier})
+
+		commonAsserter(t, testCase.expected, actual, testCase.expectedErr, actualErr)
+		for _, c := range testCase.expectedCalls {
+			gitInstance.(*mockGit).AssertCalled(t, c.name, c.arguments...)
+		}
+	}
+}
+
+// TestStatusIntegrity tests that Status reports missing and corrupt RFC files as integrity errors
+func TestStatusIntegrity(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	testCases := []struct {
+		content        *string
+		err            error
+		expectedReason models.IntegrityReason
+	}{
+		{content: nil, err: fmt.Errorf("%w: %s", exGit.ErrRFCFileNotFound, identifier), expectedReason: models.MissingRFC},
+		{content: getStringPointer("  \n"), err: nil, expectedReason: models.EmptyRFC},
+		{content: getStringPointer(`{"actions": [`), err: nil, expectedReason: models.CorruptRFC},
+	}
+
+	// assert
+	for _, testCase := range testCases {
+		mg := &mockGit{getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return testCase.content, nil, testCase.err
+		}}
+
+		_, err := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+
+		var integrityErr *models.IntegrityError
+		if !errors.As(err, &integrityErr) {
+			t.Errorf("expected an IntegrityError, got %v", err)
+		} else if integrityErr.Reason != testCase.expectedReason || integrityErr.Remediation == "" {
+			t.Errorf("unexpected integrity error. expected reason: %s\n actual: %+v", testCase.expectedReason,
+				integrityErr)
+		}
+	}
+}
+
+// TestEmbargo tests that embargoed RFCs are neither merged nor loaded and that their embargo is reported in the status
+func TestEmbargo(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	content := signed(fmt.Sprintf(`{"actions": [], "embargoUntil": "%s"}`, until.Format(time.RFC3339)))
+	mg := &mockGit{
+		getUserLogin:   func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, getStringPointer("junk-sha"), nil
+		},
+	}
+
+	// act
+	mergeErr := func() error {
+		_, err := MergeRequest(context.Background(), mg, &models.Merge{RFCIdentifier: identifier})
+		return err
+	}()
+	loadErr := LoadRequest(context.Background(), mg, &models.Load{RFCIdentifier: identifier})
+	status, err := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+
+	// assert
+	for _, err := range []error{mergeErr, loadErr} {
+		var embargoErr *models.EmbargoError
+		if !errors.As(err, &embargoErr) || !embargoErr.Until.Equal(until) {
+			t.Errorf("expected an EmbargoError until %s, got %v", until, err)
+		}
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !status.Embargoed || status.EmbargoUntil == nil || !status.EmbargoUntil.Equal(until) {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+// TestDependencies tests that RFCs only depend on RFCs that may be merged, without cycles, and that they are neither
+// merged nor loaded before their dependencies are merged, which their status reports
+func TestDependencies(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	contents := map[string]string{
+		identifier: signed(`{"actions": [], "dependsOn": ["dep-merged", "dep-open"]}`),
+		"dep-open": `{"actions": [], "dependsOn": ["dep-merged"]}`,
+	}
+	mg := &mockGit{
+		getUserLogin: func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			if branch == "dep-missing" {
+				return nil, fmt.Errorf("exactly one PR was NOT returned")
+			}
+			return branch, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			details := &exGit.PullRequestDetails{RFCIdentifier: pr.(string), State: exGit.OPEN_STATE}
+			if pr == "dep-merged" || pr == "dep-closed" {
+				details.State, details.Merged = exGit.CLOSED_STATE, pr == "dep-merged"
+			}
+			return details, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			content := contents[branch]
+			return &content, getStringPointer("junk-sha"), nil
+		},
+	}
+
+	// act
+	mergeErr := func() error {
+		_, err := MergeRequest(context.Background(), mg, &models.Merge{RFCIdentifier: identifier})
+		return err
+	}()
+	loadErr := LoadRequest(context.Background(), mg, &models.Load{RFCIdentifier: identifier})
+	status, err := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+	var invalidErrs []error
+	for _, dependsOn := range [][]string{{"dep-missing"}, {"dep-closed"}, {"dep-open", "dep-open"}} {
+		invalidErrs = append(invalidErrs, validateDependencies(context.Background(), mg,
+			&models.RFC{DependsOn: dependsOn}, ""))
+	}
+	contents["dep-open"] = fmt.Sprintf(`{"actions": [], "dependsOn": ["%s"]}`, identifier)
+	invalidErrs = append(invalidErrs, validateDependencies(context.Background(), mg,
+		&models.RFC{DependsOn: []string{"dep-merged", "dep-open"}}, identifier))
+	validErr := validateDependencies(context.Background(), mg, &models.RFC{DependsOn: []string{"dep-open"}}, "")
+
+	// assert
+	for _, err := range []error{mergeErr, loadErr} {
+		if !errors.Is(err, models.ErrUnmetDependencies) || !strings.Contains(err.Error(), "dep-open (open)") ||
+			strings.Contains(err.Error(), "dep-merged") {
+			t.Errorf("expected an unmet dependency on dep-open, got %v", err)
+		}
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := []models.Dependency{{RFCIdentifier: "dep-merged", State: models.MergedDependency},
+		{RFCIdentifier: "dep-open", State: models.OpenDependency}}
+	if !reflect.DeepEqual(status.Dependencies, expected) {
+		t.Errorf("unexpected dependencies in status: %+v", status.Dependencies)
+	}
+	for i, err := range invalidErrs {
+		if !errors.Is(err, models.ErrInvalidDependency) {
+			t.Errorf("expected dependency declaration %d to be invalid, got %v", i, err)
+		}
+	}
+	if validErr != nil {
+		t.Errorf("unexpected error: %s", validErr.Error())
+	}
+}
+
+// TestQuorum tests that RFCs short of the quorum of approvals of the approval policy are not merged, the approvals of
+// their author and dismissed approvals not counting towards it
+func TestQuorum(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	policy, err := quorum.NewPolicy(quorum.Policy{Rules: []quorum.Rule{
+		{TargetType: models.ItemTarget, Approvals: 2, Teams: map[string]int{"team-data": 1}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quorum.Default = policy
+	defer func() { quorum.Default = nil }()
+	content := `{"actions": [{"actionType": "add", "target": {"targetType": "item", "targetDescriptor": "Event"}}]}`
+	now := time.Now()
+	reviews := []exGit.ReviewDetails{
+		{Reviewer: "tstark", State: exGit.APPROVED_STATE, SubmittedAt: now},
+		{Reviewer: "bbanner", State: exGit.APPROVED_STATE, SubmittedAt: now},
+		{Reviewer: "pparker", State: exGit.APPROVED_STATE, SubmittedAt: now},
+		{Reviewer: "pparker", State: exGit.DISMISSED_STATE, SubmittedAt: now.Add(time.Minute)},
+	}
+	mg := &mockGit{
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return &exGit.PullRequestDetails{RFCIdentifier: identifier, Author: "tstark"}, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, getStringPointer("junk-sha"), nil
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return nil, nil
+		},
+		getReviewDetails: func(r exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) { return reviews, nil },
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			return set.NewSetOf("tstark", "pparker", "nromanoff"), nil
+		},
+	}
+
+	// act
+	_, mergeErr := MergeRequest(context.Background(), mg, &models.Merge{RFCIdentifier: identifier})
+	reviews = append(reviews, exGit.ReviewDetails{Reviewer: "nromanoff", State: exGit.APPROVED_STATE, SubmittedAt: now})
+	rfc, _ := readRFC(context.Background(), mg, identifier)
+	metErr := checkQuorum(context.Background(), mg, nil, rfc)
+
+	// assert
+	if !errors.Is(mergeErr, models.ErrQuorumNotMet) || !strings.Contains(mergeErr.Error(), "1 given") {
+		t.Errorf("expected the merge to be short of its quorum, got %v", mergeErr)
+	}
+	if metErr != nil {
+		t.Errorf("unexpected error: %v", metErr)
+	}
+}
+
+// TestSSOQuorum tests that the reviews of users authenticated through SSO are submitted as comments of the shared
+// account and counted towards the quorum from their seal in the RFC, the approvals of its submitter and unsealed
+// approvals not counting
+func TestSSOQuorum(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	policy, err := quorum.NewPolicy(quorum.Policy{Rules: []quorum.Rule{{TargetType: models.ItemTarget, Approvals: 2}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quorum.Default = policy
+	signing.Records = signing.NewRecordSealer("record-secret")
+	defer func() { quorum.Default, signing.Records = nil, nil }()
+	rfc := &models.RFC{}
+	if err = json.Unmarshal([]byte(signed(`{"actions": [{"actionType": "add", "target": {"targetType": "item",
+		"targetDescriptor": "Event"}}]}`)), rfc); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	rfc.Submitter = "tstark"
+	if err = rfc.SealSubmitter(identifier, signing.Records.Seal); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	content, _ := json.Marshal(rfc)
+	store := &gatedStore{content: string(content)}
+	mg := store.mock(exGit.DEPLOYMENT_PENDING_STATE)
+	providerReviews := []models.Review{}
+	mg.createReview = func(ctx context.Context, pr exGit.PullRequest, data *models.Review) error {
+		providerReviews = append(providerReviews, *data)
+		return nil
+	}
+	mg.getPullRequestDetails = func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+		return &exGit.PullRequestDetails{RFCIdentifier: identifier, Author: "harmonia"}, nil
+	}
+	mg.getReviews = func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+		return nil, nil
+	}
+	mg.getReviewDetails = func(r exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+		return []exGit.ReviewDetails{{Reviewer: "harmonia", State: exGit.APPROVED_STATE, SubmittedAt: time.Now()}}, nil
+	}
+	review := func(login string, reviewType models.ReviewType) {
+		ctx := oidc.NewContext(context.Background(), &oidc.Identity{Login: login})
+		if _, err := ReviewRequest(ctx, mg, mg, &models.Review{RFCIdentifier: identifier,
+			Type: string(reviewType)}); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	quorumOf := func() error {
+		reviewed, err := readRFC(context.Background(), mg, identifier)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		return checkQuorum(context.Background(), mg, nil, reviewed)
+	}
+
+	// act
+	review("tstark", models.ApproveReview)
+	review("bbanner", models.ApproveReview)
+	forged := &models.RFC{}
+	_ = json.Unmarshal([]byte(store.content), forged)
+	forged.Actions = append(forged.Actions, &models.Action{ActionType: "approve",
+		Target: models.Target{TargetType: models.RfcTarget, LookupKey: models.SignatureLookupKey,
+			LookupValue: forged.Signature},
+		Data: map[string]interface{}{string(models.ReviewerData): "pparker", string(models.SealData): "forged"}})
+	forgedContent, _ := json.Marshal(forged)
+	store.content = string(forgedContent)
+	shortErr := quorumOf()
+	review("nromanoff", models.ApproveReview)
+	metErr := quorumOf()
+
+	// assert
+	if !errors.Is(shortErr, models.ErrQuorumNotMet) || !strings.Contains(shortErr.Error(), "1 given") {
+		t.Errorf("expected the RFC to be short of its quorum, got %v", shortErr)
+	}
+	if metErr != nil {
+		t.Errorf("unexpected error: %v", metErr)
+	}
+	for _, providerReview := range providerReviews {
+		if providerReview.Type != string(models.CommentReview) ||
+			!strings.HasPrefix(providerReview.TopLevelComment, "APPROVE by @") {
+			t.Errorf("expected reviews to be submitted as labelled comments, got %+v", providerReview)
+		}
+	}
+}
+
+// TestExternalVerdict tests that verdicts of external systems count towards the quorum of the RFC they decided on
+func TestExternalVerdict(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	policy, err := quorum.NewPolicy(quorum.Policy{Rules: []quorum.Rule{
+		{TargetType: models.ItemTarget, Approvals: 2, Systems: []string{"cab"}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	systems, err := signing.NewSystemVerifier(map[string]string{"cab": "shh", "itsm": "hush"}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	quorum.Default, signing.Systems = policy, systems
+	defer func() { quorum.Default, signing.Systems = nil, nil }()
+	models.RegisterExternalApprover("cab")
+	models.RegisterExternalApprover("itsm")
+	content := `{"signature": "rfc-sha", "actions": [{"actionType": "add",
+		"target": {"targetType": "item", "targetDescriptor": "Event"}}]}`
+	mg := &mockGit{
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return &exGit.PullRequestDetails{RFCIdentifier: identifier, Author: "tstark"}, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, getStringPointer("junk-sha"), nil
+		},
+		updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+			updated, err := json.Marshal(data)
+			content = string(updated)
+			return err
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return nil, nil
+		},
+		getReviewDetails: func(r exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+			return []exGit.ReviewDetails{{Reviewer: "pparker", State: exGit.APPROVED_STATE}}, nil
+		},
+	}
+	quorumOf := func() error {
+		rfc, err := readRFC(context.Background(), mg, identifier)
+		if err != nil {
+			return err
+		}
+		return checkQuorum(context.Background(), mg, nil, rfc)
+	}
+	verdict := func(system string, verdict models.Verdict, signature string) error {
+		_, err := RecordExternalVerdict(context.Background(), mg, system, &models.ExternalApproval{
+			RFCIdentifier: identifier, Verdict: verdict, Signature: signature, Reference: "CHG0042"})
+		return err
+	}
+
+	// act & assert
+	if err = quorumOf(); !errors.Is(err, models.ErrQuorumNotMet) || !strings.Contains(err.Error(), "system cab") {
+		t.Errorf("expected the approval of cab to be required, got %v", err)
+	}
+	if err = verdict("lint", models.ApprovedVerdict, "rfc-sha"); !errors.Is(err, models.ErrUnknownApprover) {
+		t.Errorf("expected verdicts of systems that are not approvers to be rejected, got %v", err)
+	}
+	if err = verdict("cab", models.ApprovedVerdict, "previous-sha"); !errors.Is(err, models.ErrStaleVerdict) {
+		t.Errorf("expected verdicts on a previous version of the RFC to be rejected, got %v", err)
+	}
+	if err = verdict("cab", models.ApprovedVerdict, "rfc-sha"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = quorumOf(); err != nil {
+		t.Errorf("expected the approval of cab to count towards the quorum, got %v", err)
+	}
+	if err = verdict("itsm", models.RejectedVerdict, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = quorumOf(); !errors.Is(err, models.ErrQuorumNotMet) || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected the rejection of itsm to block the RFC, got %v", err)
+	}
+	// an approval of itsm committed to the branch of the RFC rather than recorded by Harmonia
+	rfc, err := readRFC(context.Background(), mg, identifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forged := *rfc.Actions[len(rfc.Actions)-1]
+	forged.Data = map[string]interface{}{"system": "itsm", "verdict": "approved", "decidedAt": "2099-01-01T00:00:00Z",
+		"seal": forged.Data["seal"]}
+	rfc.Actions = append(rfc.Actions, &forged)
+	if err = mg.updateFile(context.Background(), nil, rfc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = quorumOf(); !errors.Is(err, models.ErrQuorumNotMet) || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected a forged approval of itsm to be ignored, got %v", err)
+	}
+	if err = verdict("itsm", models.ApprovedVerdict, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = quorumOf(); err != nil {
+		t.Errorf("expected the latest verdict of itsm to count, got %v", err)
+	}
+}
+
+// TestGetAction tests the GetAction function
+func TestGetAction(t *testing.T) {
+	// initialize an RFC with a commented action, a reply to that comment and an unrelated comment
+	identifier, _ := setup()
+	rfc := &models.RFC{Signature: "rfc-sha"}
+	for _, id := range []string{"a", "b"} {
+		action := models.Action{ActionType: models.AddAction, Data: map[string]interface{}{"id": id}}
+		if err := rfc.AddAction(action); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	target, other := rfc.Actions[0].Signature, rfc.Actions[1].Signature
+	if err := rfc.AddComments(map[string][]string{target: {"first"}, other: {"unrelated"}}, "tstark"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var first string
+	for _, action := range rfc.Actions {
+		if action.Data[string(models.CommentData)] == "first" {
+			first = action.Signature
+		}
+	}
+	if err := rfc.AddComments(map[string][]string{first: {"reply"}}, "bbanner"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	content, err := json.Marshal(rfc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	mg := &mockGit{getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+		return getStringPointer(string(content)), getStringPointer("junk-sha"), nil
+	}}
+
+	// act
+	actual, err := GetAction(context.Background(), mg, &models.GetAction{RFCIdentifier: identifier, Signature: target})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if actual.Action.Signature != target {
+		t.Errorf("unexpected action. expected signature: %s\n actual: %s", target, actual.Action.Signature)
+	}
+	comments := []interface{}{}
+	for _, comment := range actual.Comments {
+		comments = append(comments, comment.Data[string(models.CommentData)])
+	}
+	if fmt.Sprint(comments) != "[first reply]" {
+		t.Errorf("unexpected comment thread. expected: [first reply]\n actual: %v", comments)
+	}
+
+	// act
+	_, err = GetAction(context.Background(), mg, &models.GetAction{RFCIdentifier: identifier, Signature: "missing"})
+
+	// assert
+	if !errors.Is(err, models.ErrActionNotFound) {
+		t.Errorf("expected an action not found error, got %v", err)
+	}
+}
+
+// TestGetRFC tests that RFCs are looked up by their RFC file and pull request, corrupt RFC files failing integrity
+func TestGetRFC(t *testing.T) {
+	// initialize an RFC file and its pull request
+	identifier, _ := setup()
+	content := `{"actions":[{"actionType":"add","target":{"targetType":"item","targetDescriptor":"Event"}}]}`
+	mg := &mockGit{
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, getStringPointer("junk-sha"), nil
+		},
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return "pr", nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return &exGit.PullRequestDetails{RFCIdentifier: identifier, Title: "Add an event", Merged: true}, nil
+		},
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			links := &models.Links{PullRequest: "https://github.com/owner/repo/pull/1"}
+			if tagged {
+				links.Tag = "https://github.com/owner/repo/tree/" + rfcIdentifier
+			}
+			return links
+		},
+	}
+
+	// act
+	rfc, err := GetRFC(context.Background(), mg, identifier)
+	reference, referenceErr := GetRFCReference(context.Background(), mg, identifier)
+
+	// assert
+	if err != nil || referenceErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, referenceErr)
+	}
+	if len(rfc.Actions) != 1 || rfc.Actions[0].Target.TargetDescriptor != "Event" {
+		t.Errorf("unexpected RFC: %+v", rfc)
+	}
+	if reference.RFCIdentifier != identifier || reference.Title != "Add an event" {
+		t.Errorf("unexpected reference: %+v", reference)
+	}
+	if reference.Links == nil || reference.Links.Tag == "" {
+		t.Errorf("expected the merged RFC to link its tag, got %+v", reference.Links)
+	}
+
+	// act
+	content = "{"
+	_, err = GetRFC(context.Background(), mg, identifier)
+
+	// assert
+	var integrityErr *models.IntegrityError
+	if !errors.As(err, &integrityErr) || integrityErr.Reason != models.CorruptRFC {
+		t.Errorf("expected a corrupt RFC integrity error, got %v", err)
+	}
+}
+
+// TestSeed tests that sample RFCs are submitted and brought to their seed state, and that unknown seed states are
+// rejected before anything is seeded
+func TestSeed(t *testing.T) {
+	// initialize a tracking repository backed by a store
+	identifier, createRFCIdentifier := setup()
+	CreateRFCIdentifier = createRFCIdentifier
+	defaultStatuses := loadstatus.Default
+	loadstatus.Default = loadstatus.NewMemoryStore()
+	defer func() { loadstatus.Default = defaultStatuses }()
+	store := &gatedStore{}
+	mg := store.mock("")
+	mg.createBranch = func(ctx context.Context, branch string, baseBranch string) error { return nil }
+	mg.createFile = func(ctx context.Context, branch string, directory string, data *models.RFC) error {
+		return mg.updateFile(ctx, nil, data)
+	}
+	mg.createPullRequest = func(ctx context.Context, branch string, baseBranch string) error { return nil }
+	mg.buildLinks = func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+		return &models.Links{PullRequest: "https://github.com/owner/repo/pull/1"}
+	}
+
+	// act
+	seeded, err := Seed(context.Background(), mg, mg, &models.Seed{States: []models.SeedState{models.FailedLoadSeed}})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if seeded.RFCs[string(models.FailedLoadSeed)] != identifier || seeded.Links[identifier] == nil {
+		t.Errorf("expected the seeded RFC and its links, got %+v", seeded)
+	}
+	status, err := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+	if err != nil || status.Status != FAILED_STATUS {
+		t.Errorf("expected the seeded RFC to have failed its load, got %+v, %v", status, err)
+	}
+
+	// act, the mock has no behavior so any call to it would panic
+	_, err = Seed(context.Background(), &mockGit{}, &mockGit{}, &models.Seed{
+		States: []models.SeedState{models.OpenSeed, "abandoned"},
+	})
+
+	// assert
+	if !errors.Is(err, models.ErrUnknownSeedState) {
+		t.Errorf("expected an unknown seed state error, got %v", err)
+	}
+}
+
+// TestEditComment tests that comments can only be edited and deleted by their author, both in the RFC and on the
+// provider
+func TestEditComment(t *testing.T) {
+	// initialize an RFC with an action, a comment on it, a reply to that comment and a comment by someone else
+	identifier, _ := setup()
+	rfc := &models.RFC{Signature: "rfc-sha"}
+	action := models.Action{ActionType: models.AddAction, Data: map[string]interface{}{"id": "a"}}
+	if err := rfc.AddAction(action); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	target := rfc.Actions[0].Signature
+	if err := rfc.AddComments(map[string][]string{target: {"tpyo"}}, "tstark"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	comment := rfc.Actions[1].Signature
+	if err := rfc.AddComments(map[string][]string{comment: {"reply"}}, "bbanner"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	other := rfc.Actions[2].Signature
+	content, err := json.Marshal(rfc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	path := fmt.Sprintf("%s/%s/%s", exGit.BASE_RFC_DIRECTORY_NAME, identifier, exGit.RFC_FILE_NAME)
+	var updated *models.RFC
+	mockCreator := func() *mockGit {
+		return &mockGit{
+			getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+				return "pr", nil
+			},
+			getUserLogin: func(ctx context.Context) (*string, error) {
+				return getStringPointer("tstark"), nil
+			},
+			getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+				return getStringPointer(string(content)), getStringPointer("junk-sha"), nil
+			},
+			updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+				updated = data
+				return nil
+			},
+			getReviewComments: func(ctx context.Context, pr exGit.PullRequest) ([]exGit.ReviewComment, error) {
+				return []exGit.ReviewComment{
+					{ID: "6", Author: "bbanner", Path: path, Body: "tpyo"},
+					{ID: "7", Author: "tstark", Path: path, Body: "tpyo"},
+				}, nil
+			},
+			editReviewComment: func(ctx context.Context, pr exGit.PullRequest, id string, body string) error {
+				return nil
+			},
+			deleteReviewComment: func(ctx context.Context, pr exGit.PullRequest, id string) error {
+				return nil
+			},
+		}
+	}
+
+	// act
+	editGit := mockCreator()
+	_, editErr := EditComment(context.Background(), editGit,
+		&models.EditComment{RFCIdentifier: identifier, Signature: comment, Comment: "typo"})
+
+	// assert the comment is edited and its previous text recorded
+	if editErr != nil {
+		t.Fatalf("unexpected error: %s", editErr.Error())
+	}
+	editGit.AssertCalled(t, "EditReviewComment", "pr", "7", "typo")
+	edited := updated.GetAction(comment)
+	edits, _ := edited.Data[string(models.EditsData)].([]interface{})
+	if edited.Data[string(models.CommentData)] != "typo" || len(edits) != 1 ||
+		edits[0].(map[string]interface{})[string(models.CommentData)] != "tpyo" {
+		t.Errorf("unexpected edited comment: %v", edited.Data)
+	}
+
+	// act
+	deleteGit := mockCreator()
+	_, deleteErr := DeleteComment(context.Background(), deleteGit,
+		&models.DeleteComment{RFCIdentifier: identifier, Signature: comment})
+
+	// assert the replied to comment is kept without its text
+	if deleteErr != nil {
+		t.Fatalf("unexpected error: %s", deleteErr.Error())
+	}
+	deleteGit.AssertCalled(t, "DeleteReviewComment", "pr", "7")
+	deleted := updated.GetAction(comment)
+	if deleted == nil || deleted.Data[string(models.DeletedData)] != true ||
+		deleted.Data[string(models.CommentData)] != nil || len(updated.GetCommentThread(target)) != 2 {
+		t.Errorf("unexpected deleted comment: %v", deleted)
+	}
+
+	// act
+	_, otherErr := EditComment(context.Background(), mockCreator(),
+		&models.EditComment{RFCIdentifier: identifier, Signature: other, Comment: "mine now"})
+	_, missingErr := DeleteComment(context.Background(), mockCreator(),
+		&models.DeleteComment{RFCIdentifier: identifier, Signature: target})
+
+	// assert
+	if !errors.Is(otherErr, models.ErrNotCommentAuthor) {
+		t.Errorf("expected a not comment author error, got %v", otherErr)
+	}
+	if !errors.Is(missingErr, models.ErrActionNotFound) {
+		t.Errorf("expected an action not found error, got %v", missingErr)
+	}
+}
+
+// TestGetRfcContents tests that RFC contents are retrieved as of the requested revision
+func TestGetRfcContents(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	mg := &mockGit{
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return getStringPointer("latest"), getStringPointer("junk-sha"), nil
+		},
+		getRFCContentsAt: func(ctx context.Context, branch string, ref string) (*string, error) {
+			if ref != "old-sha" {
+				return nil, &exGit.ProviderError{Kind: exGit.ErrNotFound, Err: exGit.ErrRFCFileNotFound}
+			}
+			return getStringPointer("historical"), nil
+		},
+	}
+
+	// act
+	latest, latestErr := GetRfcContents(context.Background(), mg, &models.GetRfcContents{RFCIdentifier: identifier})
+	historical, historicalErr := GetRfcContents(context.Background(), mg,
+		&models.GetRfcContents{RFCIdentifier: identifier, Ref: "old-sha"})
+	_, missingErr := GetRfcContents(context.Background(), mg,
+		&models.GetRfcContents{RFCIdentifier: identifier, Ref: "unknown-sha"})
+
+	// assert
+	commonAsserter(t, getStringPointer("latest"), latest, nil, latestErr)
+	commonAsserter(t, getStringPointer("historical"), historical, nil, historicalErr)
+	if !errors.Is(missingErr, exGit.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", missingErr)
+	}
+}
+
+// TestGetRfcRendered tests that RFCs are rendered in Markdown unless another format is requested
+func TestGetRfcRendered(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	mg := &mockGit{
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return getStringPointer(`{"actions": [{"actionType": "add", "target": {"targetType": "item", ` +
+				`"targetDescriptor": "Event"}}]}`), getStringPointer("junk-sha"), nil
+		},
+	}
+
+	// act
+	markdown, markdownErr := GetRfcRendered(context.Background(), mg, &models.GetRfcRendered{RFCIdentifier: identifier})
+	html, htmlErr := GetRfcRendered(context.Background(), mg,
+		&models.GetRfcRendered{RFCIdentifier: identifier, Format: "html"})
+
+	// assert
+	if markdownErr != nil || htmlErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", markdownErr, htmlErr)
+	}
+	if markdown.Format != "markdown" || !strings.Contains(markdown.Body, "### item Event") {
+		t.Errorf("expected the RFC in Markdown, got %+v", markdown)
+	}
+	if html.Format != "html" || !strings.Contains(html.Body, "<h3>item Event</h3>") {
+		t.Errorf("expected the RFC in HTML, got %+v", html)
+	}
+}
+
+// TestGetRfcs tests the GetRfcs function
+func TestGetRfcs(t *testing.T) {
+	// initialize
+	prs := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "open", Title: "RFC: open"},
+		&exGit.PullRequestDetails{RFCIdentifier: "merged", Title: "RFC: merged", Merged: true},
+	}
+	filter := func(exGit.PullRequest) bool { return true }
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return prs, nil
+		},
+		getIdsAndTitles: func(prs exGit.PullRequests) (exGit.IdsAndTitles, error) {
+			idsAndTitles := exGit.IdsAndTitles{}
+			for _, pr := range prs {
+				details := pr.(*exGit.PullRequestDetails)
+				idsAndTitles = append(idsAndTitles, map[string]string{details.RFCIdentifier: details.Title})
+			}
+			return idsAndTitles, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			links := &models.Links{PullRequest: "pr/" + rfcIdentifier}
+			if tagged {
+				links.Tag = "tag/" + rfcIdentifier
+			}
+			return links
+		},
+		withOwner: func(owner *string) exGit.FilterOption { return filter },
+		isMerged:  func(merged *bool) exGit.FilterOption { return filter },
+	}
+
+	// act
+	rfcs, err := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	links := rfcs.Links
+	if len(rfcs.RFCs) != 2 || *rfcs.Count != 2 {
+		t.Errorf("unexpected results. expected 2 RFCs\n actual: %v", rfcs.RFCs)
+	}
+	if rfcs.Summaries != nil {
+		t.Errorf("unexpected summaries without an owner filter: %v", rfcs.Summaries)
+	}
+	if links["open"] == nil || links["open"].PullRequest != "pr/open" || links["open"].Tag != "" {
+		t.Errorf("unexpected links for open RFC: %+v", links["open"])
+	}
+	if links["merged"] == nil || links["merged"].Tag != "tag/merged" {
+		t.Errorf("unexpected links for merged RFC: %+v", links["merged"])
+	}
+}
+
+// TestCachedRfcs tests that equivalent getRfcs queries are served from cache until an RFC changes, and that a fresh
+// response can be requested
+func TestCachedRfcs(t *testing.T) {
+	// initialize
+	queries := 0
+	filter := func(exGit.PullRequest) bool { return true }
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			queries++
+			return exGit.PullRequests{}, nil
+		},
+		getIdsAndTitles: func(prs exGit.PullRequests) (exGit.IdsAndTitles, error) {
+			return exGit.IdsAndTitles{}, nil
+		},
+		withOwner: func(owner *string) exGit.FilterOption { return filter },
+		isMerged:  func(merged *bool) exGit.FilterOption { return filter },
+	}
+	query := func(data *models.GetRfcs, refresh bool) time.Time {
+		_, retrievedAt, err := CachedRfcs(context.Background(), mg, data, refresh)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		return retrievedAt
+	}
+
+	// act
+	first := query(&models.GetRfcs{Count: -1, Fields: []models.RFCField{models.LinksField, models.StateField}}, false)
+	cached := query(&models.GetRfcs{Count: -1, State: "ALL",
+		Fields: []models.RFCField{models.StateField, models.LinksField}}, false)
+	cachedQueries := queries
+	query(&models.GetRfcs{Count: -1, Fields: []models.RFCField{models.LinksField, models.StateField}}, true)
+	refreshedQueries := queries
+	publishEvent(models.UpdateEvent, "123", "tstark", "", nil)
+	query(&models.GetRfcs{Count: -1, Fields: []models.RFCField{models.LinksField, models.StateField}}, false)
+
+	// assert
+	if cachedQueries != 1 || !cached.Equal(first) {
+		t.Errorf("expected the equivalent query to be served from cache, got %d queries", cachedQueries)
+	}
+	if refreshedQueries != 2 || queries != 3 {
+		t.Errorf("expected refreshed queries and queries after a change to bypass the cache, got %d and %d queries",
+			refreshedQueries-cachedQueries, queries-refreshedQueries)
+	}
+}
+
+// TestGetRfcsFilters tests that the filters of the request are all applied to the pull requests
+func TestGetRfcsFilters(t *testing.T) {
+	// initialize
+	prs := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "1662-catalog", Labels: []string{"breaking-change"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "1662-playback"},
+		&exGit.PullRequestDetails{RFCIdentifier: "1663-catalog", Labels: []string{"breaking-change"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "1662-search", Labels: []string{"docs"}},
+	}
+	filter := func(exGit.PullRequest) bool { return true }
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			filtered := exGit.PullRequests{}
+			for _, pr := range prs {
+				if exGit.And(opts...)(pr) {
+					filtered = append(filtered, pr)
+				}
+			}
+			return filtered, nil
+		},
+		getIdsAndTitles: func(prs exGit.PullRequests) (exGit.IdsAndTitles, error) {
+			idsAndTitles := exGit.IdsAndTitles{}
+			for _, pr := range prs {
+				idsAndTitles = append(idsAndTitles, map[string]string{pr.(*exGit.PullRequestDetails).RFCIdentifier: ""})
+			}
+			return idsAndTitles, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			return &models.Links{}
+		},
+		withOwner: func(owner *string) exGit.FilterOption { return filter },
+		isMerged:  func(merged *bool) exGit.FilterOption { return filter },
+	}
+	prefix := "1662"
+
+	// act
+	rfcs, err := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1, HeadPrefix: &prefix,
+		Filter: &models.RFCFilter{Or: []models.RFCFilter{
+			{Name: exGit.LABEL_FILTER, Argument: "breaking-change"},
+			{Not: &models.RFCFilter{Name: exGit.LABEL_FILTER, Argument: "docs"}},
+		}}})
+	_, unknownErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1,
+		Filter: &models.RFCFilter{Not: &models.RFCFilter{Name: "reviewer", Argument: "tstark"}}})
+	_, ambiguousErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1,
+		Filter: &models.RFCFilter{Name: exGit.LABEL_FILTER, Not: &models.RFCFilter{Name: exGit.LABEL_FILTER}}})
+	mg.capabilities = func() models.Capabilities { return models.Capabilities{Provider: "bitbucket"} }
+	_, unlabelledErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1, Label: getStringPointer("docs")})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if fmt.Sprint(rfcs.RFCs) != "[map[1662-catalog:] map[1662-playback:]]" {
+		t.Errorf("unexpected results: %v", rfcs.RFCs)
+	}
+	if !errors.Is(unknownErr, exGit.ErrInvalidFilter) || !errors.Is(ambiguousErr, exGit.ErrInvalidFilter) {
+		t.Errorf("expected invalid filter errors, got %v and %v", unknownErr, ambiguousErr)
+	}
+	if !errors.Is(unlabelledErr, exGit.ErrInvalidFilter) {
+		t.Errorf("expected labels to be rejected without the capability, got %v", unlabelledErr)
+	}
+}
+
+// TestCheckReadiness tests the CheckReadiness function
+func TestCheckReadiness(t *testing.T) {
+	// initialize
+	sufficient := &mockGit{getMissingPermissions: func(ctx context.Context) ([]string, error) { return []string{}, nil }}
+	insufficient := &mockGit{getMissingPermissions: func(ctx context.Context) ([]string, error) {
+		return []string{"read:org scope"}, nil
+	}}
+	failing := &mockGit{getMissingPermissions: func(ctx context.Context) ([]string, error) {
+		return nil, fmt.Errorf("bad credentials")
+	}}
+
+	testCases := []struct {
+		clients       map[string]exGit.Git
+		setupErrors   map[string]error
+		expectedReady bool
+		expected      string
+	}{
+		{
+			clients:       map[string]exGit.Git{"machine": sufficient, "user": sufficient},
+			expectedReady: true,
+			expected:      "[{machine [] } {user [] }]",
+		},
+		{
+			clients:       map[string]exGit.Git{"machine": insufficient, "user": sufficient},
+			expectedReady: false,
+			expected:      "[{machine [read:org scope] } {user [] }]",
+		},
+		{
+			clients:       map[string]exGit.Git{"user": failing},
+			setupErrors:   map[string]error{"machine": fmt.Errorf("no machine token specified")},
+			expectedReady: false,
+			expected:      "[{machine [] no machine token specified} {user [] bad credentials}]",
+		},
+	}
+
+	// assert
+	for _, testCase := range testCases {
+		tokenCheckCache.Clear()
+
+		actual := CheckReadiness(context.Background(), testCase.clients, testCase.setupErrors)
+
+		if actual.Ready != testCase.expectedReady {
+			t.Errorf("unexpected readiness. expected: %v\n actual: %v", testCase.expectedReady, actual.Ready)
+		}
+		if fmt.Sprint(actual.Tokens) != testCase.expected {
+			t.Errorf("unexpected token checks. expected: %s\n actual: %v", testCase.expected, actual.Tokens)
+		}
+	}
+}
+
+// TestAuthorize tests that the user is authorized through their teams, which are read once, and approvals require the
+// approve permission
+func TestAuthorize(t *testing.T) {
+	// initialize
+	policy, err := authz.NewPolicy(authz.Policy{Teams: map[string][]models.Permission{
+		"reviewers": {models.ReviewPermission},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authz.Default = policy
+	defer func() { authz.Default = nil }()
+	calls := 0
+	mg := &mockGit{getUserTeams: func(ctx context.Context) (set.Set[string], error) {
+		calls++
+		return set.NewSetOf("reviewers"), nil
+	}}
+	userTeamsCache.Clear()
+	defer userTeamsCache.Clear()
+
+	// act
+	reviewErr := Authorize(context.Background(), mg, models.ReviewPermission)
+	mergeErr := Authorize(context.Background(), mg, models.MergePermission)
+	_, approveErr := ReviewRequest(context.Background(), mg, mg, &models.Review{
+		RFCIdentifier: "123456",
+		Type:          string(models.ApproveReview),
+	})
+
+	// assert
+	if reviewErr != nil {
+		t.Errorf("expected the review permission to be granted, got %v", reviewErr)
+	}
+	if !errors.Is(mergeErr, models.ErrNotPermitted) || !errors.Is(approveErr, models.ErrNotPermitted) {
+		t.Errorf("expected merging and approving not to be permitted, got %v, %v", mergeErr, approveErr)
+	}
+	if calls != 1 {
+		t.Errorf("expected the teams of the user to be read once, got %d", calls)
+	}
+}
+
+// TestAuthorizeSSO tests that users authenticated through SSO are authorized through the teams listing their login
+// rather than the teams of the shared client
+func TestAuthorizeSSO(t *testing.T) {
+	// initialize
+	policy, err := authz.NewPolicy(authz.Policy{Teams: map[string][]models.Permission{
+		"reviewers":     {models.ReviewPermission},
+		"schema-admins": {models.MergePermission},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authz.Default = policy
+	defer func() { authz.Default = nil }()
+	mg := &mockGit{
+		getUserTeams: func(ctx context.Context) (set.Set[string], error) {
+			return set.NewSetOf("reviewers", "schema-admins"), nil
+		},
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			if team == "reviewers" {
+				return set.NewSetOf("tstark", "bbanner"), nil
+			}
+			return set.NewSetOf("nfury"), nil
+		},
+	}
+	ssoTeamsCache.Clear()
+	defer ssoTeamsCache.Clear()
+	ctx := oidc.NewContext(context.Background(), &oidc.Identity{Login: "tstark"})
+
+	// act
+	reviewErr := Authorize(ctx, mg, models.ReviewPermission)
+	mergeErr := Authorize(ctx, mg, models.MergePermission)
+	login := currentUser(ctx, mg)
+
+	// assert
+	if reviewErr != nil || !errors.Is(mergeErr, models.ErrNotPermitted) {
+		t.Errorf("expected reviewing only to be permitted, got %v, %v", reviewErr, mergeErr)
+	}
+	if login != "tstark" {
+		t.Errorf("expected the SSO login to be the current user, got %s", login)
+	}
+}
+
+// TestCheckBranchProtection tests that drift of the branch protection is reported and conclusive checks are cached
+func TestCheckBranchProtection(t *testing.T) {
+	// initialize
+	protectionCheckCache.Clear()
+	defer protectionCheckCache.Clear()
+	calls := 0
+	mg := &mockGit{getBranchProtection: func(ctx context.Context) (*exGit.BranchProtection, error) {
+		if calls++; calls == 1 {
+			return nil, fmt.Errorf("403 Resource not accessible by integration")
+		}
+		return &exGit.BranchProtection{Branch: "main", Protected: true, RequiredApprovals: 1}, nil
+	}}
+
+	// act
+	failed := CheckBranchProtection(context.Background(), mg, "events", nil, 1)
+	drifted := CheckBranchProtection(context.Background(), mg, "events", []string{"ci/build"}, 2)
+	cached := CheckBranchProtection(context.Background(), mg, "events", []string{"ci/build"}, 2)
+
+	// assert
+	if failed.Error == "" || failed.Domain != "events" {
+		t.Errorf("expected the failed check to be reported, got %+v", failed)
+	}
+	if fmt.Sprint(drifted.Drift) != "[branch main requires 1 approvals, expected at least 2 branch main does not "+
+		"require status checks to pass]" {
+		t.Errorf("unexpected drift: %v", drifted.Drift)
+	}
+	if calls != 2 || !reflect.DeepEqual(cached, drifted) {
+		t.Errorf("expected the conclusive check to be served from cache, got %d calls", calls)
+	}
+}
+
+// TestStartWarmUp tests that the service is not ready while the startup warm-up is in progress
+func TestStartWarmUp(t *testing.T) {
+	// initialize
+	sufficient := &mockGit{getMissingPermissions: func(ctx context.Context) ([]string, error) { return []string{}, nil }}
+	clients := map[string]exGit.Git{"machine": sufficient}
+	tokenCheckCache.Clear()
+
+	// assert
+	done := StartWarmUp()
+	if readiness := CheckReadiness(context.Background(), clients, nil); readiness.Ready || !readiness.WarmingUp {
+		t.Errorf("expected the service not to be ready while warming up, got %+v", readiness)
+	}
+	done()
+	if readiness := CheckReadiness(context.Background(), clients, nil); !readiness.Ready || readiness.WarmingUp {
+		t.Errorf("expected the service to be ready once warmed up, got %+v", readiness)
+	}
+}
+
+// TestBuildDigests tests the BuildDigests function
+func TestBuildDigests(t *testing.T) {
+	// initialize
+	now := time.Now()
+	since := now.Add(-DIGEST_PERIOD)
+	openPullRequestCache.Clear()
+	ownership.Default = ownership.New(map[string][]string{"EntityType": {"schema-admins"}})
+	defer func() { ownership.Default = ownership.New(nil) }()
+	open := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "awaiting", Author: "bbanner", RequestedTeams: []string{"avengers"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "failed", Author: "tstark", UpdatedAt: now},
+	}
+	closed := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "merged", Merged: true, MergedAt: now.Add(-time.Hour)},
+		&exGit.PullRequestDetails{RFCIdentifier: "merged-stale", Merged: true, MergedAt: now.Add(-48 * time.Hour)},
+		&exGit.PullRequestDetails{RFCIdentifier: "merged-unowned", Merged: true, MergedAt: now.Add(-time.Hour)},
+	}
+	contents := map[string]string{
+		"awaiting":       `{"actions": []}`,
+		"failed":         `{"actions": [{"actionType": "load", "data": {"status": "failed"}}]}`,
+		"merged":         `{"actions": [{"actionType": "add", "target": {"targetDescriptor": "EntityType"}}]}`,
+		"merged-stale":   `{"actions": [{"actionType": "add", "target": {"targetDescriptor": "EntityType"}}]}`,
+		"merged-unowned": `{"actions": [{"actionType": "add", "target": {"targetDescriptor": "Event"}}]}`,
+	}
+	members := map[string]set.Set[string]{
+		"avengers":      set.NewSetOf("tstark", "bbanner"),
+		"schema-admins": set.NewSetOf("nromanoff"),
+	}
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			if state == exGit.CLOSED_STATE {
+				return closed, nil
+			}
+			return open, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			content := contents[branch]
+			return &content, getStringPointer("junk-sha"), nil
+		},
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			return members[team], nil
+		},
+		isMerged: func(merged *bool) exGit.FilterOption { return func(exGit.PullRequest) bool { return true } },
+	}
+
+	// act
+	actual, err := BuildDigests(context.Background(), mg, since)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	summary := []string{}
+	for _, digest := range actual {
+		summary = append(summary, fmt.Sprintf("%s:%v/%v/%v", digest.Team, len(digest.AwaitingReview),
+			len(digest.FailedLoads), len(digest.NewlyMerged)))
+	}
+	if fmt.Sprint(summary) != "[avengers:1/1/0 schema-admins:0/0/1]" {
+		t.Errorf("unexpected digests. expected: [avengers:1/1/0 schema-admins:0/0/1]\n actual: %v", summary)
+	}
+}
+
+// TestAssignReviewers tests that a single, least loaded, member of each owning team other than the author is requested
+// to review an RFC
+func TestAssignReviewers(t *testing.T) {
+	// initialize
+	openPullRequestCache.Clear()
+	assignment.Default, _ = assignment.NewAssigner(assignment.LeastLoaded)
+	ownership.Default = ownership.New(map[string][]string{"EntityType": {"avengers"}, "Event": {"shield"}})
+	defer func() {
+		assignment.Default = nil
+		ownership.Default = ownership.New(nil)
+	}()
+	rfc := &models.RFC{Actions: models.Actions{
+		&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+		&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "Event"}},
+	}}
+	open := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "a", RequestedReviewers: []string{"bbanner", "pcoulson"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "b", RequestedReviewers: []string{"bbanner"}},
+	}
+	members := map[string]set.Set[string]{
+		"avengers": set.NewSetOf("tstark", "bbanner", "nromanoff"),
+		"shield":   set.NewSetOf("nromanoff", "pcoulson"),
+	}
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return open, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			return members[team], nil
+		},
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		requestReviewers: func(ctx context.Context, pr exGit.PullRequest, reviewers []string) error {
+			return nil
+		},
+	}
+
+	// act
+	assignReviewers(context.Background(), mg, "rfc", rfc, "tstark")
+
+	// assert
+	// nromanoff is the least loaded avenger and, as a member of shield, also covers it
+	mg.AssertCalled(t, "RequestReviewers", nil, []string{"nromanoff"})
+	if state := assignment.Default.Teams(); state["avengers"].LastAssigned != "nromanoff" || len(state) != 1 {
+		t.Errorf("unexpected assignment state: %+v", state)
+	}
+}
+
+// TestRequestTeamReviewers tests that the owning teams of an RFC are requested as a whole without an assignment
+// strategy
+func TestRequestTeamReviewers(t *testing.T) {
+	// initialize
+	ownership.Default = ownership.NewWithRules([]ownership.Rule{{Pattern: "Event*", Teams: []string{"shield"}}},
+		map[string][]string{"EntityType": {"avengers"}})
+	defer func() { ownership.Default = ownership.New(nil) }()
+	rfc := &models.RFC{Actions: models.Actions{
+		&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+		&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EventType"}},
+	}}
+	mg := &mockGit{
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		requestTeamReviewers: func(ctx context.Context, pr exGit.PullRequest, teams []string) error {
+			return nil
+		},
+	}
+
+	// act
+	assignReviewers(context.Background(), mg, "rfc", rfc, "tstark")
+
+	// assert
+	mg.AssertCalled(t, "RequestTeamReviewers", nil, []string{"avengers", "shield"})
+}
+
+// TestPullRequestOptions tests that pull requests are opened with the triage derived from their RFC, without requesting
+// a review from the author
+func TestPullRequestOptions(t *testing.T) {
+	// initialize
+	triage.Default, _ = triage.NewPolicy(triage.Policy{
+		Defaults: triage.Triage{Labels: []string{"rfc"}},
+		Rules: []triage.Rule{{
+			ActionType:       models.AddAction,
+			TargetDescriptor: "Entity*",
+			Triage:           triage.Triage{Labels: []string{"schema"}, Reviewers: []string{"pparker", "tstark"}},
+		}},
+	})
+	defer func() { triage.Default = nil }()
+	rfc := &models.RFC{Actions: models.Actions{
+		&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+	}}
+	login := "tstark"
+	mg := &mockGit{getUserLogin: func(ctx context.Context) (*string, error) { return &login, nil }}
+
+	// act
+	options := pullRequestOptions(context.Background(), mg, "rfc", rfc)
+
+	// assert
+	if !reflect.DeepEqual(options.Labels, []string{"rfc", "schema"}) {
+		t.Errorf("expected the default and matching labels, got %v", options.Labels)
+	}
+	if !reflect.DeepEqual(options.Reviewers, []string{"pparker"}) {
+		t.Errorf("expected the author not to be requested to review, got %v", options.Reviewers)
+	}
+	if options.Title == "" {
+		t.Errorf("expected the pull request to be titled")
+	}
+	if !strings.Contains(options.Description, "| add |  | EntityType |") {
+		t.Errorf("expected the pull request to be described by its actions, got %s", options.Description)
+	}
+}
+
+// TestModerateComments tests that the comments the comment filters object to are rejected, flagged or held for
+// moderation depending on the configured action
+func TestModerateComments(t *testing.T) {
+	// initialize
+	defer func() { moderation.Default = nil }()
+	review := func() *models.Review {
+		return &models.Review{RFCIdentifier: "123456", TopLevelComment: "buy spam",
+			Comments: map[string][]string{"action-sha": {"looks good", "spam spam"}}}
+	}
+
+	// act
+	moderation.Default, _ = moderation.NewModerator(moderation.REJECT_ACTION, moderation.NewWordList([]string{"spam"}))
+	_, _, rejectErr := moderateComments(context.Background(), review(), "tstark", "rfc-sha")
+	moderation.Default, _ = moderation.NewModerator(moderation.FLAG_ACTION, moderation.NewWordList([]string{"spam"}))
+	flagged, _, flagErr := moderateComments(context.Background(), review(), "tstark", "rfc-sha")
+	moderation.Default, _ = moderation.NewModerator(moderation.HOLD_ACTION, moderation.NewWordList([]string{"spam"}))
+	heldReview := review()
+	_, held, holdErr := moderateComments(context.Background(), heldReview, "tstark", "rfc-sha")
+
+	// assert
+	if !errors.Is(rejectErr, models.ErrCommentRejected) {
+		t.Errorf("expected the review to be rejected, got %v", rejectErr)
+	}
+	if flagErr != nil || holdErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", flagErr, holdErr)
+	}
+	if len(flagged) != 2 || flagged["looks good"] != nil {
+		t.Errorf("expected the objectionable comments to be flagged, got %v", flagged)
+	}
+	if held != 2 || heldReview.TopLevelComment != "" ||
+		!reflect.DeepEqual(heldReview.Comments, map[string][]string{"action-sha": {"looks good"}}) {
+		t.Errorf("expected the objectionable comments to be held, got %d held and %+v", held, heldReview)
+	}
+	if comments := GetHeldComments().Comments; len(comments) != 2 || comments[0].Commenter != "tstark" {
+		t.Errorf("expected the held comments to be listed, got %+v", comments)
+	}
+}
+
+// TestValidateActionData tests that submitted RFCs are rejected with an error per offending field when the data of
+// their actions does not conform to the schemas of the tracking repository
+func TestValidateActionData(t *testing.T) {
+	// initialize
+	schemas.Directory = "schemas"
+	schemaRegistryCache.Clear()
+	defer func() {
+		schemas.Directory = ""
+		schemaRegistryCache.Clear()
+	}()
+	fetches := 0
+	gitInstance := &mockGit{
+		getSchemas: func(ctx context.Context, directory string) (map[string]string, error) {
+			fetches++
+			return map[string]string{"add.item.json": `{"type": "object", "required": ["name"],
+				"properties": {"name": {"type": "string"}, "size": {"type": "integer"}}}`}, nil
+		},
+	}
+	rfc := &models.RFC{Actions: models.Actions{
+		{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget},
+			Data: map[string]interface{}{"size": "large"}},
+	}}
+
+	// act
+	_, err := SubmitRequest(context.Background(), gitInstance, rfc, true)
+
+	// assert
+	var schemaErr *models.SchemaError
+	if !errors.As(err, &schemaErr) || !errors.Is(err, models.ErrInvalid) {
+		t.Fatalf("expected a schema error, got %v", err)
+	}
+	var fields []string
+	for _, fieldErr := range schemaErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	if expected := []string{"actions[0].data", "actions[0].data.size"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected offending fields. expected: %v\n actual: %v", expected, fields)
+	}
+
+	// act & assert the compiled schemas are reused
+	if err = validateActionData(context.Background(), gitInstance, rfc); err == nil || fetches != 1 {
+		t.Errorf("expected the cached schemas to reject the RFC again, got %v after %d fetches", err, fetches)
+	}
+}
+
+// TestCheckActions tests that submissions and updates holding an action that does not meet the requirements of its
+// action type are rejected before the RFC is written
+func TestCheckActions(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	content := signed(`{"actions": [{"actionType": "add", "target": {"targetType": "item",
+		"targetDescriptor": "Event"}}]}`)
+	mg := &mockGit{
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return nil, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, nil, nil
+		},
+	}
+	rename := &models.Action{ActionType: models.RenameAction, Target: models.Target{TargetType: models.ItemTarget,
+		TargetDescriptor: "Event", LookupKey: "name", LookupValue: "Played"}}
+
+	// act & assert a rename without a new name is rejected on submission
+	_, err := SubmitRequest(context.Background(), mg, &models.RFC{Actions: models.Actions{rename}}, true)
+	if !errors.Is(err, models.ErrInvalidAction) || !strings.Contains(err.Error(), "actions[0].data.newName") {
+		t.Errorf("expected an invalid action error, got %v", err)
+	}
+
+	// act & assert a delete without lookup is rejected on update
+	remove := &models.Action{ActionType: models.DeleteAction, Target: models.Target{TargetType: models.ItemTarget,
+		TargetDescriptor: "Event"}}
+	_, err = UpdateRequest(context.Background(), mg, &models.Update{RFCIdentifier: identifier,
+		RFC: &models.RFC{Actions: models.Actions{remove}}, Signature: signatureOf(content)})
+	if !errors.Is(err, models.ErrInvalidAction) || !strings.Contains(err.Error(), "actions[0].target.lookupKey") {
+		t.Errorf("expected an invalid action error, got %v", err)
+	}
+}
+
+// TestReservations tests that reserve actions keep other teams from adding to their target descriptors once loaded,
+// until they are released by their team
+func TestReservations(t *testing.T) {
+	// initialize
+	defaultReservations := reservations.Default
+	reservations.Default = reservations.NewMemoryStore()
+	defer func() { reservations.Default = defaultReservations }()
+	login := "hulk"
+	mg := &mockGit{
+		getUserLogin: func(ctx context.Context) (*string, error) {
+			return &login, nil
+		},
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			return set.NewSetOf("tstark"), nil
+		},
+	}
+	reserving := &models.RFC{Actions: models.Actions{{ActionType: models.ReserveAction, Target: models.Target{
+		TargetType: models.ItemTarget, TargetDescriptor: "playback.*"}, Data: map[string]interface{}{"team": "avengers"}}}}
+	adding := &models.RFC{Actions: models.Actions{{ActionType: models.AddAction, Target: models.Target{
+		TargetType: models.ItemTarget, TargetDescriptor: "playback.Started"}}}}
+
+	// act
+	recordReservations(context.Background(), reserving, "123")
+	rejected := checkReservations(context.Background(), mg, adding, "")
+	own := checkReservations(context.Background(), mg, reserving, "123")
+	_, notMember := ReleaseReservation(context.Background(), mg, &models.ReleaseReservation{
+		TargetDescriptor: "playback.*"})
+	login = "tstark"
+	allowed := checkReservations(context.Background(), mg, adding, "")
+	_, releaseErr := ReleaseReservation(context.Background(), mg, &models.ReleaseReservation{
+		TargetDescriptor: "playback.*"})
+	held, _ := GetReservations(context.Background())
+
+	// assert
+	if !errors.Is(rejected, models.ErrTargetReserved) {
+		t.Errorf("expected the RFC of another team to be rejected, got %v", rejected)
+	}
+	if own != nil || allowed != nil {
+		t.Errorf("expected the reserving RFC and the members of the team to be allowed, got %v, %v", own, allowed)
+	}
+	if !errors.Is(notMember, models.ErrNotReservationTeam) {
+		t.Errorf("expected the reservation to only be released by its team, got %v", notMember)
+	}
+	if releaseErr != nil || len(held.Reservations) != 0 {
+		t.Errorf("expected the reservation to be released, got %v, %+v", releaseErr, held)
+	}
+}
+
+// TestReviewStats tests that review requests are recorded as responded to by the first review answering them, whether
+// it is submitted through Harmonia or through the provider, and that requests no longer pending are reconciled
+func TestReviewStats(t *testing.T) {
+	// initialize
+	defaultRequests := reviewrequests.Default
+	reviewrequests.Default = reviewrequests.NewMemoryStore()
+	defer func() { reviewrequests.Default = defaultRequests }()
+	openPullRequestCache.Clear()
+	now := time.Now().UTC()
+	open := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "1", RequestedReviewers: []string{"tstark"},
+			RequestedTeams: []string{"avengers"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "2"},
+	}
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return open, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return pr, nil
+		},
+		getReviewDetails: func(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+			return []exGit.ReviewDetails{{Reviewer: "bbanner", State: exGit.APPROVED_STATE,
+				SubmittedAt: now.Add(-70 * time.Hour)}}, nil
+		},
+		getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+			return set.NewSetOf("nromanoff"), nil
+		},
+	}
+	// reviewed through the provider, and made on an RFC that is no longer open
+	_ = reviewrequests.Default.Save(context.Background(), models.ReviewRequest{RFCIdentifier: "2", Reviewer: "bbanner",
+		RequestedAt: now.Add(-72 * time.Hour)})
+	_ = reviewrequests.Default.Save(context.Background(), models.ReviewRequest{RFCIdentifier: "3", Reviewer: "bbanner",
+		RequestedAt: now.Add(-72 * time.Hour)})
+
+	// act
+	recordReviewRequests(context.Background(), mg, "1", []string{"tstark"}, []string{"avengers"})
+	recordReviewResponse(context.Background(), mg, "1", "nromanoff", now.Add(time.Hour))
+	stats, err := GetReviewStats(context.Background(), mg)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	summary := []string{}
+	for _, reviewer := range stats.Reviewers {
+		summary = append(summary, fmt.Sprintf("%s:%v/%v/%v", reviewer.Reviewer, reviewer.Requests,
+			reviewer.Responded, reviewer.Pending))
+	}
+	if fmt.Sprint(summary) != "[avengers:1/1/0 bbanner:1/1/0 tstark:1/0/1]" {
+		t.Errorf("unexpected review stats. expected: [avengers:1/1/0 bbanner:1/1/0 tstark:1/0/1]\n actual: %v", summary)
+	}
+	if stats.Reviewers[1].MeanResponseSeconds != (2*time.Hour).Seconds() || len(stats.Breaches) != 0 {
+		t.Errorf("expected the provider review to respond within the SLA, got %+v", stats)
+	}
+}
+
+// TestLoadTargets tests that RFCs are only submitted with configured load targets, are loaded into each of them and are
+// only merged as allowed by the merge policy
+func TestLoadTargets(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	loader.Default.Register("search", loader.LoaderFunc(func(ctx context.Context, content []byte) error {
+		return fmt.Errorf("search index unavailable")
+	}))
+	defer func() { loader.Default = defaultLoaders }()
+
+	// act & assert unknown targets are rejected
+	_, err := SubmitRequest(context.Background(), &mockGit{}, &models.RFC{LoadTargets: []string{"warehouse"}}, true)
+	if !errors.Is(err, models.ErrUnknownLoadTarget) {
+		t.Errorf("expected an unknown load target error, got %v", err)
+	}
+
+	// act
+	store := &gatedStore{}
+	rfc := &models.RFC{Actions: models.Actions{}, LoadTargets: []string{"primary", "search"}}
+	err = loadAndMerge(context.Background(), store.mock(""), nil, rfc, identifier)
+
+	// assert partially loaded RFCs are not merged by default
+	if err == nil {
+		t.Errorf("expected the partially loaded RFC not to be merged")
+	}
+	loaded, _ := decodeRFC(context.Background(), identifier, &store.content)
+	expected := map[string]string{"primary": SUCCESSFUL_STATUS, "search": FAILED_STATUS}
+	if status := loaded.GetLoadStatus(); status == nil || *status != PARTIAL_STATUS {
+		t.Errorf("unexpected load status: %v", status)
+	}
+	if actual := loaded.GetTargetLoadStatuses(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("unexpected target statuses. expected: %v\n actual: %v", expected, actual)
+	}
+
+	// act & assert the merge policy can allow partially loaded RFCs to be merged
+	if err = loader.Default.SetMergePolicy(loader.AllowPartial); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !mergeAllowed(PARTIAL_STATUS) || mergeAllowed(FAILED_STATUS) {
+		t.Errorf("unexpected merge policy, partial loads should be mergeable and failed loads should not")
+	}
+}
+
+// TestTenantLoadTargets tests that RFCs of a tenant are only loaded into the load targets of their tenant, and RFCs of
+// other schema domains never are
+func TestTenantLoadTargets(t *testing.T) {
+	// initialize
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	loader.Default.Register("catalog-db", loader.Placeholder("catalog-db"))
+	registry, err := tenants.New(map[string]tenants.Tenant{
+		"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	tenants.Default = registry
+	defer func() {
+		loader.Default = defaultLoaders
+		tenants.Default = nil
+	}()
+
+	// act
+	_, submitErr := SubmitRequest(context.Background(), &mockGit{}, &models.RFC{LoadTargets: []string{"catalog-db"}}, true)
+	validation, validateErr := ValidateRequest(context.Background(), &mockGit{},
+		&models.RFC{Domain: "catalog", LoadTargets: []string{"primary"}}, true)
+
+	// assert
+	if !errors.Is(submitErr, tenants.ErrCrossTenant) {
+		t.Errorf("expected a cross-tenant error, got %v", submitErr)
+	}
+	if validateErr != nil || !strings.Contains(fmt.Sprint(validation.Errors), "cannot be loaded into primary") {
+		t.Errorf("expected the load target of another domain to be reported, got %+v, err: %v", validation, validateErr)
+	}
+	catalog := &models.RFC{Domain: "catalog"}
+	if actual := loadTargets(catalog); fmt.Sprint(actual) != "[catalog-db]" {
+		t.Errorf("expected the RFC to be loaded into the load targets of its tenant, got %v", actual)
+	}
+	if actual := loadTargets(&models.RFC{}); fmt.Sprint(actual) != "[primary]" {
+		t.Errorf("expected the RFC to be loaded into the load targets of no tenant, got %v", actual)
+	}
+}
+
+// TestCheckTenantMember tests that the shared token of a tenant is only used for the members of its teams, not for
+// users of another tenant naming its schema domain nor for users without an identity
+func TestCheckTenantMember(t *testing.T) {
+	// initialize
+	registry, err := tenants.New(map[string]tenants.Tenant{
+		"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"},
+			Members: []string{"catalog"}},
+		"playback": {MachineToken: "PLAYBACK_MACHINE_TOKEN", LoadTargets: []string{"playback-db"},
+			Members: []string{"playback"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	tenants.Default = registry
+	defer func() { tenants.Default = nil }()
+	memberTeamsCache.Clear()
+	defer memberTeamsCache.Clear()
+	mg := &mockGit{getTeamMembers: func(ctx context.Context, team string) (set.Set[string], error) {
+		if team == "playback" {
+			return set.NewSetOf("tstark"), nil
+		}
+		return set.NewSetOf("bbanner"), nil
+	}}
+	ctx := oidc.NewContext(context.Background(), &oidc.Identity{Login: "tstark"})
+
+	// act
+	memberErr := CheckTenantMember(ctx, mg, "playback")
+	otherTenantErr := CheckTenantMember(ctx, mg, "catalog")
+	anonymousErr := CheckTenantMember(context.Background(), mg, "playback")
+	noTenantErr := CheckTenantMember(context.Background(), mg, "search")
+
+	// assert
+	if memberErr != nil || noTenantErr != nil {
+		t.Errorf("expected members and schema domains that are not tenants to be admitted, got %v, %v", memberErr,
+			noTenantErr)
+	}
+	if !errors.Is(otherTenantErr, tenants.ErrCrossTenant) || !errors.Is(anonymousErr, tenants.ErrCrossTenant) {
+		t.Errorf("expected a cross-tenant error, got %v, %v", otherTenantErr, anonymousErr)
+	}
+}
+
+// TestLoadTemplate tests that template variables are expanded in the loaded content only, and that RFCs referencing
+// variables without a value fail to load
+func TestLoadTemplate(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defaultLoaders := loader.Default
+	var content string
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.LoaderFunc(func(ctx context.Context, loaded []byte) error {
+		content = string(loaded)
+		return nil
+	}))
+	defer func() {
+		loader.Default = defaultLoaders
+		models.SetEnvironment("")
+	}()
+	models.SetEnvironment("qa")
+	store := &gatedStore{}
+	mg := store.mock("")
+	mg.getPullRequestDetails = func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+		return &exGit.PullRequestDetails{Author: "tstark", CreatedAt: time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC)}, nil
+	}
+	rfc := &models.RFC{Actions: models.Actions{{
+		ActionType: models.AddAction,
+		Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+		Data:       map[string]interface{}{"topic": "${environment}.events", "note": "${submitter} ${submittedAt}"},
+	}}}
+
+	// act
+	status, err := loadRequest(context.Background(), mg, nil, rfc, identifier)
+
+	// assert
+	if err != nil || status != SUCCESSFUL_STATUS {
+		t.Fatalf("expected a successful load, got %s, %v", status, err)
+	}
+	if !strings.Contains(content, `"topic":"qa.events"`) || !strings.Contains(content, `"note":"tstark 2022-09-01"`) {
+		t.Errorf("expected the loaded content to be expanded, got %s", content)
+	}
+	if !strings.Contains(store.content, "${environment}.events") {
+		t.Errorf("expected the RFC file to keep its placeholders, got %s", store.content)
+	}
+
+	// act
+	models.SetEnvironment("")
+	_, err = loadRequest(context.Background(), mg, nil, rfc, identifier)
+
+	// assert
+	if !errors.Is(err, models.ErrUnknownVariable) {
+		t.Errorf("expected an unknown variable error, got %v", err)
+	}
+	loaded, _ := decodeRFC(context.Background(), identifier, &store.content)
+	if loadStatus := loaded.GetLoadStatus(); loadStatus == nil || *loadStatus != FAILED_STATUS {
+		t.Errorf("expected the load to be recorded as failed, got %v", loadStatus)
+	}
+}
+
+// TestLoadStatusStore tests that load statuses are read from the load status store and, unless configured otherwise, no
+// longer committed to the RFC file
+func TestLoadStatusStore(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defaultLoaders, defaultStatuses := loader.Default, loadstatus.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	loadstatus.Default = loadstatus.NewMemoryStore()
+	loadstatus.SetInRFCFile(false)
+	defer func() {
+		loader.Default, loadstatus.Default = defaultLoaders, defaultStatuses
+		loadstatus.SetInRFCFile(true)
+	}()
+	store := &gatedStore{content: signed(`{"actions": []}`)}
+	mg := store.mock("")
+	updates := 0
+	mg.updateFile = func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+		updates++
+		return nil
+	}
+
+	// act
+	loaded, err := loadRequest(context.Background(), mg, nil, &models.RFC{Actions: models.Actions{},
+		LoadTargets: []string{"primary"}}, identifier)
+	status, statusErr := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+
+	// assert
+	if err != nil || statusErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, statusErr)
+	}
+	if loaded != SUCCESSFUL_STATUS || updates != 0 {
+		t.Errorf("expected a successful load without RFC file updates, got %s after %d updates", loaded, updates)
+	}
+	if status.Status != SUCCESSFUL_STATUS || fmt.Sprint(status.Targets) != "map[primary:successful]" {
+		t.Errorf("expected the status to be read from the store, got %+v", status)
+	}
+}
+
+// TestLoadTimings tests that the time each action took to apply, as reported by the loader, is recorded in the RFC file
+// and only returned in the status when detail is requested, and that it is cleared when the RFC is loaded again
+func TestLoadTimings(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	timed := true
+	loader.Default.Register("primary", loader.LoaderFunc(func(ctx context.Context, content []byte) error {
+		rfc := &models.RFC{}
+		if err := json.Unmarshal(content, rfc); err != nil {
+			return err
+		}
+		for i, action := range rfc.Actions {
+			if timed && action.IsProposal() {
+				loader.StartAction(ctx, i, action)("CREATE TABLE events")
+			}
+		}
+		return nil
+	}))
+	defer func() { loader.Default = defaultLoaders }()
+	store := &gatedStore{content: signed(`{"actions": [{"actionType": "add", "target": {"targetType": "item",
+		"targetDescriptor": "Event"}}]}`)}
+	mg := store.mock("")
+	rfc, _ := decodeRFC(context.Background(), identifier, &store.content)
+
+	// act
+	_, err := loadRequest(context.Background(), mg, nil, rfc, identifier)
+	status, statusErr := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+	detailed, detailErr := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier, Detail: true})
+
+	// assert
+	if err != nil || statusErr != nil || detailErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v", err, statusErr, detailErr)
+	}
+	if status.Timings != nil {
+		t.Errorf("expected no timings without detail, got %+v", status.Timings)
+	}
+	timings := detailed.Timings["primary"]
+	if len(timings) != 1 || timings[0].Index != 0 || timings[0].ActionType != models.AddAction ||
+		timings[0].TargetDescriptor != "Event" || fmt.Sprint(timings[0].Statements) != "[CREATE TABLE events]" {
+		t.Errorf("expected the timing of the action to be recorded, got %+v", detailed.Timings)
+	}
+
+	// act & assert the timings of a previous load are cleared
+	timed = false
+	rfc, _ = decodeRFC(context.Background(), identifier, &store.content)
+	if _, err = loadRequest(context.Background(), mg, nil, rfc, identifier); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if detailed, _ = Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier,
+		Detail: true}); detailed.Timings != nil {
+		t.Errorf("expected the timings of the previous load to be cleared, got %+v", detailed.Timings)
+	}
+}
+
+// TestHandleWebhookEvent tests that webhook events drop the cached state of their RFC and that approvals load RFCs
+// that were not loaded yet
+func TestHandleWebhookEvent(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defaultQueue, defaultStatuses := jobs.Default, loadstatus.Default
+	jobs.Default = jobs.NewMemoryQueue(1, 1, time.Millisecond)
+	loadstatus.Default = loadstatus.NewMemoryStore()
+	defer func() { jobs.Default, loadstatus.Default = defaultQueue, defaultStatuses }()
+	store := &gatedStore{content: signed(`{"actions": []}`)}
+	mg := store.mock("")
+	mg.explainMergeability = func(ctx context.Context, pr exGit.PullRequest) (*models.Mergeability, error) {
+		return &models.Mergeability{Mergeable: false, Reasons: []string{"checks pending"}}, nil
+	}
+	mergeabilityCache.Set(identifier+"@yesterday", &models.Mergeability{Mergeable: true})
+	approval := &exGit.WebhookEvent{Type: exGit.REVIEW_WEBHOOK_EVENT, RFCIdentifiers: []string{identifier},
+		Actor: "pparker", Action: "approved"}
+
+	// act
+	_, statusErr := HandleWebhookEvent(context.Background(), mg, &exGit.WebhookEvent{
+		Type: exGit.STATUS_WEBHOOK_EVENT, RFCIdentifiers: []string{identifier}, Action: "success"}, true)
+	_, cached := mergeabilityCache.Get(identifier + "@yesterday")
+	ignored, ignoredErr := HandleWebhookEvent(context.Background(), mg, approval, false)
+	queuedJobs := jobs.Default.Latest(identifier)
+	loaded, loadedErr := HandleWebhookEvent(context.Background(), mg, approval, true)
+	job := jobs.Default.Latest(identifier)
+	for job != nil && job.State != models.SucceededJob && job.State != models.FailedJob {
+		time.Sleep(time.Millisecond)
+		job, _ = jobs.Default.Get(job.ID)
+	}
+	_ = loadstatus.Default.Save(context.Background(), models.LoadRecord{RFCIdentifier: identifier,
+		Status: SUCCESSFUL_STATUS})
+	skipped, skippedErr := HandleWebhookEvent(context.Background(), mg, approval, true)
+
+	// assert
+	if statusErr != nil || ignoredErr != nil || loadedErr != nil || skippedErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", statusErr, ignoredErr, loadedErr, skippedErr)
+	}
+	if cached {
+		t.Errorf("expected the cached mergeability of the RFC to be dropped")
+	}
+	if queuedJobs != nil || strings.Contains(*ignored, "load") {
+		t.Errorf("expected no load without loading on approval, got %s", *ignored)
+	}
+	if job == nil || job.Kind != models.LoadAndMergeJob || !strings.Contains(*loaded, "load request was submitted") {
+		t.Errorf("expected a load and merge job to be queued, got %+v: %s", job, *loaded)
+	}
+	if !strings.Contains(*skipped, "not loaded") || jobs.Default.Latest(identifier).ID != job.ID {
+		t.Errorf("expected a loaded RFC not to be loaded again, got %s", *skipped)
+	}
+}
+
+// TestArchiveRFCs tests that RFCs merged before the cutoff are archived oldest first, once, in a single commit
+func TestArchiveRFCs(t *testing.T) {
+	// initialize
+	now := time.Now()
+	closed := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "recent", Merged: true, MergedAt: now.Add(-time.Hour)},
+		&exGit.PullRequestDetails{RFCIdentifier: "old", Title: "RFC: old", Author: "tstark", Merged: true,
+			MergedAt: now.Add(-400 * 24 * time.Hour)},
+		&exGit.PullRequestDetails{RFCIdentifier: "older", Merged: true, MergedAt: now.Add(-500 * 24 * time.Hour)},
+		&exGit.PullRequestDetails{RFCIdentifier: "archived", Merged: true, MergedAt: now.Add(-600 * 24 * time.Hour)},
+	}
+	index := `{"rfcs": [{"rfcIdentifier": "archived", "tag": "archived"}]}`
+	var committed []string
+	var committedIndex models.ArchiveIndex
+	mg := &mockGit{
+		getArchiveIndex: func(ctx context.Context) (*string, error) { return &index, nil },
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return closed, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		isMerged: func(merged *bool) exGit.FilterOption { return func(exGit.PullRequest) bool { return true } },
+		archiveRFCs: func(ctx context.Context, rfcIdentifiers []string, content string, message string) error {
+			committed = append(committed, rfcIdentifiers...)
+			return json.Unmarshal([]byte(content), &committedIndex)
+		},
+	}
+
+	// act
+	archived, err := ArchiveRFCs(context.Background(), mg, now.Add(-365*24*time.Hour))
+	index = `{"rfcs": []}`
+	closed = closed[:1]
+	none, noneErr := ArchiveRFCs(context.Background(), mg, now.Add(-365*24*time.Hour))
+
+	// assert
+	if err != nil || noneErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, noneErr)
+	}
+	if fmt.Sprint(committed) != "[older old]" || len(archived) != 2 || archived[1].Author != "tstark" ||
+		archived[1].Tag != "old" {
+		t.Errorf("expected the old RFCs to be archived oldest first, got %v: %+v", committed, archived)
+	}
+	if len(committedIndex.RFCs) != 3 || !committedIndex.Contains("archived") || !committedIndex.Contains("old") {
+		t.Errorf("expected the index to keep previously archived RFCs, got %+v", committedIndex)
+	}
+	if len(none) != 0 || len(committed) != 2 {
+		t.Errorf("expected nothing to be committed without RFCs to archive, got %+v", none)
+	}
+}
+
+// gatedStore is an in memory RFC file used by load gate tests, it is shared with asynchronous loads so access is locked
+type gatedStore struct {
+	mu      sync.Mutex
+	content string
+}
+
+// mock returns a mockGit whose RFC file is backed by the store
+func (gs *gatedStore) mock(deploymentState string) *mockGit {
+	return &mockGit{
+		getUserLogin:   func(ctx context.Context) (*string, error) { return getStringPointer("tstark"), nil },
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil },
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			gs.mu.Lock()
+			defer gs.mu.Unlock()
+			return getStringPointer(gs.content), getStringPointer("junk-sha"), nil
+		},
+		updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+			content, err := json.Marshal(data)
+			gs.mu.Lock()
+			defer gs.mu.Unlock()
+			gs.content = string(content)
+			return err
+		},
+		createDeployment: func(ctx context.Context, pr exGit.PullRequest, environment string) (*string, error) {
+			return getStringPointer("42"), nil
+		},
+		getDeploymentStatus: func(ctx context.Context, deploymentID string) (*exGit.DeploymentStatus, error) {
+			return &exGit.DeploymentStatus{State: deploymentState, Actor: "prod-approver"}, nil
+		},
+	}
+}
+
+// TestLoadGate tests that gated loads wait for, and honor, manual and deployment approvals
+func TestLoadGate(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defer models.ClearLoadGate()
+	approve, reject := true, false
+	statusOf := func(mg *mockGit) *models.StatusResponse {
+		response, err := Status(context.Background(), mg, &models.Status{RFCIdentifier: identifier})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		return response
+	}
+
+	// a manual gate holds the load until it is decided
+	if err := models.SetLoadGate(models.ManualGate, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	store := &gatedStore{content: signed(`{"actions": []}`)}
+	mg := store.mock(exGit.DEPLOYMENT_PENDING_STATE)
+	if err := LoadRequest(context.Background(), mg, &models.Load{RFCIdentifier: identifier}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if status := statusOf(mg); status.Status != AWAITING_APPROVAL_STATUS || status.Gate == nil ||
+		status.Gate.Type != models.ManualGate || status.Gate.State != models.PendingGate {
+		t.Errorf("expected a pending manual gate, got %+v", status)
+	}
+
+	// deployment gates cannot be decided manually
+	if _, _, _, err := decideLoadGate(context.Background(), mg, identifier, models.DeploymentGate, "42", true,
+		"tstark"); !errors.Is(err, models.ErrNoPendingGate) {
+		t.Errorf("expected ErrNoPendingGate, got %v", err)
+	}
+
+	// a rejection is recorded and the load never executes
+	message, err := ApproveLoad(context.Background(), mg, mg, &models.ApproveLoad{RFCIdentifier: identifier,
+		Approve: &reject})
+	commonAsserter(t, getStringPointer("Rejected load of RFC test-identifier"), message, nil, err)
+	if status := statusOf(mg); status.Status != REJECTED_STATUS || status.Gate.State != models.RejectedGate ||
+		status.Gate.Approver != "tstark" {
+		t.Errorf("expected a rejected gate, got %+v", status)
+	}
+
+	// a decided gate cannot be decided again
+	if _, err = ApproveLoad(context.Background(), mg, mg, &models.ApproveLoad{RFCIdentifier: identifier,
+		Approve: &approve}); !errors.Is(err, models.ErrNoPendingGate) {
+		t.Errorf("expected ErrNoPendingGate, got %v", err)
+	}
+
+	// a deployment gate is approved through the environment and then loaded
+	if err = models.SetLoadGate(models.DeploymentGate, "production"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	store = &gatedStore{content: `{"actions": []}`}
+	mg = store.mock(exGit.DEPLOYMENT_PENDING_STATE)
+	if err = openLoadGate(context.Background(), mg, nil, &models.RFC{Actions: models.Actions{}}, identifier, "tstark",
+		models.NewLoadGate()); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if status := statusOf(mg); status.Gate == nil || status.Gate.Environment != "production" ||
+		status.Gate.DeploymentID != "42" {
+		t.Errorf("expected a pending deployment gate, got %+v", status)
+	}
+	if decided, err := checkDeploymentGate(context.Background(), mg, identifier, "42"); decided || err != nil {
+		t.Errorf("expected an undecided gate, got decided: %v, error: %v", decided, err)
+	}
+	mg = store.mock(exGit.DEPLOYMENT_APPROVED_STATE)
+	if decided, err := checkDeploymentGate(context.Background(), mg, identifier, "42"); !decided || err != nil {
+		t.Errorf("expected a decided gate, got decided: %v, error: %v", decided, err)
+	}
+	if status := statusOf(mg); status.Status != SUCCESSFUL_STATUS || status.Gate.State != models.ApprovedGate ||
+		status.Gate.Approver != "prod-approver" {
+		t.Errorf("expected an approved and loaded gate, got %+v", status)
+	}
+}
+
+// TestReviewedRFC tests that reviews recorded in an RFC do not fail the verification of its file, so that a reviewed
+// RFC is reviewed again, loaded and updated
+func TestReviewedRFC(t *testing.T) {
+	// initialize
+	identifier, _ := setup()
+	defer models.ClearLoadGate()
+	if err := models.SetLoadGate(models.ManualGate, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	store := &gatedStore{content: signed(`{"actions": [{"actionType": "add", "target": {"targetType": "item",
+		"targetDescriptor": "Event"}, "data": {"id": "MyEvent"}}]}`)}
+	mg := store.mock(exGit.DEPLOYMENT_PENDING_STATE)
+	mg.createReview = func(ctx context.Context, pr exGit.PullRequest, data *models.Review) error { return nil }
+	mg.getReviews = func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+		return nil, nil
+	}
+	mg.dismissApprovalReviews = func(ctx context.Context, reviews exGit.PullRequestReviews,
+		pr exGit.PullRequest) error {
+		return nil
+	}
+
+	// act
+	var errs []error
+	for _, reviewType := range []models.ReviewType{models.ApproveReview, models.AcknowledgeReview,
+		models.BlockReview} {
+		_, err := ReviewRequest(context.Background(), mg, mg, &models.Review{RFCIdentifier: identifier,
+			Type: string(reviewType)})
+		errs = append(errs, err)
+	}
+	errs = append(errs, LoadRequest(context.Background(), mg, &models.Load{RFCIdentifier: identifier}))
+	reviewed := &models.RFC{}
+	if err := json.Unmarshal([]byte(store.content), reviewed); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	_, err := UpdateRequest(context.Background(), mg, &models.Update{RFCIdentifier: identifier,
+		Signature: reviewed.Signature, RFC: &models.RFC{Actions: models.Actions{{ActionType: models.AddAction,
+			Target: models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+			Data:   map[string]interface{}{"id": "YourEvent"}}}}})
+	errs = append(errs, err)
+
+	// assert
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		}
+	}
+	updated := &models.RFC{}
+	if err := json.Unmarshal([]byte(store.content), updated); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if verification, err := updated.Verify(identifier); err != nil || !verification.Valid {
+		t.Errorf("expected the updated RFC to verify, got %+v, %v", verification, err)
+	}
+}
+
+// TestGetRfcsSummaries tests that RFCs filtered by owner are summarized
+func TestGetRfcsSummaries(t *testing.T) {
+	// initialize
+	updatedAt := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	prs := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "summary-open", State: exGit.OPEN_STATE, UpdatedAt: updatedAt},
+		&exGit.PullRequestDetails{RFCIdentifier: "summary-merged", State: exGit.CLOSED_STATE, Merged: true,
+			UpdatedAt: updatedAt},
+		&exGit.PullRequestDetails{RFCIdentifier: "summary-corrupt", State: exGit.OPEN_STATE, UpdatedAt: updatedAt},
+	}
+	reviews := []exGit.ReviewDetails{
+		{Reviewer: "tstark", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: updatedAt.Add(-2 * time.Hour)},
+		{Reviewer: "tstark", State: exGit.APPROVED_STATE, SubmittedAt: updatedAt.Add(-time.Hour)},
+		{Reviewer: "tstark", State: exGit.COMMENTED_STATE, SubmittedAt: updatedAt},
+		{Reviewer: "bbanner", State: exGit.APPROVED_STATE, SubmittedAt: updatedAt},
+		{Reviewer: "nromanoff", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: updatedAt},
+	}
+	loaded := `{"actions": [{"actionType": "load", "data": {"status": "successful"}}]}`
+	filter := func(exGit.PullRequest) bool { return true }
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return prs, nil
+		},
+		getIdsAndTitles: func(prs exGit.PullRequests) (exGit.IdsAndTitles, error) { return exGit.IdsAndTitles{}, nil },
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links { return nil },
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return nil, nil
+		},
+		getReviewDetails: func(r exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) { return reviews, nil },
+		getMergeability: func(ctx context.Context, pr exGit.PullRequest) (*bool, error) {
+			mergeable := true
+			return &mergeable, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			if branch == "summary-merged" {
+				return &loaded, getStringPointer("junk-sha"), nil
+			}
+			if branch == "summary-corrupt" {
+				return getStringPointer("junk-data"), getStringPointer("junk-sha"), nil
+			}
+			return getStringPointer(`{"actions": []}`), getStringPointer("junk-sha"), nil
+		},
+		withOwner: func(owner *string) exGit.FilterOption { return filter },
+		isMerged:  func(merged *bool) exGit.FilterOption { return filter },
+	}
+
+	// act
+	rfcs, err := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1, Owner: getStringPointer("pparker")})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	open, merged := rfcs.Summaries["summary-open"], rfcs.Summaries["summary-merged"]
+	if open == nil || open.State != exGit.OPEN_STATE || open.Approvals == nil || *open.Approvals != 2 ||
+		open.ChangesRequested == nil || *open.ChangesRequested != 1 || open.Comments == nil || *open.Comments != 1 ||
+		open.Mergeable == nil || !*open.Mergeable || open.LoadStatus != "none" {
+		t.Errorf("unexpected summary for open RFC: %+v", open)
+	}
+	if merged == nil || merged.Mergeable != nil || merged.LoadStatus != SUCCESSFUL_STATUS {
+		t.Errorf("unexpected summary for merged RFC: %+v", merged)
+	}
+	if _, ok := rfcs.Summaries["summary-corrupt"]; ok || len(rfcs.Summaries) != 2 {
+		t.Errorf("expected the corrupt RFC to be left out of summaries: %v", rfcs.Summaries)
+	}
+}
+
+// TestGetRfcsFields tests that only the selected fields of RFCs are computed and returned
+func TestGetRfcsFields(t *testing.T) {
+	// initialize
+	prs := exGit.PullRequests{&exGit.PullRequestDetails{RFCIdentifier: "fields-open", State: exGit.OPEN_STATE}}
+	filter := func(exGit.PullRequest) bool { return true }
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return prs, nil
+		},
+		getIdsAndTitles: func(prs exGit.PullRequests) (exGit.IdsAndTitles, error) { return exGit.IdsAndTitles{}, nil },
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		buildLinks: func(rfcIdentifier string, pr exGit.PullRequest, tagged bool) *models.Links {
+			return &models.Links{}
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			t.Errorf("unexpected review lookup")
+			return nil, nil
+		},
+		getMergeability: func(ctx context.Context, pr exGit.PullRequest) (*bool, error) {
+			t.Errorf("unexpected mergeability lookup")
+			return nil, nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return getStringPointer(`{"actions": []}`), getStringPointer("junk-sha"), nil
+		},
+		withOwner: func(owner *string) exGit.FilterOption { return filter },
+		isMerged:  func(merged *bool) exGit.FilterOption { return filter },
+	}
+
+	// act
+	linked, linkedErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1,
+		Fields: []models.RFCField{models.LinksField, models.StateField}})
+	loaded, loadedErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1, Owner: getStringPointer("pparker"),
+		Fields: []models.RFCField{models.LoadStatusField}})
+	_, invalidErr := GetRfcs(context.Background(), mg, &models.GetRfcs{Count: -1,
+		Fields: []models.RFCField{"approvals"}})
+
+	// assert
+	if linkedErr != nil || loadedErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", linkedErr, loadedErr)
+	}
+	if summary := linked.Summaries["fields-open"]; linked.Links["fields-open"] == nil || summary == nil ||
+		!reflect.DeepEqual(*summary, models.RFCSummary{State: exGit.OPEN_STATE}) {
+		t.Errorf("expected links and states only, got %+v", linked)
+	}
+	if summary := loaded.Summaries["fields-open"]; loaded.Links != nil || summary == nil ||
+		!reflect.DeepEqual(*summary, models.RFCSummary{LoadStatus: "none"}) {
+		t.Errorf("expected load statuses only, got %+v", loaded)
+	}
+	if !errors.Is(invalidErr, models.ErrInvalidField) {
+		t.Errorf("expected an invalid field error, got %v", invalidErr)
+	}
+}
+
+// TestWithdrawRequest tests that only the author of an RFC can withdraw it, and that the withdrawal is recorded before
+// the pull request is closed and the branch deleted
+func TestWithdrawRequest(t *testing.T) {
+	// arrange
+	identifier, _ := setup()
+	content, err := json.Marshal(&models.RFC{Signature: "rfc-sha"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var updated *models.RFC
+	mockCreator := func(login string) *mockGit {
+		return &mockGit{
+			getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+				return "pr", nil
+			},
+			getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+				return &exGit.PullRequestDetails{RFCIdentifier: identifier, Author: "tstark"}, nil
+			},
+			getUserLogin: func(ctx context.Context) (*string, error) {
+				return &login, nil
+			},
+			getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+				return getStringPointer(string(content)), getStringPointer("junk-sha"), nil
+			},
+			updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+				updated = data
+				return nil
+			},
+			closePullRequest: func(ctx context.Context, pr exGit.PullRequest) error {
+				return nil
+			},
+			deleteBranch: func(ctx context.Context, branch string) error {
+				return nil
+			},
+		}
+	}
+
+	// act
+	otherGit := mockCreator("bbanner")
+	_, otherErr := WithdrawRequest(context.Background(), otherGit, &models.Withdraw{RFCIdentifier: identifier})
+	authorGit := mockCreator("tstark")
+	message, authorErr := WithdrawRequest(context.Background(), authorGit,
+		&models.Withdraw{RFCIdentifier: identifier, Reason: "superseded"})
+
+	// assert
+	if !errors.Is(otherErr, models.ErrNotRFCAuthor) {
+		t.Errorf("expected a not author error, got %v", otherErr)
+	}
+	otherGit.AssertNotCalled(t, "ClosePullRequest", "pr")
+	commonAsserter(t, getStringPointer("Successfully withdrew RFC test-identifier"), message, nil, authorErr)
+	authorGit.AssertCalled(t, "ClosePullRequest", "pr")
+	authorGit.AssertCalled(t, "DeleteBranch", identifier)
+	if len(updated.Actions) != 1 || updated.Actions[0].ActionType != models.WithdrawnAction ||
+		updated.Actions[0].Data[string(models.ReasonData)] != "superseded" {
+		t.Errorf("expected a withdrawn action, got %v", updated.Actions)
+	}
+}
+
+// TestWithdrawSubmittedRequest tests that the submitter recorded in an RFC is its author rather than the author of its
+// pull request, which is the account of the token every user shares under SSO
+func TestWithdrawSubmittedRequest(t *testing.T) {
+	// arrange
+	identifier, _ := setup()
+	signing.Records = signing.NewRecordSealer("record-secret")
+	defer func() { signing.Records = nil }()
+	rfc := &models.RFC{Signature: "rfc-sha", Submitter: "tstark"}
+	if err := rfc.SealSubmitter(identifier, signing.Records.Seal); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	sealed, err := json.Marshal(rfc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	rfc.Submitter = "bbanner"
+	forged, err := json.Marshal(rfc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	mockCreator := func(content []byte) *mockGit {
+		return &mockGit{
+			getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+				return "pr", nil
+			},
+			getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+				return &exGit.PullRequestDetails{RFCIdentifier: identifier, Author: "harmonia"}, nil
+			},
+			getUserLogin: func(ctx context.Context) (*string, error) {
+				return getStringPointer("harmonia"), nil
+			},
+			getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+				return getStringPointer(string(content)), getStringPointer("junk-sha"), nil
+			},
+			updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+				return nil
+			},
+			closePullRequest: func(ctx context.Context, pr exGit.PullRequest) error {
+				return nil
+			},
+			deleteBranch: func(ctx context.Context, branch string) error {
+				return nil
+			},
+		}
+	}
+	withdraw := &models.Withdraw{RFCIdentifier: identifier}
+
+	// act
+	otherGit := mockCreator(sealed)
+	_, otherErr := WithdrawRequest(oidc.NewContext(context.Background(), &oidc.Identity{Login: "bbanner"}), otherGit,
+		withdraw)
+	forgedGit := mockCreator(forged)
+	_, forgedErr := WithdrawRequest(oidc.NewContext(context.Background(), &oidc.Identity{Login: "bbanner"}), forgedGit,
+		withdraw)
+	tokenGit := mockCreator(sealed)
+	_, tokenErr := WithdrawRequest(context.Background(), tokenGit, withdraw)
+	submitterGit := mockCreator(sealed)
+	message, submitterErr := WithdrawRequest(oidc.NewContext(context.Background(), &oidc.Identity{Login: "tstark"}),
+		submitterGit, withdraw)
+
+	// assert
+	if !errors.Is(otherErr, models.ErrNotRFCAuthor) {
+		t.Errorf("expected a not author error, got %v", otherErr)
+	}
+	otherGit.AssertNotCalled(t, "ClosePullRequest", "pr")
+	if !errors.Is(forgedErr, models.ErrNotRFCAuthor) {
+		t.Errorf("expected a submitter without its seal not to be the author of the RFC, got %v", forgedErr)
+	}
+	forgedGit.AssertNotCalled(t, "ClosePullRequest", "pr")
+	if !errors.Is(tokenErr, models.ErrNotRFCAuthor) {
+		t.Errorf("expected the author of the pull request not to be the author of the RFC, got %v", tokenErr)
+	}
+	tokenGit.AssertNotCalled(t, "ClosePullRequest", "pr")
+	commonAsserter(t, getStringPointer("Successfully withdrew RFC test-identifier"), message, nil, submitterErr)
+	submitterGit.AssertCalled(t, "ClosePullRequest", "pr")
+}
+
+// TestBreakGlass tests that only break-glass admins can force an RFC live, which is recorded before it is merged
+func TestBreakGlass(t *testing.T) {
+	// arrange
+	identifier := "break-glass-identifier"
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	defer func() { loader.Default = defaultLoaders }()
+	models.RegisterBreakGlassAdmin("nfury")
+	embargo := time.Now().Add(24 * time.Hour)
+	content, err := json.Marshal(&models.RFC{Signature: "rfc-sha", EmbargoUntil: &embargo})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var mu sync.Mutex
+	var recorded *models.RFC
+	merged := make(chan string, 1)
+	userGit := func(login string) *mockGit {
+		return &mockGit{getUserLogin: func(ctx context.Context) (*string, error) { return &login, nil }}
+	}
+	machineGit := &mockGit{
+		getUserLogin: func(ctx context.Context) (*string, error) {
+			return getStringPointer("harmonia"), nil
+		},
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return "pr", nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return getStringPointer(string(content)), getStringPointer("junk-sha"), nil
+		},
+		updateFile: func(ctx context.Context, pr exGit.PullRequest, data *models.RFC) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if recorded == nil {
+				copied := *data
+				copied.Actions = append(models.Actions{}, data.Actions...)
+				recorded = &copied
+			}
+			return nil
+		},
+		mergePullRequest: func(ctx context.Context, pr exGit.PullRequest) (*string, error) {
+			return getStringPointer("merge-sha"), nil
+		},
+		createTag: func(ctx context.Context, sha string, name string) error {
+			merged <- name
+			return nil
+		},
+	}
+
+	// act
+	_, otherErr := BreakGlass(context.Background(), userGit("tstark"), machineGit,
+		&models.BreakGlass{RFCIdentifier: identifier, Justification: "outage"})
+	_, blankErr := BreakGlass(context.Background(), userGit("nfury"), machineGit,
+		&models.BreakGlass{RFCIdentifier: identifier, Justification: "  "})
+	message, err := BreakGlass(context.Background(), userGit("nfury"), machineGit,
+		&models.BreakGlass{RFCIdentifier: identifier, Justification: "INC-42: catalog outage"})
+
+	// assert
+	if !errors.Is(otherErr, models.ErrNotBreakGlassAdmin) {
+		t.Errorf("expected a not break-glass admin error, got %v", otherErr)
+	}
+	if !errors.Is(blankErr, models.ErrMissingJustification) {
+		t.Errorf("expected a missing justification error, got %v", blankErr)
+	}
+	expected := fmt.Sprintf("Broke glass on RFC %s, you may query the load status through the /status endpoint",
+		identifier)
+	commonAsserter(t, &expected, message, nil, err)
+	select {
+	case tag := <-merged:
+		if tag != identifier {
+			t.Errorf("expected the RFC to be tagged %s, got %s", identifier, tag)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("embargoed RFC was not merged after breaking glass")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(recorded.Actions) != 1 || recorded.Actions[0].ActionType != models.BreakGlassAction ||
+		recorded.Actions[0].Data[string(models.ForcedByData)] != "nfury" ||
+		recorded.Actions[0].Data[string(models.JustificationData)] != "INC-42: catalog outage" {
+		t.Errorf("expected a break-glass action, got %v", recorded.Actions)
+	}
+}
+
+// TestGetRfcHistory tests that each revision is diffed against the one before it, newest first
+func TestGetRfcHistory(t *testing.T) {
+	// arrange
+	identifier, _ := setup()
+	contents := map[string]string{
+		"first":  `{"actions":[]}`,
+		"second": `{"actions":[],"loadTargets":["primary"]}`,
+	}
+	mg := &mockGit{
+		getRFCHistory: func(ctx context.Context, branch string) ([]exGit.RFCRevision, error) {
+			return []exGit.RFCRevision{{Sha: "second", Author: "bbanner"}, {Sha: "first", Author: "tstark"}}, nil
+		},
+		getRFCContentsAt: func(ctx context.Context, branch string, ref string) (*string, error) {
+			content := contents[ref]
+			return &content, nil
+		},
+	}
+
+	// act
+	history, err := GetRfcHistory(context.Background(), mg, &models.GetRfcHistory{RFCIdentifier: identifier})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(history.Revisions) != 2 || history.Revisions[0].Sha != "second" || history.Revisions[1].Author != "tstark" {
+		t.Fatalf("unexpected revisions: %+v", history.Revisions)
+	}
+	if !strings.Contains(history.Revisions[0].Diff, `+  "loadTargets": [`) ||
+		!strings.Contains(history.Revisions[0].Diff, `-  "actions": []`) {
+		t.Errorf("unexpected diff of the second revision: %s", history.Revisions[0].Diff)
+	}
+	if !strings.Contains(history.Revisions[1].Diff, `+  "actions": []`) {
+		t.Errorf("expected the first revision to add the file: %s", history.Revisions[1].Diff)
+	}
+}
+
+// TestGetReviews tests that reviews are returned oldest first with the type they were submitted as
+func TestGetReviews(t *testing.T) {
+	// arrange
+	identifier, _ := setup()
+	now := time.Now()
+	mg := &mockGit{
+		getPullRequest: func(ctx context.Context, branch string) (exGit.PullRequest, error) {
+			return "pr", nil
+		},
+		getReviews: func(ctx context.Context, pr exGit.PullRequest) (exGit.PullRequestReviews, error) {
+			return nil, nil
+		},
+		getReviewDetails: func(reviews exGit.PullRequestReviews) ([]exGit.ReviewDetails, error) {
+			return []exGit.ReviewDetails{
+				{Reviewer: "bbanner", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: now},
+				{Reviewer: "tstark", State: exGit.DISMISSED_STATE, SubmittedAt: now.Add(-time.Hour)},
+			}, nil
+		},
+	}
+
+	// act
+	reviews, err := GetReviews(context.Background(), mg, &models.GetReviews{RFCIdentifier: identifier})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := []models.RFCReview{
+		{Reviewer: "tstark", State: exGit.DISMISSED_STATE, SubmittedAt: now.Add(-time.Hour), Dismissed: true},
+		{Reviewer: "bbanner", Type: "REQUEST_CHANGES", State: exGit.CHANGES_REQUESTED_STATE, SubmittedAt: now},
+	}
+	if !reflect.DeepEqual(reviews.Reviews, expected) {
+		t.Errorf("unexpected reviews: %+v", reviews.Reviews)
+	}
+}
+
+// resolvingLoader is a loader whose datastore holds the given items keyed by lookup value
+type resolvingLoader map[string]map[string]interface{}
+
+func (l resolvingLoader) Load(ctx context.Context, content []byte) error {
+	return nil
+}
+
+func (l resolvingLoader) Resolve(ctx context.Context, target models.Target) (map[string]interface{}, error) {
+	return l[target.LookupValue], nil
+}
+
+// TestDiffRequest tests that each proposal action is diffed against the item it targets in every load target
+func TestDiffRequest(t *testing.T) {
+	// arrange
+	identifier, _ := setup()
+	content := `{"loadTargets": ["primary", "legacy"], "actions": [
+		{"actionType": "update", "target": {"targetType": "item", "targetDescriptor": "acceptedValueChecker",
+			"lookupKey": "name", "lookupValue": "OurField"}, "data": {"acceptedValues": "one;two"}},
+		{"actionType": "deprecate", "target": {"targetType": "item", "targetDescriptor": "Event"}},
+		{"actionType": "comment", "target": {"targetType": "rfc"}, "data": {"comment": "lgtm"}}]}`
+	mg := &mockGit{
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			return &content, nil, nil
+		},
+	}
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", resolvingLoader{
+		"OurField": {"name": "OurField", "acceptedValues": "one"},
+	})
+	loader.Default.Register("legacy", loader.LoaderFunc(func(ctx context.Context, content []byte) error {
+		return nil
+	}))
+	defer func() { loader.Default = defaultLoaders }()
+
+	// act
+	diff, err := DiffRequest(context.Background(), mg, &models.Diff{RFCIdentifier: identifier})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(diff.Actions) != 4 {
+		t.Fatalf("expected a diff per proposal action and load target, got %+v", diff.Actions)
+	}
+	update := diff.Actions[0]
+	if update.LoadTarget != "primary" || update.After["acceptedValues"] != "one;two" ||
+		update.After["name"] != "OurField" || !strings.Contains(update.Diff, `-  "acceptedValues": "one",`) ||
+		!strings.Contains(update.Diff, `+  "acceptedValues": "one;two",`) {
+		t.Errorf("unexpected diff of the update: %+v", update)
+	}
+	if diff.Actions[1].LoadTarget != "legacy" || diff.Actions[1].Diff != "" || diff.Actions[1].Note == "" {
+		t.Errorf("expected a note for the load target that cannot resolve items, got %+v", diff.Actions[1])
+	}
+	if diff.Actions[2].ActionType != "deprecate" || diff.Actions[2].Diff != "" || diff.Actions[2].Note == "" {
+		t.Errorf("expected a note for the action of unknown effect, got %+v", diff.Actions[2])
+	}
+}
+
+// TestBackupAndRestore tests that the open RFCs of a tracking repository are backed up with their pull requests, and
+// restored in another tracking repository, RFCs whose branch exists being skipped and unreadable RFCs reported
+func TestBackupAndRestore(t *testing.T) {
+	// arrange
+	created := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	open := exGit.PullRequests{
+		&exGit.PullRequestDetails{RFCIdentifier: "new", Number: 12, Title: "RFC: new", Author: "tstark",
+			CreatedAt: created, Labels: []string{"catalog"}, RequestedReviewers: []string{"pparker"}},
+		&exGit.PullRequestDetails{RFCIdentifier: "existing", Number: 7, Author: "tstark", CreatedAt: created},
+		&exGit.PullRequestDetails{RFCIdentifier: "missing", Number: 9},
+	}
+	content := signed(`{"actions": []}`)
+	index := `{"rfcs": []}`
+	var opened []string
+	mg := &mockGit{
+		getPullRequests: func(ctx context.Context, state string, count int, opts ...exGit.FilterOption) (
+			exGit.PullRequests, error) {
+			return open, nil
+		},
+		getPullRequestDetails: func(pr exGit.PullRequest) (*exGit.PullRequestDetails, error) {
+			return pr.(*exGit.PullRequestDetails), nil
+		},
+		getRFCContents: func(ctx context.Context, branch string) (*string, *string, error) {
+			if branch == "missing" {
+				return nil, nil, exGit.ErrRFCFileNotFound
+			}
+			return &content, nil, nil
+		},
+		getArchiveIndex: func(ctx context.Context) (*string, error) { return &index, nil },
+		createBranch: func(ctx context.Context, branch string, baseBranch string) error {
+			if branch == "existing" {
+				return exGit.ErrConflict
+			}
+			return nil
+		},
+		createFile: func(ctx context.Context, branch string, directory string, data *models.RFC) error { return nil },
+		createPullRequest: func(ctx context.Context, branch string, baseBranch string) error {
+			opened = append(opened, branch)
+			return nil
+		},
+	}
+
+	// act
+	backup, err := Backup(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	backup.RFCs = append(backup.RFCs, models.BackupRFC{RFCIdentifier: "corrupt", Content: "{"})
+	restoration, restoreErr := Restore(context.Background(), mg, &models.RestoreBackup{Backup: backup})
+
+	// assert
+	if len(backup.RFCs) != 3 || backup.RFCs[0].RFCIdentifier != "existing" || backup.RFCs[1].RFCIdentifier != "new" ||
+		backup.RFCs[1].Content != content || backup.RFCs[1].PullRequest.Title != "RFC: new" ||
+		backup.ArchiveIndex != index || backup.Version != models.BACKUP_VERSION {
+		t.Errorf("unexpected backup: %+v", backup)
+	}
+	if restoreErr != nil {
+		t.Fatalf("unexpected error: %v", restoreErr)
+	}
+	if fmt.Sprint(restoration.Restored) != "[new]" || fmt.Sprint(restoration.Skipped) != "[existing]" ||
+		len(restoration.Failed) != 1 || restoration.Failed[0].RFCIdentifier != "corrupt" {
+		t.Errorf("unexpected restoration: %+v", restoration)
+	}
+	if fmt.Sprint(opened) != "[new]" {
+		t.Errorf("expected a single pull request to be opened, got %v", opened)
+	}
+
+	// act & assert nothing is written on a dry run
+	opened = nil
+	backup.RFCs = backup.RFCs[:2]
+	restoration, err = Restore(context.Background(), mg, &models.RestoreBackup{Backup: backup, DryRun: true})
+	if err != nil || len(restoration.Restored) != 2 || !restoration.DryRun || len(opened) != 0 {
+		t.Errorf("expected a dry run to restore nothing, got %+v and %v", restoration, err)
+	}
+
+	// act & assert backups of another version are refused
+	backup.Version = models.BACKUP_VERSION + 1
+	if _, err = Restore(context.Background(), mg, &models.RestoreBackup{Backup: backup}); !errors.Is(err,
+		models.ErrUnsupportedBackup) {
+		t.Errorf("expected an unsupported backup error, got %v", err)
+	}
+}
+
+// TestGenerateTypes tests that the types generated from the action data schemas are committed to the codegen
+// repository in its configured directory, unless this is a dry run, and that unrenderable schemas are reported
+func TestGenerateTypes(t *testing.T) {
+	// initialize
+	schemas.Directory = "schemas"
+	codegen.Directory = "types"
+	defer func() {
+		schemas.Directory = ""
+		codegen.Directory = ""
+	}()
+	var committed map[string]string
+	mg := &mockGit{
+		getSchemas: func(ctx context.Context, directory string) (map[string]string, error) {
+			return map[string]string{
+				"add.item.json": `{"type": "object", "properties": {"id": {"type": "string"}}}`,
+				"broken.json":   `{`,
+			}, nil
+		},
+		commitFiles: func(ctx context.Context, files map[string]string, message string) (bool, error) {
+			committed = files
+			return true, nil
+		},
+	}
+
+	// act
+	preview, previewErr := GenerateTypes(context.Background(), mg, mg, &models.GenerateTypes{DryRun: true})
+	generated, err := GenerateTypes(context.Background(), mg, mg, &models.GenerateTypes{})
+
+	// assert
+	if previewErr != nil || err != nil {
+		t.Fatalf("unexpected errors: %v, %v", previewErr, err)
+	}
+	if fmt.Sprint(generated.Files) != "[types/schemas.go types/schemas.ts]" || !generated.Committed ||
+		len(generated.Contents) != 0 {
+		t.Errorf("unexpected generated types: %+v", generated)
+	}
+	if _, ok := generated.Failures["broken.json"]; !ok || len(generated.Failures) != 1 {
+		t.Errorf("expected the broken schema to be reported, got %v", generated.Failures)
+	}
+	if len(committed) != 2 || !strings.Contains(committed["types/schemas.go"], "type AddItem struct") {
+		t.Errorf("unexpected committed files: %v", committed)
+	}
+	if preview.Committed || preview.Contents["types/schemas.ts"] != committed["types/schemas.ts"] {
+		t.Errorf("expected the dry run to return the files without committing them, got %+v", preview)
+	}
+
+	// act & assert types cannot be generated without schemas
+	schemas.Directory = ""
+	if _, err = GenerateTypes(context.Background(), mg, mg, &models.GenerateTypes{}); err == nil {
+		t.Errorf("expected an error without a schema directory")
+	}
+}
+
+// TestAuthorSignature tests that RFCs signed by their author are only submitted by their author with a key registered
+// to them, that the key is recorded, that unsigned RFCs are rejected once author signatures are required and that RFCs
+// signed with a key revoked since are not merged
+func TestAuthorSignature(t *testing.T) {
+	// initialize, a receipt signer stands in for the private key of the author
+	identifier, _ := setup()
+	key, _ := signing.NewReceiptSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{5}, 32)))
+	publicKey, _ := base64.StdEncoding.DecodeString(key.PublicKey())
+	keyID := signing.KeyID(publicKey)
+	signing.Authors, _ = signing.NewAuthorKeys(map[string][]string{"tstark": {key.PublicKey()}}, nil)
+	defer func() {
+		signing.Authors = nil
+		signing.AuthorSignaturesRequired = false
+	}()
+	login := "tstark"
+	errStop := errors.New("stop before creating the branch")
+	mg := &mockGit{
+		getUserLogin: func(ctx context.Context) (*string, error) { return &login, nil },
+		createBranch: func(ctx context.Context, branch string, baseBranch string) error { return errStop },
+	}
+	newRFC := func(title string, author string) *models.RFC {
+		rfc := &models.RFC{Title: title, Actions: models.Actions{{ActionType: models.AddAction,
+			Target: models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"}}}}
+		canonical, _ := rfc.Canonical()
+		rfc.AuthorSignature = &models.AuthorSignature{Author: author, Signature: key.Sign(canonical)}
+		return rfc
+	}
+
+	// act
+	rfc := newRFC("Add Event", "tstark")
+	_, err := SubmitRequest(context.Background(), mg, rfc, true)
+
+	// assert
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the signed RFC to be submitted, got %v", err)
+	}
+	attestation := rfc.Actions[len(rfc.Actions)-1]
+	if attestation.ActionType != models.AttestationAction || attestation.Target.LookupValue != rfc.Signature ||
+		attestation.Data[string(models.SignerData)] != "tstark" || attestation.Data[string(models.KeyIDData)] != keyID {
+		t.Errorf("expected the key the RFC was signed with to be recorded, got %+v", attestation)
+	}
+
+	// act & assert RFCs signed by someone else or changed since they were signed are rejected
+	login = "hulk"
+	if _, err = SubmitRequest(context.Background(), mg, newRFC("Add Event", "tstark"), true); !errors.Is(err,
+		models.ErrInvalidAuthorSignature) {
+		t.Errorf("expected an RFC signed by someone else to be rejected, got %v", err)
+	}
+	login = "tstark"
+	tampered := newRFC("Add Event", "tstark")
+	tampered.Title = "Add Events"
+	if _, err = SubmitRequest(context.Background(), mg, tampered, true); !errors.Is(err,
+		models.ErrInvalidAuthorSignature) {
+		t.Errorf("expected an RFC changed since it was signed to be rejected, got %v", err)
+	}
+
+	// act & assert unsigned RFCs are only rejected once author signatures are required
+	unsigned := &models.RFC{Actions: newRFC("", "").Actions}
+	if _, err = SubmitRequest(context.Background(), mg, unsigned, true); !errors.Is(err, errStop) {
+		t.Errorf("expected the unsigned RFC to be submitted, got %v", err)
+	}
+	signing.AuthorSignaturesRequired = true
+	unsigned = &models.RFC{Actions: newRFC("", "").Actions}
+	if _, err = SubmitRequest(context.Background(), mg, unsigned, true); !errors.Is(err,
+		models.ErrAuthorSignatureRequired) {
+		t.Errorf("expected the unsigned RFC to be rejected, got %v", err)
+	}
+
+	// act & assert RFCs signed with a revoked key are not merged
+	content, _ := json.Marshal(newRFC("Add Event", "tstark"))
+	signedContent := signed(string(content))
+	mg.getPullRequest = func(ctx context.Context, branch string) (exGit.PullRequest, error) { return nil, nil }
+	mg.getRFCContents = func(ctx context.Context, branch string) (*string, *string, error) {
+		return &signedContent, getStringPointer("junk-sha"), nil
+	}
+	signing.Authors, _ = signing.NewAuthorKeys(map[string][]string{"tstark": {key.PublicKey()}}, []string{keyID})
+	if _, err = MergeRequest(context.Background(), mg, &models.Merge{RFCIdentifier: identifier}); !errors.Is(err,
+		models.ErrInvalidAuthorSignature) {
+		t.Errorf("expected the RFC signed with a revoked key not to be merged, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+//go:build integration
+
+// This is to hold the end-to-end tests of the controllers against the real GitHub implementation, which create a
+// disposable tracking repository in a GitHub organization, take an RFC through its whole lifecycle and delete the
+// repository. They exercise the GitHub calls the mocks of controller_test.go stand in for, and only run with the
+// integration build tag:
+//
+//	HARMONIA_IT_ORG=... HARMONIA_IT_TOKEN=... HARMONIA_IT_REVIEWER_TOKEN=... go test -tags integration ./controllers
+//
+// HARMONIA_IT_TOKEN submits, loads and merges RFCs like the machine token and must be allowed to create and delete
+// repositories in the organization, HARMONIA_IT_REVIEWER_TOKEN approves them and must belong to another user
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v40/github"
+	"golang.org/x/oauth2"
+	"harmonia-example.io/src/models"
+	exGit "harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/loader"
+)
+
+const (
+	// how long the asynchronous load of an RFC is waited on before the test fails
+	INTEGRATION_LOAD_TIMEOUT = 2 * time.Minute
+	// how often the job of an asynchronous load is polled
+	INTEGRATION_POLL_INTERVAL = 2 * time.Second
+)
+
+// integrationEnv returns the value of the given environment variable, skipping the test if it is not set
+func integrationEnv(t *testing.T, name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		t.Skipf("%s is not set, skipping integration test", name)
+	}
+	return value
+}
+
+// newGitHubClient returns a go-github client authenticated with the given access token
+func newGitHubClient(ctx context.Context, accessToken string) *github.Client {
+	return github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})))
+}
+
+// newDisposableRepository creates a private tracking repository in the given organization, initialized with a
+// BASE_BRANCH, and gives the reviewer push access to it. The repository is deleted once the test and its subtests
+// complete, whatever their outcome
+func newDisposableRepository(t *testing.T, ctx context.Context, org string, client *github.Client,
+	reviewer *github.Client) exGit.Repository {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatalf("unable to name the disposable repository: %s", err.Error())
+	}
+	name := "harmonia-it-" + hex.EncodeToString(suffix)
+
+	repository, _, err := client.Repositories.Create(ctx, org, &github.Repository{
+		Name:        github.String(name),
+		Description: github.String("Disposable tracking repository of a Harmonia integration test"),
+		Private:     github.Bool(true),
+		AutoInit:    github.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("unable to create the disposable repository: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		// the test context may be done by now, the repository must be deleted regardless
+		if _, err := client.Repositories.Delete(context.Background(), org, name); err != nil {
+			t.Errorf("unable to delete the disposable repository %s/%s, delete it by hand: %s", org, name, err.Error())
+		}
+	})
+
+	// the default branch of new repositories is configurable, Harmonia expects BASE_BRANCH
+	if repository.GetDefaultBranch() != exGit.BASE_BRANCH {
+		if _, _, err = client.Repositories.RenameBranch(ctx, org, name, repository.GetDefaultBranch(),
+			exGit.BASE_BRANCH); err != nil {
+			t.Fatalf("unable to rename the default branch to %s: %s", exGit.BASE_BRANCH, err.Error())
+		}
+	}
+
+	// reviewers outside the organization are invited rather than added, and must accept the invitation
+	login, _, err := reviewer.Users.Get(ctx, "")
+	if err != nil {
+		t.Fatalf("unable to look up the reviewer: %s", err.Error())
+	}
+	invitation, _, err := client.Repositories.AddCollaborator(ctx, org, name, login.GetLogin(),
+		&github.RepositoryAddCollaboratorOptions{Permission: "push"})
+	if err != nil {
+		t.Fatalf("unable to give the reviewer access to the disposable repository: %s", err.Error())
+	}
+	if invitation != nil && invitation.ID != nil {
+		if _, err = reviewer.Users.AcceptInvitation(ctx, invitation.GetID()); err != nil {
+			t.Fatalf("unable to accept the invitation of the reviewer: %s", err.Error())
+		}
+	}
+
+	return exGit.Repository{Owner: org, Name: name}
+}
+
+// waitForJob polls the given job until it completes, failing the test if it fails or does not complete in time
+func waitForJob(t *testing.T, id string) *models.Job {
+	deadline := time.Now().Add(INTEGRATION_LOAD_TIMEOUT)
+	for time.Now().Before(deadline) {
+		job, err := jobs.Default.Get(id)
+		if err != nil {
+			t.Fatalf("unable to get job %s: %s", id, err.Error())
+		}
+		switch job.State {
+		case models.SucceededJob:
+			return job
+		case models.FailedJob:
+			t.Fatalf("job %s failed after %d attempts: %s", id, job.Attempts, job.Error)
+		}
+		time.Sleep(INTEGRATION_POLL_INTERVAL)
+	}
+	t.Fatalf("job %s did not complete within %s", id, INTEGRATION_LOAD_TIMEOUT)
+	return nil
+}
+
+// TestIntegrationGitHub takes an RFC through submission, review, load and merge in a disposable GitHub repository
+func TestIntegrationGitHub(t *testing.T) {
+	// initialize the disposable tracking repository and the clients of both users
+	org := integrationEnv(t, "HARMONIA_IT_ORG")
+	token := integrationEnv(t, "HARMONIA_IT_TOKEN")
+	reviewerToken := integrationEnv(t, "HARMONIA_IT_REVIEWER_TOKEN")
+	ctx := context.Background()
+	repository := newDisposableRepository(t, ctx, org, newGitHubClient(ctx, token),
+		newGitHubClient(ctx, reviewerToken))
+
+	git, err := exGit.NewGitHub(ctx, token, repository)
+	if err != nil {
+		t.Fatalf("unable to create the Git client: %s", err.Error())
+	}
+	reviewerGit, err := exGit.NewGitHub(ctx, reviewerToken, repository)
+	if err != nil {
+		t.Fatalf("unable to create the Git client of the reviewer: %s", err.Error())
+	}
+
+	defaultLoaders := loader.Default
+	loader.Default = loader.NewRegistry()
+	loader.Default.Register("primary", loader.Placeholder("primary"))
+	defer func() { loader.Default = defaultLoaders }()
+
+	// act: submit
+	rfc := &models.RFC{Actions: models.Actions{{
+		ActionType: models.AddAction,
+		Target:     models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"},
+		Data:       map[string]interface{}{"name": "IntegrationTested"},
+	}}}
+	rfcIdentifier, err := SubmitRequest(ctx, git, rfc, false)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unable to submit the RFC: %s", err.Error())
+	}
+	rfcs, err := GetRfcs(ctx, git, &models.GetRfcs{Count: -1, State: "open"})
+	if err != nil {
+		t.Fatalf("unable to list RFCs: %s", err.Error())
+	}
+	if len(rfcs.RFCs) != 1 || rfcs.RFCs[0][*rfcIdentifier] == "" {
+		t.Fatalf("expected the submitted RFC to be the only open RFC, got %+v", rfcs.RFCs)
+	}
+
+	// act: review
+	_, err = ReviewRequest(ctx, reviewerGit, git, &models.Review{
+		RFCIdentifier:   *rfcIdentifier,
+		Type:            string(models.ApproveReview),
+		TopLevelComment: "Approved by the integration test",
+	})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unable to review the RFC: %s", err.Error())
+	}
+	reviews, err := GetReviews(ctx, git, &models.GetReviews{RFCIdentifier: *rfcIdentifier})
+	if err != nil {
+		t.Fatalf("unable to get the reviews of the RFC: %s", err.Error())
+	}
+	if len(reviews.Reviews) != 1 || reviews.Reviews[0].State != "APPROVED" {
+		t.Fatalf("expected a single approval, got %+v", reviews.Reviews)
+	}
+
+	// act: load
+	err = LoadRequest(ctx, git, &models.Load{RFCIdentifier: *rfcIdentifier})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unable to request the load of the RFC: %s", err.Error())
+	}
+	status, err := Status(ctx, git, &models.Status{RFCIdentifier: *rfcIdentifier})
+	if err != nil || status.Job == nil {
+		t.Fatalf("expected the load to run as a job, got %+v, %v", status, err)
+	}
+	waitForJob(t, status.Job.ID)
+	status, err = Status(ctx, git, &models.Status{RFCIdentifier: *rfcIdentifier})
+	if err != nil || status.Status != SUCCESSFUL_STATUS {
+		t.Fatalf("expected a successful load, got %+v, %v", status, err)
+	}
+
+	// act: merge
+	_, err = MergeRequest(ctx, git, &models.Merge{RFCIdentifier: *rfcIdentifier})
+
+	// assert
+	if err != nil {
+		t.Fatalf("unable to merge the RFC: %s", err.Error())
+	}
+	client := newGitHubClient(ctx, token)
+	if _, response, err := client.Git.GetRef(ctx, repository.Owner, repository.Name,
+		"tags/"+*rfcIdentifier); err != nil || response.StatusCode != http.StatusOK {
+		t.Errorf("expected the merged RFC to be tagged %s: %v", *rfcIdentifier, err)
+	}
+	merged := true
+	rfcs, err = GetRfcs(ctx, git, &models.GetRfcs{Count: -1, State: "closed", Merged: &merged})
+	if err != nil || len(rfcs.RFCs) != 1 || rfcs.RFCs[0][*rfcIdentifier] == "" {
+		t.Errorf("expected the RFC to be listed as merged, got %+v, %v", rfcs, err)
+	}
+}
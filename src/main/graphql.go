@@ -0,0 +1,685 @@
+// this holds the GraphQL schema over RFCs, their actions, comments, reviews and load status, served at /graphql so
+// clients like the web UI fetch exactly the fields they need in one round trip instead of chaining routes
+// Every field is resolved by the controller of the route it mirrors, with the read token, and only when it is selected
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/controllers"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/metadata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DEFAULT_GRAPHQL_COUNT is the number of RFCs listed when the rfcs query is given no count
+const DEFAULT_GRAPHQL_COUNT = 100
+
+// summaryFields maps each field of the RFCSummary type to the RFC field computing it, see models.RFCField
+var summaryFields = map[string]models.RFCField{
+	"state":            models.StateField,
+	"approvals":        models.ReviewsField,
+	"changesRequested": models.ReviewsField,
+	"comments":         models.ReviewsField,
+	"mergeable":        models.MergeabilityField,
+	"mergeability":     models.MergeabilityField,
+	"loadStatus":       models.LoadStatusField,
+}
+
+// graphqlClientsKey is the key the Git clients of a GraphQL request are stored under in its context
+type graphqlClientsKey struct{}
+
+// graphqlClients builds, once per schema domain, the read-only Git clients a GraphQL request resolves its fields with
+type graphqlClients struct {
+	mu      sync.Mutex
+	clients map[string]git.Git
+}
+
+// forDomain returns the read-only Git client of the tracking repository of the given schema domain
+func (g *graphqlClients) forDomain(ctx context.Context, domain string) (git.Git, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if client, ok := g.clients[domain]; ok {
+		return client, nil
+	}
+	// each tracking repository is read with the read token of its schema domain, which differ between tenants
+	token, err := domainReadToken(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	client, err := git.NewForDomain(ctx, settings.GitProvider(), *token, domain)
+	if err != nil {
+		return nil, err
+	}
+	g.clients[domain] = client
+	return client, nil
+}
+
+// graphqlRFC is the source of the fields of an RFC, its reference and RFC file are read once by the first field
+// that needs them
+type graphqlRFC struct {
+	identifier string
+	title      string
+	domain     string
+	client     git.Git
+	// listed is set for RFCs listed by the rfcs query, whose links and summary were computed along with the list
+	listed  bool
+	links   *models.Links
+	summary *models.RFCSummary
+
+	referenceOnce sync.Once
+	reference     *models.RFCReference
+	referenceErr  error
+
+	fileOnce sync.Once
+	file     *models.RFC
+	fileErr  error
+}
+
+// readReference returns the reference to the RFC, holding its title and links
+func (r *graphqlRFC) readReference(ctx context.Context) (*models.RFCReference, error) {
+	r.referenceOnce.Do(func() {
+		r.reference, r.referenceErr = controllers.GetRFCReference(ctx, r.client, r.identifier)
+	})
+	return r.reference, r.referenceErr
+}
+
+// readFile returns the decoded RFC file of the RFC
+func (r *graphqlRFC) readFile(ctx context.Context) (*models.RFC, error) {
+	r.fileOnce.Do(func() {
+		r.file, r.fileErr = controllers.GetRFC(ctx, r.client, r.identifier)
+	})
+	return r.file, r.fileErr
+}
+
+// graphqlAction is the source of the fields of an action, along with the RFC file holding it
+type graphqlAction struct {
+	action *models.Action
+	rfc    *models.RFC
+}
+
+// newGraphQLActions returns the given actions of the given RFC file as sources of the fields of actions
+func newGraphQLActions(rfc *models.RFC, actions models.Actions) []*graphqlAction {
+	sources := make([]*graphqlAction, 0, len(actions))
+	for _, action := range actions {
+		sources = append(sources, &graphqlAction{action: action, rfc: rfc})
+	}
+	return sources
+}
+
+// graphqlError is an error of a GraphQL field, whose sanitized message and code are those of the REST response to it
+type graphqlError struct {
+	message string
+	code    models.Code
+}
+
+// Error returns the sanitized message of the error
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+// Extensions returns the code of the error, reported along with its message
+func (e *graphqlError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// fieldError logs the given error of a GraphQL field and returns it sanitized along with the given message, see
+// errorResponse
+func fieldError(ctx context.Context, err error, message string) error {
+	status, body := errorResponse(err, message)
+	logging.FromContext(ctx).Error("field resolution failed", "status", status, logging.ERROR_KEY, err)
+
+	switch body := body.(type) {
+	case *models.Integrity:
+		return &graphqlError{message: body.Error, code: body.Code}
+	case *models.Duplicate:
+		return &graphqlError{message: body.Error, code: body.Code}
+	case *models.Error:
+		return &graphqlError{message: body.Error, code: body.Code}
+	}
+	return &graphqlError{message: message, code: models.InternalErrorCode}
+}
+
+// selections returns the fields selected in the given selection set by name, including those selected through
+// fragments
+func selections(selectionSet *ast.SelectionSet, fragments map[string]ast.Definition) map[string]*ast.Field {
+	fields := map[string]*ast.Field{}
+	if selectionSet == nil {
+		return fields
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			fields[selection.Name.Value] = selection
+		case *ast.InlineFragment:
+			for name, field := range selections(selection.SelectionSet, fragments) {
+				fields[name] = field
+			}
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[selection.Name.Value].(*ast.FragmentDefinition); ok {
+				for name, field := range selections(fragment.SelectionSet, fragments) {
+					fields[name] = field
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// selectedRFCFields returns the RFC fields computing the links and summary fields selected by the given rfcs query,
+// only links are computed if none are selected so no summary is computed needlessly
+func selectedRFCFields(p graphql.ResolveParams) []models.RFCField {
+	selected := map[models.RFCField]bool{}
+	for _, query := range p.Info.FieldASTs {
+		rfc := selections(query.SelectionSet, p.Info.Fragments)
+		if _, ok := rfc["links"]; ok {
+			selected[models.LinksField] = true
+		}
+		if summary, ok := rfc["summary"]; ok {
+			for name := range selections(summary.SelectionSet, p.Info.Fragments) {
+				if field, ok := summaryFields[name]; ok {
+					selected[field] = true
+				}
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return []models.RFCField{models.LinksField}
+	}
+
+	fields := make([]models.RFCField, 0, len(selected))
+	for field := range selected {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i] < fields[j] })
+	return fields
+}
+
+// stringArgument returns the given string argument, nil if it is not given
+func stringArgument(p graphql.ResolveParams, name string) *string {
+	if value, ok := p.Args[name].(string); ok {
+		return &value
+	}
+	return nil
+}
+
+// resolveRFCs resolves the rfcs query with the GetRfcs controller, computing only the selected links and summaries
+func resolveRFCs(p graphql.ResolveParams) (interface{}, error) {
+	clients := p.Context.Value(graphqlClientsKey{}).(*graphqlClients)
+	domain, _ := p.Args["domain"].(string)
+	count, _ := p.Args["count"].(int)
+	state, _ := p.Args["state"].(string)
+	query := &models.GetRfcs{
+		DomainSelector: models.DomainSelector{Domain: domain},
+		Count:          count,
+		State:          state,
+		Owner:          stringArgument(p, "owner"),
+		Label:          stringArgument(p, "label"),
+		HeadPrefix:     stringArgument(p, "headPrefix"),
+		Fields:         selectedRFCFields(p),
+	}
+	if merged, ok := p.Args["merged"].(bool); ok {
+		query.Merged = &merged
+	}
+	if createdAfter, ok := p.Args["createdAfter"].(time.Time); ok {
+		query.CreatedAfter = &createdAfter
+	}
+
+	client, err := clients.forDomain(p.Context, domain)
+	if err != nil {
+		return nil, fieldError(p.Context, err, "Service error occurred - Git reader")
+	}
+	rfcs, err := controllers.GetRfcs(p.Context, client, query)
+	if err != nil {
+		return nil, fieldError(p.Context, err, "Error occurred when retrieving RFCs")
+	}
+
+	sources := []*graphqlRFC{}
+	for _, entry := range rfcs.RFCs {
+		identifiers := make([]string, 0, len(entry))
+		for identifier := range entry {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+		for _, identifier := range identifiers {
+			sources = append(sources, &graphqlRFC{
+				identifier: identifier,
+				title:      entry[identifier],
+				domain:     domain,
+				client:     client,
+				listed:     true,
+				links:      rfcs.Links[identifier],
+				summary:    rfcs.Summaries[identifier],
+			})
+		}
+	}
+	return sources, nil
+}
+
+// resolveRFC resolves the rfc query, the fields of the RFC are resolved as they are selected
+func resolveRFC(p graphql.ResolveParams) (interface{}, error) {
+	clients := p.Context.Value(graphqlClientsKey{}).(*graphqlClients)
+	domain, _ := p.Args["domain"].(string)
+	identifier, _ := p.Args["id"].(string)
+	metadata.SetRFCIdentifier(p.Context, identifier)
+
+	client, err := clients.forDomain(p.Context, domain)
+	if err != nil {
+		return nil, fieldError(p.Context, err, "Service error occurred - Git reader")
+	}
+	return &graphqlRFC{identifier: identifier, domain: domain, client: client}, nil
+}
+
+// resolveJob resolves the job query
+func resolveJob(p graphql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(string)
+	job, err := jobs.Default.Get(id)
+	if err != nil {
+		return nil, fieldError(p.Context, err, "Job retrieval error occurred")
+	}
+	return job, nil
+}
+
+// jsonScalar is the JSON scalar type, holding the free form data of actions and the load status of load targets
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "The `JSON` scalar type represents a free form JSON value.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return valueAST.GetValue()
+	},
+})
+
+// newGraphQLSchema returns the GraphQL schema served at /graphql
+func newGraphQLSchema() (graphql.Schema, error) {
+	linksType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Links",
+		Description: "Git provider URLs of an RFC",
+		Fields: graphql.Fields{
+			"pullRequest": &graphql.Field{Type: graphql.String},
+			"file":        &graphql.Field{Type: graphql.String},
+			"tag":         &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	mergeabilityType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Mergeability",
+		Description: "Whether an RFC can be merged and, if not, why",
+		Fields: graphql.Fields{
+			"mergeable":        &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"reasons":          &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"requiredContexts": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"contexts":         &graphql.Field{Type: jsonScalar},
+		},
+	})
+
+	summaryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "RFCSummary",
+		Description: "Review, mergeability and load status of an RFC at a glance, only the selected fields are computed",
+		Fields: graphql.Fields{
+			"state":            &graphql.Field{Type: graphql.String},
+			"approvals":        &graphql.Field{Type: graphql.Int},
+			"changesRequested": &graphql.Field{Type: graphql.Int},
+			"comments":         &graphql.Field{Type: graphql.Int},
+			"mergeable":        &graphql.Field{Type: graphql.Boolean},
+			"loadStatus":       &graphql.Field{Type: graphql.String},
+			"mergeability":     &graphql.Field{Type: mergeabilityType},
+		},
+	})
+
+	targetType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Target",
+		Description: "Data used to locate a given item within the system",
+		Fields: graphql.Fields{
+			"targetType":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"targetDescriptor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"lookupKey":        &graphql.Field{Type: graphql.String},
+			"lookupValue":      &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	var actionType *graphql.Object
+	actionType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Action",
+		Description: "A single action of an RFC, comments and annotations are actions too",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"actionType": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*graphqlAction).action.ActionType, nil
+					},
+				},
+				"signature": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*graphqlAction).action.Signature, nil
+					},
+				},
+				"target": &graphql.Field{
+					Type: graphql.NewNonNull(targetType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*graphqlAction).action.Target, nil
+					},
+				},
+				"data": &graphql.Field{
+					Type: jsonScalar,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*graphqlAction).action.Data, nil
+					},
+				},
+				"comments": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(actionType))),
+					Description: "Comment thread of the action, oldest first",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(*graphqlAction)
+						return newGraphQLActions(source.rfc, source.rfc.GetCommentThread(source.action.Signature)), nil
+					},
+				},
+				"annotations": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(actionType))),
+					Description: "Annotations analyzers attached to the action",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						source := p.Source.(*graphqlAction)
+						return newGraphQLActions(source.rfc, source.rfc.GetAnnotations(source.action.Signature)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	reviewType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Review",
+		Description: "A single review of an RFC",
+		Fields: graphql.Fields{
+			"reviewer":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type":        &graphql.Field{Type: graphql.String},
+			"state":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"submittedAt": &graphql.Field{Type: graphql.DateTime},
+			"dismissed":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	jobType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Job",
+		Description: "An asynchronous job started by a request, e.g. the load of an RFC",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"kind":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"rfcIdentifier": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"state":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"priority":      &graphql.Field{Type: graphql.String},
+			"attempts":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"maxAttempts":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"error":         &graphql.Field{Type: graphql.String},
+			"nextAttemptAt": &graphql.Field{Type: graphql.DateTime},
+			"createdAt":     &graphql.Field{Type: graphql.DateTime},
+			"updatedAt":     &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	loadGateType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LoadGate",
+		Description: "Approval a load is waiting on, or received",
+		Fields: graphql.Fields{
+			"type":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"state":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"environment":  &graphql.Field{Type: graphql.String},
+			"deploymentId": &graphql.Field{Type: graphql.String},
+			"approver":     &graphql.Field{Type: graphql.String},
+			"mergeOnLoad":  &graphql.Field{Type: graphql.Boolean},
+		},
+	})
+
+	dependencyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Dependency",
+		Description: "An RFC another RFC depends on, along with its state",
+		Fields: graphql.Fields{
+			"rfcIdentifier": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"state":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	loadStatusType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "LoadStatus",
+		Description: "Load status of an RFC",
+		Fields: graphql.Fields{
+			"status":       &graphql.Field{Type: graphql.String},
+			"gate":         &graphql.Field{Type: loadGateType},
+			"targets":      &graphql.Field{Type: jsonScalar},
+			"embargoUntil": &graphql.Field{Type: graphql.DateTime},
+			"embargoed":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"job":          &graphql.Field{Type: jobType},
+			"dependencies": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(dependencyType))},
+		},
+	})
+
+	rfcType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "RFC",
+		Description: "A request for schema changes",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*graphqlRFC).identifier, nil
+				},
+			},
+			"title": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					if source.listed {
+						return source.title, nil
+					}
+					reference, err := source.readReference(p.Context)
+					if err != nil {
+						return nil, fieldError(p.Context, err, fmt.Sprintf(
+							"Error occurred when querying RFC #%v", source.identifier))
+					}
+					return reference.Title, nil
+				},
+			},
+			"links": &graphql.Field{
+				Type: linksType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					if source.listed {
+						return source.links, nil
+					}
+					reference, err := source.readReference(p.Context)
+					if err != nil {
+						return nil, fieldError(p.Context, err, fmt.Sprintf(
+							"Error occurred when querying RFC #%v", source.identifier))
+					}
+					return reference.Links, nil
+				},
+			},
+			"summary": &graphql.Field{
+				Type:        summaryType,
+				Description: "Summary of the RFC, only for RFCs listed by the rfcs query",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*graphqlRFC).summary, nil
+				},
+			},
+			"contents": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Body of the RFC file, optionally as of the given commit sha, branch or tag",
+				Args: graphql.FieldConfigArgument{
+					"ref": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					ref, _ := p.Args["ref"].(string)
+					contents, err := controllers.GetRfcContents(p.Context, source.client, &models.GetRfcContents{
+						DomainSelector: models.DomainSelector{Domain: source.domain},
+						RFCIdentifier:  source.identifier,
+						Ref:            ref,
+					})
+					if err != nil {
+						return nil, fieldError(p.Context, err, fmt.Sprintf(
+							"Error occurred when querying contents for RFC #%v", source.identifier))
+					}
+					return contents, nil
+				},
+			},
+			"actions": &graphql.Field{
+				Type:        graphql.NewList(graphql.NewNonNull(actionType)),
+				Description: "Actions of the RFC, only those of the given action type if one is given",
+				Args: graphql.FieldConfigArgument{
+					"actionType": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					rfc, err := source.readFile(p.Context)
+					if err != nil {
+						return nil, fieldError(p.Context, err, fmt.Sprintf(
+							"Error occurred when querying actions for RFC #%v", source.identifier))
+					}
+					actionType, filtered := p.Args["actionType"].(string)
+					actions := models.Actions{}
+					for _, action := range rfc.Actions {
+						if !filtered || string(action.ActionType) == actionType {
+							actions = append(actions, action)
+						}
+					}
+					return newGraphQLActions(rfc, actions), nil
+				},
+			},
+			"reviews": &graphql.Field{
+				Type:        graphql.NewList(graphql.NewNonNull(reviewType)),
+				Description: "Every review submitted on the RFC, oldest first",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					reviews, err := controllers.GetReviews(p.Context, source.client, &models.GetReviews{
+						DomainSelector: models.DomainSelector{Domain: source.domain},
+						RFCIdentifier:  source.identifier,
+					})
+					if err != nil {
+						return nil, fieldError(p.Context, err, fmt.Sprintf(
+							"Error occurred when querying reviews for RFC #%v", source.identifier))
+					}
+					return reviews.Reviews, nil
+				},
+			},
+			"loadStatus": &graphql.Field{
+				Type: loadStatusType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(*graphqlRFC)
+					status, err := controllers.Status(p.Context, source.client, &models.Status{
+						DomainSelector: models.DomainSelector{Domain: source.domain},
+						RFCIdentifier:  source.identifier,
+					})
+					if err != nil {
+						return nil, fieldError(p.Context, err, "Status error occurred")
+					}
+					return status, nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"rfcs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(rfcType))),
+				Description: "Submitted RFCs, unset filters do not filter",
+				Args: graphql.FieldConfigArgument{
+					"domain":       &graphql.ArgumentConfig{Type: graphql.String},
+					"count":        &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: DEFAULT_GRAPHQL_COUNT},
+					"state":        &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "all"},
+					"owner":        &graphql.ArgumentConfig{Type: graphql.String},
+					"merged":       &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"label":        &graphql.ArgumentConfig{Type: graphql.String},
+					"createdAfter": &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"headPrefix":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolveRFCs,
+			},
+			"rfc": &graphql.Field{
+				Type:        rfcType,
+				Description: "The RFC with the given identifier",
+				Args: graphql.FieldConfigArgument{
+					"domain": &graphql.ArgumentConfig{Type: graphql.String},
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolveRFC,
+			},
+			"job": &graphql.Field{
+				Type:        jobType,
+				Description: "The asynchronous job with the given ID",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolveJob,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// graphqlSchema is the GraphQL schema served at /graphql, an invalid schema is a programming error
+var graphqlSchema = func() graphql.Schema {
+	schema, err := newGraphQLSchema()
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}()
+
+// @description query RFCs, their actions, comments, reviews and load status with GraphQL, fetching exactly the
+// @description selected fields in one round trip. Errors of fields are reported in the errors of the result along
+// @description with their code, the result is still returned with a 200
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.GraphQL true "GraphQL request"
+// @Response 200 {object} object
+// @Response 400 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /graphql [post]
+// graphqlQuery executes the given GraphQL query against the schema over RFCs
+func graphqlQuery(c *gin.Context) {
+	request := new(models.GraphQL)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// operate with the read token for queries, nothing is written
+		if _, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			clients := &graphqlClients{clients: map[string]git.Git{}}
+			result := graphql.Do(graphql.Params{
+				Schema:         graphqlSchema,
+				RequestString:  request.Query,
+				VariableValues: request.Variables,
+				OperationName:  request.OperationName,
+				Context:        context.WithValue(c.Request.Context(), graphqlClientsKey{}, clients),
+			})
+			if result.HasErrors() {
+				logging.FromContext(c).Warn("GraphQL query failed", logging.ERROR_KEY, errors.New(result.Errors[0].Message))
+			}
+			c.JSON(http.StatusOK, result)
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
@@ -0,0 +1,496 @@
+// this holds the gRPC server serving the API of Harmonia alongside the REST routes, see api/harmonia.proto
+// Every method mirrors a REST route: it calls the same controller with the same Git credentials and, like the route,
+// is rejected during maintenance and must be signed if it is mutating
+// as with the route handlers, no complex logic should live here and errors are sanitized before being returned
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/api"
+	"harmonia-example.io/src/controllers"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/maintenance"
+	"harmonia-example.io/src/services/metadata"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/oidc"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tracing"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ERROR_DOMAIN is the domain of the error info attached to the errors of gRPC calls, whose reason is the models.Code
+// of the error
+const ERROR_DOMAIN = "harmonia-example.io"
+
+// IF_MATCH_METADATA is the metadata holding the signature of the RFC an update was made from, as the Update message
+// has no field for it
+const IF_MATCH_METADATA = "if-match"
+
+// mutatingMethods are the gRPC methods rejected while maintenance mode is enabled and that must be signed if request
+// signing is enabled, like the Mutating and Signed routes they mirror
+var mutatingMethods = map[string]bool{
+	api.Harmonia_SubmitRequest_FullMethodName: true,
+	api.Harmonia_UpdateRequest_FullMethodName: true,
+	api.Harmonia_ReviewRequest_FullMethodName: true,
+	api.Harmonia_MergeRequest_FullMethodName:  true,
+	api.Harmonia_LoadRequest_FullMethodName:   true,
+}
+
+// methodPermissions are the permissions the user must be granted to call each gRPC method, like the routes they
+// mirror
+var methodPermissions = map[string]models.Permission{
+	api.Harmonia_SubmitRequest_FullMethodName: models.SubmitPermission,
+	api.Harmonia_UpdateRequest_FullMethodName: models.SubmitPermission,
+	api.Harmonia_ReviewRequest_FullMethodName: models.ReviewPermission,
+	api.Harmonia_MergeRequest_FullMethodName:  models.MergePermission,
+	api.Harmonia_LoadRequest_FullMethodName:   models.LoadPermission,
+}
+
+// rpcCodes maps the status of the REST response to an error, see errorResponse, to the code of the gRPC error
+var rpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusLocked:              codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// serveGRPC serves the gRPC API on the configured port in the background, the gRPC API is not served if no port is
+// configured. Misconfiguration is fatal so the API is never silently left unserved
+func serveGRPC() {
+	port, err := settings.GRPCPort()
+	if err != nil {
+		panic(err)
+	}
+	if port == nil {
+		return
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
+	if err != nil {
+		panic(err)
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(observeRPC, authenticateRPC, guardRPC))
+	api.RegisterHarmoniaServer(server, &harmoniaServer{})
+
+	logging.Default.Info("serving gRPC API", "port", *port)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logging.Default.Error("gRPC server stopped", logging.ERROR_KEY, err)
+		}
+	}()
+}
+
+// metadataCarrier carries the trace context propagated by the caller of a gRPC call in the call metadata
+type metadataCarrier grpcMetadata.MD
+
+// Get returns the first value of the given key
+func (m metadataCarrier) Get(key string) string {
+	if values := grpcMetadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set sets the value of the given key
+func (m metadataCarrier) Set(key string, value string) {
+	grpcMetadata.MD(m).Set(key, value)
+}
+
+// Keys returns the keys of the metadata
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// observeRPC identifies, traces and times every gRPC call and gives it a logger of its own, like the middleware of the
+// REST routes. The ID of the call is the one given by the client in the x-request-id metadata, or a generated one,
+// the access token of its user the one given in the authorization metadata unless SSO is enabled, see authenticateRPC,
+// and its tenant the domain of its request
+func observeRPC(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	md, _ := grpcMetadata.FromIncomingContext(ctx)
+	requestID := metadataCarrier(md).Get(REQUEST_ID_HEADER)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	ctx = metadata.NewContext(ctx, requestID)
+	if oidc.Default == nil {
+		ctx = withUserToken(ctx, metadataCarrier(md).Get(AUTHORIZATION_HEADER))
+	}
+	// the tenant of the call is the schema domain of its request, like selectTenant does for the REST routes
+	if selector, ok := request.(interface{ GetDomain() string }); ok {
+		metadata.SetTenant(ctx, selector.GetDomain())
+	}
+	service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+	ctx, span := tracing.StartRPC(ctx, metadataCarrier(md), service, method,
+		tracing.REQUEST_ID_KEY.String(requestID))
+	ctx = logging.NewContext(ctx, logging.Default.With(
+		logging.REQUEST_ID_KEY, requestID,
+		logging.TRACE_ID_KEY, tracing.TraceID(ctx),
+		"method", info.FullMethod,
+	))
+	_ = grpc.SetHeader(ctx, grpcMetadata.Pairs(REQUEST_ID_HEADER, requestID))
+
+	response, err := handler(ctx, request)
+	code := grpcStatus.Code(err)
+	tracing.EndRPC(span, int(code), err)
+	metrics.RPCDuration.Observe(time.Since(start).Seconds(), info.FullMethod, code.String())
+
+	return response, err
+}
+
+// authenticateRPC rejects every gRPC call whose authorization metadata carries no valid ID token when SSO is enabled
+// and records the identity of the user otherwise, like authenticate does for the REST routes
+func authenticateRPC(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if oidc.Default == nil {
+		return handler(ctx, request)
+	}
+
+	md, _ := grpcMetadata.FromIncomingContext(ctx)
+	token := bearerToken(metadataCarrier(md).Get(AUTHORIZATION_HEADER))
+	if token == "" {
+		return nil, rpcError(ctx, errNoIDToken, "Authentication error occurred - no ID token")
+	}
+	identity, err := oidc.Default.Verify(ctx, token)
+	if err != nil {
+		logging.FromContext(ctx).Warn("rejected unauthenticated request", logging.ERROR_KEY, err)
+		return nil, rpcError(ctx, models.NewError(models.ErrUnauthenticated, models.UnauthenticatedCode, err.Error()),
+			"Authentication error occurred - invalid ID token")
+	}
+
+	ctx = oidc.NewContext(ctx, identity)
+	metadata.SetUser(ctx, identity.Login)
+	return handler(ctx, request)
+}
+
+// guardRPC rejects mutating gRPC calls unless they carry a valid, unused signature of the deterministic binary encoding
+// of their request when request signing is enabled, while maintenance mode is enabled, and unless the user is granted
+// the permission of the method, see verifyRequestSignature, rejectDuringMaintenance and authorize
+func guardRPC(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if !mutatingMethods[info.FullMethod] {
+		return handler(ctx, request)
+	}
+
+	if signing.Default != nil {
+		md, _ := grpcMetadata.FromIncomingContext(ctx)
+		carrier := metadataCarrier(md)
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(request.(proto.Message))
+		if err != nil {
+			return nil, rpcStatus(codes.InvalidArgument, models.MalformedRequestCode, "Unable to read request body")
+		}
+		if err = signing.Default.Verify(
+			carrier.Get(signing.TIMESTAMP_HEADER),
+			carrier.Get(signing.NONCE_HEADER),
+			carrier.Get(signing.SIGNATURE_HEADER),
+			body,
+		); err != nil {
+			logging.FromContext(ctx).Warn("rejected unsigned request", logging.ERROR_KEY, err)
+			if errors.Is(err, signing.ErrReplayedRequest) {
+				return nil, rpcStatus(codes.AlreadyExists, models.ReplayedRequestCode,
+					"Request has already been processed")
+			}
+			return nil, rpcStatus(codes.Unauthenticated, models.InvalidSignatureCode,
+				fmt.Sprintf("Request signature verification failed: %s", err.Error()))
+		}
+	}
+	if enabled, message := maintenance.Default.Enabled(); enabled {
+		return nil, rpcStatus(codes.Unavailable, models.MaintenanceCode, message)
+	}
+	if permission, ok := methodPermissions[info.FullMethod]; ok {
+		if err := authorizeUser(ctx, permission); err != nil {
+			return nil, rpcError(ctx, err, fmt.Sprintf("Unable to authorize the %s permission", permission))
+		}
+	}
+
+	return handler(ctx, request)
+}
+
+// rpcStatus returns a gRPC error of the given code with the given message, carrying the given models.Code as the
+// reason of its error info
+func rpcStatus(code codes.Code, reason models.Code, message string) error {
+	return rpcStatusWithMetadata(code, reason, message, nil)
+}
+
+// rpcStatusWithMetadata returns a gRPC error like rpcStatus, whose error info also carries the given metadata
+func rpcStatusWithMetadata(code codes.Code, reason models.Code, message string, errorMetadata map[string]string) error {
+	info := &errdetails.ErrorInfo{Reason: string(reason), Domain: ERROR_DOMAIN, Metadata: errorMetadata}
+	if withDetails, err := grpcStatus.New(code, message).WithDetails(info); err == nil {
+		return withDetails.Err()
+	}
+	return grpcStatus.Error(code, message)
+}
+
+// rpcError logs the given error of a gRPC call and returns it as a gRPC error along with the given sanitized message
+// The code, message and reason of the error are those of the REST response to it, see errorResponse, duplicate RFCs
+// are reported as already existing, the RFC identifier of duplicate, stale and corrupt RFCs is in the error metadata,
+// along with the signature and contents of the latest RFC for stale updates
+func rpcError(ctx context.Context, err error, message string) error {
+	httpStatus, body := errorResponse(err, message)
+	logging.FromContext(ctx).Error("request failed", "status", httpStatus, logging.ERROR_KEY, err)
+
+	code, ok := rpcCodes[httpStatus]
+	if !ok {
+		code = codes.Internal
+	}
+	switch body := body.(type) {
+	case *models.Duplicate:
+		return rpcStatusWithMetadata(codes.AlreadyExists, body.Code, body.Error, map[string]string{
+			"rfcIdentifier": body.RFCIdentifier,
+		})
+	case *models.StaleRFC:
+		contents, _ := json.Marshal(body.RFC)
+		return rpcStatusWithMetadata(code, body.Code, body.Error, map[string]string{
+			"rfcIdentifier": body.RFCIdentifier,
+			"signature":     body.Signature,
+			"rfc":           string(contents),
+		})
+	case *models.Integrity:
+		return rpcStatusWithMetadata(code, body.Code, body.Error, map[string]string{
+			"rfcIdentifier": body.RFCIdentifier,
+			"reason":        body.Reason,
+			"remediation":   body.Remediation,
+		})
+	case *models.Error:
+		return rpcStatus(code, body.Code, body.Error)
+	}
+	return rpcStatus(codes.Internal, models.InternalErrorCode, message)
+}
+
+// malformedRPC logs the given validation error and returns it as an invalid argument
+func malformedRPC(ctx context.Context, err error) error {
+	logging.FromContext(ctx).Warn("malformed request received", logging.ERROR_KEY, err)
+	return rpcStatus(codes.InvalidArgument, models.MalformedRequestCode,
+		fmt.Sprintf("Malformed request received: %s", err.Error()))
+}
+
+// configurationRPCError returns an internal error with the given message when a required configuration value, e.g. a
+// Git token, is missing
+func configurationRPCError(message string) error {
+	return rpcStatus(codes.Internal, models.ConfigurationErrorCode, message)
+}
+
+// harmoniaServer implements the Harmonia gRPC service
+type harmoniaServer struct {
+	api.UnimplementedHarmoniaServer
+}
+
+// SubmitRequest handles submitting an initial schema change request
+func (s *harmoniaServer) SubmitRequest(ctx context.Context, request *api.Submit) (*api.RFCIdentifier, error) {
+	RFC := request.Model()
+	// ensure the request conforms to the RFC model, as the route binding does
+	if err := binding.Validator.ValidateStruct(RFC); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if accessToken, err := userToken(ctx); err != nil {
+		return nil, rpcError(ctx, err, "Authentication error occurred - no token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *accessToken, RFC.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git")
+	} else if identifier, err := controllers.SubmitRequest(ctx, client, RFC, request.GetAllowDuplicate()); err != nil {
+		return nil, rpcError(ctx, err, "Request creation error occurred")
+	} else {
+		return api.NewRFCIdentifier(*identifier, controllers.GetLinks(ctx, client, *identifier, false)), nil
+	}
+}
+
+// UpdateRequest handles updating an existing schema change request, made from the RFC with the signature given in the
+// if-match metadata
+func (s *harmoniaServer) UpdateRequest(ctx context.Context, request *api.Update) (*api.RFCIdentifier, error) {
+	update := request.Model()
+	md, _ := grpcMetadata.FromIncomingContext(ctx)
+	update.Signature = metadataCarrier(md).Get(IF_MATCH_METADATA)
+	metadata.SetRFCIdentifier(ctx, update.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(update); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if accessToken, err := userToken(ctx); err != nil {
+		return nil, rpcError(ctx, err, "Authentication error occurred - no token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *accessToken, update.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git")
+	} else if identifier, err := controllers.UpdateRequest(ctx, client, update); err != nil {
+		return nil, rpcError(ctx, err, "update request error occurred")
+	} else {
+		return api.NewRFCIdentifier(*identifier, nil), nil
+	}
+}
+
+// ReviewRequest handles all review actions: approval, requesting changes, or commenting
+func (s *harmoniaServer) ReviewRequest(ctx context.Context, request *api.Review) (*api.Success, error) {
+	review := request.Model()
+	metadata.SetRFCIdentifier(ctx, review.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(review); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if _, err := models.ReviewType(review.Type).Base(); err != nil {
+		// reject unknown review types, listing the allowed values
+		return nil, rpcStatus(codes.InvalidArgument, models.InvalidReviewTypeCode, err.Error())
+	} else if accessToken, err := userToken(ctx); err != nil {
+		return nil, rpcError(ctx, err, "Authentication error occurred - no token")
+	} else if machineAccessToken, err := machineToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no machine token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *accessToken, review.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git")
+	} else if machineClient, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken,
+		review.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git machine")
+	} else if message, err := controllers.ReviewRequest(ctx, client, machineClient, review); err != nil {
+		return nil, rpcError(ctx, err, "Review submission error occurred")
+	} else {
+		return api.NewSuccess(*message, controllers.GetLinks(ctx, client, review.RFCIdentifier, false)), nil
+	}
+}
+
+// MergeRequest handles merging the given RFC and tagging it for tracking
+func (s *harmoniaServer) MergeRequest(ctx context.Context, request *api.Merge) (*api.Success, error) {
+	merge := request.Model()
+	metadata.SetRFCIdentifier(ctx, merge.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(merge); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if machineAccessToken, err := machineToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no machine token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken,
+		merge.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git machine")
+	} else if message, err := controllers.MergeRequest(ctx, client, merge); err != nil {
+		// Git providers reject merges of pull requests that are not mergeable as conflicts, RFCs short of their
+		// approval quorum are reported as such
+		if errors.Is(err, git.ErrConflict) && !errors.Is(err, models.ErrQuorumNotMet) {
+			return nil, rpcStatus(codes.FailedPrecondition, models.RFCNotMergeableCode,
+				fmt.Sprintf("RFC #%v is not mergeable", merge.RFCIdentifier))
+		}
+		return nil, rpcError(ctx, err, "Merge error occurred")
+	} else {
+		return api.NewSuccess(*message, controllers.GetLinks(ctx, client, merge.RFCIdentifier, true)), nil
+	}
+}
+
+// LoadRequest handles loading the given RFC into the underlying datastore
+func (s *harmoniaServer) LoadRequest(ctx context.Context, request *api.Load) (*api.Success, error) {
+	load := request.Model()
+	metadata.SetRFCIdentifier(ctx, load.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(load); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if accessToken, err := userToken(ctx); err != nil {
+		return nil, rpcError(ctx, err, "Authentication error occurred - no token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *accessToken, load.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git")
+	} else if err = controllers.LoadRequest(ctx, client, load); err != nil {
+		// this only captures setup errors because the actual load is handled asynchronously
+		return nil, rpcError(ctx, err, "Load request error occurred")
+	} else {
+		return api.NewSuccess(fmt.Sprintf(
+			"Submitted load request for RFC %s. You may query the load status through the Status method.",
+			load.RFCIdentifier), nil), nil
+	}
+}
+
+// Status handles retrieving the load status of the given RFC, including its load gate if loads are gated
+func (s *harmoniaServer) Status(ctx context.Context, request *api.Status) (*api.StatusResponse, error) {
+	status := request.Model()
+	metadata.SetRFCIdentifier(ctx, status.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(status); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if readAccessToken, err := readToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no read token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *readAccessToken,
+		status.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git reader")
+	} else if loadStatus, err := controllers.Status(ctx, client, status); err != nil {
+		return nil, rpcError(ctx, err, "Status error occurred")
+	} else {
+		return api.NewStatusResponse(loadStatus), nil
+	}
+}
+
+// GetJob returns the progress of the asynchronous job with the given ID
+func (s *harmoniaServer) GetJob(ctx context.Context, request *api.GetJob) (*api.Job, error) {
+	if job, err := jobs.Default.Get(request.GetId()); err != nil {
+		return nil, rpcError(ctx, err, "Job retrieval error occurred")
+	} else {
+		metadata.SetRFCIdentifier(ctx, job.RFCIdentifier)
+		return api.NewJob(job), nil
+	}
+}
+
+// GetRfcs queries the datastore for all RFCs with a given state, paginated output
+func (s *harmoniaServer) GetRfcs(ctx context.Context, request *api.GetRfcs) (*api.RFCs, error) {
+	query := request.Model()
+	if err := binding.Validator.ValidateStruct(query); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if readAccessToken, err := readToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no read token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *readAccessToken,
+		query.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git reader")
+	} else if rfcs, err := controllers.GetRfcs(ctx, client, query); err != nil {
+		return nil, rpcError(ctx, err, "Error occurred when retrieving RFCs")
+	} else {
+		return api.NewRFCs(rfcs), nil
+	}
+}
+
+// GetRfcContents retrieves the body of a given RFC, optionally as of a given revision
+func (s *harmoniaServer) GetRfcContents(ctx context.Context, request *api.GetRfcContents) (*api.RFCContents, error) {
+	query := request.Model()
+	metadata.SetRFCIdentifier(ctx, query.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(query); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if readAccessToken, err := readToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no read token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *readAccessToken,
+		query.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git reader")
+	} else if contents, err := controllers.GetRfcContents(ctx, client, query); err != nil {
+		return nil, rpcError(ctx, err, fmt.Sprintf("Error occurred when querying contents for RFC #%v",
+			query.RFCIdentifier))
+	} else if contents == nil {
+		return &api.RFCContents{Ref: query.Ref}, nil
+	} else {
+		return &api.RFCContents{Body: *contents, Ref: query.Ref}, nil
+	}
+}
+
+// GetReviews retrieves the reviewers of a given RFC and the state of their reviews
+func (s *harmoniaServer) GetReviews(ctx context.Context, request *api.GetReviews) (*api.RFCReviews, error) {
+	query := request.Model()
+	metadata.SetRFCIdentifier(ctx, query.RFCIdentifier)
+	if err := binding.Validator.ValidateStruct(query); err != nil {
+		return nil, malformedRPC(ctx, err)
+	} else if readAccessToken, err := readToken(ctx); err != nil {
+		return nil, configurationRPCError("Configuration error occurred - no read token")
+	} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *readAccessToken,
+		query.Domain); err != nil {
+		return nil, rpcError(ctx, err, "Service error occurred - Git reader")
+	} else if reviews, err := controllers.GetReviews(ctx, client, query); err != nil {
+		return nil, rpcError(ctx, err, fmt.Sprintf("Error occurred when querying reviews for RFC #%v",
+			query.RFCIdentifier))
+	} else {
+		return api.NewRFCReviews(reviews), nil
+	}
+}
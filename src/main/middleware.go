@@ -1,2 +1,397 @@
 // add middleware logic here if you desire!
 package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/controllers"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/authz"
+	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/maintenance"
+	"harmonia-example.io/src/services/metadata"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tenants"
+	"harmonia-example.io/src/services/tracing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// headers and media types used to identify requests and negotiate the response envelope
+const (
+	REQUEST_ID_HEADER = "X-Request-ID"
+	ENVELOPE_HEADER   = "X-Harmonia-Envelope"
+	ENVELOPE_MEDIA    = "application/vnd.harmonia.v2+json"
+)
+
+// assignRequestID identifies every request with the ID given by the client in the X-Request-ID header, or a generated
+// one, which is echoed back in the same header so clients and logs can correlate requests
+// The ID starts the metadata of the request, which handlers and controllers fill in, see metadata.Set
+func assignRequestID(c *gin.Context) {
+	requestID := c.GetHeader(REQUEST_ID_HEADER)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	c.Request = c.Request.WithContext(metadata.NewContext(c.Request.Context(), requestID))
+	c.Header(REQUEST_ID_HEADER, requestID)
+}
+
+// newRequestID returns a random request ID, empty if the random source fails
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// injectLogger gives every request a logger of its own carrying the request and trace IDs, which is annotated with the
+// metadata handlers and controllers record for the request, see metadata.Set
+func injectLogger(c *gin.Context) {
+	logger := logging.Default.With(
+		logging.REQUEST_ID_KEY, metadata.RequestID(c),
+		logging.TRACE_ID_KEY, tracing.TraceID(c.Request.Context()),
+		"method", c.Request.Method,
+		"path", c.FullPath(),
+	)
+	c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), logger))
+}
+
+// traceRequest traces every request in a span continuing the trace propagated by the caller through the traceparent
+// header, if any. The span is failed if the handler recorded an error, see controllerError, or responded with a server
+// error
+func traceRequest(c *gin.Context) {
+	ctx, span := tracing.StartRequest(c.Request, c.FullPath(),
+		tracing.REQUEST_ID_KEY.String(metadata.RequestID(c)))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+
+	var err error
+	if last := c.Errors.Last(); last != nil {
+		err = last.Err
+	}
+	tracing.EndRequest(span, c.Writer.Status(), err)
+}
+
+// observeDuration records the time taken to serve every request, see metrics.RequestDuration. Requests that match no
+// route are recorded under an empty route so probing unknown paths cannot grow the number of series
+func observeDuration(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+	metrics.RequestDuration.Observe(time.Since(start).Seconds(), c.Request.Method, c.FullPath(),
+		strconv.Itoa(c.Writer.Status()))
+}
+
+// envelopeWriter buffers a response so it can be wrapped in an envelope once the handler has written it
+type envelopeWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+// WriteHeader records the status code instead of sending it
+func (w *envelopeWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow does nothing, the status is sent with the envelope
+func (w *envelopeWriter) WriteHeaderNow() {}
+
+// Write buffers the given response body
+func (w *envelopeWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// WriteString buffers the given response body
+func (w *envelopeWriter) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
+
+// Status returns the recorded status code
+func (w *envelopeWriter) Status() int {
+	return w.status
+}
+
+// Size returns the size of the buffered response body
+func (w *envelopeWriter) Size() int {
+	return w.body.Len()
+}
+
+// Written returns whether a response has been buffered
+func (w *envelopeWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// wantsEnvelope returns whether the client negotiated the response envelope, either through the X-Harmonia-Envelope
+// header or by accepting the v2 API media type
+func wantsEnvelope(c *gin.Context) bool {
+	return strings.EqualFold(c.GetHeader(ENVELOPE_HEADER), "true") ||
+		strings.Contains(c.GetHeader("Accept"), ENVELOPE_MEDIA)
+}
+
+// envelopeResponse wraps the JSON response of the request in a models.Envelope if the client negotiated it, other
+// responses (swagger pages, redirects, metrics...) are sent untouched
+func envelopeResponse(c *gin.Context) {
+	if !wantsEnvelope(c) {
+		return
+	}
+
+	requestedAt := time.Now()
+	original := c.Writer
+	writer := &envelopeWriter{ResponseWriter: original, status: http.StatusOK}
+	c.Writer = writer
+	c.Next()
+	c.Writer = original
+
+	if !strings.HasPrefix(original.Header().Get("Content-Type"), gin.MIMEJSON) {
+		original.WriteHeader(writer.status)
+		if _, err := original.Write(writer.body.Bytes()); err != nil {
+			logging.FromContext(c).Error("unable to write response", logging.ERROR_KEY, err)
+		}
+		return
+	}
+
+	envelope := models.NewEnvelope(writer.status, writer.body.Bytes(), metadata.RequestID(c), requestedAt)
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		logging.FromContext(c).Error("json envelope marshal error", logging.ERROR_KEY, err)
+		original.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	original.WriteHeader(writer.status)
+	if _, err = original.Write(body); err != nil {
+		logging.FromContext(c).Error("unable to write response", logging.ERROR_KEY, err)
+	}
+}
+
+// respondToErrors responds to the requests whose handler recorded an error, see controllerError, without responding
+// with the status and code of the last error according to errorResponse. The error itself is logged as it is never
+// part of the response
+func respondToErrors(c *gin.Context) {
+	c.Next()
+
+	last := c.Errors.Last()
+	if last == nil || c.Writer.Written() {
+		return
+	}
+	message, _ := last.Meta.(string)
+	status, body := errorResponse(last.Err, message)
+	logging.FromContext(c).Error("request failed", "status", status, logging.ERROR_KEY, last.Err)
+	c.JSON(status, body)
+}
+
+// rejectDuringMaintenance aborts the request with a 503 and the maintenance message while maintenance mode is enabled
+// It is bound in front of every mutating route, so read routes keep working during maintenance
+func rejectDuringMaintenance(c *gin.Context) {
+	if enabled, message := maintenance.Default.Enabled(); enabled {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, &models.Error{Code: models.MaintenanceCode, Error: message})
+	}
+}
+
+// authorize returns a handler aborting the request with a 403 unless the user is granted the given permission by the
+// authorization policy, see authorizeUser. It is bound in front of every route requiring a permission
+func authorize(permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := authorizeUser(c, permission); err != nil {
+			status, body := errorResponse(err, fmt.Sprintf("Unable to authorize the %s permission", permission))
+			c.AbortWithStatusJSON(status, body)
+		}
+	}
+}
+
+// authorizeUser returns models.ErrNotPermitted (wrapped) unless the user making the request of the given context is
+// granted the given permission by the authorization policy, through one of their teams. Teams span the tracking
+// repositories of the provider, so they are read through the client of the default tracking repository
+func authorizeUser(ctx context.Context, permission models.Permission) error {
+	if authz.Default == nil {
+		return nil
+	}
+
+	accessToken, err := userToken(ctx)
+	if err != nil {
+		return err
+	}
+	client, err := git.New(ctx, settings.GitProvider(), *accessToken)
+	if err != nil {
+		return err
+	}
+	return controllers.Authorize(ctx, client, permission)
+}
+
+// selectTenant records the schema domain the request operates on as its tenant, from the domain query parameter or
+// the domain attribute of its JSON body, so it is made with the tokens of its tenant in the multi-tenant mode, see
+// userToken and machineToken. Requests are not inspected unless the multi-tenant mode is enabled
+func selectTenant(c *gin.Context) {
+	if tenants.Default == nil {
+		return
+	}
+
+	domain := c.Query("domain")
+	if domain == "" && c.Request.Body != nil {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, &models.Error{
+				Code:  models.MalformedRequestCode,
+				Error: "Unable to read request body",
+			})
+			return
+		}
+		// restore the body so the route handler can bind it
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// bodies that are not JSON objects are left for the route handler to reject
+		selector := models.DomainSelector{}
+		if json.Unmarshal(body, &selector) == nil {
+			domain = selector.Domain
+		}
+	}
+	metadata.SetTenant(c, domain)
+}
+
+// verifyRequestSignature aborts the request with a 401 unless it carries a valid, unused signature of its body, or
+// with a 409 if it replays a previously seen request. Requests are let through if request signing is not enabled
+// It is bound in front of every state changing route
+func verifyRequestSignature(c *gin.Context) {
+	if signing.Default == nil {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &models.Error{
+			Code:  models.MalformedRequestCode,
+			Error: "Unable to read request body",
+		})
+		return
+	}
+	// restore the body so the route handler can bind it
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = signing.Default.Verify(
+		c.GetHeader(signing.TIMESTAMP_HEADER),
+		c.GetHeader(signing.NONCE_HEADER),
+		c.GetHeader(signing.SIGNATURE_HEADER),
+		body,
+	); err != nil {
+		logging.FromContext(c).Warn("rejected unsigned request", "clientIp", c.ClientIP(), logging.ERROR_KEY, err)
+		if errors.Is(err, signing.ErrReplayedRequest) {
+			c.AbortWithStatusJSON(http.StatusConflict, &models.Error{
+				Code:  models.ReplayedRequestCode,
+				Error: "Request has already been processed",
+			})
+		} else {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, &models.Error{
+				Code:  models.InvalidSignatureCode,
+				Error: fmt.Sprintf("Request signature verification failed: %s", err.Error()),
+			})
+		}
+	}
+}
+
+// verifyWebhookSignature aborts the request with a 401 unless it carries a valid GitHub signature of its body, or with
+// a 409 if it redelivers a previously seen delivery. All requests are rejected with a 500 if no webhook secret is set
+// It is bound in front of every webhook route
+func verifyWebhookSignature(c *gin.Context) {
+	if signing.Webhooks == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, &models.Error{
+			Code:  models.ConfigurationErrorCode,
+			Error: "Configuration error occurred - no webhook secret",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &models.Error{
+			Code:  models.MalformedRequestCode,
+			Error: "Unable to read request body",
+		})
+		return
+	}
+	// restore the body so the route handler can parse it
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err = signing.Webhooks.Verify(
+		c.GetHeader(signing.GITHUB_DELIVERY_HEADER),
+		c.GetHeader(signing.GITHUB_SIGNATURE_HEADER),
+		body,
+	); err != nil {
+		logging.FromContext(c).Warn("rejected unsigned webhook", "clientIp", c.ClientIP(), logging.ERROR_KEY, err)
+		if errors.Is(err, signing.ErrReplayedRequest) {
+			c.AbortWithStatusJSON(http.StatusConflict, &models.Error{
+				Code:  models.ReplayedRequestCode,
+				Error: "Delivery has already been processed",
+			})
+		} else {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, &models.Error{
+				Code:  models.InvalidSignatureCode,
+				Error: fmt.Sprintf("Webhook signature verification failed: %s", err.Error()),
+			})
+		}
+	}
+}
+
+// verifySystemSignature aborts the request with a 401 unless it carries a valid signature of its body by the external
+// system its system header names, or with a 409 if it replays a previous request of the system. All requests are
+// rejected with a 500 if no external system is registered
+// It is bound in front of every external route
+func verifySystemSignature(c *gin.Context) {
+	if signing.Systems == nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, &models.Error{
+			Code:  models.ConfigurationErrorCode,
+			Error: "Configuration error occurred - no external system",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, &models.Error{
+			Code:  models.MalformedRequestCode,
+			Error: "Unable to read request body",
+		})
+		return
+	}
+	// restore the body so the route handler can bind it
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	system := c.GetHeader(signing.SYSTEM_HEADER)
+	if err = signing.Systems.Verify(
+		system,
+		c.GetHeader(signing.TIMESTAMP_HEADER),
+		c.GetHeader(signing.NONCE_HEADER),
+		c.GetHeader(signing.SIGNATURE_HEADER),
+		body,
+	); err != nil {
+		logging.FromContext(c).Warn("rejected unsigned external request", "clientIp", c.ClientIP(), "system", system,
+			logging.ERROR_KEY, err)
+		if errors.Is(err, signing.ErrReplayedRequest) {
+			c.AbortWithStatusJSON(http.StatusConflict, &models.Error{
+				Code:  models.ReplayedRequestCode,
+				Error: "Request has already been processed",
+			})
+		} else {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, &models.Error{
+				Code:  models.InvalidSignatureCode,
+				Error: fmt.Sprintf("External system signature verification failed: %s", err.Error()),
+			})
+		}
+		return
+	}
+	metadata.SetUser(c, system)
+}
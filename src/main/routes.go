@@ -7,13 +7,28 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"harmonia-example.io/src/controllers"
 	"harmonia-example.io/src/models"
-	"harmonia-example.io/src/services/config"
+	"harmonia-example.io/src/services/codegen"
 	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/maintenance"
+	"harmonia-example.io/src/services/metadata"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/notify"
+	"harmonia-example.io/src/services/schemas"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tenants"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -29,59 +44,177 @@ func GetRoutes() []models.Route {
 			Path:     "/health",
 			Handler:  getHealth,
 			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		{
+			Path:     "/health/ready",
+			Handler:  getReadiness,
+			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		{
+			Path:     "/metrics",
+			Handler:  getMetrics,
+			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		// meta routes
+		{
+			Path:     "/meta/capabilities",
+			Handler:  getCapabilities,
+			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		{
+			Path:     "/meta/receiptKey",
+			Handler:  getReceiptKey,
+			HttpVerb: http.MethodGet,
+			Public:   true,
 		},
 		// swagger docs routes
 		{
 			Path:     "/",
 			Handler:  swaggerRedirect,
 			HttpVerb: http.MethodGet,
+			Public:   true,
 		},
 		{
 			Path:     "/index.html",
 			Handler:  swaggerRedirect,
 			HttpVerb: http.MethodGet,
+			Public:   true,
 		},
 		{
 			Path:     "/docs",
 			Handler:  swaggerRedirect,
 			HttpVerb: http.MethodGet,
+			Public:   true,
 		},
 		{
 			Path:     "/swagger/*any",
 			Handler:  swagger,
 			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		// web ui routes
+		{
+			Path:     UI_PATH,
+			Handler:  uiRedirect,
+			HttpVerb: http.MethodGet,
+			Public:   true,
+		},
+		{
+			Path:     UI_PATH + "/*filepath",
+			Handler:  ui,
+			HttpVerb: http.MethodGet,
+			Public:   true,
 		},
 		// rfc routes
 		{
-			Path:     "/submitRequest",
-			Handler:  submitRequest,
-			HttpVerb: http.MethodPost,
+			Path:       "/submitRequest",
+			Handler:    submitRequest,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.SubmitPermission,
 		},
 		{
-			Path:     "/updateRequest",
-			Handler:  updateRequest,
-			HttpVerb: http.MethodPost,
+			Path:       "/submitRequests",
+			Handler:    submitRequests,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.SubmitPermission,
 		},
 		{
-			Path:     "/reviewRequest",
-			Handler:  reviewRequest,
+			Path:     "/validateRequest",
+			Handler:  validateRequest,
 			HttpVerb: http.MethodPost,
 		},
 		{
-			Path:     "/mergeRequest",
-			Handler:  mergeRequest,
-			HttpVerb: http.MethodPost,
+			Path:       "/updateRequest",
+			Handler:    updateRequest,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.SubmitPermission,
+		},
+		{
+			Path:       "/reviewRequest",
+			Handler:    reviewRequest,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.ReviewPermission,
+		},
+		{
+			Path:       "/editComment",
+			Handler:    editComment,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.ReviewPermission,
+		},
+		{
+			Path:       "/deleteComment",
+			Handler:    deleteComment,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.ReviewPermission,
+		},
+		{
+			Path:       "/withdrawRequest",
+			Handler:    withdrawRequest,
+			HttpVerb:   http.MethodDelete,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.SubmitPermission,
 		},
 		{
-			Path:     "/loadRequest",
-			Handler:  loadRequest,
+			Path:     "/annotate",
+			Handler:  annotate,
 			HttpVerb: http.MethodPost,
+			Mutating: true,
+			External: true,
+			Public:   true,
+		},
+		{
+			Path:       "/mergeRequest",
+			Handler:    mergeRequest,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.MergePermission,
+		},
+		{
+			Path:       "/loadRequest",
+			Handler:    loadRequest,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.LoadPermission,
 		},
 		{
 			Path:     "/status",
 			Handler:  status,
 			HttpVerb: http.MethodPost,
 		},
+		{
+			Path:     "/jobs/stats",
+			Handler:  getJobStats,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:     "/stats/reviews",
+			Handler:  getReviewStats,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:     "/jobs/:id",
+			Handler:  getJob,
+			HttpVerb: http.MethodGet,
+		},
 		{
 			Path:     "/getRfcs",
 			Handler:  getRfcs,
@@ -92,7 +225,347 @@ func GetRoutes() []models.Route {
 			Handler:  getRfcContents,
 			HttpVerb: http.MethodPost,
 		},
+		{
+			Path:     "/verifyRfc",
+			Handler:  verifyRfc,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/getRfcRendered",
+			Handler:  getRfcRendered,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/getReviews",
+			Handler:  getReviews,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/graphql",
+			Handler:  graphqlQuery,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/getRfcHistory",
+			Handler:  getRfcHistory,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/diffRequest",
+			Handler:  diffRequest,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/getAction",
+			Handler:  getAction,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/getReservations",
+			Handler:  getReservations,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:       "/releaseReservation",
+			Handler:    releaseReservation,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.SubmitPermission,
+		},
+		// activity routes
+		{
+			Path:     "/activity",
+			Handler:  activity,
+			HttpVerb: http.MethodPost,
+		},
+		{
+			Path:     "/myWork",
+			Handler:  myWork,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:     "/events",
+			Handler:  streamEvents,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:     "/ws/rfc/:id",
+			Handler:  reviewSession,
+			HttpVerb: http.MethodGet,
+		},
+		// admin routes
+		{
+			Path:       "/admin/rebuildRfc",
+			Handler:    rebuildRfc,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/heldComments",
+			Handler:    getHeldComments,
+			HttpVerb:   http.MethodGet,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/deadLetters",
+			Handler:    getDeadLetters,
+			HttpVerb:   http.MethodGet,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/redeliverDeadLetter",
+			Handler:    redeliverDeadLetter,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/moderateComment",
+			Handler:    moderateComment,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/migrateSignatures",
+			Handler:    migrateSignatures,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/backup",
+			Handler:    backup,
+			HttpVerb:   http.MethodPost,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/restore",
+			Handler:    restore,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/generateTypes",
+			Handler:    generateTypes,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:       "/admin/approveLoad",
+			Handler:    approveLoad,
+			HttpVerb:   http.MethodPost,
+			Mutating:   true,
+			Signed:     true,
+			Permission: models.ApprovePermission,
+		},
+		{
+			Path:     "/admin/breakGlass",
+			Handler:  breakGlass,
+			HttpVerb: http.MethodPost,
+			Mutating: true,
+			Signed:   true,
+		},
+		{
+			Path:     "/admin/maintenance",
+			Handler:  getMaintenance,
+			HttpVerb: http.MethodGet,
+		},
+		{
+			Path:       "/admin/maintenance",
+			Handler:    setMaintenance,
+			HttpVerb:   http.MethodPost,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		{
+			Path:     "/admin/seed",
+			Handler:  seed,
+			HttpVerb: http.MethodPost,
+			Mutating: true,
+			Signed:   true,
+		},
+		{
+			Path:       "/admin/testNotification",
+			Handler:    testNotification,
+			HttpVerb:   http.MethodPost,
+			Signed:     true,
+			Permission: models.AdminPermission,
+		},
+		// external system routes
+		{
+			Path:     "/externalApproval",
+			Handler:  externalApproval,
+			HttpVerb: http.MethodPost,
+			Mutating: true,
+			External: true,
+			Public:   true,
+		},
+		// webhook routes
+		{
+			Path:     "/webhooks/github",
+			Handler:  githubWebhook,
+			HttpVerb: http.MethodPost,
+			Mutating: true,
+			Webhook:  true,
+			Public:   true,
+		},
+	}
+}
+
+// bindJSON binds the JSON request body to the given model
+// If the body cannot be decoded, the returned error describes the JSON path and snippet of the document that failed
+func bindJSON(c *gin.Context, obj interface{}) error {
+	err := c.ShouldBindBodyWith(obj, binding.JSON)
+	if err == nil {
+		return nil
+	}
+
+	// the body is cached by the binding above, decode it again to locate the failure
+	if body, ok := c.Get(gin.BodyBytesKey); ok {
+		if raw, ok := body.([]byte); ok {
+			if decodeErr := models.Unmarshal(raw, obj); decodeErr != nil {
+				return decodeErr
+			}
+		}
+	}
+
+	return err
+}
+
+// malformedRequest logs the given binding error and responds with it as a bad request
+func malformedRequest(c *gin.Context, err error) {
+	logging.FromContext(c).Warn("malformed request received", "clientIp", c.ClientIP(), logging.ERROR_KEY, err)
+	c.JSON(http.StatusBadRequest, &models.Error{
+		Code:  models.MalformedRequestCode,
+		Error: fmt.Sprintf("Malformed request received: %s", err.Error()),
+	})
+}
+
+// configurationError responds with a 500 and the given message when a required configuration value, e.g. a Git token,
+// is missing
+func configurationError(c *gin.Context, message string) {
+	c.JSON(http.StatusInternalServerError, &models.Error{Code: models.ConfigurationErrorCode, Error: message})
+}
+
+// controllerError records the given error of the request along with the given sanitized message, respondToErrors
+// then responds with errorResponse once the handler returns
+func controllerError(c *gin.Context, err error, message string) {
+	_ = c.Error(err).SetMeta(message)
+}
+
+// gitClientError records the given error establishing a Git client like controllerError, so a request for a schema
+// domain without a tracking repository is answered with a 400
+func gitClientError(c *gin.Context, err error, message string) {
+	controllerError(c, err, message)
+}
+
+// errorKinds maps the kinds of failure to the status and code of the response, in order of precedence, along with
+// the detail appended to the sanitized message of the request
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   models.Code
+	detail string
+}{
+	{kind: models.ErrInvalid, status: http.StatusBadRequest, code: models.InvalidParameterCode, detail: "invalid request"},
+	{kind: models.ErrNotFound, status: http.StatusNotFound, code: models.NotFoundCode, detail: "not found"},
+	{kind: models.ErrConflict, status: http.StatusConflict, code: models.ConflictCode, detail: "conflicting change"},
+	{
+		kind:   models.ErrRateLimited,
+		status: http.StatusTooManyRequests,
+		code:   models.RateLimitedCode,
+		detail: "rate limited, retry later",
+	},
+	{
+		kind:   models.ErrUnauthenticated,
+		status: http.StatusUnauthorized,
+		code:   models.UnauthenticatedCode,
+		detail: "authentication required",
+	},
+	{
+		kind:   models.ErrUnauthorized,
+		status: http.StatusForbidden,
+		code:   models.PermissionDeniedCode,
+		detail: "permission denied",
+	},
+	{
+		kind:   models.ErrProvider,
+		status: http.StatusBadGateway,
+		code:   models.ProviderErrorCode,
+		detail: "Git provider error",
+	},
+}
+
+// errorResponse returns the status and body of the response to the given error along with the given sanitized message
+// RFC integrity failures come with their details so they can be repaired, stale updates with the latest RFC, schema
+// violations with an error for each offending field, embargoed RFCs with a 423, errors of a kind declared in models
+// with their own message and code, provider errors with the status of their kind and the sanitized message, and any
+// other error with a 500 and the sanitized message
+func errorResponse(err error, message string) (int, interface{}) {
+	var integrityErr *models.IntegrityError
+	var embargoErr *models.EmbargoError
+	var duplicateErr *models.DuplicateError
+	var staleErr *models.StaleUpdateError
+	var schemaErr *models.SchemaError
+	var kindErr *models.KindError
+	if errors.As(err, &embargoErr) {
+		return http.StatusLocked, &models.Error{Code: models.RFCEmbargoedCode, Error: embargoErr.Error()}
+	} else if errors.As(err, &integrityErr) {
+		return http.StatusConflict, &models.Integrity{
+			Error:         integrityErr.Error(),
+			Code:          models.RFCIntegrityCode,
+			RFCIdentifier: integrityErr.RFCIdentifier,
+			Reason:        string(integrityErr.Reason),
+			Remediation:   integrityErr.Remediation,
+		}
+	} else if errors.As(err, &duplicateErr) {
+		return http.StatusConflict, &models.Duplicate{
+			Error:         duplicateErr.Error(),
+			Code:          models.DuplicateRFCCode,
+			RFCIdentifier: duplicateErr.RFCIdentifier,
+		}
+	} else if errors.As(err, &staleErr) {
+		return http.StatusConflict, &models.StaleRFC{
+			Error:         staleErr.Error(),
+			Code:          models.StaleRFCCode,
+			RFCIdentifier: staleErr.RFCIdentifier,
+			Signature:     staleErr.Latest.Signature,
+			RFC:           staleErr.Latest,
+		}
+	} else if errors.As(err, &schemaErr) {
+		return http.StatusBadRequest, &models.SchemaViolation{
+			Error:  schemaErr.Error(),
+			Code:   models.InvalidActionDataCode,
+			Errors: schemaErr.Errors,
+		}
 	}
+
+	for _, errorKind := range errorKinds {
+		if !errors.Is(err, errorKind.kind) {
+			continue
+		}
+		if errors.As(err, &kindErr) && errors.Is(kindErr, errorKind.kind) {
+			return errorKind.status, &models.Error{Code: kindErr.Code, Error: err.Error()}
+		}
+		return errorKind.status, &models.Error{
+			Code:  errorKind.code,
+			Error: fmt.Sprintf("%s - %s", message, errorKind.detail),
+		}
+	}
+
+	return http.StatusInternalServerError, &models.Error{Code: models.InternalErrorCode, Error: message}
 }
 
 // @Summary Health check
@@ -106,6 +579,127 @@ func getHealth(c *gin.Context) {
 	c.JSON(http.StatusOK, &models.Healthy{Message: "healthy"})
 }
 
+// @Summary Metrics
+// @Description Exposes operational metrics (route latency, Git API calls, loads, caches) in the Prometheus text format
+// @Tags Health
+// @Produce plain
+// @Success 200 {string} string "metrics in the Prometheus text exposition format"
+// @Router /metrics [get]
+// getMetrics writes every registered metric in the Prometheus text exposition format
+func getMetrics(c *gin.Context) {
+	c.Header("Content-Type", metrics.CONTENT_TYPE)
+	c.Status(http.StatusOK)
+	if err := metrics.Default.Write(c.Writer); err != nil {
+		logging.FromContext(c).Error("unable to write metrics", logging.ERROR_KEY, err)
+	}
+}
+
+// @Summary Readiness check
+// @Description Validates that the startup warm-up is complete and the Git tokens have the required permissions, and
+// @Description reports drift of the branch protection of the tracking repository without affecting readiness
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.Readiness "ready response"
+// @Failure 503 {object} models.Readiness "not ready response, warming up or listing missing permissions per token"
+// @Router /health/ready [get]
+// getReadiness returns whether the service is warmed up and the configured Git tokens are sufficient for it to handle
+// requests, along with any drift of the branch protection
+func getReadiness(c *gin.Context) {
+	clients, setupErrors := tokenClients(c, "")
+	readiness := controllers.CheckReadiness(c, clients, setupErrors)
+	// drift of the branch protection is a warning, it does not make the service unready
+	if client, ok := clients["machine"]; ok {
+		if requiredApprovals, err := settings.RequiredApprovals(); err == nil {
+			check := controllers.CheckBranchProtection(c, client, "", settings.RequiredStatusContexts(),
+				requiredApprovals)
+			readiness.Protection = &check
+		}
+	}
+
+	if readiness.Ready {
+		c.JSON(http.StatusOK, readiness)
+	} else {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+	}
+}
+
+// @description get the features the Git provider of a tracking repository supports (labels, draft pull requests,
+// @description team reviewers, check runs...), so clients can hide or degrade what the provider lacks
+// @Tags Meta
+// @Produce json
+// @Param domain query string false "schema domain whose tracking repository is described, the default one if omitted"
+// @Response 200 {object} models.Capabilities
+// @Response 400 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /meta/capabilities [get]
+// getCapabilities returns the capabilities of the Git provider of the requested tracking repository
+func getCapabilities(c *gin.Context) {
+	// operate as machine, capabilities do not depend on the caller
+	if machineAccessToken, err := machineToken(c); err != nil {
+		configurationError(c, "Configuration error occurred - no machine token")
+	} else {
+		// establish git client
+		if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, c.Query("domain")); err != nil {
+			gitClientError(c, err, "Service error occurred - Git machine")
+		} else {
+			c.JSON(http.StatusOK, client.Capabilities())
+		}
+	}
+}
+
+// @description get the public key submission receipts are signed with, to verify the server signature of receipts
+// @Tags Meta
+// @Produce json
+// @Response 200 {object} models.ReceiptKey
+// @Response 404 {object} models.Error
+// @Router /meta/receiptKey [get]
+// getReceiptKey returns the public key submission receipts are signed with
+func getReceiptKey(c *gin.Context) {
+	if signing.Receipts == nil {
+		c.JSON(http.StatusNotFound, &models.Error{
+			Code:  models.NotFoundCode,
+			Error: "receipts are not signed",
+		})
+	} else {
+		c.JSON(http.StatusOK, &models.ReceiptKey{
+			Algorithm: signing.RECEIPT_ALGORITHM,
+			PublicKey: signing.Receipts.PublicKey(),
+		})
+	}
+}
+
+// tokenClients establishes a git client of the tracking repository of the given schema domain for each configured
+// token, keyed by token name. Tokens that could not be configured are returned as errors keyed by token name instead,
+// except for the shared user token which is optional since requests carry the token of their user
+func tokenClients(ctx context.Context, domain string) (map[string]git.Git, map[string]error) {
+	clients := map[string]git.Git{}
+	setupErrors := map[string]error{}
+	tokens := map[string]func() (*string, error){
+		"user":    settings.Token,
+		"machine": func() (*string, error) { return domainMachineToken(ctx, domain) },
+	}
+	// tenants never share GIT_TOKEN, see userToken
+	if tenants.Default != nil {
+		if tenant, ok := tenants.Default.Get(domain); ok {
+			tokens["user"] = func() (*string, error) { return settings.TenantToken(tenant.Token) }
+		}
+	}
+
+	for name, getToken := range tokens {
+		if token, err := getToken(); err != nil {
+			if name != "user" {
+				setupErrors[name] = err
+			}
+		} else if client, err := git.NewForDomain(ctx, settings.GitProvider(), *token, domain); err != nil {
+			setupErrors[name] = err
+		} else {
+			clients[name] = client
+		}
+	}
+
+	return clients, setupErrors
+}
+
 // you don't see any openapi comments here because this is swagger itself
 // swaggerRedirect redirects request to the swagger docs page
 func swaggerRedirect(c *gin.Context) {
@@ -124,31 +718,156 @@ func swagger(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param RFC body models.RFC true "RFC JSON"
+// @Param allowDuplicate query bool false "submit the RFC even if an open RFC proposes the same change"
 // @Response 200 {object} models.RFCIdentifier
 // @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 409 {object} models.Duplicate
 // @Response 500 {object} models.Error
+// @Security BearerAuth
 // @Router /submitRequest [post]
 // submitRequest handles submitting an initial schema change request
 func submitRequest(c *gin.Context) {
 	RFC := new(models.RFC)
 	// ensure the incoming request body conforms to the RFC model
-	if err := c.ShouldBindBodyWith(RFC, binding.JSON); err != nil {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+	if err := bindJSON(c, RFC); err != nil {
+		malformedRequest(c, err)
+	} else if allowDuplicate, err := strconv.ParseBool(c.DefaultQuery("allowDuplicate", "false")); err != nil {
+		c.JSON(http.StatusBadRequest, &models.Error{
+			Code:  models.InvalidParameterCode,
+			Error: "allowDuplicate must be a boolean",
+		})
 	} else {
 		// initialize params for controller
-		if accessToken, err := config.GetToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no token"})
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
 		} else {
 			// establish git client
-			if github, err := git.NewGitHub(c, *accessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, RFC.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
 			} else {
 				// submit RFC
-				if identifier, err := controllers.SubmitRequest(c, github, RFC); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Request creation error occurred"})
+				var duplicateErr *models.DuplicateError
+				if identifier, err := controllers.SubmitWithReceipt(c, client, RFC, allowDuplicate); err != nil {
+					if errors.As(err, &duplicateErr) {
+						c.JSON(http.StatusConflict, &models.Duplicate{
+							Error:         duplicateErr.Error(),
+							Code:          models.DuplicateRFCCode,
+							RFCIdentifier: duplicateErr.RFCIdentifier,
+							Links:         controllers.GetLinks(c, client, duplicateErr.RFCIdentifier, false),
+						})
+					} else {
+						controllerError(c, err, "Request creation error occurred")
+					}
 				} else {
-					c.JSON(http.StatusOK, &models.RFCIdentifier{RFCIdentifier: *identifier})
+					c.JSON(http.StatusOK, identifier)
+				}
+			}
+		}
+	}
+}
+
+// @description submit a batch of RFCs, each submitted like /submitRequest and reported on separately
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param batch body models.SubmitBatch true "RFCs JSON"
+// @Param allowDuplicate query bool false "submit the RFCs even if an open RFC proposes the same change"
+// @Response 200 {object} models.BatchSubmission
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /submitRequests [post]
+// submitRequests handles submitting a batch of schema change requests, useful for bulk schema migrations
+func submitRequests(c *gin.Context) {
+	batch := new(models.SubmitBatch)
+	// ensure the incoming request body conforms to the batch model
+	if err := bindJSON(c, batch); err != nil {
+		malformedRequest(c, err)
+	} else if allowDuplicate, err := strconv.ParseBool(c.DefaultQuery("allowDuplicate", "false")); err != nil {
+		c.JSON(http.StatusBadRequest, &models.Error{
+			Code:  models.InvalidParameterCode,
+			Error: "allowDuplicate must be a boolean",
+		})
+	} else {
+		// initialize params for controller
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, batch.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
+			} else {
+				// submit RFCs
+				if identifiers, failures, err := controllers.SubmitBatch(c, client, batch, allowDuplicate); err != nil {
+					controllerError(c, err, "Request creation error occurred")
+				} else {
+					c.JSON(http.StatusOK, batchSubmission(identifiers, failures))
+				}
+			}
+		}
+	}
+}
+
+// batchSubmission returns the result of each RFC of a batch given the identifier of each RFC submitted and the error
+// of each RFC that failed, failures being reported as a single submission would have answered them
+func batchSubmission(identifiers []*models.RFCIdentifier, failures []error) *models.BatchSubmission {
+	submission := &models.BatchSubmission{Results: make([]models.BatchResult, len(identifiers))}
+	for i, identifier := range identifiers {
+		result := models.BatchResult{Index: i, RFCIdentifier: identifier, Status: http.StatusOK}
+		if failures[i] != nil {
+			result.Status, result.Error = errorResponse(failures[i], "Request creation error occurred")
+			submission.Failed++
+		} else {
+			submission.Submitted++
+		}
+		submission.Results[i] = result
+	}
+
+	return submission
+}
+
+// @description validate an RFC as it would be submitted, without creating its branch or pull request
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param RFC body models.RFC true "RFC JSON"
+// @Param allowDuplicate query bool false "skip the check for an open RFC proposing the same change"
+// @Response 200 {object} models.Validation
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /validateRequest [post]
+// validateRequest handles checking an RFC ahead of its submission, reporting every error found rather than the first
+func validateRequest(c *gin.Context) {
+	RFC := new(models.RFC)
+	// decode without binding so that missing attributes are reported as validation errors
+	if body, err := c.GetRawData(); err != nil {
+		malformedRequest(c, err)
+	} else if err = models.Unmarshal(body, RFC); err != nil {
+		malformedRequest(c, err)
+	} else if allowDuplicate, err := strconv.ParseBool(c.DefaultQuery("allowDuplicate", "false")); err != nil {
+		c.JSON(http.StatusBadRequest, &models.Error{
+			Code:  models.InvalidParameterCode,
+			Error: "allowDuplicate must be a boolean",
+		})
+	} else {
+		// operate as machine, nothing is written
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, RFC.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// validate RFC
+				if validation, err := controllers.ValidateRequest(c, client, RFC, allowDuplicate); err != nil {
+					controllerError(c, err, "Validation error occurred")
+				} else {
+					c.JSON(http.StatusOK, validation)
 				}
 			}
 		}
@@ -162,33 +881,36 @@ func submitRequest(c *gin.Context) {
 // @Param Update body models.Update true "Update JSON"
 // @Response 200 {object} models.RFCIdentifier
 // @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 409 {object} models.StaleRFC
 // @Response 500 {object} models.Error
+// @Security BearerAuth
 // @Router /updateRequest [post]
 // updateRequest handles updating an existing schema change request
 func updateRequest(c *gin.Context) {
 	update := new(models.Update)
 	// ensure the incoming request body conforms to the Update model
-	if c.ShouldBindBodyWith(update, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
+	if err := bindJSON(c, update); err == nil {
+		metadata.SetRFCIdentifier(c, update.RFCIdentifier)
 		// initialize params for controller
-		if accessToken, err := config.GetToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no token"})
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
 		} else {
 			// establish git client
-			if github, err := git.NewGitHub(c, *accessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, update.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
 			} else {
 				// submit update request
-				if identifier, err := controllers.UpdateRequest(c, github, update); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "update request error occurred"})
+				if identifier, err := controllers.UpdateRequest(c, client, update); err != nil {
+					controllerError(c, err, "update request error occurred")
 				} else {
 					c.JSON(http.StatusOK, &models.RFCIdentifier{RFCIdentifier: *identifier})
 				}
 			}
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+		malformedRequest(c, err)
 	}
 }
 
@@ -199,113 +921,345 @@ func updateRequest(c *gin.Context) {
 // @Param Review body models.Review true "Review JSON"
 // @Response 200 {object} models.Success
 // @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 409 {object} models.Integrity
 // @Response 500 {object} models.Error
+// @Security BearerAuth
 // @Router /reviewRequest [post]
 // reviewRequest handles all review actions: approval, requesting changes, or commenting. Requesting changes blocks
 // merging, while the other events do not.
 func reviewRequest(c *gin.Context) {
 	review := new(models.Review)
 	// ensure the incoming request body conforms to the Review model
-	if c.ShouldBindBodyWith(review, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
+	if err := bindJSON(c, review); err != nil {
+		malformedRequest(c, err)
+	} else if _, err := models.ReviewType(review.Type).Base(); err != nil {
+		// reject unknown review types, listing the allowed values
+		c.JSON(http.StatusBadRequest, &models.Error{Code: models.InvalidReviewTypeCode, Error: err.Error()})
+	} else {
+		metadata.SetRFCIdentifier(c, review.RFCIdentifier)
 		// initialize params for controller
-		if accessToken, err := config.GetToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no token"})
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
 		} else {
-			if machineAccessToken, err := config.GetMachineToken(); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{
-					Error: "Configuration error occurred - no machine token"})
+			if machineAccessToken, err := machineToken(c); err != nil {
+				configurationError(c, "Configuration error occurred - no machine token")
 			} else {
 				// establish git clients
-				if github, err := git.NewGitHub(c, *accessToken); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git"})
+				if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, review.Domain); err != nil {
+					gitClientError(c, err, "Service error occurred - Git")
 				} else {
-					if githubMachine, err := git.NewGitHub(c, *machineAccessToken); err != nil {
-						c.JSON(http.StatusInternalServerError, &models.Error{
-							Error: "Service error occurred - Git machine"})
+					machineClient, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, review.Domain)
+					if err != nil {
+						gitClientError(c, err, "Service error occurred - Git machine")
 					} else {
 						// submit review
-						if message, err := controllers.ReviewRequest(c, github, githubMachine, review); err != nil {
-							c.JSON(http.StatusInternalServerError, &models.Error{
-								Error: "Review submission error occurred"})
+						if message, err := controllers.ReviewRequest(c, client, machineClient, review); err != nil {
+							controllerError(c, err, "Review submission error occurred")
 						} else {
-							c.JSON(http.StatusOK, &models.Success{Success: *message})
+							c.JSON(http.StatusOK, &models.Success{
+								Success: *message,
+								Links:   controllers.GetLinks(c, client, review.RFCIdentifier, false),
+							})
 						}
 					}
 				}
 			}
 		}
-	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
 	}
 }
 
-// @description merge RFC
+// @description edit a comment the caller made on an RFC, the previous text is kept in the comment's edit history
 // @Tags RFC
 // @Accept json
 // @Produce json
-// @Param Merge body models.Merge true "Merge JSON"
+// @Param EditComment body models.EditComment true "Edit comment JSON"
 // @Response 200 {object} models.Success
 // @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
 // @Response 500 {object} models.Error
-// @Router /mergeRequest [post]
-// mergeRequest handles merging the given RFC and tagging it for tracking
-func mergeRequest(c *gin.Context) {
-	merge := new(models.Merge)
-	// ensure the incoming request body conforms to the Merge model
-	if c.ShouldBindBodyWith(merge, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
+// @Security BearerAuth
+// @Router /editComment [post]
+// editComment handles editing a comment of an RFC
+func editComment(c *gin.Context) {
+	request := new(models.EditComment)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err != nil {
+		malformedRequest(c, err)
+	} else {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
 		// initialize params for controller
-		if machineAccessToken, err := config.GetMachineToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no machine token"})
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
 		} else {
-			// establish git clients
-			if github, err := git.NewGitHub(c, *machineAccessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git machine"})
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
 			} else {
-				// submit merge request
-				if message, err := controllers.MergeRequest(c, github, merge); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Merge error occurred"})
+				// edit comment
+				if message, err := controllers.EditComment(c, client, request); err != nil {
+					commentError(c, err, request.RFCIdentifier, request.Signature, "Comment edit error occurred")
 				} else {
-					c.JSON(http.StatusOK, &models.Success{Success: *message})
+					c.JSON(http.StatusOK, &models.Success{
+						Success: *message,
+						Links:   controllers.GetLinks(c, client, request.RFCIdentifier, false),
+					})
 				}
 			}
 		}
-	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
 	}
 }
 
-// @description load RFC
+// @description delete a comment the caller made on an RFC
 // @Tags RFC
 // @Accept json
 // @Produce json
-// @Param Load body models.Load true "Load JSON"
+// @Param DeleteComment body models.DeleteComment true "Delete comment JSON"
 // @Response 200 {object} models.Success
 // @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
 // @Response 500 {object} models.Error
-// @Router /loadRequest [post]
-// loadRequest handles loading the given RFC into the underlying datastore
-func loadRequest(c *gin.Context) {
+// @Security BearerAuth
+// @Router /deleteComment [post]
+// deleteComment handles deleting a comment of an RFC
+func deleteComment(c *gin.Context) {
+	request := new(models.DeleteComment)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err != nil {
+		malformedRequest(c, err)
+	} else {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// initialize params for controller
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
+			} else {
+				// delete comment
+				if message, err := controllers.DeleteComment(c, client, request); err != nil {
+					commentError(c, err, request.RFCIdentifier, request.Signature, "Comment deletion error occurred")
+				} else {
+					c.JSON(http.StatusOK, &models.Success{
+						Success: *message,
+						Links:   controllers.GetLinks(c, client, request.RFCIdentifier, false),
+					})
+				}
+			}
+		}
+	}
+}
+
+// commentError responds with a 404 if the targeted comment does not exist, with a 403 if the caller did not make it
+// and as controllerError does otherwise
+func commentError(c *gin.Context, err error, rfcIdentifier string, signature string, message string) {
+	if errors.Is(err, models.ErrActionNotFound) {
+		c.JSON(http.StatusNotFound, &models.Error{Code: models.ActionNotFoundCode, Error: fmt.Sprintf(
+			"No comment with signature %s found in RFC #%v", signature, rfcIdentifier)})
+	} else if errors.Is(err, models.ErrNotCommentAuthor) {
+		c.JSON(http.StatusForbidden, &models.Error{
+			Code:  models.NotCommentAuthorCode,
+			Error: "Only the author of a comment can change it",
+		})
+	} else {
+		controllerError(c, err, message)
+	}
+}
+
+// @description withdraw an RFC the caller submitted, closing its pull request and deleting its branch
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Withdraw body models.Withdraw true "Withdraw JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /withdrawRequest [delete]
+// withdrawRequest handles retracting a submitted RFC
+func withdrawRequest(c *gin.Context) {
+	withdraw := new(models.Withdraw)
+	// ensure the incoming request body conforms to the Withdraw model
+	if err := bindJSON(c, withdraw); err != nil {
+		malformedRequest(c, err)
+	} else {
+		metadata.SetRFCIdentifier(c, withdraw.RFCIdentifier)
+		// initialize params for controller
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, withdraw.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
+			} else {
+				// submit withdrawal
+				if message, err := controllers.WithdrawRequest(c, client, withdraw); err != nil {
+					if errors.Is(err, models.ErrNotRFCAuthor) {
+						c.JSON(http.StatusForbidden, &models.Error{
+							Code:  models.NotRFCAuthorCode,
+							Error: "Only the author of an RFC can withdraw it",
+						})
+					} else {
+						controllerError(c, err, fmt.Sprintf("Error occurred when withdrawing RFC #%v",
+							withdraw.RFCIdentifier))
+					}
+				} else {
+					// no links, the pull request is closed and the branch holding the RFC file is gone
+					c.JSON(http.StatusOK, &models.Success{Success: *message})
+				}
+			}
+		}
+	}
+}
+
+// @description attach the findings of a registered automated analyzer to RFC actions, replacing its previous findings
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Annotate body models.Annotate true "Annotate JSON"
+// @Param X-Harmonia-System header string true "Name of the analyzer"
+// @Param X-Harmonia-Timestamp header string true "Unix time the request was signed at"
+// @Param X-Harmonia-Nonce header string true "Single use nonce"
+// @Param X-Harmonia-Signature header string true "HMAC-SHA256 signature keyed with the secret of the analyzer"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 500 {object} models.Error
+// @Router /annotate [post]
+// annotate handles attaching analyzer annotations to the actions of an RFC
+// Analyzers authenticate as external systems, their annotations are written by the machine client since they are not
+// made by a user
+func annotate(c *gin.Context) {
+	request := new(models.Annotate)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err != nil {
+		malformedRequest(c, err)
+	} else {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// initialize params for controller
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// attach annotations, the system header was verified along with the signature of the request
+				analyzer := c.GetHeader(signing.SYSTEM_HEADER)
+				if message, err := controllers.Annotate(c, client, analyzer, request); err != nil {
+					if errors.Is(err, models.ErrUnknownAnalyzer) {
+						c.JSON(http.StatusForbidden, &models.Error{Code: models.UnknownAnalyzerCode, Error: fmt.Sprintf(
+							"Analyzer %s is not registered", analyzer)})
+					} else {
+						controllerError(c, err, "Annotation error occurred")
+					}
+				} else {
+					c.JSON(http.StatusOK, &models.Success{
+						Success: *message,
+						Links:   controllers.GetLinks(c, client, request.RFCIdentifier, false),
+					})
+				}
+			}
+		}
+	}
+}
+
+// @description merge RFC
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Merge body models.Merge true "Merge JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 423 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /mergeRequest [post]
+// mergeRequest handles merging the given RFC and tagging it for tracking
+func mergeRequest(c *gin.Context) {
+	merge := new(models.Merge)
+	// ensure the incoming request body conforms to the Merge model
+	if err := bindJSON(c, merge); err == nil {
+		metadata.SetRFCIdentifier(c, merge.RFCIdentifier)
+		// initialize params for controller
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, merge.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// submit merge request
+				if message, err := controllers.MergeRequest(c, client, merge); err != nil {
+					// Git providers reject merges of pull requests that are not mergeable as conflicts, RFCs short of
+					// their approval quorum are reported as such
+					if errors.Is(err, git.ErrConflict) && !errors.Is(err, models.ErrQuorumNotMet) {
+						c.JSON(http.StatusConflict, &models.Error{Code: models.RFCNotMergeableCode, Error: fmt.Sprintf(
+							"RFC #%v is not mergeable", merge.RFCIdentifier)})
+					} else {
+						controllerError(c, err, "Merge error occurred")
+					}
+				} else {
+					c.JSON(http.StatusOK, &models.Success{
+						Success: *message,
+						Links:   controllers.GetLinks(c, client, merge.RFCIdentifier, true),
+					})
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description load RFC
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Load body models.Load true "Load JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 423 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /loadRequest [post]
+// loadRequest handles loading the given RFC into the underlying datastore
+func loadRequest(c *gin.Context) {
 	load := new(models.Load)
 	// ensure the incoming request body conforms to the Load model
-	if c.ShouldBindBodyWith(load, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
+	if err := bindJSON(c, load); err == nil {
+		metadata.SetRFCIdentifier(c, load.RFCIdentifier)
 		// initialize params for controller
-		if accessToken, err := config.GetToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no token"})
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
 		} else {
 			// establish git client
-			if github, err := git.NewGitHub(c, *accessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, load.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
 			} else {
 				// submit load request
 				// this only captures setup errors because the actual load is handled asynchronously
-				if err = controllers.LoadRequest(c, github, load); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Load request error occurred"})
+				if err = controllers.LoadRequest(c, client, load); err != nil {
+					controllerError(c, err, "Load request error occurred")
 				} else {
 					c.JSON(http.StatusOK, &models.LoadRequest{Message: fmt.Sprintf(
 						"Submitted load request for RFC %s.You may query the load status through the /status endpoint.",
@@ -314,7 +1268,62 @@ func loadRequest(c *gin.Context) {
 			}
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the progress of an asynchronous job started by a request, e.g. the load of an RFC
+// @Tags RFC
+// @Produce json
+// @Param id path string true "Job ID"
+// @Response 200 {object} models.Job
+// @Response 404 {object} models.Error
+// @Router /jobs/{id} [get]
+// getJob returns the progress of the asynchronous job with the given ID
+func getJob(c *gin.Context) {
+	if job, err := jobs.Default.Get(c.Param("id")); err != nil {
+		controllerError(c, err, "Job retrieval error occurred")
+	} else {
+		metadata.SetRFCIdentifier(c, job.RFCIdentifier)
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// @description get the utilization of the workers and the backlog of the job queue, along with its recent failures
+// @Tags RFC
+// @Produce json
+// @Response 200 {object} models.JobStats
+// @Router /jobs/stats [get]
+// getJobStats returns the worker utilization, queue depth, oldest pending job and recent failures of the job queue
+func getJobStats(c *gin.Context) {
+	c.JSON(http.StatusOK, jobs.Default.Stats())
+}
+
+// @description get the review workload of each reviewer and team, how quickly they first respond to review requests
+// @description and the pending review requests breaching the review SLA
+// @Tags RFC
+// @Produce json
+// @Param domain query string false "schema domain whose tracking repository is reported, the default one if omitted"
+// @Response 200 {object} models.ReviewStats
+// @Response 400 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /stats/reviews [get]
+// getReviewStats returns the review workload and response times of the reviewers of the requested tracking repository
+func getReviewStats(c *gin.Context) {
+	// operate as machine, the stats do not depend on the caller
+	if machineAccessToken, err := machineToken(c); err != nil {
+		configurationError(c, "Configuration error occurred - no machine token")
+	} else {
+		// establish git client
+		if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, c.Query("domain")); err != nil {
+			gitClientError(c, err, "Service error occurred - Git machine")
+		} else {
+			if stats, err := controllers.GetReviewStats(c, client); err != nil {
+				controllerError(c, err, "Error occurred when computing review stats")
+			} else {
+				c.JSON(http.StatusOK, stats)
+			}
+		}
 	}
 }
 
@@ -323,81 +1332,79 @@ func loadRequest(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param Status body models.Status true "Load Status JSON"
-// @Response 200 {object} models.Success
+// @Response 200 {object} models.StatusResponse
 // @Response 400 {object} models.Error
+// @Response 409 {object} models.Integrity
 // @Response 500 {object} models.Error
 // @Router /status [post]
-// status handles retrieving the load status of the given RFC
+// status handles retrieving the load status of the given RFC, including its load gate if loads are gated
 func status(c *gin.Context) {
 	status := new(models.Status)
 	// ensure the incoming request body conforms to the Status model
-	if c.ShouldBindBodyWith(status, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
-		// operate as machine for status requests
-		if machineAccessToken, err := config.GetMachineToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no machine token"})
+	if err := bindJSON(c, status); err == nil {
+		metadata.SetRFCIdentifier(c, status.RFCIdentifier)
+		// operate with the read token for status requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
 		} else {
 			// establish git clients
-			if github, err := git.NewGitHub(c, *machineAccessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git machine"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, status.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
 			} else {
 				// submit status request
-				if loadStatus, err := controllers.Status(c, github, status); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Status error occurred"})
+				if loadStatus, err := controllers.Status(c, client, status); err != nil {
+					controllerError(c, err, "Status error occurred")
 				} else {
-					if loadStatus == nil {
-						c.JSON(http.StatusOK, &models.StatusResponse{Status: "none"})
-					} else {
-						c.JSON(http.StatusOK, &models.StatusResponse{Status: *loadStatus})
-					}
+					c.JSON(http.StatusOK, loadStatus)
 				}
 			}
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+		malformedRequest(c, err)
 	}
 }
 
-// @description get submitted RFCs
+// @description get submitted RFCs, including a review, mergeability and load status summary of each when filtering by
+// @description owner. Selecting fields returns, and computes, only the selected links and summary fields
 // @Tags RFC
 // @Accept json
 // @Produce json
 // @Param Query body models.GetRfcs true "Query JSON"
+// @Param Cache-Control header string false "no-cache to bypass the cached response"
 // @Response 200 {object} models.RFCs
+// @Header 200 {integer} Age "seconds since the RFCs were retrieved from the Git provider"
+// @Header 200 {string} Last-Modified "time the RFCs were retrieved from the Git provider"
 // @Response 400 {object} models.Error
 // @Response 403 {object} models.Error
 // @Response 500 {object} models.Error
 // @Router /getRfcs [post]
-// getRfcs queries the datastore for all RFCs with a given state, paginated output
+// getRfcs queries the datastore for all RFCs with a given state, paginated output, served from a short lived cache
 func getRfcs(c *gin.Context) {
 	request := new(models.GetRfcs)
 	// ensure the incoming request body conforms to the request model
-	if c.ShouldBindBodyWith(request, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
-		// operate as machine for credentials
-		if machineAccessToken, err := config.GetMachineToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no machine token"})
+	if err := bindJSON(c, request); err == nil {
+		// operate with the read token for listing requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
 		} else {
 			// establish git clients
-			if github, err := git.NewGitHub(c, *machineAccessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git machine"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
 			} else {
-				// submit status request
-				if results, err := controllers.GetRfcs(c, github, request); err != nil {
-					fmt.Println(err)
-					c.JSON(http.StatusInternalServerError, &models.Error{Error: "Error occurred when retrieving RFCs"})
+				// submit status request, served from cache unless the client asks for a fresh response
+				refresh := strings.Contains(strings.ToLower(c.GetHeader("Cache-Control")), "no-cache")
+				if rfcs, retrievedAt, err := controllers.CachedRfcs(c, client, request, refresh); err != nil {
+					controllerError(c, err, "Error occurred when retrieving RFCs")
 				} else {
-					count := len(results)
-					if results == nil {
-						c.JSON(http.StatusOK, &models.RFCs{RFCs: []map[string]string{}, Count: &count})
-					} else {
-						c.JSON(http.StatusOK, &models.RFCs{RFCs: results, Count: &count})
-					}
+					// tell the client how stale the response may be
+					c.Header("Age", strconv.Itoa(int(time.Since(retrievedAt).Seconds())))
+					c.Header("Last-Modified", retrievedAt.Format(http.TimeFormat))
+					c.JSON(http.StatusOK, rfcs)
 				}
 			}
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+		malformedRequest(c, err)
 	}
 }
 
@@ -409,36 +1416,1060 @@ func getRfcs(c *gin.Context) {
 // @Response 200 {object} models.RFCContents
 // @Response 400 {object} models.Error
 // @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
 // @Response 500 {object} models.Error
 // @Router /getRfcContents [post]
-// getRfcContents retrieves the body of a given RFC
+// getRfcContents retrieves the body of a given RFC, optionally as of a given revision
 func getRfcContents(c *gin.Context) {
 	request := new(models.GetRfcContents)
 	// ensure the incoming request body conforms to the request model
-	if c.ShouldBindBodyWith(request, binding.JSON) == nil {
-		// <this is a good point to augment logger with request metadata> //
-		// operate as machine for status requests
-		if machineAccessToken, err := config.GetMachineToken(); err != nil {
-			c.JSON(http.StatusInternalServerError, &models.Error{Error: "Configuration error occurred - no machine token"})
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for content requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
 		} else {
 			// establish git clients
-			if github, err := git.NewGitHub(c, *machineAccessToken); err != nil {
-				c.JSON(http.StatusInternalServerError, &models.Error{Error: "Service error occurred - Git machine"})
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
 			} else {
 				// submit status request
-				if contents, err := controllers.GetRfcContents(c, github, request); err != nil {
-					c.JSON(http.StatusInternalServerError, &models.Error{
-						Error: fmt.Sprintf("Error occurred when querying contents for RFC #%v", request.RFCIdentifier)})
+				if contents, err := controllers.GetRfcContents(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when querying contents for RFC #%v",
+						request.RFCIdentifier))
 				} else {
 					if contents == nil {
-						c.JSON(http.StatusOK, &models.RFCContents{Body: ""})
+						c.JSON(http.StatusOK, &models.RFCContents{Body: "", Ref: request.Ref})
+					} else {
+						c.JSON(http.StatusOK, &models.RFCContents{Body: *contents, Ref: request.Ref})
+					}
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description verify the signatures recorded in an RFC file, as of a revision if requested, against its content
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param RFC body models.VerifyRfc true "Query JSON"
+// @Response 200 {object} models.Verification
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 500 {object} models.Error
+// @Router /verifyRfc [post]
+// verifyRfc reports whether the RFC file of a given RFC was tampered with
+func verifyRfc(c *gin.Context) {
+	request := new(models.VerifyRfc)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for verify requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit verify request
+				if verification, err := controllers.VerifyRfc(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when verifying RFC #%v", request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, verification)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get an RFC rendered in Markdown or HTML for human review, its actions grouped by action type and its
+// @description comments threaded under the actions they were made on
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.GetRfcRendered true "Query JSON"
+// @Response 200 {object} models.RFCRendered
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /getRfcRendered [post]
+// getRfcRendered renders a given RFC for human review, optionally as of a given revision
+func getRfcRendered(c *gin.Context) {
+	request := new(models.GetRfcRendered)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for rendering requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit rendering request
+				if rendered, err := controllers.GetRfcRendered(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when rendering RFC #%v", request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, rendered)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get every review submitted on an RFC, oldest first
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.GetReviews true "Query JSON"
+// @Response 200 {object} models.RFCReviews
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /getReviews [post]
+// getReviews retrieves the reviewers of a given RFC and the state of their reviews
+func getReviews(c *gin.Context) {
+	request := new(models.GetReviews)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for review requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit reviews request
+				if reviews, err := controllers.GetReviews(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when querying reviews for RFC #%v",
+						request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, reviews)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the commit history of an RFC file, newest first, with the diff of the RFC JSON at each commit
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.GetRfcHistory true "Query JSON"
+// @Response 200 {object} models.RFCHistory
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /getRfcHistory [post]
+// getRfcHistory retrieves how a given RFC evolved, commit by commit
+func getRfcHistory(c *gin.Context) {
+	request := new(models.GetRfcHistory)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for history requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit history request
+				if history, err := controllers.GetRfcHistory(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when querying history for RFC #%v",
+						request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, history)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description preview the changes an RFC would make to the items its actions target, in each of its load targets
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.Diff true "Query JSON"
+// @Response 200 {object} models.RFCDiff
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 500 {object} models.Error
+// @Router /diffRequest [post]
+// diffRequest computes the before and after state of each item a given RFC changes
+func diffRequest(c *gin.Context) {
+	request := new(models.Diff)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for diff requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit diff request
+				if diff, err := controllers.DiffRequest(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when diffing RFC #%v", request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, diff)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get an RFC action by signature along with its comment thread
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param Query body models.GetAction true "Query JSON"
+// @Response 200 {object} models.ActionThread
+// @Response 400 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Integrity
+// @Response 500 {object} models.Error
+// @Router /getAction [post]
+// getAction resolves an action signature of the given RFC back to the action and its comments
+func getAction(c *gin.Context) {
+	request := new(models.GetAction)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// operate with the read token for action requests, nothing is written
+		if readAccessToken, err := readToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no read token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *readAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git reader")
+			} else {
+				// submit action request
+				if thread, err := controllers.GetAction(c, client, request); err != nil {
+					if errors.Is(err, models.ErrActionNotFound) {
+						c.JSON(http.StatusNotFound, &models.Error{Code: models.ActionNotFoundCode, Error: fmt.Sprintf(
+							"No action with signature %s found in RFC #%v", request.Signature, request.RFCIdentifier)})
 					} else {
-						c.JSON(http.StatusOK, &models.RFCContents{Body: *contents})
+						controllerError(c, err, fmt.Sprintf("Error occurred when querying action for RFC #%v",
+							request.RFCIdentifier))
 					}
+				} else {
+					c.JSON(http.StatusOK, thread)
 				}
 			}
 		}
 	} else {
-		c.JSON(http.StatusBadRequest, &models.Error{Error: "Malformed request received"})
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the reservations of target descriptors that have not expired, oldest first
+// @Tags RFC
+// @Produce json
+// @Response 200 {object} models.Reservations
+// @Response 500 {object} models.Error
+// @Router /getReservations [get]
+// getReservations returns the target descriptors reserved by teams
+func getReservations(c *gin.Context) {
+	if held, err := controllers.GetReservations(c); err != nil {
+		controllerError(c, err, "Error occurred when querying reservations")
+	} else {
+		c.JSON(http.StatusOK, held)
+	}
+}
+
+// @description release the reservation of a target descriptor, only members of the team holding it can release it
+// @Tags RFC
+// @Accept json
+// @Produce json
+// @Param ReleaseReservation body models.ReleaseReservation true "Release JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /releaseReservation [post]
+// releaseReservation handles releasing the reservation of a target descriptor
+func releaseReservation(c *gin.Context) {
+	request := new(models.ReleaseReservation)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err != nil {
+		malformedRequest(c, err)
+	} else {
+		// initialize params for controller
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git")
+			} else {
+				// release the reservation
+				if message, err := controllers.ReleaseReservation(c, client, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when releasing reservation of %s",
+						request.TargetDescriptor))
+				} else {
+					c.JSON(http.StatusOK, &models.Success{Success: *message})
+				}
+			}
+		}
+	}
+}
+
+// @description get a feed of recent RFC activity
+// @Tags Activity
+// @Accept json
+// @Produce json
+// @Param Query body models.Activity true "Query JSON"
+// @Response 200 {object} models.ActivityFeed
+// @Response 400 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /activity [post]
+// activity returns a chronological feed of recent RFC events (submissions, reviews, loads, merges)
+func activity(c *gin.Context) {
+	request := new(models.Activity)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// operate as machine for team lookups
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.New(c, settings.GitProvider(), *machineAccessToken); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// submit activity request
+				if feed, err := controllers.GetActivity(c, client, request); err != nil {
+					controllerError(c, err, "Error occurred when retrieving activity")
+				} else {
+					c.JSON(http.StatusOK, &models.ActivityFeed{Events: feed, Count: len(feed)})
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the open RFCs that require the attention of the authenticated user
+// @Tags Activity
+// @Produce json
+// @Param domain query string false "schema domain whose tracking repository is searched, the default one if omitted"
+// @Response 200 {object} models.MyWork
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /myWork [get]
+// myWork returns the RFCs the authenticated user authored that need changes or failed to load, and the RFCs
+// awaiting their review
+func myWork(c *gin.Context) {
+	// operate as the user so their reviews and teams are resolved
+	if accessToken, err := userToken(c); err != nil {
+		controllerError(c, err, "Authentication error occurred - no token")
+	} else {
+		// establish git client
+		if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, c.Query("domain")); err != nil {
+			gitClientError(c, err, "Service error occurred - Git")
+		} else {
+			// submit work request
+			if work, err := controllers.MyWork(c, client); err != nil {
+				controllerError(c, err, "Error occurred when retrieving work")
+			} else {
+				c.JSON(http.StatusOK, work)
+			}
+		}
+	}
+}
+
+// @description stream RFC lifecycle events (submissions, updates, reviews, loads, merges...) as Server-Sent Events
+// @description as they are published, so clients follow the progress of RFCs without polling their status. Each
+// @description event is sent under its type, idle streams are sent a comment as a heartbeat
+// @Tags Activity
+// @Produce text/event-stream
+// @Param rfcIdentifier query string false "RFC whose events are streamed, every RFC if omitted"
+// @Param type query []string false "types of the events streamed, every type if omitted" collectionFormat(multi)
+// @Response 200 {object} models.Event
+// @Router /events [get]
+// streamEvents streams the RFC lifecycle events matching the query until the client disconnects
+func streamEvents(c *gin.Context) {
+	var rfcIdentifier *string
+	if value, ok := c.GetQuery("rfcIdentifier"); ok {
+		rfcIdentifier = &value
+		metadata.SetRFCIdentifier(c, value)
+	}
+	types := []models.EventType{}
+	for _, value := range c.QueryArray("type") {
+		types = append(types, models.EventType(value))
+	}
+
+	stream := controllers.StreamEvents(c, rfcIdentifier, types)
+	heartbeat := time.NewTicker(controllers.EVENT_STREAM_HEARTBEAT)
+	defer heartbeat.Stop()
+	// keep proxies from buffering the stream
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-stream:
+			if ok {
+				c.SSEvent(string(event.Type), event)
+			}
+			return ok
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
+
+// @description rebuild a missing or corrupted RFC file from its pull request commit history
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Rebuild body models.Rebuild true "Rebuild JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/rebuildRfc [post]
+// rebuildRfc restores the RFC file of the given RFC from the most recent valid revision in its commit history
+func rebuildRfc(c *gin.Context) {
+	rebuild := new(models.Rebuild)
+	// ensure the incoming request body conforms to the Rebuild model
+	if err := bindJSON(c, rebuild); err == nil {
+		metadata.SetRFCIdentifier(c, rebuild.RFCIdentifier)
+		// all admin work to be performed by machine client
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, rebuild.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// submit rebuild request
+				if message, err := controllers.RebuildRequest(c, client, rebuild); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when rebuilding RFC #%v", rebuild.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, &models.Success{Success: *message})
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the comments held for moderation by the comment filters, oldest first
+// @Tags Admin
+// @Produce json
+// @Response 200 {object} models.HeldComments
+// @Response 403 {object} models.Error
+// @Router /admin/heldComments [get]
+// getHeldComments returns the comments held for moderation
+func getHeldComments(c *gin.Context) {
+	c.JSON(http.StatusOK, controllers.GetHeldComments())
+}
+
+// @description get the notifications whose delivery was given up on after every retry, oldest first
+// @Tags Admin
+// @Produce json
+// @Response 200 {object} models.DeadLetters
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/deadLetters [get]
+// getDeadLetters returns the notifications whose delivery was given up on
+func getDeadLetters(c *gin.Context) {
+	if letters, err := controllers.GetDeadLetters(c); err != nil {
+		controllerError(c, err, "Error occurred when querying dead letters")
+	} else {
+		c.JSON(http.StatusOK, letters)
+	}
+}
+
+// @description deliver a notification whose delivery was given up on again, or discard it
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param RedeliverDeadLetter body models.RedeliverDeadLetter true "Redelivery Decision JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/redeliverDeadLetter [post]
+// redeliverDeadLetter delivers the given dead letter again or discards it
+func redeliverDeadLetter(c *gin.Context) {
+	request := new(models.RedeliverDeadLetter)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		if message, err := controllers.RedeliverDeadLetter(c, request); err != nil {
+			controllerError(c, err, fmt.Sprintf("Error occurred when redelivering dead letter %s", request.ID))
+		} else {
+			c.JSON(http.StatusOK, &models.Success{Success: *message})
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description approve a comment held for moderation, adding it to its RFC, or discard it
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param ModerateComment body models.ModerateComment true "Moderation Decision JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/moderateComment [post]
+// moderateComment adds the given held comment to its RFC or discards it
+func moderateComment(c *gin.Context) {
+	request := new(models.ModerateComment)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// the RFC of the comment is found in the domain it was made in
+		if held, err := controllers.GetHeldComment(request.ID); err != nil {
+			controllerError(c, err, fmt.Sprintf("Error occurred when moderating comment %s", request.ID))
+		} else {
+			// all admin work to be performed by machine client
+			if machineAccessToken, err := machineToken(c); err != nil {
+				configurationError(c, "Configuration error occurred - no machine token")
+			} else {
+				// establish git clients
+				if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, held.Domain); err != nil {
+					gitClientError(c, err, "Service error occurred - Git machine")
+				} else {
+					// moderate comment
+					if message, err := controllers.ModerateComment(c, client, request); err != nil {
+						controllerError(c, err, fmt.Sprintf("Error occurred when moderating comment %s", request.ID))
+					} else {
+						c.JSON(http.StatusOK, &models.Success{Success: *message})
+					}
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description sign every open RFC signed before volatile fields were left out of signatures again, and report the
+// @description open RFCs whose signature is invalid
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param MigrateSignatures body models.MigrateSignatures true "Signature Migration JSON"
+// @Response 200 {object} models.SignatureMigration
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/migrateSignatures [post]
+// migrateSignatures checks the signature of every open RFC, signing the RFCs with legacy signatures again
+func migrateSignatures(c *gin.Context) {
+	request := new(models.MigrateSignatures)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// all admin work to be performed by machine client
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// migrate signatures
+				if migration, err := controllers.MigrateSignatures(c, client, request); err != nil {
+					controllerError(c, err, "Error occurred when migrating RFC signatures")
+				} else {
+					c.JSON(http.StatusOK, migration)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description export the open RFCs of a tracking repository, along with their pull requests, its action data schemas
+// @description and its archive index, to rebuild them with /admin/restore should the repository be lost
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param CreateBackup body models.CreateBackup true "Backup JSON"
+// @Response 200 {object} models.Backup
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/backup [post]
+// backup exports the state of a tracking repository as an attachment
+func backup(c *gin.Context) {
+	request := new(models.CreateBackup)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// all admin work to be performed by machine client
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// export the backup
+				if backup, err := controllers.Backup(c, client); err != nil {
+					controllerError(c, err, "Error occurred when backing up the tracking repository")
+				} else {
+					c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"harmonia-backup-%s.json\"",
+						backup.CreatedAt.Format("20060102T150405Z")))
+					c.JSON(http.StatusOK, backup)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description rebuild the branch, RFC file and pull request of each open RFC of a backup exported by /admin/backup in
+// @description a tracking repository, typically a new one replacing a lost tracking repository
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param RestoreBackup body models.RestoreBackup true "Restore JSON"
+// @Response 200 {object} models.Restoration
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/restore [post]
+// restore rebuilds the open RFCs of a backup in a tracking repository
+func restore(c *gin.Context) {
+	request := new(models.RestoreBackup)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// all admin work to be performed by machine client
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// restore the backup
+				if restoration, err := controllers.Restore(c, client, request); err != nil {
+					controllerError(c, err, "Error occurred when restoring the backup")
+				} else {
+					c.JSON(http.StatusOK, restoration)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description render the action data schemas of a tracking repository into Go structs and TypeScript interfaces and
+// @description commit them to the codegen repository, as is done after each merge
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param GenerateTypes body models.GenerateTypes true "Generate types JSON"
+// @Response 200 {object} models.GeneratedTypes
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/generateTypes [post]
+// generateTypes generates types from the action data schemas of a tracking repository
+func generateTypes(c *gin.Context) {
+	request := new(models.GenerateTypes)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// all admin work to be performed by machine client, the codegen repository is written to with the machine token
+		// of the default tracking repository
+		if codegen.Repository == "" || schemas.Directory == "" {
+			configurationError(c, "Configuration error occurred - no codegen repository or schema directory")
+		} else if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else if outputAccessToken, err := domainMachineToken(c, ""); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else if output, err := git.NewForRepository(c, settings.GitProvider(), *outputAccessToken,
+				codegen.Repository); err != nil {
+				gitClientError(c, err, "Service error occurred - Git codegen")
+			} else {
+				// generate the types
+				if generated, err := controllers.GenerateTypes(c, client, output, request); err != nil {
+					controllerError(c, err, "Error occurred when generating types")
+				} else {
+					c.JSON(http.StatusOK, generated)
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description send a sample notification to verify templates and channel configuration
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param TestNotification body models.TestNotification true "Test notification JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /admin/testNotification [post]
+// testNotification renders a sample event with the configured templates and delivers it on the requested channel
+func testNotification(c *gin.Context) {
+	request := new(models.TestNotification)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		// all admin work to be performed by machine client
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git clients
+			if client, err := git.New(c, settings.GitProvider(), *machineAccessToken); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// submit test notification
+				if body, err := controllers.TestNotification(c, client, request); err != nil {
+					if errors.Is(err, notify.ErrUnknownChannel) {
+						c.JSON(http.StatusBadRequest, &models.Error{Code: models.UnknownChannelCode, Error: fmt.Sprintf(
+							"Unknown channel '%s', configured channels: %s", request.Channel,
+							strings.Join(notify.Default.Channels(), ", "))})
+					} else {
+						c.JSON(http.StatusInternalServerError, &models.Error{Code: models.InternalErrorCode, Error: fmt.Sprintf(
+							"Error occurred when sending test notification: %s", err.Error())})
+					}
+				} else {
+					c.JSON(http.StatusOK, &models.Success{Success: *body})
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description seed the tracking repository of a local stack with one sample RFC in each requested state: open,
+// @description approved, failed_load and merged by default. Approvals are submitted with the user token, which must
+// @description not be the machine token. Only available on local stacks
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param Seed body models.Seed true "Seed JSON"
+// @Response 200 {object} models.Seeded
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /admin/seed [post]
+// seed submits sample RFCs and brings each to its requested state
+func seed(c *gin.Context) {
+	request := new(models.Seed)
+	// seeding would litter shared tracking repositories, it is only available locally
+	if !settings.IsLocal() {
+		c.JSON(http.StatusNotFound, &models.Error{Code: models.NotFoundCode,
+			Error: "Seeding is only available on local stacks"})
+	} else if err := bindJSON(c, request); err != nil {
+		malformedRequest(c, err)
+	} else {
+		// the machine submits the samples and the user approves them, as nobody may approve their own pull request
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			if machineAccessToken, err := machineToken(c); err != nil {
+				configurationError(c, "Configuration error occurred - no machine token")
+			} else {
+				// establish git clients
+				if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, request.Domain); err != nil {
+					gitClientError(c, err, "Service error occurred - Git")
+				} else {
+					machineClient, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain)
+					if err != nil {
+						gitClientError(c, err, "Service error occurred - Git machine")
+					} else {
+						// seed sample RFCs
+						if seeded, err := controllers.Seed(c, machineClient, client, request); err != nil {
+							controllerError(c, err, "Error occurred when seeding sample RFCs")
+						} else {
+							c.JSON(http.StatusOK, seeded)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// @description approve or reject an RFC load awaiting a manual load gate
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param ApproveLoad body models.ApproveLoad true "Load Gate Decision JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 409 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /admin/approveLoad [post]
+// approveLoad records the decision of the user on a manually gated load, approved loads are then executed
+func approveLoad(c *gin.Context) {
+	approval := new(models.ApproveLoad)
+	// ensure the incoming request body conforms to the ApproveLoad model
+	if err := bindJSON(c, approval); err == nil {
+		metadata.SetRFCIdentifier(c, approval.RFCIdentifier)
+		// the decision is attributed to the user, while the load is performed by the machine
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			if machineAccessToken, err := machineToken(c); err != nil {
+				configurationError(c, "Configuration error occurred - no machine token")
+			} else {
+				// establish git clients
+				if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, approval.Domain); err != nil {
+					gitClientError(c, err, "Service error occurred - Git")
+				} else {
+					machineClient, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, approval.Domain)
+					if err != nil {
+						gitClientError(c, err, "Service error occurred - Git machine")
+					} else {
+						// submit load gate decision
+						if message, err := controllers.ApproveLoad(c, client, machineClient, approval); err != nil {
+							if errors.Is(err, models.ErrNoPendingGate) {
+								c.JSON(http.StatusConflict, &models.Error{Code: models.NoPendingGateCode, Error: fmt.Sprintf(
+									"RFC #%v has no load awaiting manual approval", approval.RFCIdentifier)})
+							} else {
+								controllerError(c, err, fmt.Sprintf("Error occurred when deciding load of RFC #%v",
+									approval.RFCIdentifier))
+							}
+						} else {
+							c.JSON(http.StatusOK, &models.Success{Success: *message})
+						}
+					}
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description force an RFC live during an incident, bypassing its reviews, embargo, load gate and the merge policy
+// @description only the admins listed in BREAK_GLASS_ADMINS may break glass, the justification is recorded in the RFC
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param BreakGlass body models.BreakGlass true "Break-glass JSON"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 500 {object} models.Error
+// @Security BearerAuth
+// @Router /admin/breakGlass [post]
+// breakGlass records the justification of the admin and forces the load and merge of the RFC
+func breakGlass(c *gin.Context) {
+	request := new(models.BreakGlass)
+	// ensure the incoming request body conforms to the BreakGlass model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// the break-glass is attributed to the user, while the load and merge are performed by the machine
+		if accessToken, err := userToken(c); err != nil {
+			controllerError(c, err, "Authentication error occurred - no token")
+		} else {
+			if machineAccessToken, err := machineToken(c); err != nil {
+				configurationError(c, "Configuration error occurred - no machine token")
+			} else {
+				// establish git clients
+				if client, err := git.NewForDomain(c, settings.GitProvider(), *accessToken, request.Domain); err != nil {
+					gitClientError(c, err, "Service error occurred - Git")
+				} else {
+					machineClient, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain)
+					if err != nil {
+						gitClientError(c, err, "Service error occurred - Git machine")
+					} else {
+						// submit break-glass
+						if message, err := controllers.BreakGlass(c, client, machineClient, request); err != nil {
+							controllerError(c, err, fmt.Sprintf("Error occurred when breaking glass on RFC #%v",
+								request.RFCIdentifier))
+						} else {
+							c.JSON(http.StatusOK, &models.Success{Success: *message})
+						}
+					}
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description register the approval or rejection of an RFC by an external system, e.g. a change advisory board tool
+// @description the approval policy counts it alongside Git reviews, and a rejection blocks the merge of the RFC
+// @Tags External
+// @Accept json
+// @Produce json
+// @Param ExternalApproval body models.ExternalApproval true "Verdict JSON"
+// @Param X-Harmonia-System header string true "Name of the external system"
+// @Param X-Harmonia-Timestamp header string true "Unix time the request was signed at"
+// @Param X-Harmonia-Nonce header string true "Single use nonce"
+// @Param X-Harmonia-Signature header string true "HMAC-SHA256 signature keyed with the secret of the system"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 403 {object} models.Error
+// @Response 404 {object} models.Error
+// @Response 409 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /externalApproval [post]
+// externalApproval records the verdict of the external system on the RFC, as the machine
+func externalApproval(c *gin.Context) {
+	request := new(models.ExternalApproval)
+	// ensure the incoming request body conforms to the ExternalApproval model
+	if err := bindJSON(c, request); err == nil {
+		metadata.SetRFCIdentifier(c, request.RFCIdentifier)
+		// external systems have no Git identity, their verdicts are recorded by the machine
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git client
+			if client, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, request.Domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				// the system header was verified along with the signature of the request
+				system := c.GetHeader(signing.SYSTEM_HEADER)
+				if message, err := controllers.RecordExternalVerdict(c, client, system, request); err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when recording the verdict on RFC #%v",
+						request.RFCIdentifier))
+				} else {
+					c.JSON(http.StatusOK, &models.Success{Success: *message})
+				}
+			}
+		}
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description get the state of the maintenance mode
+// @Tags Admin
+// @Produce json
+// @Response 200 {object} models.Maintenance
+// @Router /admin/maintenance [get]
+// getMaintenance returns whether maintenance mode is enabled, and if so since when and with which message
+func getMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, maintenance.Default.Status())
+}
+
+// @description enable or disable the maintenance mode, during which mutating operations (submit, update, review, load,
+// @description merge) are rejected with a 503 while read operations keep working
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param SetMaintenance body models.SetMaintenance true "Maintenance JSON"
+// @Response 200 {object} models.Maintenance
+// @Response 400 {object} models.Error
+// @Response 403 {object} models.Error
+// @Router /admin/maintenance [post]
+// setMaintenance switches the maintenance mode on or off
+func setMaintenance(c *gin.Context) {
+	request := new(models.SetMaintenance)
+	// ensure the incoming request body conforms to the request model
+	if err := bindJSON(c, request); err == nil {
+		if *request.Enabled {
+			maintenance.Default.Enable(request.Message)
+			logging.FromContext(c).Warn("maintenance mode enabled, mutating operations are rejected", "clientIp", c.ClientIP())
+		} else {
+			maintenance.Default.Disable()
+			logging.FromContext(c).Warn("maintenance mode disabled", "clientIp", c.ClientIP())
+		}
+		c.JSON(http.StatusOK, maintenance.Default.Status())
+	} else {
+		malformedRequest(c, err)
+	}
+}
+
+// @description receive a GitHub webhook delivery of the tracking repository, signed with the webhook secret. Review,
+// @description pull request, status and check events wake pending mergeability checks and drop the cached state of
+// @description their RFCs, and approvals load the RFC if loading on approval is enabled. Other events are ignored
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param X-GitHub-Event header string true "Type of the event"
+// @Param X-GitHub-Delivery header string true "Unique ID of the delivery"
+// @Param X-Hub-Signature-256 header string true "HMAC-SHA256 signature of the payload"
+// @Response 200 {object} models.Success
+// @Response 400 {object} models.Error
+// @Response 401 {object} models.Error
+// @Response 409 {object} models.Error
+// @Response 500 {object} models.Error
+// @Router /webhooks/github [post]
+// githubWebhook hands the event of a GitHub webhook delivery to the controller, as the machine
+func githubWebhook(c *gin.Context) {
+	eventType := c.GetHeader(signing.GITHUB_EVENT_HEADER)
+	if payload, err := c.GetRawData(); err != nil {
+		malformedRequest(c, err)
+	} else if event, err := git.ParseGitHubWebhook(eventType, payload); err != nil {
+		malformedRequest(c, err)
+	} else if event == nil {
+		c.JSON(http.StatusOK, &models.Success{Success: fmt.Sprintf("Ignored %s event", eventType)})
+	} else if domain, err := git.DomainOf(event.Owner, event.Repository); err != nil {
+		gitClientError(c, err, "Service error occurred - Git")
+	} else {
+		metadata.SetTenant(c, domain)
+		// initialize params for controller
+		if machineAccessToken, err := machineToken(c); err != nil {
+			configurationError(c, "Configuration error occurred - no machine token")
+		} else {
+			// establish git client
+			if machineClient, err := git.NewForDomain(c, settings.GitProvider(), *machineAccessToken, domain); err != nil {
+				gitClientError(c, err, "Service error occurred - Git machine")
+			} else {
+				message, err := controllers.HandleWebhookEvent(c, machineClient, event, settings.IsWebhookLoadOnApproval())
+				if err != nil {
+					controllerError(c, err, fmt.Sprintf("Error occurred when handling %s event", eventType))
+				} else {
+					c.JSON(http.StatusOK, &models.Success{Success: *message})
+				}
+			}
+		}
 	}
 }
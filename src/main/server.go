@@ -4,10 +4,52 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"go/token"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"harmonia-example.io/src/controllers"
 	"harmonia-example.io/src/main/docs"
 	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/assignment"
+	"harmonia-example.io/src/services/auth"
+	"harmonia-example.io/src/services/authz"
+	"harmonia-example.io/src/services/codegen"
+	"harmonia-example.io/src/services/config"
+	"harmonia-example.io/src/services/description"
+	"harmonia-example.io/src/services/directory"
+	"harmonia-example.io/src/services/events"
+	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/jobs"
+	"harmonia-example.io/src/services/leader"
+	"harmonia-example.io/src/services/loader"
+	"harmonia-example.io/src/services/loadstatus"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/maintenance"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/moderation"
+	"harmonia-example.io/src/services/naming"
+	"harmonia-example.io/src/services/notify"
+	"harmonia-example.io/src/services/oidc"
+	"harmonia-example.io/src/services/ownership"
+	"harmonia-example.io/src/services/quorum"
+	"harmonia-example.io/src/services/reservations"
+	"harmonia-example.io/src/services/reviewrequests"
+	"harmonia-example.io/src/services/schedule"
+	"harmonia-example.io/src/services/schemas"
+	"harmonia-example.io/src/services/set"
+	"harmonia-example.io/src/services/signing"
+	"harmonia-example.io/src/services/tenants"
+	"harmonia-example.io/src/services/tracing"
+	"harmonia-example.io/src/services/triage"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +57,10 @@ import (
 // harmoniaVersion is passed in from build and is used for swagger display
 var harmoniaVersion string
 
+// settings is the configuration the application is wired with and reloads on SIGHUP, see configureReload. It is handed
+// to the Git implementations, and the handlers read the settings they need from it
+var settings = config.Default
+
 // @title Harmonia
 // @description Harmonia is a service for processing and accepting requests for schema changes
 
@@ -26,23 +72,208 @@ var harmoniaVersion string
 
 // @schemes https http
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description access token of the user the request is made on behalf of, as "Bearer <token>"
+
 // main handles initializing the application and ultimately serving it
 func main() {
+	// check the configuration file and reload the configuration on SIGHUP
+	configureReload()
+
+	// log in the configured format and level
+	configureLogging()
+
+	// trace requests with the configured exporter and sampling
+	configureTracing()
+
 	// initialize the gin engine
-	engine := gin.Default()
+	engine := newEngine()
 
 	// < this is a good place to bind middleware > //
+	// time every request, identify, trace and give it a logger of its own, wrap responses in an envelope when clients
+	// ask for it, respond to the errors of handlers and read the access token of the user making it and its tenant
+	engine.Use(observeDuration, assignRequestID, traceRequest, injectLogger, envelopeResponse, respondToErrors,
+		extractUserToken, selectTenant)
 
 	// configure dynamic swagger documentation
 	configureSwagger(harmoniaVersion)
 
+	// register deployment specific review intents
+	configureReviewTypes()
+
+	// allow the configured analyzers to annotate RFC actions
+	configureAnalyzers()
+
+	// allow the configured admins to break glass
+	configureBreakGlass()
+
+	// check comments with the configured comment filters, if any
+	configureCommentFilters()
+
+	// select the Git provider hosting the tracking repository
+	configureGitProvider()
+
+	// inject faults into the calls made to the Git provider, if configured outside of production
+	configureGitFaults()
+
+	// authenticate the machine identity as a GitHub App installation, if an app is configured
+	configureGitHubApp()
+
+	// poll the mergeability of pull requests with the configured backoff
+	configureMergeabilityPolling()
+
+	// name the pull requests of RFCs with the configured templates, if any
+	configurePullRequestTitles()
+
+	// label, assign and request reviews of the pull requests of RFCs as they are opened, if a triage policy is configured
+	configurePullRequestTriage()
+
+	// describe the pull requests of RFCs with the configured template, if any
+	configurePullRequestDescriptions()
+
+	// validate action data against the schemas of the tracking repositories, if a schema directory is configured
+	configureSchemas()
+
+	// commit types generated from the action data schemas to the codegen repository after each merge, if configured
+	configureCodegen()
+
+	// keep the reservations of target descriptors in the configured file, if any, for the configured duration
+	configureReservations()
+
+	// resolve Git logins to the people behind them, if a directory is configured
+	configureDirectory()
+
+	// deliver RFC lifecycle events on the configured notification channels
+	configureNotifications()
+
+	// load the teams that own each target
+	configureOwnership()
+
+	// register the datastores RFCs are loaded into
+	configureLoadTargets()
+
+	// isolate the credentials, load targets and notification channels of the configured tenants, if any
+	configureTenants()
+
+	// gate loads behind an approval, if required for the backing datastore
+	configureLoadGate()
+
+	// name the environment the template variables of RFCs are expanded for
+	configureEnvironment()
+
+	// campaign for leadership of the deployment on the configured lock, if any
+	configureLeaderElection()
+
+	// run the asynchronous work of requests on the configured job queue
+	configureJobs()
+
+	// record load statuses in the configured store
+	configureLoadStatus()
+
+	// assign a reviewer from each owning team to new RFCs, if enabled
+	configureReviewerAssignment()
+
+	// track review requests against the configured review SLA
+	configureReviewRequests()
+
+	// send daily digests, if enabled
+	scheduleDigests()
+
+	// compare the branch protection of the tracking repositories to the expected protection periodically
+	scheduleProtectionChecks()
+
+	// archive RFCs merged longer ago than the retention period daily, if enabled
+	scheduleRetention()
+
+	// remind reviewers of the review requests they left unanswered past the review SLA, if enabled
+	scheduleReviewReminders()
+
+	// start in maintenance mode, if requested
+	configureMaintenance()
+
+	// require signed requests on state changing routes, if enabled
+	configureRequestSigning()
+
+	// sign the receipts returned by submissions, if a receipt key is configured
+	configureReceiptSigning()
+	configureAuthorSignatures()
+
+	// authenticate users through the configured identity provider, if SSO is enabled
+	configureSSO()
+
+	// authorize the user against the configured authorization policy, if any
+	configureAuthorization()
+
+	// accept the verdicts of the configured external systems, counted by the approval policy, if any
+	configureExternalSystems()
+
+	// require the configured quorums of approvals before RFCs are merged, if any
+	configureApprovalPolicy()
+
+	// expose operational metrics
+	configureMetrics()
+
+	// build clients, validate tokens and tracking repositories and prime caches before reporting ready
+	warmUp()
+
 	// create routes for app
 	bindRoutes(engine, GetRoutes())
 
+	// serve the gRPC API alongside the REST routes, if a port is configured
+	serveGRPC()
+
 	// run application
 	engine.Run(":8080")
 }
 
+// newEngine initializes the gin engine in the configured mode, trusting only the configured proxies to report the
+// client IP through forwarded headers so it cannot be spoofed by clients
+// Misconfiguration is fatal so the service never silently trusts every proxy
+func newEngine() *gin.Engine {
+	gin.SetMode(settings.GinMode())
+	engine := gin.Default()
+	// handlers pass the gin context to controllers, it must carry the logger and span of the request context
+	engine.ContextWithFallback = true
+
+	proxies, err := settings.TrustedProxies()
+	if err != nil {
+		panic(err)
+	}
+	if err = engine.SetTrustedProxies(proxies); err != nil {
+		panic(err)
+	}
+	engine.RemoteIPHeaders = settings.RemoteIPHeaders()
+	engine.ForwardedByClientIP = len(engine.RemoteIPHeaders) > 0
+
+	return engine
+}
+
+// configureLogging replaces the default logger with one logging in the configured format and level to stdout
+// Misconfiguration is fatal so records are never silently dropped or malformed
+func configureLogging() {
+	logger, err := logging.New(os.Stdout, settings.LogFormat(), settings.LogLevel())
+	if err != nil {
+		panic(err)
+	}
+	logging.Default = logger
+}
+
+// configureTracing registers a tracer provider exporting spans with the configured exporter
+// Misconfiguration is fatal so traces are never silently dropped
+func configureTracing() {
+	ratio, err := settings.TraceSampleRatio()
+	if err != nil {
+		panic(err)
+	}
+	provider, err := tracing.NewProvider(settings.TraceExporter(), ratio, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	tracing.Register(provider)
+}
+
 // configureSwagger sets dynamic swagger configuration that is version/environment dependent
 func configureSwagger(ver string) {
 	// set display version (this is what is listed at the top of the swagger page)
@@ -52,18 +283,1069 @@ func configureSwagger(ver string) {
 
 }
 
+// configureReviewTypes registers the custom review intents defined in configuration
+// Misconfiguration is fatal so that reviews are never submitted with an unexpected provider type
+func configureReviewTypes() {
+	reviewTypes, err := settings.CustomReviewTypes()
+	if err != nil {
+		panic(err)
+	}
+	for intent, base := range reviewTypes {
+		if err = models.RegisterReviewType(models.ReviewType(intent), models.ReviewType(base)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// configureAnalyzers allows the automated analyzers defined in configuration to annotate RFC actions, they
+// authenticate as external systems, see configureExternalSystems
+func configureAnalyzers() {
+	secrets, err := settings.Analyzers()
+	if err != nil {
+		panic(err)
+	}
+	for analyzer := range secrets {
+		models.RegisterAnalyzer(analyzer)
+	}
+}
+
+// configureBreakGlass allows the configured admins to force RFCs live bypassing policy
+func configureBreakGlass() {
+	for _, admin := range settings.BreakGlassAdmins() {
+		models.RegisterBreakGlassAdmin(admin)
+	}
+}
+
+// configureCommentFilters builds the moderator comments are checked by from the configured blocked words, maximum
+// length and rate limit. Comments are not filtered if none is configured, a malformed setting is fatal
+func configureCommentFilters() {
+	filters := []moderation.Filter{}
+	if words := settings.CommentBlockedWords(); len(words) > 0 {
+		filters = append(filters, moderation.NewWordList(words))
+	}
+	length, err := settings.CommentMaxLength()
+	if err != nil {
+		panic(err)
+	}
+	if length != nil {
+		filters = append(filters, moderation.NewSizeCap(*length))
+	}
+	limit, err := settings.CommentRateLimit()
+	if err != nil {
+		panic(err)
+	}
+	window, err := settings.CommentRateWindow()
+	if err != nil {
+		panic(err)
+	}
+	if limit != nil {
+		rateWindow := moderation.DEFAULT_RATE_WINDOW
+		if window != nil {
+			rateWindow = *window
+		}
+		filters = append(filters, moderation.NewRateLimit(*limit, rateWindow))
+	}
+	if len(filters) == 0 {
+		return
+	}
+
+	moderator, err := moderation.NewModerator(settings.CommentFilterAction(), filters...)
+	if err != nil {
+		panic(err)
+	}
+	moderation.Default = moderator
+}
+
+// configureReload reloads the configuration whenever the process receives a SIGHUP, from its configuration file since
+// the environment of the process does not change. Only the settings read on every request, such as the tokens and the
+// tracking repositories, take effect, the others require a restart. A configuration file that cannot be read is fatal
+// at startup, while a reload failing keeps the settings the application runs with
+func configureReload() {
+	if err := settings.Reload(); err != nil {
+		panic(err)
+	}
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := settings.Reload(); err != nil {
+				logging.Default.Error("unable to reload configuration", logging.ERROR_KEY, err)
+				continue
+			}
+			logging.Default.Info("configuration reloaded")
+		}
+	}()
+}
+
+// configureGitProvider gives the Git implementations the configuration of the application and ensures the configured
+// Git provider is registered and the tracking repository of each schema domain and its owner are configured, an unknown
+// provider or repository is fatal
+// Bitbucket has no deployment environments, so it cannot gate loads through deployments
+func configureGitProvider() {
+	git.Configure(settings)
+	provider := settings.GitProvider()
+	if !git.IsRegistered(provider) {
+		panic(fmt.Errorf("%w: %s, expected one of %s", git.ErrUnknownProvider, provider,
+			strings.Join(git.Providers(), ", ")))
+	}
+	if gate := settings.LoadGate(); provider == git.BITBUCKET_PROVIDER && gate != nil &&
+		models.GateType(*gate) == models.DeploymentGate {
+		panic(fmt.Errorf("%s load gates are not supported by %s", models.DeploymentGate, provider))
+	}
+	for _, domain := range trackingDomains() {
+		if _, err := git.ConfiguredRepository(domain); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// configureGitFaults ensures faults are only injected into the calls made to the Git provider outside of production,
+// where they are logged as they make Harmonia fail on purpose. Malformed faults or faults configured in production are
+// fatal
+func configureGitFaults() {
+	faults, err := git.ConfiguredFaults()
+	if err != nil {
+		panic(err)
+	} else if faults != nil {
+		logging.Default.Warn("injecting faults into Git calls", "errorRate", faults.ErrorRate, "latency",
+			faults.Latency.String(), "methods", faults.Methods)
+	}
+}
+
+// configureGitHubApp authenticates the machine identity as the configured installation of a GitHub App rather than
+// with GIT_MACHINE_TOKEN, if an app is configured. A partially configured app or a malformed private key is fatal
+func configureGitHubApp() {
+	id := settings.GitHubAppID()
+	if id == nil {
+		return
+	}
+	if provider := settings.GitProvider(); provider != git.GITHUB_PROVIDER {
+		panic(fmt.Errorf("GitHub App authentication is not supported by %s", provider))
+	}
+	installationID, err := settings.GitHubAppInstallationID()
+	if err != nil {
+		panic(err)
+	}
+	keyFile, err := settings.GitHubAppPrivateKeyFile()
+	if err != nil {
+		panic(err)
+	}
+	key, err := os.ReadFile(*keyFile)
+	if err != nil {
+		panic(fmt.Errorf("unable to read GitHub App private key file %s: %w", *keyFile, err))
+	}
+	app, err := auth.NewApp(*id, *installationID, key)
+	if err != nil {
+		panic(err)
+	}
+	auth.Default = app
+}
+
+// configureMergeabilityPolling overrides the default polling of status checks and mergeable states being computed by
+// the Git provider with the configured attempts and waits. Misconfiguration is fatal
+func configureMergeabilityPolling() {
+	attempts, err := settings.MergeabilityAttempts()
+	if err != nil {
+		panic(err)
+	}
+	if attempts != nil {
+		git.Polling.Attempts = *attempts
+	}
+	wait, err := settings.MergeabilityWait()
+	if err != nil {
+		panic(err)
+	}
+	if wait != nil {
+		git.Polling.Wait = *wait
+	}
+	maxWait, err := settings.MergeabilityMaxWait()
+	if err != nil {
+		panic(err)
+	}
+	if maxWait != nil {
+		git.Polling.MaxWait = *maxWait
+	}
+	if git.Polling.MaxWait < git.Polling.Wait {
+		panic(fmt.Errorf("mergeability max wait %s is shorter than the mergeability wait %s", git.Polling.MaxWait,
+			git.Polling.Wait))
+	}
+}
+
+// trackingDomains returns the empty domain, standing for the default tracking repository, followed by each schema
+// domain with a tracking repository of its own, sorted. A malformed domain configuration is fatal
+func trackingDomains() []string {
+	repositories, err := settings.TrackingRepositories()
+	if err != nil {
+		panic(err)
+	}
+
+	domains := make([]string, 0, len(repositories))
+	for domain := range repositories {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	return append([]string{""}, domains...)
+}
+
+// configureDirectory resolves Git logins with the configured directory, served from cache, logins are not resolved if
+// no directory is configured
+// The LDAP directory is a placeholder, register a client of the LDAP server instead. Misconfiguration is fatal
+func configureDirectory() {
+	provider := settings.DirectoryProvider()
+	if provider == nil {
+		return
+	}
+	source := settings.DirectorySource()
+	if source == nil {
+		panic(fmt.Errorf("no source specified for the %s directory", *provider))
+	}
+	ttl, err := settings.DirectoryCacheTTL()
+	if err != nil {
+		panic(err)
+	}
+	if ttl == nil {
+		defaultTTL := directory.DEFAULT_CACHE_TTL
+		ttl = &defaultTTL
+	}
+
+	var users directory.Provider
+	switch *provider {
+	case directory.STATIC_PROVIDER:
+		if users, err = directory.LoadStatic(*source); err != nil {
+			panic(err)
+		}
+	case directory.SCIM_PROVIDER:
+		users = directory.NewSCIM(*source, settings.DirectoryToken())
+	case directory.LDAP_PROVIDER:
+		users = directory.LDAPPlaceholder(*source)
+	default:
+		panic(fmt.Errorf("unknown directory provider %s, expected one of %s, %s or %s", *provider,
+			directory.STATIC_PROVIDER, directory.SCIM_PROVIDER, directory.LDAP_PROVIDER))
+	}
+	directory.Default = directory.NewCached(users, *ttl)
+}
+
+// configureNotifications loads deployment specific notification templates, configures the notification channels and
+// their routing rules and subscribes them to the event bus
+// Invalid templates and routing rules are fatal so that misconfiguration is caught at deploy time rather than when an
+// event is delivered
+func configureNotifications() {
+	templates := notify.NewTemplates()
+	if dir := settings.NotificationTemplatesDir(); dir != nil {
+		if err := templates.LoadDir(*dir); err != nil {
+			panic(err)
+		}
+	}
+
+	// notifications given up on are kept so they can be redelivered
+	var deadLetters notify.DeadLetterStore = notify.NewMemoryDeadLetters()
+	if file := settings.DeadLetterFile(); file != nil {
+		store, err := notify.NewFileDeadLetters(*file)
+		if err != nil {
+			panic(err)
+		}
+		deadLetters = store
+	}
+
+	channels := []notify.Channel{}
+	if url := settings.NotificationWebhookURL(); url != nil {
+		webhook := notify.NewWebhookChannel(*url)
+		webhook.DeadLetters = deadLetters
+		attempts, err := settings.NotificationAttempts()
+		if err != nil {
+			panic(err)
+		}
+		if attempts != nil {
+			webhook.Attempts = *attempts
+		}
+		backoff, err := settings.NotificationBackoff()
+		if err != nil {
+			panic(err)
+		}
+		if backoff != nil {
+			webhook.Backoff = *backoff
+		}
+		channels = append(channels, webhook)
+	}
+	if settings.IsLocal() {
+		channels = append(channels, &notify.LogChannel{})
+	}
+
+	notify.Default = notify.NewNotifier(templates, channels...)
+	notify.Default.SetDirectory(directory.Default)
+	notify.Default.SetDeadLetters(deadLetters)
+	if file := settings.NotificationRoutesFile(); file != nil {
+		router, err := notify.LoadRouter(*file)
+		if err != nil {
+			panic(err)
+		}
+		if err = notify.Default.SetRouter(router); err != nil {
+			panic(err)
+		}
+	}
+	notify.Default.Subscribe(events.Default)
+}
+
+// configurePullRequestTitles names the pull requests of RFCs with the configured template, and the pull requests of
+// the schema domains listed in the configured templates file with their own. A malformed template is fatal
+func configurePullRequestTitles() {
+	var strategy naming.Strategy = naming.Default
+	if text := settings.PullRequestTitleTemplate(); text != nil {
+		template, err := naming.NewTemplate(*text)
+		if err != nil {
+			panic(err)
+		}
+		strategy = template
+	}
+	if file := settings.PullRequestTitleTemplatesFile(); file != nil {
+		tenants, err := naming.LoadTenants(*file, strategy)
+		if err != nil {
+			panic(err)
+		}
+		strategy = tenants
+	}
+	naming.Default = strategy
+}
+
+// configurePullRequestTriage loads the triage policy the pull requests of RFCs are opened with, if any. A malformed
+// policy is fatal
+func configurePullRequestTriage() {
+	if file := settings.PullRequestTriageFile(); file != nil {
+		policy, err := triage.Load(*file)
+		if err != nil {
+			panic(err)
+		}
+		triage.Default = policy
+	}
+}
+
+// configurePullRequestDescriptions loads the template the descriptions of the pull requests of RFCs are rendered
+// with, if any. A malformed template is fatal
+func configurePullRequestDescriptions() {
+	if file := settings.PullRequestDescriptionFile(); file != nil {
+		renderer, err := description.Load(*file)
+		if err != nil {
+			panic(err)
+		}
+		description.Default = renderer
+	}
+}
+
+// configureSchemas sets the directory of the tracking repositories holding the JSON Schemas action data is validated
+// against, if any
+func configureSchemas() {
+	if directory := settings.SchemaDirectory(); directory != nil {
+		schemas.Directory = *directory
+	}
+}
+
+// codegenLock serializes the generation of types, so the commits made to the codegen repository do not race
+var codegenLock sync.Mutex
+
+// configureCodegen generates types from the action data schemas of the tracking repository of each merged RFC and
+// commits them to the configured codegen repository, if any. A codegen repository without a schema directory, or a
+// malformed Go package name, is fatal
+func configureCodegen() {
+	repository := settings.CodegenRepository()
+	if repository == nil {
+		return
+	}
+	if schemas.Directory == "" {
+		panic(fmt.Errorf("types are generated from the action data schemas, SCHEMA_DIRECTORY must be set"))
+	}
+	codegen.Repository = *repository
+	codegen.Directory = settings.CodegenDirectory()
+	if goPackage := settings.CodegenGoPackage(); goPackage != nil {
+		if !token.IsIdentifier(*goPackage) {
+			panic(fmt.Errorf("malformed codegen Go package, expected an identifier: %s", *goPackage))
+		}
+		codegen.GoPackage = *goPackage
+	}
+
+	events.Default.Subscribe(func(event models.Event) {
+		if event.Type != models.MergeEvent {
+			return
+		}
+		domain := ""
+		if event.Subject != nil {
+			domain = event.Subject.Domain
+		}
+		generateMergedTypes(domain, event.RFCIdentifier)
+	})
+}
+
+// generateMergedTypes generates types from the action data schemas of the tracking repository of the given schema
+// domain and commits them to the codegen repository, following the merge of the given RFC. Failures are logged
+func generateMergedTypes(domain string, rfcIdentifier string) {
+	codegenLock.Lock()
+	defer codegenLock.Unlock()
+
+	// all codegen work to be performed by machine client, the codegen repository is written to with the machine token
+	// of the default tracking repository
+	ctx := context.Background()
+	logger := logging.Default.With("domain", domain, logging.RFC_IDENTIFIER_KEY, rfcIdentifier)
+	machineAccessToken, err := domainMachineToken(ctx, domain)
+	if err != nil {
+		logger.Error("unable to generate types", logging.ERROR_KEY, err)
+		return
+	}
+	client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken, domain)
+	if err != nil {
+		logger.Error("unable to generate types", logging.ERROR_KEY, err)
+		return
+	}
+	outputAccessToken, err := domainMachineToken(ctx, "")
+	if err != nil {
+		logger.Error("unable to generate types", logging.ERROR_KEY, err)
+		return
+	}
+	output, err := git.NewForRepository(ctx, settings.GitProvider(), *outputAccessToken, codegen.Repository)
+	if err != nil {
+		logger.Error("unable to generate types", logging.ERROR_KEY, err)
+		return
+	}
+
+	if generated, err := controllers.GenerateTypes(ctx, client, output, &models.GenerateTypes{}); err != nil {
+		logger.Error("unable to generate types", logging.ERROR_KEY, err)
+	} else {
+		for file, failure := range generated.Failures {
+			logger.Warn("unable to render schema file", "file", file, logging.ERROR_KEY, failure)
+		}
+		if generated.Committed {
+			logger.Info("committed generated types", "repository", generated.Repository, "files", generated.Files)
+		}
+	}
+}
+
+// configureReservations keeps the reservations of target descriptors in the configured file, if any, and sets how long
+// they last unless their reserve action sets their expiry
+func configureReservations() {
+	if file := settings.ReservationFile(); file != nil {
+		store, err := reservations.NewFileStore(*file)
+		if err != nil {
+			panic(err)
+		}
+		reservations.Default = store
+	}
+
+	ttl, err := settings.ReservationTTL()
+	if err != nil {
+		panic(err)
+	}
+	if ttl != nil {
+		reservations.TTL = *ttl
+	}
+}
+
+// configureOwnership loads the teams that own each RFC target descriptor from configuration, the rules of the target
+// owners file, if any, being overridden by the mappings of TARGET_OWNERS
+func configureOwnership() {
+	owners, err := settings.TargetOwners()
+	if err != nil {
+		panic(err)
+	}
+	var rules []ownership.Rule
+	if file := settings.TargetOwnersFile(); file != nil {
+		if rules, err = ownership.Load(*file); err != nil {
+			panic(err)
+		}
+	}
+	ownership.Default = ownership.NewWithRules(rules, owners)
+}
+
+// configureLoadTargets registers a loader for each configured load target, replacing the default target, and a shadow
+// loader for each shadowed target. It also sets how many targets are loaded at the same time and whether partially
+// loaded RFCs can be merged
+// Each target is registered with a placeholder, register a client of the target's datastore instead
+// Misconfiguration is fatal so that partially loaded RFCs are never merged by mistake
+func configureLoadTargets() {
+	if targets := settings.LoadTargets(); len(targets) > 0 {
+		registry := loader.NewRegistry()
+		for _, target := range targets {
+			registry.Register(target, loader.Placeholder(target))
+		}
+		loader.Default = registry
+	}
+
+	// shadow loaders only report how their outcome compares to the target's loader, see loader.RegisterShadow
+	for _, target := range settings.ShadowLoadTargets() {
+		if err := loader.Default.RegisterShadow(target, loader.Placeholder(target+" (shadow)")); err != nil {
+			panic(err)
+		}
+	}
+
+	concurrency, err := settings.LoadConcurrency()
+	if err != nil {
+		panic(err)
+	}
+	if concurrency != nil {
+		if err = loader.Default.SetConcurrency(*concurrency); err != nil {
+			panic(err)
+		}
+	}
+	if policy := settings.LoadMergePolicy(); policy != nil {
+		if err = loader.Default.SetMergePolicy(loader.MergePolicy(*policy)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// configureTenants enables the multi-tenant mode with the configured tenants, whose tracking repositories, tokens, load
+// targets and notification channels must all be configured. Misconfiguration is fatal so that tenants never fall back
+// on the credentials or datastores of another schema domain by mistake
+func configureTenants() {
+	file := settings.TenantsFile()
+	if file == nil {
+		return
+	}
+	registry, err := tenants.Load(*file)
+	if err != nil {
+		panic(err)
+	}
+
+	channels := set.NewSetOf(notify.Default.Channels()...)
+	for _, domain := range registry.Domains() {
+		tenant, _ := registry.Get(domain)
+		if _, err = git.ConfiguredRepository(domain); err != nil {
+			panic(fmt.Errorf("tenant %s has no tracking repository: %w", domain, err))
+		}
+		if _, err = settings.TenantToken(tenant.MachineToken); err != nil {
+			panic(fmt.Errorf("tenant %s has no machine token: %w", domain, err))
+		}
+		for _, target := range tenant.LoadTargets {
+			if _, ok := loader.Default.Get(target); !ok {
+				panic(fmt.Errorf("%w: tenant %s loads into %s", models.ErrUnknownLoadTarget, domain, target))
+			}
+		}
+		for _, channel := range tenant.Channels {
+			if !channels.Contains(channel) {
+				panic(fmt.Errorf("%w: tenant %s is notified on '%s'", notify.ErrUnknownChannel, domain, channel))
+			}
+		}
+	}
+
+	tenants.Default = registry
+	notify.Default.SetTenants(registry)
+}
+
+// configureLoadGate gates every load behind the configured approval, loads are not gated if none is configured
+// Misconfiguration is fatal so that loads into a production datastore are never left ungated by mistake
+func configureLoadGate() {
+	gate := settings.LoadGate()
+	if gate == nil {
+		return
+	}
+	if err := models.SetLoadGate(models.GateType(*gate), settings.LoadGateEnvironment()); err != nil {
+		panic(err)
+	}
+}
+
+// configureEnvironment sets the environment RFCs reference as the ${environment} template variable, RFCs referencing
+// it fail to load if none is configured
+func configureEnvironment() {
+	if environment := settings.Environment(); environment != nil {
+		models.SetEnvironment(*environment)
+	}
+}
+
+// configureLeaderElection campaigns for leadership of the deployment on the configured lock, only the leading instance
+// then runs the scheduled jobs and the jobs of a shared job queue. Every instance leads if no lock is configured
+// Misconfiguration is fatal so that singleton work is never run by several instances by mistake
+func configureLeaderElection() {
+	lease, err := settings.LeaderLease()
+	if err != nil {
+		panic(err)
+	}
+	if lease == nil {
+		defaultLease := leader.DEFAULT_LEASE
+		lease = &defaultLease
+	}
+
+	var lock leader.Lock
+	switch backend := settings.LeaderBackend(); backend {
+	case leader.NONE_BACKEND:
+		return
+	case leader.FILE_BACKEND:
+		url := settings.LeaderBackendURL()
+		if url == nil {
+			panic(fmt.Errorf("no URL specified for the %s leader backend", backend))
+		}
+		lock = leader.NewFileLock(*url)
+	default:
+		// locks of other backends, e.g. Redis or DynamoDB, are not implemented, a lock held in memory would let every
+		// instance lead
+		panic(fmt.Errorf("unknown leader backend %s, expected %s or %s", backend, leader.NONE_BACKEND,
+			leader.FILE_BACKEND))
+	}
+
+	leader.Default = leader.NewElector(lock, *lease)
+	leader.Default.Start()
+}
+
+// configureJobs replaces the default job queue with one running the configured number of workers and attempts on the
+// configured backend
+// Misconfiguration is fatal so that loads are never queued somewhere they are not run
+func configureJobs() {
+	workers, err := settings.JobWorkers()
+	if err != nil {
+		panic(err)
+	}
+	if workers == nil {
+		defaultWorkers := jobs.DEFAULT_WORKERS
+		workers = &defaultWorkers
+	}
+	maxAttempts, err := settings.JobMaxAttempts()
+	if err != nil {
+		panic(err)
+	}
+	if maxAttempts == nil {
+		defaultMaxAttempts := jobs.DEFAULT_MAX_ATTEMPTS
+		maxAttempts = &defaultMaxAttempts
+	}
+
+	switch backend := settings.JobBackend(); backend {
+	case jobs.MEMORY_BACKEND:
+		jobs.Default = jobs.NewMemoryQueue(*workers, *maxAttempts, jobs.DEFAULT_BACKOFF)
+	default:
+		// queues of other backends, e.g. Redis or SQS, are not implemented, jobs queued in memory would be lost with
+		// the instance that queued them
+		panic(fmt.Errorf("unknown job backend %s, expected %s", backend, jobs.MEMORY_BACKEND))
+	}
+}
+
+// configureLoadStatus replaces the default store of load statuses, the RFC file, with the configured backend. Load
+// statuses are then only recorded in the RFC file if STATUS_IN_RFC_FILE is set
+// Misconfiguration is fatal so that load statuses are never recorded somewhere they are not read from
+func configureLoadStatus() {
+	switch backend := settings.StatusBackend(); backend {
+	case loadstatus.RFC_BACKEND:
+		return
+	case loadstatus.MEMORY_BACKEND:
+		loadstatus.Default = loadstatus.NewMemoryStore()
+	case loadstatus.FILE_BACKEND:
+		path := settings.StatusFile()
+		if path == nil {
+			panic(fmt.Errorf("no file specified for the %s load status backend", backend))
+		}
+		store, err := loadstatus.NewFileStore(*path)
+		if err != nil {
+			panic(err)
+		}
+		loadstatus.Default = store
+	default:
+		// stores of other backends, e.g. DynamoDB or Postgres, are not implemented, statuses kept in memory would be
+		// lost on restart and not shared by the instances
+		panic(fmt.Errorf("unknown load status backend %s, expected one of %s, %s or %s", backend,
+			loadstatus.RFC_BACKEND, loadstatus.MEMORY_BACKEND, loadstatus.FILE_BACKEND))
+	}
+	loadstatus.SetInRFCFile(settings.IsStatusInRFCFile())
+}
+
+// configureReviewerAssignment enables assigning a reviewer from each owning team to new RFCs with the configured
+// strategy, an unknown strategy is fatal
+func configureReviewerAssignment() {
+	strategy := settings.ReviewerAssignment()
+	if strategy == nil {
+		return
+	}
+	assigner, err := assignment.NewAssigner(assignment.Strategy(*strategy))
+	if err != nil {
+		panic(err)
+	}
+	assignment.Default = assigner
+}
+
+// configureMaintenance enables maintenance mode at startup if configured, so a restart during an incident does not
+// resume mutating operations
+func configureMaintenance() {
+	if settings.IsMaintenanceMode() {
+		maintenance.Default.Enable(settings.MaintenanceMessage())
+		logging.Default.Warn("starting in maintenance mode, mutating operations are rejected")
+	}
+}
+
+// configureRequestSigning enables verification of signed requests if a signing secret is configured, and of GitHub
+// webhook deliveries if a webhook secret is configured
+func configureRequestSigning() {
+	if secret := settings.RequestSigningSecret(); secret != nil {
+		signing.Default = signing.NewVerifier(*secret, signing.DEFAULT_TOLERANCE)
+	}
+	if secret := settings.GitHubWebhookSecret(); secret != nil {
+		signing.Webhooks = signing.NewWebhookVerifier(*secret, signing.DEFAULT_DELIVERY_TTL)
+	}
+}
+
+// configureReceiptSigning signs submission receipts with the configured receipt key, receipts are returned unsigned if
+// none is configured. A malformed key is fatal rather than silently returning unsigned receipts
+func configureReceiptSigning() {
+	if key := settings.ReceiptSigningKey(); key != nil {
+		signer, err := signing.NewReceiptSigner(*key)
+		if err != nil {
+			panic(err)
+		}
+		signing.Receipts = signer
+	}
+}
+
+// configureAuthorSignatures registers the keys of the configured author keys file, which the signatures authors make
+// over their RFCs are verified with, and whether RFCs must be signed by their author. Requiring author signatures
+// without registering keys is fatal as no RFC could be submitted
+func configureAuthorSignatures() {
+	if file := settings.AuthorKeysFile(); file != nil {
+		keys, err := signing.LoadAuthorKeys(*file)
+		if err != nil {
+			panic(err)
+		}
+		signing.Authors = keys
+	}
+	signing.AuthorSignaturesRequired = settings.IsAuthorSignatureRequired()
+	if signing.AuthorSignaturesRequired && signing.Authors == nil {
+		panic(fmt.Errorf("author signatures are required but AUTHOR_KEYS_FILE is not set"))
+	}
+}
+
+// configureSSO verifies the ID tokens of the configured OpenID Connect issuer on every route that is not public, if an
+// issuer is configured. An issuer without an audience is fatal so that tokens issued for other clients are never
+// accepted, as is an issuer without a seal secret, without which the reviews of SSO users could not be counted
+func configureSSO() {
+	if issuer := settings.OIDCIssuer(); issuer != nil {
+		audience, err := settings.OIDCAudience()
+		if err != nil {
+			panic(err)
+		}
+		secret, err := settings.OIDCSealSecret()
+		if err != nil {
+			panic(err)
+		}
+		oidc.Default = oidc.NewVerifier(*issuer, *audience, settings.OIDCLoginClaim())
+		signing.Records = signing.NewRecordSealer(*secret)
+	}
+}
+
+// configureAuthorization loads the authorization policy the user is authorized against on routes requiring a
+// permission, every user is granted every permission if none is configured. A malformed policy is fatal so that
+// routes are never left unguarded by mistake
+func configureAuthorization() {
+	if file := settings.AuthzPolicyFile(); file != nil {
+		policy, err := authz.Load(*file)
+		if err != nil {
+			panic(err)
+		}
+		authz.Default = policy
+	}
+}
+
+// configureApprovalPolicy loads the approval policy RFCs must meet before they are merged, merges only require what
+// the branch protection requires if none is configured. A malformed policy is fatal so that RFCs are never merged
+// short of their quorum by mistake
+func configureApprovalPolicy() {
+	if file := settings.ApprovalPolicyFile(); file != nil {
+		policy, err := quorum.Load(*file)
+		if err != nil {
+			panic(err)
+		}
+		// RFCs requiring the approval of an external system that is not registered could never be merged
+		for _, system := range policy.Systems().Values() {
+			if !models.IsExternalApprover(system) {
+				panic(fmt.Errorf("approval policy requires the approval of unregistered external system %s", system))
+			}
+		}
+		quorum.Default = policy
+	}
+}
+
+// configureExternalSystems registers the external systems of EXTERNAL_APPROVERS and ANALYZERS, which sign their
+// verdicts on RFCs and their annotations with a secret of their own. A system without a secret, or listed in both with
+// different secrets, is fatal
+func configureExternalSystems() {
+	secrets, err := settings.ExternalApprovers()
+	if err != nil {
+		panic(err)
+	}
+	for system := range secrets {
+		models.RegisterExternalApprover(system)
+	}
+	analyzers, err := settings.Analyzers()
+	if err != nil {
+		panic(err)
+	}
+	for analyzer, secret := range analyzers {
+		if approverSecret, ok := secrets[analyzer]; ok && approverSecret != secret {
+			panic(fmt.Errorf("external system %s is both an approver and an analyzer with different secrets",
+				analyzer))
+		}
+		secrets[analyzer] = secret
+	}
+	if len(secrets) == 0 {
+		return
+	}
+	if signing.Systems, err = signing.NewSystemVerifier(secrets, signing.DEFAULT_TOLERANCE); err != nil {
+		panic(err)
+	}
+}
+
+// configureMetrics registers the collectors exposed through the metrics endpoint
+func configureMetrics() {
+	metrics.Default.Register(metrics.CacheCollector)
+	metrics.Default.Register(metrics.ShadowLoadCollector)
+	metrics.Default.Register(metrics.RequestDuration.Collect)
+	metrics.Default.Register(metrics.RPCDuration.Collect)
+	metrics.Default.Register(metrics.GitCalls.Collect)
+	metrics.Default.Register(metrics.GitCallDuration.Collect)
+	metrics.Default.Register(metrics.MergeabilityPollDuration.Collect)
+	metrics.Default.Register(metrics.Loads.Collect)
+	metrics.Default.Register(metrics.LoadDuration.Collect)
+}
+
+// configureReviewRequests keeps the review requests of RFCs in the configured file, if any, and sets how long reviewers
+// have to first respond to them
+func configureReviewRequests() {
+	if file := settings.ReviewRequestFile(); file != nil {
+		store, err := reviewrequests.NewFileStore(*file)
+		if err != nil {
+			panic(err)
+		}
+		reviewrequests.Default = store
+	}
+
+	sla, err := settings.ReviewSLA()
+	if err != nil {
+		panic(err)
+	}
+	if sla != nil {
+		reviewrequests.SLA = *sla
+	}
+}
+
+// scheduleDigests sends the daily digests at the configured time of day, digests are disabled if no time is configured
+func scheduleDigests() {
+	digestTime, err := settings.DigestTime()
+	if err != nil {
+		panic(err)
+	}
+	if digestTime == nil {
+		return
+	}
+
+	schedule.Daily(*digestTime, leader.Only(func() {
+		// all digest work to be performed by machine client
+		ctx := context.Background()
+		// each tracking repository is summarized in digests of its own, with the machine token of its domain
+		for _, domain := range trackingDomains() {
+			machineAccessToken, err := domainMachineToken(ctx, domain)
+			if err != nil {
+				logging.Default.Error("unable to send digests", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken, domain)
+			if err != nil {
+				logging.Default.Error("unable to send digests", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			if err = controllers.SendDigests(ctx, client); err != nil {
+				logging.Default.Error("unable to send digests", "domain", domain, logging.ERROR_KEY, err)
+			}
+		}
+	}))
+}
+
+// scheduleReviewReminders reminds reviewers of each tracking repository of the review requests they left unanswered
+// past the review SLA every configured interval, reviewers are never reminded if no interval is configured
+// Misconfiguration is fatal
+func scheduleReviewReminders() {
+	interval, err := settings.ReviewReminderInterval()
+	if err != nil {
+		panic(err)
+	}
+	if interval == nil {
+		return
+	}
+
+	schedule.Every(*interval, leader.Only(func() {
+		// all reminder work to be performed by machine client, with the machine token of each domain
+		ctx := context.Background()
+		for _, domain := range trackingDomains() {
+			machineAccessToken, err := domainMachineToken(ctx, domain)
+			if err != nil {
+				logging.Default.Error("unable to send review reminders", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken, domain)
+			if err != nil {
+				logging.Default.Error("unable to send review reminders", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			if err = controllers.SendReviewReminders(ctx, client); err != nil {
+				logging.Default.Error("unable to send review reminders", "domain", domain, logging.ERROR_KEY, err)
+			}
+		}
+	}))
+}
+
+// warmUp builds the Git clients of each configured token and tracking repository, reports tokens lacking permissions
+// and primes the caches of each tracking repository in the background, so none of it is left to the first requests
+// The service does not report ready until it completes. Nothing here is fatal, failures are logged
+func warmUp() {
+	done := controllers.StartWarmUp()
+	go func() {
+		defer done()
+		ctx := context.Background()
+
+		// report misconfigured tokens before they fail midway through a request
+		reportTokenPermissions(ctx)
+
+		// report tracking repositories whose protection Harmonia's guarantees rely on
+		reportBranchProtection(ctx)
+
+		for _, domain := range trackingDomains() {
+			clients, _ := tokenClients(ctx, domain)
+			client, ok := clients["machine"]
+			if !ok {
+				// the machine token could not be configured, it has already been reported
+				continue
+			}
+			if err := controllers.WarmUp(ctx, client); err != nil {
+				logging.Default.Error("unable to warm up tracking repository", "domain", domain, logging.ERROR_KEY, err)
+			}
+		}
+		logging.Default.Info("warm-up complete, ready to handle requests")
+	}()
+}
+
+// reportTokenPermissions logs each configured token that lacks permissions Harmonia requires
+// This is not fatal so the service can still start while permissions are granted, /health/ready reports the same
+func reportTokenPermissions(ctx context.Context) {
+	clients, setupErrors := tokenClients(ctx, "")
+	for _, check := range controllers.CheckReadiness(ctx, clients, setupErrors).Tokens {
+		if check.Error != "" {
+			logging.Default.Warn("unable to validate token permissions", "token", check.Token, logging.ERROR_KEY, check.Error)
+		} else if len(check.Missing) > 0 {
+			logging.Default.Warn("token is missing permissions", "token", check.Token,
+				"missing", strings.Join(check.Missing, ", "))
+		}
+	}
+}
+
+// scheduleProtectionChecks reports drift of the branch protection of the tracking repositories every configured
+// interval, so protection weakened after startup does not go unnoticed. Misconfiguration is fatal
+func scheduleProtectionChecks() {
+	if _, err := settings.RequiredApprovals(); err != nil {
+		panic(err)
+	}
+	interval, err := settings.ProtectionCheckInterval()
+	if err != nil {
+		panic(err)
+	}
+	if interval == nil {
+		defaultInterval := controllers.DEFAULT_PROTECTION_CHECK_INTERVAL
+		interval = &defaultInterval
+	}
+
+	schedule.Every(*interval, leader.Only(func() {
+		reportBranchProtection(context.Background())
+	}))
+}
+
+// scheduleRetention archives the RFCs merged more than the configured number of days ago from each tracking repository
+// every day, nothing is archived if no retention is configured. Misconfiguration is fatal
+func scheduleRetention() {
+	days, err := settings.RetentionDays()
+	if err != nil {
+		panic(err)
+	}
+	if days == nil {
+		return
+	}
+
+	schedule.Every(24*time.Hour, leader.Only(func() {
+		// all archive work to be performed by machine client, with the machine token of each domain
+		ctx := context.Background()
+		mergedBefore := time.Now().AddDate(0, 0, -*days)
+		for _, domain := range trackingDomains() {
+			machineAccessToken, err := domainMachineToken(ctx, domain)
+			if err != nil {
+				logging.Default.Error("unable to archive RFCs", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken, domain)
+			if err != nil {
+				logging.Default.Error("unable to archive RFCs", "domain", domain, logging.ERROR_KEY, err)
+				continue
+			}
+			if archived, err := controllers.ArchiveRFCs(ctx, client, mergedBefore); err != nil {
+				logging.Default.Error("unable to archive RFCs", "domain", domain, logging.ERROR_KEY, err)
+			} else if len(archived) > 0 {
+				logging.Default.Info("archived merged RFCs", "domain", domain, "count", len(archived))
+			}
+		}
+	}))
+}
+
+// reportBranchProtection logs each tracking repository whose branch protection falls short of the protection Harmonia
+// relies on, as read by the machine client. This is not fatal, /health/ready reports the same for the default tracking
+// repository
+func reportBranchProtection(ctx context.Context) {
+	requiredApprovals, _ := settings.RequiredApprovals()
+	for _, domain := range trackingDomains() {
+		clients, _ := tokenClients(ctx, domain)
+		client, ok := clients["machine"]
+		if !ok {
+			// the machine token could not be configured, it has already been reported
+			continue
+		}
+		check := controllers.CheckBranchProtection(ctx, client, domain, settings.RequiredStatusContexts(),
+			requiredApprovals)
+		if check.Error != "" {
+			logging.Default.Warn("unable to check branch protection", "domain", domain, logging.ERROR_KEY, check.Error)
+		} else if len(check.Drift) > 0 {
+			logging.Default.Warn("branch protection drifted from the expected protection", "domain", domain,
+				"drift", strings.Join(check.Drift, ", "))
+		}
+	}
+}
+
 // bindRoutes iterates over the provided routes array and adds the proper handlers to the given engine
+// Signed, webhook and external routes are guarded so their request signature is verified, mutating routes so they are
+// rejected while maintenance mode is enabled, and routes requiring a permission so the user is authorized
 func bindRoutes(engine *gin.Engine, routes []models.Route) {
 	for _, route := range routes {
+		handlers := []gin.HandlerFunc{}
+		if !route.Public {
+			handlers = append(handlers, authenticate)
+		}
+		if route.Signed {
+			handlers = append(handlers, verifyRequestSignature)
+		}
+		if route.Webhook {
+			handlers = append(handlers, verifyWebhookSignature)
+		}
+		if route.External {
+			handlers = append(handlers, verifySystemSignature)
+		}
+		if route.Mutating {
+			handlers = append(handlers, rejectDuringMaintenance)
+		}
+		if route.Permission != "" {
+			handlers = append(handlers, authorize(route.Permission))
+		}
+		handlers = append(handlers, route.Handler)
+
 		// GET routes
 		if route.HttpVerb == http.MethodGet {
 			if route.Handler != nil {
-				engine.GET(route.Path, route.Handler)
+				engine.GET(route.Path, handlers...)
 			}
 			// POST ROUTES
 		} else if route.HttpVerb == http.MethodPost {
 			if route.Handler != nil {
-				engine.POST(route.Path, route.Handler)
+				engine.POST(route.Path, handlers...)
+			}
+			// DELETE ROUTES
+		} else if route.HttpVerb == http.MethodDelete {
+			if route.Handler != nil {
+				engine.DELETE(route.Path, handlers...)
 			}
 		}
 	}
@@ -0,0 +1,108 @@
+// this holds the WebSocket sessions of collaborative RFC reviews, which broadcast the comments and reviews left on an
+// RFC to everyone reviewing it as they happen
+package main
+
+import (
+	"context"
+	"time"
+
+	"harmonia-example.io/src/controllers"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/metadata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// timings of review sessions
+const (
+	// how long writing a message to a review session may take before the session is closed
+	SESSION_WRITE_WAIT = 10 * time.Second
+	// how often review sessions are pinged, and how long a session may go without answering before it is closed
+	SESSION_PING_INTERVAL = 30 * time.Second
+	SESSION_PONG_WAIT     = 60 * time.Second
+	// size of the largest message read from clients, which only send control messages
+	SESSION_READ_LIMIT = 512
+)
+
+// sessionEvents are the types of the events broadcast to review sessions
+var sessionEvents = []models.EventType{models.CommentEvent, models.ReviewEvent}
+
+// sessionUpgrader upgrades requests to review sessions, only from pages served by Harmonia itself, e.g. the web UI
+var sessionUpgrader = websocket.Upgrader{}
+
+// @description join the collaborative review session of an RFC, a WebSocket over which every comment and review left
+// @description on the RFC from then on is sent as a JSON event. Messages sent by clients are discarded
+// @Tags Activity
+// @Param id path string true "RFC identifier"
+// @Response 101
+// @Response 400 {string} string
+// @Router /ws/rfc/{id} [get]
+// reviewSession upgrades the request to a WebSocket and broadcasts the comments and reviews of the requested RFC over
+// it until the client leaves the session
+func reviewSession(c *gin.Context) {
+	rfcIdentifier := c.Param("id")
+	metadata.SetRFCIdentifier(c, rfcIdentifier)
+	// the upgrader responds to requests that cannot be upgraded itself
+	conn, err := sessionUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logging.FromContext(c).Warn("unable to open review session", logging.ERROR_KEY, err)
+		return
+	}
+	defer conn.Close()
+
+	// the session ends when the client leaves it or stops answering pings
+	ctx, leave := context.WithCancel(c)
+	defer leave()
+	go readSession(conn, leave)
+
+	stream := controllers.StreamEvents(ctx, &rfcIdentifier, sessionEvents)
+	ping := time.NewTicker(SESSION_PING_INTERVAL)
+	defer ping.Stop()
+	for {
+		select {
+		case event, ok := <-stream:
+			if !ok {
+				closing := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+				_ = conn.WriteControl(websocket.CloseMessage, closing, time.Now().Add(SESSION_WRITE_WAIT))
+				return
+			}
+			if err := writeSession(conn, event); err != nil {
+				logging.FromContext(c).Warn("unable to write to review session", logging.ERROR_KEY, err)
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(SESSION_WRITE_WAIT)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeSession sends the given event to the given review session as JSON
+func writeSession(conn *websocket.Conn, event models.Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(SESSION_WRITE_WAIT)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(event)
+}
+
+// readSession reads the given review session, answering its control messages, until the client leaves it or stops
+// answering pings, then calls leave
+func readSession(conn *websocket.Conn, leave func()) {
+	defer leave()
+
+	conn.SetReadLimit(SESSION_READ_LIMIT)
+	if err := conn.SetReadDeadline(time.Now().Add(SESSION_PONG_WAIT)); err != nil {
+		return
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(SESSION_PONG_WAIT))
+	})
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
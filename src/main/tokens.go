@@ -0,0 +1,189 @@
+// this holds the resolution of the access token each request is made with, so RFCs are submitted, reviewed and
+// commented on by the user calling Harmonia rather than by a user shared by every caller, and of the access token of
+// the machine identity Harmonia performs operations of its own with
+package main
+
+import (
+	"context"
+	"strings"
+
+	"harmonia-example.io/src/controllers"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/auth"
+	"harmonia-example.io/src/services/git"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/metadata"
+	"harmonia-example.io/src/services/oidc"
+	"harmonia-example.io/src/services/tenants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AUTHORIZATION_HEADER is the header, and the gRPC metadata, carrying the access token of the user making a request
+const AUTHORIZATION_HEADER = "Authorization"
+
+// errNoUserToken is returned when a request carries no access token and no shared GIT_TOKEN is configured
+var errNoUserToken = models.NewError(models.ErrUnauthenticated, models.UnauthenticatedCode,
+	"no access token, authenticate with an Authorization bearer token")
+
+// errNoIDToken is returned when SSO is enabled and a request carries no ID token
+var errNoIDToken = models.NewError(models.ErrUnauthenticated, models.UnauthenticatedCode,
+	"no ID token, authenticate with an Authorization bearer token issued by the identity provider")
+
+// userTokenKey is the key the access token of the user making a request is stored under in its context
+type userTokenKey struct{}
+
+// bearerToken returns the token of the given Authorization header, empty unless it uses the Bearer scheme or the
+// token scheme of GitHub
+func bearerToken(authorization string) string {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found || (!strings.EqualFold(scheme, "Bearer") && !strings.EqualFold(scheme, "token")) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// withUserToken returns a copy of the given context carrying the access token of the given Authorization header, the
+// context itself if the header carries none
+func withUserToken(ctx context.Context, authorization string) context.Context {
+	if token := bearerToken(authorization); token != "" {
+		return context.WithValue(ctx, userTokenKey{}, token)
+	}
+	return ctx
+}
+
+// extractUserToken records the access token of the Authorization header of the request in its context, see userToken
+// Personal access tokens and the user-to-server tokens of GitHub Apps are both bearer tokens. When SSO is enabled the
+// header carries the ID token of the user instead, see authenticate
+func extractUserToken(c *gin.Context) {
+	if oidc.Default != nil {
+		return
+	}
+	c.Request = c.Request.WithContext(withUserToken(c.Request.Context(), c.GetHeader(AUTHORIZATION_HEADER)))
+}
+
+// authenticate aborts the request with a 401 unless the Authorization header carries a valid ID token of the identity
+// provider when SSO is enabled, recording the identity it carries in the request context otherwise. It is bound in
+// front of every route that is not public
+func authenticate(c *gin.Context) {
+	if oidc.Default == nil {
+		return
+	}
+
+	token := bearerToken(c.GetHeader(AUTHORIZATION_HEADER))
+	if token == "" {
+		status, body := errorResponse(errNoIDToken, "Authentication error occurred - no ID token")
+		c.AbortWithStatusJSON(status, body)
+		return
+	}
+	identity, err := oidc.Default.Verify(c, token)
+	if err != nil {
+		logging.FromContext(c).Warn("rejected unauthenticated request", "clientIp", c.ClientIP(), logging.ERROR_KEY, err)
+		status, body := errorResponse(models.NewError(models.ErrUnauthenticated, models.UnauthenticatedCode,
+			err.Error()), "Authentication error occurred - invalid ID token")
+		c.AbortWithStatusJSON(status, body)
+		return
+	}
+
+	c.Request = c.Request.WithContext(oidc.NewContext(c.Request.Context(), identity))
+	metadata.SetUser(c, identity.Login)
+}
+
+// userToken returns the access token of the user making the request of the given context: the token it carries, or
+// the shared GIT_TOKEN if it carries none and one is configured, for local stacks and deployments that have not moved
+// to per-request tokens. errNoUserToken is returned otherwise
+// Requests of a tenant in the multi-tenant mode fall back on the shared token of the tenant instead, provided their
+// user is a member of the tenant, see tenants.Tenant and checkTenantMember
+func userToken(ctx context.Context) (*string, error) {
+	if token, ok := ctx.Value(userTokenKey{}).(string); ok {
+		return &token, nil
+	}
+	if tenants.Default != nil {
+		domain := metadata.Tenant(ctx)
+		if tenant, ok := tenants.Default.Get(domain); ok {
+			token, err := settings.TenantToken(tenant.Token)
+			if err != nil {
+				return nil, errNoUserToken
+			}
+			if err = checkTenantMember(ctx, domain); err != nil {
+				return nil, err
+			}
+			return token, nil
+		}
+	}
+	if token, err := settings.Token(); err == nil {
+		return token, nil
+	}
+	return nil, errNoUserToken
+}
+
+// checkTenantMember returns tenants.ErrCrossTenant (wrapped) unless the user making the request of the given context is
+// a member of the tenant of the given schema domain, whose teams are read with the machine token of the tenant, see
+// controllers.CheckTenantMember
+func checkTenantMember(ctx context.Context, domain string) error {
+	machineAccessToken, err := domainMachineToken(ctx, domain)
+	if err != nil {
+		return err
+	}
+	client, err := git.NewForDomain(ctx, settings.GitProvider(), *machineAccessToken, domain)
+	if err != nil {
+		return err
+	}
+	return controllers.CheckTenantMember(ctx, client, domain)
+}
+
+// readToken returns the access token the read-only requests of the tenant the request of the given context operates
+// on are made with, see domainReadToken
+func readToken(ctx context.Context) (*string, error) {
+	return domainReadToken(ctx, metadata.Tenant(ctx))
+}
+
+// domainReadToken returns the access token read-only requests are made with for the tracking repository of the given
+// schema domain, so that a compromised read path cannot write: the read token of its tenant in the multi-tenant mode,
+// otherwise GIT_READ_TOKEN. Without a read token requests are anonymous if GIT_READ_ANONYMOUS is set, an empty token
+// being returned, and are made with the machine token otherwise, see domainMachineToken
+func domainReadToken(ctx context.Context, domain string) (*string, error) {
+	if tenants.Default != nil {
+		if tenant, ok := tenants.Default.Get(domain); ok {
+			if tenant.ReadToken != "" {
+				return settings.TenantToken(tenant.ReadToken)
+			}
+			return anonymousOrMachineToken(ctx, domain)
+		}
+	}
+	if token := settings.ReadToken(); token != nil {
+		return token, nil
+	}
+	return anonymousOrMachineToken(ctx, domain)
+}
+
+// anonymousOrMachineToken returns an empty token if read-only requests are anonymous, the machine token of the given
+// schema domain otherwise
+func anonymousOrMachineToken(ctx context.Context, domain string) (*string, error) {
+	if settings.IsAnonymousRead() {
+		anonymous := ""
+		return &anonymous, nil
+	}
+	return domainMachineToken(ctx, domain)
+}
+
+// machineToken returns the access token of the machine identity for the tenant the request of the given context
+// operates on, see domainMachineToken
+func machineToken(ctx context.Context) (*string, error) {
+	return domainMachineToken(ctx, metadata.Tenant(ctx))
+}
+
+// domainMachineToken returns the access token of the machine identity for the tracking repository of the given schema
+// domain: the machine token of its tenant in the multi-tenant mode, otherwise an installation token of the configured
+// GitHub App, or GIT_MACHINE_TOKEN if no app is configured
+func domainMachineToken(ctx context.Context, domain string) (*string, error) {
+	if tenants.Default != nil {
+		if tenant, ok := tenants.Default.Get(domain); ok {
+			return settings.TenantToken(tenant.MachineToken)
+		}
+	}
+	if auth.Default != nil {
+		return auth.Default.Token(ctx)
+	}
+	return settings.MachineToken()
+}
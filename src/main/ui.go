@@ -0,0 +1,37 @@
+// this holds the embedded web UI, a single page driving the API from the browser for users not comfortable with a CLI
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UI_PATH is the path the web UI is served at
+const UI_PATH = "/ui"
+
+//go:embed ui
+var uiFiles embed.FS
+
+// uiHandler serves the embedded UI files, rooted at the ui directory
+var uiHandler = func() http.Handler {
+	root, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		// the ui directory is embedded at compile time, so this can only fail if the embed directive is changed
+		panic(err)
+	}
+	return http.StripPrefix(UI_PATH, http.FileServer(http.FS(root)))
+}()
+
+// you don't see any openapi comments here because this is not part of the API
+// uiRedirect redirects to the UI index, so that the UI files are resolved relative to the UI path
+func uiRedirect(c *gin.Context) {
+	c.Redirect(http.StatusFound, UI_PATH+"/")
+}
+
+// ui serves the UI file at the requested path, the index for the UI path itself
+func ui(c *gin.Context) {
+	uiHandler.ServeHTTP(c.Writer, c.Request)
+}
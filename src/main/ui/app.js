@@ -0,0 +1,274 @@
+// Harmonia UI: lists RFCs and lets users inspect, review and merge them through the Harmonia API
+// Everything is rendered with textContent, RFC contents are never interpreted as HTML
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+let selected = null;
+let stream = null;
+
+// lifecycle events streamed for the selected RFC, each refreshes it
+const EVENT_TYPES = ["update", "review", "load", "merge", "rebuild", "comment", "annotate", "withdraw", "breakGlass"];
+
+// post sends the given body to the given API endpoint, resolving to the decoded response or rejecting with its error
+async function post(path, body) {
+  const domain = $("domain").value.trim();
+  return send(path, Object.assign(domain ? { domain } : {}, body));
+}
+
+// query runs the given GraphQL query, resolving to its data along with the errors of the fields that failed
+async function query(graphqlQuery, variables) {
+  const domain = $("domain").value.trim();
+  const result = await send("/graphql", {
+    query: graphqlQuery,
+    variables: Object.assign(domain ? { domain } : {}, variables),
+  });
+  if (!result.data) {
+    throw new Error((result.errors || [{ message: "No data returned" }])[0].message);
+  }
+  return result;
+}
+
+// field settles the given field of the given query result like Promise.allSettled would, failed if it or a field
+// around it has errors
+function field(result, path) {
+  const error = (result.errors || []).find((err) => {
+    const errorPath = (err.path || []).join(".");
+    return `${path}.`.startsWith(`${errorPath}.`) || errorPath.startsWith(`${path}.`);
+  });
+  if (error) {
+    return { status: "rejected", reason: new Error(error.message) };
+  }
+  return { status: "fulfilled", value: path.split(".").reduce((value, key) => value && value[key], result.data) };
+}
+
+// send posts the given payload to the given endpoint on behalf of the user of the token entered, if any, otherwise
+// the server falls back to its GIT_TOKEN
+async function send(path, payload) {
+  const headers = { "Content-Type": "application/json" };
+  const token = $("token").value.trim();
+  if (token) {
+    headers.Authorization = `Bearer ${token}`;
+  }
+  const response = await fetch(path, {
+    method: "POST",
+    headers,
+    body: JSON.stringify(payload),
+  });
+  const data = await response.json().catch(() => ({}));
+  if (!response.ok) {
+    throw new Error(data.error || response.statusText);
+  }
+  return data;
+}
+
+function show(message, isError) {
+  $("message").textContent = message;
+  $("message").className = isError ? "error" : "";
+}
+
+function element(tag, text, className) {
+  const node = document.createElement(tag);
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  if (className) {
+    node.className = className;
+  }
+  return node;
+}
+
+// every RFC with the given state, along with the links shown once one is selected
+const RFCS_QUERY = `query ($domain: String, $state: String) {
+  rfcs(domain: $domain, count: -1, state: $state) { id title links { pullRequest } }
+}`;
+
+// everything shown about the selected RFC but its history, fetched in one round trip
+const RFC_QUERY = `query ($domain: String, $id: ID!) {
+  rfc(domain: $domain, id: $id) {
+    loadStatus { status embargoed gate { type state } targets }
+    reviews { reviewer type state submittedAt dismissed }
+    contents
+  }
+}`;
+
+async function listRfcs() {
+  try {
+    const { data, errors } = await query(RFCS_QUERY, { state: $("state").value });
+    if (errors) {
+      throw new Error(errors[0].message);
+    }
+    const list = $("rfcs");
+    list.replaceChildren();
+    for (const rfc of data.rfcs) {
+      const item = element("li", `#${rfc.id} ${rfc.title}`);
+      item.dataset.id = rfc.id;
+      item.onclick = () => selectRfc(rfc.id, rfc.title, rfc.links);
+      list.appendChild(item);
+    }
+    show(list.children.length ? "" : "No RFCs found");
+  } catch (err) {
+    show(`Unable to list RFCs: ${err.message}`, true);
+  }
+}
+
+async function selectRfc(id, title, links) {
+  selected = id;
+  for (const item of $("rfcs").children) {
+    item.classList.toggle("selected", item.dataset.id === id);
+  }
+  $("rfc").hidden = false;
+  $("rfc-title").textContent = `#${id} ${title}`;
+  const linkParagraph = $("rfc-links");
+  linkParagraph.replaceChildren();
+  if (links && links.pullRequest) {
+    const link = element("a", "Pull request");
+    link.href = links.pullRequest;
+    link.target = "_blank";
+    linkParagraph.appendChild(link);
+  }
+  follow(id);
+  await refreshRfc();
+}
+
+// follow refreshes the selected RFC whenever an event about the RFC with the given ID is streamed, instead of polling
+function follow(id) {
+  if (stream) {
+    stream.close();
+  }
+  stream = new EventSource(`/events?rfcIdentifier=${encodeURIComponent(id)}`);
+  for (const type of EVENT_TYPES) {
+    stream.addEventListener(type, () => {
+      if (id === selected) {
+        refreshRfc();
+      }
+    });
+  }
+}
+
+async function refreshRfc() {
+  const id = selected;
+  const [rfc, history] = await Promise.allSettled([
+    query(RFC_QUERY, { id }),
+    post("/getRfcHistory", { rfcIdentifier: id }),
+  ]);
+  // ignore responses for an RFC that is no longer selected
+  if (id !== selected) {
+    return;
+  }
+  if (rfc.status === "rejected") {
+    renderStatus(rfc);
+    renderReviews(rfc);
+    renderContent(rfc);
+  } else {
+    renderStatus(field(rfc.value, "rfc.loadStatus"));
+    renderReviews(field(rfc.value, "rfc.reviews"));
+    renderContent(field(rfc.value, "rfc.contents"));
+  }
+  renderHistory(history);
+}
+
+function renderStatus(result) {
+  const list = $("rfc-status");
+  list.replaceChildren();
+  if (result.status === "rejected") {
+    list.appendChild(element("dd", result.reason.message, "removed"));
+    return;
+  }
+  const status = result.value;
+  const entries = [["Load status", status.status], ["Embargoed", String(status.embargoed)]];
+  if (status.gate) {
+    entries.push(["Load gate", `${status.gate.type} (${status.gate.state})`]);
+  }
+  for (const [target, targetStatus] of Object.entries(status.targets || {})) {
+    entries.push([`Target ${target}`, targetStatus]);
+  }
+  for (const [term, description] of entries) {
+    list.appendChild(element("dt", term));
+    list.appendChild(element("dd", description));
+  }
+}
+
+function renderReviews(result) {
+  const list = $("rfc-reviews");
+  list.replaceChildren();
+  if (result.status === "rejected") {
+    list.appendChild(element("li", result.reason.message, "removed"));
+    return;
+  }
+  for (const review of result.value || []) {
+    const state = review.dismissed ? "dismissed" : review.type || review.state;
+    list.appendChild(element("li", `${review.reviewer}: ${state} (${new Date(review.submittedAt).toLocaleString()})`));
+  }
+  if (!list.children.length) {
+    list.appendChild(element("li", "No reviews yet"));
+  }
+}
+
+function renderContent(result) {
+  if (result.status === "rejected") {
+    $("rfc-content").textContent = result.reason.message;
+    return;
+  }
+  try {
+    $("rfc-content").textContent = JSON.stringify(JSON.parse(result.value), null, 2);
+  } catch (err) {
+    $("rfc-content").textContent = result.value;
+  }
+}
+
+function renderHistory(result) {
+  const history = $("rfc-history");
+  history.replaceChildren();
+  if (result.status === "rejected") {
+    history.appendChild(element("p", result.reason.message, "removed"));
+    return;
+  }
+  for (const revision of result.value.revisions || []) {
+    const details = element("details");
+    details.appendChild(element("summary",
+      `${revision.sha.slice(0, 7)} ${revision.author}: ${revision.message} (${new Date(revision.timestamp).toLocaleString()})`));
+    const diff = element("pre");
+    for (const line of (revision.diff || "").split("\n")) {
+      const className = line.startsWith("+") ? "added" : line.startsWith("-") ? "removed" : "";
+      diff.appendChild(element("div", line, className));
+    }
+    details.appendChild(diff);
+    history.appendChild(details);
+  }
+}
+
+$("filters").onsubmit = (event) => {
+  event.preventDefault();
+  listRfcs();
+};
+
+$("review").onsubmit = async (event) => {
+  event.preventDefault();
+  try {
+    const data = await post("/reviewRequest", {
+      rfcIdentifier: selected,
+      type: $("review-type").value,
+      topLevelComment: $("review-comment").value,
+    });
+    $("review-comment").value = "";
+    show(data.success || "Review submitted");
+    await refreshRfc();
+  } catch (err) {
+    show(`Unable to review RFC #${selected}: ${err.message}`, true);
+  }
+};
+
+$("merge").onclick = async () => {
+  if (!window.confirm(`Merge RFC #${selected}?`)) {
+    return;
+  }
+  try {
+    const data = await post("/mergeRequest", { rfcIdentifier: selected });
+    show(data.success || "RFC merged");
+    await listRfcs();
+  } catch (err) {
+    show(`Unable to merge RFC #${selected}: ${err.message}`, true);
+  }
+};
+
+listRfcs();
@@ -0,0 +1,107 @@
+// this holds the annotations automated analyzers attach to RFC actions
+package models
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Severity represents how serious the finding reported by an annotation is
+type Severity string
+
+// supported annotation severities
+var InfoSeverity Severity = "info"
+var WarningSeverity Severity = "warning"
+var ErrorSeverity Severity = "error"
+
+// ErrUnknownAnalyzer is returned (wrapped) when annotations are attached by an analyzer that is not registered
+var ErrUnknownAnalyzer = NewError(ErrUnauthorized, UnknownAnalyzerCode, "analyzer is not registered")
+
+// ErrInvalidAnnotation is returned (wrapped) when an annotation is missing its message or has an unknown severity
+var ErrInvalidAnnotation = NewError(ErrInvalid, InvalidAnnotationCode, "invalid annotation")
+
+// analyzers holds the names of the analyzers allowed to annotate RFC actions
+var analyzers = map[string]bool{}
+var analyzersMu sync.RWMutex
+
+// RegisterAnalyzer allows the analyzer with the given name to annotate RFC actions
+func RegisterAnalyzer(name string) {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+
+	analyzers[name] = true
+}
+
+// IsRegisteredAnalyzer returns true if the analyzer with the given name may annotate RFC actions
+func IsRegisteredAnalyzer(name string) bool {
+	analyzersMu.RLock()
+	defer analyzersMu.RUnlock()
+
+	return analyzers[name]
+}
+
+// IsValid returns whether the severity is one of the supported severities
+func (s Severity) IsValid() bool {
+	return s == InfoSeverity || s == WarningSeverity || s == ErrorSeverity
+}
+
+// Annotate attaches the given annotations, made by the given analyzer, to the proposal actions they target
+// Annotations previously attached by the analyzer are replaced, so that re-running an analyzer does not accumulate
+// stale findings. Annotations are not carried over when the RFC is updated, analyzers are expected to run again
+func (rfc *RFC) Annotate(analyzer string, annotations []Annotation) error {
+	// validate the analyzer and every annotation before changing anything
+	if !IsRegisteredAnalyzer(analyzer) {
+		return fmt.Errorf("%w: %s", ErrUnknownAnalyzer, analyzer)
+	}
+	for _, annotation := range annotations {
+		if !annotation.Severity.IsValid() || annotation.Message == "" {
+			return fmt.Errorf("%w: severity %q, message %q", ErrInvalidAnnotation, annotation.Severity,
+				annotation.Message)
+		}
+		if action := rfc.GetAction(annotation.Signature); action == nil || !action.IsProposal() {
+			return fmt.Errorf("%w: no proposal action with signature %s", ErrActionNotFound, annotation.Signature)
+		}
+	}
+
+	// drop the previous annotations of the analyzer
+	actions := Actions{}
+	for _, action := range rfc.Actions {
+		if action.ActionType != AnnotationAction || action.Data[string(AnalyzerData)] != analyzer {
+			actions = append(actions, action)
+		}
+	}
+	rfc.Actions = actions
+
+	for _, annotation := range annotations {
+		err := rfc.AddAction(Action{
+			ActionType: AnnotationAction,
+			Target: Target{
+				TargetType:  ActionTarget,
+				LookupKey:   SignatureLookupKey,
+				LookupValue: annotation.Signature,
+			},
+			Data: map[string]interface{}{
+				string(AnalyzerData): analyzer,
+				string(SeverityData): string(annotation.Severity),
+				string(MessageData):  annotation.Message,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAnnotations returns the annotations attached to the action with the given signature, in the order they were made
+func (rfc *RFC) GetAnnotations(signature string) Actions {
+	annotations := Actions{}
+	for _, action := range rfc.Actions {
+		if action.ActionType == AnnotationAction && action.Target.LookupValue == signature {
+			annotations = append(annotations, action)
+		}
+	}
+
+	return annotations
+}
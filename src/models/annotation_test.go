@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestAnnotate tests that annotations are kept apart from comments and replace the analyzer's previous annotations
+func TestAnnotate(t *testing.T) {
+	// arrange
+	RegisterAnalyzer("lint")
+	rfc := &RFC{Signature: "rfc-sha"}
+	if err := rfc.AddAction(Action{ActionType: AddAction, Data: map[string]interface{}{"id": "a"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	target := rfc.Actions[0].Signature
+	if err := rfc.AddComments(map[string][]string{target: {"looks good"}}, "tstark"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	first := []Annotation{{Signature: target, Severity: WarningSeverity, Message: "name is plural"}}
+	second := []Annotation{{Signature: target, Severity: ErrorSeverity, Message: "breaks consumers"}}
+
+	// act
+	firstErr := rfc.Annotate("lint", first)
+	secondErr := rfc.Annotate("lint", second)
+	unknownErr := rfc.Annotate("impact", first)
+	invalidErr := rfc.Annotate("lint", []Annotation{{Signature: target, Severity: "fatal", Message: "oops"}})
+	missingErr := rfc.Annotate("lint", []Annotation{{Signature: "missing", Severity: InfoSeverity, Message: "oops"}})
+
+	// assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", firstErr, secondErr)
+	}
+	annotations := rfc.GetAnnotations(target)
+	if len(annotations) != 1 || annotations[0].Data[string(MessageData)] != "breaks consumers" {
+		t.Errorf("expected only the latest annotation, got %v", annotations)
+	}
+	if thread := rfc.GetCommentThread(target); len(thread) != 1 {
+		t.Errorf("expected annotations to be kept out of the comment thread, got %v", thread)
+	}
+	if !errors.Is(unknownErr, ErrUnknownAnalyzer) || !errors.Is(invalidErr, ErrInvalidAnnotation) ||
+		!errors.Is(missingErr, ErrActionNotFound) {
+		t.Errorf("unexpected errors: %v, %v, %v", unknownErr, invalidErr, missingErr)
+	}
+}
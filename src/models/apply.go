@@ -0,0 +1,53 @@
+// this holds how schema actions change the items they target, so the changes of an RFC can be previewed
+package models
+
+// Apply returns the attributes of the targeted item, whose current attributes are given (nil if it does not exist),
+// once the action is applied, along with whether the action type is one whose effect is known
+// add actions create the item with the action data, update actions overwrite the attributes of the item present in the
+// action data, delete actions remove the item, rename actions set its lookup key to the new name of the action data and
+// deprecate actions flag it as deprecated along with the action data, while reserve actions leave it unchanged as they
+// only claim its target descriptor. The effect of changes to items that do not exist, and of other action types, is
+// left to the datastore, nil and false are returned for them
+func (action *Action) Apply(before map[string]interface{}) (map[string]interface{}, bool) {
+	switch action.ActionType {
+	case AddAction:
+		after := make(map[string]interface{}, len(action.Data))
+		for key, value := range action.Data {
+			after[key] = value
+		}
+		return after, true
+	case UpdateAction:
+		after := make(map[string]interface{}, len(before)+len(action.Data))
+		for key, value := range before {
+			after[key] = value
+		}
+		for key, value := range action.Data {
+			after[key] = value
+		}
+		return after, true
+	case ReserveAction:
+		return before, true
+	case DeleteAction, RenameAction, DeprecateAction:
+		if before == nil {
+			return nil, false
+		}
+		if action.ActionType == DeleteAction {
+			return nil, true
+		}
+		after := make(map[string]interface{}, len(before)+len(action.Data))
+		for key, value := range before {
+			after[key] = value
+		}
+		if action.ActionType == RenameAction {
+			after[action.Target.LookupKey] = action.Data[string(NewNameData)]
+			return after, true
+		}
+		for key, value := range action.Data {
+			after[key] = value
+		}
+		after[string(DeprecatedData)] = true
+		return after, true
+	default:
+		return nil, false
+	}
+}
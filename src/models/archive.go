@@ -0,0 +1,30 @@
+// this holds the archive index of a tracking repository, listing the merged RFCs whose directories were archived
+package models
+
+import "time"
+
+// ArchivedRFC is the entry of an archived RFC in the archive index, the RFC file itself is kept by the tag the RFC was
+// merged under
+type ArchivedRFC struct {
+	RFCIdentifier string    `json:"rfcIdentifier" example:"123456"`
+	Title         string    `json:"title" example:"RFC: 123456"`
+	Author        string    `json:"author" example:"tstark"`
+	Tag           string    `json:"tag" example:"123456"`
+	MergedAt      time.Time `json:"mergedAt" example:"2022-09-01T00:00:00Z"`
+	ArchivedAt    time.Time `json:"archivedAt" example:"2024-09-01T00:00:00Z"`
+}
+
+// ArchiveIndex lists the archived RFCs of a tracking repository, oldest archived first
+type ArchiveIndex struct {
+	RFCs []ArchivedRFC `json:"rfcs"`
+}
+
+// Contains returns whether the RFC with the given identifier is archived
+func (index *ArchiveIndex) Contains(rfcIdentifier string) bool {
+	for _, archived := range index.RFCs {
+		if archived.RFCIdentifier == rfcIdentifier {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// this holds the signatures authors make over the RFCs they submit, which make them accountable for the change proposed
+package models
+
+import (
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// AUTHOR_SIGNATURE_ALGORITHM is the algorithm authors sign RFCs with
+const AUTHOR_SIGNATURE_ALGORITHM string = "ed25519"
+
+// ErrAuthorSignatureRequired is returned (wrapped) when an RFC is not signed by its author although author signatures
+// are required
+var ErrAuthorSignatureRequired = NewError(ErrInvalid, AuthorSignatureRequiredCode, "author signature required")
+
+// ErrInvalidAuthorSignature is returned (wrapped) when the author signature of an RFC cannot be verified with a key of
+// its author
+var ErrInvalidAuthorSignature = NewError(ErrUnauthorized, InvalidAuthorSignatureCode, "invalid author signature")
+
+// AuthorSignature is the signature of an RFC by its author, made over the canonical form of the RFC (see
+// RFC.Canonical) with a private key whose public key is registered to the author
+type AuthorSignature struct {
+	// Author is the Git login of the author, who must be the user submitting or updating the RFC
+	Author string `json:"author" example:"octocat" binding:"required"`
+	// KeyID is the ID of the public key the signature is verified with, every key of the author is tried if empty
+	KeyID string `json:"keyId,omitempty" example:"SHA256:n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg"`
+	// Algorithm is the algorithm the signature is made with, AUTHOR_SIGNATURE_ALGORITHM if empty
+	Algorithm string `json:"algorithm,omitempty" enums:"ed25519" example:"ed25519"`
+	// Signature is the base64 encoded signature of the canonical form of the RFC
+	Signature string `json:"signature" binding:"required"`
+} // @name AuthorSignature
+
+// Attest records that the author signature of the RFC was verified at the given time with the public key of the given
+// ID, so that the key the RFC was signed with stays on record even if the key is revoked later
+func (rfc *RFC) Attest(keyID string, attestedAt time.Time) error {
+	attestation := Action{
+		ActionType: AttestationAction,
+		Target: Target{
+			TargetType:  RfcTarget,
+			LookupKey:   SignatureLookupKey,
+			LookupValue: rfc.Signature,
+		},
+		Data: map[string]interface{}{
+			string(SignerData):     rfc.AuthorSignature.Author,
+			string(KeyIDData):      keyID,
+			string(AlgorithmData):  AUTHOR_SIGNATURE_ALGORITHM,
+			string(AttestedAtData): attestedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	if err := rfc.AddAction(attestation); err != nil {
+		logging.Default.Error("unable to record RFC attestation", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+// this holds the permissions the authorization policy grants the members of Git teams, which routes require of the
+// user making the request
+package models
+
+// Permission represents an operation on RFCs the authorization policy grants
+type Permission string
+
+// SubmitPermission allows submitting, updating and withdrawing RFCs
+var SubmitPermission Permission = "submit"
+var ReviewPermission Permission = "review"
+var ApprovePermission Permission = "approve"
+var MergePermission Permission = "merge"
+var LoadPermission Permission = "load"
+
+// AdminPermission allows the operations of the admin routes, e.g. rebuilding RFC files and redelivering dead letters
+var AdminPermission Permission = "admin"
+
+// Permissions are all the permissions the authorization policy can grant
+var Permissions = []Permission{SubmitPermission, ReviewPermission, ApprovePermission, MergePermission, LoadPermission,
+	AdminPermission}
+
+// ErrNotPermitted is returned (wrapped) when the user is not granted the permission a request requires
+var ErrNotPermitted = NewError(ErrUnauthorized, NotPermittedCode, "caller is not permitted")
+
+// IsPermission returns true if the given permission is one the authorization policy can grant
+func IsPermission(permission Permission) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
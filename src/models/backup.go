@@ -0,0 +1,63 @@
+// this holds the backups of the state of a tracking repository, exported so that its open RFCs can be rebuilt in
+// another tracking repository should it be lost
+package models
+
+import "time"
+
+// BACKUP_VERSION is the version of the format backups are exported in, backups of other versions cannot be restored
+const BACKUP_VERSION = 1
+
+// ErrUnsupportedBackup is returned (wrapped) when restoring a backup exported in a format of another version
+var ErrUnsupportedBackup = NewError(ErrInvalid, UnsupportedBackupCode, "unsupported backup version")
+
+// Backup is the state of a tracking repository: the RFC file and pull request of each of its open RFCs, the action
+// data schemas and the archive index of its base branch
+type Backup struct {
+	Version   int       `json:"version" example:"1"`
+	CreatedAt time.Time `json:"createdAt" example:"2022-06-01T09:00:00Z"`
+	Domain    string    `json:"domain,omitempty" example:"catalog"`
+	// Repository is the tracking repository the backup was exported from, as owner/name
+	Repository string      `json:"repository" example:"schema-team/rfcs"`
+	RFCs       []BackupRFC `json:"rfcs"`
+	// Schemas are the contents of the JSON Schemas action data was validated against, keyed by file name, empty if
+	// action data was not validated
+	Schemas map[string]string `json:"schemas"`
+	// ArchiveIndex is the content of the archive index, empty if no RFC was archived
+	ArchiveIndex string `json:"archiveIndex,omitempty"`
+} //@name Backup
+
+// BackupRFC is an open RFC of a backup along with its pull request
+type BackupRFC struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	// Content is the raw content of the RFC file
+	Content     string            `json:"content" example:"{\"actions\": []}"`
+	PullRequest BackupPullRequest `json:"pullRequest"`
+} //@name BackupRFC
+
+// BackupPullRequest is the pull request of an open RFC of a backup
+type BackupPullRequest struct {
+	Number             int       `json:"number" example:"42"`
+	Title              string    `json:"title" example:"[RFC] Add Event"`
+	Author             string    `json:"author" example:"tstark"`
+	CreatedAt          time.Time `json:"createdAt" example:"2022-06-01T09:00:00Z"`
+	Labels             []string  `json:"labels,omitempty" example:"catalog"`
+	RequestedReviewers []string  `json:"requestedReviewers,omitempty" example:"bbanner"`
+	RequestedTeams     []string  `json:"requestedTeams,omitempty" example:"avengers"`
+} //@name BackupPullRequest
+
+// Restoration holds the outcome of restoring a backup, by RFC identifier
+type Restoration struct {
+	// Restored are the RFCs whose branch, RFC file and pull request were rebuilt, or would be on a dry run
+	Restored []string `json:"restored" example:"123456"`
+	// Skipped are the RFCs whose branch already exists, left as they are
+	Skipped []string `json:"skipped" example:"234567"`
+	// Failed are the RFCs that could not be restored, along with why
+	Failed []RestoreFailure `json:"failed"`
+	DryRun bool             `json:"dryRun" example:"false"`
+} //@name Restoration
+
+// RestoreFailure is an RFC of a backup that could not be restored
+type RestoreFailure struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"345678"`
+	Error         string `json:"error" example:"RFC 345678 file is corrupt"`
+} //@name RestoreFailure
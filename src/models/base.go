@@ -4,8 +4,8 @@ package models
 
 import (
 	"crypto/sha256"
-	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // RFCIdentifierCreator is a function type that returns a custom RFC identifier string, for example, a branch name
@@ -13,9 +13,30 @@ type RFCIdentifierCreator func() *string
 
 // RFC contains a set of actions that, in total, represent a proposal for change
 type RFC struct {
-	Actions    Actions `json:"actions" binding:"required"`
-	Signature  string  `json:"signature,omitempty" swaggerignore:"true"`
-	Identifier string  `json:"identifier,omitempty" swaggerignore:"true"`
+	// Title is a short summary of the RFC its pull request is named after, see the PR_TITLE_TEMPLATE setting
+	Title   string  `json:"title,omitempty" example:"Add EventType X"`
+	Actions Actions `json:"actions" binding:"required"`
+	// EmbargoUntil is the earliest time the RFC may be merged or loaded, e.g. the launch date of the change
+	EmbargoUntil *time.Time `json:"embargoUntil,omitempty" example:"2022-09-01T00:00:00Z"`
+	// LoadTargets are the configured load targets the RFC is loaded into, every configured target if empty
+	LoadTargets []string `json:"loadTargets,omitempty" example:"primary,search"`
+	// Priority is how urgent the RFC is, its asynchronous loads and merges are run ahead of those of less urgent RFCs,
+	// notifications about it can be routed on it and are flagged as urgent if it is high or above
+	Priority Priority `json:"priority,omitempty" enums:"low,normal,high,urgent" example:"high"`
+	// DependsOn are the RFCs that must be merged before the RFC is loaded or merged
+	DependsOn []string `json:"dependsOn,omitempty" example:"123456"`
+	// Domain is the schema domain whose tracking repository holds the RFC, the default tracking repository if empty
+	Domain string `json:"domain,omitempty" example:"catalog"`
+	// Submitter is the login of the user who submitted the RFC, recorded by Harmonia
+	Submitter string `json:"submitter,omitempty" swaggerignore:"true"`
+	// SubmitterSeal seals the submitter of an RFC submitted by a user authenticated through SSO, see SealSubmitter
+	SubmitterSeal string `json:"submitterSeal,omitempty" swaggerignore:"true"`
+	// AuthorSignature is the signature of the RFC by its author, see AuthorSignature
+	AuthorSignature *AuthorSignature `json:"authorSignature,omitempty"`
+	Signature       string           `json:"signature,omitempty" swaggerignore:"true"`
+	// SignatureVersion is the version of the form the RFC was signed as, see SIGNATURE_VERSION
+	SignatureVersion int    `json:"signatureVersion,omitempty" swaggerignore:"true"`
+	Identifier       string `json:"identifier,omitempty" swaggerignore:"true"`
 } // @name RFC
 
 // Actions is a slice of *Action types used to hold all RFC actions
@@ -28,6 +49,16 @@ type ActionType string
 var CommentAction ActionType = "comment"
 var LoadAction ActionType = "load"
 var AddAction ActionType = "add"
+var UpdateAction ActionType = "update"
+var DeleteAction ActionType = "delete"
+var RenameAction ActionType = "rename"
+var DeprecateAction ActionType = "deprecate"
+var ReserveAction ActionType = "reserve"
+var AnnotationAction ActionType = "annotation"
+var WithdrawnAction ActionType = "withdrawn"
+var BreakGlassAction ActionType = "breakGlass"
+var AttestationAction ActionType = "attestation"
+var ExternalApprovalAction ActionType = "externalApproval"
 
 // DataKey represents an attribute key within the Action Data object.
 type DataKey string
@@ -38,6 +69,31 @@ var NoteData DataKey = "note"
 var LoadStatus DataKey = "status"
 var LoadRequester DataKey = "requester"
 var ReviewerData DataKey = "reviewer"
+var EditsData DataKey = "edits"
+var EditedAtData DataKey = "editedAt"
+var DeletedData DataKey = "deleted"
+var AnalyzerData DataKey = "analyzer"
+var SeverityData DataKey = "severity"
+var MessageData DataKey = "message"
+var WithdrawerData DataKey = "withdrawer"
+var WithdrawnAtData DataKey = "withdrawnAt"
+var ReasonData DataKey = "reason"
+var ForcedByData DataKey = "forcedBy"
+var ForcedAtData DataKey = "forcedAt"
+var JustificationData DataKey = "justification"
+var NewNameData DataKey = "newName"
+var DeprecatedData DataKey = "deprecated"
+var TeamData DataKey = "team"
+var ExpiresAtData DataKey = "expiresAt"
+var SignerData DataKey = "signer"
+var KeyIDData DataKey = "keyId"
+var AlgorithmData DataKey = "algorithm"
+var AttestedAtData DataKey = "attestedAt"
+var SystemData DataKey = "system"
+var VerdictData DataKey = "verdict"
+var ReferenceData DataKey = "reference"
+var DecidedAtData DataKey = "decidedAt"
+var SealData DataKey = "seal"
 
 // Action is a struct that represents a single schema action
 type Action struct {
@@ -64,16 +120,18 @@ type Target struct {
 // SignatureLookupKey is used to target the signature attributes
 var SignatureLookupKey string = `signature`
 
-// ToSha enables an `RFC` to return a SHA256 hash of itself
+// ErrActionNotFound is returned (wrapped) when no action of an RFC matches a requested signature
+var ErrActionNotFound = NewError(ErrNotFound, ActionNotFoundCode, "action not found")
+
+// ToSha enables an `RFC` to return a SHA256 hash of itself, as of its canonical form at the current signature version
+// (see Canonical), use Sign to record it
 func (rfc *RFC) ToSha() (*string, error) {
 	// init. vars to maintain state beyond "if" statements
 	var err error
 	var jsonBytes []byte
 
-	// build JSON string
-	if jsonBytes, err = json.Marshal(rfc); err != nil {
-		errStr := "json marshal rfc error"
-		fmt.Println(errStr)
+	// build canonical JSON string
+	if jsonBytes, err = rfc.Canonical(); err != nil {
 		return nil, err
 	}
 
@@ -236,16 +294,44 @@ func (rfc *RFC) GetLoadStatus() *string {
 	return nil
 }
 
-// ToSha enables an `Action` to return a SHA256 hash of itself
+// GetAction returns the action with the given signature, nil is returned if there is none
+func (rfc *RFC) GetAction(signature string) *Action {
+	for _, action := range rfc.Actions {
+		if action.Signature == signature {
+			return action
+		}
+	}
+
+	return nil
+}
+
+// GetCommentThread returns the comments targeting the action with the given signature, followed by any replies to those
+// comments, in the order they were made
+func (rfc *RFC) GetCommentThread(signature string) Actions {
+	thread := Actions{}
+	targets := map[string]bool{signature: true}
+
+	// comments are always appended after their target, so a single pass picks up replies to replies
+	for _, action := range rfc.Actions {
+		if action.ActionType == CommentAction && action.Target.TargetType == ActionTarget &&
+			targets[action.Target.LookupValue] {
+			thread = append(thread, action)
+			targets[action.Signature] = true
+		}
+	}
+
+	return thread
+}
+
+// ToSha enables an `Action` to return a SHA256 hash of itself, as of its canonical JSON (see canonicalJSON), its
+// signature left out as it is derived from the hash
 func (action *Action) ToSha() (*string, error) {
 	// init. vars to maintain state beyond "if" statements
 	var err error
 	var jsonBytes []byte
 
-	// build JSON string
-	if jsonBytes, err = json.Marshal(action); err != nil {
-		errStr := "json marshal action error"
-		fmt.Println(errStr)
+	// build canonical JSON string
+	if jsonBytes, err = canonicalJSON(action.signedFields()); err != nil {
 		return nil, err
 	}
 
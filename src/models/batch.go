@@ -0,0 +1,12 @@
+// this holds the batch submission of RFCs, which bulk schema migrations use to submit many RFCs in a single request
+package models
+
+// MAX_BATCH_SIZE is the largest number of RFCs a batch submission may hold
+const MAX_BATCH_SIZE int = 50
+
+// ErrInvalidBatch is returned (wrapped) when a batch submission holds no RFC or more than MAX_BATCH_SIZE
+var ErrInvalidBatch = NewError(ErrInvalid, InvalidParameterCode, "invalid batch")
+
+// ErrDuplicateInBatch is returned (wrapped) for an RFC of a batch submission proposing the same change as an earlier
+// RFC of the batch
+var ErrDuplicateInBatch = NewError(ErrConflict, DuplicateRFCCode, "RFC proposes the same change as an earlier RFC")
@@ -0,0 +1,68 @@
+// this holds the break-glass path, which lets designated admins force an RFC live during an incident
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// ErrNotBreakGlassAdmin is returned (wrapped) when glass is broken by someone who is not a break-glass admin
+var ErrNotBreakGlassAdmin = NewError(ErrUnauthorized, NotBreakGlassAdminCode, "caller is not a break-glass admin")
+
+// ErrMissingJustification is returned (wrapped) when glass is broken without a justification
+var ErrMissingJustification = NewError(ErrInvalid, MissingJustificationCode, "a justification is required")
+
+// breakGlassAdmins holds the Git logins allowed to break glass
+var breakGlassAdmins = map[string]bool{}
+var breakGlassAdminsMu sync.RWMutex
+
+// RegisterBreakGlassAdmin allows the given Git login to break glass
+func RegisterBreakGlassAdmin(login string) {
+	breakGlassAdminsMu.Lock()
+	defer breakGlassAdminsMu.Unlock()
+
+	breakGlassAdmins[login] = true
+}
+
+// IsBreakGlassAdmin returns true if the given Git login may break glass
+func IsBreakGlassAdmin(login string) bool {
+	breakGlassAdminsMu.RLock()
+	defer breakGlassAdminsMu.RUnlock()
+
+	return breakGlassAdmins[login]
+}
+
+// BreakGlass records that the given admin forced the RFC live at the given time for the given justification, which
+// is required. The admin must be a break-glass admin
+func (rfc *RFC) BreakGlass(admin string, justification string, brokenAt time.Time) error {
+	if !IsBreakGlassAdmin(admin) {
+		return fmt.Errorf("%w: %s", ErrNotBreakGlassAdmin, admin)
+	}
+	if strings.TrimSpace(justification) == "" {
+		return ErrMissingJustification
+	}
+
+	breakGlass := Action{
+		ActionType: BreakGlassAction,
+		Target: Target{
+			TargetType:  RfcTarget,
+			LookupKey:   SignatureLookupKey,
+			LookupValue: rfc.Signature,
+		},
+		Data: map[string]interface{}{
+			string(ForcedByData):      admin,
+			string(ForcedAtData):      brokenAt.UTC().Format(time.RFC3339),
+			string(JustificationData): strings.TrimSpace(justification),
+		},
+	}
+	if err := rfc.AddAction(breakGlass); err != nil {
+		logging.Default.Error("unable to record RFC break-glass", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// this holds the capabilities of Git providers, so controllers and UIs can degrade gracefully on a provider that lacks
+// a feature rather than fail
+package models
+
+// holds the features the Git provider of a tracking repository supports
+type Capabilities struct {
+	Provider string `json:"provider" example:"github"`
+	// Labels is set if pull requests carry labels, RFCs can only be filtered by label if so
+	Labels bool `json:"labels" example:"true"`
+	// DraftPullRequests is set if pull requests can be drafts, which are not mergeable until marked ready
+	DraftPullRequests bool `json:"draftPullRequests" example:"true"`
+	// TeamReviewers is set if reviews are requested from teams rather than only from their members
+	TeamReviewers bool `json:"teamReviewers" example:"true"`
+	// RequiredApprovals is set if the provider can require approvals before a pull request is merged
+	RequiredApprovals bool `json:"requiredApprovals" example:"true"`
+	// CheckRuns is set if check runs are considered on top of commit statuses for mergeability
+	CheckRuns bool `json:"checkRuns" example:"true"`
+	// RequiredContexts is set if the branch protection names the status checks required to pass, so their drift
+	// can be reported
+	RequiredContexts bool `json:"requiredContexts" example:"true"`
+	// DeploymentGates is set if loads can be gated behind the protection rules of a deployment environment
+	DeploymentGates bool `json:"deploymentGates" example:"true"`
+	// Webhooks is set if the provider's webhooks are received to wake mergeability checks and load approved RFCs
+	Webhooks bool `json:"webhooks" example:"true"`
+} // @name Capabilities
@@ -0,0 +1,68 @@
+// this holds the machine-readable codes error responses carry, so clients can act on the outcome of a request without
+// matching its human-readable message
+package models
+
+// Code identifies why a request failed, codes are stable and are not renamed once released
+// They are listed as the enum of the code attribute of every error response in the swagger documentation, so clients
+// generated from it get a constant for each of them
+type Code string //@name Code
+
+// request validation codes
+var MalformedRequestCode Code = "MALFORMED_REQUEST"
+var InvalidParameterCode Code = "INVALID_PARAMETER"
+var InvalidReviewTypeCode Code = "INVALID_REVIEW_TYPE"
+var InvalidAnnotationCode Code = "INVALID_ANNOTATION"
+var InvalidActionCode Code = "INVALID_ACTION"
+var InvalidActionDataCode Code = "INVALID_ACTION_DATA"
+var InvalidDependencyCode Code = "INVALID_DEPENDENCY"
+var SignatureRequiredCode Code = "SIGNATURE_REQUIRED"
+var AuthorSignatureRequiredCode Code = "AUTHOR_SIGNATURE_REQUIRED"
+var UnsupportedBackupCode Code = "UNSUPPORTED_BACKUP"
+var UnsupportedSignatureCode Code = "UNSUPPORTED_SIGNATURE"
+var MissingJustificationCode Code = "MISSING_JUSTIFICATION"
+var UnknownLoadTargetCode Code = "UNKNOWN_LOAD_TARGET"
+var UnknownDomainCode Code = "UNKNOWN_DOMAIN"
+var UnknownChannelCode Code = "UNKNOWN_CHANNEL"
+var InvalidFilterCode Code = "INVALID_FILTER"
+var UnknownVariableCode Code = "UNKNOWN_VARIABLE"
+var CommentRejectedCode Code = "COMMENT_REJECTED"
+
+// RFC state codes
+var NotFoundCode Code = "NOT_FOUND"
+var ActionNotFoundCode Code = "ACTION_NOT_FOUND"
+var ConflictCode Code = "CONFLICT"
+var DuplicateRFCCode Code = "DUPLICATE_RFC"
+var StaleRFCCode Code = "STALE_RFC"
+var TargetReservedCode Code = "TARGET_RESERVED"
+var RFCNotMergeableCode Code = "RFC_NOT_MERGEABLE"
+var RFCEmbargoedCode Code = "RFC_EMBARGOED"
+var UnmetDependenciesCode Code = "UNMET_DEPENDENCIES"
+var RFCIntegrityCode Code = "RFC_INTEGRITY"
+var QuorumNotMetCode Code = "QUORUM_NOT_MET"
+var NoPendingGateCode Code = "NO_PENDING_GATE"
+var JobNotFoundCode Code = "JOB_NOT_FOUND"
+var HeldCommentNotFoundCode Code = "HELD_COMMENT_NOT_FOUND"
+var DeadLetterNotFoundCode Code = "DEAD_LETTER_NOT_FOUND"
+var ReservationNotFoundCode Code = "RESERVATION_NOT_FOUND"
+
+// caller codes
+var UnauthenticatedCode Code = "UNAUTHENTICATED"
+var PermissionDeniedCode Code = "PERMISSION_DENIED"
+var NotRFCAuthorCode Code = "NOT_RFC_AUTHOR"
+var NotCommentAuthorCode Code = "NOT_COMMENT_AUTHOR"
+var NotReservationTeamCode Code = "NOT_RESERVATION_TEAM"
+var NotBreakGlassAdminCode Code = "NOT_BREAK_GLASS_ADMIN"
+var NotPermittedCode Code = "NOT_PERMITTED"
+var CrossTenantCode Code = "CROSS_TENANT"
+var UnknownAnalyzerCode Code = "UNKNOWN_ANALYZER"
+var UnknownApproverCode Code = "UNKNOWN_APPROVER"
+var InvalidSignatureCode Code = "INVALID_SIGNATURE"
+var InvalidAuthorSignatureCode Code = "INVALID_AUTHOR_SIGNATURE"
+var ReplayedRequestCode Code = "REPLAYED_REQUEST"
+
+// service codes
+var RateLimitedCode Code = "RATE_LIMITED"
+var ProviderErrorCode Code = "PROVIDER_ERROR"
+var MaintenanceCode Code = "MAINTENANCE"
+var ConfigurationErrorCode Code = "CONFIGURATION_ERROR"
+var InternalErrorCode Code = "INTERNAL_ERROR"
@@ -0,0 +1,75 @@
+// this holds the editing and deletion of RFC comments by their author
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrNotCommentAuthor is returned (wrapped) when a comment is edited or deleted by someone other than its author
+var ErrNotCommentAuthor = NewError(ErrUnauthorized, NotCommentAuthorCode, "comment was not made by the caller")
+
+// getComment returns the comment action with the given signature, provided it was made by the given login
+func (rfc *RFC) getComment(signature string, login string) (*Action, error) {
+	comment := rfc.GetAction(signature)
+	if comment == nil || comment.ActionType != CommentAction || comment.Data[string(DeletedData)] == true {
+		return nil, fmt.Errorf("%w: no comment with signature %s", ErrActionNotFound, signature)
+	}
+	if comment.Data[string(CommenterData)] != login {
+		return nil, fmt.Errorf("%w: comment %s was made by %v", ErrNotCommentAuthor, signature,
+			comment.Data[string(CommenterData)])
+	}
+
+	return comment, nil
+}
+
+// EditComment replaces the text of the comment with the given signature, made by the given editor, and records the
+// previous text in the comment's edit history. The previous text is returned
+// The comment keeps its signature so that replies to it remain part of its thread
+func (rfc *RFC) EditComment(signature string, text string, editor string, editedAt time.Time) (*string, error) {
+	comment, err := rfc.getComment(signature, editor)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := fmt.Sprint(comment.Data[string(CommentData)])
+	edits, _ := comment.Data[string(EditsData)].([]interface{})
+	comment.Data[string(EditsData)] = append(edits, map[string]interface{}{
+		string(CommentData):  previous,
+		string(EditedAtData): editedAt.UTC().Format(time.RFC3339),
+	})
+	comment.Data[string(CommentData)] = text
+
+	return &previous, nil
+}
+
+// DeleteComment deletes the comment with the given signature, made by the given deleter, and returns its text
+// A comment that was replied to is kept, without its text or edit history, so that the replies remain part of its
+// thread
+func (rfc *RFC) DeleteComment(signature string, deleter string) (*string, error) {
+	comment, err := rfc.getComment(signature, deleter)
+	if err != nil {
+		return nil, err
+	}
+	text := fmt.Sprint(comment.Data[string(CommentData)])
+
+	for _, action := range rfc.Actions {
+		if action.ActionType == CommentAction && action.Target.TargetType == ActionTarget &&
+			action.Target.LookupValue == signature {
+			comment.Data = map[string]interface{}{
+				string(CommenterData): deleter,
+				string(DeletedData):   true,
+			}
+			return &text, nil
+		}
+	}
+
+	for i, action := range rfc.Actions {
+		if action == comment {
+			rfc.Actions = append(rfc.Actions[:i], rfc.Actions[i+1:]...)
+			break
+		}
+	}
+
+	return &text, nil
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDeleteComment tests that comments nobody replied to are removed from the RFC
+func TestDeleteComment(t *testing.T) {
+	// arrange
+	rfc := &RFC{Signature: "rfc-sha"}
+	if err := rfc.AddComments(map[string][]string{"rfc-sha": {"first", "second"}}, "tstark"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	first, second := rfc.Actions[0].Signature, rfc.Actions[1].Signature
+
+	// act
+	text, err := rfc.DeleteComment(first, "tstark")
+	_, editErr := rfc.EditComment(first, "again", "tstark", time.Now())
+
+	// assert
+	if err != nil || *text != "first" {
+		t.Errorf("unexpected deletion. text: %v, err: %v", text, err)
+	}
+	if len(rfc.Actions) != 1 || rfc.Actions[0].Signature != second {
+		t.Errorf("expected only the second comment to remain, got %v", rfc.Actions)
+	}
+	if editErr == nil {
+		t.Errorf("expected deleted comments not to be editable")
+	}
+}
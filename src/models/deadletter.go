@@ -0,0 +1,23 @@
+// this holds the dead letters of notifications, the deliveries that kept failing until they were given up on
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ErrDeadLetterNotFound is returned (wrapped) when no dead letter matches a requested ID
+var ErrDeadLetterNotFound = NewError(ErrNotFound, DeadLetterNotFoundCode, "dead letter not found")
+
+// DeadLetter is a notification whose delivery was given up on, kept so it can be inspected and delivered again
+type DeadLetter struct {
+	ID      string `json:"id" example:"9b1c4e7a2f3d5c6e"`
+	Channel string `json:"channel" example:"webhook"`
+	// Payload is the body the delivery posted, redelivered as-is
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	Event   Event           `json:"event"`
+	// Attempts is the number of deliveries made, redeliveries included
+	Attempts  int       `json:"attempts" example:"3"`
+	LastError string    `json:"lastError" example:"webhook notification rejected with status 503"`
+	FailedAt  time.Time `json:"failedAt" example:"2022-06-01T09:00:00Z"`
+} //@name DeadLetter
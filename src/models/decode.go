@@ -0,0 +1,148 @@
+// this holds JSON decoding helpers that report where in a document decoding failed
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// number of bytes shown on either side of the failure offset in a DecodeError snippet
+const decodeSnippetRadius = 24
+
+// DecodeError describes a JSON decoding failure and where in the document it occurred
+type DecodeError struct {
+	// Path is the JSON path of the value being decoded when the failure occurred, for example $.actions[1].target
+	Path string
+	// Offset is the byte offset in the document at which the failure occurred
+	Offset int64
+	// Snippet is the portion of the document surrounding the failure
+	Snippet string
+	// Err is the underlying decoding error
+	Err error
+}
+
+// Error returns a description of the failure including its location
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s at %s (offset %d, near `%s`)", e.Err.Error(), e.Path, e.Offset, e.Snippet)
+}
+
+// Unwrap returns the underlying decoding error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Unmarshal decodes the given JSON document into v, just like json.Unmarshal, but on failure returns a *DecodeError
+// that reports the JSON path and a snippet of the document where the failure occurred
+func Unmarshal(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	// determine the offset of the failure, if the error carries one
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	return &DecodeError{
+		Path:    jsonPathAt(data, offset),
+		Offset:  offset,
+		Snippet: snippetAt(data, offset),
+		Err:     err,
+	}
+}
+
+// jsonPathFrame tracks the position within a single JSON object or array while walking a document
+type jsonPathFrame struct {
+	array     bool
+	index     int
+	key       string
+	expectKey bool
+}
+
+// jsonPathAt walks the given document and returns the JSON path of the value being read at the given offset
+func jsonPathAt(data []byte, offset int64) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	stack := []*jsonPathFrame{}
+	path := "$"
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+
+		delim, isDelim := tok.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+		} else if len(stack) > 0 && stack[len(stack)-1].expectKey {
+			// object keys are only recorded, the value that follows is what is being read
+			top := stack[len(stack)-1]
+			top.key = fmt.Sprint(tok)
+			top.expectKey = false
+			path = renderJSONPath(stack)
+		} else {
+			// this token starts a value
+			if len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if top.array {
+					top.index++
+				} else {
+					top.expectKey = true
+				}
+			}
+			path = renderJSONPath(stack)
+			if isDelim {
+				stack = append(stack, &jsonPathFrame{array: delim == '[', index: -1, expectKey: delim == '{'})
+			}
+		}
+
+		if dec.InputOffset() >= offset {
+			break
+		}
+	}
+
+	return path
+}
+
+// renderJSONPath renders the given frames as a JSON path expression
+func renderJSONPath(stack []*jsonPathFrame) string {
+	var sb strings.Builder
+	sb.WriteString("$")
+	for _, frame := range stack {
+		if frame.array && frame.index >= 0 {
+			sb.WriteString(fmt.Sprintf("[%d]", frame.index))
+		} else if !frame.array && frame.key != "" {
+			sb.WriteString("." + frame.key)
+		}
+	}
+	return sb.String()
+}
+
+// snippetAt returns the portion of the given document surrounding the given offset, with whitespace collapsed
+func snippetAt(data []byte, offset int64) string {
+	start := offset - decodeSnippetRadius
+	if start < 0 {
+		start = 0
+	}
+	end := offset + decodeSnippetRadius
+	if end > int64(len(data)) {
+		end = int64(len(data))
+	}
+	if start > end {
+		start = end
+	}
+
+	return strings.Join(strings.Fields(string(data[start:end])), " ")
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestUnmarshal tests that decoding failures report the JSON path and snippet of the failure
+func TestUnmarshal(t *testing.T) {
+	testCases := []struct {
+		data         string
+		expectedPath string
+		snippet      string
+	}{
+		{
+			data:         `junk-data`,
+			expectedPath: "$",
+			snippet:      "junk-data",
+		},
+		{
+			data:         `{"actions": [{"actionType": "add"}, {"actionType": 5}]}`,
+			expectedPath: "$.actions[1].actionType",
+			snippet:      `"actionType": 5`,
+		},
+		{
+			data:         `{"actions": [{"actionType": "add", "target": {"targetType": "item", "lookupKey": true}}]}`,
+			expectedPath: "$.actions[0].target.lookupKey",
+			snippet:      `"lookupKey": true`,
+		},
+		{
+			data:         `{"actions": [{"actionType": "add"}, {"actionType": "add",, }]}`,
+			expectedPath: "$.actions[1].actionType",
+			snippet:      `"add",,`,
+		},
+		{
+			data:         `{"actions": {"actionType": "add"}}`,
+			expectedPath: "$.actions",
+			snippet:      `{"actions": {"actionType"`,
+		},
+	}
+
+	for _, test := range testCases {
+		err := Unmarshal([]byte(test.data), &RFC{})
+
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("expected a DecodeError for %s, got %v", test.data, err)
+			continue
+		}
+		if decodeErr.Path != test.expectedPath {
+			t.Errorf("unexpected path for %s. expected: %s\n actual: %s", test.data, test.expectedPath, decodeErr.Path)
+		}
+		if !strings.Contains(decodeErr.Snippet, test.snippet) {
+			t.Errorf("unexpected snippet for %s. expected to contain: %s\n actual: %s", test.data, test.snippet,
+				decodeErr.Snippet)
+		}
+	}
+
+	// valid documents decode as usual
+	rfc := &RFC{}
+	if err := Unmarshal([]byte(`{"actions": [{"actionType": "add"}]}`), rfc); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	} else if len(rfc.Actions) != 1 || rfc.Actions[0].ActionType != AddAction {
+		t.Errorf("unexpected decoded value: %v", rfc.Actions)
+	}
+}
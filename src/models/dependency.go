@@ -0,0 +1,73 @@
+// this holds the dependencies of RFCs on other RFCs, which must be merged before the RFCs depending on them are loaded
+// or merged
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependencyState represents the state of an RFC another RFC depends on
+type DependencyState string
+
+var MergedDependency DependencyState = "merged"
+var OpenDependency DependencyState = "open"
+var ClosedDependency DependencyState = "closed"
+var MissingDependency DependencyState = "missing"
+
+// ErrInvalidDependency is returned (wrapped) when an RFC depends on an RFC that does not exist, was closed without
+// being merged or depends on it in turn
+var ErrInvalidDependency = NewError(ErrInvalid, InvalidDependencyCode, "invalid dependency")
+
+// ErrUnmetDependencies is returned (wrapped) when an RFC is loaded or merged before every RFC it depends on is merged
+var ErrUnmetDependencies = NewError(ErrConflict, UnmetDependenciesCode, "dependencies not merged")
+
+// Dependency is an RFC another RFC depends on, along with its state
+type Dependency struct {
+	RFCIdentifier string          `json:"rfcIdentifier" example:"123456"`
+	State         DependencyState `json:"state" swaggertype:"string" enums:"merged,open,closed,missing" example:"open"`
+} //@name Dependency
+
+// CheckDependencies returns ErrUnmetDependencies (wrapped) naming the given dependencies of the RFC with the given
+// identifier that are not merged
+func CheckDependencies(rfcIdentifier string, dependencies []Dependency) error {
+	var unmet []string
+	for _, dependency := range dependencies {
+		if dependency.State != MergedDependency {
+			unmet = append(unmet, fmt.Sprintf("%s (%s)", dependency.RFCIdentifier, dependency.State))
+		}
+	}
+	if len(unmet) > 0 {
+		return fmt.Errorf("%w: RFC %s depends on RFCs that are not merged: %s", ErrUnmetDependencies, rfcIdentifier,
+			strings.Join(unmet, ", "))
+	}
+
+	return nil
+}
+
+// validateDependencies returns the errors of the dependencies the RFC declares: each must name an RFC, once
+func (rfc *RFC) validateDependencies() []ValidationError {
+	var errs []ValidationError
+	declared := map[string]bool{}
+	for i, dependency := range rfc.DependsOn {
+		field := fmt.Sprintf("dependsOn[%d]", i)
+		if strings.TrimSpace(dependency) == "" {
+			errs = append(errs, ValidationError{Field: field, Message: "RFC identifier is required"})
+		} else if declared[dependency] {
+			errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf(
+				"RFC %s is already a dependency", dependency)})
+		}
+		declared[dependency] = true
+	}
+
+	return errs
+}
+
+// CheckDependencyDeclarations returns ErrInvalidDependency (wrapped) describing the first dependency the RFC declares
+// that does not name an RFC or that is declared twice, see Validate
+func (rfc *RFC) CheckDependencyDeclarations() error {
+	if errs := rfc.validateDependencies(); len(errs) > 0 {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidDependency, errs[0].Field, errs[0].Message)
+	}
+	return nil
+}
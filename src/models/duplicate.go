@@ -0,0 +1,74 @@
+// this holds the detection of RFCs that are submitted more than once
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// DuplicateError reports a submitted RFC whose proposed change is identical to the one of an open RFC
+type DuplicateError struct {
+	// RFCIdentifier is the identifier of the open RFC the submission duplicates
+	RFCIdentifier string
+}
+
+// Error returns a description of the duplicate submission
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("RFC is identical to open RFC %s", e.RFCIdentifier)
+}
+
+// Is returns whether the given error is ErrConflict, the kind of duplicate errors
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// IsProposal returns whether the action is part of the change proposed by its RFC, as opposed to a review, comment,
+// annotation or load action recorded against the RFC or its actions
+func (action *Action) IsProposal() bool {
+	if action.ActionType == LoadAction || action.ActionType == CommentAction || action.ActionType == AnnotationAction {
+		return false
+	}
+
+	return action.Target.TargetType != RfcTarget && action.Target.TargetType != ActionTarget
+}
+
+// ContentSignature returns a SHA256 hash of the change proposed by this RFC, i.e. its proposal actions, embargo and
+// load targets. Unlike ToSha it ignores signatures, reviews, comments and load status so that two submissions of the
+// same change share the same content signature
+func (rfc *RFC) ContentSignature() (*string, error) {
+	actions := Actions{}
+	for _, action := range rfc.Actions {
+		if action.IsProposal() {
+			proposal := *action
+			proposal.Signature = ""
+			actions = append(actions, &proposal)
+		}
+	}
+	loadTargets := append([]string{}, rfc.LoadTargets...)
+	sort.Strings(loadTargets)
+	var embargoUntil *time.Time
+	if rfc.EmbargoUntil != nil {
+		utc := rfc.EmbargoUntil.UTC()
+		embargoUntil = &utc
+	}
+
+	// build canonical JSON string, map keys are always marshaled in sorted order
+	jsonBytes, err := json.Marshal(struct {
+		Actions      Actions    `json:"actions"`
+		EmbargoUntil *time.Time `json:"embargoUntil"`
+		LoadTargets  []string   `json:"loadTargets"`
+	}{actions, embargoUntil, loadTargets})
+	if err != nil {
+		logging.Default.Error("json marshal rfc content error", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	hashStr := fmt.Sprintf("%x", sha256.Sum256(jsonBytes))
+
+	return &hashStr, nil
+}
@@ -0,0 +1,32 @@
+// this holds the embargo that keeps an RFC from going live before a launch date
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// EmbargoError is returned when an RFC is merged or loaded before its embargo ends
+type EmbargoError struct {
+	RFCIdentifier string
+	Until         time.Time
+}
+
+// Error returns a description of the embargo
+func (e *EmbargoError) Error() string {
+	return fmt.Sprintf("RFC %s is embargoed until %s", e.RFCIdentifier, e.Until.UTC().Format(time.RFC3339))
+}
+
+// Embargoed returns whether the RFC is still embargoed at the given time
+func (rfc *RFC) Embargoed(now time.Time) bool {
+	return rfc.EmbargoUntil != nil && now.Before(*rfc.EmbargoUntil)
+}
+
+// CheckEmbargo returns an *EmbargoError if the RFC with the given identifier is still embargoed at the given time
+func (rfc *RFC) CheckEmbargo(rfcIdentifier string, now time.Time) error {
+	if rfc.Embargoed(now) {
+		return &EmbargoError{RFCIdentifier: rfcIdentifier, Until: *rfc.EmbargoUntil}
+	}
+
+	return nil
+}
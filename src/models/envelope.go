@@ -0,0 +1,70 @@
+// this holds the optional response envelope, which gives every endpoint a consistent response shape
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Envelope wraps the response of any endpoint when requested by the client
+// Successful responses carry the endpoint response under data, while failed responses (4xx/5xx) carry error instead
+type Envelope struct {
+	Data        json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	Error       *EnvelopeError  `json:"error,omitempty"`
+	Status      int             `json:"status" example:"200"`
+	RequestID   string          `json:"requestId" example:"4f1d2c3b5a6e7f8091a2b3c4d5e6f708"`
+	RequestedAt time.Time       `json:"requestedAt" example:"2022-06-01T09:00:00Z"`
+	RespondedAt time.Time       `json:"respondedAt" example:"2022-06-01T09:00:01Z"`
+} //@name Envelope
+
+// EnvelopeError describes why an enveloped request failed
+type EnvelopeError struct {
+	Message string `json:"message" example:"whoops!"`
+	// Code identifies why the request failed, it is empty if the error response does not carry one
+	Code Code `json:"code,omitempty" example:"NOT_FOUND"`
+	// Details holds the original error response when it carries more than a message, e.g. an Integrity response
+	Details json.RawMessage `json:"details,omitempty" swaggertype:"object"`
+} //@name EnvelopeError
+
+// NewEnvelope wraps the given JSON response body, sent with the given status code, in an Envelope
+// Error messages and codes are taken from the "error" and "code" attributes every error response (see Error) carries,
+// messages falling back to the status text
+func NewEnvelope(status int, body []byte, requestID string, requestedAt time.Time) *Envelope {
+	envelope := &Envelope{
+		Status:      status,
+		RequestID:   requestID,
+		RequestedAt: requestedAt.UTC(),
+		RespondedAt: time.Now().UTC(),
+	}
+	if status < http.StatusBadRequest {
+		if len(body) > 0 {
+			envelope.Data = body
+		}
+		return envelope
+	}
+
+	envelope.Error = &EnvelopeError{Message: http.StatusText(status)}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return envelope
+	}
+	if raw, ok := fields["error"]; ok {
+		var message string
+		if json.Unmarshal(raw, &message) == nil && message != "" {
+			envelope.Error.Message = message
+		}
+		delete(fields, "error")
+	}
+	if raw, ok := fields["code"]; ok {
+		if json.Unmarshal(raw, &envelope.Error.Code) != nil {
+			envelope.Error.Code = ""
+		}
+		delete(fields, "code")
+	}
+	if len(fields) > 0 {
+		envelope.Error.Details = body
+	}
+
+	return envelope
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestNewEnvelope tests that responses are placed under data and errors under error
+func TestNewEnvelope(t *testing.T) {
+	requestedAt := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		status          int
+		body            string
+		expectedData    string
+		expectedMessage string
+		expectedCode    Code
+		expectedDetails string
+	}{
+		{
+			status:       http.StatusOK,
+			body:         `{"success":"Success!"}`,
+			expectedData: `{"success":"Success!"}`,
+		},
+		{
+			status:          http.StatusBadRequest,
+			body:            `{"error":"Malformed request received","code":"MALFORMED_REQUEST"}`,
+			expectedMessage: "Malformed request received",
+			expectedCode:    MalformedRequestCode,
+		},
+		{
+			status:          http.StatusConflict,
+			body:            `{"error":"RFC 123456 file is corrupt","code":"RFC_INTEGRITY","reason":"corrupt"}`,
+			expectedMessage: "RFC 123456 file is corrupt",
+			expectedCode:    RFCIntegrityCode,
+			expectedDetails: `{"error":"RFC 123456 file is corrupt","code":"RFC_INTEGRITY","reason":"corrupt"}`,
+		},
+		{
+			status:          http.StatusServiceUnavailable,
+			body:            `{"ready":false}`,
+			expectedMessage: "Service Unavailable",
+			expectedDetails: `{"ready":false}`,
+		},
+	}
+
+	for _, test := range testCases {
+		envelope := NewEnvelope(test.status, []byte(test.body), "request-id", requestedAt)
+		if envelope.Status != test.status || envelope.RequestID != "request-id" ||
+			!envelope.RequestedAt.Equal(requestedAt) || envelope.RespondedAt.IsZero() {
+			t.Errorf("unexpected envelope metadata: %+v", envelope)
+		}
+		if string(envelope.Data) != test.expectedData {
+			t.Errorf("unexpected data. expected: %s\n actual: %s", test.expectedData, envelope.Data)
+		}
+		if test.expectedMessage == "" {
+			if envelope.Error != nil {
+				t.Errorf("unexpected error: %+v", envelope.Error)
+			}
+		} else if envelope.Error == nil || envelope.Error.Message != test.expectedMessage ||
+			envelope.Error.Code != test.expectedCode || string(envelope.Error.Details) != test.expectedDetails {
+			t.Errorf("unexpected error. expected: %s %s %s\n actual: %+v", test.expectedMessage, test.expectedCode,
+				test.expectedDetails, envelope.Error)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// this holds the kinds of failure errors are classified in, so the routes can respond to an error with an accurate
+// status and code wherever it was raised
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of failure, errors of a kind are matched to it by errors.Is
+var (
+	// ErrInvalid is the kind of errors caused by a request that can never succeed as it is
+	ErrInvalid = errors.New("invalid request")
+	// ErrNotFound is the kind of errors caused by a resource (RFC, action, branch...) that does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is the kind of errors caused by a request that conflicts with the current state of a resource
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthenticated is the kind of errors caused by a caller that did not say who they are
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrUnauthorized is the kind of errors caused by a caller that is not allowed to perform the request
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited is the kind of errors caused by a throttled client, the request can be retried later
+	ErrRateLimited = errors.New("rate limited")
+	// ErrProvider is the kind of every error returned by the Git provider, see ProviderError
+	ErrProvider = errors.New("provider error")
+)
+
+// KindError is an error of a kind of failure identified by its own code
+// errors.Is reports a match for both the error itself and its kind
+type KindError struct {
+	// Kind is one of ErrInvalid, ErrNotFound, ErrConflict, ErrUnauthenticated, ErrUnauthorized or ErrRateLimited
+	Kind    error
+	Code    Code
+	Message string
+}
+
+// NewError returns an error of the given kind and code with the given message, meant to be declared as a sentinel
+func NewError(kind error, code Code, message string) error {
+	return &KindError{Kind: kind, Code: code, Message: message}
+}
+
+// Error returns the message of the error
+func (e *KindError) Error() string {
+	return e.Message
+}
+
+// Is returns whether the given error is the kind of the error
+func (e *KindError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// ProviderError is an error returned by a Git provider, mapped to the kind of failure it corresponds to
+// errors.Is reports a match for ErrProvider, the kind of failure and any error wrapped by the provider error
+type ProviderError struct {
+	// Kind is one of ErrNotFound, ErrConflict, ErrRateLimited or ErrUnauthorized, nil if the provider error is none of
+	// them
+	Kind error
+	// Err is the provider error
+	Err error
+}
+
+// Error returns the kind of failure along with the provider error
+func (e *ProviderError) Error() string {
+	if e.Kind == nil {
+		return fmt.Sprintf("%s: %s", ErrProvider.Error(), e.Err.Error())
+	}
+	return fmt.Sprintf("%s: %s", e.Kind.Error(), e.Err.Error())
+}
+
+// Unwrap returns the provider error
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns whether the given error is ErrProvider or the kind of failure
+func (e *ProviderError) Is(target error) bool {
+	return target == ErrProvider || (e.Kind != nil && target == e.Kind)
+}
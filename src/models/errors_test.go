@@ -0,0 +1,56 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestErrorKinds tests that errors match their kind of failure, and only it
+func TestErrorKinds(t *testing.T) {
+	providerErr := fmt.Errorf("status 500")
+	testCases := []struct {
+		err      error
+		matches  []error
+		excludes []error
+	}{
+		{
+			err:      fmt.Errorf("%w: abc", ErrActionNotFound),
+			matches:  []error{ErrActionNotFound, ErrNotFound},
+			excludes: []error{ErrConflict, ErrProvider},
+		},
+		{
+			err:      &DuplicateError{RFCIdentifier: "1"},
+			matches:  []error{ErrConflict},
+			excludes: []error{ErrNotFound},
+		},
+		{
+			err:      &ProviderError{Kind: ErrUnauthorized, Err: providerErr},
+			matches:  []error{ErrUnauthorized, ErrProvider, providerErr},
+			excludes: []error{ErrNotFound},
+		},
+		{
+			err:      &ProviderError{Err: providerErr},
+			matches:  []error{ErrProvider, providerErr},
+			excludes: []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrRateLimited},
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, target := range testCase.matches {
+			if !errors.Is(testCase.err, target) {
+				t.Errorf("expected %v to match %v", testCase.err, target)
+			}
+		}
+		for _, target := range testCase.excludes {
+			if errors.Is(testCase.err, target) {
+				t.Errorf("expected %v not to match %v", testCase.err, target)
+			}
+		}
+	}
+
+	var kindErr *KindError
+	if !errors.As(fmt.Errorf("%w: primary", ErrUnknownLoadTarget), &kindErr) || kindErr.Code != UnknownLoadTargetCode {
+		t.Errorf("expected unknown load targets to carry their code, got %+v", kindErr)
+	}
+}
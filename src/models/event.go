@@ -0,0 +1,50 @@
+// this holds RFC lifecycle event definitions that are broadcast by the event bus
+package models
+
+import (
+	"time"
+)
+
+// EventType represents a specific RFC lifecycle event
+type EventType string
+
+// supported RFC lifecycle event types
+var SubmitEvent EventType = "submit"
+var UpdateEvent EventType = "update"
+var ReviewEvent EventType = "review"
+var LoadEvent EventType = "load"
+var MergeEvent EventType = "merge"
+var RebuildEvent EventType = "rebuild"
+var CommentEvent EventType = "comment"
+var AnnotateEvent EventType = "annotate"
+var WithdrawEvent EventType = "withdraw"
+var BreakGlassEvent EventType = "breakGlass"
+
+// DigestEvent identifies periodic digest notifications, digests are not published on the event bus
+var DigestEvent EventType = "digest"
+
+// ReviewReminderEvent identifies reminders of review requests awaited past the review SLA, reminders are not published
+// on the event bus
+var ReviewReminderEvent EventType = "reviewReminder"
+
+// Event represents a single occurrence in the lifecycle of an RFC
+type Event struct {
+	Type          EventType `json:"type" example:"submit"`
+	RFCIdentifier string    `json:"rfcIdentifier" example:"123456"`
+	Actor         string    `json:"actor,omitempty" example:"tstark"`
+	Message       string    `json:"message,omitempty" example:"Successfully reviewed RFC 123456 with type of 'APPROVE'"`
+	Timestamp     time.Time `json:"timestamp" example:"2022-06-01T12:00:00Z"`
+	// Subject describes the RFC the event is about so notifications can be routed on it, nil if it is unknown
+	Subject *EventSubject `json:"subject,omitempty"`
+} // @name Event
+
+// EventSubject describes the RFC an event is about
+type EventSubject struct {
+	ActionTypes       []ActionType `json:"actionTypes,omitempty" example:"add,update"`
+	TargetDescriptors []string     `json:"targetDescriptors,omitempty" example:"EntityType,FieldType"`
+	// Teams are the teams that own the targets changed by the RFC
+	Teams    []string `json:"teams,omitempty" example:"catalog-team"`
+	Priority string   `json:"priority,omitempty" example:"high"`
+	// Domain is the schema domain of the RFC, empty for the default one
+	Domain string `json:"domain,omitempty" example:"catalog"`
+} // @name EventSubject
@@ -0,0 +1,163 @@
+// this holds the approvals and rejections of RFCs registered by external systems, e.g. change advisory board tools,
+// which the approval policy counts alongside the reviews of the Git provider
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// Verdict is the decision of an external system on an RFC
+type Verdict string
+
+// ApprovedVerdict approves an RFC, RejectedVerdict blocks its merge until the system approves it
+var ApprovedVerdict Verdict = "approved"
+var RejectedVerdict Verdict = "rejected"
+
+// ErrUnknownApprover is returned (wrapped) when a verdict is recorded by an external system that is not an approver
+var ErrUnknownApprover = NewError(ErrUnauthorized, UnknownApproverCode, "external system is not an approver")
+
+// approvers holds the names of the external systems allowed to approve or reject RFCs
+var approvers = map[string]bool{}
+var approversMu sync.RWMutex
+
+// RegisterExternalApprover allows the external system with the given name to approve or reject RFCs
+func RegisterExternalApprover(system string) {
+	approversMu.Lock()
+	defer approversMu.Unlock()
+
+	approvers[system] = true
+}
+
+// IsExternalApprover returns true if the external system with the given name may approve or reject RFCs
+func IsExternalApprover(system string) bool {
+	approversMu.RLock()
+	defer approversMu.RUnlock()
+
+	return approvers[system]
+}
+
+// ErrStaleVerdict is returned (wrapped) when an external system decides on an RFC that has changed since
+var ErrStaleVerdict = NewError(ErrConflict, StaleRFCCode, "RFC changed since it was decided on")
+
+// ExternalVerdict is the latest decision of an external system on an RFC
+type ExternalVerdict struct {
+	System    string  `json:"system" example:"cab"`
+	Verdict   Verdict `json:"verdict" enums:"approved,rejected" example:"approved"`
+	Reference string  `json:"reference,omitempty" example:"CHG0042"`
+	Reason    string  `json:"reason,omitempty" example:"Approved by the weekly CAB"`
+	DecidedAt string  `json:"decidedAt" example:"2022-06-01T12:00:00Z"`
+	// Seal is the HMAC of the verdict keyed with the secret of the system, see SealedContent
+	Seal string `json:"-"`
+} // @name ExternalVerdict
+
+// Sealer returns the HMAC of the given content keyed with the secret of the given external system
+type Sealer func(system string, content []byte) (string, error)
+
+// SealChecker returns whether the given seal is the HMAC of the given content keyed with the secret of the given
+// external system
+type SealChecker func(system string, content []byte, seal string) bool
+
+// SealedContent returns the content the seal of the verdict is made over: the verdict along with the signature of the
+// RFC it was made on. The RFC file can be changed by anyone who can push to its branch, while the seal can only be
+// made with the secret of the system, so verdicts whose seal does not match are not the system's
+func (verdict ExternalVerdict) SealedContent(rfcSignature string) ([]byte, error) {
+	return canonicalJSON(map[string]interface{}{
+		"rfcSignature":        rfcSignature,
+		string(SystemData):    verdict.System,
+		string(VerdictData):   string(verdict.Verdict),
+		string(ReferenceData): verdict.Reference,
+		string(ReasonData):    verdict.Reason,
+		string(DecidedAtData): verdict.DecidedAt,
+	})
+}
+
+// RecordVerdict records the given verdict of the given external system on the RFC as it is currently signed, along
+// with the reference of the decision in the system (e.g. a change ticket) and the reason given, if any, sealed with
+// the given sealer. Verdicts apply to the content the RFC was decided on, see ExternalVerdicts
+func (rfc *RFC) RecordVerdict(system string, verdict Verdict, reference string, reason string, decidedAt time.Time,
+	seal Sealer) error {
+	if !IsExternalApprover(system) {
+		return fmt.Errorf("%w: %s", ErrUnknownApprover, system)
+	}
+
+	recorded := ExternalVerdict{
+		System:    system,
+		Verdict:   verdict,
+		Reference: strings.TrimSpace(reference),
+		Reason:    strings.TrimSpace(reason),
+		DecidedAt: decidedAt.UTC().Format(time.RFC3339),
+	}
+	content, err := recorded.SealedContent(rfc.Signature)
+	if err != nil {
+		return err
+	}
+	if recorded.Seal, err = seal(system, content); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		string(SystemData):    recorded.System,
+		string(VerdictData):   string(recorded.Verdict),
+		string(DecidedAtData): recorded.DecidedAt,
+		string(SealData):      recorded.Seal,
+	}
+	if recorded.Reference != "" {
+		data[string(ReferenceData)] = recorded.Reference
+	}
+	if recorded.Reason != "" {
+		data[string(ReasonData)] = recorded.Reason
+	}
+
+	externalApproval := Action{
+		ActionType: ExternalApprovalAction,
+		Target: Target{
+			TargetType:  RfcTarget,
+			LookupKey:   SignatureLookupKey,
+			LookupValue: rfc.Signature,
+		},
+		Data: data,
+	}
+	if err := rfc.AddAction(externalApproval); err != nil {
+		logging.Default.Error("unable to record RFC external verdict", "system", system, logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// ExternalVerdicts returns the latest verdict of each external system on the RFC as it is currently signed, keyed by
+// system. Verdicts on content the RFC no longer proposes are ignored, as are verdicts whose seal the given checker
+// rejects, so a forged verdict never hides a verdict the system made
+func (rfc *RFC) ExternalVerdicts(check SealChecker) map[string]ExternalVerdict {
+	verdicts := map[string]ExternalVerdict{}
+	for _, action := range rfc.Actions {
+		if action.ActionType != ExternalApprovalAction || action.Target.LookupValue != rfc.Signature {
+			continue
+		}
+		verdict := ExternalVerdict{}
+		verdict.System, _ = action.Data[string(SystemData)].(string)
+		verdict.Reference, _ = action.Data[string(ReferenceData)].(string)
+		verdict.Reason, _ = action.Data[string(ReasonData)].(string)
+		verdict.DecidedAt, _ = action.Data[string(DecidedAtData)].(string)
+		verdict.Seal, _ = action.Data[string(SealData)].(string)
+		if value, ok := action.Data[string(VerdictData)].(string); ok {
+			verdict.Verdict = Verdict(value)
+		}
+		if verdict.System == "" {
+			continue
+		}
+		content, err := verdict.SealedContent(rfc.Signature)
+		if err != nil || !check(verdict.System, content, verdict.Seal) {
+			logging.Default.Warn("ignoring external verdict with an invalid seal", "system", verdict.System)
+			continue
+		}
+		verdicts[verdict.System] = verdict
+	}
+
+	return verdicts
+}
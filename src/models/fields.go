@@ -0,0 +1,47 @@
+// this holds the fields clients select on list requests, so the data they do not need is neither computed nor returned
+package models
+
+import (
+	"fmt"
+)
+
+// RFCField represents a field of the RFCs listed by getRfcs that clients may select
+type RFCField string //@name RFCField
+var LinksField RFCField = "links"
+var StateField RFCField = "state"
+var ReviewsField RFCField = "reviews"
+var MergeabilityField RFCField = "mergeability"
+var LoadStatusField RFCField = "loadStatus"
+var DependenciesField RFCField = "dependencies"
+
+// ErrInvalidField is returned (wrapped) when selecting a field that is not one of the supported fields
+var ErrInvalidField = NewError(ErrInvalid, InvalidParameterCode, "invalid field")
+
+// rfcFields holds every supported field, the summary fields (all but links) each cost calls to the Git provider
+var rfcFields = []RFCField{LinksField, StateField, ReviewsField, MergeabilityField, LoadStatusField,
+	DependenciesField}
+
+// Valid returns whether the field is one of the supported fields
+func (f RFCField) Valid() bool {
+	for _, field := range rfcFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Summarized returns whether the field is part of the summary of an RFC, see RFCSummary
+func (f RFCField) Summarized() bool {
+	return f.Valid() && f != LinksField
+}
+
+// ValidateFields returns an error wrapping ErrInvalidField if any of the given fields is not supported
+func ValidateFields(fields []RFCField) error {
+	for _, field := range fields {
+		if !field.Valid() {
+			return fmt.Errorf("%w: %s, expected one of %v", ErrInvalidField, field, rfcFields)
+		}
+	}
+	return nil
+}
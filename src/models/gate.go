@@ -0,0 +1,135 @@
+// this holds load gate definitions, which require an approval before an RFC is loaded into the backing datastore
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// GateType represents the mechanism used to approve a gated load
+type GateType string //@name GateType
+
+// DeploymentGate loads are approved through the protection rules of a Git provider deployment environment
+var DeploymentGate GateType = "deployment"
+
+// ManualGate loads are approved through Harmonia by an administrator
+var ManualGate GateType = "manual"
+
+// GateState represents the progress of a gated load approval
+type GateState string //@name GateState
+var PendingGate GateState = "pending"
+var ApprovedGate GateState = "approved"
+var RejectedGate GateState = "rejected"
+
+// LoadGateData is the load action data key holding the load gate
+var LoadGateData DataKey = "gate"
+
+// ErrNoPendingGate is returned (wrapped) when a load gate decision is made for an RFC whose load is not awaiting one
+var ErrNoPendingGate = NewError(ErrConflict, NoPendingGateCode, "no pending load gate")
+
+// LoadGate holds the approval an RFC load is waiting on, or received, before the load step executes
+type LoadGate struct {
+	Type  GateType  `json:"type" enums:"deployment,manual" example:"deployment"`
+	State GateState `json:"state" enums:"pending,approved,rejected" example:"pending"`
+	// Environment is the deployment environment whose protection rules approve the load
+	Environment  string `json:"environment,omitempty" example:"production"`
+	DeploymentID string `json:"deploymentId,omitempty" example:"123456789"`
+	Approver     string `json:"approver,omitempty" example:"tstark"`
+	// MergeOnLoad is set when the load was requested on approval, so the RFC is merged once loaded
+	MergeOnLoad bool `json:"mergeOnLoad,omitempty"`
+} //@name LoadGate
+
+// loadGate is the gate configured for loads, nil if loads are not gated
+var loadGate *LoadGate
+var loadGateMu sync.RWMutex
+
+// SetLoadGate gates every load behind the given gate type, deployment gates are approved through the given
+// environment
+func SetLoadGate(gateType GateType, environment string) error {
+	if gateType != DeploymentGate && gateType != ManualGate {
+		return fmt.Errorf("load gate must be one of %s or %s, not %s", DeploymentGate, ManualGate, gateType)
+	}
+	if gateType == DeploymentGate && environment == "" {
+		return fmt.Errorf("%s load gate requires an environment", DeploymentGate)
+	}
+
+	loadGateMu.Lock()
+	defer loadGateMu.Unlock()
+	loadGate = &LoadGate{Type: gateType}
+	if gateType == DeploymentGate {
+		loadGate.Environment = environment
+	}
+
+	return nil
+}
+
+// ClearLoadGate removes the configured load gate so loads execute immediately
+func ClearLoadGate() {
+	loadGateMu.Lock()
+	defer loadGateMu.Unlock()
+	loadGate = nil
+}
+
+// NewLoadGate returns a pending gate of the configured type, nil is returned if loads are not gated
+func NewLoadGate() *LoadGate {
+	loadGateMu.RLock()
+	defer loadGateMu.RUnlock()
+	if loadGate == nil {
+		return nil
+	}
+
+	gate := *loadGate
+	gate.State = PendingGate
+	return &gate
+}
+
+// UpdateLoadGate records the given gate on the RFC load action, a nil gate removes it
+// The load action must already exist, i.e. the load status must have been set beforehand
+func (rfc *RFC) UpdateLoadGate(gate *LoadGate) error {
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var sha *string
+
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			if gate == nil {
+				delete(action.Data, string(LoadGateData))
+			} else {
+				action.Data[string(LoadGateData)] = gate
+			}
+			if sha, err = action.ToSha(); err != nil {
+				return err
+			}
+			action.Signature = *sha
+			return nil
+		}
+	}
+
+	return fmt.Errorf("RFC has no load action to gate")
+}
+
+// GetLoadGate gets the gate of the RFC load, if any, nil is returned otherwise
+func (rfc *RFC) GetLoadGate() *LoadGate {
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			data, ok := action.Data[string(LoadGateData)]
+			if !ok || data == nil {
+				return nil
+			}
+
+			// gates read back from the RFC file are generic maps, round trip them through JSON
+			jsonBytes, err := json.Marshal(data)
+			if err != nil {
+				return nil
+			}
+			gate := new(LoadGate)
+			if err = json.Unmarshal(jsonBytes, gate); err != nil {
+				return nil
+			}
+			return gate
+		}
+	}
+
+	return nil
+}
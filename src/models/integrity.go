@@ -0,0 +1,50 @@
+// this holds the error reported when an RFC file in the tracking repository can no longer be trusted
+package models
+
+import (
+	"fmt"
+)
+
+// IntegrityReason describes why an RFC file failed its integrity check
+type IntegrityReason string
+
+// integrity failure reasons
+var MissingRFC IntegrityReason = "missing"
+var EmptyRFC IntegrityReason = "empty"
+var CorruptRFC IntegrityReason = "corrupt"
+var TamperedRFC IntegrityReason = "tampered"
+
+// IntegrityError reports an RFC file that was deleted, whose content cannot be decoded or whose signatures do not match
+// its content, typically because it was edited by hand in the tracking repository
+type IntegrityError struct {
+	RFCIdentifier string
+	Reason        IntegrityReason
+	// Remediation describes how the RFC file can be repaired
+	Remediation string
+	// Err is the underlying retrieval or decoding error, if any
+	Err error
+}
+
+// NewIntegrityError returns an IntegrityError for the given RFC with remediation guidance
+func NewIntegrityError(rfcIdentifier string, reason IntegrityReason, err error) *IntegrityError {
+	return &IntegrityError{
+		RFCIdentifier: rfcIdentifier,
+		Reason:        reason,
+		Remediation: fmt.Sprintf("an administrator can restore the RFC file from the last valid revision in its "+
+			"pull request history via /admin/rebuildRfc with rfcIdentifier %s", rfcIdentifier),
+		Err: err,
+	}
+}
+
+// Error returns a description of the integrity failure
+func (e *IntegrityError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("RFC %s file is %s", e.RFCIdentifier, e.Reason)
+	}
+	return fmt.Sprintf("RFC %s file is %s: %s", e.RFCIdentifier, e.Reason, e.Err.Error())
+}
+
+// Unwrap returns the underlying error
+func (e *IntegrityError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,62 @@
+// this holds asynchronous job definitions, jobs run the work requests start but do not wait on, e.g. loading an RFC
+package models
+
+import "time"
+
+// JobKind represents the work an asynchronous job runs
+type JobKind string //@name JobKind
+var LoadJob JobKind = "load"
+var LoadAndMergeJob JobKind = "load_and_merge"
+var LoadAfterGateJob JobKind = "load_after_gate"
+var BreakGlassJob JobKind = "break_glass"
+
+// JobState represents the progress of an asynchronous job
+type JobState string //@name JobState
+var QueuedJob JobState = "queued"
+var RunningJob JobState = "running"
+var SucceededJob JobState = "succeeded"
+var FailedJob JobState = "failed"
+
+// ErrJobNotFound is returned (wrapped) when no job matches a requested ID, jobs are only retained for a while after
+// they finish
+var ErrJobNotFound = NewError(ErrNotFound, JobNotFoundCode, "job not found")
+
+// Job holds the progress of an asynchronous job
+type Job struct {
+	ID            string   `json:"id" example:"4f9c2b7e0a1d3c5b"`
+	Kind          JobKind  `json:"kind" enums:"load,load_and_merge,load_after_gate,break_glass" example:"load_and_merge"`
+	RFCIdentifier string   `json:"rfcIdentifier" example:"123456"`
+	State         JobState `json:"state" enums:"queued,running,succeeded,failed" example:"running"`
+	// Priority is the priority of the RFC, queued jobs of more urgent RFCs are run first
+	Priority Priority `json:"priority,omitempty" enums:"low,normal,high,urgent" example:"high"`
+	// Attempts counts the attempts made so far, failed attempts are retried with backoff until MaxAttempts is reached
+	Attempts    int    `json:"attempts" example:"1"`
+	MaxAttempts int    `json:"maxAttempts" example:"3"`
+	Error       string `json:"error,omitempty" example:"rate limited: API rate limit exceeded"`
+	// NextAttemptAt is when a failed attempt is retried, set while the job is queued for a retry
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" example:"2022-09-01T00:00:05Z"`
+	CreatedAt     time.Time  `json:"createdAt" example:"2022-09-01T00:00:00Z"`
+	UpdatedAt     time.Time  `json:"updatedAt" example:"2022-09-01T00:00:01Z"`
+} //@name Job
+
+// JobStats holds the utilization of the workers and the backlog of the job queue, so on-call can tell whether the
+// asynchronous work of requests keeps up
+type JobStats struct {
+	Workers int `json:"workers" example:"4"`
+	// Busy is the number of workers running a job
+	Busy int `json:"busy" example:"3"`
+	// Utilization is the share of the workers running a job, between 0 and 1
+	Utilization float64 `json:"utilization" example:"0.75"`
+	// QueueDepth is the number of queued jobs, including failed jobs waiting to be retried
+	QueueDepth int `json:"queueDepth" example:"12"`
+	// OldestPendingSeconds is how long the oldest queued job has been waiting for, 0 if no job is queued
+	OldestPendingSeconds float64 `json:"oldestPendingSeconds" example:"42.5"`
+	// OldestPendingJob is the ID of the oldest queued job, empty if no job is queued
+	OldestPendingJob string `json:"oldestPendingJob,omitempty" example:"4f9c2b7e0a1d3c5b"`
+	// RecentFailures counts the jobs of each kind that failed within the window
+	RecentFailures map[JobKind]int `json:"recentFailures"`
+	// RecentRetries counts the failed attempts that were retried within the window
+	RecentRetries int `json:"recentRetries" example:"2"`
+	// Window is the period recent failures and retries are counted over
+	Window string `json:"window" example:"1h0m0s"`
+} //@name JobStats
@@ -0,0 +1,16 @@
+// this holds the load status records kept in a status store, outside of the RFC file
+package models
+
+import "time"
+
+// LoadRecord holds the load status of an RFC, along with the status of each of its load targets, as recorded in a
+// status store
+type LoadRecord struct {
+	RFCIdentifier string            `json:"rfcIdentifier" example:"123456"`
+	Status        string            `json:"status" example:"loading"`
+	Targets       map[string]string `json:"targets,omitempty"`
+	Requester     string            `json:"requester" example:"tstark"`
+	UpdatedAt     time.Time         `json:"updatedAt" example:"2022-09-01T00:00:00Z"`
+	// Timings holds the action timings of each load target that reported them, see ActionTiming
+	Timings map[string][]ActionTiming `json:"timings,omitempty"`
+}
@@ -0,0 +1,63 @@
+// this holds the per target status of RFC loads, for deployments with several datastores
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LoadTargetsData is the load action Data key holding the load status of each target
+var LoadTargetsData DataKey = "targets"
+
+// ErrUnknownLoadTarget is returned (wrapped) when an RFC declares a load target that is not configured
+var ErrUnknownLoadTarget = NewError(ErrInvalid, UnknownLoadTargetCode, "unknown load target")
+
+// SetTargetLoadStatuses records the given load status of each target on the RFC load action, replacing any previous
+// statuses. The load action must already exist, i.e. the load status must have been set beforehand
+func (rfc *RFC) SetTargetLoadStatuses(statuses map[string]string) error {
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var sha *string
+
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			targets := make(map[string]string, len(statuses))
+			for target, status := range statuses {
+				targets[target] = status
+			}
+			action.Data[string(LoadTargetsData)] = targets
+			if sha, err = action.ToSha(); err != nil {
+				return err
+			}
+			action.Signature = *sha
+			return nil
+		}
+	}
+
+	return fmt.Errorf("RFC has no load action to record target statuses on")
+}
+
+// GetTargetLoadStatuses returns the load status of each target of the RFC load, nil is returned if there is none
+func (rfc *RFC) GetTargetLoadStatuses() map[string]string {
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			data, ok := action.Data[string(LoadTargetsData)]
+			if !ok || data == nil {
+				return nil
+			}
+
+			// statuses read back from the RFC file are generic maps, round trip them through JSON
+			jsonBytes, err := json.Marshal(data)
+			if err != nil {
+				return nil
+			}
+			statuses := map[string]string{}
+			if err = json.Unmarshal(jsonBytes, &statuses); err != nil {
+				return nil
+			}
+			return statuses
+		}
+	}
+
+	return nil
+}
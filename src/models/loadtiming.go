@@ -0,0 +1,92 @@
+// this holds the timing of the actions of RFC loads, so slow schema operations can be identified and planned for
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LoadTimingsData is the load action Data key holding the action timings of each target
+var LoadTimingsData DataKey = "timings"
+
+// ActionTiming is how long the loader of a load target took to apply an action of an RFC, along with the statements
+// it ran to apply it
+type ActionTiming struct {
+	// Index is the index of the action in the RFC
+	Index            int        `json:"index" example:"0"`
+	ActionType       ActionType `json:"actionType" example:"add"`
+	TargetDescriptor string     `json:"targetDescriptor,omitempty" example:"Event"`
+	Seconds          float64    `json:"seconds" example:"1.25"`
+	// Statements are the statements the loader ran to apply the action, as reported by the loader
+	Statements []string `json:"statements,omitempty" example:"ALTER TABLE events ADD COLUMN played_at TIMESTAMP"`
+} // @name ActionTiming
+
+// SetLoadTimings records the given action timings of each target of the RFC load on its load action, which must exist
+func (rfc *RFC) SetLoadTimings(timings map[string][]ActionTiming) error {
+	// init. vars to maintain state beyond "if" statements
+	var err error
+	var sha *string
+
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			targets := make(map[string][]ActionTiming, len(timings))
+			for target, targetTimings := range timings {
+				targets[target] = targetTimings
+			}
+			action.Data[string(LoadTimingsData)] = targets
+			if sha, err = action.ToSha(); err != nil {
+				return err
+			}
+			action.Signature = *sha
+			return nil
+		}
+	}
+
+	return fmt.Errorf("RFC has no load action to record action timings on")
+}
+
+// ClearLoadTimings removes the action timings recorded on the load action of the RFC, if any, as they no longer apply
+// once the RFC is loaded again
+func (rfc *RFC) ClearLoadTimings() error {
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			if _, ok := action.Data[string(LoadTimingsData)]; !ok {
+				return nil
+			}
+			delete(action.Data, string(LoadTimingsData))
+			sha, err := action.ToSha()
+			if err != nil {
+				return err
+			}
+			action.Signature = *sha
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// GetLoadTimings returns the action timings of each target of the RFC load, nil is returned if there are none
+func (rfc *RFC) GetLoadTimings() map[string][]ActionTiming {
+	for _, action := range rfc.Actions {
+		if action.ActionType == LoadAction {
+			data, ok := action.Data[string(LoadTimingsData)]
+			if !ok || data == nil {
+				return nil
+			}
+
+			// timings read back from the RFC file are generic maps, round trip them through JSON
+			jsonBytes, err := json.Marshal(data)
+			if err != nil {
+				return nil
+			}
+			timings := map[string][]ActionTiming{}
+			if err = json.Unmarshal(jsonBytes, &timings); err != nil {
+				return nil
+			}
+			return timings
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// this holds the moderation of comments, which are checked by comment filters before they are added to an RFC
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// FlaggedData holds the reasons a comment filter flagged a comment for, when flagged comments are kept
+var FlaggedData DataKey = "flagged"
+
+// ErrCommentRejected is returned (wrapped) when a comment filter rejects a comment
+var ErrCommentRejected = NewError(ErrInvalid, CommentRejectedCode, "comment rejected")
+
+// ErrHeldCommentNotFound is returned (wrapped) when no comment held for moderation matches a requested ID
+var ErrHeldCommentNotFound = NewError(ErrNotFound, HeldCommentNotFoundCode, "held comment not found")
+
+// HeldComment is a comment held for moderation rather than added to its RFC
+type HeldComment struct {
+	ID            string `json:"id" example:"4f9c2b7e0a1d3c5b"`
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	Domain        string `json:"domain,omitempty" example:"catalog"`
+	// Target is the signature of the action or RFC the comment targets
+	Target    string    `json:"target" example:"0a1b2c3d"`
+	Commenter string    `json:"commenter" example:"tstark"`
+	Comment   string    `json:"comment" example:"looks good"`
+	Reasons   []string  `json:"reasons" example:"comment exceeds 2000 characters"`
+	HeldAt    time.Time `json:"heldAt" example:"2022-06-01T09:00:00Z"`
+} //@name HeldComment
+
+// Flag records the given reasons a comment filter flagged the comment for, and signs the comment again
+func (action *Action) Flag(reasons []string) error {
+	action.Data[string(FlaggedData)] = strings.Join(reasons, "; ")
+	sha, err := action.ToSha()
+	if err != nil {
+		return err
+	}
+	action.Signature = *sha
+	return nil
+}
@@ -0,0 +1,36 @@
+// this holds RFC priorities, which order the asynchronous work of RFCs and flag notifications about urgent RFCs
+package models
+
+// Priority represents how urgent an RFC is
+type Priority string //@name Priority
+var LowPriority Priority = "low"
+var NormalPriority Priority = "normal"
+var HighPriority Priority = "high"
+var UrgentPriority Priority = "urgent"
+
+// priorityRanks holds the rank of each priority, RFCs without a priority rank as normal ones
+var priorityRanks = map[Priority]int{
+	LowPriority:    -1,
+	"":             0,
+	NormalPriority: 0,
+	HighPriority:   1,
+	UrgentPriority: 2,
+}
+
+// Valid returns whether the priority is one of the supported priorities, or unset
+func (p Priority) Valid() bool {
+	_, ok := priorityRanks[p]
+	return ok
+}
+
+// Rank returns the rank of the priority, the work of RFCs of a higher rank goes first. Unknown priorities rank as
+// normal
+func (p Priority) Rank() int {
+	return priorityRanks[p]
+}
+
+// Urgent returns whether notifications about RFCs of the priority are flagged as urgent, i.e. the priority is high or
+// above
+func (p Priority) Urgent() bool {
+	return p.Rank() >= HighPriority.Rank()
+}
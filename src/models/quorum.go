@@ -0,0 +1,6 @@
+// this holds the outcome of evaluating the approval policy, which requires a quorum of approvals of RFCs before they
+// are merged
+package models
+
+// ErrQuorumNotMet is returned (wrapped) when an RFC is merged before the approvals the approval policy requires of it
+var ErrQuorumNotMet = NewError(ErrConflict, QuorumNotMetCode, "approval quorum is not met")
@@ -0,0 +1,43 @@
+// this holds the receipts of submissions, which give clients proof of exactly what Harmonia accepted
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Receipt is proof of exactly what a submission was accepted as
+type Receipt struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	// RFC is the canonical JSON of the RFC as accepted, before it and its actions were signed
+	RFC json.RawMessage `json:"rfc" swaggertype:"object"`
+	// Signature is the hex encoded SHA-256 of RFC, the signature of the RFC in the tracking repository
+	Signature      string    `json:"signature" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Branch         string    `json:"branch" example:"123456"`
+	PullRequestURL string    `json:"pullRequestUrl,omitempty" example:"https://github.com/owner/repo/pull/1"`
+	AcceptedAt     time.Time `json:"acceptedAt" example:"2022-09-01T00:00:00Z"`
+	// ServerSignature is the base64 encoded Ed25519 signature of the SignedContent of the receipt, made with the key
+	// whose public key is served at /meta/receiptKey. It is omitted if receipts are not signed
+	ServerSignature string `json:"serverSignature,omitempty"`
+} //@name Receipt
+
+// SignedContent returns the content the server signature of the receipt is made over, the newline separated
+// identifier, branch, pull request URL, signature and RFC3339 acceptance time of the receipt. The signature binds
+// the content of the RFC
+func (r *Receipt) SignedContent() []byte {
+	return []byte(strings.Join([]string{
+		r.RFCIdentifier,
+		r.Branch,
+		r.PullRequestURL,
+		r.Signature,
+		r.AcceptedAt.UTC().Format(time.RFC3339Nano),
+	}, "\n"))
+}
+
+// ReceiptKey is the public key submission receipts are signed with
+type ReceiptKey struct {
+	Algorithm string `json:"algorithm" example:"ed25519"`
+	// PublicKey is the base64 encoded public key
+	PublicKey string `json:"publicKey" example:"O2onvM62pC1io6jQKm8Nc2UyFXcd4kOmOsBIoYtZ2ik="`
+} //@name ReceiptKey
@@ -1,20 +1,31 @@
 // this holds request objects that are populated upon HTTP request
 package models
 
+import "time"
+
+// DomainSelector selects the tracking repository of the schema domain an RFC belongs to
+type DomainSelector struct {
+	// Domain is the schema domain whose tracking repository holds the RFC, the default tracking repository if empty
+	Domain string `json:"domain,omitempty" example:"catalog"`
+}
+
 // incoming request structure for loads
 type Load struct {
+	DomainSelector
 	RFCIdentifier string `json:"rfcIdentifier" binding:"required"`
 } // @name Load
 
 // incoming request structure for merges
 type Merge struct {
+	DomainSelector
 	RFCIdentifier string `json:"rfcIdentifier" binding:"required"`
 } // @name Merge
 
 // incoming request structure for reveiws
 type Review struct {
+	DomainSelector
 	RFCIdentifier   string `json:"rfcIdentifier" binding:"required" example:"123456"`
-	Type            string `json:"type" binding:"required" example:"COMMENT"`
+	Type            string `json:"type" binding:"required" example:"COMMENT"` //One of APPROVE, REQUEST_CHANGES, COMMENT, ACKNOWLEDGE, BLOCK or a configured custom intent
 	TopLevelComment string `json:"topLevelComment,omitempty" example:"This is my review comment!"`
 	// this was not made into its own struct so that we can efficiently look up targets using the power of maps
 	Comments       map[string][]string `json:"comments,omitempty" swaggertype:"object,array,string"`
@@ -23,26 +34,254 @@ type Review struct {
 
 // incoming request structure for load status requests
 type Status struct {
+	DomainSelector
 	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Detail includes how long each action of the RFC took to apply into each load target
+	Detail bool `json:"detail,omitempty" example:"true"`
 } // @name Status
 
+// incoming request structure for load gate decisions
+type ApproveLoad struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Approve       *bool  `json:"approve" binding:"required" example:"true"` //Whether the load is approved or rejected
+} // @name ApproveLoad
+
 // incoming request structure for updates
 type Update struct {
+	DomainSelector
 	RFC           *RFC   `json:"rfc" binding:"required"`
 	RFCIdentifier string `json:"rfcIdentifier" binding:"required"`
+	// Signature is the signature of the RFC the update was made from, the update is rejected with the latest RFC if it
+	// was updated since. Required
+	Signature string `json:"signature" example:"5d41402abc4b2a76b9719d911017c592"`
 } // @name Update
 
+// incoming request structure for withdrawRequest requests
+type Withdraw struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Reason        string `json:"reason,omitempty" example:"Superseded by RFC 654321"` //Why the RFC is withdrawn.
+} // @name Withdraw
+
+// incoming request structure for breakGlass requests
+type BreakGlass struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Justification string `json:"justification" binding:"required" example:"INC-42: catalog outage"` //Why policy is bypassed.
+} // @name BreakGlass
+
+// incoming request structure for the verdicts of external systems
+type ExternalApproval struct {
+	DomainSelector
+	RFCIdentifier string  `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Verdict       Verdict `json:"verdict" binding:"required,oneof=approved rejected" enums:"approved,rejected" example:"approved"`
+	// Signature is the signature of the RFC the system decided on, the verdict is rejected if the RFC changed since
+	Signature string `json:"signature,omitempty" example:"9f86d081884c7d65"`
+	Reference string `json:"reference,omitempty" example:"CHG0042"` //Reference of the decision in the system.
+	Reason    string `json:"reason,omitempty" example:"Approved by the weekly CAB"`
+} // @name ExternalApproval
+
 // incoming request structure for getRfcs requests
 type GetRfcs struct {
+	DomainSelector
 	Count int    `json:"count" example:"100" binding:"required"` //Number of requests wanted. If count is -1, return all requests. Required
 	State string `json:"state" example:"open"`                   //State of the request, one of "open", "closed", or "all". Default: "all"
 
 	// The following are options used to filter the returned PRs, the default value for all is to not filter
-	Owner  *string `json:"owner" example:"tstark"` //Username of the owner of the requests.
-	Merged *bool   `json:"merged" example:"false"` //Merged status of the RFC. A closed RFC that has Merged:false indicates that the change was rejected.
+	Owner        *string    `json:"owner" example:"tstark"`                      //Username of the owner of the requests.
+	Merged       *bool      `json:"merged" example:"false"`                      //Merged status of the RFC. A closed RFC that has Merged:false indicates that the change was rejected.
+	Label        *string    `json:"label" example:"breaking-change"`             //Label of the requests. Bitbucket requests have no labels.
+	CreatedAfter *time.Time `json:"createdAfter" example:"2022-09-01T00:00:00Z"` //Time after which the requests were created.
+	HeadPrefix   *string    `json:"headPrefix" example:"1662"`                   //Prefix of the RFC identifier, the head branch, of the requests.
+	Filter       *RFCFilter `json:"filter"`                                      //Combination of filters the requests must also satisfy.
+
+	// Fields selects the links and summary fields returned, only the selected summary fields are computed. Every
+	// field is returned if omitted, summaries only when filtering by owner
+	Fields []RFCField `json:"fields,omitempty" example:"links,loadStatus"`
 } // @name GetRfcs
 
+// holds a filter of RFCs, either a registered filter built from its argument or a combination of filters
+// Exactly one of Name, And, Or and Not is expected
+type RFCFilter struct {
+	Name     string      `json:"name,omitempty" example:"label"` //Registered filter, e.g. "owner", "merged", "label", "createdAfter" or "headPrefix".
+	Argument string      `json:"argument,omitempty" example:"breaking-change"`
+	And      []RFCFilter `json:"and,omitempty"` //Filters the requests must all satisfy.
+	Or       []RFCFilter `json:"or,omitempty"`  //Filters the requests must satisfy at least one of.
+	Not      *RFCFilter  `json:"not,omitempty"` //Filter the requests must not satisfy.
+} // @name RFCFilter
+
 // incoming request structure for getRfcContents requests
 type GetRfcContents struct {
+	DomainSelector
 	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Ref is the commit sha, branch or tag to retrieve the RFC as of, its latest version if empty
+	Ref string `json:"ref,omitempty" example:"3f8e2a1"`
 } // @name GetRfcContents
+
+// incoming request structure for verifyRfc requests
+type VerifyRfc struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Ref is the commit sha, branch or tag to verify the RFC as of, its latest version if empty
+	Ref string `json:"ref,omitempty" example:"3f8e2a1"`
+} // @name VerifyRfc
+
+// incoming request structure for getRfcRendered requests
+type GetRfcRendered struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Ref is the commit sha, branch or tag to render the RFC as of, its latest version if empty
+	Ref string `json:"ref,omitempty" example:"3f8e2a1"`
+	// Format is the format to render the RFC in, markdown if empty
+	Format string `json:"format,omitempty" binding:"omitempty,oneof=markdown html" enums:"markdown,html" example:"html"`
+} // @name GetRfcRendered
+
+// incoming request structure for getRfcHistory requests
+type GetRfcHistory struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+} // @name GetRfcHistory
+
+// incoming request structure for diffRequest requests
+type Diff struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+} // @name Diff
+
+// incoming request structure for getReviews requests
+type GetReviews struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+} // @name GetReviews
+
+// incoming request structure for editComment requests
+type EditComment struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Signature is the signature of the comment action to edit
+	Signature string `json:"signature" binding:"required" example:"3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"`
+	Comment   string `json:"comment" binding:"required" example:"The name should be singular"`
+} // @name EditComment
+
+// incoming request structure for deleteComment requests
+type DeleteComment struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	// Signature is the signature of the comment action to delete
+	Signature string `json:"signature" binding:"required" example:"3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"`
+} // @name DeleteComment
+
+// incoming request structure for annotate requests
+type Annotate struct {
+	DomainSelector
+	RFCIdentifier string       `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Annotations   []Annotation `json:"annotations"` //Replace any annotations previously attached by the analyzer.
+} // @name Annotate
+
+// a single finding of an analyzer on an RFC action
+type Annotation struct {
+	// Signature is the signature of the annotated action
+	Signature string   `json:"signature" binding:"required" example:"3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"`
+	Severity  Severity `json:"severity" binding:"required" swaggertype:"string" enums:"info,warning,error" example:"warning"`
+	Message   string   `json:"message" binding:"required" example:"Removing this field breaks existing consumers"`
+} // @name Annotation
+
+// incoming request structure for getAction requests
+type GetAction struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+	Signature     string `json:"signature" binding:"required" example:"3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b"`
+} // @name GetAction
+
+// incoming request structure for activity feed requests
+type Activity struct {
+	Limit int `json:"limit" example:"50"` //Number of events wanted. If limit is omitted a default is used, -1 returns all retained events
+
+	// The following are options used to filter the returned events, the default value for all is to not filter
+	User  *string     `json:"user" example:"tstark"`                                    //Login of the user that performed the events.
+	Team  *string     `json:"team" example:"schema-admins"`                             //Slug of the team whose members performed the events.
+	Types []EventType `json:"types" swaggertype:"array,string" example:"submit,review"` //Event types to include.
+} // @name Activity
+
+// incoming request structure for RFC file rebuild requests
+type Rebuild struct {
+	DomainSelector
+	RFCIdentifier string `json:"rfcIdentifier" binding:"required" example:"123456"`
+} // @name Rebuild
+
+// incoming request structure for RFC signature migrations
+type MigrateSignatures struct {
+	DomainSelector
+	DryRun bool `json:"dryRun,omitempty" example:"true"` //Report the RFCs that would be signed again without signing them
+} // @name MigrateSignatures
+
+// incoming request structure for backups of a tracking repository
+type CreateBackup struct {
+	DomainSelector
+} // @name CreateBackup
+
+// incoming request structure for restores of a backup into a tracking repository
+type RestoreBackup struct {
+	DomainSelector
+	Backup *Backup `json:"backup" binding:"required"`
+	DryRun bool    `json:"dryRun,omitempty" example:"true"` //Report the RFCs that would be restored without restoring them
+} // @name RestoreBackup
+
+// incoming request structure for generating types from the action data schemas of a tracking repository
+type GenerateTypes struct {
+	DomainSelector
+	DryRun bool `json:"dryRun,omitempty" example:"true"` //Return the generated files without committing them
+} // @name GenerateTypes
+
+// incoming request structure for moderation decisions on held comments
+type ModerateComment struct {
+	ID      string `json:"id" binding:"required" example:"4f9c2b7e0a1d3c5b"`
+	Approve bool   `json:"approve,omitempty" example:"true"` //Add the comment to its RFC, rather than discard it
+} // @name ModerateComment
+
+// incoming request structure for redelivery decisions on dead letters
+type RedeliverDeadLetter struct {
+	ID      string `json:"id" binding:"required" example:"9b1c4e7a2f3d5c6e"`
+	Discard bool   `json:"discard,omitempty" example:"false"` //Drop the dead letter, rather than redeliver it
+} // @name RedeliverDeadLetter
+
+// incoming request structure for releasing reservations
+type ReleaseReservation struct {
+	DomainSelector
+	TargetDescriptor string `json:"targetDescriptor" binding:"required" example:"playback.*"`
+} // @name ReleaseReservation
+
+// incoming request structure for test notification requests
+type TestNotification struct {
+	Channel       string    `json:"channel" binding:"required" example:"webhook"`
+	Type          EventType `json:"type" binding:"required" swaggertype:"string" example:"review"`
+	RFCIdentifier string    `json:"rfcIdentifier" example:"123456"` //RFC the sample event refers to. Default: "000000"
+} // @name TestNotification
+
+// incoming request structure for maintenance mode requests
+type SetMaintenance struct {
+	Enabled *bool  `json:"enabled" binding:"required" example:"true"`
+	Message string `json:"message" example:"Database failover in progress"` //Message rejected requests are given
+} // @name SetMaintenance
+
+// incoming request structure for batch submissions
+type SubmitBatch struct {
+	// Domain is the schema domain of every RFC of the batch, RFCs that declare no domain are submitted to it
+	DomainSelector
+	RFCs []*RFC `json:"rfcs" binding:"required,dive"`
+} // @name SubmitBatch
+
+// incoming request structure for seed requests
+type Seed struct {
+	DomainSelector
+	// States are the states sample RFCs are seeded in, one RFC each. Default: open, approved, failed_load, merged
+	States []SeedState `json:"states" swaggertype:"array,string" example:"open,approved"`
+} // @name Seed
+
+// incoming request structure for GraphQL requests
+type GraphQL struct {
+	Query         string                 `json:"query" binding:"required" example:"{ rfcs(count: 10) { id title } }"`
+	Variables     map[string]interface{} `json:"variables"`     //Values of the variables of the query.
+	OperationName string                 `json:"operationName"` //Operation to execute if the query holds several.
+} // @name GraphQL
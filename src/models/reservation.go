@@ -0,0 +1,106 @@
+// this holds the reservations of target descriptors, claimed by reserve actions so that other teams cannot add entities
+// to them until they are released or expire
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NAMESPACE_WILDCARD ends the target descriptors reserving the namespace of every descriptor they prefix, e.g.
+// "playback.*" reserves "playback.Started" and "playback.video.Stalled"
+const NAMESPACE_WILDCARD = "*"
+
+// ErrTargetReserved is returned (wrapped) when an RFC adds to or reserves a target descriptor reserved by a team the
+// caller is not a member of
+var ErrTargetReserved = NewError(ErrConflict, TargetReservedCode, "target descriptor reserved")
+
+// ErrReservationNotFound is returned (wrapped) when no reservation holds a requested target descriptor
+var ErrReservationNotFound = NewError(ErrNotFound, ReservationNotFoundCode, "reservation not found")
+
+// ErrNotReservationTeam is returned (wrapped) when a reservation is released by a user outside of the team holding it
+var ErrNotReservationTeam = NewError(ErrUnauthorized, NotReservationTeamCode, "caller is not a member of the team")
+
+// Reservation is the claim of a team on a target descriptor of a schema domain, recorded when the RFC holding its
+// reserve action is loaded
+type Reservation struct {
+	Domain           string    `json:"domain,omitempty" example:"catalog"`
+	TargetDescriptor string    `json:"targetDescriptor" example:"playback.*"`
+	Team             string    `json:"team" example:"playback"`
+	RFCIdentifier    string    `json:"rfcIdentifier" example:"123456"`
+	ReservedAt       time.Time `json:"reservedAt" example:"2022-06-01T09:00:00Z"`
+	ExpiresAt        time.Time `json:"expiresAt" example:"2022-08-30T09:00:00Z"`
+} //@name Reservation
+
+// Expired returns whether the reservation no longer holds at the given time
+func (r *Reservation) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Conflicts returns whether the given action of another RFC reaches into the reservation: add actions targeting a
+// descriptor it covers, and reserve actions claiming a descriptor it covers or covering its own
+func (r *Reservation) Conflicts(action *Action) bool {
+	if action == nil || !action.IsProposal() {
+		return false
+	}
+	switch action.ActionType {
+	case AddAction:
+		return covers(r.TargetDescriptor, action.Target.TargetDescriptor)
+	case ReserveAction:
+		return covers(r.TargetDescriptor, action.Target.TargetDescriptor) ||
+			covers(action.Target.TargetDescriptor, r.TargetDescriptor)
+	default:
+		return false
+	}
+}
+
+// Conflict returns an error wrapping ErrTargetReserved describing the reservation
+func (r *Reservation) Conflict() error {
+	return fmt.Errorf("%w: %s is reserved by team %s until %s", ErrTargetReserved, r.TargetDescriptor, r.Team,
+		r.ExpiresAt.UTC().Format(time.RFC3339))
+}
+
+// covers returns whether the given reserved target descriptor claims the other given descriptor, which may itself
+// reserve a namespace
+func covers(reserved string, descriptor string) bool {
+	if namespace, ok := strings.CutSuffix(reserved, NAMESPACE_WILDCARD); ok {
+		return strings.HasPrefix(strings.TrimSuffix(descriptor, NAMESPACE_WILDCARD), namespace)
+	}
+	return reserved == descriptor
+}
+
+// Reservations returns the reservations claimed by the reserve actions of the RFC with the given identifier, reserved
+// at the given time. They expire at the expiresAt of their action data, or once the given duration elapsed
+func (rfc *RFC) Reservations(rfcIdentifier string, now time.Time, ttl time.Duration) []Reservation {
+	var reservations []Reservation
+	for _, action := range rfc.Actions {
+		if action == nil || action.ActionType != ReserveAction || !action.IsProposal() {
+			continue
+		}
+		reservation := Reservation{
+			Domain:           rfc.Domain,
+			TargetDescriptor: action.Target.TargetDescriptor,
+			RFCIdentifier:    rfcIdentifier,
+			ReservedAt:       now,
+			ExpiresAt:        now.Add(ttl),
+		}
+		reservation.Team, _ = action.Data[string(TeamData)].(string)
+		if expiresAt, ok := action.expiresAt(); ok {
+			reservation.ExpiresAt = expiresAt
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations
+}
+
+// expiresAt returns the expiry of the action data, false is returned if it has none or it is not an RFC 3339 time
+func (action *Action) expiresAt() (time.Time, bool) {
+	value, ok := action.Data[string(ExpiresAtData)].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	return expiresAt, err == nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReservationConflicts tests that reservations only conflict with the add and reserve actions reaching into them
+func TestReservationConflicts(t *testing.T) {
+	// arrange
+	namespace := &Reservation{TargetDescriptor: "playback.*"}
+	descriptor := &Reservation{TargetDescriptor: "Search"}
+	action := func(actionType ActionType, descriptor string) *Action {
+		return &Action{ActionType: actionType, Target: Target{TargetType: ItemTarget, TargetDescriptor: descriptor}}
+	}
+	testCases := []struct {
+		reservation *Reservation
+		action      *Action
+		expected    bool
+	}{
+		{reservation: namespace, action: action(AddAction, "playback.Started"), expected: true},
+		{reservation: namespace, action: action(AddAction, "playbackStarted")},
+		{reservation: namespace, action: action(UpdateAction, "playback.Started")},
+		{reservation: namespace, action: action(ReserveAction, "playback.video.*"), expected: true},
+		{reservation: namespace, action: action(ReserveAction, "*"), expected: true},
+		{reservation: descriptor, action: action(AddAction, "Search"), expected: true},
+		{reservation: descriptor, action: action(AddAction, "SearchResult")},
+		{reservation: descriptor, action: &Action{ActionType: AddAction, Target: Target{TargetType: RfcTarget,
+			TargetDescriptor: "Search"}}},
+	}
+
+	for _, test := range testCases {
+		// act
+		conflicts := test.reservation.Conflicts(test.action)
+
+		// assert
+		if conflicts != test.expected {
+			t.Errorf("expected %s %s to conflict with the reservation of %s: %t", test.action.ActionType,
+				test.action.Target.TargetDescriptor, test.reservation.TargetDescriptor, test.expected)
+		}
+	}
+}
+
+// TestReservations tests that the reserve actions of an RFC claim their target descriptor until their expiry
+func TestReservations(t *testing.T) {
+	// arrange
+	now := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	rfc := &RFC{Domain: "catalog", Actions: Actions{
+		{ActionType: ReserveAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "playback.*"},
+			Data: map[string]interface{}{"team": "playback"}},
+		{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "playback.Started"}},
+		{ActionType: ReserveAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Search"},
+			Data: map[string]interface{}{"team": "search", "expiresAt": "2022-07-01T00:00:00Z"}},
+	}}
+
+	// act
+	reservations := rfc.Reservations("123", now, 24*time.Hour)
+
+	// assert
+	if len(reservations) != 2 {
+		t.Fatalf("expected a reservation per reserve action, got %+v", reservations)
+	}
+	if reservations[0].Team != "playback" || reservations[0].Domain != "catalog" ||
+		reservations[0].RFCIdentifier != "123" || !reservations[0].ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("unexpected reservation: %+v", reservations[0])
+	}
+	if !reservations[1].ExpiresAt.Equal(time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)) ||
+		reservations[1].Expired(now) || !reservations[1].Expired(reservations[1].ExpiresAt) {
+		t.Errorf("expected the reservation to last until the expiry of its action, got %+v", reservations[1])
+	}
+}
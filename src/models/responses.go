@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 // holds health message
@@ -13,21 +14,58 @@ type Healthy struct {
 	Message string `json:"message" example:"healthy"`
 } // @name Healthy
 
+// holds the readiness of the service to handle requests
+type Readiness struct {
+	Ready     bool         `json:"ready" example:"false"`
+	WarmingUp bool         `json:"warmingUp,omitempty" example:"true"`
+	Tokens    []TokenCheck `json:"tokens"`
+	// Protection reports drift of the branch protection of the tracking repository, which does not affect readiness
+	Protection *ProtectionCheck `json:"protection,omitempty"`
+} // @name Readiness
+
+// holds the result of validating a single configured Git token
+type TokenCheck struct {
+	Token   string   `json:"token" example:"machine"`
+	Missing []string `json:"missing,omitempty" example:"read:org scope"`
+	Error   string   `json:"error,omitempty" example:"no machine token specified"`
+} // @name TokenCheck
+
+// holds the result of comparing the branch protection of a tracking repository to the protection Harmonia relies on
+type ProtectionCheck struct {
+	Domain string   `json:"domain,omitempty" example:"events"`
+	Branch string   `json:"branch,omitempty" example:"main"`
+	Drift  []string `json:"drift,omitempty" example:"branch main is not protected"`
+	Error  string   `json:"error,omitempty" example:"403 Resource not accessible by integration"`
+} // @name ProtectionCheck
+
 // holds errors
 type Error struct {
 	Error string `json:"error" example:"whoops!"`
+	// Code identifies why the request failed, see Code
+	Code Code `json:"code" enums:"MALFORMED_REQUEST,INVALID_PARAMETER,INVALID_REVIEW_TYPE,INVALID_ANNOTATION,INVALID_ACTION,INVALID_ACTION_DATA,INVALID_DEPENDENCY,SIGNATURE_REQUIRED,AUTHOR_SIGNATURE_REQUIRED,UNSUPPORTED_BACKUP,UNSUPPORTED_SIGNATURE,MISSING_JUSTIFICATION,UNKNOWN_LOAD_TARGET,UNKNOWN_DOMAIN,UNKNOWN_CHANNEL,INVALID_FILTER,UNKNOWN_VARIABLE,COMMENT_REJECTED,NOT_FOUND,ACTION_NOT_FOUND,CONFLICT,DUPLICATE_RFC,STALE_RFC,TARGET_RESERVED,RFC_NOT_MERGEABLE,RFC_EMBARGOED,UNMET_DEPENDENCIES,RFC_INTEGRITY,QUORUM_NOT_MET,NO_PENDING_GATE,JOB_NOT_FOUND,HELD_COMMENT_NOT_FOUND,DEAD_LETTER_NOT_FOUND,RESERVATION_NOT_FOUND,UNAUTHENTICATED,PERMISSION_DENIED,NOT_RFC_AUTHOR,NOT_COMMENT_AUTHOR,NOT_RESERVATION_TEAM,NOT_BREAK_GLASS_ADMIN,NOT_PERMITTED,CROSS_TENANT,UNKNOWN_ANALYZER,INVALID_SIGNATURE,INVALID_AUTHOR_SIGNATURE,REPLAYED_REQUEST,RATE_LIMITED,PROVIDER_ERROR,MAINTENANCE,CONFIGURATION_ERROR,INTERNAL_ERROR" example:"NOT_FOUND"`
 } // @name Error
 
 // holds RFC unique identifier
 type RFCIdentifier struct {
 	RFCIdentifier string `json:"rfcIdentifier" example:"woo-hoo123"`
+	Links         *Links `json:"links,omitempty"`
+	// Receipt is proof of exactly what was accepted, returned by submissions
+	Receipt *Receipt `json:"receipt,omitempty"`
 } //@name RFCIdentifier
 
 // holds a success message
 type Success struct {
 	Success string `json:"success" example:"Success!"`
+	Links   *Links `json:"links,omitempty"`
 } //@name Success
 
+// holds Git provider URLs of an RFC so users can jump to it
+type Links struct {
+	PullRequest string `json:"pullRequest,omitempty" example:"https://github.com/owner/repo/pull/1"`
+	File        string `json:"file,omitempty" example:"https://github.com/owner/repo/blob/123456/RFC/123456/RFC.json"`
+	Tag         string `json:"tag,omitempty" example:"https://github.com/owner/repo/tree/123456"`
+} //@name Links
+
 // holds a load request response message
 type LoadRequest struct {
 	Message string `json:"message" example:"submitted load request for 12345, check status via the /status endpoint!"`
@@ -35,18 +73,317 @@ type LoadRequest struct {
 
 // holds a status response message
 type StatusResponse struct {
-	Status string `json:"status" example:"loading"`
+	Status string    `json:"status" example:"loading"`
+	Gate   *LoadGate `json:"gate,omitempty"` //Approval the load is waiting on, or received, if loads are gated
+	// Targets holds the load status of each load target the RFC was loaded into
+	Targets map[string]string `json:"targets,omitempty" swaggertype:"object,string" example:"primary:successful"`
+	// Timings holds how long each action took to apply into each load target, keyed by target, when detail is requested
+	// and the loader of the target reports it
+	Timings map[string][]ActionTiming `json:"timings,omitempty"`
+	// EmbargoUntil is the earliest time the RFC may be merged or loaded, Embargoed reports whether it is still in effect
+	EmbargoUntil *time.Time `json:"embargoUntil,omitempty" example:"2022-09-01T00:00:00Z"`
+	Embargoed    bool       `json:"embargoed" example:"false"`
+	// Job is the most recent asynchronous job of the RFC, e.g. its load, if one is still retained
+	Job *Job `json:"job,omitempty"`
+	// Dependencies holds the state of each RFC the RFC depends on, it is loaded or merged once they are all merged
+	Dependencies []Dependency `json:"dependencies,omitempty"`
 } //@name Status
 
 type RFCs struct {
 	RFCs  []map[string]string `json:"rfcs" swaggertype:"object,string" example:"1234:Example RFC title"`
 	Count *int                `json:"count,omitempty" example:"10"`
+	Links map[string]*Links   `json:"links,omitempty"` //Provider URLs keyed by RFC ID
+	// Summaries holds the review, mergeability and load status of each RFC keyed by RFC ID, only when filtering by owner
+	// or selecting summary fields
+	Summaries map[string]*RFCSummary `json:"summaries,omitempty"`
 }
 
+// holds the review, mergeability and load status of an RFC at a glance, fields that were not selected are omitted
+type RFCSummary struct {
+	State            string `json:"state,omitempty" example:"open"`
+	Approvals        *int   `json:"approvals,omitempty" example:"2"`        //Reviewers whose latest review approves
+	ChangesRequested *int   `json:"changesRequested,omitempty" example:"0"` //Reviewers whose last review requests changes
+	Comments         *int   `json:"comments,omitempty" example:"3"`         //Comment only reviews
+	Mergeable        *bool  `json:"mergeable,omitempty" example:"true"`
+	LoadStatus       string `json:"loadStatus,omitempty" example:"successful"`
+	// Mergeability explains why an open RFC is not mergeable
+	Mergeability *Mergeability `json:"mergeability,omitempty"`
+	// Dependencies holds the state of each RFC the RFC depends on
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+} //@name RFCSummary
+
+// holds whether an RFC can be merged and, if not, why
+type Mergeability struct {
+	Mergeable bool `json:"mergeable" example:"false"`
+	// Reasons explains why the RFC cannot be merged
+	Reasons []string `json:"reasons,omitempty" example:"status check ci/build is failure"`
+	// RequiredContexts lists the status contexts and check names that must pass, every context must if it is empty
+	RequiredContexts []string `json:"requiredContexts,omitempty" example:"ci/build"`
+	// Contexts holds the state of each considered context, one of success, pending, failure or missing
+	Contexts map[string]string `json:"contexts,omitempty" swaggertype:"object,string" example:"ci/build:failure"`
+} //@name Mergeability
+
 type RFCContents struct {
 	Body string `json:"body" binding:"required"`
+	// Ref is the revision the body was retrieved as of, omitted for the latest version
+	Ref string `json:"ref,omitempty" example:"3f8e2a1"`
 }
 
+// holds an RFC rendered for human review
+type RFCRendered struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	Format        string `json:"format" enums:"markdown,html" example:"markdown"`
+	Body          string `json:"body" example:"# RFC 123456"`
+	// Ref is the revision the RFC was rendered as of, omitted for the latest version
+	Ref string `json:"ref,omitempty" example:"3f8e2a1"`
+} // @name RFCRendered
+
+// holds every review submitted on an RFC, oldest first
+type RFCReviews struct {
+	RFCIdentifier string      `json:"rfcIdentifier" example:"123456"`
+	Reviews       []RFCReview `json:"reviews"`
+} //@name RFCReviews
+
+// holds a single review of an RFC
+type RFCReview struct {
+	Reviewer string `json:"reviewer" example:"tstark"`
+	// Type is the review type the review was submitted as, empty if it was dismissed since the provider no longer
+	// reports it
+	Type        string    `json:"type,omitempty" example:"APPROVE"`
+	State       string    `json:"state" example:"APPROVED"`
+	SubmittedAt time.Time `json:"submittedAt" example:"2022-06-01T12:00:00Z"`
+	Dismissed   bool      `json:"dismissed" example:"false"`
+} //@name RFCReview
+
+// holds the changes an RFC would make to the items its actions target
+type RFCDiff struct {
+	RFCIdentifier string       `json:"rfcIdentifier" example:"123456"`
+	Actions       []ActionDiff `json:"actions"`
+} //@name RFCDiff
+
+// holds the change an action would make to the item it targets in the datastore of a load target
+type ActionDiff struct {
+	Signature  string `json:"signature" example:"60303ae22b998861"`
+	ActionType string `json:"actionType" example:"update"`
+	Target     Target `json:"target" swaggertype:"object,string" example:"targetType:item,targetDescriptor:EntityType"`
+	LoadTarget string `json:"loadTarget" example:"primary"`
+	// Before and After are the attributes of the item before and after the action, omitted if there is no such item
+	Before map[string]interface{} `json:"before,omitempty" swaggertype:"object,string" example:"name:OurField"`
+	After  map[string]interface{} `json:"after,omitempty" swaggertype:"object,string" example:"name:OurField"`
+	// Diff is a unified diff of the JSON attributes of the item before and after the action
+	Diff string `json:"diff,omitempty" example:"@@ -1,3 +1,3 @@..."`
+	// Note explains why the change could not be computed, if it could not
+	Note string `json:"note,omitempty" example:"load target cannot resolve action targets: primary"`
+} //@name ActionDiff
+
+// holds the result of validating an RFC without submitting it
+type Validation struct {
+	Valid bool `json:"valid" example:"false"`
+	// Signature is the signature the RFC would be submitted with
+	Signature string `json:"signature,omitempty" example:"9f86d081884c7d65"`
+	// Errors holds the errors of the RFC as a whole, including one for each action that has errors
+	Errors  []ValidationError  `json:"errors,omitempty"`
+	Actions []ActionValidation `json:"actions"`
+} //@name Validation
+
+// holds the result of validating a single action of an RFC, in the order the actions were submitted
+type ActionValidation struct {
+	Index int `json:"index" example:"0"`
+	// Signature is the signature the action would be submitted with, other actions may target it
+	Signature string            `json:"signature,omitempty" example:"60303ae22b998861"`
+	Errors    []ValidationError `json:"errors,omitempty"`
+} //@name ActionValidation
+
+// holds a single validation error and the attribute it relates to
+type ValidationError struct {
+	Field   string `json:"field,omitempty" example:"target.targetType"`
+	Message string `json:"message" example:"target type is required"`
+} //@name ValidationError
+
+// holds the commits that modified an RFC file, newest first
+type RFCHistory struct {
+	RFCIdentifier string     `json:"rfcIdentifier" example:"123456"`
+	Revisions     []Revision `json:"revisions"`
+} //@name RFCHistory
+
+// holds a single commit of an RFC file and the change it made to the RFC JSON
+type Revision struct {
+	Sha       string    `json:"sha" example:"3f8e2a1"`
+	Author    string    `json:"author" example:"tstark"`
+	Message   string    `json:"message" example:"update."`
+	Timestamp time.Time `json:"timestamp" example:"2022-06-01T12:00:00Z"`
+	// Diff is the unified diff of the indented RFC JSON against the previous revision
+	Diff string `json:"diff" example:"@@ -1,3 +1,3 @@..."`
+} //@name Revision
+
+// holds a single RFC action, the comment thread targeting it and the annotations analyzers attached to it
+type ActionThread struct {
+	Action      *Action `json:"action"`
+	Comments    Actions `json:"comments"`
+	Annotations Actions `json:"annotations"`
+} //@name ActionThread
+
+// holds a reference to a single RFC
+type RFCReference struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	Title         string `json:"title" example:"RFC: 123456"`
+	Links         *Links `json:"links,omitempty"`
+} //@name RFCReference
+
+// holds the open RFCs that require the attention of the authenticated user
+type MyWork struct {
+	NeedsChanges   []RFCReference `json:"needsChanges"`
+	AwaitingReview []RFCReference `json:"awaitingReview"`
+	FailedLoads    []RFCReference `json:"failedLoads"`
+} //@name MyWork
+
+// holds a periodic summary of the RFCs that concern a team
+type Digest struct {
+	Team           string         `json:"team" example:"schema-admins"`
+	Since          time.Time      `json:"since" example:"2022-06-01T09:00:00Z"`
+	AwaitingReview []RFCReference `json:"awaitingReview"`
+	FailedLoads    []RFCReference `json:"failedLoads"`
+	NewlyMerged    []RFCReference `json:"newlyMerged"`
+	// Domain is the schema domain of the tracking repository the digest summarizes, empty for the default one
+	Domain string `json:"domain,omitempty" example:"catalog"`
+} //@name Digest
+
+// IsEmpty returns true if nothing in the digest concerns the team
+func (d *Digest) IsEmpty() bool {
+	return len(d.AwaitingReview) == 0 && len(d.FailedLoads) == 0 && len(d.NewlyMerged) == 0
+}
+
+// holds a chronological feed of RFC lifecycle events, newest first
+type ActivityFeed struct {
+	Events []Event `json:"events"`
+	Count  int     `json:"count" example:"10"`
+} //@name ActivityFeed
+
+// holds the state of the maintenance mode, during which mutating operations are rejected
+type Maintenance struct {
+	Enabled bool       `json:"enabled" example:"true"`
+	Message string     `json:"message,omitempty" example:"Harmonia is undergoing maintenance..."`
+	Since   *time.Time `json:"since,omitempty" example:"2022-06-01T09:00:00Z"`
+} //@name Maintenance
+
+// holds an RFC file integrity failure and how to repair it
+type Integrity struct {
+	Error         string `json:"error" example:"RFC 123456 file is corrupt"`
+	Code          Code   `json:"code" example:"RFC_INTEGRITY"`
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	Reason        string `json:"reason" example:"corrupt"`
+	Remediation   string `json:"remediation" example:"an administrator can restore the RFC file..."`
+} //@name Integrity
+
+// holds the action data of an RFC that does not conform to the schemas of the tracking repository
+type SchemaViolation struct {
+	Error  string            `json:"error" example:"action data does not conform to its schema"`
+	Code   Code              `json:"code" example:"INVALID_ACTION_DATA"`
+	Errors []ValidationError `json:"errors"`
+} //@name SchemaViolation
+
+// holds the open RFC a submission duplicates
+type Duplicate struct {
+	Error         string `json:"error" example:"RFC is identical to open RFC 123456"`
+	Code          Code   `json:"code" example:"DUPLICATE_RFC"`
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	Links         *Links `json:"links,omitempty"`
+} //@name Duplicate
+
+// holds the latest contents of an RFC updated since the update was made from it
+type StaleRFC struct {
+	Error         string `json:"error" example:"RFC 123456 was updated since it was read, its signature is now 5d41..."`
+	Code          Code   `json:"code" example:"STALE_RFC"`
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	// Signature is the signature of the latest contents, to make the update from
+	Signature string `json:"signature" example:"5d41402abc4b2a76b9719d911017c592"`
+	RFC       *RFC   `json:"rfc"`
+} //@name StaleRFC
+
+// holds the open RFCs checked by a signature migration, by the state of their signature
+type SignatureMigration struct {
+	Valid      []string `json:"valid" example:"123456"`
+	Migrated   []string `json:"migrated" example:"234567"`
+	Invalid    []string `json:"invalid" example:"345678"`
+	Unreadable []string `json:"unreadable" example:"456789"`
+	DryRun     bool     `json:"dryRun" example:"false"`
+} //@name SignatureMigration
+
+// holds the files generated from the action data schemas of a tracking repository and where they were committed
+type GeneratedTypes struct {
+	Domain string `json:"domain,omitempty" example:"catalog"`
+	// Repository is the repository the files are committed to, as "owner/name"
+	Repository string `json:"repository" example:"owner/schema-types"`
+	// Files are the paths of the generated files in the repository, sorted
+	Files []string `json:"files" example:"catalog/schemas.go,catalog/schemas.ts"`
+	// Contents holds the content of each generated file, by path, on a dry run only
+	Contents map[string]string `json:"contents,omitempty"`
+	// Failures holds why each schema file that could not be rendered failed, by file name
+	Failures map[string]string `json:"failures"`
+	// Committed is whether a commit was made, none is made on a dry run or if the files are unchanged
+	Committed bool `json:"committed" example:"true"`
+	DryRun    bool `json:"dryRun" example:"false"`
+} //@name GeneratedTypes
+
+// holds the outcome of checking the signatures recorded in an RFC file against its content
+type Verification struct {
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	// Valid is whether the signature of the RFC and of each of its signed actions match their content
+	Valid          bool           `json:"valid" example:"false"`
+	Signature      string         `json:"signature" example:"5d41402abc4b2a76b9719d911017c592"`
+	SignatureState SignatureState `json:"signatureState" example:"valid" enums:"valid,legacy,invalid"`
+	// InvalidActions are the actions whose recorded signature does not match their content
+	InvalidActions []InvalidAction `json:"invalidActions"`
+} //@name Verification
+
+// holds an action of an RFC whose recorded signature does not match its content
+type InvalidAction struct {
+	// Index is the position of the action in the actions of the RFC
+	Index             int        `json:"index" example:"0"`
+	ActionType        ActionType `json:"actionType" example:"add"`
+	Signature         string     `json:"signature" example:"7d357b0ef1f85ba71c5ccebb6671b0c3"`
+	ComputedSignature string     `json:"computedSignature" example:"9f86d081884c7d659a2feaa0c55ad015"`
+} //@name InvalidAction
+
+// holds the comments held for moderation, oldest first
+type HeldComments struct {
+	Comments []HeldComment `json:"comments"`
+} //@name HeldComments
+
+// holds the notifications whose delivery failed, oldest first
+type DeadLetters struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+} //@name DeadLetters
+
+// holds the reservations of target descriptors that have not expired, oldest first
+type Reservations struct {
+	Reservations []Reservation `json:"reservations"`
+} //@name Reservations
+
+// holds the result of submitting each RFC of a batch, in the order the RFCs were submitted
+type BatchSubmission struct {
+	Submitted int           `json:"submitted" example:"2"`
+	Failed    int           `json:"failed" example:"1"`
+	Results   []BatchResult `json:"results"`
+} //@name BatchSubmission
+
+// holds the result of submitting a single RFC of a batch, either its identifier or the error it failed with
+type BatchResult struct {
+	Index int `json:"index" example:"0"`
+	// RFCIdentifier holds the identifier, links and receipt of the RFC if it was submitted
+	*RFCIdentifier
+	// Status is the status a single submission of the RFC would have been answered with
+	Status int `json:"status" example:"200"`
+	// Error is the body a single submission of the RFC would have been answered with if it failed, see Error
+	Error interface{} `json:"error,omitempty" swaggertype:"object"`
+} //@name BatchResult
+
+// holds the sample RFCs seeded into a local stack, by the state they were left in
+type Seeded struct {
+	RFCs  map[string]string `json:"rfcs" swaggertype:"object,string" example:"open:123456"`
+	Links map[string]*Links `json:"links"`
+} //@name Seeded
+
 // Implement Marshaler interface to make the output more compact while retaining meaning of an ordered set of key
 // value pairs
 func (r *RFCs) MarshalJSON() ([]byte, error) {
@@ -69,6 +406,22 @@ func (r *RFCs) MarshalJSON() ([]byte, error) {
 		c := strconv.Itoa(*r.Count)
 		marshaled = append(marshaled, []byte(fmt.Sprintf(`, "count": %v`, c))...) // add count if it exists
 	}
+	if len(r.Links) > 0 {
+		linksJson, err := json.Marshal(r.Links)
+		if err != nil {
+			return nil, err
+		}
+		marshaled = append(marshaled, []byte(`, "links": `)...) // add links if they exist
+		marshaled = append(marshaled, linksJson...)
+	}
+	if len(r.Summaries) > 0 {
+		summariesJson, err := json.Marshal(r.Summaries)
+		if err != nil {
+			return nil, err
+		}
+		marshaled = append(marshaled, []byte(`, "summaries": `)...) // add summaries if they exist
+		marshaled = append(marshaled, summariesJson...)
+	}
 	marshaled = append(marshaled, []byte(`}`)...) // close braces
 	return marshaled, nil
 }
@@ -0,0 +1,92 @@
+// this holds review type definitions and the mapping of Harmonia review intents to Git provider review types
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ReviewType represents the intent of a review
+type ReviewType string
+
+// base review types, these are understood natively by every Git provider
+var ApproveReview ReviewType = "APPROVE"
+var RequestChangesReview ReviewType = "REQUEST_CHANGES"
+var CommentReview ReviewType = "COMMENT"
+
+// Harmonia review intents, these are submitted to the Git provider as one of the base review types
+var AcknowledgeReview ReviewType = "ACKNOWLEDGE"
+var BlockReview ReviewType = "BLOCK"
+
+// reviewTypes maps every supported review intent to the base review type it is submitted to the Git provider as
+var reviewTypes = map[ReviewType]ReviewType{
+	ApproveReview:        ApproveReview,
+	RequestChangesReview: RequestChangesReview,
+	CommentReview:        CommentReview,
+	AcknowledgeReview:    CommentReview,
+	BlockReview:          RequestChangesReview,
+}
+var reviewTypesMu sync.RWMutex
+
+// RegisterReviewType adds a custom review intent that is submitted to the Git provider as the given base review type
+func RegisterReviewType(intent ReviewType, base ReviewType) error {
+	intent = ReviewType(strings.ToUpper(string(intent)))
+	if base != ApproveReview && base != RequestChangesReview && base != CommentReview {
+		return fmt.Errorf("review type %s must map to one of %s, %s or %s, not %s", intent, ApproveReview,
+			RequestChangesReview, CommentReview, base)
+	}
+
+	reviewTypesMu.Lock()
+	defer reviewTypesMu.Unlock()
+	reviewTypes[intent] = base
+
+	return nil
+}
+
+// IsValid returns true if the review type is a registered review intent
+func (r ReviewType) IsValid() bool {
+	reviewTypesMu.RLock()
+	defer reviewTypesMu.RUnlock()
+
+	_, ok := reviewTypes[r]
+	return ok
+}
+
+// IsCustom returns true if the review type is a Harmonia intent rather than a base review type
+func (r ReviewType) IsCustom() bool {
+	base, err := r.Base()
+	return err == nil && base != r
+}
+
+// Base returns the base review type the review intent is submitted to the Git provider as
+func (r ReviewType) Base() (ReviewType, error) {
+	reviewTypesMu.RLock()
+	defer reviewTypesMu.RUnlock()
+
+	base, ok := reviewTypes[r]
+	if !ok {
+		return "", fmt.Errorf("invalid review type '%s', allowed values: %s", r, strings.Join(allowedReviewTypes(), ", "))
+	}
+	return base, nil
+}
+
+// AllowedReviewTypes returns the sorted names of all registered review intents
+func AllowedReviewTypes() []string {
+	reviewTypesMu.RLock()
+	defer reviewTypesMu.RUnlock()
+
+	return allowedReviewTypes()
+}
+
+// allowedReviewTypes returns the sorted names of all registered review intents, the caller must hold the lock
+func allowedReviewTypes() []string {
+	allowed := make([]string, 0, len(reviewTypes))
+	for reviewType := range reviewTypes {
+		allowed = append(allowed, string(reviewType))
+	}
+	sort.Strings(allowed)
+
+	return allowed
+}
@@ -0,0 +1,138 @@
+// this holds the review requests of RFCs and how long their reviewers took to first respond to them, measured against
+// the review service level agreement (SLA)
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ReviewRequest is a request to review an RFC made to a reviewer or to a team, along with its first response
+type ReviewRequest struct {
+	Domain        string `json:"domain,omitempty" example:"catalog"`
+	RFCIdentifier string `json:"rfcIdentifier" example:"123456"`
+	// Reviewer is the login of the requested reviewer, or the slug of the requested team
+	Reviewer    string    `json:"reviewer" example:"tstark"`
+	Team        bool      `json:"team" example:"false"`
+	RequestedAt time.Time `json:"requestedAt" example:"2022-06-01T09:00:00Z"`
+	// RespondedAt is when the reviewer, or a member of the team, first reviewed the RFC, nil while it is awaited
+	RespondedAt *time.Time `json:"respondedAt,omitempty" example:"2022-06-02T09:00:00Z"`
+	Responder   string     `json:"responder,omitempty" example:"tstark"`
+} //@name ReviewRequest
+
+// ResponseTime returns how long the reviewer took to first respond to the request, or has been awaited for at the given
+// time if they have not responded yet
+func (r *ReviewRequest) ResponseTime(now time.Time) time.Duration {
+	if r.RespondedAt != nil {
+		return r.RespondedAt.Sub(r.RequestedAt)
+	}
+	return now.Sub(r.RequestedAt)
+}
+
+// Breached returns whether the request was, or has been at the given time, awaited for longer than the given SLA
+func (r *ReviewRequest) Breached(now time.Time, sla time.Duration) bool {
+	return r.ResponseTime(now) > sla
+}
+
+// ReviewerStats holds the review workload of a reviewer or team and how quickly they respond to review requests
+type ReviewerStats struct {
+	// Reviewer is the login of the reviewer, or the slug of the team
+	Reviewer string `json:"reviewer" example:"tstark"`
+	Team     bool   `json:"team" example:"false"`
+	// Requests is the number of review requests made to the reviewer, pending ones included
+	Requests  int `json:"requests" example:"12"`
+	Responded int `json:"responded" example:"10"`
+	Pending   int `json:"pending" example:"2"`
+	// Breaches is the number of requests responded to, or awaited, past the SLA
+	Breaches int `json:"breaches" example:"1"`
+	// MeanResponseSeconds is the mean time taken to first respond to requests, 0 if none was responded to
+	MeanResponseSeconds float64 `json:"meanResponseSeconds" example:"5400"`
+	// LongestPendingSeconds is how long the longest awaited pending request has been awaited for, 0 if none is pending
+	LongestPendingSeconds float64 `json:"longestPendingSeconds" example:"187200"`
+} //@name ReviewerStats
+
+// ReviewStats holds the review workload of each reviewer and team of a tracking repository, along with the pending
+// review requests breaching the SLA
+type ReviewStats struct {
+	SLA string `json:"sla" example:"48h0m0s"`
+	// Window is the period requests are counted over, pending requests are counted however old they are
+	Window string `json:"window" example:"720h0m0s"`
+	// Reviewers holds the stats of each reviewer and team, sorted by reviewer
+	Reviewers []ReviewerStats `json:"reviewers"`
+	// Breaches are the pending requests awaited past the SLA, longest awaited first
+	Breaches []ReviewRequest `json:"breaches"`
+} //@name ReviewStats
+
+// NewReviewStats returns the stats of the given review requests at the given time, for the given SLA. Requests made
+// before the given window are left out unless they are pending
+func NewReviewStats(requests []ReviewRequest, now time.Time, sla time.Duration, window time.Duration) ReviewStats {
+	stats := ReviewStats{SLA: sla.String(), Window: window.String(), Reviewers: []ReviewerStats{},
+		Breaches: []ReviewRequest{}}
+	since := now.Add(-window)
+
+	byReviewer := map[string]*ReviewerStats{}
+	responseTimes := map[string]time.Duration{}
+	for _, request := range requests {
+		if request.RespondedAt != nil && request.RequestedAt.Before(since) {
+			continue
+		}
+		key := request.Reviewer
+		if request.Team {
+			key = "team/" + key
+		}
+		reviewer, ok := byReviewer[key]
+		if !ok {
+			reviewer = &ReviewerStats{Reviewer: request.Reviewer, Team: request.Team}
+			byReviewer[key] = reviewer
+		}
+
+		reviewer.Requests++
+		responseTime := request.ResponseTime(now)
+		if request.RespondedAt != nil {
+			reviewer.Responded++
+			responseTimes[key] += responseTime
+		} else {
+			reviewer.Pending++
+			if responseTime.Seconds() > reviewer.LongestPendingSeconds {
+				reviewer.LongestPendingSeconds = responseTime.Seconds()
+			}
+		}
+		if request.Breached(now, sla) {
+			reviewer.Breaches++
+			if request.RespondedAt == nil {
+				stats.Breaches = append(stats.Breaches, request)
+			}
+		}
+	}
+
+	for key, reviewer := range byReviewer {
+		if reviewer.Responded > 0 {
+			reviewer.MeanResponseSeconds = responseTimes[key].Seconds() / float64(reviewer.Responded)
+		}
+		stats.Reviewers = append(stats.Reviewers, *reviewer)
+	}
+	sort.Slice(stats.Reviewers, func(i, j int) bool {
+		if stats.Reviewers[i].Reviewer != stats.Reviewers[j].Reviewer {
+			return stats.Reviewers[i].Reviewer < stats.Reviewers[j].Reviewer
+		}
+		return !stats.Reviewers[i].Team
+	})
+	sort.SliceStable(stats.Breaches, func(i, j int) bool {
+		return stats.Breaches[i].RequestedAt.Before(stats.Breaches[j].RequestedAt)
+	})
+
+	return stats
+}
+
+// ReviewReminder holds the pending review requests of a reviewer or team awaited past the SLA, the longest awaited of
+// which are escalated
+type ReviewReminder struct {
+	Domain   string `json:"domain,omitempty" example:"catalog"`
+	Reviewer string `json:"reviewer" example:"tstark"`
+	Team     bool   `json:"team" example:"false"`
+	SLA      string `json:"sla" example:"48h0m0s"`
+	// Overdue are the requests awaited past the SLA but not yet escalated, longest awaited first
+	Overdue []ReviewRequest `json:"overdue"`
+	// Escalated are the requests awaited past the escalation threshold, longest awaited first
+	Escalated []ReviewRequest `json:"escalated"`
+} //@name ReviewReminder
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReviewStats(t *testing.T) {
+	// arrange
+	now := time.Date(2022, 6, 10, 9, 0, 0, 0, time.UTC)
+	at := func(hoursAgo int) time.Time { return now.Add(-time.Duration(hoursAgo) * time.Hour) }
+	respondedAt := func(hoursAgo int) *time.Time { t := at(hoursAgo); return &t }
+	requests := []ReviewRequest{
+		// answered within and past the SLA
+		{RFCIdentifier: "1", Reviewer: "tstark", RequestedAt: at(100), RespondedAt: respondedAt(98)},
+		{RFCIdentifier: "2", Reviewer: "tstark", RequestedAt: at(100), RespondedAt: respondedAt(40)},
+		// awaited within and past the SLA
+		{RFCIdentifier: "3", Reviewer: "tstark", RequestedAt: at(10)},
+		{RFCIdentifier: "4", Reviewer: "avengers", Team: true, RequestedAt: at(72)},
+		// answered before the window
+		{RFCIdentifier: "5", Reviewer: "tstark", RequestedAt: at(24 * 40), RespondedAt: respondedAt(24 * 39)},
+	}
+
+	// act
+	stats := NewReviewStats(requests, now, 48*time.Hour, 30*24*time.Hour)
+
+	// assert
+	if len(stats.Reviewers) != 2 {
+		t.Fatalf("expected stats of 2 reviewers, got %+v", stats.Reviewers)
+	}
+	team, reviewer := stats.Reviewers[0], stats.Reviewers[1]
+	if !team.Team || team.Requests != 1 || team.Pending != 1 || team.Breaches != 1 ||
+		team.LongestPendingSeconds != (72*time.Hour).Seconds() || team.MeanResponseSeconds != 0 {
+		t.Errorf("unexpected team stats: %+v", team)
+	}
+	if reviewer.Team || reviewer.Requests != 3 || reviewer.Responded != 2 || reviewer.Pending != 1 ||
+		reviewer.Breaches != 1 || reviewer.MeanResponseSeconds != (31*time.Hour).Seconds() ||
+		reviewer.LongestPendingSeconds != (10*time.Hour).Seconds() {
+		t.Errorf("unexpected reviewer stats: %+v", reviewer)
+	}
+	if len(stats.Breaches) != 1 || stats.Breaches[0].RFCIdentifier != "4" {
+		t.Errorf("expected the pending team request to breach the SLA, got %+v", stats.Breaches)
+	}
+}
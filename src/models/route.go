@@ -10,4 +10,18 @@ type Route struct {
 	Path     string
 	Handler  gin.HandlerFunc
 	HttpVerb string
+	// Mutating routes change RFCs or the schema and are rejected while maintenance mode is enabled
+	Mutating bool
+	// Signed routes change state and, when request signing is enabled, are rejected unless they carry a valid signature
+	Signed bool
+	// Webhook routes receive GitHub webhook deliveries and are rejected unless they carry a valid webhook signature
+	Webhook bool
+	// External routes are called by external systems and are rejected unless they carry a valid signature of a
+	// registered external system
+	External bool
+	// Permission is the permission the user must be granted, when an authorization policy is configured, for the route
+	// to be served. Routes without one are served to everyone
+	Permission Permission
+	// Public routes are served without authentication when SSO is enabled, e.g. health checks, docs and webhooks
+	Public bool
 }
@@ -0,0 +1,27 @@
+// this holds the schema violations of RFCs, whose action data must conform to the schemas of their tracking repository
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaError is returned when the data of actions of an RFC does not conform to the schema of their action type and
+// target type, it holds an error for each offending field
+type SchemaError struct {
+	Errors []ValidationError
+}
+
+// Error returns a description of the schema violations
+func (e *SchemaError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		fields = append(fields, fmt.Sprintf("%s: %s", err.Field, err.Message))
+	}
+	return fmt.Sprintf("action data does not conform to its schema: %s", strings.Join(fields, "; "))
+}
+
+// Is returns whether the given error is ErrInvalid, the kind of schema errors
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrInvalid
+}
@@ -0,0 +1,77 @@
+// this holds the sample RFCs seeded into local stacks, so frontend and integration development starts out with RFCs
+// in every state rather than requiring manual setup
+package models
+
+import (
+	"fmt"
+)
+
+// SeedState represents the state a sample RFC is left in once seeded
+type SeedState string
+
+// OpenSeed represents an open RFC without reviews
+var OpenSeed SeedState = "open"
+var ApprovedSeed SeedState = "approved"
+var FailedLoadSeed SeedState = "failed_load"
+var MergedSeed SeedState = "merged"
+
+// SeedStates are the states sample RFCs are seeded in when none are requested, in seeding order
+var SeedStates = []SeedState{OpenSeed, ApprovedSeed, FailedLoadSeed, MergedSeed}
+
+// ErrUnknownSeedState is returned (wrapped) when a sample RFC is requested in a state it cannot be seeded in
+var ErrUnknownSeedState = NewError(ErrInvalid, InvalidParameterCode, "unknown seed state")
+
+// sampleActions holds the actions of the sample RFC of each seed state
+var sampleActions = map[SeedState]Action{
+	OpenSeed: {
+		ActionType: AddAction,
+		Target:     Target{TargetType: ItemTarget, TargetDescriptor: "Event"},
+		Data: map[string]interface{}{
+			"name":        "PlaybackStarted",
+			"description": "Emitted when a viewer starts playing a title",
+		},
+	},
+	ApprovedSeed: {
+		ActionType: UpdateAction,
+		Target: Target{
+			TargetType:       ItemTarget,
+			TargetDescriptor: "acceptedValueChecker",
+			LookupKey:        "name",
+			LookupValue:      "ContentRating",
+		},
+		Data: map[string]interface{}{"enum": "G;PG;PG-13;R"},
+	},
+	FailedLoadSeed: {
+		ActionType: AddAction,
+		Target:     Target{TargetType: ItemTarget, TargetDescriptor: "Field"},
+		Data: map[string]interface{}{
+			"name":        "ProfileLanguage",
+			"description": "Preferred language of a viewer profile",
+		},
+	},
+	MergedSeed: {
+		ActionType: AddAction,
+		Target:     Target{TargetType: ItemTarget, TargetDescriptor: "Event"},
+		Data: map[string]interface{}{
+			"name":        "ProfileCreated",
+			"description": "Emitted when a viewer profile is created",
+		},
+	},
+}
+
+// SampleRFC returns the sample RFC of the given seed state, in the given schema domain
+func SampleRFC(state SeedState, domain string) (*RFC, error) {
+	action, ok := sampleActions[state]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSeedState, state)
+	}
+
+	// copy the data so the sample can be signed and annotated without altering it
+	data := map[string]interface{}{}
+	for key, value := range action.Data {
+		data[key] = value
+	}
+	action.Data = data
+
+	return &RFC{Actions: Actions{&action}, Domain: domain}, nil
+}
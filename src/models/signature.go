@@ -0,0 +1,352 @@
+// this holds the canonical forms RFCs and actions are signed as
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// volatileActionTypes are the action types recorded against an RFC after it is signed, as it is reviewed and loaded
+// The RFC file changes each time one is added or updated, e.g. as its load status moves, without the change it proposes
+// changing, so they are left out of the signature of the RFC. Review actions are volatile too, see IsReview
+var volatileActionTypes = map[ActionType]bool{
+	CommentAction:          true,
+	LoadAction:             true,
+	AnnotationAction:       true,
+	WithdrawnAction:        true,
+	BreakGlassAction:       true,
+	AttestationAction:      true,
+	ExternalApprovalAction: true,
+}
+
+// SignatureState describes how the recorded signature of an RFC compares to the signature of its content
+type SignatureState string
+
+// SIGNATURE_VERSION is the version of the form RFCs are signed as, recorded along with their signature. Version 1 is
+// their canonical JSON, RFCs without a version were signed as marshaled
+const SIGNATURE_VERSION = 1
+
+// ErrUnsupportedSignature is returned (wrapped) when an RFC is signed at a version of its form this release predates
+var ErrUnsupportedSignature = NewError(ErrInvalid, UnsupportedSignatureCode, "unsupported signature version")
+
+// ValidSignature is the state of RFCs whose recorded signature is the signature of their canonical form
+var ValidSignature SignatureState = "valid"
+
+// LegacySignature is the state of RFCs signed before signature versions were introduced, whose recorded signature is
+// the signature of a form they were signed as back then. They can safely be signed again
+var LegacySignature SignatureState = "legacy"
+
+// InvalidSignature is the state of RFCs whose recorded signature matches none of the forms they may have been signed
+// as, typically because their file was edited by hand
+var InvalidSignature SignatureState = "invalid"
+
+// IsVolatile returns whether the action is recorded against its RFC after it is signed, see volatileActionTypes
+func (action *Action) IsVolatile() bool {
+	return volatileActionTypes[action.ActionType] || action.IsReview()
+}
+
+// IsReview returns whether the action records a review of its RFC, e.g. an approval, a request for changes or a
+// custom intent. Review actions are typed as their lowercased review intent, except top level review comments, which
+// are comment actions
+func (action *Action) IsReview() bool {
+	return action.ActionType != CommentAction && ReviewType(strings.ToUpper(string(action.ActionType))).IsValid()
+}
+
+// Canonical returns the canonical JSON the RFC is signed as, see canonicalJSON, made of the signed fields of the RFC
+// and of its actions. Volatile fields are left out of it so that the signature of an RFC holds as it is reviewed and
+// loaded: its own signature, signature version and identifier, the signatures of its actions, which are derived from
+// them, and its volatile actions, e.g. comments, annotations and its load status
+func (rfc *RFC) Canonical() ([]byte, error) {
+	return canonicalJSON(rfc.signedFields())
+}
+
+// signedFields returns the fields of the RFC its signature is made over. They are listed explicitly rather than
+// marshaled from the RFC so that adding a field to RFCs does not change the signature of existing ones, signing a new
+// field takes a new signature version
+func (rfc *RFC) signedFields() map[string]interface{} {
+	actions := []interface{}{}
+	for _, action := range rfc.Actions {
+		if action != nil && !action.IsVolatile() {
+			actions = append(actions, action.signedFields())
+		}
+	}
+
+	fields := map[string]interface{}{"actions": actions}
+	if rfc.Title != "" {
+		fields["title"] = rfc.Title
+	}
+	if rfc.EmbargoUntil != nil {
+		fields["embargoUntil"] = rfc.EmbargoUntil
+	}
+	if len(rfc.LoadTargets) > 0 {
+		fields["loadTargets"] = rfc.LoadTargets
+	}
+	if rfc.Priority != "" {
+		fields["priority"] = rfc.Priority
+	}
+	if len(rfc.DependsOn) > 0 {
+		fields["dependsOn"] = rfc.DependsOn
+	}
+	if rfc.Domain != "" {
+		fields["domain"] = rfc.Domain
+	}
+	return fields
+}
+
+// signedFields returns the fields of the action its signature is made over, every field but its signature
+func (action *Action) signedFields() map[string]interface{} {
+	target := map[string]interface{}{
+		"targetType":       action.Target.TargetType,
+		"targetDescriptor": action.Target.TargetDescriptor,
+	}
+	if action.Target.LookupKey != "" {
+		target["lookupKey"] = action.Target.LookupKey
+	}
+	if action.Target.LookupValue != "" {
+		target["lookupValue"] = action.Target.LookupValue
+	}
+
+	fields := map[string]interface{}{"actionType": action.ActionType, "target": target}
+	if len(action.Data) > 0 {
+		fields["data"] = action.Data
+	}
+	return fields
+}
+
+// canonicalJSON returns the canonical JSON encoding of the given value: its JSON form with the keys of every object
+// sorted, without insignificant whitespace and without escaping HTML characters, so that it only depends on the
+// content of the value and not on how it is held, e.g. the order of struct fields or of map entries
+func canonicalJSON(value interface{}) ([]byte, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		logging.Default.Error("json marshal canonical value error", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	// numbers are kept as marshaled rather than read back as floats
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err = decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err = writeCanonical(buf, decoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes the canonical JSON encoding of the given decoded JSON value to the given buffer
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(v.String())
+	case string:
+		encoded := &bytes.Buffer{}
+		encoder := json.NewEncoder(encoded)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		buf.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+// Sign records the signature of the RFC, made at the current signature version
+func (rfc *RFC) Sign() error {
+	signature, err := rfc.ToSha()
+	if err != nil {
+		return err
+	}
+	rfc.Signature = *signature
+	rfc.SignatureVersion = SIGNATURE_VERSION
+	return nil
+}
+
+// marshaled returns the JSON form of the RFC as marshaled, with the actions the given filter keeps, cleared of their
+// signatures if requested. The signature and identifier of the RFC are always left out. RFCs were signed as marshaled
+// before signature versions were introduced
+func (rfc *RFC) marshaled(keep func(action *Action) bool, clearSignatures bool) ([]byte, error) {
+	marshaled := RFC{Title: rfc.Title, EmbargoUntil: rfc.EmbargoUntil, LoadTargets: rfc.LoadTargets,
+		Priority: rfc.Priority, DependsOn: rfc.DependsOn, Domain: rfc.Domain}
+	// an RFC without actions keeps marshaling them as it did, null or empty
+	if rfc.Actions != nil {
+		marshaled.Actions = make(Actions, 0, len(rfc.Actions))
+	}
+	for _, action := range rfc.Actions {
+		if !keep(action) {
+			continue
+		}
+		kept := *action
+		if clearSignatures {
+			kept.Signature = ""
+		}
+		marshaled.Actions = append(marshaled.Actions, &kept)
+	}
+
+	jsonBytes, err := json.Marshal(&marshaled)
+	if err != nil {
+		logging.Default.Error("json marshal rfc error", logging.ERROR_KEY, err)
+		return nil, err
+	}
+	return jsonBytes, nil
+}
+
+// legacySha returns the signature of the action as made before signature versions were introduced, the SHA-256 of the
+// action as marshaled without its signature
+func (action *Action) legacySha() (string, error) {
+	unsigned := *action
+	unsigned.Signature = ""
+	jsonBytes, err := json.Marshal(&unsigned)
+	if err != nil {
+		logging.Default.Error("json marshal action error", logging.ERROR_KEY, err)
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(jsonBytes)), nil
+}
+
+// CheckSignature returns how the recorded signature of the RFC compares to the signature of its canonical form, as of
+// its signature version. RFCs signed before signature versions were introduced were signed as marshaled: without
+// their volatile fields or, before volatile fields were left out, either as submitted, i.e. with the actions they were
+// submitted with and without action signatures, or as updated, i.e. along with the signatures of their actions and
+// the comments carried over from the previous version. Each of those forms is recovered by leaving out the actions
+// recorded since, they are then checked in turn
+func (rfc *RFC) CheckSignature() (SignatureState, error) {
+	switch rfc.SignatureVersion {
+	case SIGNATURE_VERSION:
+		signature, err := rfc.ToSha()
+		if err != nil {
+			return "", err
+		}
+		if *signature == rfc.Signature {
+			return ValidSignature, nil
+		}
+		return InvalidSignature, nil
+	case 0:
+	default:
+		return "", fmt.Errorf("%w: version %d, expected at most version %d", ErrUnsupportedSignature,
+			rfc.SignatureVersion, SIGNATURE_VERSION)
+	}
+
+	// comments made through reviews are attributed to their commenter, comments submitted with the RFC are not
+	submitted := func(action *Action) bool {
+		return !reservedActionTypes[action.ActionType] && !action.IsReview() &&
+			(action.ActionType != CommentAction || action.Data[string(CommenterData)] == nil)
+	}
+	updated := func(action *Action) bool { return !reservedActionTypes[action.ActionType] && !action.IsReview() }
+	legacyForms := []struct {
+		keep            func(action *Action) bool
+		clearSignatures bool
+	}{{func(action *Action) bool { return !action.IsVolatile() }, true}, {submitted, true}, {updated, false}}
+	for _, form := range legacyForms {
+		jsonBytes, err := rfc.marshaled(form.keep, form.clearSignatures)
+		if err != nil {
+			return "", err
+		}
+		if fmt.Sprintf("%x", sha256.Sum256(jsonBytes)) == rfc.Signature {
+			return LegacySignature, nil
+		}
+	}
+
+	return InvalidSignature, nil
+}
+
+// Verify checks the signatures recorded in the RFC with the given identifier against its content: its own signature,
+// see CheckSignature, and the signature of each of its actions that is not volatile. Volatile actions are left out as
+// they are updated in place as the RFC is reviewed and loaded, e.g. a deleted comment or a load status
+func (rfc *RFC) Verify(rfcIdentifier string) (*Verification, error) {
+	state, err := rfc.CheckSignature()
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &Verification{RFCIdentifier: rfcIdentifier, Signature: rfc.Signature, SignatureState: state,
+		InvalidActions: []InvalidAction{}}
+	for i, action := range rfc.Actions {
+		if action == nil || action.IsVolatile() {
+			continue
+		}
+		signature, err := action.ToSha()
+		if err != nil {
+			return nil, err
+		}
+		if *signature == action.Signature {
+			continue
+		}
+		// action signatures identify actions, e.g. as the target of comments, so they are never signed again and
+		// signatures made before signature versions were introduced hold
+		if legacy, err := action.legacySha(); err != nil {
+			return nil, err
+		} else if legacy != action.Signature {
+			verification.InvalidActions = append(verification.InvalidActions, InvalidAction{Index: i,
+				ActionType: action.ActionType, Signature: action.Signature, ComputedSignature: *signature})
+		}
+	}
+	verification.Valid = state != InvalidSignature && len(verification.InvalidActions) == 0
+
+	return verification, nil
+}
+
+// Err returns a *IntegrityError reporting the RFC file as tampered with if the verification failed, nil otherwise
+func (v *Verification) Err() error {
+	if v.Valid {
+		return nil
+	}
+
+	var mismatches []string
+	if v.SignatureState == InvalidSignature {
+		mismatches = append(mismatches, "its signature")
+	}
+	for _, action := range v.InvalidActions {
+		mismatches = append(mismatches, "the signature of action "+strconv.Itoa(action.Index))
+	}
+	return NewIntegrityError(v.RFCIdentifier, TamperedRFC,
+		fmt.Errorf("its content no longer matches %s", strings.Join(mismatches, " and ")))
+}
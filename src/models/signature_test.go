@@ -0,0 +1,180 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestCheckSignature tests that signatures hold as volatile actions are recorded, and that RFCs signed the legacy way
+// are told apart from RFCs whose content changed
+func TestCheckSignature(t *testing.T) {
+	// arrange
+	newRFC := func() *RFC {
+		rfc := &RFC{Actions: Actions{{ActionType: AddAction, Target: Target{TargetType: ItemTarget,
+			TargetDescriptor: "Event"}, Data: map[string]interface{}{"id": "MyEvent"}}}}
+		_ = rfc.Sign()
+		sha, _ := rfc.Actions[0].ToSha()
+		rfc.Actions[0].Signature = *sha
+		return rfc
+	}
+	loaded := newRFC()
+	loaded.AddComments(map[string][]string{loaded.Actions[0].Signature: {"looks good"}}, "tstark")
+	loaded.UpdateLoadStatus("successful", "tstark")
+	// reviews are recorded after the RFC is signed, as approvals, requests for changes and custom intents
+	review := func(rfc *RFC, reviewType ReviewType) {
+		_ = rfc.AddAction(Action{ActionType: ActionType(strings.ToLower(string(reviewType))),
+			Target: Target{TargetType: RfcTarget, LookupKey: SignatureLookupKey, LookupValue: rfc.Signature},
+			Data:   map[string]interface{}{string(ReviewerData): "tstark"}})
+	}
+	reviewed := newRFC()
+	for _, reviewType := range []ReviewType{ApproveReview, RequestChangesReview, AcknowledgeReview, BlockReview} {
+		review(reviewed, reviewType)
+	}
+	// updates used to sign RFCs along with the signatures of their actions
+	legacy := newRFC()
+	legacy.Signature, legacy.SignatureVersion = "", 0
+	content, _ := json.Marshal(legacy)
+	legacy.Signature = fmt.Sprintf("%x", sha256.Sum256(content))
+	legacy.UpdateLoadStatus("loading", "tstark")
+	review(legacy, ApproveReview)
+	// RFCs were signed as marshaled, without their volatile fields, before signature versions were introduced
+	marshaled := newRFC()
+	marshaled.SignatureVersion = 0
+	content, _ = json.Marshal(&RFC{Actions: Actions{{ActionType: AddAction, Target: marshaled.Actions[0].Target,
+		Data: marshaled.Actions[0].Data}}})
+	marshaled.Signature = fmt.Sprintf("%x", sha256.Sum256(content))
+	edited := newRFC()
+	edited.Actions[0].Data["id"] = "YourEvent"
+	legacyEdited := newRFC()
+	legacyEdited.SignatureVersion = 0
+	testCases := []struct {
+		name     string
+		rfc      *RFC
+		expected SignatureState
+	}{
+		{name: "volatile actions recorded", rfc: loaded, expected: ValidSignature},
+		{name: "reviewed", rfc: reviewed, expected: ValidSignature},
+		{name: "signed the legacy way", rfc: legacy, expected: LegacySignature},
+		{name: "signed as marshaled", rfc: marshaled, expected: LegacySignature},
+		{name: "edited by hand", rfc: edited, expected: InvalidSignature},
+		{name: "version removed by hand", rfc: legacyEdited, expected: InvalidSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			state, err := tc.rfc.CheckSignature()
+
+			// assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if state != tc.expected {
+				t.Errorf("expected a %s signature, got %s", tc.expected, state)
+			}
+		})
+	}
+	// act & assert RFCs signed at a version this release predates are refused
+	future := newRFC()
+	future.SignatureVersion = SIGNATURE_VERSION + 1
+	if _, err := future.CheckSignature(); !errors.Is(err, ErrUnsupportedSignature) {
+		t.Errorf("expected an unsupported signature error, got %v", err)
+	}
+}
+
+// TestCanonical tests that the canonical JSON of an RFC only depends on its signed fields, whatever the order its
+// action data was built in, and that keys are sorted without escaping HTML characters
+func TestCanonical(t *testing.T) {
+	// arrange
+	first := &RFC{Title: "Add <Event>", Actions: Actions{{ActionType: AddAction, Target: Target{TargetType: ItemTarget,
+		TargetDescriptor: "Event"}, Data: map[string]interface{}{"id": "MyEvent", "fields": map[string]interface{}{
+		"b": 1.5, "a": []interface{}{true, nil}}}}}}
+	second := &RFC{Title: "Add <Event>", Identifier: "123456", Signature: "signature", SignatureVersion: 1,
+		Actions: Actions{{ActionType: AddAction, Signature: "signature", Target: Target{TargetType: ItemTarget,
+			TargetDescriptor: "Event"}, Data: map[string]interface{}{"fields": map[string]interface{}{
+			"a": []interface{}{true, nil}, "b": 1.5}, "id": "MyEvent"}}}}
+
+	// act
+	firstJSON, err := first.Canonical()
+	secondJSON, secondErr := second.Canonical()
+
+	// assert
+	if err != nil || secondErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, secondErr)
+	}
+	expected := `{"actions":[{"actionType":"add","data":{"fields":{"a":[true,null],"b":1.5},"id":"MyEvent"},` +
+		`"target":{"targetDescriptor":"Event","targetType":"item"}}],"title":"Add <Event>"}`
+	if string(firstJSON) != expected || string(secondJSON) != expected {
+		t.Errorf("unexpected canonical JSON:\n%s\n%s", firstJSON, secondJSON)
+	}
+}
+
+// TestVerify tests that RFCs are verified against the signatures recorded for them and for their actions, volatile
+// actions aside, and that failed verifications are reported as tampered files
+func TestVerify(t *testing.T) {
+	// arrange
+	newRFC := func() *RFC {
+		rfc := &RFC{Actions: Actions{{ActionType: AddAction, Target: Target{TargetType: ItemTarget,
+			TargetDescriptor: "Event"}, Data: map[string]interface{}{"id": "MyEvent"}}}}
+		sha, _ := rfc.Actions[0].ToSha()
+		rfc.Actions[0].Signature = *sha
+		_ = rfc.Sign()
+		return rfc
+	}
+	loaded := newRFC()
+	loaded.AddComments(map[string][]string{loaded.Actions[0].Signature: {"looks good"}}, "tstark")
+	loaded.UpdateLoadStatus("successful", "tstark")
+	loaded.Actions[1].Data["comment"] = "edited in place"
+	reviewed := newRFC()
+	_ = reviewed.AddAction(Action{ActionType: "approve", Target: Target{TargetType: RfcTarget,
+		LookupKey: SignatureLookupKey, LookupValue: reviewed.Signature},
+		Data: map[string]interface{}{string(ReviewerData): "tstark"}})
+	// the signature of the RFC recomputed, but not that of its action
+	resigned := newRFC()
+	resigned.Actions[0].Data["id"] = "YourEvent"
+	_ = resigned.Sign()
+	// actions signed before signature versions were introduced keep their signature
+	legacy := newRFC()
+	legacy.Actions[0].Signature, _ = legacy.Actions[0].legacySha()
+	edited := newRFC()
+	edited.Actions[0].Data["id"] = "YourEvent"
+	testCases := []struct {
+		name           string
+		rfc            *RFC
+		expected       bool
+		state          SignatureState
+		invalidActions int
+	}{
+		{name: "volatile actions recorded", rfc: loaded, expected: true, state: ValidSignature},
+		{name: "reviewed", rfc: reviewed, expected: true, state: ValidSignature},
+		{name: "RFC signed again", rfc: resigned, expected: false, state: ValidSignature, invalidActions: 1},
+		{name: "legacy action signature", rfc: legacy, expected: true, state: ValidSignature},
+		{name: "edited by hand", rfc: edited, expected: false, state: InvalidSignature, invalidActions: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			verification, err := tc.rfc.Verify("123456")
+
+			// assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if verification.Valid != tc.expected || verification.SignatureState != tc.state ||
+				len(verification.InvalidActions) != tc.invalidActions {
+				t.Errorf("unexpected verification: %+v", verification)
+			}
+			var integrityErr *IntegrityError
+			if err = verification.Err(); tc.expected && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if !tc.expected && (!errors.As(err, &integrityErr) || integrityErr.Reason != TamperedRFC) {
+				t.Errorf("expected a tampered RFC error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// this holds the records Harmonia makes in RFC files on behalf of users authenticated through SSO, who all act on the
+// Git provider as the account of the shared token, so that the provider cannot tell who submitted or reviewed an RFC
+// The records are sealed with a secret of Harmonia's own since RFC files can be changed by anyone who can push to
+// their branch
+package models
+
+import (
+	"strings"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// RecordSealer returns the HMAC of the given content keyed with the secret Harmonia seals records with
+type RecordSealer func(content []byte) string
+
+// RecordSealChecker returns whether the given seal is the HMAC of the given content keyed with the secret Harmonia
+// seals records with
+type RecordSealChecker func(content []byte, seal string) bool
+
+// submitterSealedContent returns the content the seal of the submitter of the RFC of the given identifier is made
+// over. It is bound to the identifier rather than the signature of the RFC, which changes as the RFC is updated
+func (rfc *RFC) submitterSealedContent(rfcIdentifier string) ([]byte, error) {
+	return canonicalJSON(map[string]interface{}{
+		"rfcIdentifier": rfcIdentifier,
+		"submitter":     rfc.Submitter,
+	})
+}
+
+// SealSubmitter seals the submitter of the RFC of the given identifier with the given sealer
+func (rfc *RFC) SealSubmitter(rfcIdentifier string, seal RecordSealer) error {
+	content, err := rfc.submitterSealedContent(rfcIdentifier)
+	if err != nil {
+		return err
+	}
+	rfc.SubmitterSeal = seal(content)
+
+	return nil
+}
+
+// SealedSubmitter returns the submitter of the RFC of the given identifier if its seal is accepted by the given
+// checker, empty otherwise
+func (rfc *RFC) SealedSubmitter(rfcIdentifier string, check RecordSealChecker) string {
+	if rfc.Submitter == "" || rfc.SubmitterSeal == "" {
+		return ""
+	}
+	content, err := rfc.submitterSealedContent(rfcIdentifier)
+	if err != nil || !check(content, rfc.SubmitterSeal) {
+		logging.Default.Warn("ignoring RFC submitter with an invalid seal", "submitter", rfc.Submitter)
+		return ""
+	}
+
+	return rfc.Submitter
+}
+
+// reviewSealedContent returns the content the seal of a review of the given reviewer and intent is made over: the
+// review along with the identifier and signature of the RFC it was made on, so it cannot be moved to another RFC nor
+// outlive an update of the RFC
+func reviewSealedContent(rfcIdentifier string, rfcSignature string, reviewer string, intent ReviewType) ([]byte,
+	error) {
+	return canonicalJSON(map[string]interface{}{
+		"rfcIdentifier":      rfcIdentifier,
+		"rfcSignature":       rfcSignature,
+		string(ReviewerData): reviewer,
+		"reviewType":         string(intent),
+	})
+}
+
+// SealReview seals the given review action, made on the RFC of the given identifier as it is currently signed, with
+// the given sealer
+func (rfc *RFC) SealReview(rfcIdentifier string, action *Action, seal RecordSealer) error {
+	reviewer, _ := action.Data[string(ReviewerData)].(string)
+	intent := ReviewType(strings.ToUpper(string(action.ActionType)))
+	content, err := reviewSealedContent(rfcIdentifier, rfc.Signature, reviewer, intent)
+	if err != nil {
+		return err
+	}
+	action.Data[string(SealData)] = seal(content)
+
+	return nil
+}
+
+// SealedReviews returns the base review type of the latest approval or request for changes of each reviewer on the
+// RFC of the given identifier as it is currently signed, keyed by reviewer. Reviews whose seal the given checker
+// rejects are ignored, and comments never override a reviewer's approval or request for changes
+func (rfc *RFC) SealedReviews(rfcIdentifier string, check RecordSealChecker) map[string]ReviewType {
+	reviews := map[string]ReviewType{}
+	for _, action := range rfc.Actions {
+		if action == nil || !action.IsReview() || action.Target.LookupValue != rfc.Signature {
+			continue
+		}
+		reviewer, _ := action.Data[string(ReviewerData)].(string)
+		seal, _ := action.Data[string(SealData)].(string)
+		if reviewer == "" || seal == "" {
+			continue
+		}
+		intent := ReviewType(strings.ToUpper(string(action.ActionType)))
+		base, err := intent.Base()
+		if err != nil || base == CommentReview {
+			continue
+		}
+		content, err := reviewSealedContent(rfcIdentifier, rfc.Signature, reviewer, intent)
+		if err != nil || !check(content, seal) {
+			logging.Default.Warn("ignoring RFC review with an invalid seal", "reviewer", reviewer)
+			continue
+		}
+		reviews[reviewer] = base
+	}
+
+	return reviews
+}
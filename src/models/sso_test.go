@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+)
+
+// testSeal and testCheck stand in for the sealer of Harmonia, the seal being the content itself
+func testSeal(content []byte) string {
+	return "sealed:" + string(content)
+}
+
+func testCheck(content []byte, seal string) bool {
+	return seal == testSeal(content)
+}
+
+// TestSealedSubmitter tests that only the submitter sealed for the RFC is returned
+func TestSealedSubmitter(t *testing.T) {
+	// arrange
+	rfc := &RFC{Submitter: "tstark"}
+	if err := rfc.SealSubmitter("rfc-1", testSeal); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	forged := &RFC{Submitter: "bbanner", SubmitterSeal: rfc.SubmitterSeal}
+
+	// act & assert
+	if submitter := rfc.SealedSubmitter("rfc-1", testCheck); submitter != "tstark" {
+		t.Errorf("expected the sealed submitter, got '%s'", submitter)
+	}
+	if submitter := rfc.SealedSubmitter("rfc-2", testCheck); submitter != "" {
+		t.Errorf("expected the seal not to hold for another RFC, got '%s'", submitter)
+	}
+	if submitter := forged.SealedSubmitter("rfc-1", testCheck); submitter != "" {
+		t.Errorf("expected a forged submitter to be ignored, got '%s'", submitter)
+	}
+	if submitter := (&RFC{Submitter: "tstark"}).SealedSubmitter("rfc-1", testCheck); submitter != "" {
+		t.Errorf("expected an unsealed submitter to be ignored, got '%s'", submitter)
+	}
+}
+
+// TestSealedReviews tests that the latest sealed approval or request for changes of each reviewer is returned
+func TestSealedReviews(t *testing.T) {
+	// arrange
+	rfc := &RFC{Signature: "rfc-sha"}
+	review := func(actionType ActionType, reviewer string, sealed bool, signature string) {
+		action := &Action{
+			ActionType: actionType,
+			Target:     Target{TargetType: RfcTarget, LookupKey: SignatureLookupKey, LookupValue: signature},
+			Data:       map[string]interface{}{string(ReviewerData): reviewer},
+		}
+		if sealed {
+			current := rfc.Signature
+			rfc.Signature = signature
+			if err := rfc.SealReview("rfc-1", action, testSeal); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			rfc.Signature = current
+		}
+		rfc.Actions = append(rfc.Actions, action)
+	}
+	review("approve", "tstark", true, "rfc-sha")
+	review("request_changes", "bbanner", true, "rfc-sha")
+	review("approve", "bbanner", true, "rfc-sha")
+	review("block", "nromanoff", true, "rfc-sha")
+	review("acknowledge", "nromanoff", true, "rfc-sha")
+	review("approve", "pparker", false, "rfc-sha")
+	review("approve", "sstrange", true, "stale-sha")
+
+	// act
+	reviews := rfc.SealedReviews("rfc-1", testCheck)
+	other := rfc.SealedReviews("rfc-2", testCheck)
+
+	// assert
+	expected := map[string]ReviewType{
+		"tstark":    ApproveReview,
+		"bbanner":   ApproveReview,
+		"nromanoff": RequestChangesReview,
+	}
+	if len(reviews) != len(expected) {
+		t.Errorf("unexpected sealed reviews. expected: %v\n actual: %v", expected, reviews)
+	}
+	for reviewer, base := range expected {
+		if reviews[reviewer] != base {
+			t.Errorf("unexpected review of %s. expected: %s\n actual: %s", reviewer, base, reviews[reviewer])
+		}
+	}
+	if len(other) != 0 {
+		t.Errorf("expected reviews not to hold for another RFC, got %v", other)
+	}
+}
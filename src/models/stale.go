@@ -0,0 +1,37 @@
+// this holds the detection of RFC updates made from contents that have since been updated
+package models
+
+import "fmt"
+
+// ErrSignatureRequired is returned (wrapped) when an RFC is updated without the signature of the RFC it was made from
+var ErrSignatureRequired = NewError(ErrInvalid, SignatureRequiredCode, "signature of the updated RFC required")
+
+// StaleUpdateError reports an RFC update made from contents that have since been updated, which it would overwrite
+type StaleUpdateError struct {
+	// RFCIdentifier is the identifier of the updated RFC
+	RFCIdentifier string
+	// Latest is the RFC as it currently is, to make the update from
+	Latest *RFC
+}
+
+// Error returns a description of the stale update
+func (e *StaleUpdateError) Error() string {
+	return fmt.Sprintf("RFC %s was updated since it was read, its signature is now %s", e.RFCIdentifier,
+		e.Latest.Signature)
+}
+
+// Is returns whether the given error is ErrConflict, the kind of stale update errors
+func (e *StaleUpdateError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// CheckUpdatedFrom returns an error if the given signature, that of the RFC an update was made from, is missing or is
+// not the signature of this RFC, i.e. the RFC with the given identifier was updated since the update was made
+func (rfc *RFC) CheckUpdatedFrom(rfcIdentifier string, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("%w: RFC %s", ErrSignatureRequired, rfcIdentifier)
+	} else if signature != rfc.Signature {
+		return &StaleUpdateError{RFCIdentifier: rfcIdentifier, Latest: rfc}
+	}
+	return nil
+}
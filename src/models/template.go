@@ -0,0 +1,173 @@
+// this holds the template variables actions can reference as ${variable} in their data, so the same RFC can be
+// reused across environments. Variables are expanded when the RFC is loaded, the RFC file keeps the placeholders
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TemplateVariable represents a variable actions can reference in their data
+type TemplateVariable string
+
+// SubmittedAtVariable represents the date the RFC was submitted on, as YYYY-MM-DD
+var SubmittedAtVariable TemplateVariable = "submittedAt"
+var SubmitterVariable TemplateVariable = "submitter"
+var EnvironmentVariable TemplateVariable = "environment"
+var RFCIdentifierVariable TemplateVariable = "rfcIdentifier"
+
+// TemplateVariables are the variables actions can reference
+var TemplateVariables = []TemplateVariable{SubmittedAtVariable, SubmitterVariable, EnvironmentVariable,
+	RFCIdentifierVariable}
+
+// environment is the environment the application is deployed to, the value of EnvironmentVariable
+var environment string
+var environmentMu sync.RWMutex
+
+// SetEnvironment sets the environment the application is deployed to, an empty environment leaves
+// EnvironmentVariable without a value
+func SetEnvironment(name string) {
+	environmentMu.Lock()
+	defer environmentMu.Unlock()
+	environment = name
+}
+
+// Environment returns the environment the application is deployed to, empty if it was not set
+func Environment() string {
+	environmentMu.RLock()
+	defer environmentMu.RUnlock()
+	return environment
+}
+
+// ErrUnknownVariable is returned (wrapped) when an action references a variable that does not exist or has no value
+var ErrUnknownVariable = NewError(ErrInvalid, UnknownVariableCode, "unknown template variable")
+
+// placeholderPattern matches ${variable} placeholders, and $${ escapes of a literal ${
+var placeholderPattern = regexp.MustCompile(`\$\$\{|\$\{([^}]*)\}`)
+
+// expandString replaces the placeholders of the given string with the value of their variable, calling unknown for
+// each placeholder whose variable has no value
+func expandString(value string, variables map[TemplateVariable]string, unknown func(name string)) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if placeholder == "$${" {
+			return "${"
+		}
+		name := strings.TrimSpace(placeholder[2 : len(placeholder)-1])
+		expanded, ok := variables[TemplateVariable(name)]
+		if !ok {
+			unknown(name)
+			return placeholder
+		}
+		return expanded
+	})
+}
+
+// expandValue returns a copy of the given JSON value with the placeholders of every string it holds expanded, see
+// expandString. Object keys are left as they are
+func expandValue(value interface{}, variables map[TemplateVariable]string, unknown func(name string)) interface{} {
+	switch value := value.(type) {
+	case string:
+		return expandString(value, variables, unknown)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			expanded[key] = expandValue(item, variables, unknown)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, len(value))
+		for i, item := range value {
+			expanded[i] = expandValue(item, variables, unknown)
+		}
+		return expanded
+	}
+	return value
+}
+
+// variableNames returns the sorted names of the given variables
+func variableNames(variables map[TemplateVariable]string) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Expand returns a copy of the RFC whose proposal actions have the placeholders of their data replaced with the value
+// of the given variables, the RFC itself is left untouched. Every placeholder must reference one of the given
+// variables, otherwise an error wrapping ErrUnknownVariable listing the unknown ones is returned
+func (rfc *RFC) Expand(variables map[TemplateVariable]string) (*RFC, error) {
+	unknown := map[string]bool{}
+	record := func(name string) { unknown[name] = true }
+
+	expanded := *rfc
+	expanded.Actions = make(Actions, len(rfc.Actions))
+	for i, action := range rfc.Actions {
+		if action == nil || !action.IsProposal() || action.Data == nil {
+			expanded.Actions[i] = action
+			continue
+		}
+		copied := *action
+		copied.Data = expandValue(action.Data, variables, record).(map[string]interface{})
+		expanded.Actions[i] = &copied
+	}
+
+	if len(unknown) > 0 {
+		names := make([]string, 0, len(unknown))
+		for name := range unknown {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %s, expected one of %s", ErrUnknownVariable, strings.Join(names, ", "),
+			strings.Join(variableNames(variables), ", "))
+	}
+
+	return &expanded, nil
+}
+
+// unknownVariables returns the variables the data of the action references that are not template variables
+func (action *Action) unknownVariables() []string {
+	known := map[TemplateVariable]string{}
+	for _, variable := range TemplateVariables {
+		known[variable] = ""
+	}
+
+	names := []string{}
+	expandValue(action.Data, known, func(name string) { names = append(names, name) })
+	return names
+}
+
+// IsTemplate returns whether the data of any proposal action of the RFC holds a placeholder or an escape, see Expand
+func (rfc *RFC) IsTemplate() bool {
+	for _, action := range rfc.Actions {
+		if action != nil && action.IsProposal() && holdsPlaceholder(action.Data) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsPlaceholder returns whether any string the given JSON value holds has a placeholder or an escape
+func holdsPlaceholder(value interface{}) bool {
+	switch value := value.(type) {
+	case string:
+		return placeholderPattern.MatchString(value)
+	case map[string]interface{}:
+		for _, item := range value {
+			if holdsPlaceholder(item) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if holdsPlaceholder(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestExpand tests that the placeholders of proposals are expanded into a copy of the RFC, leaving escapes, comments
+// and the RFC itself untouched, and that unknown variables are rejected
+func TestExpand(t *testing.T) {
+	// arrange
+	rfc := &RFC{Actions: Actions{
+		{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"},
+			Data: map[string]interface{}{
+				"topic":  "${ environment }.playback",
+				"labels": []interface{}{"by ${submitter} on ${submittedAt}", "$${literal}", 3.0},
+				"nested": map[string]interface{}{"rfc": "${rfcIdentifier}"},
+			}},
+		{ActionType: CommentAction, Target: Target{TargetType: RfcTarget, TargetDescriptor: "RFC"},
+			Data: map[string]interface{}{"comment": "should this be ${environment}?"}},
+	}}
+	variables := map[TemplateVariable]string{
+		EnvironmentVariable:   "qa",
+		SubmitterVariable:     "tstark",
+		SubmittedAtVariable:   "2022-09-01",
+		RFCIdentifierVariable: "123456",
+	}
+
+	// act
+	expanded, err := rfc.Expand(variables)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := map[string]interface{}{
+		"topic":  "qa.playback",
+		"labels": []interface{}{"by tstark on 2022-09-01", "${literal}", 3.0},
+		"nested": map[string]interface{}{"rfc": "123456"},
+	}
+	if !reflect.DeepEqual(expanded.Actions[0].Data, expected) {
+		t.Errorf("unexpected expansion: %v", expanded.Actions[0].Data)
+	}
+	if expanded.Actions[1] != rfc.Actions[1] {
+		t.Errorf("expected comments to be left untouched")
+	}
+	if rfc.Actions[0].Data["topic"] != "${ environment }.playback" {
+		t.Errorf("expected the RFC to keep its placeholders, got %v", rfc.Actions[0].Data)
+	}
+	if !rfc.IsTemplate() || (&RFC{Actions: Actions{rfc.Actions[1]}}).IsTemplate() {
+		t.Errorf("expected only RFCs with placeholders in their proposals to be templates")
+	}
+
+	// act
+	delete(variables, EnvironmentVariable)
+	_, err = rfc.Expand(variables)
+
+	// assert
+	if !errors.Is(err, ErrUnknownVariable) {
+		t.Errorf("expected an unknown variable error, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// this holds the validation of RFCs ahead of their submission
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAction is returned (wrapped) when a proposal action does not meet the requirements of its action type
+var ErrInvalidAction = NewError(ErrInvalid, InvalidActionCode, "invalid action")
+
+// reservedActionTypes are the action types Harmonia records itself, which cannot be part of a submission
+var reservedActionTypes = map[ActionType]bool{
+	LoadAction:             true,
+	AnnotationAction:       true,
+	WithdrawnAction:        true,
+	BreakGlassAction:       true,
+	AttestationAction:      true,
+	ExternalApprovalAction: true,
+}
+
+// lookupActionTypes are the action types changing an existing item, whose item targets must look the item up
+var lookupActionTypes = map[ActionType]bool{
+	UpdateAction:    true,
+	DeleteAction:    true,
+	RenameAction:    true,
+	DeprecateAction: true,
+}
+
+// Validate checks the RFC as it would be submitted, without changing it: every action must have a submittable type
+// and a complete target, and action targets must resolve to another action of the RFC. Signatures are computed the
+// same way submissions compute them, so they can be referenced before the RFC is submitted
+// The returned validation is valid only if neither the RFC nor any of its actions has errors
+func (rfc *RFC) Validate() *Validation {
+	validation := &Validation{Actions: make([]ActionValidation, len(rfc.Actions))}
+	if len(rfc.Actions) == 0 {
+		validation.Errors = append(validation.Errors, ValidationError{Field: "actions",
+			Message: "at least one action is required"})
+	}
+	if !rfc.Priority.Valid() {
+		validation.Errors = append(validation.Errors, ValidationError{Field: "priority",
+			Message: fmt.Sprintf("unknown priority %s, expected one of %s, %s, %s or %s", rfc.Priority, LowPriority,
+				NormalPriority, HighPriority, UrgentPriority)})
+	}
+
+	validation.Errors = append(validation.Errors, rfc.validateDependencies()...)
+
+	// compute signatures first so action targets can be resolved, the RFC signature is computed before the action
+	// signatures are set, as on submission
+	if signature, err := rfc.ToSha(); err != nil {
+		validation.Errors = append(validation.Errors, ValidationError{Field: "signature", Message: err.Error()})
+	} else {
+		validation.Signature = *signature
+	}
+	signatures := map[string]bool{}
+	for i, action := range rfc.Actions {
+		validation.Actions[i].Index = i
+		if action == nil {
+			continue
+		}
+		if signature, err := action.ToSha(); err != nil {
+			validation.Actions[i].Errors = append(validation.Actions[i].Errors, ValidationError{Field: "signature",
+				Message: err.Error()})
+		} else {
+			validation.Actions[i].Signature = *signature
+			signatures[*signature] = true
+		}
+	}
+
+	for i, action := range rfc.Actions {
+		if action == nil {
+			validation.Actions[i].Errors = []ValidationError{{Message: "action is required"}}
+		} else {
+			validation.Actions[i].Errors = append(validation.Actions[i].Errors, action.validate(signatures)...)
+		}
+		if len(validation.Actions[i].Errors) > 0 {
+			validation.Errors = append(validation.Errors, ValidationError{Field: fmt.Sprintf("actions[%d]", i),
+				Message: "action is not valid"})
+		}
+	}
+
+	validation.Valid = len(validation.Errors) == 0
+	return validation
+}
+
+// validate returns the errors of the action, action targets are resolved against the given action signatures
+func (action *Action) validate(signatures map[string]bool) []ValidationError {
+	var errs []ValidationError
+	if action.ActionType == "" {
+		errs = append(errs, ValidationError{Field: "actionType", Message: "action type is required"})
+	} else if reservedActionTypes[action.ActionType] {
+		errs = append(errs, ValidationError{Field: "actionType",
+			Message: fmt.Sprintf("%s actions are recorded by Harmonia and cannot be submitted", action.ActionType)})
+	}
+
+	target := action.Target
+	switch target.TargetType {
+	case "":
+		errs = append(errs, ValidationError{Field: "target.targetType", Message: "target type is required"})
+	case ItemTarget, ActionTarget, RfcTarget:
+	default:
+		errs = append(errs, ValidationError{Field: "target.targetType", Message: fmt.Sprintf(
+			"unknown target type %s, must be one of %s, %s or %s", target.TargetType, ItemTarget, ActionTarget,
+			RfcTarget)})
+	}
+	if target.TargetDescriptor == "" {
+		errs = append(errs, ValidationError{Field: "target.targetDescriptor", Message: "target descriptor is required"})
+	}
+	if (target.LookupKey == "") != (target.LookupValue == "") {
+		errs = append(errs, ValidationError{Field: "target.lookupValue",
+			Message: "lookup key and lookup value must be set together"})
+	}
+
+	// placeholders in the data of proposals must reference template variables, they are expanded on load
+	if action.IsProposal() {
+		errs = append(errs, action.validateType()...)
+		for _, name := range action.unknownVariables() {
+			errs = append(errs, ValidationError{Field: "data", Message: fmt.Sprintf(
+				"unknown template variable %s", name)})
+		}
+	}
+
+	// action targets must point at another action of the RFC
+	if target.TargetType == ActionTarget && target.LookupKey == SignatureLookupKey && target.LookupValue != "" {
+		if !signatures[target.LookupValue] {
+			errs = append(errs, ValidationError{Field: "target.lookupValue", Message: fmt.Sprintf(
+				"no action of the RFC has signature %s", target.LookupValue)})
+		}
+	}
+
+	return errs
+}
+
+// validateType returns the errors of the proposal action against the requirements of its action type: the item
+// targets of actions changing an existing item must look it up, rename actions must carry the new name of the item and
+// reserve actions the team they reserve their target descriptor for
+func (action *Action) validateType() []ValidationError {
+	var errs []ValidationError
+	target := action.Target
+	if lookupActionTypes[action.ActionType] && target.TargetType == ItemTarget &&
+		(target.LookupKey == "" || target.LookupValue == "") {
+		errs = append(errs, ValidationError{Field: "target.lookupKey", Message: fmt.Sprintf(
+			"%s actions must look up the item they change with a lookup key and value", action.ActionType)})
+	}
+	if action.ActionType == RenameAction {
+		name, _ := action.Data[string(NewNameData)].(string)
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, ValidationError{Field: "data." + string(NewNameData),
+				Message: "rename actions must carry the new name of the item"})
+		} else if name == target.LookupValue {
+			errs = append(errs, ValidationError{Field: "data." + string(NewNameData),
+				Message: "the new name of the item must differ from its current name"})
+		}
+	}
+
+	if action.ActionType == ReserveAction {
+		if team, _ := action.Data[string(TeamData)].(string); strings.TrimSpace(team) == "" {
+			errs = append(errs, ValidationError{Field: "data." + string(TeamData),
+				Message: "reserve actions must name the team the target descriptor is reserved for"})
+		}
+		if _, ok := action.Data[string(ExpiresAtData)]; ok {
+			if _, valid := action.expiresAt(); !valid {
+				errs = append(errs, ValidationError{Field: "data." + string(ExpiresAtData),
+					Message: "the expiry of a reservation must be an RFC 3339 time"})
+			}
+		}
+	}
+
+	return errs
+}
+
+// CheckActions returns ErrInvalidAction (wrapped) describing the first proposal action of the RFC that does not meet
+// the requirements of its action type, so submissions, updates and loads reject them as Validate reports them
+func (rfc *RFC) CheckActions() error {
+	for i, action := range rfc.Actions {
+		if action == nil || !action.IsProposal() {
+			continue
+		}
+		if errs := action.validateType(); len(errs) > 0 {
+			return fmt.Errorf("%w: actions[%d].%s: %s", ErrInvalidAction, i, errs[0].Field, errs[0].Message)
+		}
+	}
+
+	return nil
+}
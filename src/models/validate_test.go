@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestValidate tests that every error of an RFC is reported against the action it was found in
+func TestValidate(t *testing.T) {
+	// arrange
+	add := &Action{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"}}
+	addSignature, _ := add.ToSha()
+	testCases := []struct {
+		rfc            *RFC
+		expectedErrors [][]string
+	}{
+		// valid RFC, with an action targeting another action
+		{
+			rfc: &RFC{Actions: Actions{add, {ActionType: "deprecate", Target: Target{TargetType: ActionTarget,
+				TargetDescriptor: "Event", LookupKey: SignatureLookupKey, LookupValue: *addSignature}}}},
+			expectedErrors: [][]string{nil, nil},
+		},
+		// no actions
+		{
+			rfc:            &RFC{},
+			expectedErrors: [][]string{},
+		},
+		// incomplete, reserved and dangling actions
+		{
+			rfc: &RFC{Actions: Actions{
+				{Target: Target{TargetType: "entity", TargetDescriptor: "Event"}},
+				{ActionType: LoadAction, Target: Target{TargetType: ItemTarget, LookupKey: "name"}},
+				{ActionType: AddAction, Target: Target{TargetType: ActionTarget, TargetDescriptor: "Event",
+					LookupKey: SignatureLookupKey, LookupValue: "missing"}},
+			}},
+			expectedErrors: [][]string{
+				{"actionType", "target.targetType"},
+				{"actionType", "target.targetDescriptor", "target.lookupValue"},
+				{"target.lookupValue"},
+			},
+		},
+		// template variables, only proposals must reference known ones
+		{
+			rfc: &RFC{Actions: Actions{
+				{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"},
+					Data: map[string]interface{}{"topic": "${environment}.events", "owner": "${region}"}},
+				{ActionType: CommentAction, Target: Target{TargetType: RfcTarget, TargetDescriptor: "RFC"},
+					Data: map[string]interface{}{"comment": "what is ${region}?"}},
+			}},
+			expectedErrors: [][]string{{"data"}, nil},
+		},
+		// action types changing an existing item must look it up, renames must carry the new name
+		{
+			rfc: &RFC{Actions: Actions{
+				{ActionType: DeleteAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event",
+					LookupKey: "name", LookupValue: "Played"}},
+				{ActionType: DeprecateAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"}},
+				{ActionType: RenameAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event",
+					LookupKey: "name", LookupValue: "Played"}, Data: map[string]interface{}{"newName": "Played"}},
+				{ActionType: RenameAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event",
+					LookupKey: "name"}},
+			}},
+			expectedErrors: [][]string{nil, {"target.lookupKey"}, {"data.newName"},
+				{"target.lookupValue", "target.lookupKey", "data.newName"}},
+		},
+		// reserve actions must name their team, and may only expire at a time
+		{
+			rfc: &RFC{Actions: Actions{
+				{ActionType: ReserveAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "playback.*"},
+					Data: map[string]interface{}{"team": "playback", "expiresAt": "2022-07-01T00:00:00Z"}},
+				{ActionType: ReserveAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Search"},
+					Data: map[string]interface{}{"expiresAt": "next week"}},
+			}},
+			expectedErrors: [][]string{nil, {"data.team", "data.expiresAt"}},
+		},
+	}
+
+	for _, test := range testCases {
+		// act
+		validation := test.rfc.Validate()
+
+		// assert
+		valid := len(test.rfc.Actions) > 0
+		for _, expected := range test.expectedErrors {
+			valid = valid && len(expected) == 0
+		}
+		if validation.Valid != valid || validation.Signature == "" {
+			t.Errorf("unexpected validation: %+v", validation)
+		}
+		if len(validation.Actions) != len(test.expectedErrors) {
+			t.Fatalf("expected %d action validations, got %d", len(test.expectedErrors), len(validation.Actions))
+		}
+		for i, action := range validation.Actions {
+			fields := []string{}
+			for _, err := range action.Errors {
+				fields = append(fields, err.Field)
+			}
+			if len(fields) != len(test.expectedErrors[i]) || action.Index != i || action.Signature == "" {
+				t.Errorf("unexpected validation of action %d: %+v, expected errors on %v", i, action,
+					test.expectedErrors[i])
+				continue
+			}
+			for j := range fields {
+				if fields[j] != test.expectedErrors[i][j] {
+					t.Errorf("unexpected errors of action %d: %v, expected %v", i, fields, test.expectedErrors[i])
+				}
+			}
+		}
+	}
+}
+
+// TestValidatePriority tests that only supported priorities are accepted
+func TestValidatePriority(t *testing.T) {
+	// arrange
+	add := &Action{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"}}
+	testCases := map[Priority]bool{"": true, LowPriority: true, UrgentPriority: true, "critical": false}
+
+	for priority, expected := range testCases {
+		// act
+		validation := (&RFC{Actions: Actions{add}, Priority: priority}).Validate()
+
+		// assert
+		if validation.Valid != expected || (!expected && validation.Errors[0].Field != "priority") {
+			t.Errorf("unexpected validation of priority '%s': %+v", priority, validation)
+		}
+	}
+}
+
+// TestValidateDependencies tests that every dependency must name an RFC, once
+func TestValidateDependencies(t *testing.T) {
+	// arrange
+	add := &Action{ActionType: AddAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"}}
+	rfc := &RFC{Actions: Actions{add}, DependsOn: []string{"123", " ", "456", "123"}}
+
+	// act
+	validation := rfc.Validate()
+	err := rfc.CheckDependencyDeclarations()
+
+	// assert
+	if validation.Valid || len(validation.Errors) != 2 || validation.Errors[0].Field != "dependsOn[1]" ||
+		validation.Errors[1].Field != "dependsOn[3]" {
+		t.Errorf("unexpected validation of dependencies: %+v", validation)
+	}
+	if !errors.Is(err, ErrInvalidDependency) || !strings.Contains(err.Error(), "dependsOn[1]") {
+		t.Errorf("expected the first invalid dependency to be reported, got %v", err)
+	}
+}
+
+// TestCheckActions tests that the first action not meeting the requirements of its action type is reported
+func TestCheckActions(t *testing.T) {
+	// arrange
+	rfc := &RFC{Actions: Actions{
+		{ActionType: CommentAction, Target: Target{TargetType: RfcTarget, TargetDescriptor: "RFC"}},
+		{ActionType: RenameAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event",
+			LookupKey: "name", LookupValue: "Played"}, Data: map[string]interface{}{"newName": "Watched"}},
+		{ActionType: DeleteAction, Target: Target{TargetType: ItemTarget, TargetDescriptor: "Event"}},
+	}}
+
+	// act
+	err := rfc.CheckActions()
+
+	// assert
+	if !errors.Is(err, ErrInvalidAction) || !strings.Contains(err.Error(), "actions[2].target.lookupKey") {
+		t.Errorf("expected the delete action without lookup to be invalid, got %v", err)
+	}
+	rfc.Actions = rfc.Actions[:2]
+	if err = rfc.CheckActions(); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+// TestApply tests the effect of the action types changing an existing item
+func TestApply(t *testing.T) {
+	// arrange
+	target := Target{TargetType: ItemTarget, TargetDescriptor: "Event", LookupKey: "name", LookupValue: "Played"}
+	before := map[string]interface{}{"name": "Played", "topic": "events"}
+	testCases := []struct {
+		action   *Action
+		before   map[string]interface{}
+		expected map[string]interface{}
+		known    bool
+	}{
+		{action: &Action{ActionType: DeleteAction, Target: target}, before: before, known: true},
+		{action: &Action{ActionType: RenameAction, Target: target, Data: map[string]interface{}{"newName": "Watched"}},
+			before: before, expected: map[string]interface{}{"name": "Watched", "topic": "events"}, known: true},
+		{action: &Action{ActionType: DeprecateAction, Target: target, Data: map[string]interface{}{"reason": "old"}},
+			before: before, known: true,
+			expected: map[string]interface{}{"name": "Played", "topic": "events", "reason": "old", "deprecated": true}},
+		{action: &Action{ActionType: DeleteAction, Target: target}},
+	}
+
+	for _, test := range testCases {
+		// act
+		after, known := test.action.Apply(test.before)
+
+		// assert
+		if known != test.known || !reflect.DeepEqual(after, test.expected) {
+			t.Errorf("unexpected effect of %s action: %v, %t", test.action.ActionType, after, known)
+		}
+	}
+	if before["name"] != "Played" {
+		t.Errorf("expected the item to be left unchanged, got %v", before)
+	}
+}
@@ -0,0 +1,38 @@
+// this holds the withdrawal of RFCs by their author
+package models
+
+import (
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// ErrNotRFCAuthor is returned (wrapped) when an RFC is withdrawn by someone other than its author
+var ErrNotRFCAuthor = NewError(ErrUnauthorized, NotRFCAuthorCode, "RFC was not submitted by the caller")
+
+// Withdraw records that the RFC was withdrawn by the given withdrawer at the given time, for the given (optional)
+// reason
+func (rfc *RFC) Withdraw(withdrawer string, reason string, withdrawnAt time.Time) error {
+	withdrawal := Action{
+		ActionType: WithdrawnAction,
+		Target: Target{
+			TargetType:  RfcTarget,
+			LookupKey:   SignatureLookupKey,
+			LookupValue: rfc.Signature,
+		},
+		Data: map[string]interface{}{
+			string(WithdrawerData):  withdrawer,
+			string(WithdrawnAtData): withdrawnAt.UTC().Format(time.RFC3339),
+		},
+	}
+	if reason != "" {
+		withdrawal.Data[string(ReasonData)] = reason
+	}
+
+	if err := rfc.AddAction(withdrawal); err != nil {
+		logging.Default.Error("unable to record RFC withdrawal", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package assignment picks the individual reviewer requested on behalf of each team that owns an RFC, so a team's
+// review load is spread across its members rather than every member being pinged for every RFC
+package assignment
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Strategy determines which team member is picked to review an RFC
+type Strategy string
+
+// Supported assignment strategies
+const (
+	// RoundRobin picks each member of a team in turn
+	RoundRobin Strategy = "round-robin"
+	// LeastLoaded picks the member of a team with the fewest open review requests, ties are broken in turn
+	LeastLoaded Strategy = "least-loaded"
+)
+
+// TeamState is a snapshot of the assignments made on behalf of a single team
+type TeamState struct {
+	// LastAssigned is the login most recently picked on behalf of the team
+	LastAssigned string
+	// Assigned counts the reviews assigned on behalf of the team, by login
+	Assigned map[string]int
+}
+
+// Assigner picks reviewers on behalf of teams with a single strategy, tracking assignments per team
+// It is safe for concurrent use
+type Assigner struct {
+	mu       sync.Mutex
+	strategy Strategy
+	teams    map[string]*TeamState
+}
+
+// Default is the assigner shared by the application, nil if reviewers are not assigned
+var Default *Assigner
+
+// NewAssigner returns an Assigner using the given strategy with no assignments made
+func NewAssigner(strategy Strategy) (*Assigner, error) {
+	if strategy != RoundRobin && strategy != LeastLoaded {
+		return nil, fmt.Errorf("unknown reviewer assignment strategy: %s", strategy)
+	}
+
+	return &Assigner{strategy: strategy, teams: map[string]*TeamState{}}, nil
+}
+
+// Strategy returns the strategy the assigner picks reviewers with
+func (a *Assigner) Strategy() Strategy {
+	return a.strategy
+}
+
+// Assign picks a reviewer among the given candidates on behalf of the given team and records the assignment
+// The given load holds the number of open review requests of each candidate and is only used by LeastLoaded
+// False is returned if there are no candidates
+func (a *Assigner) Assign(team string, candidates []string, load map[string]int) (string, bool) {
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.teams[team]
+	if !ok {
+		state = &TeamState{Assigned: map[string]int{}}
+		a.teams[team] = state
+	}
+
+	// candidates are taken in turn starting after the last assignee, comparing logins rather than positions so the
+	// rotation survives members joining or leaving the team
+	ordered := append([]string{}, candidates...)
+	sort.Strings(ordered)
+	start := sort.SearchStrings(ordered, state.LastAssigned)
+	if start < len(ordered) && ordered[start] == state.LastAssigned {
+		start++
+	}
+
+	picked := ordered[start%len(ordered)]
+	if a.strategy == LeastLoaded {
+		for i := 1; i < len(ordered); i++ {
+			candidate := ordered[(start+i)%len(ordered)]
+			if load[candidate] < load[picked] {
+				picked = candidate
+			}
+		}
+	}
+
+	state.LastAssigned = picked
+	state.Assigned[picked]++
+
+	return picked, true
+}
+
+// Teams returns a snapshot of the assignments made on behalf of each team
+func (a *Assigner) Teams() map[string]TeamState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	teams := make(map[string]TeamState, len(a.teams))
+	for team, state := range a.teams {
+		assigned := make(map[string]int, len(state.Assigned))
+		for login, count := range state.Assigned {
+			assigned[login] = count
+		}
+		teams[team] = TeamState{LastAssigned: state.LastAssigned, Assigned: assigned}
+	}
+
+	return teams
+}
@@ -0,0 +1,69 @@
+package assignment
+
+import (
+	"testing"
+)
+
+func TestNewAssigner(t *testing.T) {
+	if _, err := NewAssigner("random"); err == nil {
+		t.Errorf("expected an error for an unknown strategy")
+	}
+	if a, err := NewAssigner(LeastLoaded); err != nil || a.Strategy() != LeastLoaded {
+		t.Errorf("unexpected assigner. err: %v", err)
+	}
+}
+
+func TestAssignRoundRobin(t *testing.T) {
+	// arrange
+	a, _ := NewAssigner(RoundRobin)
+	members := []string{"carol", "alice", "bob"}
+
+	// act
+	var picked []string
+	for i := 0; i < 4; i++ {
+		login, _ := a.Assign("team-a", members, nil)
+		picked = append(picked, login)
+	}
+	other, _ := a.Assign("team-b", members, nil)
+	// the last assignee leaving the team does not restart the rotation
+	afterLeave, _ := a.Assign("team-a", []string{"bob", "carol", "dave"}, nil)
+	_, ok := a.Assign("team-a", nil, nil)
+
+	// assert
+	expected := []string{"alice", "bob", "carol", "alice"}
+	for i := range expected {
+		if picked[i] != expected[i] {
+			t.Errorf("unexpected rotation. wanted %v, got %v", expected, picked)
+			break
+		}
+	}
+	if other != "alice" {
+		t.Errorf("teams do not rotate independently. wanted %v, got %v", "alice", other)
+	}
+	if afterLeave != "bob" {
+		t.Errorf("unexpected assignee after membership change. wanted %v, got %v", "bob", afterLeave)
+	}
+	if ok {
+		t.Errorf("expected no assignment without candidates")
+	}
+	state := a.Teams()["team-a"]
+	if state.LastAssigned != "bob" || state.Assigned["alice"] != 2 || state.Assigned["bob"] != 2 {
+		t.Errorf("unexpected team state: %+v", state)
+	}
+}
+
+func TestAssignLeastLoaded(t *testing.T) {
+	// arrange
+	a, _ := NewAssigner(LeastLoaded)
+	members := []string{"alice", "bob", "carol"}
+
+	// act
+	first, _ := a.Assign("team-a", members, map[string]int{"alice": 2, "bob": 1, "carol": 1})
+	// ties are broken in turn, after the last assignee
+	second, _ := a.Assign("team-a", members, map[string]int{"alice": 2, "bob": 1, "carol": 1})
+
+	// assert
+	if first != "bob" || second != "carol" {
+		t.Errorf("unexpected assignees. wanted [bob carol], got [%v %v]", first, second)
+	}
+}
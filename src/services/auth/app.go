@@ -0,0 +1,176 @@
+// Package auth holds the authentication of the machine identity of Harmonia as a GitHub App installation, whose
+// installation tokens expire within the hour rather than living as long as a personal access token
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+const (
+	// GITHUB_API_URL is the URL of the GitHub API installation tokens are requested from
+	GITHUB_API_URL = "https://api.github.com"
+	// REFRESH_MARGIN is how long before it expires an installation token is replaced, so no request is made with a
+	// token expiring while it is in flight
+	REFRESH_MARGIN = 5 * time.Minute
+	// jwtTTL is how long the JSON web tokens authenticating as the app are valid for, GitHub rejects more than 10
+	// minutes
+	jwtTTL = 9 * time.Minute
+	// clockSkew is how far in the past JSON web tokens are issued, to allow for clocks drifting from those of GitHub
+	clockSkew   = time.Minute
+	authTimeout = 10 * time.Second
+)
+
+// ErrMalformedKey is returned (wrapped) when the private key of an app is not a PEM encoded RSA private key
+var ErrMalformedKey = errors.New("malformed GitHub App private key, expected a PEM encoded RSA private key")
+
+// App authenticates as an installation of a GitHub App, its installation token is cached and refreshed shortly
+// before it expires
+type App struct {
+	URL            string
+	id             string
+	installationID string
+	key            *rsa.PrivateKey
+	client         *http.Client
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// installationToken is the GitHub response to an installation token request
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewApp returns the installation of the given ID of the GitHub App of the given ID, authenticating with the given PEM
+// encoded private key of the app (PKCS #1 as generated by GitHub, or PKCS #8)
+func NewApp(id string, installationID string, privateKey []byte) (*App, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, ErrMalformedKey
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if pkcs8Err != nil || !ok {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedKey, err)
+		}
+		key = rsaKey
+	}
+
+	return &App{
+		URL:            GITHUB_API_URL,
+		id:             id,
+		installationID: installationID,
+		key:            key,
+		client:         &http.Client{Timeout: authTimeout},
+		now:            time.Now,
+	}, nil
+}
+
+// Default is the GitHub App the machine identity authenticates as, nil if the machine identity uses a static token
+var Default *App
+
+// Token returns an installation token of the app, the cached one unless it expires within REFRESH_MARGIN
+func (a *App) Token(ctx context.Context) (*string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Add(REFRESH_MARGIN).Before(a.expiresAt) {
+		token := a.token
+		return &token, nil
+	}
+
+	issued, err := a.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.token, a.expiresAt = issued.Token, issued.ExpiresAt
+
+	token := a.token
+	return &token, nil
+}
+
+// requestToken exchanges a JSON web token of the app for a new installation token
+func (a *App) requestToken(ctx context.Context) (*installationToken, error) {
+	jwt, err := a.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/app/installations/%s/access_tokens", strings.TrimSuffix(a.URL, "/"), a.installationID), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("Authorization", "Bearer "+jwt)
+
+	response, err := a.client.Do(request)
+	if err != nil {
+		logging.FromContext(ctx).Error("GitHub App installation token request error", "app", a.id, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("GitHub responded to the installation token request of app %s with status %d", a.id,
+			response.StatusCode)
+	}
+
+	var issued installationToken
+	if err = json.NewDecoder(response.Body).Decode(&issued); err != nil {
+		logging.FromContext(ctx).Error("json installation token response unmarshal error", "app", a.id,
+			logging.ERROR_KEY, err)
+		return nil, err
+	}
+	if issued.Token == "" {
+		return nil, fmt.Errorf("GitHub issued no installation token for app %s", a.id)
+	}
+
+	return &issued, nil
+}
+
+// jwt returns a JSON web token authenticating as the app, signed with RS256 as GitHub requires
+func (a *App) jwt() (string, error) {
+	now := a.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-clockSkew).Unix(),
+		"exp": now.Add(jwtTTL).Unix(),
+		"iss": a.id,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
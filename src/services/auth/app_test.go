@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToken(t *testing.T) {
+	// arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1654074000, 0)
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/42/access_tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// the JSON web token must be issued by the app and signed with its key
+		parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[len(parts)-1])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var decoded map[string]interface{}
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil ||
+			json.Unmarshal(claims, &decoded) != nil || decoded["iss"] != "7" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issued++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": "%s"}`, issued, now.Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+	app, err := NewApp("7", "42", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	app.URL = server.URL
+	app.now = func() time.Time { return now }
+
+	// act
+	first, firstErr := app.Token(context.Background())
+	cached, _ := app.Token(context.Background())
+	now = now.Add(time.Hour - REFRESH_MARGIN)
+	refreshed, _ := app.Token(context.Background())
+
+	// assert
+	if firstErr != nil || *first != "ghs_1" {
+		t.Fatalf("unexpected token: %v, err: %v", first, firstErr)
+	}
+	if *cached != "ghs_1" {
+		t.Errorf("expected the token to be cached, got %s", *cached)
+	}
+	if *refreshed != "ghs_2" {
+		t.Errorf("expected the token to be refreshed before it expires, got %s", *refreshed)
+	}
+}
+
+func TestNewApp(t *testing.T) {
+	// arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	_, pkcs8Err := NewApp("7", "42", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	_, malformedErr := NewApp("7", "42", []byte("not a key"))
+
+	// assert
+	if pkcs8Err != nil {
+		t.Errorf("expected PKCS #8 keys to be accepted: %v", pkcs8Err)
+	}
+	if !errors.Is(malformedErr, ErrMalformedKey) {
+		t.Errorf("expected a malformed key error, got %v", malformedErr)
+	}
+}
@@ -0,0 +1,97 @@
+// Package authz holds the authorization policy, which grants the members of Git teams the permissions routes require
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// Policy grants permissions to every user and to the members of teams, a user is granted the permissions of all their
+// teams on top of those granted to everyone
+type Policy struct {
+	// Everyone are the permissions granted to every user, whatever their teams
+	Everyone []models.Permission `json:"everyone,omitempty"`
+	// Teams are the permissions granted to the members of each team, keyed by team slug
+	Teams map[string][]models.Permission `json:"teams,omitempty"`
+}
+
+// Default is the authorization policy of the application, nil if every user is granted every permission
+var Default *Policy
+
+// NewPolicy returns the given policy once its permissions are validated
+func NewPolicy(policy Policy) (*Policy, error) {
+	for _, permission := range policy.Everyone {
+		if !models.IsPermission(permission) {
+			return nil, fmt.Errorf("unknown permission %s granted to everyone", permission)
+		}
+	}
+	for team, permissions := range policy.Teams {
+		for _, permission := range permissions {
+			if !models.IsPermission(permission) {
+				return nil, fmt.Errorf("unknown permission %s granted to team %s", permission, team)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// Load returns the policy of the given JSON file, a Policy object
+func Load(file string) (*Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read authorization policy file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var policy Policy
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("malformed authorization policy file %s: %w", file, err)
+	}
+
+	return NewPolicy(policy)
+}
+
+// Granted returns the permissions granted to a member of the given teams
+func (p *Policy) Granted(teams set.Set[string]) set.Set[models.Permission] {
+	granted := set.NewSetOf(p.Everyone...)
+	for team, permissions := range p.Teams {
+		if teams.Contains(team) {
+			granted.Add(permissions...)
+		}
+	}
+
+	return granted
+}
+
+// Authorize returns ErrNotPermitted (wrapped) unless a member of the given teams is granted the given permission
+func (p *Policy) Authorize(teams set.Set[string], permission models.Permission) error {
+	if p.Granted(teams).Contains(permission) {
+		return nil
+	}
+
+	// name the teams granting the permission so the user knows whom to ask
+	var granting []string
+	for team, permissions := range p.Teams {
+		for _, granted := range permissions {
+			if granted == permission {
+				granting = append(granting, team)
+				break
+			}
+		}
+	}
+	if len(granting) == 0 {
+		return fmt.Errorf("%w: %s is granted to no team", models.ErrNotPermitted, permission)
+	}
+	sort.Strings(granting)
+
+	return fmt.Errorf("%w: %s requires membership of one of the teams %s", models.ErrNotPermitted, permission,
+		strings.Join(granting, ", "))
+}
@@ -0,0 +1,76 @@
+package authz
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/set"
+)
+
+// TestAuthorize tests that users are granted the permissions of everyone and of their teams only
+func TestAuthorize(t *testing.T) {
+	// arrange
+	policy, err := NewPolicy(Policy{
+		Everyone: []models.Permission{models.SubmitPermission},
+		Teams: map[string][]models.Permission{
+			"schema-admins": {models.ApprovePermission, models.MergePermission},
+			"reviewers":     {models.ReviewPermission, models.ApprovePermission},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reviewer := set.NewSetOf("reviewers", "unlisted")
+
+	// act
+	submitErr := policy.Authorize(set.NewSet[string](), models.SubmitPermission)
+	approveErr := policy.Authorize(reviewer, models.ApprovePermission)
+	mergeErr := policy.Authorize(reviewer, models.MergePermission)
+	loadErr := policy.Authorize(reviewer, models.LoadPermission)
+
+	// assert
+	if submitErr != nil || approveErr != nil {
+		t.Errorf("expected granted permissions to be authorized: %v, %v", submitErr, approveErr)
+	}
+	if !errors.Is(mergeErr, models.ErrNotPermitted) || !strings.Contains(mergeErr.Error(), "schema-admins") {
+		t.Errorf("expected merging to require the teams granting it, got %v", mergeErr)
+	}
+	if !errors.Is(loadErr, models.ErrNotPermitted) {
+		t.Errorf("expected loading to be granted to no one, got %v", loadErr)
+	}
+	if granted := policy.Granted(reviewer); granted.Size() != 3 {
+		t.Errorf("unexpected granted permissions: %v", granted)
+	}
+}
+
+// TestLoad tests that policies are loaded from JSON files and unknown permissions are rejected
+func TestLoad(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	content := `{"everyone": ["review"], "teams": {"admins": ["merge", "admin"]}}`
+	if err := os.WriteFile(valid, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte(`{"teams": {"admins": ["deploy"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	policy, err := Load(valid)
+	_, invalidErr := Load(invalid)
+
+	// assert
+	if err != nil || policy.Authorize(set.NewSetOf("admins"), models.MergePermission) != nil ||
+		policy.Authorize(set.NewSetOf("admins"), models.AdminPermission) != nil {
+		t.Errorf("unexpected policy: %+v, err: %v", policy, err)
+	}
+	if invalidErr == nil {
+		t.Errorf("expected an unknown permission to be rejected")
+	}
+}
@@ -0,0 +1,205 @@
+// Package cache holds a simple concurrency safe in-memory cache with time based expiry
+package cache
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Common constants used by all caches
+const (
+	// number of entries at which a cache sweeps expired entries on write
+	SWEEP_THRESHOLD int = 1024
+)
+
+type entry[V any] struct {
+	val     V
+	expires time.Time
+}
+
+// Cache is a key/value store whose entries expire after a fixed time to live
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[K]entry[V]
+	now     func() time.Time
+
+	// usage counters, reported through Stats
+	name      string
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// Stats is a snapshot of the usage of a cache
+type Stats struct {
+	Name   string
+	Hits   uint64
+	Misses uint64
+	// Evictions counts entries removed because they expired, explicit deletes are not evictions
+	Evictions uint64
+	// Size is the number of entries, including expired entries that have not been swept yet
+	Size int
+}
+
+// statser is implemented by every Cache regardless of its type parameters, so named caches can be listed together
+type statser interface {
+	Stats() Stats
+}
+
+// named holds the caches created with NewNamed, keyed by name
+var named = map[string]statser{}
+var namedMu sync.Mutex
+
+// New creates an empty cache whose entries expire after the given time to live
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:     ttl,
+		entries: make(map[K]entry[V]),
+		now:     time.Now,
+	}
+}
+
+// NewNamed creates an empty cache like New and registers it under the given name, so its usage is reported by
+// AllStats. A cache registered under an existing name replaces it
+func NewNamed[K comparable, V any](name string, ttl time.Duration) *Cache[K, V] {
+	c := New[K, V](ttl)
+	c.name = name
+
+	namedMu.Lock()
+	defer namedMu.Unlock()
+	named[name] = c
+
+	return c
+}
+
+// AllStats returns the usage of every cache created with NewNamed, sorted by name
+func AllStats() []Stats {
+	namedMu.Lock()
+	caches := make([]statser, 0, len(named))
+	for _, c := range named {
+		caches = append(caches, c)
+	}
+	namedMu.Unlock()
+
+	stats := make([]Stats, 0, len(caches))
+	for _, c := range caches {
+		stats = append(stats, c.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+
+	return stats
+}
+
+// Stats returns a snapshot of the usage of the cache
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{Name: c.name, Hits: c.hits, Misses: c.misses, Evictions: c.evictions, Size: len(c.entries)}
+}
+
+// Get returns the value stored for the given key and true, or the zero value and false if the key is absent or
+// expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		c.evictions++
+		c.misses++
+		var zero V
+		return zero, false
+	}
+
+	c.hits++
+	return e.val, true
+}
+
+// Set stores the given value for the given key, replacing any existing value and resetting its expiry
+func (c *Cache[K, V]) Set(key K, val V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= SWEEP_THRESHOLD {
+		c.sweep()
+	}
+	c.entries[key] = entry[V]{val: val, expires: c.now().Add(c.ttl)}
+}
+
+// SetIfAbsent stores the given value for the given key only if the key is absent or expired, returning whether it was
+// stored. The check and the write are atomic, so concurrent callers cannot both store the same key
+func (c *Cache[K, V]) SetIfAbsent(key K, val V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		if c.now().Before(e.expires) {
+			return false
+		}
+		c.evictions++
+	}
+	if len(c.entries) >= SWEEP_THRESHOLD {
+		c.sweep()
+	}
+	c.entries[key] = entry[V]{val: val, expires: c.now().Add(c.ttl)}
+
+	return true
+}
+
+// Delete removes the given keys from the cache
+func (c *Cache[K, V]) Delete(keys ...K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// DeleteMatching removes the entries whose key satisfies the given predicate from the cache
+func (c *Cache[K, V]) DeleteMatching(match func(key K) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if match(key) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Clear removes all entries from the cache
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[K]entry[V])
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been swept yet
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// sweep removes all expired entries, the caller must hold the lock
+func (c *Cache[K, V]) sweep() {
+	now := c.now()
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+			c.evictions++
+		}
+	}
+}
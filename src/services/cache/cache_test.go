@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestCache returns a cache with a controllable clock
+func newTestCache(ttl time.Duration) (*Cache[string, int], *time.Time) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](ttl)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCacheGetSet(t *testing.T) {
+	// arrange
+	c, _ := newTestCache(time.Minute)
+
+	// act
+	c.Set("a", 1)
+	actual, ok := c.Get("a")
+	_, missing := c.Get("b")
+
+	// assert
+	if !ok || actual != 1 {
+		t.Errorf("unexpected value. wanted %v, got %v (present: %v)", 1, actual, ok)
+	}
+	if missing {
+		t.Errorf("unexpected value present for missing key")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	// arrange
+	c, now := newTestCache(time.Minute)
+	c.Set("a", 1)
+
+	// act
+	*now = now.Add(59 * time.Second)
+	_, beforeExpiry := c.Get("a")
+	*now = now.Add(time.Second)
+	_, atExpiry := c.Get("a")
+
+	// assert
+	if !beforeExpiry {
+		t.Errorf("entry expired early")
+	}
+	if atExpiry {
+		t.Errorf("entry did not expire")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expired entry was not removed. wanted length %v, got %v", 0, c.Len())
+	}
+}
+
+func TestCacheSetIfAbsent(t *testing.T) {
+	// arrange
+	c, now := newTestCache(time.Minute)
+
+	// act
+	first := c.SetIfAbsent("a", 1)
+	second := c.SetIfAbsent("a", 2)
+	*now = now.Add(time.Minute)
+	afterExpiry := c.SetIfAbsent("a", 3)
+	actual, _ := c.Get("a")
+
+	// assert
+	if !first || second {
+		t.Errorf("unexpected stores. wanted first: true, second: false, got first: %v, second: %v", first, second)
+	}
+	if !afterExpiry || actual != 3 {
+		t.Errorf("expired entry was not replaced. wanted %v, got %v (stored: %v)", 3, actual, afterExpiry)
+	}
+}
+
+func TestCacheDeleteClear(t *testing.T) {
+	// arrange
+	c, _ := newTestCache(time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	// act
+	c.Delete("a", "b")
+
+	// assert
+	if c.Len() != 1 {
+		t.Errorf("unexpected length. wanted %v, got %v", 1, c.Len())
+	}
+
+	// act
+	c.Clear()
+
+	// assert
+	if c.Len() != 0 {
+		t.Errorf("unexpected length. wanted %v, got %v", 0, c.Len())
+	}
+}
+
+func TestCacheDeleteMatching(t *testing.T) {
+	// arrange
+	c, _ := newTestCache(time.Minute)
+	c.Set("123@1", 1)
+	c.Set("123@2", 2)
+	c.Set("456@1", 3)
+
+	// act
+	c.DeleteMatching(func(key string) bool { return strings.HasPrefix(key, "123@") })
+
+	// assert
+	if _, ok := c.Get("456@1"); !ok || c.Len() != 1 {
+		t.Errorf("expected only the entry not matching to remain, got %v entries", c.Len())
+	}
+}
+
+func TestCacheSweep(t *testing.T) {
+	// arrange
+	c, now := newTestCache(time.Minute)
+	for i := 0; i < SWEEP_THRESHOLD; i++ {
+		c.Set(string(rune(i)), i)
+	}
+	*now = now.Add(time.Hour)
+
+	// act
+	c.Set("fresh", 1)
+
+	// assert
+	if c.Len() != 1 {
+		t.Errorf("expired entries were not swept. wanted length %v, got %v", 1, c.Len())
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	// arrange
+	c, now := newTestCache(time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// act
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+	*now = now.Add(time.Minute)
+	c.Get("b")
+
+	// assert
+	expected := Stats{Hits: 2, Misses: 2, Evictions: 1, Size: 1}
+	if actual := c.Stats(); actual != expected {
+		t.Errorf("unexpected stats. wanted %+v, got %+v", expected, actual)
+	}
+}
+
+func TestAllStats(t *testing.T) {
+	// arrange
+	NewNamed[string, int]("test-b", time.Minute)
+	first := NewNamed[string, int]("test-a", time.Minute)
+	first.Set("a", 1)
+	NewNamed[string, int]("test-a", time.Minute).Set("b", 2)
+
+	// act
+	var names []string
+	var replaced Stats
+	for _, stats := range AllStats() {
+		names = append(names, stats.Name)
+		if stats.Name == "test-a" {
+			replaced = stats
+		}
+	}
+
+	// assert
+	if len(names) < 2 || names[0] != "test-a" || names[1] != "test-b" {
+		t.Errorf("unexpected caches. wanted sorted names starting with [test-a test-b], got %v", names)
+	}
+	if replaced.Size != 1 {
+		t.Errorf("cache registered under an existing name did not replace it. wanted size 1, got %d", replaced.Size)
+	}
+}
@@ -0,0 +1,466 @@
+// Package codegen renders the JSON Schemas of a tracking repository, the schemas the data of RFC actions is validated
+// against (see package schemas), into typed models downstream services can build against: Go structs and TypeScript
+// interfaces. Each schema file is rendered as a type named after it, e.g. "add.item.json" as AddItem, and each of its
+// definitions as a type prefixed with it, e.g. the "id" definition of "common.json" as CommonID
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Common constants used when rendering schemas
+const (
+	GO_FILE_NAME         string = "schemas.go"
+	TYPESCRIPT_FILE_NAME string = "schemas.ts"
+	DEFAULT_GO_PACKAGE   string = "schemas"
+	// the extension of schema files, left out of the names of the types they are rendered as
+	SCHEMA_FILE_EXTENSION string = ".json"
+	// the header generated files start with, so they are recognized as generated by tools and reviewers alike
+	header string = "// Code generated by Harmonia from the action data schemas. DO NOT EDIT.\n"
+)
+
+// Repository is the name of the repository generated types are committed to, empty if types are not generated
+var Repository string
+
+// Directory is the directory of Repository generated types are committed to, the root directory if empty
+var Directory string
+
+// GoPackage is the package of the generated Go types
+var GoPackage = DEFAULT_GO_PACKAGE
+
+// kinds of the shapes of schemas
+const (
+	stringKind  string = "string"
+	integerKind string = "integer"
+	numberKind  string = "number"
+	booleanKind string = "boolean"
+	anyKind     string = "any"
+	mapKind     string = "map"
+	arrayKind   string = "array"
+	namedKind   string = "named"
+)
+
+// shape is the type of values a schema accepts, as far as Go and TypeScript types can tell
+type shape struct {
+	kind string
+	// elem is the shape of the elements of arrays and of the values of maps
+	elem *shape
+	// name is the name of the type of named shapes
+	name string
+	// enum holds the values the schema is restricted to, if any
+	enum []interface{}
+}
+
+// property is a property of an object schema
+type property struct {
+	name        string
+	shape       *shape
+	required    bool
+	description string
+}
+
+// definition is a type rendered from a schema: a struct of its properties if it is an object schema, an alias of its
+// shape otherwise
+type definition struct {
+	name        string
+	description string
+	properties  []property
+	alias       *shape
+}
+
+// generator collects the definitions rendered from a set of schema files
+type generator struct {
+	// roots holds the name of the type each schema file is rendered as, keyed by file name
+	roots       map[string]string
+	definitions map[string]*definition
+}
+
+// Render returns the Go and TypeScript files rendering the given schema files, keyed by file name, the Go file being
+// part of the given package. Schema files that cannot be decoded are left out, and the errors they failed with are
+// returned along with the rendered files, keyed by file name. So is the error the Go file failed to be formatted with,
+// e.g. if the package name is not valid, the Go file being left out
+func Render(files map[string]string, goPackage string) (map[string]string, map[string]error) {
+	failures := map[string]error{}
+	schemas := map[string]map[string]interface{}{}
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		schema := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(content), &schema); err != nil {
+			failures[name] = err
+			continue
+		}
+		schemas[name] = schema
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	g := &generator{roots: map[string]string{}, definitions: map[string]*definition{}}
+	for _, name := range names {
+		g.roots[name] = typeName(strings.TrimSuffix(name, SCHEMA_FILE_EXTENSION))
+	}
+	for _, name := range names {
+		schema := schemas[name]
+		for _, keyword := range []string{"$defs", "definitions"} {
+			defs, _ := schema[keyword].(map[string]interface{})
+			for _, defName := range sortedKeys(defs) {
+				if def, ok := defs[defName].(map[string]interface{}); ok {
+					g.define(name, g.roots[name]+typeName(defName), def)
+				}
+			}
+		}
+		// files only holding definitions, e.g. ones referenced by other schemas, are not types of their own
+		if schema["type"] != nil || schema["properties"] != nil || schema["$ref"] != nil {
+			g.define(name, g.roots[name], schema)
+		}
+	}
+
+	goFile, err := g.renderGo(goPackage)
+	if err != nil {
+		failures[GO_FILE_NAME] = err
+	}
+	rendered := map[string]string{TYPESCRIPT_FILE_NAME: g.renderTypeScript()}
+	if goFile != "" {
+		rendered[GO_FILE_NAME] = goFile
+	}
+
+	return rendered, failures
+}
+
+// define adds the definition of the given schema of the given file under the given name, named after the schema it is
+// nested in if the name is taken. The name of the definition is returned
+func (g *generator) define(file string, name string, schema map[string]interface{}) string {
+	unique := name
+	for i := 2; g.definitions[unique] != nil; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	def := &definition{name: unique, description: description(schema)}
+	g.definitions[unique] = def
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	if properties == nil {
+		def.alias = g.shapeOf(file, unique, schema)
+		return unique
+	}
+	required := map[string]bool{}
+	if names, ok := schema["required"].([]interface{}); ok {
+		for _, name := range names {
+			if name, ok := name.(string); ok {
+				required[name] = true
+			}
+		}
+	}
+	for _, propertyName := range sortedKeys(properties) {
+		propertySchema, _ := properties[propertyName].(map[string]interface{})
+		def.properties = append(def.properties, property{
+			name:        propertyName,
+			shape:       g.shapeOf(file, unique+typeName(propertyName), propertySchema),
+			required:    required[propertyName],
+			description: description(propertySchema),
+		})
+	}
+	return unique
+}
+
+// shapeOf returns the shape of the given schema of the given file. Object schemas with properties nested in it are
+// defined under the given name
+func (g *generator) shapeOf(file string, name string, schema map[string]interface{}) *shape {
+	if schema == nil {
+		return &shape{kind: anyKind}
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		return g.resolve(file, ref)
+	}
+
+	kind, _ := schema["type"].(string)
+	if types, ok := schema["type"].([]interface{}); ok {
+		// nullable types are rendered as their other type
+		var kinds []string
+		for _, t := range types {
+			if t, ok := t.(string); ok && t != "null" {
+				kinds = append(kinds, t)
+			}
+		}
+		if len(kinds) == 1 {
+			kind = kinds[0]
+		}
+	}
+	_, hasProperties := schema["properties"].(map[string]interface{})
+	enum, _ := schema["enum"].([]interface{})
+	if constant, ok := schema["const"]; ok {
+		enum = []interface{}{constant}
+	}
+	if kind == "" && hasProperties {
+		kind = "object"
+	} else if kind == "" && schema["items"] != nil {
+		kind = arrayKind
+	} else if kind == "" && len(enum) > 0 {
+		if _, ok := enum[0].(string); ok {
+			kind = stringKind
+		}
+	}
+
+	switch kind {
+	case stringKind, integerKind, numberKind, booleanKind:
+		return &shape{kind: kind, enum: enum}
+	case arrayKind:
+		items, _ := schema["items"].(map[string]interface{})
+		return &shape{kind: arrayKind, elem: g.shapeOf(file, name+"Item", items)}
+	case "object":
+		if hasProperties {
+			return &shape{kind: namedKind, name: g.define(file, name, schema)}
+		}
+		values, _ := schema["additionalProperties"].(map[string]interface{})
+		return &shape{kind: mapKind, elem: g.shapeOf(file, name+"Value", values)}
+	}
+	return &shape{kind: anyKind}
+}
+
+// resolve returns the shape of the given reference made from the given file: the type of the referenced file, or of
+// one of its definitions. References to other parts of schemas are rendered as any value
+func (g *generator) resolve(file string, ref string) *shape {
+	target, pointer, _ := strings.Cut(ref, "#")
+	if target == "" {
+		target = file
+	}
+	root, ok := g.roots[target]
+	if !ok {
+		return &shape{kind: anyKind}
+	}
+	if pointer == "" || pointer == "/" {
+		return &shape{kind: namedKind, name: root}
+	}
+	for _, keyword := range []string{"/$defs/", "/definitions/"} {
+		if defName, ok := strings.CutPrefix(pointer, keyword); ok && !strings.Contains(defName, "/") {
+			return &shape{kind: namedKind, name: root + typeName(defName)}
+		}
+	}
+	return &shape{kind: anyKind}
+}
+
+// renderGo returns the Go file declaring the definitions, in the given package, formatted
+func (g *generator) renderGo(goPackage string) (string, error) {
+	var b strings.Builder
+	b.WriteString(header)
+	fmt.Fprintf(&b, "\npackage %s\n", goPackage)
+	for _, name := range sortedKeys(g.definitions) {
+		def := g.definitions[name]
+		b.WriteString("\n")
+		writeComment(&b, "// ", def.name, def.description)
+		if def.alias != nil {
+			fmt.Fprintf(&b, "type %s %s\n", def.name, goType(def.alias))
+			continue
+		}
+		fmt.Fprintf(&b, "type %s struct {\n", def.name)
+		fields := map[string]bool{}
+		for _, p := range def.properties {
+			// properties spelled apart, e.g. "user_id" and "userId", may be named alike
+			field := typeName(p.name)
+			for i := 2; fields[field]; i++ {
+				field = fmt.Sprintf("%s%d", typeName(p.name), i)
+			}
+			fields[field] = true
+			writeComment(&b, "\t// ", field, p.description)
+			fieldType, tag := goType(p.shape), p.name
+			if !p.required {
+				tag += ",omitempty"
+				// optional structs are pointers so they are left out when unset
+				if p.shape.kind == namedKind && g.definitions[p.shape.name] != nil &&
+					g.definitions[p.shape.name].alias == nil {
+					fieldType = "*" + fieldType
+				}
+			}
+			fmt.Fprintf(&b, "\t%s %s %s\n", field, fieldType, goTag(fmt.Sprintf("json:%q", tag)))
+		}
+		b.WriteString("}\n")
+	}
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(formatted), nil
+}
+
+// renderTypeScript returns the TypeScript file exporting the definitions
+func (g *generator) renderTypeScript() string {
+	var b strings.Builder
+	b.WriteString(header)
+	for _, name := range sortedKeys(g.definitions) {
+		def := g.definitions[name]
+		b.WriteString("\n")
+		writeDocComment(&b, "", def.description)
+		if def.alias != nil {
+			fmt.Fprintf(&b, "export type %s = %s;\n", def.name, typeScriptType(def.alias))
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", def.name)
+		for _, p := range def.properties {
+			writeDocComment(&b, "  ", p.description)
+			optional := "?"
+			if p.required {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", propertyName(p.name), optional, typeScriptType(p.shape))
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// goType returns the Go type of the given shape, enums being typed as their values
+func goType(s *shape) string {
+	switch s.kind {
+	case stringKind:
+		return "string"
+	case integerKind:
+		return "int64"
+	case numberKind:
+		return "float64"
+	case booleanKind:
+		return "bool"
+	case mapKind:
+		return "map[string]" + goType(s.elem)
+	case arrayKind:
+		return "[]" + goType(s.elem)
+	case namedKind:
+		return s.name
+	}
+	return "interface{}"
+}
+
+// goTag returns the given struct tag as a Go string literal, a raw one unless the tag holds a backquote
+func goTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// typeScriptType returns the TypeScript type of the given shape, enums being typed as the union of their values
+func typeScriptType(s *shape) string {
+	if len(s.enum) > 0 {
+		literals := make([]string, 0, len(s.enum))
+		for _, value := range s.enum {
+			literal, _ := json.Marshal(value)
+			literals = append(literals, string(literal))
+		}
+		return strings.Join(literals, " | ")
+	}
+
+	switch s.kind {
+	case stringKind:
+		return "string"
+	case integerKind, numberKind:
+		return "number"
+	case booleanKind:
+		return "boolean"
+	case mapKind:
+		return "Record<string, " + typeScriptType(s.elem) + ">"
+	case arrayKind:
+		elem := typeScriptType(s.elem)
+		if len(s.elem.enum) > 1 {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case namedKind:
+		return s.name
+	}
+	return "unknown"
+}
+
+// initialisms are the words Go names spell in upper case
+var initialisms = map[string]bool{"api": true, "html": true, "http": true, "id": true, "json": true, "sql": true,
+	"uri": true, "url": true, "uuid": true}
+
+// typeName returns the exported Go and TypeScript name of the given schema file or definition name, e.g. "add.item" as
+// AddItem, or "user_id" as UserID. Names are split into words on any character other than letters and digits
+func typeName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var b strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		b.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	if b.Len() == 0 || unicode.IsDigit([]rune(b.String())[0]) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// propertyName returns the given property name as a TypeScript property name, quoted unless it is an identifier
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && unicode.IsDigit(r))) {
+			quoted, _ := json.Marshal(name)
+			return string(quoted)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+// description returns the description of the given schema, its title if it has none
+func description(schema map[string]interface{}) string {
+	if text, ok := schema["description"].(string); ok && text != "" {
+		return text
+	}
+	text, _ := schema["title"].(string)
+	return text
+}
+
+// writeComment writes the given description of the given Go declaration as a comment with the given prefix
+func writeComment(b *strings.Builder, prefix string, name string, text string) {
+	if text == "" {
+		return
+	}
+	for i, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if i == 0 {
+			line = name + " is " + lowerFirst(line)
+		}
+		b.WriteString(strings.TrimRight(prefix+line, " ") + "\n")
+	}
+}
+
+// writeDocComment writes the given description as a TSDoc comment indented by the given indent
+func writeDocComment(b *strings.Builder, indent string, text string) {
+	if text == "" {
+		return
+	}
+	b.WriteString(indent + "/**\n")
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		// the comment must not be closed early
+		b.WriteString(strings.TrimRight(indent+" * "+strings.ReplaceAll(line, "*/", "*\\/"), " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+}
+
+// lowerFirst returns the given text with its first letter in lower case, unless it starts with an acronym
+func lowerFirst(text string) string {
+	runes := []rune(text)
+	if len(runes) > 1 && unicode.IsUpper(runes[1]) {
+		return text
+	}
+	return strings.ToLower(string(runes[:1])) + string(runes[1:])
+}
+
+// sortedKeys returns the keys of the given map, sorted
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
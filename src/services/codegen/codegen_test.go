@@ -0,0 +1,77 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRender tests that schema files are rendered as Go structs and TypeScript interfaces named after them, their
+// nested objects and definitions as types of their own, and that files that cannot be decoded are reported
+func TestRender(t *testing.T) {
+	// arrange
+	files := map[string]string{
+		"add.item.json": `{"type": "object", "description": "Data of items added", "required": ["id", "kind"],
+			"properties": {"id": {"$ref": "common.json#/$defs/id"}, "kind": {"enum": ["event", "entity"]},
+			"tags": {"type": "array", "items": {"type": "string"}}, "weight": {"type": ["number", "null"]},
+			"owner": {"type": "object", "properties": {"team": {"type": "string"}}},
+			"attributes": {"type": "object", "additionalProperties": {"type": "integer"}}}}`,
+		"common.json": `{"$defs": {"id": {"type": "string", "description": "Identifier of the item"}}}`,
+		"broken.json": `{`,
+	}
+
+	// act
+	rendered, failures := Render(files, "catalog")
+
+	// assert
+	if _, ok := failures["broken.json"]; !ok || len(failures) != 1 {
+		t.Errorf("expected the file that cannot be decoded to fail, got %v", failures)
+	}
+	goFile := rendered[GO_FILE_NAME]
+	for _, expected := range []string{
+		"// Code generated by Harmonia from the action data schemas. DO NOT EDIT.",
+		"package catalog",
+		"// AddItem is data of items added\ntype AddItem struct {",
+		"\tAttributes map[string]int64 `json:\"attributes,omitempty\"`",
+		"\tID         CommonID         `json:\"id\"`",
+		"\tKind       string           `json:\"kind\"`",
+		"\tOwner      *AddItemOwner    `json:\"owner,omitempty\"`",
+		"\tTags       []string         `json:\"tags,omitempty\"`",
+		"\tWeight     float64          `json:\"weight,omitempty\"`",
+		"type AddItemOwner struct {\n\tTeam string `json:\"team,omitempty\"`\n}",
+		"// CommonID is identifier of the item\ntype CommonID string",
+	} {
+		if !strings.Contains(goFile, expected) {
+			t.Errorf("expected the Go file to contain %q, got:\n%s", expected, goFile)
+		}
+	}
+	typeScriptFile := rendered[TYPESCRIPT_FILE_NAME]
+	for _, expected := range []string{
+		"export interface AddItem {\n  attributes?: Record<string, number>;\n  id: CommonID;\n" +
+			"  kind: \"event\" | \"entity\";",
+		"  tags?: string[];\n  weight?: number;\n}",
+		"export interface AddItemOwner {\n  team?: string;\n}",
+		"/**\n * Identifier of the item\n */\nexport type CommonID = string;",
+	} {
+		if !strings.Contains(typeScriptFile, expected) {
+			t.Errorf("expected the TypeScript file to contain %q, got:\n%s", expected, typeScriptFile)
+		}
+	}
+	if strings.Contains(goFile, "type Common ") || strings.Contains(typeScriptFile, "Broken") {
+		t.Errorf("expected files without a type of their own to be left out")
+	}
+}
+
+// TestTypeName tests that names are exported, split into words and spelled with Go initialisms
+func TestTypeName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"add.item":     "AddItem",
+		"user_id":      "UserID",
+		"targetType":   "TargetType",
+		"3d-model":     "X3dModel",
+		"external url": "ExternalURL",
+	} {
+		if actual := typeName(name); actual != expected {
+			t.Errorf("expected %s to be named %s, got %s", name, expected, actual)
+		}
+	}
+}
@@ -1,39 +1,1040 @@
 // Package config holds all config related entities
+//
+// Settings are read through the typed getters of a Service, never from the environment directly. The service the
+// application runs with is handed to the packages that read it: package main, which wires the application together,
+// and the Git implementations, through git.Configure. The controllers are handed settings by the services main
+// configures rather than reading the configuration themselves
 package config
 
 import (
 	"fmt"
-	"os"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // IsLocal returns whether or not the running application is operating locally
-func IsLocal() bool {
-	return os.Getenv("IS_LOCAL") == "true"
+func (s *Service) IsLocal() bool {
+	return s.Get("IS_LOCAL") == "true"
 }
 
-// GetToken returns a GitHub access token for the user
-func GetToken() (*string, error) {
-	token := os.Getenv("GIT_TOKEN")
+// Environment returns the name of the environment the application is deployed to, which RFCs reference as the
+// ${environment} template variable, nil is returned if unspecified
+func (s *Service) Environment() *string {
+	environment := strings.TrimSpace(s.Get("ENVIRONMENT"))
+	if environment == "" {
+		return nil
+	}
+	return &environment
+}
+
+// IsProduction returns whether the service is configured as running in production: stacks that are neither local nor
+// deployed to a named environment other than "production" or "prod". Features meant for testing are refused there
+func (s *Service) IsProduction() bool {
+	if s.Get("IS_LOCAL") == "true" {
+		return false
+	}
+	environment := strings.ToLower(strings.TrimSpace(s.Get("ENVIRONMENT")))
+	return environment == "" || environment == "production" || environment == "prod"
+}
+
+// IsMaintenanceMode returns whether the application should start with maintenance mode enabled
+func (s *Service) IsMaintenanceMode() bool {
+	return s.Get("MAINTENANCE_MODE") == "true"
+}
+
+// MaintenanceMessage returns the message requests rejected during maintenance are given, empty if unspecified
+func (s *Service) MaintenanceMessage() string {
+	return s.Get("MAINTENANCE_MESSAGE")
+}
+
+// Token returns the Git access token shared by the requests that do not carry the token of their user
+func (s *Service) Token() (*string, error) {
+	token := s.Get("GIT_TOKEN")
 	if token == "" {
 		return nil, fmt.Errorf("no token specified")
 	}
 	return &token, nil
 }
 
-// GetMachineToken returns a GitHub machine access token for machine actions
-func GetMachineToken() (*string, error) {
-	token := os.Getenv("GIT_MACHINE_TOKEN")
+// MachineToken returns a GitHub machine access token for machine actions
+func (s *Service) MachineToken() (*string, error) {
+	token := s.Get("GIT_MACHINE_TOKEN")
 	if token == "" {
 		return nil, fmt.Errorf("no machine token specified")
 	}
 	return &token, nil
 }
 
-// GetTrackingRepo returns the GitHub repository to use as a backing store
-func GetTrackingRepo() (*string, error) {
-	repo := os.Getenv("TRACKING_REPOSITORY")
+// ReadToken returns the read-only Git access token the read endpoints are served with instead of the machine token,
+// nil is returned if none is specified
+func (s *Service) ReadToken() *string {
+	token := s.Get("GIT_READ_TOKEN")
+	if token == "" {
+		return nil
+	}
+	return &token
+}
+
+// IsAnonymousRead returns whether the read endpoints are served without any token when no read token is specified,
+// which only suits public tracking repositories
+func (s *Service) IsAnonymousRead() bool {
+	return s.Get("GIT_READ_ANONYMOUS") == "true"
+}
+
+// TenantToken returns the access token held by the given setting, which a tenant names as one of its tokens
+func (s *Service) TenantToken(setting string) (*string, error) {
+	token := s.Get(setting)
+	if setting == "" || token == "" {
+		return nil, fmt.Errorf("no token specified in %s", setting)
+	}
+	return &token, nil
+}
+
+// TenantsFile returns the path of the JSON file holding the tenants of the multi-tenant mode, nil is returned if
+// the schema domains share their credentials, load targets and notification channels
+func (s *Service) TenantsFile() *string {
+	file := s.Get("TENANTS_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// GitHubAppID returns the ID of the GitHub App the machine identity authenticates as, nil is returned if machine
+// actions use GIT_MACHINE_TOKEN instead
+func (s *Service) GitHubAppID() *string {
+	id := s.Get("GITHUB_APP_ID")
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// GitHubAppInstallationID returns the ID of the installation of the GitHub App on the owner of the tracking
+// repositories
+func (s *Service) GitHubAppInstallationID() (*string, error) {
+	id := s.Get("GITHUB_APP_INSTALLATION_ID")
+	if id == "" {
+		return nil, fmt.Errorf("no GitHub App installation ID specified")
+	}
+	return &id, nil
+}
+
+// GitHubAppPrivateKeyFile returns the PEM file holding the private key of the GitHub App
+func (s *Service) GitHubAppPrivateKeyFile() (*string, error) {
+	file := s.Get("GITHUB_APP_KEY_FILE")
+	if file == "" {
+		return nil, fmt.Errorf("no GitHub App private key file specified")
+	}
+	return &file, nil
+}
+
+// TrackingRepo returns the GitHub repository to use as a backing store
+func (s *Service) TrackingRepo() (*string, error) {
+	repo := s.Get("TRACKING_REPOSITORY")
 	if repo == "" {
 		return nil, fmt.Errorf("no tracking repository specified")
 	}
 	return &repo, nil
 }
+
+// TrackingRepositories returns the tracking repository of each schema domain whose RFCs are not tracked in
+// TRACKING_REPOSITORY, keyed by domain
+// The expected format is a comma separated list of DOMAIN=REPOSITORY pairs, for example
+// "catalog=catalog-rfcs,playback=playback-rfcs"
+func (s *Service) TrackingRepositories() (map[string]string, error) {
+	repositories := map[string]string{}
+	value := s.Get("TRACKING_REPOSITORIES")
+	if value == "" {
+		return repositories, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		domain, repo, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || domain == "" || repo == "" {
+			return nil, fmt.Errorf("malformed tracking repository: %s", pair)
+		}
+		repositories[domain] = repo
+	}
+	return repositories, nil
+}
+
+// RepositoryOwner returns the user, organization or workspace owning the given tracking repository
+// REPOSITORY_OWNERS maps repositories to their owner as a comma separated list of REPOSITORY=OWNER pairs, for example
+// "rfcs=schema-team,rfcs-sandbox=platform", repositories it does not list belong to REPOSITORY_OWNER
+func (s *Service) RepositoryOwner(repository string) (*string, error) {
+	if value := s.Get("REPOSITORY_OWNERS"); value != "" {
+		for _, pair := range strings.Split(value, ",") {
+			repo, owner, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if !found || repo == "" || owner == "" {
+				return nil, fmt.Errorf("malformed repository owner: %s", pair)
+			}
+			if repo == repository {
+				return &owner, nil
+			}
+		}
+	}
+
+	owner := s.Get("REPOSITORY_OWNER")
+	if owner == "" {
+		return nil, fmt.Errorf("no owner specified for repository %s", repository)
+	}
+	return &owner, nil
+}
+
+// GitProvider returns the name of the Git provider hosting the tracking repository, "github" unless specified
+func (s *Service) GitProvider() string {
+	if provider := strings.ToLower(strings.TrimSpace(s.Get("GIT_PROVIDER"))); provider != "" {
+		return provider
+	}
+	return "github"
+}
+
+// CustomReviewTypes returns custom review intents mapped to the base review type they are submitted as
+// The expected format is a comma separated list of INTENT=BASE pairs, for example
+// "ACKNOWLEDGE=COMMENT,VETO=REQUEST_CHANGES"
+func (s *Service) CustomReviewTypes() (map[string]string, error) {
+	reviewTypes := map[string]string{}
+	value := s.Get("CUSTOM_REVIEW_TYPES")
+	if value == "" {
+		return reviewTypes, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		intent, base, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || intent == "" || base == "" {
+			return nil, fmt.Errorf("malformed custom review type: %s", pair)
+		}
+		reviewTypes[strings.ToUpper(intent)] = strings.ToUpper(base)
+	}
+	return reviewTypes, nil
+}
+
+// NotificationWebhookURL returns the URL notifications are posted to, nil is returned if webhook notifications are
+// not configured
+func (s *Service) NotificationWebhookURL() *string {
+	url := s.Get("NOTIFICATION_WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &url
+}
+
+// NotificationAttempts returns the number of deliveries made of a webhook notification before it is given up
+// on, nil is returned if it is not specified
+func (s *Service) NotificationAttempts() (*int, error) {
+	attempts, err := s.Int("NOTIFICATION_ATTEMPTS")
+	if err != nil || (attempts != nil && *attempts < 1) {
+		return nil, fmt.Errorf("malformed notification attempts, expected a positive integer: %s",
+			s.Get("NOTIFICATION_ATTEMPTS"))
+	}
+	return attempts, nil
+}
+
+// NotificationBackoff returns the wait before the second delivery of a webhook notification, doubled before
+// every delivery after it, nil is returned if it is not specified
+func (s *Service) NotificationBackoff() (*time.Duration, error) {
+	backoff, err := s.Duration("NOTIFICATION_BACKOFF")
+	if err != nil || (backoff != nil && *backoff <= 0) {
+		return nil, fmt.Errorf("malformed notification backoff, expected a positive duration: %s",
+			s.Get("NOTIFICATION_BACKOFF"))
+	}
+	return backoff, nil
+}
+
+// DeadLetterFile returns the path of the JSON file the notifications given up on are kept in, nil is returned if
+// they are kept in memory
+func (s *Service) DeadLetterFile() *string {
+	file := s.Get("DEAD_LETTER_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// NotificationTemplatesDir returns the directory holding notification template overrides, nil is returned if the
+// built-in templates should be used
+func (s *Service) NotificationTemplatesDir() *string {
+	dir := s.Get("NOTIFICATION_TEMPLATES_DIR")
+	if dir == "" {
+		return nil
+	}
+	return &dir
+}
+
+// NotificationRoutesFile returns the path of the JSON file holding the notification routing rules, nil is returned
+// if every event should be delivered on every channel
+func (s *Service) NotificationRoutesFile() *string {
+	file := s.Get("NOTIFICATION_ROUTES_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// OIDCIssuer returns the URL of the OpenID Connect issuer users authenticate with, nil is returned if SSO is not
+// enabled
+func (s *Service) OIDCIssuer() *string {
+	issuer := s.Get("OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+	return &issuer
+}
+
+// OIDCAudience returns the client ID ID tokens must be issued for
+func (s *Service) OIDCAudience() (*string, error) {
+	audience := s.Get("OIDC_AUDIENCE")
+	if audience == "" {
+		return nil, fmt.Errorf("no OIDC audience specified")
+	}
+	return &audience, nil
+}
+
+// OIDCLoginClaim returns the claim of ID tokens Git logins are read from, empty if not configured
+func (s *Service) OIDCLoginClaim() string {
+	return s.Get("OIDC_LOGIN_CLAIM")
+}
+
+// OIDCSealSecret returns the secret the submissions and reviews of users authenticated through SSO are sealed with
+func (s *Service) OIDCSealSecret() (*string, error) {
+	secret := s.Get("OIDC_SEAL_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("no OIDC seal secret specified")
+	}
+	return &secret, nil
+}
+
+// PullRequestTitleTemplate returns the Go template the pull requests of RFCs are named with, nil is returned if
+// they keep the default title
+func (s *Service) PullRequestTitleTemplate() *string {
+	template := s.Get("PR_TITLE_TEMPLATE")
+	if template == "" {
+		return nil
+	}
+	return &template
+}
+
+// PullRequestTitleTemplatesFile returns the path of the JSON file holding the pull request title templates of schema
+// domains overriding PR_TITLE_TEMPLATE, nil is returned if there are no overrides
+func (s *Service) PullRequestTitleTemplatesFile() *string {
+	file := s.Get("PR_TITLE_TEMPLATES_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// PullRequestTriageFile returns the path of the JSON file holding the labels, assignees and reviewers pull requests
+// of RFCs are opened with, nil is returned if they are opened untriaged
+func (s *Service) PullRequestTriageFile() *string {
+	file := s.Get("PR_TRIAGE_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// PullRequestDescriptionFile returns the path of the file holding the Go template the descriptions of
+// the pull requests of RFCs are rendered with, nil is returned if they are rendered with the built-in template
+func (s *Service) PullRequestDescriptionFile() *string {
+	file := s.Get("PR_DESCRIPTION_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// SchemaDirectory returns the directory of the tracking repositories holding the JSON Schemas action data is
+// validated against, nil is returned if action data is not validated
+func (s *Service) SchemaDirectory() *string {
+	directory := strings.Trim(s.Get("SCHEMA_DIRECTORY"), "/")
+	if directory == "" {
+		return nil
+	}
+	return &directory
+}
+
+// CodegenRepository returns the name of the repository the types generated from the action data schemas are
+// committed to, nil is returned if types are not generated
+func (s *Service) CodegenRepository() *string {
+	repository := s.Get("CODEGEN_REPOSITORY")
+	if repository == "" {
+		return nil
+	}
+	return &repository
+}
+
+// CodegenDirectory returns the directory of the codegen repository generated types are committed to, the root
+// directory if it is empty
+func (s *Service) CodegenDirectory() string {
+	return strings.Trim(s.Get("CODEGEN_DIRECTORY"), "/")
+}
+
+// CodegenGoPackage returns the package of the generated Go types, nil is returned if it is not specified
+func (s *Service) CodegenGoPackage() *string {
+	goPackage := s.Get("CODEGEN_GO_PACKAGE")
+	if goPackage == "" {
+		return nil
+	}
+	return &goPackage
+}
+
+// ReservationFile returns the path of the JSON file the reservations of target descriptors are kept in, nil is
+// returned if they are kept in memory
+func (s *Service) ReservationFile() *string {
+	file := s.Get("RESERVATION_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// ReservationTTL returns how long reservations last unless their reserve action sets their expiry, nil is returned
+// if it is not specified
+// The expected format is a duration, for example "720h"
+func (s *Service) ReservationTTL() (*time.Duration, error) {
+	ttl, err := s.Duration("RESERVATION_TTL")
+	if err != nil || (ttl != nil && *ttl <= 0) {
+		return nil, fmt.Errorf("malformed reservation ttl, expected a positive duration: %s",
+			s.Get("RESERVATION_TTL"))
+	}
+	return ttl, nil
+}
+
+// ReviewRequestFile returns the path of the JSON file review requests are kept in, nil is returned if they are kept
+// in memory only
+func (s *Service) ReviewRequestFile() *string {
+	file := s.Get("REVIEW_REQUEST_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// ReviewSLA returns how long reviewers have to first respond to a review request, nil is returned if it is not
+// specified
+// The expected format is a duration, for example "48h"
+func (s *Service) ReviewSLA() (*time.Duration, error) {
+	sla, err := s.Duration("REVIEW_SLA")
+	if err != nil || (sla != nil && *sla <= 0) {
+		return nil, fmt.Errorf("malformed review SLA, expected a positive duration: %s", s.Get("REVIEW_SLA"))
+	}
+	return sla, nil
+}
+
+// ReviewReminderInterval returns how often reviewers are reminded of the review requests they left unanswered past
+// the review SLA, nil is returned if they are never reminded
+// The expected format is a duration, for example "4h"
+func (s *Service) ReviewReminderInterval() (*time.Duration, error) {
+	interval, err := s.Duration("REVIEW_REMINDER_INTERVAL")
+	if err != nil || (interval != nil && *interval <= 0) {
+		return nil, fmt.Errorf("malformed review reminder interval, expected a positive duration: %s",
+			s.Get("REVIEW_REMINDER_INTERVAL"))
+	}
+	return interval, nil
+}
+
+// AuthzPolicyFile returns the path of the JSON file holding the authorization policy, nil is returned if every
+// user is granted every permission
+func (s *Service) AuthzPolicyFile() *string {
+	file := s.Get("AUTHZ_POLICY_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// ApprovalPolicyFile returns the path of the JSON file holding the approval policy, nil is returned if merges only
+// require what the branch protection of the tracking repository requires
+func (s *Service) ApprovalPolicyFile() *string {
+	file := s.Get("APPROVAL_POLICY_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// DirectoryProvider returns the type of directory Git logins are resolved to people with, nil is returned if logins
+// are not resolved. The expected values are "static", "scim" and "ldap"
+func (s *Service) DirectoryProvider() *string {
+	provider := s.Get("DIRECTORY_PROVIDER")
+	if provider == "" {
+		return nil
+	}
+	return &provider
+}
+
+// DirectorySource returns where the directory is read from, the path of the JSON file of a static directory or the
+// URL of a SCIM service or LDAP server, nil is returned if it is not specified
+func (s *Service) DirectorySource() *string {
+	source := s.Get("DIRECTORY_SOURCE")
+	if source == "" {
+		return nil
+	}
+	return &source
+}
+
+// DirectoryToken returns the bearer token used to query the directory, empty if unspecified
+func (s *Service) DirectoryToken() string {
+	return s.Get("DIRECTORY_TOKEN")
+}
+
+// DirectoryCacheTTL returns how long directory entries may be served from cache, nil is returned if it is not
+// specified
+// The expected format is a duration, for example "15m"
+func (s *Service) DirectoryCacheTTL() (*time.Duration, error) {
+	ttl, err := s.Duration("DIRECTORY_CACHE_TTL")
+	if err != nil || (ttl != nil && *ttl <= 0) {
+		return nil, fmt.Errorf("malformed directory cache ttl, expected a positive duration: %s",
+			s.Get("DIRECTORY_CACHE_TTL"))
+	}
+	return ttl, nil
+}
+
+// TargetOwners returns the teams that own each RFC target descriptor
+// The expected format is a comma separated list of DESCRIPTOR=TEAM pairs, where TEAM is a team slug and a descriptor
+// may be listed once per owning team, for example "EntityType=schema-admins,Event=events,Event=analytics"
+func (s *Service) TargetOwners() (map[string][]string, error) {
+	owners := map[string][]string{}
+	value := s.Get("TARGET_OWNERS")
+	if value == "" {
+		return owners, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		descriptor, team, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || descriptor == "" || team == "" {
+			return nil, fmt.Errorf("malformed target owner: %s", pair)
+		}
+		owners[descriptor] = append(owners[descriptor], team)
+	}
+	return owners, nil
+}
+
+// TargetOwnersFile returns the path of the CODEOWNERS style file assigning target descriptor patterns to the teams
+// that own them, nil is returned if ownership is only configured through TARGET_OWNERS
+func (s *Service) TargetOwnersFile() *string {
+	file := s.Get("TARGET_OWNERS_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// DigestTime returns the time of day (offset from midnight UTC) at which daily digests are sent, nil is returned
+// if digests are disabled
+// The expected format is HH:MM, for example "09:00"
+func (s *Service) DigestTime() (*time.Duration, error) {
+	value := s.Get("DIGEST_TIME")
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed digest time, expected HH:MM: %s", value)
+	}
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return &offset, nil
+}
+
+// LoadGate returns the type of approval required before an RFC is loaded, nil is returned if loads are not gated
+// Gating is intended for production datastores, the expected values are "deployment" (approved through a GitHub
+// deployment environment) and "manual" (approved through Harmonia)
+func (s *Service) LoadGate() *string {
+	gate := s.Get("LOAD_GATE")
+	if gate == "" {
+		return nil
+	}
+	return &gate
+}
+
+// LoadGateEnvironment returns the deployment environment whose protection rules approve gated loads, "production"
+// is returned if none is specified
+func (s *Service) LoadGateEnvironment() string {
+	environment := s.Get("LOAD_GATE_ENVIRONMENT")
+	if environment == "" {
+		return "production"
+	}
+	return environment
+}
+
+// ReviewerAssignment returns the strategy used to pick the member of each owning team requested to review new RFCs,
+// nil is returned if the owning teams are not assigned a reviewer. The expected values are "round-robin" and
+// "least-loaded"
+func (s *Service) ReviewerAssignment() *string {
+	strategy := s.Get("REVIEWER_ASSIGNMENT")
+	if strategy == "" {
+		return nil
+	}
+	return &strategy
+}
+
+// LoadTargets returns the names of the datastores RFCs can be loaded into, nil is returned if none are specified in
+// which case RFCs are loaded into a single default target
+func (s *Service) LoadTargets() []string {
+	return s.List("LOAD_TARGETS")
+}
+
+// ShadowLoadTargets returns the names of the load targets whose loads are shadowed by a new loader implementation,
+// nil is returned if none are specified
+func (s *Service) ShadowLoadTargets() []string {
+	return s.List("SHADOW_LOAD_TARGETS")
+}
+
+// Analyzers returns the secret shared with each automated analyzer allowed to annotate RFC actions, keyed by
+// analyzer, none are returned if annotations are rejected
+// The expected format is a comma separated list of ANALYZER=SETTING pairs, where SETTING names the setting holding the
+// secret of the analyzer, for example "lint=LINT_ANALYZER_SECRET"
+func (s *Service) Analyzers() (map[string]string, error) {
+	return s.systemSecrets("ANALYZERS", "analyzer")
+}
+
+// BreakGlassAdmins returns the Git logins allowed to force RFCs live bypassing policy, nil is returned if none are
+// specified in which case glass cannot be broken
+func (s *Service) BreakGlassAdmins() []string {
+	return s.List("BREAK_GLASS_ADMINS")
+}
+
+// LoadConcurrency returns the number of load targets an RFC is loaded into at the same time, nil is returned if it
+// is not specified
+func (s *Service) LoadConcurrency() (*int, error) {
+	concurrency, err := s.Int("LOAD_CONCURRENCY")
+	if err != nil || (concurrency != nil && *concurrency < 1) {
+		return nil, fmt.Errorf("malformed load concurrency, expected a positive integer: %s",
+			s.Get("LOAD_CONCURRENCY"))
+	}
+	return concurrency, nil
+}
+
+// LoadMergePolicy returns whether RFCs loaded into only some of their targets can be merged, nil is returned if it
+// is not specified. The expected values are "all" and "partial"
+func (s *Service) LoadMergePolicy() *string {
+	policy := s.Get("LOAD_MERGE_POLICY")
+	if policy == "" {
+		return nil
+	}
+	return &policy
+}
+
+// RequiredStatusContexts returns the status contexts and check names a pull request must pass to be mergeable, nil
+// is returned if none are specified in which case every status context and check must pass
+func (s *Service) RequiredStatusContexts() []string {
+	return s.List("REQUIRED_STATUS_CONTEXTS")
+}
+
+// GitFaultRate returns the probability, between 0 and 1, of a call to the Git provider failing with an injected fault,
+// 0 is returned if no fault is injected
+func (s *Service) GitFaultRate() (float64, error) {
+	value := s.Get("GIT_FAULT_RATE")
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("malformed Git fault rate, expected a number between 0 and 1: %s", value)
+	}
+	return rate, nil
+}
+
+// GitFaultLatency returns how long every call to the Git provider is delayed for, nil is returned if calls are not
+// delayed
+// The expected format is a duration, for example "2s"
+func (s *Service) GitFaultLatency() (*time.Duration, error) {
+	latency, err := s.Duration("GIT_FAULT_LATENCY")
+	if err != nil || (latency != nil && *latency <= 0) {
+		return nil, fmt.Errorf("malformed Git fault latency, expected a positive duration: %s",
+			s.Get("GIT_FAULT_LATENCY"))
+	}
+	return latency, nil
+}
+
+// GitFaultMethods returns the names of the Git methods faults are injected into, nil is returned if faults are
+// injected into all of them
+// The expected format is a comma separated list, for example "UpdateFile,MergePullRequest"
+func (s *Service) GitFaultMethods() []string {
+	return s.List("GIT_FAULT_METHODS")
+}
+
+// RequestSigningSecret returns the secret shared with callers to sign requests to state changing endpoints, nil is
+// returned if request signing is not required
+func (s *Service) RequestSigningSecret() *string {
+	secret := s.Get("REQUEST_SIGNING_SECRET")
+	if secret == "" {
+		return nil
+	}
+	return &secret
+}
+
+// ReceiptSigningKey returns the base64 encoded Ed25519 seed submission receipts are signed with, nil is returned
+// if receipts are not signed
+func (s *Service) ReceiptSigningKey() *string {
+	key := s.Get("RECEIPT_SIGNING_KEY")
+	if key == "" {
+		return nil
+	}
+	return &key
+}
+
+// AuthorKeysFile returns the path of the JSON file holding the public keys authors sign their RFCs with, nil is
+// returned if no author key is registered
+func (s *Service) AuthorKeysFile() *string {
+	file := s.Get("AUTHOR_KEYS_FILE")
+	if file == "" {
+		return nil
+	}
+	return &file
+}
+
+// IsAuthorSignatureRequired returns whether RFCs must be signed by their author to be submitted, updated and merged
+func (s *Service) IsAuthorSignatureRequired() bool {
+	return s.Get("REQUIRE_AUTHOR_SIGNATURES") == "true"
+}
+
+// ExternalApprovers returns the secret shared with each external system allowed to approve RFCs, keyed by system
+// The expected format is a comma separated list of SYSTEM=SETTING pairs, where SETTING names the setting holding the
+// secret of the system, for example "cab=CAB_APPROVAL_SECRET"
+func (s *Service) ExternalApprovers() (map[string]string, error) {
+	return s.systemSecrets("EXTERNAL_APPROVERS", "external approver")
+}
+
+// systemSecrets returns the secrets of the external systems listed in the given setting as SYSTEM=SETTING pairs,
+// keyed by system, the given kind of system naming them in errors
+func (s *Service) systemSecrets(key string, kind string) (map[string]string, error) {
+	secrets := map[string]string{}
+	value := s.Get(key)
+	if value == "" {
+		return secrets, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		system, setting, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || system == "" || setting == "" {
+			return nil, fmt.Errorf("malformed %s: %s", kind, pair)
+		}
+		secret := s.Get(setting)
+		if secret == "" {
+			return nil, fmt.Errorf("no secret specified for %s %s, set %s", kind, system, setting)
+		}
+		secrets[system] = secret
+	}
+	return secrets, nil
+}
+
+// GitHubWebhookSecret returns the secret GitHub signs the webhook deliveries of the tracking repositories with, nil
+// is returned if webhooks are not received
+func (s *Service) GitHubWebhookSecret() *string {
+	secret := s.Get("GITHUB_WEBHOOK_SECRET")
+	if secret == "" {
+		return nil
+	}
+	return &secret
+}
+
+// IsWebhookLoadOnApproval returns whether RFCs approved on GitHub, as reported by webhooks, are loaded and merged
+func (s *Service) IsWebhookLoadOnApproval() bool {
+	return s.Get("WEBHOOK_LOAD_ON_APPROVAL") == "true"
+}
+
+// GinMode returns the mode the gin server runs in, one of "debug", "release" or "test"
+// The GIN_MODE env var takes precedence, otherwise local stacks run in "debug" and all others in "release" so debug
+// output is never leaked
+func (s *Service) GinMode() string {
+	if mode := s.Get("GIN_MODE"); mode != "" {
+		return mode
+	}
+	if s.IsLocal() {
+		return "debug"
+	}
+	return "release"
+}
+
+// TrustedProxies returns the IPs and CIDRs of the proxies trusted to report the client IP through forwarded
+// headers, an empty result means no proxy is trusted and the client IP is always the connecting address
+// The expected format is a comma separated list, for example "10.0.0.0/8,192.168.1.10"
+func (s *Service) TrustedProxies() ([]string, error) {
+	var proxies []string
+	value := s.Get("TRUSTED_PROXIES")
+	if value == "" {
+		return proxies, nil
+	}
+
+	for _, proxy := range strings.Split(value, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("malformed trusted proxy, expected an IP or CIDR: %s", proxy)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+// RemoteIPHeaders returns the forwarded headers, in order of precedence, that trusted proxies report the client IP
+// in. "X-Forwarded-For" and "X-Real-IP" are returned if none are specified
+func (s *Service) RemoteIPHeaders() []string {
+	value := s.Get("REMOTE_IP_HEADERS")
+	if value == "" {
+		return []string{"X-Forwarded-For", "X-Real-IP"}
+	}
+
+	var headers []string
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+// LogFormat returns the format the application logs in, "json" or "text", defaulting to "text"
+func (s *Service) LogFormat() string {
+	if format := s.Get("LOG_FORMAT"); format != "" {
+		return format
+	}
+	return "text"
+}
+
+// LogLevel returns the minimum level of the records the application logs, one of "debug", "info", "warn" or
+// "error", defaulting to "info"
+func (s *Service) LogLevel() string {
+	if level := s.Get("LOG_LEVEL"); level != "" {
+		return level
+	}
+	return "info"
+}
+
+// TraceExporter returns where spans are exported, "stdout" or "none", defaulting to "none"
+func (s *Service) TraceExporter() string {
+	if exporter := s.Get("TRACE_EXPORTER"); exporter != "" {
+		return exporter
+	}
+	return "none"
+}
+
+// TraceSampleRatio returns the ratio of traces started by Harmonia that are sampled, between 0 and 1, defaulting
+// to 1. Traces continued from a caller are sampled if the caller sampled them
+func (s *Service) TraceSampleRatio() (float64, error) {
+	value := s.Get("TRACE_SAMPLE_RATIO")
+	if value == "" {
+		return 1, nil
+	}
+
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 0, fmt.Errorf("malformed trace sample ratio, expected a number between 0 and 1: %s", value)
+	}
+	return ratio, nil
+}
+
+// RequiredApprovals returns the number of approvals the branch protection of the tracking repositories is expected
+// to require before merging, defaulting to 1
+func (s *Service) RequiredApprovals() (int, error) {
+	value := s.Get("REQUIRED_APPROVALS")
+	if value == "" {
+		return 1, nil
+	}
+
+	approvals, err := strconv.Atoi(value)
+	if err != nil || approvals < 0 {
+		return 0, fmt.Errorf("malformed required approvals, expected a non-negative integer: %s", value)
+	}
+	return approvals, nil
+}
+
+// ProtectionCheckInterval returns how often the branch protection of the tracking repositories is compared to the
+// expected protection, nil is returned if it is not specified
+func (s *Service) ProtectionCheckInterval() (*time.Duration, error) {
+	interval, err := s.Duration("PROTECTION_CHECK_INTERVAL")
+	if err != nil || (interval != nil && *interval <= 0) {
+		return nil, fmt.Errorf("malformed protection check interval, expected a positive duration: %s",
+			s.Get("PROTECTION_CHECK_INTERVAL"))
+	}
+	return interval, nil
+}
+
+// RetentionDays returns the number of days merged RFCs are kept in the base branch of the tracking repositories
+// before they are archived, nil is returned if merged RFCs are never archived
+func (s *Service) RetentionDays() (*int, error) {
+	days, err := s.Int("RFC_RETENTION_DAYS")
+	if err != nil || (days != nil && *days < 1) {
+		return nil, fmt.Errorf("malformed RFC retention days, expected a positive integer: %s",
+			s.Get("RFC_RETENTION_DAYS"))
+	}
+	return days, nil
+}
+
+// MergeabilityAttempts returns the number of times status checks and mergeable states being computed by the Git
+// provider are read before mergeability is determined, nil is returned if it is not specified
+func (s *Service) MergeabilityAttempts() (*int, error) {
+	attempts, err := s.Int("MERGEABILITY_ATTEMPTS")
+	if err != nil || (attempts != nil && *attempts < 1) {
+		return nil, fmt.Errorf("malformed mergeability attempts, expected a positive integer: %s",
+			s.Get("MERGEABILITY_ATTEMPTS"))
+	}
+	return attempts, nil
+}
+
+// MergeabilityWait returns the delay before status checks and mergeable states being computed are read again,
+// doubled for every read after it, nil is returned if it is not specified
+func (s *Service) MergeabilityWait() (*time.Duration, error) {
+	wait, err := s.Duration("MERGEABILITY_WAIT")
+	if err != nil || (wait != nil && *wait <= 0) {
+		return nil, fmt.Errorf("malformed mergeability wait, expected a positive duration: %s",
+			s.Get("MERGEABILITY_WAIT"))
+	}
+	return wait, nil
+}
+
+// MergeabilityMaxWait returns the longest delay before status checks and mergeable states being computed are read
+// again, nil is returned if it is not specified
+func (s *Service) MergeabilityMaxWait() (*time.Duration, error) {
+	wait, err := s.Duration("MERGEABILITY_MAX_WAIT")
+	if err != nil || (wait != nil && *wait <= 0) {
+		return nil, fmt.Errorf("malformed mergeability max wait, expected a positive duration: %s",
+			s.Get("MERGEABILITY_MAX_WAIT"))
+	}
+	return wait, nil
+}
+
+// JobBackend returns the backend asynchronous jobs are queued on, defaulting to "memory", the only backend
+func (s *Service) JobBackend() string {
+	if backend := s.Get("JOB_BACKEND"); backend != "" {
+		return backend
+	}
+	return "memory"
+}
+
+// JobWorkers returns the number of asynchronous jobs run at the same time, nil is returned if it is not specified
+func (s *Service) JobWorkers() (*int, error) {
+	workers, err := s.Int("JOB_WORKERS")
+	if err != nil || (workers != nil && *workers < 1) {
+		return nil, fmt.Errorf("malformed job workers, expected a positive integer: %s", s.Get("JOB_WORKERS"))
+	}
+	return workers, nil
+}
+
+// JobMaxAttempts returns the number of times an asynchronous job is attempted before it fails, nil is returned if
+// it is not specified
+func (s *Service) JobMaxAttempts() (*int, error) {
+	attempts, err := s.Int("JOB_MAX_ATTEMPTS")
+	if err != nil || (attempts != nil && *attempts < 1) {
+		return nil, fmt.Errorf("malformed job max attempts, expected a positive integer: %s",
+			s.Get("JOB_MAX_ATTEMPTS"))
+	}
+	return attempts, nil
+}
+
+// StatusBackend returns the store the load status of RFCs is recorded in, the RFC file if unspecified
+func (s *Service) StatusBackend() string {
+	if backend := s.Get("STATUS_BACKEND"); backend != "" {
+		return backend
+	}
+	return "rfc"
+}
+
+// StatusFile returns the path of the file load statuses are recorded in, nil is returned if it is not specified
+func (s *Service) StatusFile() *string {
+	path := s.Get("STATUS_FILE")
+	if path == "" {
+		return nil
+	}
+	return &path
+}
+
+// IsStatusInRFCFile returns whether load statuses are also recorded in the RFC file when another store is configured
+func (s *Service) IsStatusInRFCFile() bool {
+	return s.Get("STATUS_IN_RFC_FILE") == "true"
+}
+
+// LeaderBackend returns the lock instances campaign for leadership on, "none" or "file", defaulting to "none" in
+// which case every instance leads
+func (s *Service) LeaderBackend() string {
+	if backend := s.Get("LEADER_BACKEND"); backend != "" {
+		return backend
+	}
+	return "none"
+}
+
+// LeaderBackendURL returns the path of the lease file of the file backend instances campaign for leadership on,
+// nil is returned if it is not specified
+func (s *Service) LeaderBackendURL() *string {
+	url := s.Get("LEADER_BACKEND_URL")
+	if url == "" {
+		return nil
+	}
+	return &url
+}
+
+// LeaderLease returns how long the lease of the leading instance lasts unless renewed, nil is returned if it is not
+// specified
+func (s *Service) LeaderLease() (*time.Duration, error) {
+	lease, err := s.Duration("LEADER_LEASE")
+	if err != nil || (lease != nil && *lease <= 0) {
+		return nil, fmt.Errorf("malformed leader lease, expected a positive duration: %s", s.Get("LEADER_LEASE"))
+	}
+	return lease, nil
+}
+
+// CommentFilterAction returns what is done with the comments the comment filters object to: "reject" the review
+// holding them, which is the default, "flag" them or "hold" them for moderation
+func (s *Service) CommentFilterAction() string {
+	if action := s.Get("COMMENT_FILTER_ACTION"); action != "" {
+		return action
+	}
+	return "reject"
+}
+
+// CommentBlockedWords returns the words comments may not hold
+func (s *Service) CommentBlockedWords() []string {
+	return s.List("COMMENT_BLOCKED_WORDS")
+}
+
+// CommentMaxLength returns the number of characters comments may not exceed, nil is returned if it is not specified
+func (s *Service) CommentMaxLength() (*int, error) {
+	length, err := s.Int("COMMENT_MAX_LENGTH")
+	if err != nil || (length != nil && *length < 1) {
+		return nil, fmt.Errorf("malformed comment max length, expected a positive integer: %s",
+			s.Get("COMMENT_MAX_LENGTH"))
+	}
+	return length, nil
+}
+
+// CommentRateLimit returns the number of comments a user may make within the comment rate window, nil is returned
+// if it is not specified
+func (s *Service) CommentRateLimit() (*int, error) {
+	limit, err := s.Int("COMMENT_RATE_LIMIT")
+	if err != nil || (limit != nil && *limit < 1) {
+		return nil, fmt.Errorf("malformed comment rate limit, expected a positive integer: %s",
+			s.Get("COMMENT_RATE_LIMIT"))
+	}
+	return limit, nil
+}
+
+// CommentRateWindow returns the window comments are counted over by the comment rate limit, nil is returned if it
+// is not specified
+func (s *Service) CommentRateWindow() (*time.Duration, error) {
+	window, err := s.Duration("COMMENT_RATE_WINDOW")
+	if err != nil || (window != nil && *window <= 0) {
+		return nil, fmt.Errorf("malformed comment rate window, expected a positive duration: %s",
+			s.Get("COMMENT_RATE_WINDOW"))
+	}
+	return window, nil
+}
+
+// GRPCPort returns the port the gRPC API is served on alongside the REST routes, nil is returned if the gRPC API is
+// not served
+func (s *Service) GRPCPort() (*int, error) {
+	value := s.Get("GRPC_PORT")
+	if value == "" {
+		return nil, nil
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("malformed gRPC port, expected a port number: %s", value)
+	}
+	return &port, nil
+}
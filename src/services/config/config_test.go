@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"testing"
 )
@@ -27,9 +28,379 @@ func TestIsLocal(t *testing.T) {
 
 	for _, test := range testCases {
 		os.Setenv("IS_LOCAL", test.setValue)
-		actual := IsLocal()
+		Default.Reload()
+		actual := Default.IsLocal()
 		if actual != test.expected {
 			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
 		}
 	}
 }
+
+// TestCustomReviewTypes tests the CustomReviewTypes functionality
+func TestCustomReviewTypes(t *testing.T) {
+	testCases := []struct {
+		setValue    string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: map[string]string{},
+		},
+		{
+			setValue: "acknowledge=comment, VETO=REQUEST_CHANGES",
+			expected: map[string]string{"ACKNOWLEDGE": "COMMENT", "VETO": "REQUEST_CHANGES"},
+		},
+		{
+			setValue:    "VETO",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("CUSTOM_REVIEW_TYPES", test.setValue)
+		Default.Reload()
+		actual, err := Default.CustomReviewTypes()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestTargetOwners tests the TargetOwners functionality
+func TestTargetOwners(t *testing.T) {
+	testCases := []struct {
+		setValue    string
+		expected    map[string][]string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: map[string][]string{},
+		},
+		{
+			setValue: "EntityType=schema-admins, Event=events,Event=analytics",
+			expected: map[string][]string{"EntityType": {"schema-admins"}, "Event": {"events", "analytics"}},
+		},
+		{
+			setValue:    "EntityType=",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("TARGET_OWNERS", test.setValue)
+		Default.Reload()
+		actual, err := Default.TargetOwners()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestExternalApprovers tests that the secret of each external approver is read from the setting it names
+func TestExternalApprovers(t *testing.T) {
+	os.Setenv("CAB_APPROVAL_SECRET", "shh")
+	defer os.Unsetenv("CAB_APPROVAL_SECRET")
+	testCases := []struct {
+		setValue    string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: map[string]string{},
+		},
+		{
+			setValue: "cab=CAB_APPROVAL_SECRET",
+			expected: map[string]string{"cab": "shh"},
+		},
+		{
+			setValue:    "cab",
+			expectedErr: true,
+		},
+		{
+			setValue:    "itsm=ITSM_APPROVAL_SECRET",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("EXTERNAL_APPROVERS", test.setValue)
+		Default.Reload()
+		actual, err := Default.ExternalApprovers()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+	os.Unsetenv("EXTERNAL_APPROVERS")
+	Default.Reload()
+}
+
+// TestAnalyzers tests that the secret of each analyzer is read from the setting it names
+func TestAnalyzers(t *testing.T) {
+	os.Setenv("LINT_ANALYZER_SECRET", "shh")
+	os.Setenv("ANALYZERS", "lint=LINT_ANALYZER_SECRET")
+	Default.Reload()
+	defer func() {
+		os.Unsetenv("LINT_ANALYZER_SECRET")
+		os.Unsetenv("ANALYZERS")
+		Default.Reload()
+	}()
+
+	actual, err := Default.Analyzers()
+	if err != nil || len(actual) != 1 || actual["lint"] != "shh" {
+		t.Errorf("expected the secret of lint, got %v, %v", actual, err)
+	}
+
+	os.Setenv("ANALYZERS", "lint")
+	Default.Reload()
+	if _, err = Default.Analyzers(); err == nil {
+		t.Errorf("expected an analyzer without a secret to be rejected")
+	}
+}
+
+// TestTrackingRepositories tests the TrackingRepositories functionality
+func TestTrackingRepositories(t *testing.T) {
+	testCases := []struct {
+		setValue    string
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: map[string]string{},
+		},
+		{
+			setValue: "catalog=catalog-rfcs, playback=playback-rfcs",
+			expected: map[string]string{"catalog": "catalog-rfcs", "playback": "playback-rfcs"},
+		},
+		{
+			setValue:    "catalog",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("TRACKING_REPOSITORIES", test.setValue)
+		Default.Reload()
+		actual, err := Default.TrackingRepositories()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestRepositoryOwner tests the RepositoryOwner functionality
+func TestRepositoryOwner(t *testing.T) {
+	testCases := []struct {
+		owners      string
+		owner       string
+		repository  string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			owners:     "rfcs=schema-team, rfcs-sandbox=platform",
+			owner:      "fallback",
+			repository: "rfcs-sandbox",
+			expected:   "platform",
+		},
+		{
+			owners:     "rfcs=schema-team",
+			owner:      "fallback",
+			repository: "other",
+			expected:   "fallback",
+		},
+		{
+			repository:  "rfcs",
+			expectedErr: true,
+		},
+		{
+			owners:      "rfcs",
+			owner:       "fallback",
+			repository:  "rfcs",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("REPOSITORY_OWNERS", test.owners)
+		os.Setenv("REPOSITORY_OWNER", test.owner)
+		Default.Reload()
+		actual, err := Default.RepositoryOwner(test.repository)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && *actual != test.expected {
+			t.Errorf("actual: %v is not equal to expected: %v", *actual, test.expected)
+		}
+	}
+}
+
+// TestDigestTime tests the DigestTime functionality
+func TestDigestTime(t *testing.T) {
+	testCases := []struct {
+		setValue    string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: "<nil>",
+		},
+		{
+			setValue: "09:30",
+			expected: "9h30m0s",
+		},
+		{
+			setValue:    "9am",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("DIGEST_TIME", test.setValue)
+		Default.Reload()
+		actual, err := Default.DigestTime()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if !test.expectedErr && (actual == nil) != (test.expected == "<nil>") {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		} else if actual != nil && actual.String() != test.expected {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestLoadGateEnvironment tests the LoadGateEnvironment functionality
+func TestLoadGateEnvironment(t *testing.T) {
+	testCases := []struct {
+		setValue string
+		expected string
+	}{
+		{
+			setValue: "",
+			expected: "production",
+		},
+		{
+			setValue: "prod-warehouse",
+			expected: "prod-warehouse",
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("LOAD_GATE_ENVIRONMENT", test.setValue)
+		Default.Reload()
+		if actual := Default.LoadGateEnvironment(); actual != test.expected {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestLoadTargets tests the LoadTargets functionality
+func TestLoadTargets(t *testing.T) {
+	testCases := []struct {
+		setValue string
+		expected []string
+	}{
+		{
+			setValue: "",
+			expected: nil,
+		},
+		{
+			setValue: "primary, search,",
+			expected: []string{"primary", "search"},
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("LOAD_TARGETS", test.setValue)
+		Default.Reload()
+		if actual := Default.LoadTargets(); fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
+
+// TestGinMode tests the GinMode functionality
+func TestGinMode(t *testing.T) {
+	testCases := []struct {
+		setValue string
+		isLocal  string
+		expected string
+	}{
+		{
+			setValue: "",
+			isLocal:  "true",
+			expected: "debug",
+		},
+		{
+			setValue: "",
+			isLocal:  "false",
+			expected: "release",
+		},
+		{
+			setValue: "test",
+			isLocal:  "false",
+			expected: "test",
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("GIN_MODE", test.setValue)
+		os.Setenv("IS_LOCAL", test.isLocal)
+		Default.Reload()
+		if actual := Default.GinMode(); actual != test.expected {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+	os.Unsetenv("IS_LOCAL")
+}
+
+// TestTrustedProxies tests the TrustedProxies functionality
+func TestTrustedProxies(t *testing.T) {
+	testCases := []struct {
+		setValue    string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			setValue: "",
+			expected: nil,
+		},
+		{
+			setValue: "10.0.0.0/8, 192.168.1.10,::1",
+			expected: []string{"10.0.0.0/8", "192.168.1.10", "::1"},
+		},
+		{
+			setValue:    "10.0.0.0/8,load-balancer",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		os.Setenv("TRUSTED_PROXIES", test.setValue)
+		Default.Reload()
+		actual, err := Default.TrustedProxies()
+		if (err != nil) != test.expectedErr {
+			t.Errorf("unexpected error state: %v", err)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("actual: %v is not equal to expected: %v", actual, test.expected)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// This holds the configuration service, the settings of the application read from its environment and from the
+// configuration file the environment names, which are read again whenever the service is reloaded
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CONFIG_FILE_KEY is the setting naming the configuration file, whose KEY=VALUE lines override the settings of the
+// environment. Blank lines and lines starting with # are ignored
+const CONFIG_FILE_KEY = "CONFIG_FILE"
+
+// Service holds the settings the application is configured with, a snapshot of its environment and configuration file
+// taken when the service is created and replaced by Reload. It is safe for concurrent use
+type Service struct {
+	sync.RWMutex
+	environ   func() []string
+	values    map[string]string
+	listeners []func(*Service)
+}
+
+// Default is the configuration of the application, read from the environment of the process and its configuration
+// file
+var Default = New(os.Environ)
+
+// New returns a service configured with the KEY=VALUE pairs returned by the given function, overridden by those of the
+// configuration file they name, if any. The function and the file are read again on every reload. The service is only
+// configured with the given pairs if the file cannot be read, see Reload
+func New(environ func() []string) *Service {
+	pairs := environ()
+	values, err := load(pairs)
+	if err != nil {
+		values = parseEnviron(pairs)
+	}
+	return &Service{environ: environ, values: values}
+}
+
+// load returns the given KEY=VALUE pairs keyed by KEY, overridden by the pairs of the configuration file they name
+func load(environ []string) (map[string]string, error) {
+	values := parseEnviron(environ)
+	file := values[CONFIG_FILE_KEY]
+	if file == "" {
+		return values, nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configuration file %s: %w", file, err)
+	}
+	for number, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("malformed line %d of configuration file %s, expected KEY=VALUE", number+1, file)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, nil
+}
+
+// parseEnviron returns the given KEY=VALUE pairs keyed by KEY, pairs without a "=" are ignored
+func parseEnviron(environ []string) map[string]string {
+	values := make(map[string]string, len(environ))
+	for _, pair := range environ {
+		if key, value, found := strings.Cut(pair, "="); found {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// Reload takes a new snapshot of the environment and configuration file and notifies the listeners registered with
+// OnReload, in order. The settings are left as they were if the configuration file cannot be read or is malformed
+// Settings read once at startup, such as the port or the job backend, are not affected until the application restarts
+// The environment of a process does not change once it is started, so settings are changed through the file
+func (s *Service) Reload() error {
+	values, err := load(s.environ())
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	s.values = values
+	listeners := append([]func(*Service){}, s.listeners...)
+	s.Unlock()
+
+	for _, listener := range listeners {
+		listener(s)
+	}
+	return nil
+}
+
+// OnReload registers a function called with the service after each reload
+func (s *Service) OnReload(listener func(*Service)) {
+	s.Lock()
+	defer s.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// Lookup returns the value of the given setting and whether it is set
+func (s *Service) Lookup(key string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Get returns the value of the given setting, empty if it is not set
+func (s *Service) Get(key string) string {
+	value, _ := s.Lookup(key)
+	return value
+}
+
+// String returns the value of the given setting, the given fallback if it is not set or empty
+func (s *Service) String(key string, fallback string) string {
+	if value := s.Get(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Int returns the value of the given setting as an integer, nil is returned if it is not set
+func (s *Service) Int(key string) (*int, error) {
+	value := s.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed %s, expected an integer: %s", key, value)
+	}
+	return &i, nil
+}
+
+// Bool returns the value of the given setting as a boolean, false if it is not set
+// The expected values are those of strconv.ParseBool, for example "true" or "0"
+func (s *Service) Bool(key string) (bool, error) {
+	value := s.Get(key)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("malformed %s, expected a boolean: %s", key, value)
+	}
+	return b, nil
+}
+
+// Duration returns the value of the given setting as a duration, nil is returned if it is not set
+// The expected format is a duration, for example "15m"
+func (s *Service) Duration(key string) (*time.Duration, error) {
+	value := s.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed %s, expected a duration: %s", key, value)
+	}
+	return &d, nil
+}
+
+// URL returns the value of the given setting as an absolute URL, nil is returned if it is not set
+func (s *Service) URL(key string) (*url.URL, error) {
+	value := s.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("malformed %s, expected an absolute URL: %s", key, value)
+	}
+	return u, nil
+}
+
+// List returns the value of the given setting as a comma separated list, without blank entries, nil is returned if it
+// is not set
+func (s *Service) List(key string) []string {
+	var list []string
+	for _, entry := range strings.Split(s.Get(key), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestServiceGetters tests the typed getters of the Service
+func TestServiceGetters(t *testing.T) {
+	service := New(func() []string {
+		return []string{"INT=42", "BAD_INT=many", "BOOL=1", "DURATION=15m", "URL=https://example.com/hook",
+			"RELATIVE_URL=hook", "LIST=a, ,b,", "EMPTY=", "EQUALS=a=b"}
+	})
+
+	if actual, err := service.Int("INT"); err != nil || *actual != 42 {
+		t.Errorf("unexpected int: %v, %v", actual, err)
+	}
+	if _, err := service.Int("BAD_INT"); err == nil {
+		t.Errorf("expected an error for a malformed int")
+	}
+	if actual, err := service.Int("UNSET"); err != nil || actual != nil {
+		t.Errorf("expected no int for an unset setting: %v, %v", actual, err)
+	}
+	if actual, err := service.Bool("BOOL"); err != nil || !actual {
+		t.Errorf("unexpected bool: %v, %v", actual, err)
+	}
+	if _, err := service.Bool("INT"); err == nil {
+		t.Errorf("expected an error for a malformed bool")
+	}
+	if actual, err := service.Duration("DURATION"); err != nil || *actual != 15*time.Minute {
+		t.Errorf("unexpected duration: %v, %v", actual, err)
+	}
+	if actual, err := service.URL("URL"); err != nil || actual.Host != "example.com" {
+		t.Errorf("unexpected url: %v, %v", actual, err)
+	}
+	if _, err := service.URL("RELATIVE_URL"); err == nil {
+		t.Errorf("expected an error for a relative url")
+	}
+	if actual := service.List("LIST"); fmt.Sprint(actual) != "[a b]" {
+		t.Errorf("unexpected list: %v", actual)
+	}
+	if actual := service.String("EMPTY", "fallback"); actual != "fallback" {
+		t.Errorf("expected the fallback for an empty setting, got %s", actual)
+	}
+	if _, ok := service.Lookup("EMPTY"); !ok {
+		t.Errorf("expected an empty setting to be set")
+	}
+	if actual := service.Get("EQUALS"); actual != "a=b" {
+		t.Errorf("expected the value to be split on the first =, got %s", actual)
+	}
+}
+
+// TestServiceReload tests that settings are read once until the Service is reloaded, and listeners are notified
+func TestServiceReload(t *testing.T) {
+	environ := []string{"TRACKING_REPOSITORY=rfcs"}
+	service := New(func() []string { return environ })
+	reloads := 0
+	service.OnReload(func(s *Service) {
+		if s != service {
+			t.Errorf("expected the listener to be given the reloaded service")
+		}
+		reloads++
+	})
+
+	environ = []string{"TRACKING_REPOSITORY=rfcs-sandbox"}
+	if actual, _ := service.TrackingRepo(); *actual != "rfcs" {
+		t.Errorf("expected the setting to be read once, got %s", *actual)
+	}
+
+	service.Reload()
+	if actual, _ := service.TrackingRepo(); *actual != "rfcs-sandbox" {
+		t.Errorf("expected the setting to be reloaded, got %s", *actual)
+	}
+	if reloads != 1 {
+		t.Errorf("expected the listener to be notified once, got %d", reloads)
+	}
+}
+
+// TestServiceReloadFile tests that the settings of the configuration file override those of the environment and are
+// read again on reload, an unreadable or malformed file leaving the settings as they were
+func TestServiceReloadFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "harmonia.env")
+	if err := os.WriteFile(file, []byte("# tracking\nTRACKING_REPOSITORY = rfcs\n\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service := New(func() []string { return []string{"CONFIG_FILE=" + file, "TRACKING_REPOSITORY=env-rfcs"} })
+	if actual, _ := service.TrackingRepo(); *actual != "rfcs" {
+		t.Errorf("expected the setting of the file to override the environment, got %s", *actual)
+	}
+
+	if err := os.WriteFile(file, []byte("TRACKING_REPOSITORY=rfcs-sandbox\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.Reload(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if actual, _ := service.TrackingRepo(); *actual != "rfcs-sandbox" {
+		t.Errorf("expected the setting of the file to be reloaded, got %s", *actual)
+	}
+
+	if err := os.WriteFile(file, []byte("TRACKING_REPOSITORY\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.Reload(); err == nil {
+		t.Errorf("expected a malformed file to fail the reload")
+	}
+	if err := os.Remove(file); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.Reload(); err == nil {
+		t.Errorf("expected a missing file to fail the reload")
+	}
+	if actual, _ := service.TrackingRepo(); *actual != "rfcs-sandbox" {
+		t.Errorf("expected the settings to be kept when the reload fails, got %s", *actual)
+	}
+}
@@ -0,0 +1,174 @@
+// Package description renders the descriptions of the pull requests of RFCs from their contents, so reviewers can
+// tell what an RFC changes from its pull request, without reading its JSON file
+package description
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+)
+
+// DEFAULT_TEMPLATE is the template pull requests are described with unless a deployment configures its own: a table
+// of the actions of the RFC followed by its metadata, in Markdown
+const DEFAULT_TEMPLATE = `Automated creation of RFC {{.Identifier}} PR
+{{- with .Title}}
+
+**{{.}}**
+{{- end}}
+
+| Action | Target type | Target | Lookup | Data |
+| --- | --- | --- | --- | --- |
+{{- range .Actions}}
+| {{.ActionType}} | {{.TargetType}} | {{.TargetDescriptor}} | {{.Lookup}} | {{.Data}} |
+{{- end}}
+{{- if or .Domain .Priority .EmbargoUntil .LoadTargets .LoadStatus}}
+{{with .Domain}}
+- Domain: {{.}}
+{{- end}}
+{{- with .Priority}}
+- Priority: {{.}}
+{{- end}}
+{{- with .EmbargoUntil}}
+- Embargoed until: {{.}}
+{{- end}}
+{{- with .LoadTargets}}
+- Load targets: {{join . ", "}}
+{{- end}}
+{{- with .LoadStatus}}
+- Load status: {{.}}
+{{- end}}
+{{- end}}
+`
+
+// MAX_LENGTH is the length descriptions are truncated to, below the limit of pull request descriptions of providers
+const MAX_LENGTH = 60000
+
+// Row is an action of an RFC as a row of a Markdown table, each cell escaped so it fits in its column
+type Row struct {
+	ActionType       string
+	TargetType       string
+	TargetDescriptor string
+	// Lookup is the lookup key and value of the target, e.g. "name=MyNewEvent", empty if it has none
+	Lookup string
+	// Data is the data of the action as compact JSON, empty if it has none
+	Data string
+}
+
+// Subject is the contents of an RFC its pull request description is rendered from
+type Subject struct {
+	Identifier string
+	Title      string
+	Domain     string
+	Priority   models.Priority
+	// EmbargoUntil is the end of the embargo of the RFC in RFC 3339, empty if it is not embargoed
+	EmbargoUntil string
+	LoadTargets  []string
+	// LoadStatus is the load status of the RFC, empty if it was never loaded
+	LoadStatus string
+	// Actions are the actions proposed by the RFC, comments, annotations and other volatile actions being left out
+	Actions []Row
+}
+
+// NewSubject returns the subject of the RFC of the given identifier
+func NewSubject(identifier string, rfc *models.RFC) Subject {
+	subject := Subject{
+		Identifier:  identifier,
+		Title:       Cell(strings.TrimSpace(rfc.Title)),
+		Domain:      rfc.Domain,
+		Priority:    rfc.Priority,
+		LoadTargets: rfc.LoadTargets,
+		Actions:     []Row{},
+	}
+	if rfc.EmbargoUntil != nil {
+		subject.EmbargoUntil = rfc.EmbargoUntil.UTC().Format(time.RFC3339)
+	}
+	if status := rfc.GetLoadStatus(); status != nil {
+		subject.LoadStatus = *status
+	}
+	for _, action := range rfc.Actions {
+		if action.IsVolatile() {
+			continue
+		}
+		row := Row{
+			ActionType:       Cell(string(action.ActionType)),
+			TargetType:       Cell(string(action.Target.TargetType)),
+			TargetDescriptor: Cell(action.Target.TargetDescriptor),
+		}
+		if action.Target.LookupKey != "" {
+			row.Lookup = Cell(fmt.Sprintf("%s=%s", action.Target.LookupKey, action.Target.LookupValue))
+		}
+		if len(action.Data) > 0 {
+			if data, err := json.Marshal(action.Data); err == nil {
+				row.Data = Cell(string(data))
+			}
+		}
+		subject.Actions = append(subject.Actions, row)
+	}
+
+	return subject
+}
+
+// Cell returns the given text escaped so it fits in a cell of a Markdown table
+func Cell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(text)
+}
+
+// Renderer renders the descriptions of pull requests with a Go template of their subject
+type Renderer struct {
+	template *template.Template
+}
+
+// NewRenderer returns the Renderer of the given Go template text, which can join lists with the join function
+func NewRenderer(text string) (*Renderer, error) {
+	parsed, err := template.New("description").Funcs(template.FuncMap{"join": strings.Join}).
+		Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("malformed pull request description template: %w", err)
+	}
+
+	return &Renderer{template: parsed}, nil
+}
+
+// Load returns the Renderer of the template of the given file
+func Load(file string) (*Renderer, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read pull request description template file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return NewRenderer(string(content))
+}
+
+// Render returns the description of the pull request of the RFC of the given identifier, truncated to MAX_LENGTH
+func (r *Renderer) Render(identifier string, rfc *models.RFC) (string, error) {
+	var rendered bytes.Buffer
+	if err := r.template.Execute(&rendered, NewSubject(identifier, rfc)); err != nil {
+		return "", err
+	}
+
+	description := rendered.String()
+	if len(description) > MAX_LENGTH {
+		description = strings.ToValidUTF8(description[:MAX_LENGTH], "") + "\n\n_Truncated, see the RFC file._"
+	}
+	return description, nil
+}
+
+// Default is the renderer the pull requests of RFCs are described with
+var Default = mustRenderer(DEFAULT_TEMPLATE)
+
+// mustRenderer returns the Renderer of the given built-in template text
+func mustRenderer(text string) *Renderer {
+	r, err := NewRenderer(text)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
@@ -0,0 +1,55 @@
+package description
+
+import (
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestRender(t *testing.T) {
+	// arrange
+	rfc := &models.RFC{
+		Title:       "Add EventType X",
+		Priority:    models.HighPriority,
+		LoadTargets: []string{"primary", "search"},
+		Actions: models.Actions{
+			{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget,
+				TargetDescriptor: "EventType", LookupKey: "name", LookupValue: "X"},
+				Data: map[string]interface{}{"note": "a|b"}},
+			{ActionType: models.CommentAction, Data: map[string]interface{}{"comment": "looks good"}},
+		},
+	}
+	expected := `Automated creation of RFC 1234 PR
+
+**Add EventType X**
+
+| Action | Target type | Target | Lookup | Data |
+| --- | --- | --- | --- | --- |
+| add | item | EventType | name=X | {"note":"a\|b"} |
+
+- Priority: high
+- Load targets: primary, search
+`
+
+	// act
+	actual, err := Default.Render("1234", rfc)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+	custom, err := NewRenderer(strings.Repeat("x", MAX_LENGTH+1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if truncated, _ := custom.Render("1234", rfc); !strings.HasSuffix(truncated, "see the RFC file._") {
+		t.Errorf("expected long descriptions to be truncated")
+	}
+	if _, err = NewRenderer(`{{.Identifier`); err == nil {
+		t.Errorf("expected a malformed template to be rejected")
+	}
+}
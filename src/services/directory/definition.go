@@ -0,0 +1,58 @@
+// Package directory maps Git logins to the people behind them (name, email, Slack handle...) so that other subsystems,
+// e.g. notifications, can address them outside of the Git provider
+// This is strictly to hold the Provider interface definition and common constants used in directory interactions
+package directory
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Common constants used across all Provider implementations
+const (
+	STATIC_PROVIDER string = "static"
+	SCIM_PROVIDER   string = "scim"
+	LDAP_PROVIDER   string = "ldap"
+	// DEFAULT_CACHE_TTL is how long directory entries may be served from cache unless configured otherwise
+	DEFAULT_CACHE_TTL = 15 * time.Minute
+)
+
+// ErrUserNotFound is returned (wrapped) when a login has no entry in the directory
+var ErrUserNotFound = errors.New("user not found in directory")
+
+// User is the directory entry of a single Git login, only the login is guaranteed to be set
+type User struct {
+	Login       string `json:"login" example:"tstark"`
+	Name        string `json:"name,omitempty" example:"Tony Stark"`
+	Email       string `json:"email,omitempty" example:"tony@starkindustries.com"`
+	SlackHandle string `json:"slackHandle,omitempty" example:"ironman"`
+}
+
+// Provider defines all methods necessary for resolving Git logins to directory entries
+// All directory types (static file, SCIM, LDAP...) should implement this interface
+type Provider interface {
+	// Name returns the name of the directory type
+	Name() string
+	// Lookup returns the directory entry of the given Git login
+	Lookup(ctx context.Context, login string) (*User, error)
+}
+
+// ProviderFunc adapts a lookup function to the Provider interface under the given name
+type ProviderFunc struct {
+	ProviderName string
+	LookupFunc   func(ctx context.Context, login string) (*User, error)
+}
+
+// Name returns the name the function was given
+func (p *ProviderFunc) Name() string {
+	return p.ProviderName
+}
+
+// Lookup calls the function
+func (p *ProviderFunc) Lookup(ctx context.Context, login string) (*User, error) {
+	return p.LookupFunc(ctx, login)
+}
+
+// Default is the directory shared by the application, nil if no directory is configured
+var Default Provider
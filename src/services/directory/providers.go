@@ -0,0 +1,187 @@
+// This holds the built-in implementations of the Provider interface found in definition.go
+package directory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/services/cache"
+	"harmonia-example.io/src/services/logging"
+)
+
+// how long a SCIM lookup may take before it is abandoned
+const scimTimeout = 10 * time.Second
+
+// Static type implements the Provider interface with a fixed set of entries, e.g. loaded from a file
+type Static struct {
+	users map[string]User
+}
+
+// NewStatic returns a Static directory holding the given entries
+func NewStatic(users []User) *Static {
+	s := &Static{users: map[string]User{}}
+	for _, user := range users {
+		s.users[user.Login] = user
+	}
+
+	return s
+}
+
+// LoadStatic returns a Static directory holding the entries of the given JSON file, a list of User objects
+func LoadStatic(path string) (*Static, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		logging.Default.Error("unable to read directory file", "file", path, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var users []User
+	if err = json.Unmarshal(content, &users); err != nil {
+		return nil, fmt.Errorf("malformed directory file %s: %w", path, err)
+	}
+
+	return NewStatic(users), nil
+}
+
+// Name returns the name of the static directory
+func (s *Static) Name() string {
+	return STATIC_PROVIDER
+}
+
+// Lookup returns the entry of the given login
+func (s *Static) Lookup(ctx context.Context, login string) (*User, error) {
+	user, ok := s.users[login]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, login)
+	}
+
+	return &user, nil
+}
+
+// SCIM type implements the Provider interface by querying the users of a SCIM 2.0 service, e.g. the identity provider
+// Logins are matched against the userName attribute, the Slack handle is read from nickName
+type SCIM struct {
+	URL    string
+	token  string
+	client *http.Client
+}
+
+// scimUsers is the SCIM list response to a users query
+type scimUsers struct {
+	Resources []struct {
+		UserName    string `json:"userName"`
+		DisplayName string `json:"displayName"`
+		NickName    string `json:"nickName"`
+		Emails      []struct {
+			Value   string `json:"value"`
+			Primary bool   `json:"primary"`
+		} `json:"emails"`
+	} `json:"Resources"`
+}
+
+// NewSCIM returns a SCIM directory querying the service at the given base URL with the given bearer token
+func NewSCIM(baseURL string, token string) *SCIM {
+	return &SCIM{URL: strings.TrimSuffix(baseURL, "/"), token: token, client: &http.Client{Timeout: scimTimeout}}
+}
+
+// Name returns the name of the SCIM directory
+func (s *SCIM) Name() string {
+	return SCIM_PROVIDER
+}
+
+// Lookup queries the SCIM service for the user whose userName is the given login
+func (s *SCIM) Lookup(ctx context.Context, login string) (*User, error) {
+	filter := url.QueryEscape(fmt.Sprintf(`userName eq "%s"`, strings.ReplaceAll(login, `"`, `\"`)))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/Users?filter=%s", s.URL, filter),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/scim+json")
+	request.Header.Set("Authorization", "Bearer "+s.token)
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		logging.FromContext(ctx).Error("SCIM directory lookup error", "login", login, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SCIM directory responded with status %d", response.StatusCode)
+	}
+
+	var users scimUsers
+	if err = json.NewDecoder(response.Body).Decode(&users); err != nil {
+		logging.FromContext(ctx).Error("json SCIM directory response unmarshal error", "login", login,
+			logging.ERROR_KEY, err)
+		return nil, err
+	}
+	if len(users.Resources) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, login)
+	}
+
+	resource := users.Resources[0]
+	user := &User{Login: login, Name: resource.DisplayName, SlackHandle: resource.NickName}
+	for _, email := range resource.Emails {
+		if user.Email == "" || email.Primary {
+			user.Email = email.Value
+		}
+	}
+
+	return user, nil
+}
+
+// LDAPPlaceholder returns a directory for the LDAP server at the given URL that only prints the logins it is asked for
+// and finds none of them. Replace it with a client of the LDAP server
+func LDAPPlaceholder(serverURL string) Provider {
+	lookup := func(ctx context.Context, login string) (*User, error) {
+		// search the LDAP server for the entry of the login
+		// ...
+		logging.FromContext(ctx).Info("looking up login in LDAP directory", "login", login, "server", serverURL)
+		// ...
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, login)
+	}
+
+	return &ProviderFunc{ProviderName: LDAP_PROVIDER, LookupFunc: lookup}
+}
+
+// Cached type implements the Provider interface by serving the entries of another directory from cache, logins that
+// are not found are cached too so unknown logins do not hit the directory on every lookup
+type Cached struct {
+	provider Provider
+	entries  *cache.Cache[string, *User]
+}
+
+// NewCached returns a directory serving the entries of the given directory from cache for the given time to live
+func NewCached(provider Provider, ttl time.Duration) *Cached {
+	return &Cached{provider: provider, entries: cache.NewNamed[string, *User]("directory", ttl)}
+}
+
+// Name returns the name of the cached directory
+func (c *Cached) Name() string {
+	return c.provider.Name()
+}
+
+// Lookup returns the entry of the given login, served from cache when possible
+func (c *Cached) Lookup(ctx context.Context, login string) (*User, error) {
+	user, ok := c.entries.Get(login)
+	if !ok {
+		var err error
+		if user, err = c.provider.Lookup(ctx, login); err != nil && !errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+		c.entries.Set(login, user)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, login)
+	}
+
+	return user, nil
+}
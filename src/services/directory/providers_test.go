@@ -0,0 +1,74 @@
+package directory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSCIMLookup(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("filter") != `userName eq "tstark"` {
+			w.Write([]byte(`{"Resources": []}`))
+			return
+		}
+		w.Write([]byte(`{"Resources": [{"userName": "tstark", "displayName": "Tony Stark", "nickName": "ironman",
+			"emails": [{"value": "tony@home.com"}, {"value": "tony@starkindustries.com", "primary": true}]}]}`))
+	}))
+	defer server.Close()
+	scim := NewSCIM(server.URL+"/", "token")
+
+	// act
+	user, err := scim.Lookup(context.Background(), "tstark")
+	_, unknownErr := scim.Lookup(context.Background(), "bbanner")
+	_, unauthorizedErr := NewSCIM(server.URL, "wrong").Lookup(context.Background(), "tstark")
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := User{Login: "tstark", Name: "Tony Stark", Email: "tony@starkindustries.com", SlackHandle: "ironman"}
+	if *user != expected {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if !errors.Is(unknownErr, ErrUserNotFound) {
+		t.Errorf("expected a user not found error, got %v", unknownErr)
+	}
+	if unauthorizedErr == nil || errors.Is(unauthorizedErr, ErrUserNotFound) {
+		t.Errorf("expected a lookup error, got %v", unauthorizedErr)
+	}
+}
+
+func TestCachedLookup(t *testing.T) {
+	// arrange
+	lookups := 0
+	lookup := func(ctx context.Context, login string) (*User, error) {
+		lookups++
+		return NewStatic([]User{{Login: "tstark"}}).Lookup(ctx, login)
+	}
+	cached := NewCached(&ProviderFunc{ProviderName: "counting", LookupFunc: lookup}, time.Minute)
+
+	// act
+	for i := 0; i < 2; i++ {
+		cached.Lookup(context.Background(), "tstark")
+		cached.Lookup(context.Background(), "bbanner")
+	}
+	user, err := cached.Lookup(context.Background(), "tstark")
+	_, unknownErr := cached.Lookup(context.Background(), "bbanner")
+
+	// assert
+	if err != nil || user.Login != "tstark" || !errors.Is(unknownErr, ErrUserNotFound) {
+		t.Errorf("unexpected lookups: %+v, %v, %v", user, err, unknownErr)
+	}
+	if lookups != 2 {
+		t.Errorf("expected each login to be looked up once, got %d lookups", lookups)
+	}
+}
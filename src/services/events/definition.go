@@ -0,0 +1,70 @@
+// Package events holds the in-process event bus used to broadcast RFC lifecycle events
+// This is strictly to hold the Bus interface definition and common constants used in event interactions
+package events
+
+import (
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/set"
+)
+
+// Common constants used across all Bus implementations
+const (
+	DEFAULT_HISTORY_SIZE int = 500
+)
+
+// Handler is a function that is invoked with every event published on a Bus it is subscribed to
+type Handler func(event models.Event)
+
+// Filter returns true if the given event should be included in a result set
+type Filter func(event models.Event) bool
+
+// Bus defines all methods necessary for publishing and consuming RFC lifecycle events
+type Bus interface {
+	// Publish broadcasts the given event to all subscribers and records it in the bus history
+	Publish(event models.Event)
+	// Subscribe registers the given handler for all future events, the returned function removes the subscription
+	Subscribe(handler Handler) func()
+	// Recent returns up to limit of the most recently published events that satisfy all given filters, newest first
+	// A limit of -1 returns all matching events in the history
+	Recent(limit int, filters ...Filter) []models.Event
+}
+
+// Default is the bus shared by the application
+var Default Bus = NewMemoryBus(DEFAULT_HISTORY_SIZE)
+
+// WithActor returns a Filter that matches events performed by the given actor. If no actor is given, returns true.
+func WithActor(actor *string) Filter {
+	return func(event models.Event) bool {
+		return actor == nil || *actor == event.Actor
+	}
+}
+
+// WithActorIn returns a Filter that matches events performed by any member of the given set. If no set is given,
+// returns true.
+func WithActorIn(actors set.Set[string]) Filter {
+	return func(event models.Event) bool {
+		return actors == nil || actors.Contains(event.Actor)
+	}
+}
+
+// WithTypes returns a Filter that matches events of any of the given types. If no types are given, returns true.
+func WithTypes(types []models.EventType) Filter {
+	return func(event models.Event) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, eventType := range types {
+			if eventType == event.Type {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WithRFCIdentifier returns a Filter that matches events about the given RFC. If no RFC is given, returns true.
+func WithRFCIdentifier(rfcIdentifier *string) Filter {
+	return func(event models.Event) bool {
+		return rfcIdentifier == nil || *rfcIdentifier == event.RFCIdentifier
+	}
+}
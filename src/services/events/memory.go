@@ -0,0 +1,116 @@
+// This is the in-memory implementation of the Bus interface found in definition.go
+package events
+
+import (
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+)
+
+// DEFAULT_SUBSCRIBER_BUFFER is the number of events queued for a subscriber that has not handled them yet
+const DEFAULT_SUBSCRIBER_BUFFER int = 256
+
+// MemoryBus type implements the Bus interface by keeping a bounded history of events in memory
+type MemoryBus struct {
+	mu          sync.RWMutex
+	history     []models.Event
+	next        int
+	full        bool
+	subscribers map[int]chan models.Event
+	nextID      int
+}
+
+// NewMemoryBus returns a MemoryBus that retains up to size of the most recent events
+func NewMemoryBus(size int) *MemoryBus {
+	if size <= 0 {
+		size = DEFAULT_HISTORY_SIZE
+	}
+
+	return &MemoryBus{
+		history:     make([]models.Event, size),
+		subscribers: map[int]chan models.Event{},
+	}
+}
+
+// Publish broadcasts the given event to all subscribers and records it in the bus history
+// Subscribers are notified asynchronously so that a slow consumer never blocks the publisher: events are queued for
+// each subscriber, which handles them in the order they were published. Events published while the queue of a
+// subscriber is full are dropped for that subscriber
+func (b *MemoryBus) Publish(event models.Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.history[b.next] = event
+	b.next = (b.next + 1) % len(b.history)
+	if b.next == 0 {
+		b.full = true
+	}
+	for _, queue := range b.subscribers {
+		select {
+		case queue <- event:
+		default:
+			logging.Default.Warn("event dropped for a slow subscriber", "event", event.Type,
+				logging.RFC_IDENTIFIER_KEY, event.RFCIdentifier)
+		}
+	}
+}
+
+// Subscribe registers the given handler for all future events, the returned function removes the subscription
+// The handler is called by a single goroutine, with one event at a time in the order they were published
+func (b *MemoryBus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	queue := make(chan models.Event, DEFAULT_SUBSCRIBER_BUFFER)
+	b.subscribers[id] = queue
+	go func() {
+		for event := range queue {
+			handler(event)
+		}
+	}()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// the queue is only closed once, events already queued are still handled
+		if _, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(queue)
+		}
+	}
+}
+
+// Recent returns up to limit of the most recently published events that satisfy all given filters, newest first
+// A limit of -1 returns all matching events in the history
+func (b *MemoryBus) Recent(limit int, filters ...Filter) []models.Event {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	size := b.next
+	if b.full {
+		size = len(b.history)
+	}
+
+	events := []models.Event{}
+	for i := 1; i <= size && (limit == -1 || len(events) < limit); i++ {
+		// walk backwards from the most recently written slot, wrapping around the ring
+		event := b.history[(b.next-i+len(b.history))%len(b.history)]
+
+		isValid := true
+		for _, filter := range filters {
+			isValid = isValid && filter(event)
+		}
+		if isValid {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/set"
+)
+
+// publishN publishes n submit events on the given bus, alternating actors between "tstark" and "bbanner"
+func publishN(bus Bus, n int) {
+	actors := []string{"tstark", "bbanner"}
+	for i := 0; i < n; i++ {
+		bus.Publish(models.Event{
+			Type:          models.SubmitEvent,
+			RFCIdentifier: fmt.Sprint(i),
+			Actor:         actors[i%len(actors)],
+		})
+	}
+}
+
+func TestMemoryBusRecentOrder(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(10)
+	publishN(bus, 3)
+
+	// act
+	recent := bus.Recent(-1)
+
+	// assert
+	if len(recent) != 3 {
+		t.Fatalf("unexpected length. wanted %v, got %v", 3, len(recent))
+	}
+	for i, expected := range []string{"2", "1", "0"} {
+		if recent[i].RFCIdentifier != expected {
+			t.Errorf("unexpected order. wanted %v at %v, got %v", expected, i, recent[i].RFCIdentifier)
+		}
+	}
+	if recent[0].Timestamp.IsZero() {
+		t.Errorf("expected timestamp to be populated on publish")
+	}
+}
+
+func TestMemoryBusRecentWraps(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(3)
+	publishN(bus, 5)
+
+	// act
+	recent := bus.Recent(-1)
+
+	// assert
+	if len(recent) != 3 {
+		t.Fatalf("unexpected length. wanted %v, got %v", 3, len(recent))
+	}
+	for i, expected := range []string{"4", "3", "2"} {
+		if recent[i].RFCIdentifier != expected {
+			t.Errorf("unexpected order. wanted %v at %v, got %v", expected, i, recent[i].RFCIdentifier)
+		}
+	}
+}
+
+func TestMemoryBusRecentFilters(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(10)
+	publishN(bus, 6)
+	actor := "tstark"
+	rfcIdentifier := "4"
+
+	testCases := []struct {
+		limit    int
+		filters  []Filter
+		expected int
+	}{
+		{limit: -1, filters: nil, expected: 6},
+		{limit: 2, filters: nil, expected: 2},
+		{limit: -1, filters: []Filter{WithActor(&actor)}, expected: 3},
+		{limit: -1, filters: []Filter{WithActorIn(set.NewSetOf("bbanner"))}, expected: 3},
+		{limit: -1, filters: []Filter{WithActorIn(set.NewSet[string]())}, expected: 0},
+		{limit: -1, filters: []Filter{WithTypes([]models.EventType{models.MergeEvent})}, expected: 0},
+		{limit: 1, filters: []Filter{WithTypes([]models.EventType{models.SubmitEvent})}, expected: 1},
+		{limit: -1, filters: []Filter{WithRFCIdentifier(&rfcIdentifier)}, expected: 1},
+		{limit: -1, filters: []Filter{WithRFCIdentifier(nil)}, expected: 6},
+	}
+
+	for _, test := range testCases {
+		// act
+		actual := bus.Recent(test.limit, test.filters...)
+
+		// assert
+		if len(actual) != test.expected {
+			t.Errorf("unexpected length. wanted %v, got %v", test.expected, len(actual))
+		}
+	}
+}
+
+func TestMemoryBusSubscribe(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(10)
+	received := make(chan models.Event, 1)
+	unsubscribe := bus.Subscribe(func(event models.Event) { received <- event })
+
+	// act
+	publishN(bus, 1)
+
+	// assert
+	select {
+	case event := <-received:
+		if event.RFCIdentifier != "0" {
+			t.Errorf("unexpected event. wanted %v, got %v", "0", event.RFCIdentifier)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("subscriber was not notified of published event")
+	}
+
+	// act
+	unsubscribe()
+	publishN(bus, 1)
+
+	// assert
+	select {
+	case event := <-received:
+		t.Errorf("unsubscribed handler received event %v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMemoryBusSubscribeOrder(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(10)
+	n := 100
+	received := make(chan models.Event, n)
+	unsubscribe := bus.Subscribe(func(event models.Event) { received <- event })
+	defer unsubscribe()
+
+	// act
+	publishN(bus, n)
+
+	// assert
+	for i := 0; i < n; i++ {
+		select {
+		case event := <-received:
+			if event.RFCIdentifier != fmt.Sprint(i) {
+				t.Fatalf("unexpected event order. wanted %v, got %v", i, event.RFCIdentifier)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber was not notified of published event %d", i)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// This holds the streaming of events published on a Bus to a single consumer, e.g. a Server-Sent Events client
+package events
+
+import (
+	"context"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// DEFAULT_STREAM_BUFFER is the number of events a stream holds for a consumer that has not received them yet
+const DEFAULT_STREAM_BUFFER int = 64
+
+// Stream subscribes to the given bus and returns a channel receiving every event published from then on that
+// satisfies all given filters. The subscription ends, and the channel is closed, when the given context is done
+// Events published while the buffer of the channel is full are dropped so a slow consumer never holds up the bus
+func Stream(ctx context.Context, bus Bus, buffer int, filters ...Filter) <-chan models.Event {
+	if buffer <= 0 {
+		buffer = DEFAULT_STREAM_BUFFER
+	}
+
+	stream := make(chan models.Event, buffer)
+	// handlers are run asynchronously and may still be running once the subscription ends, so sending and closing
+	// are guarded
+	var mu sync.Mutex
+	closed := false
+	unsubscribe := bus.Subscribe(func(event models.Event) {
+		for _, filter := range filters {
+			if !filter(event) {
+				return
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case stream <- event:
+		default:
+		}
+	})
+
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		close(stream)
+	}()
+
+	return stream
+}
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestStream(t *testing.T) {
+	// arrange
+	bus := NewMemoryBus(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	rfcIdentifier := "1"
+	stream := Stream(ctx, bus, 1, WithRFCIdentifier(&rfcIdentifier))
+
+	// act
+	publishN(bus, 3)
+
+	// assert
+	select {
+	case event := <-stream:
+		if event.RFCIdentifier != rfcIdentifier || event.Type != models.SubmitEvent {
+			t.Errorf("unexpected event. wanted %v, got %+v", rfcIdentifier, event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("stream did not receive the published event")
+	}
+
+	// act
+	cancel()
+
+	// assert
+	select {
+	case event, ok := <-stream:
+		if ok {
+			t.Errorf("unexpected event after the stream ended: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("stream was not closed once its context was done")
+	}
+	publishN(bus, 3)
+}
@@ -0,0 +1,1494 @@
+// This is the Bitbucket Cloud implementation of the Git interface found in definition.go
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/description"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// Bitbucket Cloud API locations and values
+const (
+	BITBUCKET_API_URL        string = "https://api.bitbucket.org/2.0"
+	BITBUCKET_GROUPS_API_URL string = "https://api.bitbucket.org/1.0"
+	BITBUCKET_PAGE_LENGTH    int    = 50
+	BITBUCKET_CLIENT_TIMEOUT int    = 30
+)
+
+// Bitbucket pull request and participant states
+const (
+	bitbucketOpenState             = "OPEN"
+	bitbucketMergedState           = "MERGED"
+	bitbucketDeclinedState         = "DECLINED"
+	bitbucketSupersededState       = "SUPERSEDED"
+	bitbucketApprovedState         = "approved"
+	bitbucketChangesRequestedState = "changes_requested"
+	bitbucketReviewerRole          = "REVIEWER"
+	bitbucketStatusInProgress      = "INPROGRESS"
+	bitbucketStatusSuccessful      = "SUCCESSFUL"
+)
+
+// bitbucketConflictStatuses are the diffstat statuses of files that cannot be merged cleanly
+var bitbucketConflictStatuses = set.NewSetOf("merge conflict", "local deleted", "remote deleted")
+
+// Bitbucket type implements the Git interface for Bitbucket Cloud, the tracking repository lives in the owner workspace
+// The access token is sent as a bearer token (workspace, project or repository access tokens), or with basic
+// authentication if it is given as username:app-password
+type Bitbucket struct {
+	AccessToken        *string
+	client             *http.Client
+	apiURL             string
+	groupsAPIURL       string
+	owner              string
+	trackingRepository *string
+	requiredContexts   []string
+}
+
+// BitbucketUser is a Bitbucket account, its nickname is used as its login
+type BitbucketUser struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+// BitbucketParticipant is a user taking part in a pull request, Bitbucket has no review objects so participants that
+// approved, requested changes or commented stand for the pull request reviews
+type BitbucketParticipant struct {
+	User           BitbucketUser `json:"user"`
+	Role           string        `json:"role"`
+	Approved       bool          `json:"approved"`
+	State          *string       `json:"state"`
+	ParticipatedOn *time.Time    `json:"participated_on"`
+}
+
+// BitbucketPullRequest is a Bitbucket Cloud pull request
+type BitbucketPullRequest struct {
+	ID           int                    `json:"id"`
+	Title        string                 `json:"title"`
+	State        string                 `json:"state"`
+	Author       BitbucketUser          `json:"author"`
+	Source       bitbucketEndpoint      `json:"source"`
+	Destination  bitbucketEndpoint      `json:"destination"`
+	MergeCommit  *bitbucketCommit       `json:"merge_commit"`
+	Reviewers    []BitbucketUser        `json:"reviewers"`
+	Participants []BitbucketParticipant `json:"participants"`
+	CreatedOn    time.Time              `json:"created_on"`
+	UpdatedOn    time.Time              `json:"updated_on"`
+	Links        struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// bitbucketEndpoint is the source or destination of a pull request
+type bitbucketEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit *bitbucketCommit `json:"commit,omitempty"`
+}
+
+// bitbucketCommit is a Bitbucket commit, only the hash is set unless more fields are requested
+type bitbucketCommit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+	Author  struct {
+		Raw  string         `json:"raw"`
+		User *BitbucketUser `json:"user"`
+	} `json:"author"`
+}
+
+// bitbucketRef is a branch or tag
+type bitbucketRef struct {
+	Name   string          `json:"name"`
+	Target bitbucketCommit `json:"target"`
+}
+
+// bitbucketFileHistoryEntry is a single commit that modified a file
+type bitbucketFileHistoryEntry struct {
+	Commit bitbucketCommit `json:"commit"`
+}
+
+// bitbucketStatus is a build status of a pull request, or the diffstat status of a file it changes
+type bitbucketStatus struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	Status string `json:"status"`
+}
+
+// bitbucketComment is a pull request comment, inline comments are attached to a file
+type bitbucketComment struct {
+	ID      int           `json:"id"`
+	User    BitbucketUser `json:"user"`
+	Deleted bool          `json:"deleted"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline *struct {
+		Path string `json:"path"`
+	} `json:"inline"`
+}
+
+// bitbucketPage is a single page of a paginated Bitbucket listing
+type bitbucketPage[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// bitbucketGroup is a workspace group, Bitbucket's equivalent of a team
+type bitbucketGroup struct {
+	Slug    string          `json:"slug"`
+	Members []BitbucketUser `json:"members"`
+}
+
+// bitbucketError is an unsuccessful Bitbucket API response
+type bitbucketError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the status and message of the response
+func (e *bitbucketError) Error() string {
+	return fmt.Sprintf("Bitbucket API responded %d: %s", e.StatusCode, e.Message)
+}
+
+// NewBitbucket returns a Bitbucket Git implementation of the given tracking repository, owned by a workspace
+func NewBitbucket(ctx context.Context, accessToken string, repository Repository) (*Bitbucket, error) {
+	b := &Bitbucket{
+		AccessToken:        &accessToken,
+		client:             &http.Client{Timeout: time.Duration(BITBUCKET_CLIENT_TIMEOUT) * time.Second},
+		apiURL:             BITBUCKET_API_URL,
+		groupsAPIURL:       BITBUCKET_GROUPS_API_URL,
+		owner:              repository.Owner,
+		trackingRepository: &repository.Name,
+	}
+	b.requiredContexts = configuration().RequiredStatusContexts()
+
+	return b, nil
+}
+
+// Repository returns the tracking repository the client operates on
+func (b *Bitbucket) Repository() Repository {
+	return Repository{Owner: b.owner, Name: *b.trackingRepository}
+}
+
+// Capabilities returns the features Bitbucket supports: pull requests have no labels, reviews are requested from
+// users only, builds are the only status checks, branch restrictions do not name the builds required to pass,
+// deployments are driven by Pipelines and Bitbucket webhooks are not received
+func (b *Bitbucket) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		Provider:          BITBUCKET_PROVIDER,
+		RequiredApprovals: true,
+	}
+}
+
+// repositoryURL returns the API URL of the given path within the tracking repository
+func (b *Bitbucket) repositoryURL(path string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s%s", b.apiURL, url.PathEscape(b.owner),
+		url.PathEscape(*b.trackingRepository), path)
+}
+
+// pullRequestURL returns the API URL of the given path within the given pull request
+func (b *Bitbucket) pullRequestURL(pr *BitbucketPullRequest, path string) string {
+	return b.repositoryURL(fmt.Sprintf("/pullrequests/%d%s", pr.ID, path))
+}
+
+// do sends a request to the given Bitbucket API URL and decodes the JSON response into out, or reads it raw if out is
+// a *[]byte. Unsuccessful responses are returned as a *bitbucketError mapped to the provider agnostic error
+func (b *Bitbucket) do(ctx context.Context, method string, endpoint string, body io.Reader, contentType string,
+	out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	// without a token requests are anonymous, which can only read public repositories
+	if username, password, ok := strings.Cut(*b.AccessToken, ":"); ok {
+		req.SetBasicAuth(username, password)
+	} else if *b.AccessToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *b.AccessToken))
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		responseErr := &bitbucketError{StatusCode: res.StatusCode, Message: http.StatusText(res.StatusCode)}
+		var errBody struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(res.Body).Decode(&errBody) == nil && errBody.Error.Message != "" {
+			responseErr.Message = errBody.Error.Message
+		}
+		return mapBitbucketError(responseErr)
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out, err = io.ReadAll(res.Body)
+		return err
+	default:
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+}
+
+// doJSON sends the given value as JSON to the given Bitbucket API URL and decodes the JSON response into out
+func (b *Bitbucket) doJSON(ctx context.Context, method string, endpoint string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		jsonBytes, err := json.Marshal(in)
+		if err != nil {
+			logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
+			return err
+		}
+		body = bytes.NewReader(jsonBytes)
+	}
+
+	return b.do(ctx, method, endpoint, body, "application/json", out)
+}
+
+// listAll follows the pagination of the given Bitbucket API URL and returns the values of every page
+func listAll[T any](ctx context.Context, b *Bitbucket, endpoint string) ([]T, error) {
+	var values []T
+	for endpoint != "" {
+		var page bitbucketPage[T]
+		if err := b.do(ctx, http.MethodGet, endpoint, nil, "", &page); err != nil {
+			return nil, err
+		}
+		values = append(values, page.Values...)
+		endpoint = page.Next
+	}
+
+	return values, nil
+}
+
+// mapBitbucketError maps the given Bitbucket API error to the provider agnostic error it corresponds to, keeping it
+// wrapped in a *ProviderError. Errors that do not correspond to one are wrapped in a *ProviderError without a kind
+func mapBitbucketError(err *bitbucketError) error {
+	switch {
+	case err.StatusCode == http.StatusNotFound:
+		return &ProviderError{Kind: ErrNotFound, Err: err}
+	case err.StatusCode == http.StatusConflict:
+		return &ProviderError{Kind: ErrConflict, Err: err}
+	case err.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(err.Message), "already exists"):
+		// Bitbucket responds with a 400 to the creation of branches and tags that already exist
+		return &ProviderError{Kind: ErrConflict, Err: err}
+	case err.StatusCode == http.StatusUnauthorized || err.StatusCode == http.StatusForbidden:
+		return &ProviderError{Kind: ErrPermission, Err: err}
+	case err.StatusCode == http.StatusTooManyRequests:
+		return &ProviderError{Kind: ErrRateLimited, Err: err}
+	}
+
+	return &ProviderError{Err: err}
+}
+
+// asBitbucketPullRequest asserts the given pull request is a Bitbucket pull request
+func asBitbucketPullRequest(ctx context.Context, pr PullRequest) (*BitbucketPullRequest, error) {
+	bitbucketPr, ok := pr.(*BitbucketPullRequest)
+	if !ok {
+		errStr := "given pull request is not of type BitbucketPullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return nil, fmt.Errorf(errStr)
+	}
+
+	return bitbucketPr, nil
+}
+
+// CreateBranch creates a new branch with the given name from the given base branch
+func (b *Bitbucket) CreateBranch(ctx context.Context, branch string, baseBranch string) error {
+	// get a reference to the base branch
+	var base bitbucketRef
+	if err := b.do(ctx, http.MethodGet, b.repositoryURL("/refs/branches/"+url.PathEscape(baseBranch)), nil, "",
+		&base); err != nil {
+		logging.FromContext(ctx).Error("error retrieving base branch", logging.ERROR_KEY, err)
+		return err
+	}
+
+	// create branch with the given name
+	if err := b.doJSON(ctx, http.MethodPost, b.repositoryURL("/refs/branches"), &bitbucketRef{
+		Name:   branch,
+		Target: bitbucketCommit{Hash: base.Target.Hash},
+	}, nil); err != nil {
+		logging.FromContext(ctx).Error("error creating new branch", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBranch deletes the branch with the given name
+func (b *Bitbucket) DeleteBranch(ctx context.Context, branch string) error {
+	if err := b.do(ctx, http.MethodDelete, b.repositoryURL("/refs/branches/"+url.PathEscape(branch)), nil, "",
+		nil); err != nil {
+		logging.FromContext(ctx).Error("unable to automatically delete branch, please delete manually", "branch", branch,
+			logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// commitFile commits the given content as the RFC file in the given directory of the given branch
+// Bitbucket does not require the sha of the file being replaced, so files are created and updated alike
+func (b *Bitbucket) commitFile(ctx context.Context, branch string, directory string, content []byte,
+	message string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, directory, RFC_FILE_NAME)
+	if err := writer.WriteField("message", message); err != nil {
+		return err
+	}
+	if err := writer.WriteField("branch", branch); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile(path, RFC_FILE_NAME)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(content); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return b.do(ctx, http.MethodPost, b.repositoryURL("/src"), &body, writer.FormDataContentType(), nil)
+}
+
+// CreateFile creates an RFC file on the given branch in the given directory using the given data
+func (b *Bitbucket) CreateFile(ctx context.Context, branch string, directory string, data *models.RFC) error {
+	// transform data to bytes, which API accepts
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	if err = b.commitFile(ctx, branch, directory, jsonBytes, "init."); err != nil {
+		logging.FromContext(ctx).Error("Bitbucket file creation error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreatePullRequest opens a new pull request of the given branch towards the given base branch with the given options
+// Bitbucket pull requests have neither labels nor assignees, so those are ignored, and teams are expanded to their
+// members as in RequestTeamReviewers
+func (b *Bitbucket) CreatePullRequest(ctx context.Context, branch string, baseBranch string,
+	options PullRequestOptions) error {
+	body := options.Description
+	if body == "" {
+		body = fmt.Sprintf("Automated creation of RFC %s PR", branch)
+	}
+	pr := map[string]interface{}{
+		"title":       options.Title,
+		"description": body,
+		"source":      map[string]interface{}{"branch": map[string]string{"name": branch}},
+		"destination": map[string]interface{}{"branch": map[string]string{"name": baseBranch}},
+	}
+	created := &BitbucketPullRequest{}
+	if err := b.doJSON(ctx, http.MethodPost, b.repositoryURL("/pullrequests"), pr, created); err != nil {
+		logging.FromContext(ctx).Error("Bitbucket PR creation error for branch", "branch", branch, logging.ERROR_KEY, err)
+		return err
+	}
+
+	// the pull request is open at this point, failing to request its reviewers is not worth failing its creation
+	// Reviewers are replaced as a whole, so the members of the teams are requested along with the reviewers at once
+	reviewers := set.NewSetOf(options.Reviewers...)
+	for _, team := range options.TeamReviewers {
+		members, err := b.GetTeamMembers(ctx, team)
+		if err != nil {
+			logging.FromContext(ctx).Warn("unable to retrieve team reviewers of new PR", "branch", branch, "team", team,
+				logging.ERROR_KEY, err)
+			continue
+		}
+		reviewers.Add(members.Values()...)
+	}
+	reviewers.Delete(created.Author.Nickname)
+	if reviewers.Size() > 0 {
+		logins := reviewers.Values()
+		sort.Strings(logins)
+		if err := b.RequestReviewers(ctx, created, logins); err != nil {
+			logging.FromContext(ctx).Warn("unable to request reviewers of new PR", "branch", branch,
+				logging.ERROR_KEY, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRFCContents returns the current contents of the RFC on the given branch
+// The hash of the commit that last modified the file is returned in place of a file sha, which Bitbucket does not have
+func (b *Bitbucket) GetRFCContents(ctx context.Context, branch string) (*string, *string, error) {
+	return b.getRFCContents(ctx, branch, branch)
+}
+
+// GetRFCContentsAt returns the contents of the RFC of the given branch as of the given commit sha
+func (b *Bitbucket) GetRFCContentsAt(ctx context.Context, branch string, ref string) (*string, error) {
+	content, _, err := b.getRFCContents(ctx, branch, ref)
+	return content, err
+}
+
+// getRFCContents returns the contents of the RFC file of the given branch at the given ref, along with the hash of
+// the commit that last modified it. ErrRFCFileNotFound is returned (wrapped) if the file does not exist at the ref
+func (b *Bitbucket) getRFCContents(ctx context.Context, branch string, ref string) (*string, *string, error) {
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, branch, RFC_FILE_NAME)
+	endpoint := b.repositoryURL(fmt.Sprintf("/src/%s/%s", url.PathEscape(ref), path))
+	notFound := &ProviderError{Kind: ErrNotFound, Err: fmt.Errorf("%w: %s at %s", ErrRFCFileNotFound, path, ref)}
+
+	// retrieve file metadata, which also tells files and directories apart
+	var meta struct {
+		Type   string          `json:"type"`
+		Commit bitbucketCommit `json:"commit"`
+	}
+	if err := b.do(ctx, http.MethodGet, endpoint+"?format=meta", nil, "", &meta); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve repository content", logging.ERROR_KEY, err)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, notFound
+		}
+		return nil, nil, err
+	}
+
+	// the path resolves to a directory rather than a file
+	if meta.Type != "commit_file" {
+		logging.FromContext(ctx).Error("RFC path is not a file")
+		return nil, nil, notFound
+	}
+
+	// retrieve raw file contents
+	var raw []byte
+	if err := b.do(ctx, http.MethodGet, endpoint, nil, "", &raw); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve repository content", logging.ERROR_KEY, err)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil, notFound
+		}
+		return nil, nil, err
+	}
+	content := string(raw)
+
+	return &content, &meta.Commit.Hash, nil
+}
+
+// GetRFCHistory returns the commits that modified the RFC file of the given branch, newest first. Paginated output
+func (b *Bitbucket) GetRFCHistory(ctx context.Context, branch string) ([]RFCRevision, error) {
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, branch, RFC_FILE_NAME)
+	query := url.Values{}
+	query.Set("pagelen", fmt.Sprint(BITBUCKET_PAGE_LENGTH))
+	query.Set("fields", "next,values.commit.hash,values.commit.date,values.commit.message,values.commit.author")
+
+	entries, err := listAll[bitbucketFileHistoryEntry](ctx, b, b.repositoryURL(fmt.Sprintf("/filehistory/%s/%s?%s",
+		url.PathEscape(branch), path, query.Encode())))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list RFC file commits", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	revisions := make([]RFCRevision, 0, len(entries))
+	for _, entry := range entries {
+		revision := RFCRevision{
+			Sha:       entry.Commit.Hash,
+			Author:    entry.Commit.Author.Raw,
+			Message:   entry.Commit.Message,
+			Timestamp: entry.Commit.Date,
+		}
+		if entry.Commit.Author.User != nil {
+			revision.Author = entry.Commit.Author.User.Nickname
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// UpdateFile creates a commit to the RFC file of the given PR using the given data
+func (b *Bitbucket) UpdateFile(ctx context.Context, pr PullRequest, data *models.RFC) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	// transform data to bytes, which API accepts
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	branch := bitbucketPr.Source.Branch.Name
+	if err = b.commitFile(ctx, branch, branch, jsonBytes, "update."); err != nil {
+		logging.FromContext(ctx).Error("Bitbucket update file error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	// the file is committed at this point, failing to refresh the description is not worth failing the update
+	body, err := description.Default.Render(branch, data)
+	if err != nil {
+		logging.FromContext(ctx).Warn("unable to render PR description", logging.ERROR_KEY, err)
+		return nil
+	}
+	// reviewers are replaced as a whole when a pull request is updated, so existing reviewers are kept
+	reviewers := []map[string]string{}
+	for _, reviewer := range bitbucketPr.Reviewers {
+		reviewers = append(reviewers, map[string]string{"uuid": reviewer.UUID})
+	}
+	if err = b.doJSON(ctx, http.MethodPut, b.pullRequestURL(bitbucketPr, ""), map[string]interface{}{
+		"title":       bitbucketPr.Title,
+		"description": body,
+		"reviewers":   reviewers,
+	}, nil); err != nil {
+		logging.FromContext(ctx).Warn("unable to update PR description", logging.ERROR_KEY, err)
+	}
+
+	return nil
+}
+
+// RestoreFile commits the given raw content as the RFC file of the given PR, recreating the file if it was deleted
+func (b *Bitbucket) RestoreFile(ctx context.Context, pr PullRequest, content string, message string) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	branch := bitbucketPr.Source.Branch.Name
+	if err = b.commitFile(ctx, branch, branch, []byte(content), message); err != nil {
+		logging.FromContext(ctx).Error("Bitbucket restore file error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// bitbucketStates returns the Bitbucket pull request states matching the given provider agnostic state
+func bitbucketStates(state string) []string {
+	switch state {
+	case OPEN_STATE:
+		return []string{bitbucketOpenState}
+	case CLOSED_STATE:
+		return []string{bitbucketMergedState, bitbucketDeclinedState, bitbucketSupersededState}
+	default:
+		return []string{bitbucketOpenState, bitbucketMergedState, bitbucketDeclinedState, bitbucketSupersededState}
+	}
+}
+
+// pullRequestsURL returns the API URL listing the pull requests with the given state that match the given query
+// Reviewers and participants are not listed by default, so they are requested explicitly
+func (b *Bitbucket) pullRequestsURL(state string, q string, pageLength int) string {
+	query := url.Values{}
+	for _, s := range bitbucketStates(state) {
+		query.Add("state", s)
+	}
+	if q != "" {
+		query.Set("q", q)
+	}
+	query.Set("pagelen", fmt.Sprint(pageLength))
+	query.Set("fields", "+values.reviewers,+values.participants")
+
+	return b.repositoryURL("/pullrequests?" + query.Encode())
+}
+
+// GetPullRequest returns the corresponding pull request for the given branch
+func (b *Bitbucket) GetPullRequest(ctx context.Context, branch string) (PullRequest, error) {
+	q := fmt.Sprintf("source.branch.name = %q", branch)
+	prs, err := listAll[*BitbucketPullRequest](ctx, b, b.pullRequestsURL(ALL_PR_FILTER, q, BITBUCKET_PAGE_LENGTH))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to fetch PRs", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	// assert we only got 1 PR back
+	if len(prs) != 1 {
+		errStr := "exactly one PR was NOT returned"
+		logging.FromContext(ctx).Error(errStr)
+		return nil, fmt.Errorf(errStr)
+	}
+
+	return prs[0], nil
+}
+
+// GetPullRequests returns all pull requests with the given state. Paginated output
+func (b *Bitbucket) GetPullRequests(ctx context.Context, state string, count int, opts ...FilterOption) (
+	PullRequests, error) {
+	var prs PullRequests
+
+	pageLength := BITBUCKET_PAGE_LENGTH
+	if count != -1 && count < pageLength {
+		pageLength = count
+	}
+
+	// retrieve PRs, looping until results are exhausted if count is -1
+	endpoint := b.pullRequestsURL(state, "", pageLength)
+	for endpoint != "" && (len(prs) < count || count == -1) {
+		var page bitbucketPage[*BitbucketPullRequest]
+		if err := b.do(ctx, http.MethodGet, endpoint, nil, "", &page); err != nil {
+			logging.FromContext(ctx).Error("unable to fetch PRs", logging.ERROR_KEY, err)
+			return nil, err
+		}
+
+		// filter
+		for _, result := range page.Values {
+			isValid := true
+			for _, opt := range opts {
+				isValid = isValid && opt(result)
+			}
+			if isValid && (len(prs) < count || count == -1) {
+				prs = append(prs, result)
+			}
+		}
+
+		endpoint = page.Next
+	}
+
+	return prs, nil
+}
+
+// GetMergeability determines if the given pull request is mergeable (approvals, conflicts, ci...)
+func (b *Bitbucket) GetMergeability(ctx context.Context, pr PullRequest) (*bool, error) {
+	mergeability, err := b.ExplainMergeability(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeability.Mergeable, nil
+}
+
+// ExplainMergeability determines if the given pull request is mergeable, along with why it is not and the state of
+// the build statuses considered. Bitbucket Cloud does not compute an overall mergeable state, so the pull request must
+// be open, have every considered build status successful, at least one approval, no change requests and no
+// conflicting files. Build statuses are identified by their name, or their key if they have none
+func (b *Bitbucket) ExplainMergeability(ctx context.Context, pr PullRequest) (*models.Mergeability, error) {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// poll for build statuses and allow time for them to complete, within reason
+	// any pending status is re-polled after a backoff, or as soon as a webhook announces a change
+	var contexts map[string]string
+	if err = Polling.poll(ctx, bitbucketPr.Source.Branch.Name, func() (bool, error) {
+		statuses, err := listAll[bitbucketStatus](ctx, b, b.pullRequestURL(bitbucketPr, "/statuses"))
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve pull request statuses", logging.ERROR_KEY, err)
+			return false, err
+		}
+
+		contexts = map[string]string{}
+		for _, status := range statuses {
+			name := status.Name
+			if name == "" {
+				name = status.Key
+			}
+			switch status.State {
+			case bitbucketStatusSuccessful:
+				setContextState(contexts, name, CONTEXT_SUCCESS_STATE)
+			case bitbucketStatusInProgress:
+				setContextState(contexts, name, CONTEXT_PENDING_STATE)
+			default:
+				setContextState(contexts, name, CONTEXT_FAILURE_STATE)
+			}
+		}
+		contexts = consideredContexts(b.requiredContexts, contexts)
+
+		return !contextsPending(contexts), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// refetch the pull request for up to date participants
+	var reasons []string
+	var current BitbucketPullRequest
+	if err = b.do(ctx, http.MethodGet, b.pullRequestURL(bitbucketPr, ""), nil, "", &current); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve pr for mergeability check", logging.ERROR_KEY, err)
+		return nil, err
+	}
+	if current.State != bitbucketOpenState {
+		reasons = append(reasons, fmt.Sprintf("the pull request is %s", strings.ToLower(current.State)))
+	}
+	approved := false
+	for _, participant := range current.Participants {
+		approved = approved || participant.Approved
+		if participant.State != nil && *participant.State == bitbucketChangesRequestedState {
+			reasons = append(reasons, fmt.Sprintf("%s requested changes", participant.User.Nickname))
+		}
+	}
+	if !approved {
+		reasons = append(reasons, "the pull request is not approved")
+	}
+
+	// conflicting files are reported in the diffstat
+	diffstat, err := listAll[bitbucketStatus](ctx, b, b.pullRequestURL(bitbucketPr, "/diffstat"))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve pull request diffstat", logging.ERROR_KEY, err)
+		return nil, err
+	}
+	for _, file := range diffstat {
+		if bitbucketConflictStatuses.Contains(file.Status) {
+			reasons = append(reasons, "the pull request has merge conflicts")
+			break
+		}
+	}
+
+	return newMergeability(b.requiredContexts, contexts, reasons...), nil
+}
+
+// ClosePullRequest declines the given pull request, Bitbucket's equivalent of closing it without merging
+func (b *Bitbucket) ClosePullRequest(ctx context.Context, pr PullRequest) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	if err = b.do(ctx, http.MethodPost, b.pullRequestURL(bitbucketPr, "/decline"), nil, "", nil); err != nil {
+		logging.FromContext(ctx).Error("unable to decline pull request", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// MergePullRequest merges the given pull request and returns the sha
+func (b *Bitbucket) MergePullRequest(ctx context.Context, pr PullRequest) (*string, error) {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged BitbucketPullRequest
+	if err = b.doJSON(ctx, http.MethodPost, b.pullRequestURL(bitbucketPr, "/merge"), map[string]interface{}{
+		"merge_strategy":      "merge_commit",
+		"close_source_branch": false,
+	}, &merged); err != nil {
+		logging.FromContext(ctx).Error("unable to merge pull request", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	// Bitbucket completes long running merges in the background, in which case the merge commit is not known yet
+	if merged.MergeCommit == nil {
+		if err = b.do(ctx, http.MethodGet, b.pullRequestURL(bitbucketPr, ""), nil, "", &merged); err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve merged pull request", logging.ERROR_KEY, err)
+			return nil, err
+		}
+	}
+	if merged.MergeCommit == nil {
+		errStr := "pull request merge is still in progress"
+		logging.FromContext(ctx).Error(errStr)
+		return nil, fmt.Errorf(errStr)
+	}
+
+	return &merged.MergeCommit.Hash, nil
+}
+
+// GetReviews returns the participants of the given pull request that approved, requested changes or commented
+func (b *Bitbucket) GetReviews(ctx context.Context, pr PullRequest) (PullRequestReviews, error) {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var current BitbucketPullRequest
+	if err = b.do(ctx, http.MethodGet, b.pullRequestURL(bitbucketPr, ""), nil, "", &current); err != nil {
+		logging.FromContext(ctx).Error("Bitbucket list reviews error", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	reviews := []BitbucketParticipant{}
+	for _, participant := range current.Participants {
+		if bitbucketReviewState(participant) != "" {
+			reviews = append(reviews, participant)
+		}
+	}
+
+	return reviews, nil
+}
+
+// bitbucketReviewState returns the review state the given participant stands for, or an empty string if they only
+// have been requested to review
+func bitbucketReviewState(participant BitbucketParticipant) string {
+	switch {
+	case participant.Approved || (participant.State != nil && *participant.State == bitbucketApprovedState):
+		return APPROVED_STATE
+	case participant.State != nil && *participant.State == bitbucketChangesRequestedState:
+		return CHANGES_REQUESTED_STATE
+	case participant.Role != bitbucketReviewerRole || participant.ParticipatedOn != nil:
+		return COMMENTED_STATE
+	}
+
+	return ""
+}
+
+// CreateReview generates a pull request review on the given pull request using the given data
+// Bitbucket has no review objects, so the comments are added first and the pull request is then approved or has
+// changes requested as the authenticated user
+func (b *Bitbucket) CreateReview(ctx context.Context, pr PullRequest, data *models.Review) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	// the file to target for review comments, all comments relate to the only line in the RFC
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, data.RFCIdentifier, RFC_FILE_NAME)
+	comments := []map[string]interface{}{}
+	for _, cmts := range data.Comments {
+		for _, cmt := range cmts {
+			comments = append(comments, map[string]interface{}{
+				"content": map[string]string{"raw": cmt},
+				"inline":  map[string]interface{}{"path": path, "to": 1},
+			})
+		}
+	}
+	if data.TopLevelComment != "" {
+		comments = append(comments, map[string]interface{}{
+			"content": map[string]string{"raw": data.TopLevelComment},
+		})
+	}
+	for _, comment := range comments {
+		if err = b.doJSON(ctx, http.MethodPost, b.pullRequestURL(bitbucketPr, "/comments"), comment,
+			nil); err != nil {
+			logging.FromContext(ctx).Error("unable to create review comment", logging.ERROR_KEY, err)
+			return err
+		}
+	}
+
+	action := ""
+	switch data.Type {
+	case APPROVE_REVIEW_TYPE:
+		action = "/approve"
+	case REQUEST_CHANGES_REVIEW_TYPE:
+		action = "/request-changes"
+	}
+	if action != "" {
+		if err = b.do(ctx, http.MethodPost, b.pullRequestURL(bitbucketPr, action), nil, "", nil); err != nil {
+			logging.FromContext(ctx).Error("unable to create review", logging.ERROR_KEY, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetReviewComments returns the comments of the given pull request, oldest first, deleted comments are omitted
+func (b *Bitbucket) GetReviewComments(ctx context.Context, pr PullRequest) ([]ReviewComment, error) {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := listAll[bitbucketComment](ctx, b, b.pullRequestURL(bitbucketPr,
+		fmt.Sprintf("/comments?pagelen=%d", BITBUCKET_PAGE_LENGTH)))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list review comments", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	comments := []ReviewComment{}
+	for _, result := range results {
+		if result.Deleted {
+			continue
+		}
+		comment := ReviewComment{ID: strconv.Itoa(result.ID), Author: result.User.Nickname, Body: result.Content.Raw}
+		if result.Inline != nil {
+			comment.Path = result.Inline.Path
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// EditReviewComment replaces the body of the comment with the given ID on the given pull request
+func (b *Bitbucket) EditReviewComment(ctx context.Context, pr PullRequest, id string, body string) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	comment := map[string]interface{}{"content": map[string]string{"raw": body}}
+	if err = b.doJSON(ctx, http.MethodPut, b.pullRequestURL(bitbucketPr, fmt.Sprintf("/comments/%s",
+		url.PathEscape(id))), comment, nil); err != nil {
+		logging.FromContext(ctx).Error("unable to edit review comment", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteReviewComment deletes the comment with the given ID from the given pull request
+func (b *Bitbucket) DeleteReviewComment(ctx context.Context, pr PullRequest, id string) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	if err = b.do(ctx, http.MethodDelete, b.pullRequestURL(bitbucketPr, fmt.Sprintf("/comments/%s",
+		url.PathEscape(id))), nil, "", nil); err != nil {
+		logging.FromContext(ctx).Error("unable to delete review comment", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// DismissApprovalReviews dismisses only the "approval" reviews in the given reviews from the given pull request
+// Bitbucket only lets users withdraw their own approval, so only the authenticated user's approval is withdrawn here.
+// The approvals of other users are reset by Bitbucket itself when the RFC file is updated, provided the repository
+// enables "Reset approvals when the source branch is modified"
+func (b *Bitbucket) DismissApprovalReviews(ctx context.Context, reviews PullRequestReviews, pr PullRequest) error {
+	// ensure given reviews are of bitbucket type
+	participants, ok := reviews.([]BitbucketParticipant)
+	if !ok {
+		errStr := "given pull request reviews is not of type []BitbucketParticipant"
+		logging.FromContext(ctx).Error(errStr)
+		return fmt.Errorf(errStr)
+	}
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	login, err := b.GetUserLogin(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, participant := range participants {
+		if bitbucketReviewState(participant) != APPROVED_STATE || participant.User.Nickname != *login {
+			continue
+		}
+		if err = b.do(ctx, http.MethodDelete, b.pullRequestURL(bitbucketPr, "/approve"), nil, "", nil); err != nil {
+			logging.FromContext(ctx).Error("Bitbucket dismiss review error", logging.ERROR_KEY, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetUserLogin returns the nickname of the authenticated Bitbucket user
+func (b *Bitbucket) GetUserLogin(ctx context.Context) (*string, error) {
+	var user BitbucketUser
+	if err := b.do(ctx, http.MethodGet, b.apiURL+"/user", nil, "", &user); err != nil {
+		logging.FromContext(ctx).Error("unable to fetch user", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return &user.Nickname, nil
+}
+
+// listGroups returns the groups of the owner workspace along with their members
+// Groups are only exposed by the 1.0 API, which does not paginate them
+func (b *Bitbucket) listGroups(ctx context.Context) ([]bitbucketGroup, error) {
+	var groups []bitbucketGroup
+	if err := b.do(ctx, http.MethodGet, fmt.Sprintf("%s/groups/%s", b.groupsAPIURL, url.PathEscape(b.owner)), nil, "",
+		&groups); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve workspace groups", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetUserTeams returns a set of the slugs of the workspace groups the authenticated user belongs to
+func (b *Bitbucket) GetUserTeams(ctx context.Context) (set.Set[string], error) {
+	login, err := b.GetUserLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := b.listGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := set.NewSet[string]()
+	for _, group := range groups {
+		for _, member := range group.Members {
+			if member.Nickname == *login {
+				teams.Add(group.Slug)
+				break
+			}
+		}
+	}
+
+	return teams, nil
+}
+
+// GetTeamMembers returns a set of logins for the members of the given workspace group slug
+func (b *Bitbucket) GetTeamMembers(ctx context.Context, team string) (set.Set[string], error) {
+	var users []BitbucketUser
+	if err := b.do(ctx, http.MethodGet, fmt.Sprintf("%s/groups/%s/%s/members", b.groupsAPIURL, url.PathEscape(b.owner),
+		url.PathEscape(team)), nil, "", &users); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve team members", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	members := set.NewSet[string]()
+	for _, user := range users {
+		members.Add(user.Nickname)
+	}
+
+	return members, nil
+}
+
+// RequestReviewers adds each of the given logins to the reviewers of the given pull request
+// Bitbucket identifies reviewers by UUID, so logins are resolved through the members of the owner workspace
+func (b *Bitbucket) RequestReviewers(ctx context.Context, pr PullRequest, reviewers []string) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	memberships, err := listAll[struct {
+		User BitbucketUser `json:"user"`
+	}](ctx, b, fmt.Sprintf("%s/workspaces/%s/members?pagelen=%d", b.apiURL, url.PathEscape(b.owner),
+		BITBUCKET_PAGE_LENGTH))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve workspace members", logging.ERROR_KEY, err)
+		return err
+	}
+	uuids := map[string]string{}
+	for _, membership := range memberships {
+		uuids[membership.User.Nickname] = membership.User.UUID
+	}
+
+	// the reviewers of a pull request are replaced as a whole, so existing reviewers are kept
+	requested := []map[string]string{}
+	for _, reviewer := range bitbucketPr.Reviewers {
+		requested = append(requested, map[string]string{"uuid": reviewer.UUID})
+	}
+	for _, reviewer := range reviewers {
+		uuid, ok := uuids[reviewer]
+		if !ok {
+			return &ProviderError{Kind: ErrNotFound, Err: fmt.Errorf("%s is not a member of workspace %s", reviewer,
+				b.owner)}
+		}
+		requested = append(requested, map[string]string{"uuid": uuid})
+	}
+
+	if err = b.doJSON(ctx, http.MethodPut, b.pullRequestURL(bitbucketPr, ""), map[string]interface{}{
+		"title":     bitbucketPr.Title,
+		"reviewers": requested,
+	}, nil); err != nil {
+		logging.FromContext(ctx).Error("unable to request reviewers", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// RequestTeamReviewers requests a review of the given pull request from each member of the given groups, Bitbucket
+// pull requests have no group reviewers. The author is left out, as they cannot review their own pull request
+func (b *Bitbucket) RequestTeamReviewers(ctx context.Context, pr PullRequest, teams []string) error {
+	bitbucketPr, err := asBitbucketPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	reviewers := set.NewSet[string]()
+	for _, team := range teams {
+		members, err := b.GetTeamMembers(ctx, team)
+		if err != nil {
+			return err
+		}
+		reviewers.Add(members.Values()...)
+	}
+	reviewers.Delete(bitbucketPr.Author.Nickname)
+	for _, reviewer := range bitbucketPr.Reviewers {
+		reviewers.Delete(reviewer.Nickname)
+	}
+	if reviewers.Size() == 0 {
+		return nil
+	}
+
+	logins := reviewers.Values()
+	sort.Strings(logins)
+	return b.RequestReviewers(ctx, pr, logins)
+}
+
+// CreateTag tags the given sha with the given name
+func (b *Bitbucket) CreateTag(ctx context.Context, sha string, tag string) error {
+	if err := b.doJSON(ctx, http.MethodPost, b.repositoryURL("/refs/tags"), &bitbucketRef{
+		Name:   tag,
+		Target: bitbucketCommit{Hash: sha},
+	}, nil); err != nil {
+		logging.FromContext(ctx).Error("unable to create tag", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateDeployment is not supported, Bitbucket deployments are driven by Pipelines rather than requested through the
+// API, so RFCs hosted on Bitbucket use the manual load gate instead
+func (b *Bitbucket) CreateDeployment(ctx context.Context, pr PullRequest, environment string) (*string, error) {
+	return nil, fmt.Errorf("deployment load gates are not supported by Bitbucket")
+}
+
+// GetDeploymentStatus is not supported, see CreateDeployment
+func (b *Bitbucket) GetDeploymentStatus(ctx context.Context, deploymentID string) (*DeploymentStatus, error) {
+	return nil, fmt.Errorf("deployment load gates are not supported by Bitbucket")
+}
+
+// GetMissingPermissions returns a description of each permission Harmonia requires on the tracking repository that
+// the client's token lacks, by checking the user's repository permission and probing the workspace groups
+func (b *Bitbucket) GetMissingPermissions(ctx context.Context) ([]string, error) {
+	missing := []string{}
+	repoName := fmt.Sprintf("%s/%s", b.owner, *b.trackingRepository)
+
+	// the repository must be visible to the token at all, nothing else can be checked otherwise
+	query := url.Values{}
+	query.Set("q", fmt.Sprintf("repository.full_name = %q", repoName))
+	var permissions bitbucketPage[struct {
+		Permission string `json:"permission"`
+	}]
+	if err := b.do(ctx, http.MethodGet, b.apiURL+"/user/permissions/repositories?"+query.Encode(), nil, "",
+		&permissions); err != nil {
+		if errors.Is(err, ErrPermission) {
+			return append(missing, fmt.Sprintf("account read permission to check access to %s", repoName)), nil
+		}
+		logging.FromContext(ctx).Error("unable to retrieve repository permissions for permission check",
+			logging.ERROR_KEY, err)
+		return nil, err
+	}
+	if len(permissions.Values) == 0 {
+		return append(missing, fmt.Sprintf("access to repository %s", repoName)), nil
+	}
+
+	// branches, files, pull requests and tags are all written
+	if permission := permissions.Values[0].Permission; permission != "write" && permission != "admin" {
+		missing = append(missing, fmt.Sprintf("repository and pull requests write permission on %s", repoName))
+	}
+
+	// group membership is used to route and attribute reviews
+	if _, err := b.listGroups(ctx); err != nil {
+		if errors.Is(err, ErrPermission) || errors.Is(err, ErrNotFound) {
+			missing = append(missing, fmt.Sprintf("workspace groups read permission on %s", b.owner))
+		} else {
+			return nil, err
+		}
+	}
+
+	return missing, nil
+}
+
+// GetArchiveIndex returns the contents of the archive index on the base branch, nil if no RFC was archived yet
+func (b *Bitbucket) GetArchiveIndex(ctx context.Context) (*string, error) {
+	path := fmt.Sprintf("%s/%s", BASE_RFC_DIRECTORY_NAME, ARCHIVE_INDEX_FILE_NAME)
+	var raw []byte
+	if err := b.do(ctx, http.MethodGet, b.repositoryURL(fmt.Sprintf("/src/%s/%s", BASE_BRANCH, path)), nil, "",
+		&raw); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		logging.FromContext(ctx).Error("unable to retrieve archive index", logging.ERROR_KEY, err)
+		return nil, err
+	}
+	content := string(raw)
+
+	return &content, nil
+}
+
+// GetSchemas returns the contents of the JSON files of the given directory on the base branch, keyed by file name,
+// an empty map if the directory does not exist
+func (b *Bitbucket) GetSchemas(ctx context.Context, directory string) (map[string]string, error) {
+	entries, err := listAll[struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}](ctx, b, b.repositoryURL(fmt.Sprintf("/src/%s/%s/?pagelen=%d", BASE_BRANCH, directory, BITBUCKET_PAGE_LENGTH)))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return map[string]string{}, nil
+		}
+		logging.FromContext(ctx).Error("unable to list schemas", "directory", directory, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	files := map[string]string{}
+	for _, entry := range entries {
+		if entry.Type != "commit_file" || !strings.HasSuffix(entry.Path, ".json") {
+			continue
+		}
+		var raw []byte
+		if err = b.do(ctx, http.MethodGet, b.repositoryURL(fmt.Sprintf("/src/%s/%s", BASE_BRANCH, entry.Path)), nil, "",
+			&raw); err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve schema", "path", entry.Path, logging.ERROR_KEY, err)
+			return nil, err
+		}
+		files[path.Base(entry.Path)] = string(raw)
+	}
+
+	return files, nil
+}
+
+// ArchiveRFCs removes the RFC files of the given RFCs from the base branch and writes the given archive index, in a
+// single commit with the given message. Files listed without content are deleted by Bitbucket. The commit is pushed
+// to the base branch directly, so the machine account must be allowed to bypass its restrictions
+func (b *Bitbucket) ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("message", message); err != nil {
+		return err
+	}
+	if err := writer.WriteField("branch", BASE_BRANCH); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile(fmt.Sprintf("%s/%s", BASE_RFC_DIRECTORY_NAME, ARCHIVE_INDEX_FILE_NAME),
+		ARCHIVE_INDEX_FILE_NAME)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write([]byte(index)); err != nil {
+		return err
+	}
+	for _, rfcIdentifier := range rfcIdentifiers {
+		path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, rfcIdentifier, RFC_FILE_NAME)
+		if err = writer.WriteField("files", path); err != nil {
+			return err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	if err = b.do(ctx, http.MethodPost, b.repositoryURL("/src"), &body, writer.FormDataContentType(), nil); err != nil {
+		logging.FromContext(ctx).Error("unable to commit archived RFCs", logging.ERROR_KEY, err)
+		return err
+	}
+
+	return nil
+}
+
+// CommitFiles writes the given files, keyed by path, to the base branch in a single commit with the given message
+// Bitbucket commits the files whether they changed or not. The commit is pushed to the base branch directly, so the
+// machine account must be allowed to bypass its restrictions
+func (b *Bitbucket) CommitFiles(ctx context.Context, files map[string]string, message string) (bool, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("message", message); err != nil {
+		return false, err
+	}
+	if err := writer.WriteField("branch", BASE_BRANCH); err != nil {
+		return false, err
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		part, err := writer.CreateFormFile(path, path[strings.LastIndex(path, "/")+1:])
+		if err != nil {
+			return false, err
+		}
+		if _, err = part.Write([]byte(files[path])); err != nil {
+			return false, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return false, err
+	}
+
+	if err := b.do(ctx, http.MethodPost, b.repositoryURL("/src"), &body, writer.FormDataContentType(), nil); err != nil {
+		logging.FromContext(ctx).Error("unable to commit files", logging.ERROR_KEY, err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// bitbucketBranchRestriction is a branch restriction of a Bitbucket repository
+type bitbucketBranchRestriction struct {
+	Kind    string `json:"kind"`
+	Pattern string `json:"pattern"`
+	Value   *int   `json:"value"`
+}
+
+// GetBranchProtection returns the protection Bitbucket enforces on the base branch of the tracking repository, i.e. the
+// branch restrictions whose pattern is the base branch. Bitbucket does not name the builds required to pass, so the
+// required contexts are left nil
+// Reading the branch restrictions requires repository admin permission
+func (b *Bitbucket) GetBranchProtection(ctx context.Context) (*BranchProtection, error) {
+	query := url.Values{}
+	query.Set("pattern", BASE_BRANCH)
+	restrictions, err := listAll[bitbucketBranchRestriction](ctx, b, b.repositoryURL("/branch-restrictions?"+
+		query.Encode()))
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch restrictions", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	protection := &BranchProtection{Branch: BASE_BRANCH}
+	for _, restriction := range restrictions {
+		if restriction.Pattern != BASE_BRANCH {
+			continue
+		}
+		protection.Protected = true
+		switch restriction.Kind {
+		case "require_approvals_to_merge":
+			if restriction.Value != nil && *restriction.Value > protection.RequiredApprovals {
+				protection.RequiredApprovals = *restriction.Value
+			}
+		case "require_passing_builds_to_merge":
+			protection.ChecksRequired = true
+		}
+	}
+
+	return protection, nil
+}
+
+// GetIdsAndTitles is a helper method used to retrieve UI data from an array of Pull Requests
+func (b *Bitbucket) GetIdsAndTitles(prs PullRequests) (IdsAndTitles, error) {
+	idsAndTitles := make([]map[string]string, len(prs))
+	for i, pr := range prs {
+		bitbucketPr, ok := pr.(*BitbucketPullRequest)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert given pull request to BitbucketPullRequest")
+		}
+		idsAndTitles[i] = map[string]string{bitbucketPr.Source.Branch.Name: bitbucketPr.Title}
+	}
+
+	return idsAndTitles, nil
+}
+
+// GetPullRequestDetails extracts the provider agnostic details of the given pull request
+// Reviewers that have not reviewed yet are the requested reviewers, Bitbucket cannot request reviews from groups
+func (b *Bitbucket) GetPullRequestDetails(pr PullRequest) (*PullRequestDetails, error) {
+	bitbucketPr, ok := pr.(*BitbucketPullRequest)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert given pull request to BitbucketPullRequest")
+	}
+
+	details := &PullRequestDetails{
+		RFCIdentifier: bitbucketPr.Source.Branch.Name,
+		Number:        bitbucketPr.ID,
+		Title:         bitbucketPr.Title,
+		Author:        bitbucketPr.Author.Nickname,
+		State:         CLOSED_STATE,
+		Merged:        bitbucketPr.State == bitbucketMergedState,
+		CreatedAt:     bitbucketPr.CreatedOn,
+		UpdatedAt:     bitbucketPr.UpdatedOn,
+	}
+	if bitbucketPr.State == bitbucketOpenState {
+		details.State = OPEN_STATE
+	}
+	// Bitbucket does not record when a pull request was merged, merging is its last update
+	if details.Merged {
+		details.MergedAt = bitbucketPr.UpdatedOn
+	}
+
+	reviewed := set.NewSet[string]()
+	for _, participant := range bitbucketPr.Participants {
+		if bitbucketReviewState(participant) != "" {
+			reviewed.Add(participant.User.UUID)
+		}
+	}
+	for _, reviewer := range bitbucketPr.Reviewers {
+		if !reviewed.Contains(reviewer.UUID) {
+			details.RequestedReviewers = append(details.RequestedReviewers, reviewer.Nickname)
+		}
+	}
+
+	return details, nil
+}
+
+// GetReviewDetails extracts the provider agnostic details of the given pull request reviews
+func (b *Bitbucket) GetReviewDetails(reviews PullRequestReviews) ([]ReviewDetails, error) {
+	participants, ok := reviews.([]BitbucketParticipant)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert given pull request reviews to []BitbucketParticipant")
+	}
+
+	details := make([]ReviewDetails, len(participants))
+	for i, participant := range participants {
+		details[i] = ReviewDetails{
+			Reviewer: participant.User.Nickname,
+			State:    bitbucketReviewState(participant),
+		}
+		if participant.ParticipatedOn != nil {
+			details[i].SubmittedAt = *participant.ParticipatedOn
+		}
+	}
+
+	return details, nil
+}
+
+// BuildLinks returns the Bitbucket URLs of the given RFC: its pull request (if given), its RFC file and, if tagged, its
+// tag. The tag shares its name with the RFC branch, so the file link still resolves once the branch is deleted
+func (b *Bitbucket) BuildLinks(rfcIdentifier string, pr PullRequest, tagged bool) *models.Links {
+	repoURL := fmt.Sprintf("%s/%s/%s", BITBUCKET_WEB_URL, url.PathEscape(b.owner), url.PathEscape(*b.trackingRepository))
+	ref := url.PathEscape(rfcIdentifier)
+	links := &models.Links{
+		File: fmt.Sprintf("%s/src/%s/%s/%s/%s", repoURL, ref, BASE_RFC_DIRECTORY_NAME, ref, RFC_FILE_NAME),
+	}
+
+	if bitbucketPr, ok := pr.(*BitbucketPullRequest); ok {
+		links.PullRequest = bitbucketPr.Links.HTML.Href
+	}
+	if tagged {
+		links.Tag = fmt.Sprintf("%s/src/%s", repoURL, ref)
+	}
+
+	return links
+}
+
+// WithOwner returns a FilterOption that returns true if a given PR is owned by the given user. If no user is given,
+// returns true.
+func (b *Bitbucket) WithOwner(owner *string) FilterOption {
+	return func(pr PullRequest) bool {
+		bitbucketPr, ok := pr.(*BitbucketPullRequest)
+		if !ok {
+			return false
+		}
+
+		if owner != nil {
+			return *owner == bitbucketPr.Author.Nickname
+		}
+
+		return true
+	}
+}
+
+// IsMerged returns a FilterOption that returns true if a given PR has a merged state equal to the provided state. If
+// no state is given, returns true.
+func (b *Bitbucket) IsMerged(merged *bool) FilterOption {
+	return func(pr PullRequest) bool {
+		bitbucketPr, ok := pr.(*BitbucketPullRequest)
+		if !ok {
+			return false
+		}
+
+		if merged != nil {
+			return *merged == (bitbucketPr.State == bitbucketMergedState)
+		}
+
+		return true
+	}
+}
@@ -0,0 +1,291 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+var _ Git = &Bitbucket{}
+
+// newTestBitbucket returns a Bitbucket client of the given handler, serving both the 2.0 and the 1.0 API
+func newTestBitbucket(t *testing.T, handler http.HandlerFunc) *Bitbucket {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	token := "token"
+	repo := "rfcs"
+	return &Bitbucket{
+		AccessToken:        &token,
+		client:             server.Client(),
+		apiURL:             server.URL + "/2.0",
+		groupsAPIURL:       server.URL + "/1.0",
+		owner:              "schema-team",
+		trackingRepository: &repo,
+	}
+}
+
+// TestMapBitbucketError tests that Bitbucket API errors are mapped to the provider agnostic errors
+func TestMapBitbucketError(t *testing.T) {
+	testCases := []struct {
+		err      *bitbucketError
+		expected error
+	}{
+		{err: &bitbucketError{StatusCode: http.StatusNotFound}, expected: ErrNotFound},
+		{err: &bitbucketError{StatusCode: http.StatusConflict}, expected: ErrConflict},
+		{
+			err:      &bitbucketError{StatusCode: http.StatusBadRequest, Message: "Branch already exists"},
+			expected: ErrConflict,
+		},
+		{err: &bitbucketError{StatusCode: http.StatusForbidden}, expected: ErrPermission},
+		{err: &bitbucketError{StatusCode: http.StatusTooManyRequests}, expected: ErrRateLimited},
+		{err: &bitbucketError{StatusCode: http.StatusBadRequest, Message: "invalid field"}, expected: models.ErrProvider},
+	}
+
+	for _, testCase := range testCases {
+		mapped := mapBitbucketError(testCase.err)
+		if !errors.Is(mapped, testCase.err) {
+			t.Errorf("provider error %v was not kept wrapped", testCase.err)
+		}
+		if !errors.Is(mapped, testCase.expected) {
+			t.Errorf("expected %v to map to %v, got %v", testCase.err, testCase.expected, mapped)
+		}
+	}
+}
+
+// TestBitbucketGetPullRequests tests that pull requests are paginated and filtered
+func TestBitbucketGetPullRequests(t *testing.T) {
+	// arrange
+	var states []string
+	var b *Bitbucket
+	b = newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			states = r.URL.Query()["state"]
+			fmt.Fprintf(w, `{"values": [
+				{"id": 1, "title": "one", "state": "MERGED", "author": {"nickname": "alice"},
+					"source": {"branch": {"name": "rfc-1"}}},
+				{"id": 2, "title": "two", "state": "DECLINED", "author": {"nickname": "alice"},
+					"source": {"branch": {"name": "rfc-2"}}}
+			], "next": "%s"}`, b.repositoryURL("/pullrequests?page=2"))
+			return
+		}
+		fmt.Fprint(w, `{"values": [
+			{"id": 3, "title": "three", "state": "SUPERSEDED", "author": {"nickname": "alice"},
+				"source": {"branch": {"name": "rfc-3"}}},
+			{"id": 4, "title": "four", "state": "DECLINED", "author": {"nickname": "bob"},
+				"source": {"branch": {"name": "rfc-4"}}}
+		]}`)
+	})
+	owner := "alice"
+	merged := false
+
+	// act
+	prs, err := b.GetPullRequests(context.Background(), CLOSED_STATE, -1, b.WithOwner(&owner), b.IsMerged(&merged))
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(states, ",") != "MERGED,DECLINED,SUPERSEDED" {
+		t.Errorf("unexpected states queried: %v", states)
+	}
+	idsAndTitles, _ := b.GetIdsAndTitles(prs)
+	if fmt.Sprint(idsAndTitles) != "[map[rfc-2:two] map[rfc-3:three]]" {
+		t.Errorf("unexpected pull requests: %v", idsAndTitles)
+	}
+}
+
+// TestBitbucketGetRFCContents tests that RFC contents are read at a ref and that missing files are reported
+func TestBitbucketGetRFCContents(t *testing.T) {
+	// arrange
+	b := newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/src/missing/"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "No such file or directory"}}`)
+		case r.URL.Query().Get("format") == "meta":
+			fmt.Fprint(w, `{"type": "commit_file", "commit": {"hash": "abc123"}}`)
+		default:
+			fmt.Fprint(w, `{"id":"rfc-1"}`)
+		}
+	})
+
+	// act
+	content, sha, err := b.GetRFCContents(context.Background(), "rfc-1")
+	_, missingErr := b.GetRFCContentsAt(context.Background(), "rfc-1", "missing")
+
+	// assert
+	if err != nil || *content != `{"id":"rfc-1"}` || *sha != "abc123" {
+		t.Errorf("unexpected RFC contents. content: %v, sha: %v, err: %v", content, sha, err)
+	}
+	if !errors.Is(missingErr, ErrRFCFileNotFound) || !errors.Is(missingErr, ErrNotFound) {
+		t.Errorf("expected a missing RFC file error, got %v", missingErr)
+	}
+}
+
+// TestBitbucketCreateReview tests that review comments are added before the pull request is approved
+func TestBitbucketCreateReview(t *testing.T) {
+	// arrange
+	var calls []string
+	b := newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		call := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if call == "comments" {
+			var comment struct {
+				Inline *struct {
+					Path string `json:"path"`
+				} `json:"inline"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&comment)
+			if comment.Inline != nil {
+				call = fmt.Sprintf("%s:%s", call, comment.Inline.Path)
+			}
+		}
+		calls = append(calls, call)
+		fmt.Fprint(w, `{}`)
+	})
+	pr := &BitbucketPullRequest{ID: 7}
+
+	// act
+	err := b.CreateReview(context.Background(), pr, &models.Review{
+		RFCIdentifier:   "rfc-1",
+		Type:            APPROVE_REVIEW_TYPE,
+		Comments:        map[string][]string{"name": {"looks good"}},
+		TopLevelComment: "ship it",
+	})
+
+	// assert
+	expected := "[comments:RFC/rfc-1/RFC.json comments approve]"
+	if err != nil || fmt.Sprint(calls) != expected {
+		t.Errorf("unexpected calls. wanted %v, got %v. err: %v", expected, calls, err)
+	}
+}
+
+// TestBitbucketRequestTeamReviewers tests that the members of groups are requested, the author and existing reviewers
+// aside
+func TestBitbucketRequestTeamReviewers(t *testing.T) {
+	// arrange
+	var requested []string
+	b := newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/1.0/groups/schema-team/data/members"):
+			fmt.Fprint(w, `[{"nickname": "tstark"}, {"nickname": "pparker"}, {"nickname": "bbanner"}]`)
+		case strings.HasPrefix(r.URL.Path, "/2.0/workspaces/schema-team/members"):
+			fmt.Fprint(w, `{"values": [{"user": {"nickname": "pparker", "uuid": "{p}"}},
+				{"user": {"nickname": "bbanner", "uuid": "{b}"}}]}`)
+		case r.Method == http.MethodPut:
+			var body struct {
+				Reviewers []map[string]string `json:"reviewers"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			for _, reviewer := range body.Reviewers {
+				requested = append(requested, reviewer["uuid"])
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	pr := &BitbucketPullRequest{ID: 7, Author: BitbucketUser{Nickname: "tstark"},
+		Reviewers: []BitbucketUser{{Nickname: "bbanner", UUID: "{b}"}}}
+
+	// act
+	err := b.RequestTeamReviewers(context.Background(), pr, []string{"data"})
+
+	// assert
+	if err != nil || fmt.Sprint(requested) != "[{b} {p}]" {
+		t.Errorf("unexpected reviewers: %v, err: %v", requested, err)
+	}
+}
+
+// TestBitbucketPullRequestDetails tests that reviewers who already reviewed are no longer requested
+func TestBitbucketPullRequestDetails(t *testing.T) {
+	// arrange
+	b := &Bitbucket{}
+	pr := &BitbucketPullRequest{}
+	if err := json.Unmarshal([]byte(`{
+		"id": 1, "title": "RFC: rfc-1", "state": "OPEN", "author": {"nickname": "alice"},
+		"source": {"branch": {"name": "rfc-1"}},
+		"reviewers": [{"uuid": "{b}", "nickname": "bob"}, {"uuid": "{c}", "nickname": "carol"}],
+		"participants": [
+			{"user": {"uuid": "{b}", "nickname": "bob"}, "role": "REVIEWER", "approved": true, "state": "approved"},
+			{"user": {"uuid": "{c}", "nickname": "carol"}, "role": "REVIEWER", "approved": false, "state": null}
+		]
+	}`), pr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// act
+	details, err := b.GetPullRequestDetails(pr)
+	reviews, _ := b.GetReviewDetails([]BitbucketParticipant{pr.Participants[0]})
+
+	// assert
+	if err != nil || details.State != OPEN_STATE || details.Merged || details.Author != "alice" ||
+		fmt.Sprint(details.RequestedReviewers) != "[carol]" {
+		t.Errorf("unexpected details: %+v, err: %v", details, err)
+	}
+	if len(reviews) != 1 || reviews[0].Reviewer != "bob" || reviews[0].State != APPROVED_STATE {
+		t.Errorf("unexpected reviews: %+v", reviews)
+	}
+}
+
+// TestBitbucketGetBranchProtection tests that the restrictions of the base branch make up its protection
+func TestBitbucketGetBranchProtection(t *testing.T) {
+	// arrange
+	var pattern string
+	b := newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		pattern = r.URL.Query().Get("pattern")
+		fmt.Fprint(w, `{"values": [
+			{"kind": "require_approvals_to_merge", "pattern": "main", "value": 2},
+			{"kind": "require_passing_builds_to_merge", "pattern": "main", "value": 1},
+			{"kind": "push", "pattern": "main-*"}
+		]}`)
+	})
+
+	// act
+	protection, err := b.GetBranchProtection(context.Background())
+
+	// assert
+	if err != nil || pattern != "main" {
+		t.Fatalf("unexpected error: %v, pattern: %s", err, pattern)
+	}
+	if !protection.Protected || !protection.ChecksRequired || protection.RequiredContexts != nil ||
+		protection.RequiredApprovals != 2 {
+		t.Errorf("unexpected protection: %+v", protection)
+	}
+}
+
+// TestBitbucketArchiveRFCs tests that archived RFC files are listed for deletion alongside the index in one commit
+func TestBitbucketArchiveRFCs(t *testing.T) {
+	// arrange
+	var form *multipart.Form
+	b := newTestBitbucket(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			form = r.MultipartForm
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// act
+	err := b.ArchiveRFCs(context.Background(), []string{"123", "456"}, `{"rfcs": []}`, "archive 2 RFCs")
+
+	// assert
+	if err != nil || form == nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(form.Value["files"]) != "[RFC/123/RFC.json RFC/456/RFC.json]" ||
+		fmt.Sprint(form.Value["branch"]) != "[main]" || len(form.File["RFC/index.json"]) != 1 {
+		t.Errorf("unexpected commit: %v, files: %v", form.Value, form.File)
+	}
+}
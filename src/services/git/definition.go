@@ -4,6 +4,8 @@ package git
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"harmonia-example.io/src/models"
 	"harmonia-example.io/src/services/set"
@@ -11,23 +13,65 @@ import (
 
 // Common constants that will be used across all Git implementations and interactions
 const (
-	OWNER                       string = "<repository-owner>"
 	BASE_BRANCH                 string = "main"
 	RFC_FILE_NAME               string = "RFC.json"
 	BASE_RFC_DIRECTORY_NAME     string = "RFC"
+	ARCHIVE_INDEX_FILE_NAME     string = "index.json"
 	APPROVED_STATE              string = "APPROVED"
+	CHANGES_REQUESTED_STATE     string = "CHANGES_REQUESTED"
+	COMMENTED_STATE             string = "COMMENTED"
+	DISMISSED_STATE             string = "DISMISSED"
 	OPEN_STATE                  string = "open"
+	CLOSED_STATE                string = "closed"
 	APPROVE_REVIEW_TYPE         string = "APPROVE"
 	REQUEST_CHANGES_REVIEW_TYPE string = "REQUEST_CHANGES"
 	COMMENT_REVIEW_TYPE         string = "COMMENT"
 	MERGEABILITY_CLEAN_STATE    string = "clean"
 	MERGEABILITY_PENDING_STATE  string = "pending"
 	MERGEABILITY_UNKNOWN_STATE  string = "unknown"
-	MERGEABILITY_RETRY_COUNT    int    = 3
-	MERGEABILITY_WAIT_TIME      int    = 10
 	ALL_PR_FILTER               string = "all"
+	GITHUB_WEB_URL              string = "https://github.com"
+	BITBUCKET_WEB_URL           string = "https://bitbucket.org"
+	REQUIRED_OAUTH_SCOPE        string = "repo"
+	REQUIRED_OAUTH_ORG_SCOPE    string = "read:org"
+	DEPLOYMENT_PENDING_STATE    string = "pending"
+	DEPLOYMENT_APPROVED_STATE   string = "approved"
+	DEPLOYMENT_REJECTED_STATE   string = "rejected"
+	CONTEXT_SUCCESS_STATE       string = "success"
+	CONTEXT_PENDING_STATE       string = "pending"
+	CONTEXT_FAILURE_STATE       string = "failure"
+	CONTEXT_MISSING_STATE       string = "missing"
 )
 
+// Provider agnostic errors that every Git implementation maps its provider errors to, so callers can take decisions
+// without knowing the provider. They are the kinds of failure of the models package, returned wrapped in a
+// *ProviderError that keeps the provider error
+var (
+	// ErrNotFound is returned when the requested resource (branch, file, pull request...) does not exist
+	ErrNotFound = models.ErrNotFound
+	// ErrConflict is returned when the request conflicts with the current state of the resource, e.g. an existing
+	// branch or a pull request that cannot be merged
+	ErrConflict = models.ErrConflict
+	// ErrRateLimited is returned when the provider throttled the client, the request can be retried later
+	ErrRateLimited = models.ErrRateLimited
+	// ErrPermission is returned when the client is not authorized to perform the request
+	ErrPermission = models.ErrUnauthorized
+)
+
+// ErrRFCFileNotFound is returned (wrapped) when the RFC file does not exist at the requested ref, it is also an
+// ErrNotFound
+var ErrRFCFileNotFound = errors.New("RFC file not found")
+
+// ProviderError is an error returned by a Git provider, mapped to the provider agnostic error it corresponds to
+// errors.Is reports a match for both the provider agnostic error and any error wrapped by the provider error
+type ProviderError = models.ProviderError
+
+// Repository identifies the tracking repository, the owner being the user, organization or workspace it belongs to
+type Repository struct {
+	Owner string
+	Name  string
+}
+
 // PullRequest is a generic Git type used to generalize implementations
 type PullRequest interface{}
 
@@ -44,22 +88,94 @@ type PullRequestReviews interface{}
 // the key is the ID of an RFC and the value is the title.
 type IdsAndTitles []map[string]string
 
+// FilterOption returns true if a given PR should be included in the results of a query, see filters.go to compose them
 type FilterOption func(PullRequest) bool
 
+// PullRequestOptions are the attributes a pull request is opened with, reviewers being logins and team reviewers team
+// slugs. Providers without labels or assignees, e.g. Bitbucket, ignore them
+type PullRequestOptions struct {
+	Title         string
+	Labels        []string
+	Assignees     []string
+	Reviewers     []string
+	TeamReviewers []string
+	// Description is the body of the pull request, a default one being used if empty
+	Description string
+}
+
+// PullRequestDetails is a provider agnostic view of the pull request attributes Harmonia reasons about
+type PullRequestDetails struct {
+	RFCIdentifier      string
+	Number             int
+	Title              string
+	Author             string
+	State              string
+	Merged             bool
+	MergedAt           time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	RequestedReviewers []string
+	RequestedTeams     []string
+	// Labels is empty for providers without pull request labels, e.g. Bitbucket
+	Labels []string
+}
+
+// ReviewDetails is a provider agnostic view of a single pull request review
+type ReviewDetails struct {
+	Reviewer    string
+	State       string
+	SubmittedAt time.Time
+}
+
+// ReviewComment is a provider agnostic view of a single pull request review comment
+// Path is the file the comment is attached to, empty for comments that are not attached to a file
+type ReviewComment struct {
+	ID     string
+	Author string
+	Path   string
+	Body   string
+}
+
+// RFCRevision describes a single commit that modified an RFC file
+type RFCRevision struct {
+	Sha       string
+	Author    string
+	Message   string
+	Timestamp time.Time
+}
+
+// DeploymentStatus is a provider agnostic view of the latest status of a deployment
+// State is one of the DEPLOYMENT_*_STATE constants, Actor is the login that set it and is empty while pending
+type DeploymentStatus struct {
+	State string
+	Actor string
+}
+
 // Git defines all methods necessary for Harmonia Git interactions
 // All git types (GitHub, BitBucket...) should implement this interface
 type Git interface {
+	// Repository returns the tracking repository the implementation operates on
+	Repository() Repository
+	// Capabilities returns the features the Git provider supports, so callers can degrade gracefully without them
+	Capabilities() models.Capabilities
 	// CreateBranch creates a new branch with the given name from the given base branch
 	CreateBranch(ctx context.Context, branch string, baseBranch string) error
 	// DeleteBranch deletes the branch with the given name
 	DeleteBranch(ctx context.Context, branch string) error
 	// CreateFile creates an RFC file on the given branch in the given directory using the given data
 	CreateFile(ctx context.Context, branch string, directory string, data *models.RFC) error
-	// CreatePullRequest opens a new pull request of the given branch towards the given base branch
-	CreatePullRequest(ctx context.Context, branch string, baseBranch string) error
+	// CreatePullRequest opens a new pull request of the given branch towards the given base branch with the given
+	// options. The pull request is opened even if it cannot be labeled, assigned or have its reviewers requested
+	CreatePullRequest(ctx context.Context, branch string, baseBranch string, options PullRequestOptions) error
 	// GetRFCContents returns the current contents of the RFC for the given pull request
 	// The sha of the file is also returned
 	GetRFCContents(ctx context.Context, branch string) (*string, *string, error)
+	// GetRFCContentsAt returns the contents of the RFC of the given branch as of the given commit sha
+	GetRFCContentsAt(ctx context.Context, branch string, ref string) (*string, error)
+	// GetRFCHistory returns the commits that modified the RFC file of the given branch, newest first
+	GetRFCHistory(ctx context.Context, branch string) ([]RFCRevision, error)
+	// RestoreFile commits the given raw content as the RFC file of the given PR, recreating the file if it was deleted
+	RestoreFile(ctx context.Context, pr PullRequest, content string, message string) error
 	// UpdateFile creates a commit to the RFC file of the given PR using the given data
 	UpdateFile(ctx context.Context, pr PullRequest, data *models.RFC) error
 	// GetPullRequest returns the most recent open pull request for the given branch
@@ -68,6 +184,11 @@ type Git interface {
 	GetPullRequests(ctx context.Context, state string, count int, opts ...FilterOption) (PullRequests, error)
 	// GetMergeability determines if the given pull request is mergeable (approvals, conflicts, ci...)
 	GetMergeability(ctx context.Context, pr PullRequest) (*bool, error)
+	// ExplainMergeability determines if the given pull request is mergeable, along with why it is not and the state of
+	// the status contexts considered. Only the required contexts are considered if any are configured
+	ExplainMergeability(ctx context.Context, pr PullRequest) (*models.Mergeability, error)
+	// ClosePullRequest closes the given pull request without merging it
+	ClosePullRequest(ctx context.Context, pr PullRequest) error
 	// MergePullRequest merges the given pull request and returns the sha
 	MergePullRequest(ctx context.Context, pr PullRequest) (*string, error)
 	// GetReviews returns all pull request reviews related to the given pull request
@@ -77,15 +198,55 @@ type Git interface {
 	CreateReview(ctx context.Context, pr PullRequest, data *models.Review) error
 	// DismissApprovalReviews dismisses only the "approval" reviews in the given reviews from the given pull request
 	DismissApprovalReviews(ctx context.Context, reviews PullRequestReviews, pr PullRequest) error
+	// GetReviewComments returns the review comments of the given pull request, oldest first
+	GetReviewComments(ctx context.Context, pr PullRequest) ([]ReviewComment, error)
+	// EditReviewComment replaces the body of the review comment with the given ID on the given pull request
+	EditReviewComment(ctx context.Context, pr PullRequest, id string, body string) error
+	// DeleteReviewComment deletes the review comment with the given ID from the given pull request
+	DeleteReviewComment(ctx context.Context, pr PullRequest, id string) error
 	// GetUserLogin returns the Git username defined by the client
 	GetUserLogin(ctx context.Context) (*string, error)
-	// GetUserTeams returns a set of teams for the current authenticated user in the form "<org-name>/<team-name>"
+	// GetUserTeams returns a set of team slugs for the current authenticated user
 	GetUserTeams(ctx context.Context) (set.Set[string], error)
+	// GetTeamMembers returns a set of logins for the members of the given team
+	GetTeamMembers(ctx context.Context, team string) (set.Set[string], error)
+	// RequestReviewers requests a review of the given pull request from each of the given logins
+	RequestReviewers(ctx context.Context, pr PullRequest, reviewers []string) error
+	// RequestTeamReviewers requests a review of the given pull request from each of the given teams
+	RequestTeamReviewers(ctx context.Context, pr PullRequest, teams []string) error
 	// CreateTag tags the given sha with the given name
 	CreateTag(ctx context.Context, sha string, name string) error
+	// CreateDeployment requests a deployment of the given pull request to the given environment, so the environment's
+	// protection rules gate it, and returns the deployment ID
+	CreateDeployment(ctx context.Context, pr PullRequest, environment string) (*string, error)
+	// GetDeploymentStatus returns the latest status of the deployment with the given ID
+	GetDeploymentStatus(ctx context.Context, deploymentID string) (*DeploymentStatus, error)
+	// GetMissingPermissions returns a description of each permission Harmonia requires on the tracking repository that
+	// the client's token lacks, an empty result means the token is sufficient
+	GetMissingPermissions(ctx context.Context) ([]string, error)
+	// GetBranchProtection returns the protection the Git provider enforces on the base branch of the tracking repository
+	GetBranchProtection(ctx context.Context) (*BranchProtection, error)
+	// GetArchiveIndex returns the contents of the archive index on the base branch, nil if no RFC was archived yet
+	GetArchiveIndex(ctx context.Context) (*string, error)
+	// ArchiveRFCs removes the RFC files of the given RFCs from the base branch and writes the given archive index, in
+	// a single commit with the given message
+	ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) error
+	// CommitFiles writes the given files, keyed by path, to the base branch in a single commit with the given message
+	// Returns whether a commit was made, none is made if the files are unchanged and the provider can tell
+	CommitFiles(ctx context.Context, files map[string]string, message string) (bool, error)
+	// GetSchemas returns the contents of the JSON files of the given directory on the base branch, keyed by file name,
+	// an empty map if the directory does not exist
+	GetSchemas(ctx context.Context, directory string) (map[string]string, error)
 
 	// GetIdsAndTitles is meant to retrieve the RFC ID and Title returned from GetPullRequests
 	GetIdsAndTitles(prs PullRequests) (IdsAndTitles, error)
+	// GetPullRequestDetails extracts the provider agnostic details of the given pull request
+	GetPullRequestDetails(pr PullRequest) (*PullRequestDetails, error)
+	// GetReviewDetails extracts the provider agnostic details of the given pull request reviews
+	GetReviewDetails(reviews PullRequestReviews) ([]ReviewDetails, error)
+	// BuildLinks returns the provider URLs of the given RFC: its pull request (if given), its RFC file and, if tagged,
+	// its tag
+	BuildLinks(rfcIdentifier string, pr PullRequest, tagged bool) *models.Links
 
 	// The following are functions that are meant to support filtering queries like e.g. GetPullRequests
 	WithOwner(owner *string) FilterOption
@@ -0,0 +1,385 @@
+// This holds the Git implementation injecting faults into the calls made to the Git provider by another
+// implementation, to exercise how Harmonia copes with a failing or slow provider in integration tests and game days
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/set"
+)
+
+// ErrInjectedFault is the provider error of the calls failed by a Faulty implementation
+var ErrInjectedFault = errors.New("injected fault")
+
+// ErrFaultsInProduction is returned (wrapped) when faults are configured to be injected in production
+var ErrFaultsInProduction = errors.New("faults cannot be injected into Git calls in production")
+
+// Faults describes the faults injected into the calls of a Git implementation
+type Faults struct {
+	// ErrorRate is the probability, between 0 and 1, of a call failing with ErrInjectedFault instead of being made
+	ErrorRate float64
+	// Latency is how long every call is delayed for before it is made or failed
+	Latency time.Duration
+	// Methods are the names of the Git methods faults are injected into, e.g. "UpdateFile", all of them if empty
+	Methods []string
+}
+
+// Faulty is a Git implementation injecting faults into the calls made to the Git provider by the implementation it
+// wraps, failed calls return a *ProviderError wrapping ErrInjectedFault as if the provider had failed them
+// Calls that do not reach the provider (e.g. GetPullRequestDetails) are passed through unaltered
+type Faulty struct {
+	Git
+	faults  Faults
+	methods set.Set[string]
+	// random returns a number in [0, 1), a call fails if it is below the error rate
+	random func() float64
+}
+
+// InjectFaults returns the given Git implementation with the given faults injected into its calls
+func InjectFaults(git Git, faults Faults) *Faulty {
+	return &Faulty{Git: git, faults: faults, methods: set.NewSetOf(faults.Methods...), random: rand.Float64}
+}
+
+// ConfiguredFaults returns the faults the Git implementations built by NewForDomain are configured to inject, nil if
+// none are. ErrFaultsInProduction is returned (wrapped) if faults are configured in production
+func ConfiguredFaults() (*Faults, error) {
+	c := configuration()
+	rate, err := c.GitFaultRate()
+	if err != nil {
+		return nil, err
+	}
+	latency, err := c.GitFaultLatency()
+	if err != nil {
+		return nil, err
+	}
+	if rate == 0 && latency == nil {
+		return nil, nil
+	}
+	if c.IsProduction() {
+		return nil, fmt.Errorf("%w: unset GIT_FAULT_RATE and GIT_FAULT_LATENCY", ErrFaultsInProduction)
+	}
+
+	faults := &Faults{ErrorRate: rate, Methods: c.GitFaultMethods()}
+	if latency != nil {
+		faults.Latency = *latency
+	}
+	return faults, nil
+}
+
+// inject delays the call of the given method by the latency and fails it at the error rate if faults are injected
+// into it. The call is failed with the error of the given context if it is done before the latency elapsed
+func (f *Faulty) inject(ctx context.Context, method string) error {
+	if f.methods.Size() > 0 && !f.methods.Contains(method) {
+		return nil
+	}
+
+	if f.faults.Latency > 0 {
+		timer := time.NewTimer(f.faults.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if f.random() < f.faults.ErrorRate {
+		return &ProviderError{Err: fmt.Errorf("%w: %s", ErrInjectedFault, method)}
+	}
+	return nil
+}
+
+// CreateBranch creates a new branch with the given name from the given base branch
+func (f *Faulty) CreateBranch(ctx context.Context, branch string, baseBranch string) (err error) {
+	if err = f.inject(ctx, "CreateBranch"); err != nil {
+		return
+	}
+	return f.Git.CreateBranch(ctx, branch, baseBranch)
+}
+
+// DeleteBranch deletes the branch with the given name
+func (f *Faulty) DeleteBranch(ctx context.Context, branch string) (err error) {
+	if err = f.inject(ctx, "DeleteBranch"); err != nil {
+		return
+	}
+	return f.Git.DeleteBranch(ctx, branch)
+}
+
+// CreateFile creates an RFC file on the given branch in the given directory using the given data
+func (f *Faulty) CreateFile(ctx context.Context, branch string, directory string, data *models.RFC) (err error) {
+	if err = f.inject(ctx, "CreateFile"); err != nil {
+		return
+	}
+	return f.Git.CreateFile(ctx, branch, directory, data)
+}
+
+// CreatePullRequest opens a new pull request of the given branch towards the given base branch with the given options
+func (f *Faulty) CreatePullRequest(ctx context.Context, branch string, baseBranch string,
+	options PullRequestOptions) (err error) {
+	if err = f.inject(ctx, "CreatePullRequest"); err != nil {
+		return
+	}
+	return f.Git.CreatePullRequest(ctx, branch, baseBranch, options)
+}
+
+// GetRFCContents returns the current contents of the RFC for the given pull request along with the sha of the file
+func (f *Faulty) GetRFCContents(ctx context.Context, branch string) (content *string, sha *string,
+	err error) {
+	if err = f.inject(ctx, "GetRFCContents"); err != nil {
+		return
+	}
+	return f.Git.GetRFCContents(ctx, branch)
+}
+
+// GetRFCContentsAt returns the contents of the RFC of the given branch as of the given commit sha
+func (f *Faulty) GetRFCContentsAt(ctx context.Context, branch string, ref string) (content *string, err error) {
+	if err = f.inject(ctx, "GetRFCContentsAt"); err != nil {
+		return
+	}
+	return f.Git.GetRFCContentsAt(ctx, branch, ref)
+}
+
+// GetRFCHistory returns the commits that modified the RFC file of the given branch, newest first
+func (f *Faulty) GetRFCHistory(ctx context.Context, branch string) (revisions []RFCRevision, err error) {
+	if err = f.inject(ctx, "GetRFCHistory"); err != nil {
+		return
+	}
+	return f.Git.GetRFCHistory(ctx, branch)
+}
+
+// RestoreFile commits the given raw content as the RFC file of the given PR, recreating the file if it was deleted
+func (f *Faulty) RestoreFile(ctx context.Context, pr PullRequest, content string, message string) (err error) {
+	if err = f.inject(ctx, "RestoreFile"); err != nil {
+		return
+	}
+	return f.Git.RestoreFile(ctx, pr, content, message)
+}
+
+// UpdateFile creates a commit to the RFC file of the given PR using the given data
+func (f *Faulty) UpdateFile(ctx context.Context, pr PullRequest, data *models.RFC) (err error) {
+	if err = f.inject(ctx, "UpdateFile"); err != nil {
+		return
+	}
+	return f.Git.UpdateFile(ctx, pr, data)
+}
+
+// GetPullRequest returns the most recent open pull request for the given branch
+func (f *Faulty) GetPullRequest(ctx context.Context, branch string) (pr PullRequest, err error) {
+	if err = f.inject(ctx, "GetPullRequest"); err != nil {
+		return
+	}
+	return f.Git.GetPullRequest(ctx, branch)
+}
+
+// GetPullRequests returns all pull requests with the given state and filters
+func (f *Faulty) GetPullRequests(ctx context.Context, state string, count int,
+	opts ...FilterOption) (prs PullRequests, err error) {
+	if err = f.inject(ctx, "GetPullRequests"); err != nil {
+		return
+	}
+	return f.Git.GetPullRequests(ctx, state, count, opts...)
+}
+
+// GetMergeability determines if the given pull request is mergeable (approvals, conflicts, ci...)
+func (f *Faulty) GetMergeability(ctx context.Context, pr PullRequest) (mergeable *bool, err error) {
+	if err = f.inject(ctx, "GetMergeability"); err != nil {
+		return
+	}
+	return f.Git.GetMergeability(ctx, pr)
+}
+
+// ExplainMergeability determines if the given pull request is mergeable, along with why it is not and the state of
+// the status contexts considered
+func (f *Faulty) ExplainMergeability(ctx context.Context,
+	pr PullRequest) (mergeability *models.Mergeability, err error) {
+	if err = f.inject(ctx, "ExplainMergeability"); err != nil {
+		return
+	}
+	return f.Git.ExplainMergeability(ctx, pr)
+}
+
+// ClosePullRequest closes the given pull request without merging it
+func (f *Faulty) ClosePullRequest(ctx context.Context, pr PullRequest) (err error) {
+	if err = f.inject(ctx, "ClosePullRequest"); err != nil {
+		return
+	}
+	return f.Git.ClosePullRequest(ctx, pr)
+}
+
+// MergePullRequest merges the given pull request and returns the sha
+func (f *Faulty) MergePullRequest(ctx context.Context, pr PullRequest) (sha *string, err error) {
+	if err = f.inject(ctx, "MergePullRequest"); err != nil {
+		return
+	}
+	return f.Git.MergePullRequest(ctx, pr)
+}
+
+// GetReviews returns all pull request reviews related to the given pull request
+func (f *Faulty) GetReviews(ctx context.Context, pr PullRequest) (reviews PullRequestReviews, err error) {
+	if err = f.inject(ctx, "GetReviews"); err != nil {
+		return
+	}
+	return f.Git.GetReviews(ctx, pr)
+}
+
+// CreateReview generates a pull request review on the given pull request using the given data
+func (f *Faulty) CreateReview(ctx context.Context, pr PullRequest, data *models.Review) (err error) {
+	if err = f.inject(ctx, "CreateReview"); err != nil {
+		return
+	}
+	return f.Git.CreateReview(ctx, pr, data)
+}
+
+// DismissApprovalReviews dismisses only the "approval" reviews in the given reviews from the given pull request
+func (f *Faulty) DismissApprovalReviews(ctx context.Context, reviews PullRequestReviews,
+	pr PullRequest) (err error) {
+	if err = f.inject(ctx, "DismissApprovalReviews"); err != nil {
+		return
+	}
+	return f.Git.DismissApprovalReviews(ctx, reviews, pr)
+}
+
+// GetReviewComments returns the review comments of the given pull request, oldest first
+func (f *Faulty) GetReviewComments(ctx context.Context, pr PullRequest) (comments []ReviewComment, err error) {
+	if err = f.inject(ctx, "GetReviewComments"); err != nil {
+		return
+	}
+	return f.Git.GetReviewComments(ctx, pr)
+}
+
+// EditReviewComment replaces the body of the review comment with the given ID on the given pull request
+func (f *Faulty) EditReviewComment(ctx context.Context, pr PullRequest, id string, body string) (err error) {
+	if err = f.inject(ctx, "EditReviewComment"); err != nil {
+		return
+	}
+	return f.Git.EditReviewComment(ctx, pr, id, body)
+}
+
+// DeleteReviewComment deletes the review comment with the given ID from the given pull request
+func (f *Faulty) DeleteReviewComment(ctx context.Context, pr PullRequest, id string) (err error) {
+	if err = f.inject(ctx, "DeleteReviewComment"); err != nil {
+		return
+	}
+	return f.Git.DeleteReviewComment(ctx, pr, id)
+}
+
+// GetUserLogin returns the Git username defined by the client
+func (f *Faulty) GetUserLogin(ctx context.Context) (login *string, err error) {
+	if err = f.inject(ctx, "GetUserLogin"); err != nil {
+		return
+	}
+	return f.Git.GetUserLogin(ctx)
+}
+
+// GetUserTeams returns a set of team slugs for the current authenticated user
+func (f *Faulty) GetUserTeams(ctx context.Context) (teams set.Set[string], err error) {
+	if err = f.inject(ctx, "GetUserTeams"); err != nil {
+		return
+	}
+	return f.Git.GetUserTeams(ctx)
+}
+
+// GetTeamMembers returns a set of logins for the members of the given team
+func (f *Faulty) GetTeamMembers(ctx context.Context, team string) (members set.Set[string], err error) {
+	if err = f.inject(ctx, "GetTeamMembers"); err != nil {
+		return
+	}
+	return f.Git.GetTeamMembers(ctx, team)
+}
+
+// RequestReviewers requests a review of the given pull request from each of the given logins
+func (f *Faulty) RequestReviewers(ctx context.Context, pr PullRequest, reviewers []string) (err error) {
+	if err = f.inject(ctx, "RequestReviewers"); err != nil {
+		return
+	}
+	return f.Git.RequestReviewers(ctx, pr, reviewers)
+}
+
+// RequestTeamReviewers requests a review of the given pull request from each of the given teams
+func (f *Faulty) RequestTeamReviewers(ctx context.Context, pr PullRequest, teams []string) (err error) {
+	if err = f.inject(ctx, "RequestTeamReviewers"); err != nil {
+		return
+	}
+	return f.Git.RequestTeamReviewers(ctx, pr, teams)
+}
+
+// CreateTag tags the given sha with the given name
+func (f *Faulty) CreateTag(ctx context.Context, sha string, name string) (err error) {
+	if err = f.inject(ctx, "CreateTag"); err != nil {
+		return
+	}
+	return f.Git.CreateTag(ctx, sha, name)
+}
+
+// CreateDeployment requests a deployment of the given pull request to the given environment and returns its ID
+func (f *Faulty) CreateDeployment(ctx context.Context, pr PullRequest, environment string) (id *string,
+	err error) {
+	if err = f.inject(ctx, "CreateDeployment"); err != nil {
+		return
+	}
+	return f.Git.CreateDeployment(ctx, pr, environment)
+}
+
+// GetDeploymentStatus returns the latest status of the deployment with the given ID
+func (f *Faulty) GetDeploymentStatus(ctx context.Context, deploymentID string) (status *DeploymentStatus,
+	err error) {
+	if err = f.inject(ctx, "GetDeploymentStatus"); err != nil {
+		return
+	}
+	return f.Git.GetDeploymentStatus(ctx, deploymentID)
+}
+
+// GetMissingPermissions returns a description of each permission Harmonia requires on the tracking repository that
+// the client's token lacks
+func (f *Faulty) GetMissingPermissions(ctx context.Context) (missing []string, err error) {
+	if err = f.inject(ctx, "GetMissingPermissions"); err != nil {
+		return
+	}
+	return f.Git.GetMissingPermissions(ctx)
+}
+
+// GetBranchProtection returns the protection the Git provider enforces on the base branch of the tracking repository
+func (f *Faulty) GetBranchProtection(ctx context.Context) (protection *BranchProtection, err error) {
+	if err = f.inject(ctx, "GetBranchProtection"); err != nil {
+		return
+	}
+	return f.Git.GetBranchProtection(ctx)
+}
+
+// GetArchiveIndex returns the contents of the archive index on the base branch, nil if no RFC was archived yet
+func (f *Faulty) GetArchiveIndex(ctx context.Context) (index *string, err error) {
+	if err = f.inject(ctx, "GetArchiveIndex"); err != nil {
+		return
+	}
+	return f.Git.GetArchiveIndex(ctx)
+}
+
+// GetSchemas returns the contents of the JSON files of the given directory on the base branch, keyed by file name
+func (f *Faulty) GetSchemas(ctx context.Context, directory string) (files map[string]string, err error) {
+	if err = f.inject(ctx, "GetSchemas"); err != nil {
+		return
+	}
+	return f.Git.GetSchemas(ctx, directory)
+}
+
+// ArchiveRFCs removes the RFC files of the given RFCs from the base branch and writes the given archive index
+func (f *Faulty) ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) (
+	err error) {
+	if err = f.inject(ctx, "ArchiveRFCs"); err != nil {
+		return
+	}
+	return f.Git.ArchiveRFCs(ctx, rfcIdentifiers, index, message)
+}
+
+// CommitFiles writes the given files, keyed by path, to the base branch in a single commit with the given message
+func (f *Faulty) CommitFiles(ctx context.Context, files map[string]string, message string) (committed bool,
+	err error) {
+	if err = f.inject(ctx, "CommitFiles"); err != nil {
+		return
+	}
+	return f.Git.CommitFiles(ctx, files, message)
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// TestFaulty tests that faults are only injected into the configured methods, failing calls at the error rate as if
+// the provider had failed them and delaying them by the latency
+func TestFaulty(t *testing.T) {
+	// arrange
+	git := InjectFaults(throttledGit{}, Faults{ErrorRate: 0.5, Methods: []string{"ExplainMergeability"}})
+	rolls := []float64{0.2, 0.7}
+	git.random = func() float64 {
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+
+	// act
+	_, failedErr := git.ExplainMergeability(context.Background(), nil)
+	mergeability, err := git.ExplainMergeability(context.Background(), nil)
+	_, loginErr := git.GetUserLogin(context.Background())
+
+	// assert
+	if !errors.Is(failedErr, ErrInjectedFault) || !errors.Is(failedErr, models.ErrProvider) {
+		t.Errorf("expected an injected provider error, got %v", failedErr)
+	}
+	if err != nil || mergeability == nil {
+		t.Errorf("expected the call above the error rate to be made, got %+v and %v", mergeability, err)
+	}
+	if errors.Is(loginErr, ErrInjectedFault) || !errors.Is(loginErr, ErrRateLimited) {
+		t.Errorf("expected the call of a method without faults to be passed through, got %v", loginErr)
+	}
+
+	// act & assert calls are delayed by the latency unless they are canceled first
+	git = InjectFaults(throttledGit{}, Faults{Latency: 20 * time.Millisecond})
+	start := time.Now()
+	if _, err = git.ExplainMergeability(context.Background(), nil); err != nil || time.Since(start) < 20*time.Millisecond {
+		t.Errorf("expected the call to be delayed, got %v after %s", err, time.Since(start))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = git.ExplainMergeability(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled call to fail, got %v", err)
+	}
+}
+
+// TestConfiguredFaults tests that faults are read from the configuration and refused in production
+func TestConfiguredFaults(t *testing.T) {
+	// arrange
+	testCases := []struct {
+		environ  []string
+		expected *Faults
+		err      error
+	}{
+		{
+			environ: []string{"ENVIRONMENT=production"},
+		},
+		{
+			environ: []string{"ENVIRONMENT=staging", "GIT_FAULT_RATE=0.1", "GIT_FAULT_LATENCY=2s",
+				"GIT_FAULT_METHODS=UpdateFile, MergePullRequest"},
+			expected: &Faults{ErrorRate: 0.1, Latency: 2 * time.Second, Methods: []string{"UpdateFile",
+				"MergePullRequest"}},
+		},
+		{
+			environ:  []string{"IS_LOCAL=true", "GIT_FAULT_RATE=1"},
+			expected: &Faults{ErrorRate: 1},
+		},
+		{
+			environ: []string{"ENVIRONMENT=prod", "GIT_FAULT_RATE=0.1"},
+			err:     ErrFaultsInProduction,
+		},
+		{
+			environ: []string{"GIT_FAULT_LATENCY=2s"},
+			err:     ErrFaultsInProduction,
+		},
+	}
+
+	for _, testCase := range testCases {
+		configure(t, testCase.environ...)
+
+		// act
+		faults, err := ConfiguredFaults()
+
+		// assert
+		if !errors.Is(err, testCase.err) {
+			t.Errorf("expected error %v for %v, got %v", testCase.err, testCase.environ, err)
+		}
+		if !reflect.DeepEqual(faults, testCase.expected) {
+			t.Errorf("unexpected faults for %v: %+v", testCase.environ, faults)
+		}
+	}
+
+	// act & assert malformed rates are rejected
+	configure(t, "IS_LOCAL=true", "GIT_FAULT_RATE=2")
+	if _, err := ConfiguredFaults(); err == nil {
+		t.Errorf("expected a malformed rate to be rejected")
+	}
+}
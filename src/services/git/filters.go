@@ -0,0 +1,175 @@
+// This holds the composition of pull request filters and the registry of named filters, so the filters of a query
+// like GetPullRequests can be built from a request
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Names of the filters registered by default
+const (
+	OWNER_FILTER         string = "owner"
+	MERGED_FILTER        string = "merged"
+	LABEL_FILTER         string = "label"
+	CREATED_AFTER_FILTER string = "createdAfter"
+	HEAD_PREFIX_FILTER   string = "headPrefix"
+)
+
+// ErrInvalidFilter is returned (wrapped) when building a filter that is not registered or from a malformed argument
+var ErrInvalidFilter = models.NewError(models.ErrInvalid, models.InvalidFilterCode, "invalid pull request filter")
+
+// FilterConstructor returns a filter of the pull requests of the given Git implementation built from the given
+// argument, ErrInvalidFilter is returned (wrapped) if the argument is malformed
+type FilterConstructor func(git Git, argument string) (FilterOption, error)
+
+// filters holds the constructor of each registered filter
+var filters = struct {
+	sync.RWMutex
+	constructors map[string]FilterConstructor
+}{constructors: map[string]FilterConstructor{
+	OWNER_FILTER: func(git Git, argument string) (FilterOption, error) {
+		return git.WithOwner(&argument), nil
+	},
+	MERGED_FILTER: func(git Git, argument string) (FilterOption, error) {
+		merged, err := strconv.ParseBool(argument)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s expects true or false, got %s", ErrInvalidFilter, MERGED_FILTER, argument)
+		}
+		return git.IsMerged(&merged), nil
+	},
+	LABEL_FILTER: func(git Git, argument string) (FilterOption, error) {
+		if capabilities := git.Capabilities(); !capabilities.Labels {
+			return nil, fmt.Errorf("%w: %s is not supported, %s pull requests have no labels", ErrInvalidFilter,
+				LABEL_FILTER, capabilities.Provider)
+		}
+		return WithLabel(git, argument), nil
+	},
+	CREATED_AFTER_FILTER: func(git Git, argument string) (FilterOption, error) {
+		after, err := time.Parse(time.RFC3339, argument)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s expects an RFC 3339 time, got %s", ErrInvalidFilter, CREATED_AFTER_FILTER,
+				argument)
+		}
+		return CreatedAfter(git, after), nil
+	},
+	HEAD_PREFIX_FILTER: func(git Git, argument string) (FilterOption, error) {
+		return WithHeadPrefix(git, argument), nil
+	},
+}}
+
+// RegisterFilter makes the given filter available through NewFilter, replacing any constructor registered under its
+// name
+func RegisterFilter(name string, constructor FilterConstructor) {
+	filters.Lock()
+	defer filters.Unlock()
+
+	filters.constructors[name] = constructor
+}
+
+// Filters returns the names of all registered filters, sorted
+func Filters() []string {
+	filters.RLock()
+	defer filters.RUnlock()
+
+	names := make([]string, 0, len(filters.constructors))
+	for name := range filters.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewFilter returns the registered filter with the given name of the pull requests of the given Git implementation,
+// built from the given argument. ErrInvalidFilter is returned (wrapped) if no filter is registered under the name or
+// the argument is malformed
+func NewFilter(git Git, name string, argument string) (FilterOption, error) {
+	filters.RLock()
+	constructor, ok := filters.constructors[name]
+	filters.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown filter %s, expected one of %s", ErrInvalidFilter, name,
+			strings.Join(Filters(), ", "))
+	}
+
+	return constructor(git, argument)
+}
+
+// And returns a FilterOption that returns true if a given PR satisfies all given filters. If no filter is given,
+// returns true.
+func And(filters ...FilterOption) FilterOption {
+	return func(pr PullRequest) bool {
+		for _, filter := range filters {
+			if !filter(pr) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a FilterOption that returns true if a given PR satisfies any of the given filters. If no filter is
+// given, returns false.
+func Or(filters ...FilterOption) FilterOption {
+	return func(pr PullRequest) bool {
+		for _, filter := range filters {
+			if filter(pr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a FilterOption that returns true if a given PR does not satisfy the given filter
+func Not(filter FilterOption) FilterOption {
+	return func(pr PullRequest) bool {
+		return !filter(pr)
+	}
+}
+
+// withDetails returns a FilterOption that returns true if the details of a given PR, extracted by the given Git
+// implementation, satisfy the given condition. PRs whose details cannot be extracted are filtered out
+func withDetails(git Git, condition func(details *PullRequestDetails) bool) FilterOption {
+	return func(pr PullRequest) bool {
+		details, err := git.GetPullRequestDetails(pr)
+		if err != nil {
+			return false
+		}
+		return condition(details)
+	}
+}
+
+// WithLabel returns a FilterOption that returns true if a given PR is labelled with the given label
+func WithLabel(git Git, label string) FilterOption {
+	return withDetails(git, func(details *PullRequestDetails) bool {
+		for _, prLabel := range details.Labels {
+			if prLabel == label {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CreatedAfter returns a FilterOption that returns true if a given PR was created after the given time
+func CreatedAfter(git Git, after time.Time) FilterOption {
+	return withDetails(git, func(details *PullRequestDetails) bool {
+		return details.CreatedAt.After(after)
+	})
+}
+
+// WithHeadPrefix returns a FilterOption that returns true if the head branch of a given PR, named after its RFC,
+// starts with the given prefix
+func WithHeadPrefix(git Git, prefix string) FilterOption {
+	return withDetails(git, func(details *PullRequestDetails) bool {
+		return strings.HasPrefix(details.RFCIdentifier, prefix)
+	})
+}
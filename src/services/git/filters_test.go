@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v40/github"
+)
+
+// TestFilters tests that the registered filters and their composition select the expected pull requests
+func TestFilters(t *testing.T) {
+	// arrange
+	g := &GitHub{}
+	newPr := func(ref string, created time.Time, labels ...string) PullRequest {
+		pr := &github.PullRequest{Head: &github.PullRequestBranch{Ref: &ref}, CreatedAt: &created}
+		for i := range labels {
+			pr.Labels = append(pr.Labels, &github.Label{Name: &labels[i]})
+		}
+		return pr
+	}
+	catalog := newPr("1662-catalog", time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), "breaking-change")
+	playback := newPr("1663-playback", time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	label, _ := NewFilter(g, LABEL_FILTER, "breaking-change")
+	createdAfter, _ := NewFilter(g, CREATED_AFTER_FILTER, "2022-04-01T00:00:00Z")
+	headPrefix, _ := NewFilter(g, HEAD_PREFIX_FILTER, "1662")
+	testCases := map[string]struct {
+		filter   FilterOption
+		expected []bool
+	}{
+		"label":           {label, []bool{true, false}},
+		"createdAfter":    {createdAfter, []bool{false, true}},
+		"headPrefix":      {headPrefix, []bool{true, false}},
+		"and":             {And(label, createdAfter), []bool{false, false}},
+		"or":              {Or(label, createdAfter), []bool{true, true}},
+		"not":             {Not(headPrefix), []bool{false, true}},
+		"empty and":       {And(), []bool{true, true}},
+		"empty or":        {Or(), []bool{false, false}},
+		"nested":          {Or(And(label, headPrefix), Not(Or(label, createdAfter))), []bool{true, false}},
+		"details missing": {WithLabel(&Bitbucket{}, "breaking-change"), []bool{false, false}},
+	}
+
+	// assert
+	for name, testCase := range testCases {
+		for i, pr := range []PullRequest{catalog, playback} {
+			if actual := testCase.filter(pr); actual != testCase.expected[i] {
+				t.Errorf("unexpected result of the %s filter for PR %d. expected: %v, actual: %v", name, i,
+					testCase.expected[i], actual)
+			}
+		}
+	}
+}
+
+// TestNewFilter tests that building a filter that is not registered or from a malformed argument fails
+func TestNewFilter(t *testing.T) {
+	// arrange
+	g := &GitHub{}
+	RegisterFilter("draft", func(git Git, argument string) (FilterOption, error) {
+		return func(pr PullRequest) bool { return pr.(*github.PullRequest).GetDraft() }, nil
+	})
+	defer func() {
+		filters.Lock()
+		delete(filters.constructors, "draft")
+		filters.Unlock()
+	}()
+
+	// act
+	draft, draftErr := NewFilter(g, "draft", "")
+	_, unknownErr := NewFilter(g, "reviewer", "tstark")
+	_, mergedErr := NewFilter(g, MERGED_FILTER, "yes")
+	_, createdAfterErr := NewFilter(g, CREATED_AFTER_FILTER, "yesterday")
+	_, labelErr := NewFilter(&Bitbucket{}, LABEL_FILTER, "breaking-change")
+
+	// assert
+	isDraft := true
+	if draftErr != nil || !draft(&github.PullRequest{Draft: &isDraft}) {
+		t.Errorf("expected the registered filter to be built, err: %v", draftErr)
+	}
+	for _, err := range []error{unknownErr, mergedErr, createdAfterErr, labelErr} {
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("expected an invalid filter error, got %v", err)
+		}
+	}
+}
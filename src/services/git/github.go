@@ -4,13 +4,19 @@ package git
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v40/github"
 	"golang.org/x/oauth2"
 	"harmonia-example.io/src/models"
-	"harmonia-example.io/src/services/config"
+	"harmonia-example.io/src/services/description"
+	"harmonia-example.io/src/services/logging"
 	"harmonia-example.io/src/services/set"
 )
 
@@ -22,29 +28,51 @@ const (
 type GitHub struct {
 	AccessToken        *string
 	client             *github.Client
+	owner              string
 	trackingRepository *string
+	requiredContexts   []string
 }
 
-// NewGitHub returns a GitHub Git implementation
-func NewGitHub(ctx context.Context, accessToken string) (*GitHub, error) {
+// NewGitHub returns a GitHub Git implementation of the given tracking repository
+func NewGitHub(ctx context.Context, accessToken string, repository Repository) (*GitHub, error) {
 	// create instance with new client
-	g := &GitHub{AccessToken: &accessToken}
+	g := &GitHub{AccessToken: &accessToken, owner: repository.Owner, trackingRepository: &repository.Name}
 	if err := g.setClient(ctx); err != nil {
 		return nil, err
 	}
-
-	// set tracking repository - env var if local, else AWS param
-	repo, err := config.GetTrackingRepo()
-	if err != nil {
-		return nil, err
-	}
-	g.trackingRepository = repo
+	g.requiredContexts = configuration().RequiredStatusContexts()
 
 	return g, nil
 }
 
+// Repository returns the tracking repository the client operates on
+func (g *GitHub) Repository() Repository {
+	return Repository{Owner: g.owner, Name: *g.trackingRepository}
+}
+
+// Capabilities returns the features GitHub supports, all of them
+func (g *GitHub) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		Provider:          GITHUB_PROVIDER,
+		Labels:            true,
+		DraftPullRequests: true,
+		TeamReviewers:     true,
+		RequiredApprovals: true,
+		CheckRuns:         true,
+		RequiredContexts:  true,
+		DeploymentGates:   true,
+		Webhooks:          true,
+	}
+}
+
 // setClient sets a Go-GitHub client on the caller that can be used to interact with GitHub
 func (g *GitHub) setClient(ctx context.Context) error {
+	// without a token the client is anonymous, which can only read public repositories
+	if *g.AccessToken == "" {
+		g.client = github.NewClient(nil)
+		return nil
+	}
+
 	// establish token config for git
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: *g.AccessToken})
 	tc := oauth2.NewClient(ctx, ts)
@@ -62,23 +90,21 @@ func (g *GitHub) CreateBranch(ctx context.Context, branch string, baseBranch str
 	var err error
 
 	// get a reference to the base branch
-	if base, _, err = g.client.Repositories.GetBranch(ctx, OWNER, *g.trackingRepository, baseBranch, true); err != nil {
-		errStr := "error retrieving base branch"
-		fmt.Println(errStr)
-		return err
+	if base, _, err = g.client.Repositories.GetBranch(ctx, g.owner, *g.trackingRepository, baseBranch, true); err != nil {
+		logging.FromContext(ctx).Error("error retrieving base branch", logging.ERROR_KEY, err)
+		return mapError(err)
 	}
 
 	// create branch with the given name
 	targetRef := fmt.Sprintf("refs/heads/%s", branch)
 	if _, _, err = g.client.Git.CreateRef(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		&github.Reference{Ref: &targetRef, Object: &github.GitObject{SHA: base.Commit.SHA}},
 	); err != nil {
-		errStr := "error creating new branch: %s"
-		fmt.Println(errStr)
-		return err
+		logging.FromContext(ctx).Error("error creating new branch", "branch", branch, logging.ERROR_KEY, err)
+		return mapError(err)
 	}
 
 	return nil
@@ -93,13 +119,13 @@ func (g *GitHub) DeleteBranch(ctx context.Context, branch string) error {
 	targetRef := fmt.Sprintf("heads/%s", branch)
 	if _, err = g.client.Git.DeleteRef(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		targetRef,
 	); err != nil {
-		errStr := "Unable to automatically delete branch: %s, please delete manually"
-		fmt.Println(errStr)
-		return err
+		logging.FromContext(ctx).Error("unable to automatically delete branch, please delete manually", "branch", branch,
+			logging.ERROR_KEY, err)
+		return mapError(err)
 	}
 
 	return nil
@@ -116,8 +142,7 @@ func (g *GitHub) CreateFile(ctx context.Context, branch string, directory string
 
 	// transform data to bytes, which API accepts
 	if jsonBytes, err = json.Marshal(data); err != nil {
-		errStr := "json data marshal error"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
 		return err
 	}
 
@@ -125,7 +150,7 @@ func (g *GitHub) CreateFile(ctx context.Context, branch string, directory string
 	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, directory, RFC_FILE_NAME)
 	if _, _, err = g.client.Repositories.CreateFile(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		path,
 		&github.RepositoryContentFileOptions{
@@ -134,38 +159,64 @@ func (g *GitHub) CreateFile(ctx context.Context, branch string, directory string
 			Branch:  &branch,
 		},
 	); err != nil {
-		errStr := "GitHub file creation error"
-		fmt.Println(errStr)
-		return err
+		logging.FromContext(ctx).Error("GitHub file creation error", logging.ERROR_KEY, err)
+		return mapError(err)
 	}
 
 	return nil
 }
 
-// CreatePullRequest opens a new pull request of the given branch towards the given base branch
-func (g *GitHub) CreatePullRequest(ctx context.Context, branch string, baseBranch string) error {
+// CreatePullRequest opens a new pull request of the given branch towards the given base branch with the given options
+func (g *GitHub) CreatePullRequest(ctx context.Context, branch string, baseBranch string,
+	options PullRequestOptions) error {
 	// init. vars to maintain scope beyond "if" statements
 	var err error
+	var githubPr *github.PullRequest
 
-	// PR title
-	title := fmt.Sprintf("RFC: %s", branch)
-	body := fmt.Sprintf("Automated creation of RFC %s PR", branch)
+	// PR body, the rendered description of the RFC unless it could not be rendered
+	body := options.Description
+	if body == "" {
+		body = fmt.Sprintf("Automated creation of RFC %s PR", branch)
+	}
 
 	// open PR
-	if _, _, err = g.client.PullRequests.Create(
+	if githubPr, _, err = g.client.PullRequests.Create(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		&github.NewPullRequest{
-			Title: &title,
+			Title: &options.Title,
 			Head:  &branch,
 			Base:  &baseBranch,
 			Body:  &body,
 		},
 	); err != nil {
-		errStr := "GitHub PR creation error for branch: %s"
-		fmt.Printf(errStr, branch)
-		return err
+		logging.FromContext(ctx).Error("GitHub PR creation error for branch", "branch", branch, logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	// the PR is open at this point, failing to triage it is not worth failing its creation
+	if len(options.Labels) > 0 {
+		if _, _, err = g.client.Issues.AddLabelsToIssue(ctx, g.owner, *g.trackingRepository, githubPr.GetNumber(),
+			options.Labels); err != nil {
+			logging.FromContext(ctx).Warn("unable to label new PR", "branch", branch, logging.ERROR_KEY, err)
+		}
+	}
+	if len(options.Assignees) > 0 {
+		if _, _, err = g.client.Issues.AddAssignees(ctx, g.owner, *g.trackingRepository, githubPr.GetNumber(),
+			options.Assignees); err != nil {
+			logging.FromContext(ctx).Warn("unable to assign new PR", "branch", branch, logging.ERROR_KEY, err)
+		}
+	}
+	if len(options.Reviewers) > 0 || len(options.TeamReviewers) > 0 {
+		if _, _, err = g.client.PullRequests.RequestReviewers(ctx, g.owner, *g.trackingRepository,
+			githubPr.GetNumber(), github.ReviewersRequest{
+				Reviewers:     options.Reviewers,
+				TeamReviewers: options.TeamReviewers,
+			}); err != nil {
+			logging.FromContext(ctx).Warn("unable to request reviewers of new PR", "branch", branch,
+				logging.ERROR_KEY, err)
+		}
 	}
 
 	return nil
@@ -174,31 +225,51 @@ func (g *GitHub) CreatePullRequest(ctx context.Context, branch string, baseBranc
 // GetRFCContents returns the current contents of the RFC on the given branch in the given directory
 // The sha of the file is also returned
 func (g *GitHub) GetRFCContents(ctx context.Context, branch string) (*string, *string, error) {
+	return g.getRFCContents(ctx, branch, branch)
+}
+
+// GetRFCContentsAt returns the contents of the RFC of the given branch as of the given commit sha
+func (g *GitHub) GetRFCContentsAt(ctx context.Context, branch string, ref string) (*string, error) {
+	content, _, err := g.getRFCContents(ctx, branch, ref)
+	return content, err
+}
+
+// getRFCContents returns the contents and sha of the RFC file of the given branch at the given ref
+// ErrRFCFileNotFound is returned (wrapped) if the file does not exist at the ref
+func (g *GitHub) getRFCContents(ctx context.Context, branch string, ref string) (*string, *string, error) {
 	// init. vars to maintain scope beyond "if" statements
 	var err error
 	var repositoryContent *github.RepositoryContent
+	var response *github.Response
 	var content string
 
 	// retrieve file contents
 	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, branch, RFC_FILE_NAME)
-	if repositoryContent, _, _, err = g.client.Repositories.GetContents(
+	if repositoryContent, _, response, err = g.client.Repositories.GetContents(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		path,
 		&github.RepositoryContentGetOptions{
-			Ref: branch,
+			Ref: ref,
 		},
 	); err != nil {
-		errStr := "unable to retrieve repository content"
-		fmt.Println(errStr)
-		return nil, nil, err
+		logging.FromContext(ctx).Error("unable to retrieve repository content", logging.ERROR_KEY, err)
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil, &ProviderError{Kind: ErrNotFound, Err: fmt.Errorf("%w: %s at %s", ErrRFCFileNotFound, path, ref)}
+		}
+		return nil, nil, mapError(err)
+	}
+
+	// the path resolves to a directory rather than a file
+	if repositoryContent == nil {
+		logging.FromContext(ctx).Error("RFC path is not a file")
+		return nil, nil, &ProviderError{Kind: ErrNotFound, Err: fmt.Errorf("%w: %s at %s", ErrRFCFileNotFound, path, ref)}
 	}
 
 	// extract content for file and retrieve sha
 	if content, err = repositoryContent.GetContent(); err != nil {
-		errStr := "unable to extract file content from repository content"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error("unable to extract file content from repository content", logging.ERROR_KEY, err)
 		return nil, nil, err
 	}
 	sha := repositoryContent.GetSHA()
@@ -206,13 +277,57 @@ func (g *GitHub) GetRFCContents(ctx context.Context, branch string) (*string, *s
 	return &content, &sha, nil
 }
 
+// GetRFCHistory returns the commits that modified the RFC file of the given branch, newest first. Paginated output
+func (g *GitHub) GetRFCHistory(ctx context.Context, branch string) ([]RFCRevision, error) {
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var commits []*github.RepositoryCommit
+	var response *github.Response
+	var revisions []RFCRevision
+
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, branch, RFC_FILE_NAME)
+	opts := &github.CommitsListOptions{SHA: branch, Path: path, ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		if commits, response, err = g.client.Repositories.ListCommits(
+			ctx,
+			g.owner,
+			*g.trackingRepository,
+			opts,
+		); err != nil {
+			logging.FromContext(ctx).Error("unable to list RFC file commits", logging.ERROR_KEY, err)
+			return nil, mapError(err)
+		}
+
+		for _, commit := range commits {
+			revision := RFCRevision{Sha: commit.GetSHA()}
+			if commit.Commit != nil {
+				revision.Message = commit.Commit.GetMessage()
+				revision.Author = commit.Commit.GetAuthor().GetName()
+				revision.Timestamp = commit.Commit.GetAuthor().GetDate()
+			}
+			if commit.Author != nil {
+				revision.Author = commit.Author.GetLogin()
+			}
+			revisions = append(revisions, revision)
+		}
+
+		// 0 value indicates there is no next page and the results are exhausted
+		if response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	return revisions, nil
+}
+
 // GetFileSha returns the current RFC file sha for the given pull request
 func (g *GitHub) getFileSha(ctx context.Context, pr PullRequest) (*string, error) {
 	// ensure given pr is of github type
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
@@ -224,16 +339,15 @@ func (g *GitHub) getFileSha(ctx context.Context, pr PullRequest) (*string, error
 	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, *githubPr.Head.Ref, RFC_FILE_NAME)
 	if repositoryContent, _, _, err = g.client.Repositories.GetContents(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		path,
 		&github.RepositoryContentGetOptions{
 			Ref: *githubPr.Head.Ref,
 		},
 	); err != nil {
-		errStr := "unable to retrieve repository content for sha extraction"
-		fmt.Println(errStr)
-		return nil, err
+		logging.FromContext(ctx).Error("unable to retrieve repository content for sha extraction", logging.ERROR_KEY, err)
+		return nil, mapError(err)
 	}
 
 	return repositoryContent.SHA, err
@@ -252,7 +366,7 @@ func (g *GitHub) UpdateFile(ctx context.Context, pr PullRequest, data *models.RF
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return fmt.Errorf(errStr)
 	}
 
@@ -263,8 +377,7 @@ func (g *GitHub) UpdateFile(ctx context.Context, pr PullRequest, data *models.RF
 
 	// transform data to bytes, which API accepts
 	if jsonBytes, err = json.Marshal(data); err != nil {
-		errStr := "json data marshal error"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error("json data marshal error", logging.ERROR_KEY, err)
 		return err
 	}
 
@@ -272,7 +385,7 @@ func (g *GitHub) UpdateFile(ctx context.Context, pr PullRequest, data *models.RF
 	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, *githubPr.Head.Ref, RFC_FILE_NAME)
 	if _, _, err = g.client.Repositories.UpdateFile(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		path,
 		&github.RepositoryContentFileOptions{
@@ -282,11 +395,58 @@ func (g *GitHub) UpdateFile(ctx context.Context, pr PullRequest, data *models.RF
 			SHA:     sha,
 		},
 	); err != nil {
-		errStr := "GitHub update file error"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error("GitHub update file error", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	// the file is committed at this point, failing to refresh the description is not worth failing the update
+	if body, renderErr := description.Default.Render(*githubPr.Head.Ref, data); renderErr != nil {
+		logging.FromContext(ctx).Warn("unable to render PR description", logging.ERROR_KEY, renderErr)
+	} else if _, _, err = g.client.PullRequests.Edit(ctx, g.owner, *g.trackingRepository, githubPr.GetNumber(),
+		&github.PullRequest{Body: &body}); err != nil {
+		logging.FromContext(ctx).Warn("unable to update PR description", logging.ERROR_KEY, err)
+	}
+
+	return nil
+}
+
+// RestoreFile commits the given raw content as the RFC file of the given PR, recreating the file if it was deleted
+func (g *GitHub) RestoreFile(ctx context.Context, pr PullRequest, content string, message string) error {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return fmt.Errorf(errStr)
+	}
+
+	// retrieve file sha if the file still exists - the sha is omitted to recreate a deleted file
+	var sha *string
+	_, existingSha, err := g.getRFCContents(ctx, *githubPr.Head.Ref, *githubPr.Head.Ref)
+	if err == nil {
+		sha = existingSha
+	} else if !errors.Is(err, ErrRFCFileNotFound) {
 		return err
 	}
 
+	// write the file in the repo
+	path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, *githubPr.Head.Ref, RFC_FILE_NAME)
+	if _, _, err = g.client.Repositories.UpdateFile(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		path,
+		&github.RepositoryContentFileOptions{
+			Message: &message,
+			Content: []byte(content),
+			Branch:  githubPr.Head.Ref,
+			SHA:     sha,
+		},
+	); err != nil {
+		logging.FromContext(ctx).Error("GitHub restore file error", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
 	return nil
 }
 
@@ -299,22 +459,21 @@ func (g *GitHub) GetPullRequest(ctx context.Context, branch string) (PullRequest
 	// retrieve PRs
 	if prs, _, err = g.client.PullRequests.List(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		&github.PullRequestListOptions{
 			State: ALL_PR_FILTER,
-			Head:  fmt.Sprintf("%s:%s", OWNER, branch),
+			Head:  fmt.Sprintf("%s:%s", g.owner, branch),
 		},
 	); err != nil {
-		errStr := "unable to fetch PRs"
-		fmt.Println(errStr)
-		return nil, err
+		logging.FromContext(ctx).Error("unable to fetch PRs", logging.ERROR_KEY, err)
+		return nil, mapError(err)
 	}
 
 	// assert we only got 1 PR back
 	if len(prs) != 1 {
 		errStr := "exactly one PR was NOT returned"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
@@ -352,7 +511,7 @@ func (g *GitHub) GetPullRequests(ctx context.Context, state string, count int, o
 	for retrieved < count || count == -1 { // loop until results are exhausted if count is -1
 		if results, response, err = g.client.PullRequests.List(
 			ctx,
-			OWNER,
+			g.owner,
 			*g.trackingRepository,
 			&github.PullRequestListOptions{
 				State: state,
@@ -362,9 +521,8 @@ func (g *GitHub) GetPullRequests(ctx context.Context, state string, count int, o
 				},
 			},
 		); err != nil {
-			errStr := "unable to fetch PRs"
-			fmt.Println(errStr)
-			return nil, err
+			logging.FromContext(ctx).Error("unable to fetch PRs", logging.ERROR_KEY, err)
+			return nil, mapError(err)
 		}
 
 		// serialize
@@ -395,40 +553,42 @@ func (g *GitHub) GetPullRequests(ctx context.Context, state string, count int, o
 
 // GetMergeability determines if the given pull request is mergeable (approvals, conflicts, ci...)
 func (g *GitHub) GetMergeability(ctx context.Context, pr PullRequest) (*bool, error) {
+	mergeability, err := g.ExplainMergeability(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeability.Mergeable, nil
+}
+
+// ExplainMergeability determines if the given pull request is mergeable, along with why it is not and the state of
+// the status contexts considered. Without required contexts the mergeable state computed by GitHub must be clean, with
+// them it may also be unstable (optional contexts failing) as long as every required commit status or check run passed
+func (g *GitHub) ExplainMergeability(ctx context.Context, pr PullRequest) (*models.Mergeability, error) {
 	// ensure given pr is of github type
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
 	// init. vars to maintain state beyond "if" statements
 	var err error
-	var status *github.CombinedStatus
-
-	// poll for commit status and allow time for it to stabilize, within reason
-	for retryCount := 0; retryCount < MERGEABILITY_RETRY_COUNT; retryCount++ {
-		// get combined status - this represents overall status, taking all checks into account
-		if status, _, err = g.client.Repositories.GetCombinedStatus(
-			ctx,
-			OWNER,
-			*g.trackingRepository,
-			*githubPr.Head.Ref,
-			&github.ListOptions{},
-		); err != nil {
-			errStr := "unable to retrieve ref combined status"
-			fmt.Println(errStr)
-			return nil, err
-		}
-
-		// check and see if the state is still pending, if so, wait a set amount of time and a re-poll
-		if status.State != nil && *status.State == MERGEABILITY_PENDING_STATE {
-			time.Sleep(time.Duration(MERGEABILITY_WAIT_TIME) * time.Second)
-			continue
+	var contexts map[string]string
+
+	// poll for the considered contexts and allow time for them to complete, within reason
+	// any pending context is re-polled after a backoff, or as soon as a webhook announces a change
+	ref := githubPr.GetHead().GetRef()
+	if err = Polling.poll(ctx, ref, func() (bool, error) {
+		states, err := g.getContextStates(ctx, ref)
+		if err != nil {
+			return false, err
 		}
-
-		break
+		contexts = consideredContexts(g.requiredContexts, states)
+		return !contextsPending(contexts), nil
+	}); err != nil {
+		return nil, err
 	}
 
 	// retrieve pr
@@ -436,38 +596,131 @@ func (g *GitHub) GetMergeability(ctx context.Context, pr PullRequest) (*bool, er
 	// it. According to the docs, mergeable state is calculated in the background by GitHub so polling is necessary here
 	// as well.
 	// https://docs.github.com/en/rest/reference/pulls#get-a-pull-request
-	for retryCount := 0; retryCount < MERGEABILITY_RETRY_COUNT; retryCount++ {
+	if err = Polling.poll(ctx, ref, func() (bool, error) {
 		// not using the "getPullRequest" function here because it uses the list functionality, which doesn't calculate
 		// the mergeable state
-		if githubPr, _, err = g.client.PullRequests.Get(
-			ctx,
-			OWNER,
-			*g.trackingRepository,
-			*githubPr.Number,
-		); err != nil {
-			errStr := "unable to retrieve pr for mergeability check"
-			fmt.Println(errStr)
-			return nil, err
+		fetched, _, err := g.client.PullRequests.Get(ctx, g.owner, *g.trackingRepository, *githubPr.Number)
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve pr for mergeability check", logging.ERROR_KEY, err)
+			return false, mapError(err)
 		}
+		githubPr = fetched
 
 		// if still calculating, wait and re-poll
-		if githubPr.MergeableState == nil || *githubPr.MergeableState == MERGEABILITY_UNKNOWN_STATE {
-			time.Sleep(time.Duration(MERGEABILITY_WAIT_TIME) * time.Second)
-			continue
-		}
-
-		break
+		return githubPr.MergeableState != nil && *githubPr.MergeableState != MERGEABILITY_UNKNOWN_STATE, nil
+	}); err != nil {
+		return nil, err
 	}
 
 	// mergeability was never able to be determined
 	if githubPr.MergeableState == nil || *githubPr.MergeableState == MERGEABILITY_UNKNOWN_STATE {
 		errStr := "unable to determine mergeability of rfc"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
-	mergeable := *githubPr.MergeableState == MERGEABILITY_CLEAN_STATE
-	return &mergeable, nil
+	// https://docs.github.com/en/graphql/reference/enums#mergestatestatus
+	var reasons []string
+	switch state := *githubPr.MergeableState; state {
+	case MERGEABILITY_CLEAN_STATE, "has_hooks":
+	case "unstable":
+		// only contexts that are not required by branch protection are failing
+		if len(g.requiredContexts) == 0 {
+			reasons = append(reasons, "a status check is not passing")
+		}
+	case "dirty":
+		reasons = append(reasons, "the pull request has merge conflicts")
+	case "blocked":
+		reasons = append(reasons, "the pull request is blocked by branch protection rules")
+	case "behind":
+		reasons = append(reasons, "the pull request branch is behind its base branch")
+	case "draft":
+		reasons = append(reasons, "the pull request is a draft")
+	default:
+		reasons = append(reasons, fmt.Sprintf("the pull request mergeable state is %s", state))
+	}
+
+	return newMergeability(g.requiredContexts, contexts, reasons...), nil
+}
+
+// getContextStates returns the state of each commit status context and check run of the given ref, keyed by context
+// or check name
+func (g *GitHub) getContextStates(ctx context.Context, ref string) (map[string]string, error) {
+	contexts := map[string]string{}
+
+	// get combined status - this represents the latest status of each context
+	status, _, err := g.client.Repositories.GetCombinedStatus(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		ref,
+		&github.ListOptions{PerPage: 100},
+	)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve ref combined status", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+	for _, repoStatus := range status.Statuses {
+		switch repoStatus.GetState() {
+		case "success":
+			setContextState(contexts, repoStatus.GetContext(), CONTEXT_SUCCESS_STATE)
+		case MERGEABILITY_PENDING_STATE:
+			setContextState(contexts, repoStatus.GetContext(), CONTEXT_PENDING_STATE)
+		default:
+			setContextState(contexts, repoStatus.GetContext(), CONTEXT_FAILURE_STATE)
+		}
+	}
+
+	// check runs (GitHub Actions, GitHub Apps) are not part of the combined status
+	checks, _, err := g.client.Checks.ListCheckRunsForRef(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		ref,
+		&github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}},
+	)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve ref check runs", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+	for _, check := range checks.CheckRuns {
+		switch {
+		case check.GetStatus() != "completed":
+			setContextState(contexts, check.GetName(), CONTEXT_PENDING_STATE)
+		case check.GetConclusion() == "success" || check.GetConclusion() == "neutral" ||
+			check.GetConclusion() == "skipped":
+			setContextState(contexts, check.GetName(), CONTEXT_SUCCESS_STATE)
+		default:
+			setContextState(contexts, check.GetName(), CONTEXT_FAILURE_STATE)
+		}
+	}
+
+	return contexts, nil
+}
+
+// ClosePullRequest closes the given pull request without merging it
+func (g *GitHub) ClosePullRequest(ctx context.Context, pr PullRequest) error {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return fmt.Errorf(errStr)
+	}
+
+	state := CLOSED_STATE
+	if _, _, err := g.client.PullRequests.Edit(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		*githubPr.Number,
+		&github.PullRequest{State: &state},
+	); err != nil {
+		logging.FromContext(ctx).Error("unable to close pull request", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
 }
 
 // MergePullRequest merges the given pull request and returns the sha
@@ -476,7 +729,7 @@ func (g *GitHub) MergePullRequest(ctx context.Context, pr PullRequest) (*string,
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
@@ -490,7 +743,7 @@ func (g *GitHub) MergePullRequest(ctx context.Context, pr PullRequest) (*string,
 	// merge
 	if res, _, err = g.client.PullRequests.Merge(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		*githubPr.Number,
 		message,
@@ -498,9 +751,8 @@ func (g *GitHub) MergePullRequest(ctx context.Context, pr PullRequest) (*string,
 			DontDefaultIfBlank: false,
 		},
 	); err != nil {
-		errStr := "unable to merge pull request"
-		fmt.Println(errStr)
-		return nil, err
+		logging.FromContext(ctx).Error("unable to merge pull request", logging.ERROR_KEY, err)
+		return nil, mapError(err)
 	}
 
 	return res.SHA, nil
@@ -512,7 +764,7 @@ func (g *GitHub) GetReviews(ctx context.Context, pr PullRequest) (PullRequestRev
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return nil, fmt.Errorf(errStr)
 	}
 
@@ -523,16 +775,15 @@ func (g *GitHub) GetReviews(ctx context.Context, pr PullRequest) (PullRequestRev
 	// retrieve reviews
 	if reviews, _, err = g.client.PullRequests.ListReviews(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		*githubPr.Number,
 		&github.ListOptions{
 			PerPage: 100,
 		},
 	); err != nil {
-		errStr := "GitHub list reviews error"
-		fmt.Println(errStr)
-		return nil, err
+		logging.FromContext(ctx).Error("GitHub list reviews error", logging.ERROR_KEY, err)
+		return nil, mapError(err)
 	}
 
 	return reviews, nil
@@ -544,7 +795,7 @@ func (g *GitHub) CreateReview(ctx context.Context, pr PullRequest, data *models.
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return fmt.Errorf(errStr)
 	}
 
@@ -582,16 +833,101 @@ func (g *GitHub) CreateReview(ctx context.Context, pr PullRequest, data *models.
 	// generate review
 	if _, _, err := g.client.PullRequests.CreateReview(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		*githubPr.Number,
 		param,
 	); err != nil {
-		errStr := "unable to create review"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error("unable to create review", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// GetReviewComments returns the review comments of the given pull request, oldest first. Paginated output
+func (g *GitHub) GetReviewComments(ctx context.Context, pr PullRequest) ([]ReviewComment, error) {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return nil, fmt.Errorf(errStr)
+	}
+
+	comments := []ReviewComment{}
+	opts := &github.PullRequestListCommentsOptions{
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		results, response, err := g.client.PullRequests.ListComments(
+			ctx,
+			g.owner,
+			*g.trackingRepository,
+			*githubPr.Number,
+			opts,
+		)
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to list review comments", logging.ERROR_KEY, err)
+			return nil, mapError(err)
+		}
+
+		for _, comment := range results {
+			comments = append(comments, ReviewComment{
+				ID:     strconv.FormatInt(comment.GetID(), 10),
+				Author: comment.GetUser().GetLogin(),
+				Path:   comment.GetPath(),
+				Body:   comment.GetBody(),
+			})
+		}
+
+		// 0 value indicates there is no next page and the results are exhausted
+		if response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	return comments, nil
+}
+
+// EditReviewComment replaces the body of the review comment with the given ID on the given pull request
+func (g *GitHub) EditReviewComment(ctx context.Context, pr PullRequest, id string, body string) error {
+	commentID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("invalid review comment ID", logging.ERROR_KEY, err)
+		return err
+	}
+
+	if _, _, err = g.client.PullRequests.EditComment(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		commentID,
+		&github.PullRequestComment{Body: &body},
+	); err != nil {
+		logging.FromContext(ctx).Error("unable to edit review comment", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// DeleteReviewComment deletes the review comment with the given ID from the given pull request
+func (g *GitHub) DeleteReviewComment(ctx context.Context, pr PullRequest, id string) error {
+	commentID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		logging.FromContext(ctx).Error("invalid review comment ID", logging.ERROR_KEY, err)
 		return err
 	}
 
+	if _, err = g.client.PullRequests.DeleteComment(ctx, g.owner, *g.trackingRepository, commentID); err != nil {
+		logging.FromContext(ctx).Error("unable to delete review comment", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
 	return nil
 }
 
@@ -601,14 +937,14 @@ func (g *GitHub) DismissApprovalReviews(ctx context.Context, reviews PullRequest
 	githubPrReviews, ok := reviews.([]*github.PullRequestReview)
 	if !ok {
 		errStr := "given pull request reviews is not of type []github.PullRequestReview"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return fmt.Errorf(errStr)
 	}
 	// ensure given pr is of github type
 	githubPr, ok := pr.(*github.PullRequest)
 	if !ok {
 		errStr := "given pull request is not of type github.PullRequest"
-		fmt.Println(errStr)
+		logging.FromContext(ctx).Error(errStr)
 		return fmt.Errorf(errStr)
 	}
 
@@ -622,7 +958,7 @@ func (g *GitHub) DismissApprovalReviews(ctx context.Context, reviews PullRequest
 			// dismiss review
 			if _, _, err := g.client.PullRequests.DismissReview(
 				ctx,
-				OWNER,
+				g.owner,
 				*g.trackingRepository,
 				*githubPr.Number,
 				*review.ID,
@@ -630,9 +966,8 @@ func (g *GitHub) DismissApprovalReviews(ctx context.Context, reviews PullRequest
 					Message: &message,
 				},
 			); err != nil {
-				errStr := "GitHub dismiss review error"
-				fmt.Println(errStr)
-				return err
+				logging.FromContext(ctx).Error("GitHub dismiss review error", logging.ERROR_KEY, err)
+				return mapError(err)
 			}
 		}
 	}
@@ -648,15 +983,14 @@ func (g *GitHub) GetUserLogin(ctx context.Context) (*string, error) {
 
 	// retrieve user
 	if user, _, err = g.client.Users.Get(ctx, ""); err != nil {
-		errStr := "unable to fetch user"
-		fmt.Println(errStr)
-		return nil, err
+		logging.FromContext(ctx).Error("unable to fetch user", logging.ERROR_KEY, err)
+		return nil, mapError(err)
 	}
 
 	return user.Login, nil
 }
 
-// GetUserTeams returns a set of teams for the current authenticated user
+// GetUserTeams returns a set of team slugs for the current authenticated user
 func (g *GitHub) GetUserTeams(ctx context.Context) (set.Set[string], error) {
 	// init. vars to maintain scope beyond "if" statements
 	var err error
@@ -675,14 +1009,13 @@ func (g *GitHub) GetUserTeams(ctx context.Context) (set.Set[string], error) {
 				Page:    page,
 			},
 		); err != nil {
-			errStr := "unable to retrieve user teams"
-			fmt.Println(errStr)
-			return nil, err
+			logging.FromContext(ctx).Error("unable to retrieve user teams", logging.ERROR_KEY, err)
+			return nil, mapError(err)
 		}
 
 		// add to teams set
 		for _, team := range ghTeams {
-			teams.Add(*team.Name)
+			teams.Add(team.GetSlug())
 		}
 
 		// check what the next page is, terminate if none left
@@ -692,22 +1025,475 @@ func (g *GitHub) GetUserTeams(ctx context.Context) (set.Set[string], error) {
 	return teams, nil
 }
 
+// CreateDeployment creates a GitHub deployment of the head branch of the given pull request to the given environment
+// The environment's protection rules (required reviewers, wait timers...) then decide whether the deployment proceeds,
+// status checks are not required since they already gate mergeability
+func (g *GitHub) CreateDeployment(ctx context.Context, pr PullRequest, environment string) (*string, error) {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return nil, fmt.Errorf(errStr)
+	}
+
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var deployment *github.Deployment
+
+	description := fmt.Sprintf("Load of RFC %s", githubPr.GetHead().GetRef())
+	if deployment, _, err = g.client.Repositories.CreateDeployment(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		&github.DeploymentRequest{
+			Ref:              githubPr.GetHead().Ref,
+			Environment:      &environment,
+			Description:      &description,
+			AutoMerge:        github.Bool(false),
+			RequiredContexts: &[]string{},
+		},
+	); err != nil {
+		logging.FromContext(ctx).Error("unable to create deployment", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+
+	deploymentID := strconv.FormatInt(deployment.GetID(), 10)
+	return &deploymentID, nil
+}
+
+// GetDeploymentStatus returns the latest status of the GitHub deployment with the given ID
+// A deployment that is in progress or succeeded has passed its environment protection rules and is approved, one that
+// failed, errored or was made inactive is rejected, and anything else (including no status at all) is pending
+func (g *GitHub) GetDeploymentStatus(ctx context.Context, deploymentID string) (*DeploymentStatus, error) {
+	id, err := strconv.ParseInt(deploymentID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub deployment ID '%s': %w", deploymentID, err)
+	}
+
+	// statuses are listed newest first, so only the first one is needed
+	statuses, _, err := g.client.Repositories.ListDeploymentStatuses(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		id,
+		&github.ListOptions{PerPage: 1},
+	)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to list deployment statuses", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+	if len(statuses) == 0 {
+		return &DeploymentStatus{State: DEPLOYMENT_PENDING_STATE}, nil
+	}
+
+	latest := statuses[0]
+	switch latest.GetState() {
+	case "in_progress", "success":
+		return &DeploymentStatus{State: DEPLOYMENT_APPROVED_STATE, Actor: latest.GetCreator().GetLogin()}, nil
+	case "failure", "error", "inactive":
+		return &DeploymentStatus{State: DEPLOYMENT_REJECTED_STATE, Actor: latest.GetCreator().GetLogin()}, nil
+	default:
+		return &DeploymentStatus{State: DEPLOYMENT_PENDING_STATE}, nil
+	}
+}
+
+// GetMissingPermissions returns a description of each permission Harmonia requires on the tracking repository that
+// the client's token lacks. Classic tokens are checked against their OAuth scopes, while fine-grained tokens (which
+// do not report scopes) are checked by probing the endpoints Harmonia depends on
+func (g *GitHub) GetMissingPermissions(ctx context.Context) ([]string, error) {
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var repo *github.Repository
+	var response *github.Response
+	missing := []string{}
+	repoName := fmt.Sprintf("%s/%s", g.owner, *g.trackingRepository)
+
+	// the repository must be visible to the token at all, nothing else can be checked otherwise
+	if repo, response, err = g.client.Repositories.Get(ctx, g.owner, *g.trackingRepository); err != nil {
+		if isAccessDenied(response) {
+			return append(missing, fmt.Sprintf("access to repository %s", repoName)), nil
+		}
+		logging.FromContext(ctx).Error("unable to retrieve tracking repository for permission check", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+
+	// classic tokens list their scopes, fine-grained tokens omit the header entirely
+	if header, ok := response.Header["X-Oauth-Scopes"]; ok {
+		scopes := set.NewSet[string]()
+		for _, value := range header {
+			for _, scope := range strings.Split(value, ",") {
+				scopes.Add(strings.TrimSpace(scope))
+			}
+		}
+		if !scopes.Contains(REQUIRED_OAUTH_SCOPE) {
+			missing = append(missing, fmt.Sprintf("%s scope", REQUIRED_OAUTH_SCOPE))
+		}
+		if !scopes.Contains(REQUIRED_OAUTH_ORG_SCOPE) && !scopes.Contains("write:org") &&
+			!scopes.Contains("admin:org") {
+			missing = append(missing, fmt.Sprintf("%s scope", REQUIRED_OAUTH_ORG_SCOPE))
+		}
+	}
+
+	// branches, files, pull requests and tags are all written
+	if !repo.GetPermissions()["push"] {
+		missing = append(missing, fmt.Sprintf("contents and pull requests write permission on %s", repoName))
+	}
+
+	// team membership is used to route and attribute reviews
+	if _, response, err = g.client.Teams.ListUserTeams(ctx, &github.ListOptions{PerPage: 1}); err != nil {
+		if isAccessDenied(response) {
+			missing = append(missing, fmt.Sprintf("organization members read permission on %s", g.owner))
+		} else {
+			logging.FromContext(ctx).Error("unable to retrieve user teams for permission check", logging.ERROR_KEY, err)
+			return nil, mapError(err)
+		}
+	}
+
+	return missing, nil
+}
+
+// GetBranchProtection returns the protection GitHub enforces on the base branch of the tracking repository
+// Reading the protection rules of a protected branch requires administration read permission on the repository
+func (g *GitHub) GetBranchProtection(ctx context.Context) (*BranchProtection, error) {
+	branch, _, err := g.client.Repositories.GetBranch(ctx, g.owner, *g.trackingRepository, BASE_BRANCH, true)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch for protection check", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+	if !branch.GetProtected() {
+		return &BranchProtection{Branch: BASE_BRANCH}, nil
+	}
+
+	protection, _, err := g.client.Repositories.GetBranchProtection(ctx, g.owner, *g.trackingRepository, BASE_BRANCH)
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch protection", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+
+	result := &BranchProtection{Branch: BASE_BRANCH, Protected: true, RequiredContexts: []string{}}
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		result.ChecksRequired = true
+		result.RequiredContexts = append(result.RequiredContexts, checks.Contexts...)
+	}
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		result.RequiredApprovals = reviews.RequiredApprovingReviewCount
+	}
+
+	return result, nil
+}
+
+// GetArchiveIndex returns the contents of the archive index on the base branch, nil if no RFC was archived yet
+func (g *GitHub) GetArchiveIndex(ctx context.Context) (*string, error) {
+	path := fmt.Sprintf("%s/%s", BASE_RFC_DIRECTORY_NAME, ARCHIVE_INDEX_FILE_NAME)
+	repositoryContent, _, response, err := g.client.Repositories.GetContents(ctx, g.owner, *g.trackingRepository, path,
+		&github.RepositoryContentGetOptions{Ref: BASE_BRANCH})
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		logging.FromContext(ctx).Error("unable to retrieve archive index", logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+	if repositoryContent == nil {
+		return nil, fmt.Errorf("archive index %s is not a file", path)
+	}
+
+	content, err := repositoryContent.GetContent()
+	if err != nil {
+		logging.FromContext(ctx).Error("unable to extract file content from repository content", logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+// GetSchemas returns the contents of the JSON files of the given directory on the base branch, keyed by file name,
+// an empty map if the directory does not exist
+func (g *GitHub) GetSchemas(ctx context.Context, directory string) (map[string]string, error) {
+	options := &github.RepositoryContentGetOptions{Ref: BASE_BRANCH}
+	_, directoryContent, response, err := g.client.Repositories.GetContents(ctx, g.owner, *g.trackingRepository,
+		directory, options)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return map[string]string{}, nil
+		}
+		logging.FromContext(ctx).Error("unable to list schemas", "directory", directory, logging.ERROR_KEY, err)
+		return nil, mapError(err)
+	}
+
+	files := map[string]string{}
+	for _, entry := range directoryContent {
+		if entry.GetType() != "file" || !strings.HasSuffix(entry.GetName(), ".json") {
+			continue
+		}
+		fileContent, _, _, err := g.client.Repositories.GetContents(ctx, g.owner, *g.trackingRepository,
+			entry.GetPath(), options)
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve schema", "path", entry.GetPath(), logging.ERROR_KEY, err)
+			return nil, mapError(err)
+		}
+		content, err := fileContent.GetContent()
+		if err != nil {
+			logging.FromContext(ctx).Error("unable to extract file content from repository content",
+				logging.ERROR_KEY, err)
+			return nil, err
+		}
+		files[entry.GetName()] = content
+	}
+
+	return files, nil
+}
+
+// ArchiveRFCs removes the RFC files of the given RFCs from the base branch and writes the given archive index, in a
+// single commit with the given message. The commit is pushed to the base branch directly, so the machine account must
+// be allowed to bypass its protection
+func (g *GitHub) ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) error {
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var ref *github.Reference
+	var parent *github.Commit
+	var tree *github.Tree
+	var commit *github.Commit
+
+	// the commit is built on top of the current head of the base branch
+	if ref, _, err = g.client.Git.GetRef(ctx, g.owner, *g.trackingRepository, "refs/heads/"+BASE_BRANCH); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch for archiving", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+	if parent, _, err = g.client.Git.GetCommit(ctx, g.owner, *g.trackingRepository, ref.GetObject().GetSHA()); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch head for archiving", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	// entries without content or sha delete their path
+	mode, blob := "100644", "blob"
+	indexPath := fmt.Sprintf("%s/%s", BASE_RFC_DIRECTORY_NAME, ARCHIVE_INDEX_FILE_NAME)
+	entries := []*github.TreeEntry{{Path: &indexPath, Mode: &mode, Type: &blob, Content: &index}}
+	for _, rfcIdentifier := range rfcIdentifiers {
+		path := fmt.Sprintf("%s/%s/%s", BASE_RFC_DIRECTORY_NAME, rfcIdentifier, RFC_FILE_NAME)
+		entries = append(entries, &github.TreeEntry{Path: &path, Mode: &mode, Type: &blob})
+	}
+	if tree, _, err = g.client.Git.CreateTree(ctx, g.owner, *g.trackingRepository, parent.GetTree().GetSHA(),
+		entries); err != nil {
+		logging.FromContext(ctx).Error("unable to create archive tree", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+	if commit, _, err = g.client.Git.CreateCommit(ctx, g.owner, *g.trackingRepository, &github.Commit{
+		Message: &message,
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: parent.SHA}},
+	}); err != nil {
+		logging.FromContext(ctx).Error("unable to create archive commit", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	// the update is not forced, so it fails if the base branch moved in the meantime
+	ref.Object.SHA = commit.SHA
+	if _, _, err = g.client.Git.UpdateRef(ctx, g.owner, *g.trackingRepository, ref, false); err != nil {
+		logging.FromContext(ctx).Error("unable to push archive commit", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// CommitFiles writes the given files, keyed by path, to the base branch in a single commit with the given message. No
+// commit is made if the files are unchanged. The commit is pushed to the base branch directly, so the machine account
+// must be allowed to bypass its protection
+func (g *GitHub) CommitFiles(ctx context.Context, files map[string]string, message string) (bool, error) {
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var ref *github.Reference
+	var parent *github.Commit
+	var tree *github.Tree
+	var commit *github.Commit
+
+	// the commit is built on top of the current head of the base branch
+	if ref, _, err = g.client.Git.GetRef(ctx, g.owner, *g.trackingRepository, "refs/heads/"+BASE_BRANCH); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch for committing files", logging.ERROR_KEY, err)
+		return false, mapError(err)
+	}
+	if parent, _, err = g.client.Git.GetCommit(ctx, g.owner, *g.trackingRepository, ref.GetObject().GetSHA()); err != nil {
+		logging.FromContext(ctx).Error("unable to retrieve base branch head for committing files", logging.ERROR_KEY, err)
+		return false, mapError(err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	mode, blob := "100644", "blob"
+	entries := make([]*github.TreeEntry, 0, len(paths))
+	for _, path := range paths {
+		path, content := path, files[path]
+		entries = append(entries, &github.TreeEntry{Path: &path, Mode: &mode, Type: &blob, Content: &content})
+	}
+	if tree, _, err = g.client.Git.CreateTree(ctx, g.owner, *g.trackingRepository, parent.GetTree().GetSHA(),
+		entries); err != nil {
+		logging.FromContext(ctx).Error("unable to create tree of files", logging.ERROR_KEY, err)
+		return false, mapError(err)
+	}
+	// unchanged files leave the tree as it was
+	if tree.GetSHA() == parent.GetTree().GetSHA() {
+		return false, nil
+	}
+	if commit, _, err = g.client.Git.CreateCommit(ctx, g.owner, *g.trackingRepository, &github.Commit{
+		Message: &message,
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: parent.SHA}},
+	}); err != nil {
+		logging.FromContext(ctx).Error("unable to create commit of files", logging.ERROR_KEY, err)
+		return false, mapError(err)
+	}
+
+	// the update is not forced, so it fails if the base branch moved in the meantime
+	ref.Object.SHA = commit.SHA
+	if _, _, err = g.client.Git.UpdateRef(ctx, g.owner, *g.trackingRepository, ref, false); err != nil {
+		logging.FromContext(ctx).Error("unable to push commit of files", logging.ERROR_KEY, err)
+		return false, mapError(err)
+	}
+
+	return true, nil
+}
+
+// mapError maps the given go-github error to the provider agnostic error it corresponds to, keeping it wrapped in a
+// *ProviderError. GitHub responses that do not correspond to one are wrapped in a *ProviderError without a kind, errors
+// that are not GitHub responses are returned unchanged
+func mapError(err error) error {
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var responseErr *github.ErrorResponse
+	if errors.As(err, &rateLimitErr) || errors.As(err, &abuseErr) {
+		return &ProviderError{Kind: ErrRateLimited, Err: err}
+	}
+	if !errors.As(err, &responseErr) || responseErr.Response == nil {
+		return err
+	}
+
+	switch responseErr.Response.StatusCode {
+	case http.StatusNotFound:
+		return &ProviderError{Kind: ErrNotFound, Err: err}
+	case http.StatusConflict, http.StatusUnprocessableEntity, http.StatusMethodNotAllowed:
+		// GitHub responds with a 405 to merges of pull requests that are not mergeable
+		return &ProviderError{Kind: ErrConflict, Err: err}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &ProviderError{Kind: ErrPermission, Err: err}
+	}
+
+	return &ProviderError{Err: err}
+}
+
+// isAccessDenied returns true if the given response indicates the token is not permitted to access the resource
+// GitHub responds with a 404 rather than a 403 for private resources to avoid leaking their existence
+func isAccessDenied(response *github.Response) bool {
+	return response != nil &&
+		(response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusForbidden)
+}
+
+// GetTeamMembers returns a set of logins for the members of the given team slug within the repository owner org
+func (g *GitHub) GetTeamMembers(ctx context.Context, team string) (set.Set[string], error) {
+	// init. vars to maintain scope beyond "if" statements
+	var err error
+	var ghUsers []*github.User
+	var response *github.Response
+	members := set.NewSet[string]()
+	page := 1
+	perPage := 100
+
+	// get team members, paginated for large teams
+	for page != 0 {
+		if ghUsers, response, err = g.client.Teams.ListTeamMembersBySlug(
+			ctx,
+			g.owner,
+			team,
+			&github.TeamListTeamMembersOptions{
+				ListOptions: github.ListOptions{
+					PerPage: perPage,
+					Page:    page,
+				},
+			},
+		); err != nil {
+			logging.FromContext(ctx).Error("unable to retrieve team members", logging.ERROR_KEY, err)
+			return nil, mapError(err)
+		}
+
+		// add to members set
+		for _, user := range ghUsers {
+			members.Add(*user.Login)
+		}
+
+		// check what the next page is, terminate if none left
+		page = response.NextPage
+	}
+
+	return members, nil
+}
+
+// RequestReviewers requests a review of the given pull request from each of the given logins
+func (g *GitHub) RequestReviewers(ctx context.Context, pr PullRequest, reviewers []string) error {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return fmt.Errorf(errStr)
+	}
+
+	if _, _, err := g.client.PullRequests.RequestReviewers(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		*githubPr.Number,
+		github.ReviewersRequest{Reviewers: reviewers},
+	); err != nil {
+		logging.FromContext(ctx).Error("unable to request reviewers", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// RequestTeamReviewers requests a review of the given pull request from each of the given teams
+func (g *GitHub) RequestTeamReviewers(ctx context.Context, pr PullRequest, teams []string) error {
+	// ensure given pr is of github type
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		errStr := "given pull request is not of type github.PullRequest"
+		logging.FromContext(ctx).Error(errStr)
+		return fmt.Errorf(errStr)
+	}
+
+	if _, _, err := g.client.PullRequests.RequestReviewers(
+		ctx,
+		g.owner,
+		*g.trackingRepository,
+		*githubPr.Number,
+		github.ReviewersRequest{TeamReviewers: teams},
+	); err != nil {
+		logging.FromContext(ctx).Error("unable to request team reviewers", logging.ERROR_KEY, err)
+		return mapError(err)
+	}
+
+	return nil
+}
+
 // CreateTag tags the given sha with the given name
 func (g *GitHub) CreateTag(ctx context.Context, sha string, tag string) error {
 	// tag resource
 	targetRef := fmt.Sprintf("refs/tags/%s", tag)
 	if _, _, err := g.client.Git.CreateRef(
 		ctx,
-		OWNER,
+		g.owner,
 		*g.trackingRepository,
 		&github.Reference{
 			Ref:    &targetRef,
 			Object: &github.GitObject{SHA: &sha},
 		},
 	); err != nil {
-		errStr := "unable to create tag"
-		fmt.Println(errStr)
-		return err
+		logging.FromContext(ctx).Error("unable to create tag", logging.ERROR_KEY, err)
+		return mapError(err)
 	}
 
 	return nil
@@ -727,6 +1513,75 @@ func (g *GitHub) GetIdsAndTitles(prs PullRequests) (IdsAndTitles, error) {
 	return idsAndTitles, nil
 }
 
+// GetPullRequestDetails extracts the provider agnostic details of the given pull request
+func (g *GitHub) GetPullRequestDetails(pr PullRequest) (*PullRequestDetails, error) {
+	githubPr, ok := pr.(*github.PullRequest)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert given pull request to github.PullRequest")
+	}
+
+	details := &PullRequestDetails{
+		RFCIdentifier: githubPr.GetHead().GetRef(),
+		Number:        githubPr.GetNumber(),
+		Title:         githubPr.GetTitle(),
+		Author:        githubPr.GetUser().GetLogin(),
+		State:         githubPr.GetState(),
+		Merged:        githubPr.GetMerged(),
+		MergedAt:      githubPr.GetMergedAt(),
+		CreatedAt:     githubPr.GetCreatedAt(),
+		UpdatedAt:     githubPr.GetUpdatedAt(),
+	}
+	for _, label := range githubPr.Labels {
+		details.Labels = append(details.Labels, label.GetName())
+	}
+	for _, reviewer := range githubPr.RequestedReviewers {
+		details.RequestedReviewers = append(details.RequestedReviewers, reviewer.GetLogin())
+	}
+	for _, team := range githubPr.RequestedTeams {
+		details.RequestedTeams = append(details.RequestedTeams, team.GetSlug())
+	}
+
+	return details, nil
+}
+
+// GetReviewDetails extracts the provider agnostic details of the given pull request reviews
+func (g *GitHub) GetReviewDetails(reviews PullRequestReviews) ([]ReviewDetails, error) {
+	githubPrReviews, ok := reviews.([]*github.PullRequestReview)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert given pull request reviews to []github.PullRequestReview")
+	}
+
+	details := make([]ReviewDetails, len(githubPrReviews))
+	for i, review := range githubPrReviews {
+		details[i] = ReviewDetails{
+			Reviewer:    review.GetUser().GetLogin(),
+			State:       review.GetState(),
+			SubmittedAt: review.GetSubmittedAt(),
+		}
+	}
+
+	return details, nil
+}
+
+// BuildLinks returns the GitHub URLs of the given RFC: its pull request (if given), its RFC file and, if tagged, its
+// tag. The tag shares its name with the RFC branch, so the file link still resolves once the branch is deleted
+func (g *GitHub) BuildLinks(rfcIdentifier string, pr PullRequest, tagged bool) *models.Links {
+	repoURL := fmt.Sprintf("%s/%s/%s", GITHUB_WEB_URL, url.PathEscape(g.owner), url.PathEscape(*g.trackingRepository))
+	ref := url.PathEscape(rfcIdentifier)
+	links := &models.Links{
+		File: fmt.Sprintf("%s/blob/%s/%s/%s/%s", repoURL, ref, BASE_RFC_DIRECTORY_NAME, ref, RFC_FILE_NAME),
+	}
+
+	if githubPr, ok := pr.(*github.PullRequest); ok {
+		links.PullRequest = githubPr.GetHTMLURL()
+	}
+	if tagged {
+		links.Tag = fmt.Sprintf("%s/tree/%s", repoURL, ref)
+	}
+
+	return links
+}
+
 // Returns a FilterOption that:
 // 	returns true if a given PR is owned by the given user. If no user is given, returns true.
 func (g *GitHub) WithOwner(owner *string) FilterOption {
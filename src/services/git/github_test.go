@@ -0,0 +1,137 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+
+	"github.com/google/go-github/v40/github"
+)
+
+// TestMapError tests that GitHub errors are mapped to the provider agnostic errors
+func TestMapError(t *testing.T) {
+	response := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Request: &http.Request{}}
+	}
+	plain := fmt.Errorf("connection reset")
+	testCases := []struct {
+		err      error
+		expected error
+	}{
+		{err: &github.ErrorResponse{Response: response(http.StatusNotFound)}, expected: ErrNotFound},
+		{err: &github.ErrorResponse{Response: response(http.StatusUnprocessableEntity)}, expected: ErrConflict},
+		{err: &github.ErrorResponse{Response: response(http.StatusMethodNotAllowed)}, expected: ErrConflict},
+		{err: &github.ErrorResponse{Response: response(http.StatusForbidden)}, expected: ErrPermission},
+		{err: &github.RateLimitError{Response: response(http.StatusForbidden)}, expected: ErrRateLimited},
+		{err: &github.AbuseRateLimitError{Response: response(http.StatusForbidden)}, expected: ErrRateLimited},
+		{err: &github.ErrorResponse{Response: response(http.StatusInternalServerError)}, expected: models.ErrProvider},
+		{err: plain, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		actual := mapError(testCase.err)
+		if !errors.Is(actual, testCase.err) {
+			t.Errorf("provider error %v was not kept wrapped", testCase.err)
+		}
+		if testCase.expected == nil {
+			if actual != testCase.err {
+				t.Errorf("expected %v to be returned unchanged, got %v", testCase.err, actual)
+			}
+		} else if !errors.Is(actual, testCase.expected) {
+			t.Errorf("expected %v to map to %v, got %v", testCase.err, testCase.expected, actual)
+		}
+	}
+}
+
+// TestProviderErrorRFCFileNotFound tests that a missing RFC file is both an ErrRFCFileNotFound and an ErrNotFound
+func TestProviderErrorRFCFileNotFound(t *testing.T) {
+	err := fmt.Errorf("reading RFC: %w",
+		&ProviderError{Kind: ErrNotFound, Err: fmt.Errorf("%w: RFC/123/RFC.json", ErrRFCFileNotFound)})
+
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, ErrRFCFileNotFound) || errors.Is(err, ErrConflict) {
+		t.Errorf("unexpected error matches for %v", err)
+	}
+}
+
+// TestGitHubExplainMergeability tests that only the required contexts decide the mergeability of pull requests whose
+// optional contexts fail, and that every context does when none are required
+func TestGitHubExplainMergeability(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			fmt.Fprint(w, `{"state": "failure", "statuses": [
+				{"context": "ci/build", "state": "success"}, {"context": "ci/flaky", "state": "failure"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			fmt.Fprint(w, `{"total_count": 1, "check_runs": [
+				{"name": "lint", "status": "completed", "conclusion": "success"}]}`)
+		default:
+			fmt.Fprint(w, `{"number": 1, "mergeable_state": "unstable"}`)
+		}
+	}))
+	defer server.Close()
+	client := github.NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	repo := "rfcs"
+	ref := "123456"
+	number := 1
+	pr := &github.PullRequest{Number: &number, Head: &github.PullRequestBranch{Ref: &ref}}
+
+	// act
+	optional := &GitHub{client: client, trackingRepository: &repo}
+	all, allErr := optional.ExplainMergeability(context.Background(), pr)
+	required := &GitHub{client: client, trackingRepository: &repo, requiredContexts: []string{"ci/build", "lint"}}
+	some, someErr := required.ExplainMergeability(context.Background(), pr)
+
+	// assert
+	if allErr != nil || all.Mergeable ||
+		fmt.Sprint(all.Reasons) != "[a status check is not passing status check ci/flaky is failure]" {
+		t.Errorf("unexpected mergeability without required contexts: %+v, err: %v", all, allErr)
+	}
+	if someErr != nil || !some.Mergeable || len(some.Reasons) != 0 ||
+		fmt.Sprint(some.Contexts) != "map[ci/build:success lint:success]" {
+		t.Errorf("unexpected mergeability with required contexts: %+v, err: %v", some, someErr)
+	}
+}
+
+// TestGitHubGetBranchProtection tests that the required status checks and approvals of the protected base branch are
+// read, and that an unprotected base branch is reported as such
+func TestGitHubGetBranchProtection(t *testing.T) {
+	// arrange
+	protected := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/branches/main/protection"):
+			fmt.Fprint(w, `{"required_status_checks": {"strict": true, "contexts": ["ci/build"]},
+				"required_pull_request_reviews": {"required_approving_review_count": 2}}`)
+		default:
+			fmt.Fprintf(w, `{"name": "main", "protected": %v}`, protected)
+		}
+	}))
+	defer server.Close()
+	client := github.NewClient(server.Client())
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	repo := "rfcs"
+	g := &GitHub{client: client, owner: "schema-team", trackingRepository: &repo}
+
+	// act
+	protection, err := g.GetBranchProtection(context.Background())
+	protected = false
+	unprotected, unprotectedErr := g.GetBranchProtection(context.Background())
+
+	// assert
+	if err != nil || fmt.Sprintf("%+v", *protection) != "{Branch:main Protected:true ChecksRequired:true "+
+		"RequiredContexts:[ci/build] RequiredApprovals:2}" {
+		t.Errorf("unexpected protection: %+v, err: %v", protection, err)
+	}
+	if unprotectedErr != nil || unprotected.Protected {
+		t.Errorf("expected the branch not to be protected, got %+v, err: %v", unprotected, unprotectedErr)
+	}
+}
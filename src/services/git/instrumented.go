@@ -0,0 +1,340 @@
+// This holds the Git implementation instrumenting the calls made to the Git provider by another implementation
+package git
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/set"
+	"harmonia-example.io/src/services/tracing"
+)
+
+// Instrumented is a Git implementation tracing and recording the count, outcome and duration of every call made to
+// the Git provider by the implementation it wraps, see metrics.GitCalls and metrics.GitCallDuration
+// Calls that do not reach the provider (e.g. GetPullRequestDetails) are passed through untraced and unrecorded
+type Instrumented struct {
+	Git
+	provider string
+	// domain is the schema domain of the tracking repository of the implementation, see ClientDomain
+	domain string
+}
+
+// Instrument returns the given Git implementation of the given provider with its calls instrumented
+func Instrument(provider string, git Git) *Instrumented {
+	return &Instrumented{Git: git, provider: provider}
+}
+
+// call starts the span of a call of the given method with the given attributes, the returned function must be called
+// with the error the call returned once it is done
+func (i *Instrumented) call(ctx context.Context, method string,
+	attributes ...attribute.KeyValue) (context.Context, func(err error)) {
+	start := time.Now()
+	attributes = append(attributes, tracing.PROVIDER_KEY.String(i.provider))
+	ctx, span := tracing.Start(ctx, "git."+method, attributes...)
+
+	return ctx, func(err error) {
+		outcome := metrics.SUCCESS_OUTCOME
+		if errors.Is(err, ErrRateLimited) {
+			outcome = metrics.RATE_LIMITED_OUTCOME
+		} else if err != nil {
+			outcome = metrics.ERROR_OUTCOME
+		}
+		metrics.GitCalls.Inc(i.provider, method, outcome)
+		metrics.GitCallDuration.Observe(time.Since(start).Seconds(), i.provider, method)
+		tracing.End(span, err)
+	}
+}
+
+// observeMergeability records a mergeability check started at the given time that returned the given mergeability
+// and error, on top of the call itself
+func (i *Instrumented) observeMergeability(start time.Time, mergeable bool, err error) {
+	outcome := "not_mergeable"
+	if err != nil {
+		outcome = metrics.ERROR_OUTCOME
+	} else if mergeable {
+		outcome = "mergeable"
+	}
+
+	metrics.MergeabilityPollDuration.Observe(time.Since(start).Seconds(), i.provider, outcome)
+}
+
+// branchAttribute returns the span attribute of the given branch
+func branchAttribute(name string) attribute.KeyValue {
+	return tracing.BRANCH_KEY.String(name)
+}
+
+// pullRequest returns the span attributes of the given pull request, none if its details cannot be extracted
+func (i *Instrumented) pullRequest(pr PullRequest) []attribute.KeyValue {
+	details, err := i.Git.GetPullRequestDetails(pr)
+	if err != nil {
+		return nil
+	}
+	return []attribute.KeyValue{tracing.BRANCH_KEY.String(details.RFCIdentifier),
+		tracing.PR_NUMBER_KEY.Int(details.Number)}
+}
+
+// CreateBranch creates a new branch with the given name from the given base branch
+func (i *Instrumented) CreateBranch(ctx context.Context, branch string, baseBranch string) (err error) {
+	ctx, done := i.call(ctx, "CreateBranch", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.CreateBranch(ctx, branch, baseBranch)
+}
+
+// DeleteBranch deletes the branch with the given name
+func (i *Instrumented) DeleteBranch(ctx context.Context, branch string) (err error) {
+	ctx, done := i.call(ctx, "DeleteBranch", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.DeleteBranch(ctx, branch)
+}
+
+// CreateFile creates an RFC file on the given branch in the given directory using the given data
+func (i *Instrumented) CreateFile(ctx context.Context, branch string, directory string, data *models.RFC) (err error) {
+	ctx, done := i.call(ctx, "CreateFile", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.CreateFile(ctx, branch, directory, data)
+}
+
+// CreatePullRequest opens a new pull request of the given branch towards the given base branch with the given options
+func (i *Instrumented) CreatePullRequest(ctx context.Context, branch string, baseBranch string,
+	options PullRequestOptions) (err error) {
+	ctx, done := i.call(ctx, "CreatePullRequest", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.CreatePullRequest(ctx, branch, baseBranch, options)
+}
+
+// GetRFCContents returns the current contents of the RFC for the given pull request along with the sha of the file
+func (i *Instrumented) GetRFCContents(ctx context.Context, branch string) (content *string, sha *string,
+	err error) {
+	ctx, done := i.call(ctx, "GetRFCContents", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.GetRFCContents(ctx, branch)
+}
+
+// GetRFCContentsAt returns the contents of the RFC of the given branch as of the given commit sha
+func (i *Instrumented) GetRFCContentsAt(ctx context.Context, branch string, ref string) (content *string, err error) {
+	ctx, done := i.call(ctx, "GetRFCContentsAt", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.GetRFCContentsAt(ctx, branch, ref)
+}
+
+// GetRFCHistory returns the commits that modified the RFC file of the given branch, newest first
+func (i *Instrumented) GetRFCHistory(ctx context.Context, branch string) (revisions []RFCRevision, err error) {
+	ctx, done := i.call(ctx, "GetRFCHistory", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.GetRFCHistory(ctx, branch)
+}
+
+// RestoreFile commits the given raw content as the RFC file of the given PR, recreating the file if it was deleted
+func (i *Instrumented) RestoreFile(ctx context.Context, pr PullRequest, content string, message string) (err error) {
+	ctx, done := i.call(ctx, "RestoreFile", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.RestoreFile(ctx, pr, content, message)
+}
+
+// UpdateFile creates a commit to the RFC file of the given PR using the given data
+func (i *Instrumented) UpdateFile(ctx context.Context, pr PullRequest, data *models.RFC) (err error) {
+	ctx, done := i.call(ctx, "UpdateFile", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.UpdateFile(ctx, pr, data)
+}
+
+// GetPullRequest returns the most recent open pull request for the given branch
+func (i *Instrumented) GetPullRequest(ctx context.Context, branch string) (pr PullRequest, err error) {
+	ctx, done := i.call(ctx, "GetPullRequest", branchAttribute(branch))
+	defer func() { done(err) }()
+	return i.Git.GetPullRequest(ctx, branch)
+}
+
+// GetPullRequests returns all pull requests with the given state and filters
+func (i *Instrumented) GetPullRequests(ctx context.Context, state string, count int,
+	opts ...FilterOption) (prs PullRequests, err error) {
+	ctx, done := i.call(ctx, "GetPullRequests")
+	defer func() { done(err) }()
+	return i.Git.GetPullRequests(ctx, state, count, opts...)
+}
+
+// GetMergeability determines if the given pull request is mergeable (approvals, conflicts, ci...)
+func (i *Instrumented) GetMergeability(ctx context.Context, pr PullRequest) (mergeable *bool, err error) {
+	ctx, done := i.call(ctx, "GetMergeability", i.pullRequest(pr)...)
+	defer func(start time.Time) {
+		i.observeMergeability(start, mergeable != nil && *mergeable, err)
+		done(err)
+	}(time.Now())
+	return i.Git.GetMergeability(ctx, pr)
+}
+
+// ExplainMergeability determines if the given pull request is mergeable, along with why it is not and the state of
+// the status contexts considered
+func (i *Instrumented) ExplainMergeability(ctx context.Context,
+	pr PullRequest) (mergeability *models.Mergeability, err error) {
+	ctx, done := i.call(ctx, "ExplainMergeability", i.pullRequest(pr)...)
+	defer func(start time.Time) {
+		i.observeMergeability(start, mergeability != nil && mergeability.Mergeable, err)
+		done(err)
+	}(time.Now())
+	return i.Git.ExplainMergeability(ctx, pr)
+}
+
+// ClosePullRequest closes the given pull request without merging it
+func (i *Instrumented) ClosePullRequest(ctx context.Context, pr PullRequest) (err error) {
+	ctx, done := i.call(ctx, "ClosePullRequest", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.ClosePullRequest(ctx, pr)
+}
+
+// MergePullRequest merges the given pull request and returns the sha
+func (i *Instrumented) MergePullRequest(ctx context.Context, pr PullRequest) (sha *string, err error) {
+	ctx, done := i.call(ctx, "MergePullRequest", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.MergePullRequest(ctx, pr)
+}
+
+// GetReviews returns all pull request reviews related to the given pull request
+func (i *Instrumented) GetReviews(ctx context.Context, pr PullRequest) (reviews PullRequestReviews, err error) {
+	ctx, done := i.call(ctx, "GetReviews", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.GetReviews(ctx, pr)
+}
+
+// CreateReview generates a pull request review on the given pull request using the given data
+func (i *Instrumented) CreateReview(ctx context.Context, pr PullRequest, data *models.Review) (err error) {
+	ctx, done := i.call(ctx, "CreateReview", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.CreateReview(ctx, pr, data)
+}
+
+// DismissApprovalReviews dismisses only the "approval" reviews in the given reviews from the given pull request
+func (i *Instrumented) DismissApprovalReviews(ctx context.Context, reviews PullRequestReviews,
+	pr PullRequest) (err error) {
+	ctx, done := i.call(ctx, "DismissApprovalReviews", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.DismissApprovalReviews(ctx, reviews, pr)
+}
+
+// GetReviewComments returns the review comments of the given pull request, oldest first
+func (i *Instrumented) GetReviewComments(ctx context.Context, pr PullRequest) (comments []ReviewComment, err error) {
+	ctx, done := i.call(ctx, "GetReviewComments", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.GetReviewComments(ctx, pr)
+}
+
+// EditReviewComment replaces the body of the review comment with the given ID on the given pull request
+func (i *Instrumented) EditReviewComment(ctx context.Context, pr PullRequest, id string, body string) (err error) {
+	ctx, done := i.call(ctx, "EditReviewComment", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.EditReviewComment(ctx, pr, id, body)
+}
+
+// DeleteReviewComment deletes the review comment with the given ID from the given pull request
+func (i *Instrumented) DeleteReviewComment(ctx context.Context, pr PullRequest, id string) (err error) {
+	ctx, done := i.call(ctx, "DeleteReviewComment", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.DeleteReviewComment(ctx, pr, id)
+}
+
+// GetUserLogin returns the Git username defined by the client
+func (i *Instrumented) GetUserLogin(ctx context.Context) (login *string, err error) {
+	ctx, done := i.call(ctx, "GetUserLogin")
+	defer func() { done(err) }()
+	return i.Git.GetUserLogin(ctx)
+}
+
+// GetUserTeams returns a set of team slugs for the current authenticated user
+func (i *Instrumented) GetUserTeams(ctx context.Context) (teams set.Set[string], err error) {
+	ctx, done := i.call(ctx, "GetUserTeams")
+	defer func() { done(err) }()
+	return i.Git.GetUserTeams(ctx)
+}
+
+// GetTeamMembers returns a set of logins for the members of the given team
+func (i *Instrumented) GetTeamMembers(ctx context.Context, team string) (members set.Set[string], err error) {
+	ctx, done := i.call(ctx, "GetTeamMembers", attribute.String("harmonia.git.team", team))
+	defer func() { done(err) }()
+	return i.Git.GetTeamMembers(ctx, team)
+}
+
+// RequestReviewers requests a review of the given pull request from each of the given logins
+func (i *Instrumented) RequestReviewers(ctx context.Context, pr PullRequest, reviewers []string) (err error) {
+	ctx, done := i.call(ctx, "RequestReviewers", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.RequestReviewers(ctx, pr, reviewers)
+}
+
+// RequestTeamReviewers requests a review of the given pull request from each of the given teams
+func (i *Instrumented) RequestTeamReviewers(ctx context.Context, pr PullRequest, teams []string) (err error) {
+	ctx, done := i.call(ctx, "RequestTeamReviewers", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.RequestTeamReviewers(ctx, pr, teams)
+}
+
+// CreateTag tags the given sha with the given name
+func (i *Instrumented) CreateTag(ctx context.Context, sha string, name string) (err error) {
+	ctx, done := i.call(ctx, "CreateTag")
+	defer func() { done(err) }()
+	return i.Git.CreateTag(ctx, sha, name)
+}
+
+// CreateDeployment requests a deployment of the given pull request to the given environment and returns its ID
+func (i *Instrumented) CreateDeployment(ctx context.Context, pr PullRequest, environment string) (id *string,
+	err error) {
+	ctx, done := i.call(ctx, "CreateDeployment", i.pullRequest(pr)...)
+	defer func() { done(err) }()
+	return i.Git.CreateDeployment(ctx, pr, environment)
+}
+
+// GetDeploymentStatus returns the latest status of the deployment with the given ID
+func (i *Instrumented) GetDeploymentStatus(ctx context.Context, deploymentID string) (status *DeploymentStatus,
+	err error) {
+	ctx, done := i.call(ctx, "GetDeploymentStatus")
+	defer func() { done(err) }()
+	return i.Git.GetDeploymentStatus(ctx, deploymentID)
+}
+
+// GetMissingPermissions returns a description of each permission Harmonia requires on the tracking repository that
+// the client's token lacks
+func (i *Instrumented) GetMissingPermissions(ctx context.Context) (missing []string, err error) {
+	ctx, done := i.call(ctx, "GetMissingPermissions")
+	defer func() { done(err) }()
+	return i.Git.GetMissingPermissions(ctx)
+}
+
+// GetBranchProtection returns the protection the Git provider enforces on the base branch of the tracking repository
+func (i *Instrumented) GetBranchProtection(ctx context.Context) (protection *BranchProtection, err error) {
+	ctx, done := i.call(ctx, "GetBranchProtection")
+	defer func() { done(err) }()
+	return i.Git.GetBranchProtection(ctx)
+}
+
+// GetArchiveIndex returns the contents of the archive index on the base branch, nil if no RFC was archived yet
+func (i *Instrumented) GetArchiveIndex(ctx context.Context) (index *string, err error) {
+	ctx, done := i.call(ctx, "GetArchiveIndex")
+	defer func() { done(err) }()
+	return i.Git.GetArchiveIndex(ctx)
+}
+
+// GetSchemas returns the contents of the JSON files of the given directory on the base branch, keyed by file name
+func (i *Instrumented) GetSchemas(ctx context.Context, directory string) (files map[string]string, err error) {
+	ctx, done := i.call(ctx, "GetSchemas")
+	defer func() { done(err) }()
+	return i.Git.GetSchemas(ctx, directory)
+}
+
+// ArchiveRFCs removes the RFC files of the given RFCs from the base branch and writes the given archive index
+func (i *Instrumented) ArchiveRFCs(ctx context.Context, rfcIdentifiers []string, index string, message string) (
+	err error) {
+	ctx, done := i.call(ctx, "ArchiveRFCs")
+	defer func() { done(err) }()
+	return i.Git.ArchiveRFCs(ctx, rfcIdentifiers, index, message)
+}
+
+// CommitFiles writes the given files, keyed by path, to the base branch in a single commit with the given message
+func (i *Instrumented) CommitFiles(ctx context.Context, files map[string]string, message string) (committed bool,
+	err error) {
+	ctx, done := i.call(ctx, "CommitFiles")
+	defer func() { done(err) }()
+	return i.Git.CommitFiles(ctx, files, message)
+}
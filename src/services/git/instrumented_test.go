@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/metrics"
+	"harmonia-example.io/src/services/tracing"
+)
+
+// throttledGit is a Git implementation whose user login is throttled and whose pull requests are never mergeable
+type throttledGit struct {
+	Git
+}
+
+func (throttledGit) GetUserLogin(ctx context.Context) (*string, error) {
+	return nil, &ProviderError{Kind: ErrRateLimited, Err: fmt.Errorf("secondary rate limit")}
+}
+
+func (throttledGit) GetPullRequestDetails(pr PullRequest) (*PullRequestDetails, error) {
+	return &PullRequestDetails{RFCIdentifier: "instrumented", Number: 42}, nil
+}
+
+func (throttledGit) ExplainMergeability(ctx context.Context, pr PullRequest) (*models.Mergeability, error) {
+	return &models.Mergeability{Reasons: []string{"the pull request has merge conflicts"}}, nil
+}
+
+// TestInstrumented tests that calls are passed through, traced and recorded by provider, method and outcome
+func TestInstrumented(t *testing.T) {
+	// arrange
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+	git := Instrument("instrumented_test", throttledGit{})
+	registry := metrics.NewRegistry()
+	registry.Register(metrics.GitCalls.Collect)
+	registry.Register(metrics.MergeabilityPollDuration.Collect)
+
+	// act
+	_, loginErr := git.GetUserLogin(context.Background())
+	mergeability, err := git.ExplainMergeability(context.Background(), nil)
+
+	// assert
+	if loginErr == nil || err != nil || mergeability.Mergeable {
+		t.Fatalf("expected the calls to be passed through, got %v, %+v and %v", loginErr, mergeability, err)
+	}
+	var b strings.Builder
+	if err = registry.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, line := range []string{
+		`harmonia_git_calls_total{method="GetUserLogin",outcome="rate_limited",provider="instrumented_test"} 1`,
+		`harmonia_git_calls_total{method="ExplainMergeability",outcome="success",provider="instrumented_test"} 1`,
+		`harmonia_mergeability_poll_duration_seconds_count{outcome="not_mergeable",provider="instrumented_test"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %s in output:\n%s", line, b.String())
+		}
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "git.GetUserLogin" || spans[0].Status().Code != codes.Error {
+		t.Fatalf("expected a failed span of the throttled call, got %+v", spans)
+	}
+	attributes := attribute.NewSet(spans[1].Attributes()...)
+	if number, ok := attributes.Value(tracing.PR_NUMBER_KEY); !ok || number.AsInt64() != 42 {
+		t.Errorf("expected the span of the mergeability check to carry the PR number, got %v", spans[1].Attributes())
+	}
+}
@@ -0,0 +1,175 @@
+// This holds the mergeability logic shared by all Git implementations
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Defaults of the polling of status checks and mergeable states being computed by the Git provider
+const (
+	// number of times the state is read before mergeability is determined from it as is
+	DEFAULT_MERGEABILITY_ATTEMPTS int = 5
+	// delay before the state is read again, doubled for every read after it
+	DEFAULT_MERGEABILITY_WAIT time.Duration = 2 * time.Second
+	// longest delay before the state is read again
+	DEFAULT_MERGEABILITY_MAX_WAIT time.Duration = 16 * time.Second
+)
+
+// MergeabilityPolling holds how mergeability checks poll status checks and mergeable states being computed by the Git
+// provider. Every wait ends early when a webhook announces a change of the ref, see NotifyChange
+type MergeabilityPolling struct {
+	// Attempts is the number of times the state is read
+	Attempts int
+	// Wait is the delay before the state is read again, doubled for every read after it up to MaxWait
+	Wait    time.Duration
+	MaxWait time.Duration
+}
+
+// Polling is how the mergeability checks of all Git implementations poll
+var Polling = MergeabilityPolling{
+	Attempts: DEFAULT_MERGEABILITY_ATTEMPTS,
+	Wait:     DEFAULT_MERGEABILITY_WAIT,
+	MaxWait:  DEFAULT_MERGEABILITY_MAX_WAIT,
+}
+
+// poll reads the state of the given ref through the given function until it reports the state settled or the polling
+// runs out of attempts, waiting with an exponential backoff between reads. An error reading the state ends the polling
+func (p MergeabilityPolling) poll(ctx context.Context, ref string, settled func() (bool, error)) error {
+	wait := p.Wait
+	for attempt := 1; ; attempt++ {
+		done, err := settled()
+		if err != nil || done || attempt >= p.Attempts {
+			return err
+		}
+
+		waitForChange(ctx, ref, wait)
+		if wait *= 2; wait > p.MaxWait {
+			wait = p.MaxWait
+		}
+	}
+}
+
+// changes holds the channels of the mergeability checks waiting for a change of each ref
+var changes = struct {
+	sync.Mutex
+	waiting map[string][]chan struct{}
+}{waiting: map[string][]chan struct{}{}}
+
+// NotifyChange wakes the mergeability checks of the given ref waiting for its status checks or mergeable state to be
+// computed, e.g. when a webhook announces a status change, so they need not wait out their delay
+func NotifyChange(ref string) {
+	changes.Lock()
+	defer changes.Unlock()
+
+	for _, changed := range changes.waiting[ref] {
+		close(changed)
+	}
+	delete(changes.waiting, ref)
+}
+
+// waitForChange waits for the given delay, returning early if a change of the given ref is notified or the given
+// context is done
+func waitForChange(ctx context.Context, ref string, delay time.Duration) {
+	changed := make(chan struct{})
+	changes.Lock()
+	changes.waiting[ref] = append(changes.waiting[ref], changed)
+	changes.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-changed:
+		return
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	// no change was notified, stop waiting for one
+	changes.Lock()
+	defer changes.Unlock()
+	waiting := changes.waiting[ref]
+	for i, c := range waiting {
+		if c == changed {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+	if len(waiting) == 0 {
+		delete(changes.waiting, ref)
+	} else {
+		changes.waiting[ref] = waiting
+	}
+}
+
+// contextRank orders context states from best to worst
+var contextRank = map[string]int{
+	CONTEXT_SUCCESS_STATE: 0,
+	CONTEXT_PENDING_STATE: 1,
+	CONTEXT_MISSING_STATE: 2,
+	CONTEXT_FAILURE_STATE: 3,
+}
+
+// setContextState records the given state of the given context, a context reported more than once (e.g. both as a
+// commit status and a check) keeps its worst state
+func setContextState(contexts map[string]string, context string, state string) {
+	if current, ok := contexts[context]; !ok || contextRank[state] > contextRank[current] {
+		contexts[context] = state
+	}
+}
+
+// consideredContexts returns the state of each of the given required contexts, CONTEXT_MISSING_STATE for those that
+// have not reported yet, or of every context if none are required
+func consideredContexts(required []string, contexts map[string]string) map[string]string {
+	if len(required) == 0 {
+		return contexts
+	}
+
+	considered := make(map[string]string, len(required))
+	for _, context := range required {
+		if state, ok := contexts[context]; ok {
+			considered[context] = state
+		} else {
+			considered[context] = CONTEXT_MISSING_STATE
+		}
+	}
+
+	return considered
+}
+
+// contextsPending returns true if any of the given contexts has yet to complete, contexts that have not reported yet
+// may not have started
+func contextsPending(contexts map[string]string) bool {
+	for _, state := range contexts {
+		if state == CONTEXT_PENDING_STATE || state == CONTEXT_MISSING_STATE {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newMergeability explains the mergeability of a pull request from the state of its considered contexts, which must
+// all have succeeded, and the reasons the provider gave for it not being mergeable otherwise (approvals, conflicts...)
+func newMergeability(required []string, contexts map[string]string, reasons ...string) *models.Mergeability {
+	mergeability := &models.Mergeability{RequiredContexts: required, Contexts: contexts, Reasons: reasons}
+
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if state := contexts[name]; state != CONTEXT_SUCCESS_STATE {
+			mergeability.Reasons = append(mergeability.Reasons, fmt.Sprintf("status check %s is %s", name, state))
+		}
+	}
+	mergeability.Mergeable = len(mergeability.Reasons) == 0
+
+	return mergeability
+}
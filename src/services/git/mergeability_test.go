@@ -0,0 +1,116 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestNewMergeability tests that required contexts that failed, are pending or never reported prevent merging
+func TestNewMergeability(t *testing.T) {
+	// arrange
+	contexts := map[string]string{}
+	setContextState(contexts, "build", CONTEXT_SUCCESS_STATE)
+	setContextState(contexts, "deploy", CONTEXT_FAILURE_STATE)
+	setContextState(contexts, "lint", CONTEXT_PENDING_STATE)
+	// a context reported twice keeps its worst state
+	setContextState(contexts, "build", CONTEXT_PENDING_STATE)
+	setContextState(contexts, "deploy", CONTEXT_SUCCESS_STATE)
+
+	// act
+	considered := consideredContexts([]string{"deploy", "docs"}, contexts)
+	mergeability := newMergeability([]string{"deploy", "docs"}, considered)
+	all := newMergeability(nil, consideredContexts(nil, map[string]string{"build": CONTEXT_SUCCESS_STATE}))
+
+	// assert
+	if fmt.Sprint(contexts) != "map[build:pending deploy:failure lint:pending]" {
+		t.Errorf("unexpected context states: %v", contexts)
+	}
+	if !contextsPending(considered) || !contextsPending(contexts) {
+		t.Errorf("expected missing and pending contexts to be pending")
+	}
+	expected := "[status check deploy is failure status check docs is missing]"
+	if mergeability.Mergeable || fmt.Sprint(mergeability.Reasons) != expected {
+		t.Errorf("unexpected mergeability. wanted reasons %v, got %+v", expected, mergeability)
+	}
+	if !all.Mergeable || len(all.Reasons) != 0 {
+		t.Errorf("unexpected mergeability: %+v", all)
+	}
+}
+
+// TestWaitForChange tests that waiting mergeability checks are woken by a notified change of their ref
+func TestWaitForChange(t *testing.T) {
+	// arrange
+	woken := make(chan struct{})
+	go func() {
+		waitForChange(context.Background(), "123", time.Minute)
+		close(woken)
+	}()
+
+	// act
+	for {
+		changes.Lock()
+		waiting := len(changes.waiting["123"])
+		changes.Unlock()
+		if waiting > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	NotifyChange("456")
+	NotifyChange("123")
+	started := time.Now()
+	waitForChange(context.Background(), "456", time.Millisecond)
+
+	// assert
+	select {
+	case <-woken:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the check to be woken by the change")
+	}
+	if time.Since(started) < time.Millisecond {
+		t.Errorf("expected the check to wait out its delay without a change")
+	}
+	changes.Lock()
+	defer changes.Unlock()
+	if len(changes.waiting) != 0 {
+		t.Errorf("expected no check to be waiting, got %v", changes.waiting)
+	}
+}
+
+// TestPoll tests that states are read again with a capped exponential backoff until they settle or attempts run out
+func TestPoll(t *testing.T) {
+	// arrange
+	polling := MergeabilityPolling{Attempts: 4, Wait: time.Millisecond, MaxWait: 2 * time.Millisecond}
+	var reads []time.Time
+	unsettled := func() (bool, error) {
+		reads = append(reads, time.Now())
+		return false, nil
+	}
+	failing := errors.New("502 Bad Gateway")
+
+	// act
+	err := polling.poll(context.Background(), "123", unsettled)
+	settledReads := 0
+	settledErr := polling.poll(context.Background(), "123", func() (bool, error) {
+		settledReads++
+		return settledReads == 2, nil
+	})
+	failedErr := polling.poll(context.Background(), "123", func() (bool, error) { return false, failing })
+
+	// assert
+	if err != nil || settledErr != nil || failedErr != failing {
+		t.Fatalf("unexpected errors: %v, %v, %v", err, settledErr, failedErr)
+	}
+	if len(reads) != 4 || settledReads != 2 {
+		t.Fatalf("expected 4 reads of an unsettled state and 2 of a settling one, got %d and %d", len(reads),
+			settledReads)
+	}
+	for i, minimum := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond} {
+		if waited := reads[i+1].Sub(reads[i]); waited < minimum {
+			t.Errorf("expected read %d to wait at least %s, waited %s", i+2, minimum, waited)
+		}
+	}
+}
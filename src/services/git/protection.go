@@ -0,0 +1,50 @@
+// This holds the branch protection shared by all Git implementations, and its comparison to the protection Harmonia
+// relies on
+package git
+
+import (
+	"fmt"
+)
+
+// BranchProtection is the protection a Git provider enforces on a branch of the tracking repository
+type BranchProtection struct {
+	Branch    string
+	Protected bool
+	// ChecksRequired is set if status checks must pass before merging
+	ChecksRequired bool
+	// RequiredContexts are the status checks that must pass before merging, nil if the provider does not name them
+	RequiredContexts []string
+	// RequiredApprovals is the number of approvals required before merging
+	RequiredApprovals int
+}
+
+// Drift returns a description of each way the protection falls short of the given policy: every given status
+// context must be required to pass and at least the given number of approvals must be required. An empty result
+// means the protection enforces the policy
+func (p *BranchProtection) Drift(requiredContexts []string, requiredApprovals int) []string {
+	drift := []string{}
+	if !p.Protected {
+		return append(drift, fmt.Sprintf("branch %s is not protected", p.Branch))
+	}
+
+	if p.RequiredApprovals < requiredApprovals {
+		drift = append(drift, fmt.Sprintf("branch %s requires %d approvals, expected at least %d", p.Branch,
+			p.RequiredApprovals, requiredApprovals))
+	}
+	if len(requiredContexts) > 0 && !p.ChecksRequired {
+		drift = append(drift, fmt.Sprintf("branch %s does not require status checks to pass", p.Branch))
+	} else if p.RequiredContexts != nil {
+		required := map[string]bool{}
+		for _, context := range p.RequiredContexts {
+			required[context] = true
+		}
+		for _, context := range requiredContexts {
+			if !required[context] {
+				drift = append(drift, fmt.Sprintf("branch %s does not require status check %s to pass", p.Branch,
+					context))
+			}
+		}
+	}
+
+	return drift
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestBranchProtectionDrift tests that unprotected branches, missing approvals and status checks not required to pass
+// are reported as drift
+func TestBranchProtectionDrift(t *testing.T) {
+	// arrange
+	testCases := []struct {
+		protection BranchProtection
+		expected   string
+	}{
+		{
+			protection: BranchProtection{Branch: "main"},
+			expected:   "[branch main is not protected]",
+		},
+		{
+			protection: BranchProtection{Branch: "main", Protected: true, ChecksRequired: true,
+				RequiredContexts: []string{"ci/build", "lint"}, RequiredApprovals: 2},
+			expected: "[]",
+		},
+		{
+			protection: BranchProtection{Branch: "main", Protected: true, ChecksRequired: true,
+				RequiredContexts: []string{"lint"}},
+			expected: "[branch main requires 0 approvals, expected at least 1 " +
+				"branch main does not require status check ci/build to pass]",
+		},
+		{
+			protection: BranchProtection{Branch: "main", Protected: true, RequiredApprovals: 1},
+			expected:   "[branch main does not require status checks to pass]",
+		},
+		// providers that do not name the required builds are trusted to require the right ones
+		{
+			protection: BranchProtection{Branch: "main", Protected: true, ChecksRequired: true, RequiredApprovals: 1},
+			expected:   "[]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		// act
+		actual := testCase.protection.Drift([]string{"ci/build"}, 1)
+
+		// assert
+		if fmt.Sprint(actual) != testCase.expected {
+			t.Errorf("unexpected drift of %+v. expected: %s, actual: %v", testCase.protection, testCase.expected,
+				actual)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// This holds the registry of Git providers, so the provider used is selected through configuration
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/cache"
+	"harmonia-example.io/src/services/config"
+	"harmonia-example.io/src/services/metadata"
+)
+
+// Git providers registered by default
+const (
+	GITHUB_PROVIDER    string = "github"
+	BITBUCKET_PROVIDER string = "bitbucket"
+)
+
+// ErrUnknownProvider is returned (wrapped) when creating a Git implementation of a provider that is not registered
+var ErrUnknownProvider = errors.New("unknown Git provider")
+
+// ErrUnknownDomain is returned (wrapped) when selecting the tracking repository of a schema domain that has none
+var ErrUnknownDomain = models.NewError(models.ErrInvalid, models.UnknownDomainCode, "unknown schema domain")
+
+// Constructor returns a Git implementation of the given tracking repository authenticated with the given access token
+type Constructor func(ctx context.Context, accessToken string, repository Repository) (Git, error)
+
+// providers holds the constructor of each registered provider
+var providers = struct {
+	sync.RWMutex
+	constructors map[string]Constructor
+}{constructors: map[string]Constructor{
+	GITHUB_PROVIDER: func(ctx context.Context, accessToken string, repository Repository) (Git, error) {
+		return NewGitHub(ctx, accessToken, repository)
+	},
+	BITBUCKET_PROVIDER: func(ctx context.Context, accessToken string, repository Repository) (Git, error) {
+		return NewBitbucket(ctx, accessToken, repository)
+	},
+}}
+
+// clientKey identifies a Git implementation built by NewForDomain
+type clientKey struct {
+	provider    string
+	accessToken string
+	repository  Repository
+	domain      string
+}
+
+// CLIENT_TTL is how long a Git implementation built by NewForDomain is kept without being used, requests carry the
+// tokens of their users so implementations of tokens no longer in use must not be kept forever
+const CLIENT_TTL = time.Hour
+
+// clients holds the Git implementations built by NewForDomain, so each is built once rather than on every request
+// The lock is held while building an implementation so concurrent requests do not build it twice
+var clients = struct {
+	sync.Mutex
+	built *cache.Cache[clientKey, Git]
+}{built: cache.NewNamed[clientKey, Git]("git_clients", CLIENT_TTL)}
+
+// settings holds the configuration the tracking repositories and the required status contexts of the Git
+// implementations are read from, see Configure
+var settings = struct {
+	sync.RWMutex
+	config *config.Service
+}{config: config.Default}
+
+// Configure makes the Git implementations read their settings from the given configuration, config.Default unless
+// configured otherwise. The implementations already built are discarded, and discarded again whenever the
+// configuration is reloaded so they are built with its new settings
+func Configure(c *config.Service) {
+	settings.Lock()
+	settings.config = c
+	settings.Unlock()
+
+	c.OnReload(func(*config.Service) { discardClients() })
+	discardClients()
+}
+
+// configuration returns the configuration the Git implementations read their settings from
+func configuration() *config.Service {
+	settings.RLock()
+	defer settings.RUnlock()
+	return settings.config
+}
+
+// discardClients discards all the Git implementations built by NewForDomain
+func discardClients() {
+	clients.built.Clear()
+}
+
+// Register makes the given provider available through New, replacing any constructor registered under its name and
+// the implementations it built
+func Register(provider string, constructor Constructor) {
+	providers.Lock()
+	defer providers.Unlock()
+
+	providers.constructors[provider] = constructor
+
+	clients.built.DeleteMatching(func(key clientKey) bool { return key.provider == provider })
+}
+
+// IsRegistered returns true if the given provider is registered
+func IsRegistered(provider string) bool {
+	providers.RLock()
+	defer providers.RUnlock()
+
+	_, ok := providers.constructors[provider]
+	return ok
+}
+
+// Providers returns the names of all registered providers, sorted
+func Providers() []string {
+	providers.RLock()
+	defer providers.RUnlock()
+
+	names := make([]string, 0, len(providers.constructors))
+	for name := range providers.constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ConfiguredRepository returns the tracking repository of the given schema domain along with its owner, the default
+// tracking repository if the domain is empty
+// ErrUnknownDomain is returned (wrapped) if no tracking repository is configured for the domain
+func ConfiguredRepository(domain string) (*Repository, error) {
+	// tracking repository - env var if local, else AWS param
+	name, err := configuration().TrackingRepo()
+	if err != nil {
+		return nil, err
+	}
+	if domain != "" {
+		repositories, err := configuration().TrackingRepositories()
+		if err != nil {
+			return nil, err
+		}
+		repository, ok := repositories[domain]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDomain, domain)
+		}
+		name = &repository
+	}
+	owner, err := configuration().RepositoryOwner(*name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{Owner: *owner, Name: *name}, nil
+}
+
+// DomainOf returns the schema domain whose tracking repository is the given repository of the given owner, empty for
+// the default tracking repository. ErrUnknownDomain is returned (wrapped) if it is not a tracking repository
+func DomainOf(owner string, name string) (string, error) {
+	if repository, err := ConfiguredRepository(""); err != nil {
+		return "", err
+	} else if strings.EqualFold(repository.Owner, owner) && repository.Name == name {
+		return "", nil
+	}
+
+	repositories, err := configuration().TrackingRepositories()
+	if err != nil {
+		return "", err
+	}
+	for domain := range repositories {
+		if repository, err := ConfiguredRepository(domain); err != nil {
+			return "", err
+		} else if strings.EqualFold(repository.Owner, owner) && repository.Name == name {
+			return domain, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s/%s is not a tracking repository", ErrUnknownDomain, owner, name)
+}
+
+// New returns the Git implementation of the given provider for the default tracking repository, authenticated with
+// the given access token
+func New(ctx context.Context, provider string, accessToken string) (Git, error) {
+	return NewForDomain(ctx, provider, accessToken, "")
+}
+
+// NewForDomain returns the Git implementation of the given provider for the tracking repository of the given schema
+// domain, authenticated with the given access token. Its calls to the provider are instrumented, see Instrumented, and
+// have the configured faults injected, see ConfiguredFaults
+// Implementations are built once per provider, access token, tracking repository and schema domain and reused until
+// they go unused for CLIENT_TTL. The domain
+// is recorded as the tenant of the request of the given context, see metadata.SetTenant
+func NewForDomain(ctx context.Context, provider string, accessToken string, domain string) (Git, error) {
+	providers.RLock()
+	constructor, ok := providers.constructors[provider]
+	providers.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, provider)
+	}
+
+	repository, err := ConfiguredRepository(domain)
+	if err != nil {
+		return nil, err
+	}
+	metadata.SetTenant(ctx, domain)
+
+	return build(ctx, provider, constructor, accessToken, *repository, domain)
+}
+
+// NewForRepository returns the Git implementation of the given provider for the given repository, which need not be
+// a tracking repository, e.g. the repository generated types are committed to, authenticated with the given access
+// token. Its owner is the configured owner of the repository. Implementations are built and reused as by NewForDomain
+func NewForRepository(ctx context.Context, provider string, accessToken string, name string) (Git, error) {
+	providers.RLock()
+	constructor, ok := providers.constructors[provider]
+	providers.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, provider)
+	}
+
+	owner, err := configuration().RepositoryOwner(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return build(ctx, provider, constructor, accessToken, Repository{Owner: *owner, Name: name}, "")
+}
+
+// build returns the Git implementation of the given provider for the given repository of the given schema domain,
+// built with the given constructor unless it was already built, instrumented and with the configured faults injected
+func build(ctx context.Context, provider string, constructor Constructor, accessToken string, repository Repository,
+	domain string) (Git, error) {
+	key := clientKey{provider: provider, accessToken: accessToken, repository: repository, domain: domain}
+	clients.Lock()
+	defer clients.Unlock()
+	if git, ok := clients.built.Get(key); ok {
+		// implementations in use are kept
+		clients.built.Set(key, git)
+		return git, nil
+	}
+
+	git, err := constructor(ctx, accessToken, repository)
+	if err != nil {
+		return nil, err
+	}
+	if faults, err := ConfiguredFaults(); err != nil {
+		return nil, err
+	} else if faults != nil {
+		git = InjectFaults(git, *faults)
+	}
+	instrumented := Instrument(provider, git)
+	instrumented.domain = domain
+	clients.built.Set(key, instrumented)
+
+	return instrumented, nil
+}
+
+// ClientDomain returns the schema domain of the tracking repository of the given Git implementation, false is
+// returned if it was not built by NewForDomain
+func ClientDomain(git Git) (string, bool) {
+	if instrumented, ok := git.(*Instrumented); ok {
+		return instrumented.domain, true
+	}
+	return "", false
+}
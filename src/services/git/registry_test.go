@@ -0,0 +1,110 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"harmonia-example.io/src/services/config"
+)
+
+// configure makes the Git implementations read their settings from the given KEY=VALUE pairs until the test completes
+func configure(t *testing.T, environ ...string) *config.Service {
+	c := config.New(func() []string { return environ })
+	Configure(c)
+	t.Cleanup(func() { Configure(config.Default) })
+	return c
+}
+
+// TestRegistry tests that registered providers can be created by name and unknown providers are rejected
+func TestRegistry(t *testing.T) {
+	// arrange
+	configure(t, "TRACKING_REPOSITORY=rfcs", "REPOSITORY_OWNER=schema-team")
+	custom := &Bitbucket{}
+	var repository Repository
+	Register("custom", func(ctx context.Context, accessToken string, r Repository) (Git, error) {
+		repository = r
+		return custom, nil
+	})
+
+	// act
+	created, err := New(context.Background(), "custom", "token")
+	_, unknownErr := New(context.Background(), "gitea", "token")
+
+	// assert
+	if instrumented, ok := created.(*Instrumented); err != nil || !ok || instrumented.Git != Git(custom) {
+		t.Errorf("unexpected Git implementation: %v, err: %v", created, err)
+	}
+	if repository != (Repository{Owner: "schema-team", Name: "rfcs"}) {
+		t.Errorf("unexpected repository: %+v", repository)
+	}
+	if !errors.Is(unknownErr, ErrUnknownProvider) {
+		t.Errorf("expected an unknown provider error, got %v", unknownErr)
+	}
+	if !IsRegistered(GITHUB_PROVIDER) || IsRegistered("gitea") {
+		t.Errorf("unexpected registrations")
+	}
+	if fmt.Sprint(Providers()) != "[bitbucket custom github]" {
+		t.Errorf("unexpected providers: %v", Providers())
+	}
+}
+
+// TestNewForDomain tests that each schema domain is given a client of its own tracking repository
+func TestNewForDomain(t *testing.T) {
+	// arrange
+	configure(t, "TRACKING_REPOSITORY=rfcs", "TRACKING_REPOSITORIES=catalog=catalog-rfcs", "REPOSITORY_OWNER=schema-team")
+	var repository Repository
+	Register("domains", func(ctx context.Context, accessToken string, r Repository) (Git, error) {
+		repository = r
+		return &Bitbucket{}, nil
+	})
+
+	// act
+	client, err := NewForDomain(context.Background(), "domains", "token", "catalog")
+	_, unknownErr := NewForDomain(context.Background(), "domains", "token", "playback")
+
+	// assert
+	if err != nil || repository != (Repository{Owner: "schema-team", Name: "catalog-rfcs"}) {
+		t.Errorf("unexpected repository: %+v, err: %v", repository, err)
+	}
+	if domain, ok := ClientDomain(client); !ok || domain != "catalog" {
+		t.Errorf("expected the client to be built for the catalog domain, got %q", domain)
+	}
+	if !errors.Is(unknownErr, ErrUnknownDomain) {
+		t.Errorf("expected an unknown domain error, got %v", unknownErr)
+	}
+}
+
+// TestNewForDomainReuse tests that clients are built once per token and repository, until their provider is replaced
+// or the configuration is reloaded
+func TestNewForDomainReuse(t *testing.T) {
+	// arrange
+	c := configure(t, "TRACKING_REPOSITORY=rfcs", "REPOSITORY_OWNER=schema-team")
+	built := 0
+	constructor := func(ctx context.Context, accessToken string, r Repository) (Git, error) {
+		built++
+		return &Bitbucket{}, nil
+	}
+	Register("reused", constructor)
+
+	// act
+	first, _ := New(context.Background(), "reused", "token")
+	second, _ := New(context.Background(), "reused", "token")
+	_, _ = New(context.Background(), "reused", "other-token")
+	Register("reused", constructor)
+	replaced, _ := New(context.Background(), "reused", "token")
+	c.Reload()
+	reloaded, _ := New(context.Background(), "reused", "token")
+
+	// assert
+	if first != second || first == replaced {
+		t.Errorf("expected clients to be reused until their provider is replaced")
+	}
+	if replaced == reloaded {
+		t.Errorf("expected clients to be built again once the configuration is reloaded")
+	}
+	if built != 4 {
+		t.Errorf("expected 4 clients to be built, got %d", built)
+	}
+}
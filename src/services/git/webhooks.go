@@ -0,0 +1,115 @@
+// This holds the provider agnostic pull request events Git providers deliver through webhooks, and the parsing of
+// GitHub webhook payloads into them
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"harmonia-example.io/src/models"
+
+	"github.com/google/go-github/v40/github"
+)
+
+// Types of the webhook events Harmonia reacts to
+const (
+	// REVIEW_WEBHOOK_EVENT is delivered when a review is submitted or dismissed, its action is the review state
+	REVIEW_WEBHOOK_EVENT string = "review"
+	// PULL_REQUEST_WEBHOOK_EVENT is delivered when a pull request changes, e.g. it is closed or pushed to
+	PULL_REQUEST_WEBHOOK_EVENT string = "pull_request"
+	// STATUS_WEBHOOK_EVENT is delivered when a commit status or check of a pull request changes
+	STATUS_WEBHOOK_EVENT string = "status"
+)
+
+// ErrInvalidWebhook is returned (wrapped) when a webhook payload cannot be parsed
+var ErrInvalidWebhook = models.NewError(models.ErrInvalid, models.MalformedRequestCode, "invalid webhook payload")
+
+// WebhookEvent is a provider agnostic pull request event delivered by a Git provider webhook
+type WebhookEvent struct {
+	// Type is one of the webhook event types above
+	Type string
+	// Owner and Repository identify the repository the event happened in
+	Owner      string
+	Repository string
+	// RFCIdentifiers are the RFCs, i.e. head branches, the event is about, a status may be about several
+	RFCIdentifiers []string
+	Actor          string
+	// Action is what happened to the pull request (e.g. "closed"), the state of the review (e.g. "approved") or the
+	// state of the status
+	Action string
+	// Merged is set on pull request events of merged pull requests
+	Merged bool
+}
+
+// ParseGitHubWebhook returns the event delivered by a GitHub webhook of the given type (the X-GitHub-Event header) with
+// the given payload, nil if Harmonia does not react to events of the type. ErrInvalidWebhook is returned (wrapped) if
+// the payload cannot be parsed
+func ParseGitHubWebhook(eventType string, payload []byte) (*WebhookEvent, error) {
+	switch eventType {
+	case "pull_request_review", "pull_request", "status", "check_run", "check_suite":
+	default:
+		return nil, nil
+	}
+
+	parsed, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidWebhook, err.Error())
+	}
+
+	var event *WebhookEvent
+	var repository *github.Repository
+	switch e := parsed.(type) {
+	case *github.PullRequestReviewEvent:
+		event = &WebhookEvent{
+			Type:           REVIEW_WEBHOOK_EVENT,
+			RFCIdentifiers: []string{e.GetPullRequest().GetHead().GetRef()},
+			Actor:          e.GetReview().GetUser().GetLogin(),
+			Action:         strings.ToLower(e.GetReview().GetState()),
+		}
+		repository = e.GetRepo()
+	case *github.PullRequestEvent:
+		event = &WebhookEvent{
+			Type:           PULL_REQUEST_WEBHOOK_EVENT,
+			RFCIdentifiers: []string{e.GetPullRequest().GetHead().GetRef()},
+			Actor:          e.GetSender().GetLogin(),
+			Action:         e.GetAction(),
+			Merged:         e.GetPullRequest().GetMerged(),
+		}
+		repository = e.GetRepo()
+	case *github.StatusEvent:
+		event = &WebhookEvent{Type: STATUS_WEBHOOK_EVENT, Actor: e.GetSender().GetLogin(), Action: e.GetState()}
+		for _, branch := range e.Branches {
+			event.RFCIdentifiers = append(event.RFCIdentifiers, branch.GetName())
+		}
+		repository = e.GetRepo()
+	case *github.CheckRunEvent:
+		event = &WebhookEvent{
+			Type:           STATUS_WEBHOOK_EVENT,
+			RFCIdentifiers: []string{e.GetCheckRun().GetCheckSuite().GetHeadBranch()},
+			Actor:          e.GetSender().GetLogin(),
+			Action:         e.GetCheckRun().GetStatus(),
+		}
+		repository = e.GetRepo()
+	case *github.CheckSuiteEvent:
+		event = &WebhookEvent{
+			Type:           STATUS_WEBHOOK_EVENT,
+			RFCIdentifiers: []string{e.GetCheckSuite().GetHeadBranch()},
+			Actor:          e.GetSender().GetLogin(),
+			Action:         e.GetCheckSuite().GetStatus(),
+		}
+		repository = e.GetRepo()
+	}
+	event.Owner = repository.GetOwner().GetLogin()
+	event.Repository = repository.GetName()
+
+	// statuses of commits that are not the head of any branch are about no RFC
+	identifiers := event.RFCIdentifiers[:0]
+	for _, identifier := range event.RFCIdentifiers {
+		if identifier != "" {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+	event.RFCIdentifiers = identifiers
+
+	return event, nil
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestParseGitHubWebhook tests that reviews, pull request changes and statuses are parsed into webhook events and
+// other events are ignored
+func TestParseGitHubWebhook(t *testing.T) {
+	// arrange
+	repository := `"repository": {"name": "rfcs", "owner": {"login": "schema-team"}}`
+	testCases := map[string]struct {
+		eventType string
+		payload   string
+		expected  string
+	}{
+		"review": {
+			eventType: "pull_request_review",
+			payload: `{"action": "submitted", "review": {"state": "APPROVED", "user": {"login": "pparker"}},
+				"pull_request": {"head": {"ref": "123"}}, ` + repository + `}`,
+			expected: "&{Type:review Owner:schema-team Repository:rfcs RFCIdentifiers:[123] Actor:pparker " +
+				"Action:approved Merged:false}",
+		},
+		"merged pull request": {
+			eventType: "pull_request",
+			payload: `{"action": "closed", "pull_request": {"merged": true, "head": {"ref": "123"}},
+				"sender": {"login": "tstark"}, ` + repository + `}`,
+			expected: "&{Type:pull_request Owner:schema-team Repository:rfcs RFCIdentifiers:[123] Actor:tstark " +
+				"Action:closed Merged:true}",
+		},
+		"status": {
+			eventType: "status",
+			payload:   `{"state": "success", "branches": [{"name": "123"}, {"name": "456"}], ` + repository + `}`,
+			expected: "&{Type:status Owner:schema-team Repository:rfcs RFCIdentifiers:[123 456] Actor: " +
+				"Action:success Merged:false}",
+		},
+		"check run of a detached commit": {
+			eventType: "check_run",
+			payload:   `{"check_run": {"status": "completed", "check_suite": {}}, ` + repository + `}`,
+			expected: "&{Type:status Owner:schema-team Repository:rfcs RFCIdentifiers:[] Actor: " +
+				"Action:completed Merged:false}",
+		},
+		"ignored": {
+			eventType: "push",
+			payload:   `{"ref": "refs/heads/main"}`,
+			expected:  "<nil>",
+		},
+	}
+
+	for name, testCase := range testCases {
+		// act
+		event, err := ParseGitHubWebhook(testCase.eventType, []byte(testCase.payload))
+
+		// assert
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err.Error())
+		} else if actual := fmt.Sprintf("%+v", event); actual != testCase.expected {
+			t.Errorf("%s: unexpected event. expected: %s, actual: %s", name, testCase.expected, actual)
+		}
+	}
+
+	// act
+	_, err := ParseGitHubWebhook("pull_request", []byte("{"))
+
+	// assert
+	if !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("expected an invalid webhook error, got %v", err)
+	}
+}
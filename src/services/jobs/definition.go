@@ -0,0 +1,63 @@
+// Package jobs holds the queue the asynchronous work of requests, e.g. loading and merging an RFC, is run on, so the
+// work is retried when it fails and its progress can be followed through a job ID
+// This is strictly to hold the Queue interface definition and common constants used in job interactions
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used across all Queue implementations
+const (
+	MEMORY_BACKEND string = "memory"
+
+	// number of jobs run at the same time
+	DEFAULT_WORKERS int = 4
+	// number of times a job is attempted before it fails
+	DEFAULT_MAX_ATTEMPTS int = 3
+	// delay before the first retry of a job, doubled for every retry after it
+	DEFAULT_BACKOFF time.Duration = 5 * time.Second
+	// number of jobs retained, the oldest finished jobs are dropped beyond it
+	DEFAULT_HISTORY_SIZE int = 500
+	// delay before a worker of an instance that does not run jobs, see MemoryQueue.SetGate, checks whether it does again
+	GATE_INTERVAL time.Duration = time.Second
+	// period the failures reported by the stats of a queue are counted over
+	STATS_WINDOW time.Duration = time.Hour
+)
+
+// Work is the function a job runs, an error fails the attempt, which is retried if the error is Retryable
+type Work func(ctx context.Context) error
+
+// Queue defines all methods necessary for running asynchronous jobs and following their progress
+type Queue interface {
+	// Enqueue queues the given work as a job of the given kind for the given RFC of the given priority and returns the
+	// queued job. Queued jobs are run by priority, then in the order they were queued
+	// The work is run with the given context, which must not be cancelled with the request, see metadata.Detach
+	Enqueue(ctx context.Context, kind models.JobKind, rfcIdentifier string, priority models.Priority,
+		work Work) models.Job
+	// Get returns the job with the given ID, models.ErrJobNotFound is returned (wrapped) if no job has it
+	Get(id string) (*models.Job, error)
+	// Latest returns the most recently queued job of the given RFC, nil if it has none
+	Latest(rfcIdentifier string) *models.Job
+	// Stats returns the utilization of the workers and the backlog of the queue, along with the failures within
+	// STATS_WINDOW
+	Stats() models.JobStats
+}
+
+// Default is the queue shared by the application
+var Default Queue = NewMemoryQueue(DEFAULT_WORKERS, DEFAULT_MAX_ATTEMPTS, DEFAULT_BACKOFF)
+
+// Retryable returns true if the given error may not occur again when the failed work is retried, i.e. the Git
+// provider throttled the work or failed for a reason that is not the work's own, e.g. an outage
+// Errors of any other kind, such as an RFC that cannot be merged, fail the job right away
+func Retryable(err error) bool {
+	if errors.Is(err, models.ErrRateLimited) {
+		return true
+	}
+	return errors.Is(err, models.ErrProvider) && !errors.Is(err, models.ErrNotFound) &&
+		!errors.Is(err, models.ErrConflict) && !errors.Is(err, models.ErrUnauthorized)
+}
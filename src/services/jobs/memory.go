@@ -0,0 +1,296 @@
+// This is the in-memory implementation of the Queue interface found in definition.go
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+)
+
+// entry holds a job along with the work it runs and the context the work is run with
+type entry struct {
+	job  models.Job
+	ctx  context.Context
+	work Work
+}
+
+// failure records a job that failed
+type failure struct {
+	kind models.JobKind
+	at   time.Time
+}
+
+// MemoryQueue type implements the Queue interface by running jobs on a pool of workers, jobs are lost when the
+// service stops
+type MemoryQueue struct {
+	mu          sync.Mutex
+	ready       *sync.Cond
+	pending     []*entry
+	jobs        map[string]*entry
+	order       []string
+	workers     int
+	busy        int
+	maxAttempts int
+	backoff     time.Duration
+	historySize int
+	// failures and retries hold the kind of each job that failed and the time of each failed attempt that was
+	// retried within STATS_WINDOW, oldest first
+	failures []failure
+	retries  []time.Time
+
+	// retryable decides whether a failed attempt is retried, see Retryable
+	retryable func(err error) bool
+	// gate decides whether the workers of this instance run jobs, checked every gateInterval, see SetGate
+	gate         func() bool
+	gateInterval time.Duration
+}
+
+// NewMemoryQueue returns a MemoryQueue running up to the given number of jobs at the same time, each attempted up to
+// the given number of times, with the given delay before its first retry
+func NewMemoryQueue(workers int, maxAttempts int, backoff time.Duration) *MemoryQueue {
+	if workers <= 0 {
+		workers = DEFAULT_WORKERS
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DEFAULT_MAX_ATTEMPTS
+	}
+
+	q := &MemoryQueue{
+		jobs:         map[string]*entry{},
+		workers:      workers,
+		maxAttempts:  maxAttempts,
+		backoff:      backoff,
+		historySize:  DEFAULT_HISTORY_SIZE,
+		retryable:    Retryable,
+		gateInterval: GATE_INTERVAL,
+	}
+	q.ready = sync.NewCond(&q.mu)
+	for i := 0; i < workers; i++ {
+		go q.runWorker()
+	}
+
+	return q
+}
+
+// Enqueue queues the given work as a job of the given kind for the given RFC of the given priority and returns the
+// queued job
+func (q *MemoryQueue) Enqueue(ctx context.Context, kind models.JobKind, rfcIdentifier string, priority models.Priority,
+	work Work) models.Job {
+	now := time.Now().UTC()
+	e := &entry{
+		job: models.Job{
+			ID:            newID(),
+			Kind:          kind,
+			RFCIdentifier: rfcIdentifier,
+			State:         models.QueuedJob,
+			Priority:      priority,
+			MaxAttempts:   q.maxAttempts,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+		ctx:  ctx,
+		work: work,
+	}
+	logging.Annotate(ctx, logging.JOB_ID_KEY, e.job.ID)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs[e.job.ID] = e
+	q.order = append(q.order, e.job.ID)
+	q.prune()
+	q.pending = append(q.pending, e)
+	q.ready.Signal()
+
+	return e.job
+}
+
+// Get returns the job with the given ID, models.ErrJobNotFound is returned (wrapped) if no job has it
+func (q *MemoryQueue) Get(id string) (*models.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	e, ok := q.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrJobNotFound, id)
+	}
+	job := e.job
+	return &job, nil
+}
+
+// Latest returns the most recently queued job of the given RFC, nil if it has none
+func (q *MemoryQueue) Latest(rfcIdentifier string) *models.Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := len(q.order) - 1; i >= 0; i-- {
+		if e := q.jobs[q.order[i]]; e.job.RFCIdentifier == rfcIdentifier {
+			job := e.job
+			return &job
+		}
+	}
+	return nil
+}
+
+// Stats returns the utilization of the workers and the backlog of the queue, along with the failures within
+// STATS_WINDOW
+func (q *MemoryQueue) Stats() models.JobStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now().UTC()
+	q.forget(now)
+	stats := models.JobStats{
+		Workers:        q.workers,
+		Busy:           q.busy,
+		Utilization:    float64(q.busy) / float64(q.workers),
+		RecentFailures: map[models.JobKind]int{},
+		RecentRetries:  len(q.retries),
+		Window:         STATS_WINDOW.String(),
+	}
+	for _, id := range q.order {
+		job := q.jobs[id].job
+		if job.State != models.QueuedJob {
+			continue
+		}
+		stats.QueueDepth++
+		// jobs are ordered by the time they were queued
+		if stats.OldestPendingJob == "" {
+			stats.OldestPendingJob = job.ID
+			stats.OldestPendingSeconds = now.Sub(job.CreatedAt).Seconds()
+		}
+	}
+	for _, f := range q.failures {
+		stats.RecentFailures[f.kind]++
+	}
+
+	return stats
+}
+
+// forget drops the failures and retries older than STATS_WINDOW
+// The caller must hold the lock
+func (q *MemoryQueue) forget(now time.Time) {
+	since := now.Add(-STATS_WINDOW)
+	for len(q.failures) > 0 && q.failures[0].at.Before(since) {
+		q.failures = q.failures[1:]
+	}
+	for len(q.retries) > 0 && q.retries[0].Before(since) {
+		q.retries = q.retries[1:]
+	}
+}
+
+// SetGate makes the workers only run jobs while the given gate returns true, e.g. while this instance leads, so the
+// jobs of a queue shared by several instances are run by a single one
+func (q *MemoryQueue) SetGate(gate func() bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.gate = gate
+}
+
+// runWorker runs pending jobs one at a time, forever
+func (q *MemoryQueue) runWorker() {
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 {
+			q.ready.Wait()
+		}
+		if q.gate != nil && !q.gate() {
+			q.mu.Unlock()
+			time.Sleep(q.gateInterval)
+			continue
+		}
+		e := q.next()
+		q.busy++
+		e.job.State = models.RunningJob
+		e.job.Attempts++
+		e.job.NextAttemptAt = nil
+		e.job.UpdatedAt = time.Now().UTC()
+		q.mu.Unlock()
+
+		q.finish(e, e.work(e.ctx))
+	}
+}
+
+// next removes and returns the pending job of the most urgent RFC, the earliest queued one among equally urgent RFCs
+// The caller must hold the lock and there must be a pending job
+func (q *MemoryQueue) next() *entry {
+	index := 0
+	for i, e := range q.pending {
+		if e.job.Priority.Rank() > q.pending[index].job.Priority.Rank() {
+			index = i
+		}
+	}
+	e := q.pending[index]
+	q.pending = append(q.pending[:index], q.pending[index+1:]...)
+
+	return e
+}
+
+// finish records the outcome of an attempt of the given job, a failed attempt is queued again after a backoff if its
+// error is retryable and the job has attempts left
+func (q *MemoryQueue) finish(e *entry, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now().UTC()
+	q.busy--
+	q.forget(now)
+	e.job.UpdatedAt = now
+	if err == nil {
+		e.job.State = models.SucceededJob
+		e.job.Error = ""
+		return
+	}
+
+	e.job.Error = err.Error()
+	if e.job.Attempts >= e.job.MaxAttempts || !q.retryable(err) {
+		e.job.State = models.FailedJob
+		q.failures = append(q.failures, failure{kind: e.job.Kind, at: now})
+		logging.FromContext(e.ctx).Error("job failed", "attempts", e.job.Attempts, logging.ERROR_KEY, err)
+		return
+	}
+
+	// the backoff doubles with every failed attempt
+	delay := q.backoff << (e.job.Attempts - 1)
+	next := now.Add(delay)
+	e.job.State = models.QueuedJob
+	e.job.NextAttemptAt = &next
+	q.retries = append(q.retries, now)
+	logging.FromContext(e.ctx).Warn("job attempt failed, retrying", "attempts", e.job.Attempts, "retryIn", delay,
+		logging.ERROR_KEY, err)
+	time.AfterFunc(delay, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.pending = append(q.pending, e)
+		q.ready.Signal()
+	})
+}
+
+// prune drops the oldest finished jobs beyond the history size, jobs still queued or running are always retained
+// The caller must hold the lock
+func (q *MemoryQueue) prune() {
+	for i := 0; len(q.order) > q.historySize && i < len(q.order); {
+		if state := q.jobs[q.order[i]].job.State; state == models.SucceededJob || state == models.FailedJob {
+			delete(q.jobs, q.order[i])
+			q.order = append(q.order[:i], q.order[i+1:]...)
+		} else {
+			i++
+		}
+	}
+}
+
+// newID returns a random job ID
+func newID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		// the clock is unique enough should the random source fail
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// await polls the given queue until the job with the given ID is finished, failing the test if it takes too long
+func await(t *testing.T, queue Queue, id string) *models.Job {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		job, err := queue.Get(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if job.State == models.SucceededJob || job.State == models.FailedJob {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish in time", id)
+	return nil
+}
+
+func TestMemoryQueueRetries(t *testing.T) {
+	// arrange
+	queue := NewMemoryQueue(2, 3, time.Millisecond)
+	throttled := &models.ProviderError{Kind: models.ErrRateLimited, Err: fmt.Errorf("API rate limit exceeded")}
+	calls := 0
+
+	// act
+	retried := queue.Enqueue(context.Background(), models.LoadJob, "123", models.NormalPriority,
+		func(ctx context.Context) error {
+			if calls++; calls < 3 {
+				return throttled
+			}
+			return nil
+		})
+	exhausted := queue.Enqueue(context.Background(), models.LoadJob, "456", models.NormalPriority,
+		func(ctx context.Context) error {
+			return throttled
+		})
+	permanent := queue.Enqueue(context.Background(), models.LoadAndMergeJob, "456", models.NormalPriority,
+		func(ctx context.Context) error {
+			return errors.New("RFC is not mergeable")
+		})
+
+	// assert
+	if retried.State != models.QueuedJob || retried.ID == "" || retried.ID == exhausted.ID {
+		t.Errorf("unexpected queued job: %+v", retried)
+	}
+	if job := await(t, queue, retried.ID); job.State != models.SucceededJob || job.Attempts != 3 || job.Error != "" {
+		t.Errorf("expected the job to succeed on its third attempt, got %+v", job)
+	}
+	if job := await(t, queue, exhausted.ID); job.State != models.FailedJob || job.Attempts != 3 {
+		t.Errorf("expected the job to fail once out of attempts, got %+v", job)
+	}
+	if job := await(t, queue, permanent.ID); job.State != models.FailedJob || job.Attempts != 1 ||
+		job.Error != "RFC is not mergeable" {
+		t.Errorf("expected the job to fail without retrying, got %+v", job)
+	}
+}
+
+func TestMemoryQueueLookup(t *testing.T) {
+	// arrange
+	queue := NewMemoryQueue(1, 1, time.Millisecond)
+	queue.historySize = 2
+	done := func(ctx context.Context) error { return nil }
+
+	// act
+	first := queue.Enqueue(context.Background(), models.LoadJob, "123", models.NormalPriority, done)
+	await(t, queue, first.ID)
+	second := queue.Enqueue(context.Background(), models.LoadAfterGateJob, "123", models.NormalPriority, done)
+	await(t, queue, second.ID)
+	queue.Enqueue(context.Background(), models.BreakGlassJob, "456", models.NormalPriority, done)
+	_, prunedErr := queue.Get(first.ID)
+	_, unknownErr := queue.Get("unknown")
+
+	// assert
+	if latest := queue.Latest("123"); latest == nil || latest.ID != second.ID {
+		t.Errorf("expected the latest job of the RFC to be %s, got %+v", second.ID, latest)
+	}
+	if queue.Latest("789") != nil {
+		t.Errorf("expected no job for an RFC without any")
+	}
+	if !errors.Is(prunedErr, models.ErrJobNotFound) || !errors.Is(unknownErr, models.ErrJobNotFound) {
+		t.Errorf("expected pruned and unknown jobs not to be found, got %v and %v", prunedErr, unknownErr)
+	}
+}
+
+func TestMemoryQueuePriority(t *testing.T) {
+	// arrange
+	queue := NewMemoryQueue(1, 1, time.Millisecond)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	order := []string{}
+	record := func(rfcIdentifier string) Work {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, rfcIdentifier)
+			return nil
+		}
+	}
+
+	// act
+	blocker := queue.Enqueue(context.Background(), models.LoadJob, "blocker", models.NormalPriority,
+		func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+	for blocked, _ := queue.Get(blocker.ID); blocked.State != models.RunningJob; blocked, _ = queue.Get(blocker.ID) {
+		time.Sleep(time.Millisecond)
+	}
+	queue.Enqueue(context.Background(), models.LoadJob, "low", models.LowPriority, record("low"))
+	queue.Enqueue(context.Background(), models.LoadJob, "routine", "", record("routine"))
+	queue.Enqueue(context.Background(), models.LoadAndMergeJob, "hotfix", models.UrgentPriority, record("hotfix"))
+	last := queue.Enqueue(context.Background(), models.LoadJob, "normal", models.NormalPriority, record("normal"))
+	close(release)
+	await(t, queue, last.ID)
+
+	// assert
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(order[:3]) != "[hotfix routine normal]" {
+		t.Errorf("expected urgent jobs first and equally urgent jobs in order, got %v", order)
+	}
+}
+
+func TestMemoryQueueStats(t *testing.T) {
+	// arrange
+	queue := NewMemoryQueue(2, 1, time.Millisecond)
+	release := make(chan struct{})
+	block := func(ctx context.Context) error {
+		<-release
+		return nil
+	}
+
+	// act
+	failed := queue.Enqueue(context.Background(), models.LoadAndMergeJob, "123", models.NormalPriority,
+		func(ctx context.Context) error {
+			return errors.New("RFC is not mergeable")
+		})
+	await(t, queue, failed.ID)
+	blockers := []models.Job{
+		queue.Enqueue(context.Background(), models.LoadJob, "456", models.NormalPriority, block),
+		queue.Enqueue(context.Background(), models.LoadJob, "789", models.NormalPriority, block),
+	}
+	for _, blocker := range blockers {
+		for job, _ := queue.Get(blocker.ID); job.State != models.RunningJob; job, _ = queue.Get(blocker.ID) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	pending := queue.Enqueue(context.Background(), models.LoadJob, "012", models.NormalPriority, block)
+	queue.Enqueue(context.Background(), models.LoadJob, "345", models.NormalPriority, block)
+	stats := queue.Stats()
+	close(release)
+
+	// assert
+	if stats.Workers != 2 || stats.Busy != 2 || stats.Utilization != 1 {
+		t.Errorf("expected every worker to be busy, got %+v", stats)
+	}
+	if stats.QueueDepth != 2 || stats.OldestPendingJob != pending.ID {
+		t.Errorf("expected two jobs to be queued behind %s, got %+v", pending.ID, stats)
+	}
+	if stats.RecentFailures[models.LoadAndMergeJob] != 1 || stats.RecentRetries != 0 {
+		t.Errorf("expected a single failure, got %+v", stats)
+	}
+}
+
+func TestMemoryQueueGate(t *testing.T) {
+	// arrange
+	queue := NewMemoryQueue(1, 1, time.Millisecond)
+	queue.gateInterval = time.Millisecond
+	var mu sync.Mutex
+	leading := false
+	queue.SetGate(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return leading
+	})
+	done := func(ctx context.Context) error { return nil }
+
+	// act
+	job := queue.Enqueue(context.Background(), models.LoadJob, "123", models.NormalPriority, done)
+	time.Sleep(10 * time.Millisecond)
+	waiting, _ := queue.Get(job.ID)
+	mu.Lock()
+	leading = true
+	mu.Unlock()
+
+	// assert
+	if waiting.State != models.QueuedJob {
+		t.Errorf("expected the job to wait while the gate is closed, got %+v", waiting)
+	}
+	if ran := await(t, queue, job.ID); ran.State != models.SucceededJob {
+		t.Errorf("expected the job to run once the gate opened, got %+v", ran)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	// arrange
+	testCases := map[error]bool{
+		&models.ProviderError{Kind: models.ErrRateLimited, Err: errors.New("throttled")}:  true,
+		&models.ProviderError{Err: errors.New("502 Bad Gateway")}:                         true,
+		&models.ProviderError{Kind: models.ErrNotFound, Err: errors.New("404 Not Found")}: false,
+		&models.ProviderError{Kind: models.ErrConflict, Err: errors.New("409 Conflict")}:  false,
+		errors.New("RFC is not mergeable"):                                                false,
+	}
+
+	// assert
+	for err, expected := range testCases {
+		if actual := Retryable(err); actual != expected {
+			t.Errorf("unexpected retryability of %v. expected: %v, actual: %v", err, expected, actual)
+		}
+	}
+}
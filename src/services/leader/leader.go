@@ -0,0 +1,151 @@
+// Package leader holds the election of the single instance of a deployment running its singleton work, the scheduled
+// jobs and the consumption of a shared job queue, while every instance keeps serving the API. Instances campaign for
+// a lease on a shared lock, the holder of an unexpired lease leads
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/schedule"
+)
+
+// Common constants used across all Lock implementations
+const (
+	NONE_BACKEND string = "none"
+	FILE_BACKEND string = "file"
+
+	// how long a lease lasts unless renewed, the leader renews it every third of it
+	DEFAULT_LEASE time.Duration = 30 * time.Second
+)
+
+// ErrContended is returned (wrapped) by a Lock that another instance is updating at the same time, the lock is neither
+// acquired nor lost this round
+var ErrContended = errors.New("lock is being updated by another instance")
+
+// Lock is a lock held by a single holder at a time, for a lease that expires unless renewed
+type Lock interface {
+	// Acquire acquires the lock for the given holder for the given lease, or renews the lease if the holder already
+	// holds it. False is returned if another holder holds an unexpired lease, ErrContended if the lock could not be
+	// read this round
+	Acquire(ctx context.Context, holder string, lease time.Duration) (bool, error)
+	// Release releases the lock if the given holder holds it
+	Release(ctx context.Context, holder string) error
+}
+
+// Elector campaigns for a lock on behalf of this instance, which leads while it holds an unexpired lease
+type Elector struct {
+	Holder string
+	lock   Lock
+	lease  time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	expiresAt time.Time
+}
+
+// NewElector returns an Elector campaigning for the given lock with leases of the given duration, DEFAULT_LEASE if not
+// positive, on behalf of a holder identifying this instance
+func NewElector(lock Lock, lease time.Duration) *Elector {
+	if lease <= 0 {
+		lease = DEFAULT_LEASE
+	}
+
+	return &Elector{Holder: newHolder(), lock: lock, lease: lease, now: time.Now}
+}
+
+// Default is the elector of the application, nil if every instance leads, as a single instance deployment does
+var Default *Elector
+
+// Leading returns true if this instance leads, i.e. if no elector is configured or its lease is unexpired
+func Leading() bool {
+	if Default == nil {
+		return true
+	}
+	return Default.Leading()
+}
+
+// Only returns a job running the given job if this instance leads when it is run, so scheduled jobs are run by a
+// single instance
+func Only(job func()) func() {
+	return func() {
+		if Leading() {
+			job()
+		}
+	}
+}
+
+// Leading returns true if this instance holds an unexpired lease
+func (e *Elector) Leading() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.now().Before(e.expiresAt)
+}
+
+// Campaign acquires or renews the lease of this instance, it steps down if another instance leads or the lock fails
+// The lease is counted from before the lock is acquired, so this instance never believes it leads past its expiry. A
+// contended lock keeps the lease of this instance until it expires, it is renewed on the next campaign
+func (e *Elector) Campaign(ctx context.Context) {
+	started := e.now()
+	acquired, err := e.lock.Acquire(ctx, e.Holder, e.lease)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	wasLeading := started.Before(e.expiresAt)
+	if errors.Is(err, ErrContended) {
+		logging.Default.Debug("leadership lock contended, campaigning again next round", "holder", e.Holder)
+		return
+	}
+	if err != nil || !acquired {
+		e.expiresAt = time.Time{}
+		if err != nil {
+			logging.Default.Warn("unable to campaign for leadership, stepping down", logging.ERROR_KEY, err)
+		} else if wasLeading {
+			logging.Default.Warn("leadership lost to another instance", "holder", e.Holder)
+		}
+		return
+	}
+	e.expiresAt = started.Add(e.lease)
+	if !wasLeading {
+		logging.Default.Info("leading the deployment", "holder", e.Holder)
+	}
+}
+
+// Start campaigns right away and then every third of the lease until the returned function is called, which releases
+// the lock so another instance can lead without waiting for the lease to expire
+func (e *Elector) Start() func() {
+	e.Campaign(context.Background())
+	stop := schedule.Every(e.lease/3, func() { e.Campaign(context.Background()) })
+
+	return func() {
+		stop()
+		e.mu.Lock()
+		e.expiresAt = time.Time{}
+		e.mu.Unlock()
+		if err := e.lock.Release(context.Background(), e.Holder); err != nil {
+			logging.Default.Warn("unable to release leadership", logging.ERROR_KEY, err)
+		}
+	}
+}
+
+// newHolder returns a holder identifying this instance, its hostname along with a random suffix so restarted instances
+// are told apart
+func newHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "harmonia"
+	}
+	suffix := make([]byte, 4)
+	if _, err = rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%x", host, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s-%s", host, hex.EncodeToString(suffix))
+}
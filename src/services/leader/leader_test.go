@@ -0,0 +1,114 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCampaign(t *testing.T) {
+	// arrange
+	now := time.Unix(1654074000, 0)
+	clock := func() time.Time { return now }
+	lock := NewMemoryLock()
+	lock.now = clock
+	first, second := NewElector(lock, time.Minute), NewElector(lock, time.Minute)
+	first.now, second.now = clock, clock
+
+	// act
+	first.Campaign(context.Background())
+	second.Campaign(context.Background())
+	firstLed, secondLed := first.Leading(), second.Leading()
+	// the first instance stops renewing its lease, e.g. it crashed
+	now = now.Add(time.Minute)
+	expired := first.Leading()
+	second.Campaign(context.Background())
+
+	// assert
+	if !firstLed || secondLed {
+		t.Errorf("expected a single leader, got %v and %v", firstLed, secondLed)
+	}
+	if expired || !second.Leading() {
+		t.Errorf("expected the second instance to lead once the lease of the first expired")
+	}
+}
+
+// contendedLock is a Lock that acquires the lock until it is contended
+type contendedLock struct {
+	contended bool
+}
+
+// Acquire acquires the lock unless it is contended
+func (c *contendedLock) Acquire(ctx context.Context, holder string, lease time.Duration) (bool, error) {
+	if c.contended {
+		return false, fmt.Errorf("%w: test", ErrContended)
+	}
+	return true, nil
+}
+
+// Release releases the lock
+func (c *contendedLock) Release(ctx context.Context, holder string) error {
+	return nil
+}
+
+func TestCampaignContended(t *testing.T) {
+	// arrange
+	now := time.Unix(1654074000, 0)
+	lock := &contendedLock{}
+	elector := NewElector(lock, time.Minute)
+	elector.now = func() time.Time { return now }
+
+	// act
+	elector.Campaign(context.Background())
+	lock.contended = true
+	now = now.Add(time.Minute / 3)
+	elector.Campaign(context.Background())
+	kept := elector.Leading()
+	now = now.Add(time.Minute)
+	expired := elector.Leading()
+
+	// assert
+	if !kept {
+		t.Errorf("expected a contended lock to keep the unexpired lease")
+	}
+	if expired {
+		t.Errorf("expected a lease that could not be renewed to expire")
+	}
+}
+
+func TestFileLock(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "leader.json")
+	first, second := NewFileLock(path), NewFileLock(path)
+
+	// act
+	firstAcquired, firstErr := first.Acquire(context.Background(), "first", time.Minute)
+	secondAcquired, secondErr := second.Acquire(context.Background(), "second", time.Minute)
+	renewed, _ := first.Acquire(context.Background(), "first", time.Minute)
+	releaseErr := first.Release(context.Background(), "first")
+	takenOver, _ := second.Acquire(context.Background(), "second", time.Minute)
+	// another instance is updating the lease file
+	if err := os.WriteFile(path+".guard", nil, 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, contendedErr := second.Acquire(context.Background(), "second", time.Minute)
+
+	// assert
+	if firstErr != nil || secondErr != nil || releaseErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v", firstErr, secondErr, releaseErr)
+	}
+	if !firstAcquired || secondAcquired || !renewed {
+		t.Errorf("expected the lease to be held by the first holder only, got %v, %v, %v", firstAcquired,
+			secondAcquired, renewed)
+	}
+	if !takenOver {
+		t.Errorf("expected a released lease to be acquired by another holder")
+	}
+	if !errors.Is(contendedErr, ErrContended) {
+		t.Errorf("expected a contended lease file, got %v", contendedErr)
+	}
+}
@@ -0,0 +1,143 @@
+// This holds the implementations of the Lock interface found in leader.go
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// lease is the holder of a lock and when its lease expires
+type lease struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// held returns true if the lease is held by a holder other than the given one at the given time
+func (l *lease) held(holder string, now time.Time) bool {
+	return l.Holder != "" && l.Holder != holder && now.Before(l.ExpiresAt)
+}
+
+// MemoryLock type implements the Lock interface in memory, so it is only shared by the electors of a single instance
+type MemoryLock struct {
+	mu    sync.Mutex
+	lease lease
+	now   func() time.Time
+}
+
+// NewMemoryLock returns a MemoryLock held by no one
+func NewMemoryLock() *MemoryLock {
+	return &MemoryLock{now: time.Now}
+}
+
+// Acquire acquires the lock for the given holder for the given lease, or renews it, unless another holder holds it
+func (m *MemoryLock) Acquire(ctx context.Context, holder string, duration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if m.lease.held(holder, now) {
+		return false, nil
+	}
+	m.lease = lease{Holder: holder, ExpiresAt: now.Add(duration)}
+	return true, nil
+}
+
+// Release releases the lock if the given holder holds it
+func (m *MemoryLock) Release(ctx context.Context, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lease.Holder == holder {
+		m.lease = lease{}
+	}
+	return nil
+}
+
+// FileLock type implements the Lock interface with a lease file on a filesystem shared by the instances, e.g. a
+// network volume. The lease file is only read and written while a guard file is exclusively created next to it
+type FileLock struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileLock returns a FileLock recording its lease in the file of the given path
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path, now: time.Now}
+}
+
+// Acquire acquires the lock for the given holder for the given lease, or renews it, unless another holder holds it
+func (f *FileLock) Acquire(ctx context.Context, holder string, duration time.Duration) (bool, error) {
+	acquired := false
+	err := f.guarded(ctx, duration, func(current *lease) (*lease, error) {
+		now := f.now()
+		if current.held(holder, now) {
+			return nil, nil
+		}
+		acquired = true
+		return &lease{Holder: holder, ExpiresAt: now.Add(duration)}, nil
+	})
+
+	return acquired, err
+}
+
+// Release releases the lock if the given holder holds it
+func (f *FileLock) Release(ctx context.Context, holder string) error {
+	return f.guarded(ctx, DEFAULT_LEASE, func(current *lease) (*lease, error) {
+		if current.Holder != holder {
+			return nil, nil
+		}
+		return &lease{}, nil
+	})
+}
+
+// guarded reads the current lease and writes the lease the given update returns, if any, while holding the guard
+// file. A guard file older than the given lease was left by a crashed instance and is removed
+func (f *FileLock) guarded(ctx context.Context, duration time.Duration,
+	update func(current *lease) (*lease, error)) error {
+	guard := f.path + ".guard"
+	file, err := os.OpenFile(guard, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if errors.Is(err, os.ErrExist) {
+		if info, statErr := os.Stat(guard); statErr == nil && f.now().Sub(info.ModTime()) > duration {
+			os.Remove(guard)
+		}
+		return fmt.Errorf("%w: lease file %s", ErrContended, f.path)
+	} else if err != nil {
+		logging.FromContext(ctx).Error("unable to create lease guard file", "file", guard, logging.ERROR_KEY, err)
+		return err
+	}
+	file.Close()
+	defer os.Remove(guard)
+
+	current := &lease{}
+	content, err := os.ReadFile(f.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(content) > 0 {
+		if err = json.Unmarshal(content, current); err != nil {
+			return fmt.Errorf("malformed lease file %s: %w", f.path, err)
+		}
+	}
+
+	next, err := update(current)
+	if err != nil || next == nil {
+		return err
+	}
+	content, err = json.Marshal(next)
+	if err != nil {
+		return err
+	}
+	// the lease is written to a temporary file first so it is never read half written
+	temporary := f.path + ".tmp"
+	if err = os.WriteFile(temporary, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(temporary, f.path)
+}
@@ -1,2 +1,216 @@
 // Package loader is where all load logic to your database should occur
 package loader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used by all registries
+const (
+	// DEFAULT_TARGET is the only load target of deployments that do not configure any
+	DEFAULT_TARGET = "default"
+	// DEFAULT_CONCURRENCY is the number of targets loaded at the same time unless configured otherwise
+	DEFAULT_CONCURRENCY = 4
+)
+
+// MergePolicy determines whether an RFC loaded into several targets can be merged when only some of them succeeded
+type MergePolicy string
+
+// Supported merge policies
+const (
+	// RequireAll only allows merging RFCs that were loaded into every target
+	RequireAll MergePolicy = "all"
+	// AllowPartial allows merging RFCs that were loaded into at least one target
+	AllowPartial MergePolicy = "partial"
+)
+
+// Loader loads the content of an RFC into a single datastore
+type Loader interface {
+	// Load loads the given JSON RFC content, returning an error if the datastore rejected it
+	Load(ctx context.Context, content []byte) error
+}
+
+// LoaderFunc adapts a function to the Loader interface
+type LoaderFunc func(ctx context.Context, content []byte) error
+
+// Load calls f
+func (f LoaderFunc) Load(ctx context.Context, content []byte) error {
+	return f(ctx, content)
+}
+
+// Placeholder returns a loader for the given target that only prints the content it is given and resolves every item
+// as missing. Replace it with a client of the target's datastore
+func Placeholder(target string) Loader {
+	return placeholder(target)
+}
+
+// placeholder is the loader returned by Placeholder
+type placeholder string
+
+// Load prints the given content
+func (p placeholder) Load(ctx context.Context, content []byte) error {
+	// call database service with the RFC content to load
+	// ...
+	fmt.Printf("loading into target %s: %s\n", p, content)
+	// ...
+	return nil
+}
+
+// Resolve resolves every item as missing
+func (p placeholder) Resolve(ctx context.Context, target models.Target) (map[string]interface{}, error) {
+	// call database service to look the targeted item up
+	// ...
+	return nil, nil
+}
+
+// Registry holds the loader of each configured load target along with how targets are loaded together
+// It is safe for concurrent use
+type Registry struct {
+	mu          sync.RWMutex
+	loaders     map[string]Loader
+	concurrency int
+	policy      MergePolicy
+	// shadows holds the shadow loader of each target running in shadow mode, see RegisterShadow
+	shadows map[string]Loader
+	// shadowMu guards the shadow stats and serializes shadow reports
+	shadowMu    sync.Mutex
+	shadowStats map[string]*ShadowStats
+	reporter    func(result ShadowResult)
+	shadowing   sync.WaitGroup
+}
+
+// NewRegistry returns a registry without any load target, loading DEFAULT_CONCURRENCY targets at a time and requiring
+// all of them to succeed before merging
+func NewRegistry() *Registry {
+	return &Registry{
+		loaders:     map[string]Loader{},
+		concurrency: DEFAULT_CONCURRENCY,
+		policy:      RequireAll,
+		shadows:     map[string]Loader{},
+		shadowStats: map[string]*ShadowStats{},
+		reporter:    LogDivergence,
+	}
+}
+
+// Default is the registry shared by the application, it holds a placeholder DEFAULT_TARGET until configured
+var Default = func() *Registry {
+	r := NewRegistry()
+	r.Register(DEFAULT_TARGET, Placeholder(DEFAULT_TARGET))
+	return r
+}()
+
+// Register sets the loader of the given target, replacing any existing one
+func (r *Registry) Register(target string, loader Loader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.loaders[target] = loader
+}
+
+// Get returns the loader of the given target and true, or nil and false if the target is not configured
+func (r *Registry) Get(target string) (Loader, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loader, ok := r.loaders[target]
+	return loader, ok
+}
+
+// Targets returns the names of all configured load targets, sorted
+func (r *Registry) Targets() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	targets := make([]string, 0, len(r.loaders))
+	for target := range r.loaders {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	return targets
+}
+
+// SetConcurrency sets the number of targets loaded at the same time, which must be at least 1
+func (r *Registry) SetConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return fmt.Errorf("load concurrency must be at least 1, got %d", concurrency)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.concurrency = concurrency
+
+	return nil
+}
+
+// SetMergePolicy sets whether RFCs loaded into only some of their targets can be merged
+func (r *Registry) SetMergePolicy(policy MergePolicy) error {
+	if policy != RequireAll && policy != AllowPartial {
+		return fmt.Errorf("unknown load merge policy: %s", policy)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+
+	return nil
+}
+
+// MergePolicy returns whether RFCs loaded into only some of their targets can be merged
+func (r *Registry) MergePolicy() MergePolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.policy
+}
+
+// LoadAll loads the given content into each of the given targets concurrently, bounded by the configured concurrency,
+// and returns the outcome of each target keyed by target, a nil error meaning the target was loaded
+// The given done function, if any, is called with the outcome of each target as soon as it is known, along with the
+// action timings its loader reported (see StartAction), calls are never concurrent so it can record progress without
+// locking. Targets that are not configured fail without being loaded
+// Targets in shadow mode are also handed to their shadow loader, in the background, whose outcome never affects the
+// returned outcomes
+func (r *Registry) LoadAll(ctx context.Context, targets []string, content []byte,
+	done func(target string, err error, timings []models.ActionTiming)) map[string]error {
+	r.mu.RLock()
+	concurrency := r.concurrency
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	results := make(map[string]error, len(targets))
+	pool := make(chan struct{}, concurrency)
+	for _, target := range targets {
+		wg.Add(1)
+		pool <- struct{}{}
+		go func(target string) {
+			defer wg.Done()
+			defer func() { <-pool }()
+
+			var err error
+			loadCtx, collector := withTimings(ctx)
+			if loader, ok := r.Get(target); !ok {
+				err = fmt.Errorf("%w: %s", models.ErrUnknownLoadTarget, target)
+			} else {
+				err = loader.Load(loadCtx, content)
+				r.shadow(ctx, target, content, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[target] = err
+			if done != nil {
+				done(target, err, collector.sorted())
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,157 @@
+package loader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestLoadAll(t *testing.T) {
+	// arrange
+	r := NewRegistry()
+	if err := r.SetConcurrency(2); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var mu sync.Mutex
+	running, peak := 0, 0
+	for i := 0; i < 5; i++ {
+		r.Register(fmt.Sprintf("target-%d", i), LoaderFunc(func(ctx context.Context, content []byte) error {
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+			return nil
+		}))
+	}
+	r.Register("broken", LoaderFunc(func(ctx context.Context, content []byte) error {
+		return fmt.Errorf("datastore unavailable")
+	}))
+
+	// act
+	done := 0
+	results := r.LoadAll(context.Background(), append(r.Targets(), "unknown"), []byte("{}"),
+		func(target string, err error, timings []models.ActionTiming) { done++ })
+
+	// assert
+	if len(results) != 7 || done != 7 {
+		t.Errorf("unexpected outcomes. wanted 7 results and calls, got %d results and %d calls", len(results), done)
+	}
+	if peak > 2 {
+		t.Errorf("concurrency was not bounded. wanted at most 2 loads at a time, got %d", peak)
+	}
+	if results["broken"] == nil || results["target-0"] != nil {
+		t.Errorf("unexpected outcomes: %v", results)
+	}
+	if !errors.Is(results["unknown"], models.ErrUnknownLoadTarget) {
+		t.Errorf("expected an unknown load target error, got %v", results["unknown"])
+	}
+}
+
+func TestRegistrySettings(t *testing.T) {
+	r := NewRegistry()
+	if r.SetConcurrency(0) == nil {
+		t.Errorf("expected an error for a concurrency below 1")
+	}
+	if r.SetMergePolicy("sometimes") == nil {
+		t.Errorf("expected an error for an unknown merge policy")
+	}
+	if r.MergePolicy() != RequireAll {
+		t.Errorf("unexpected default merge policy. wanted %v, got %v", RequireAll, r.MergePolicy())
+	}
+}
+
+func TestShadow(t *testing.T) {
+	// arrange
+	r := NewRegistry()
+	r.Register("primary", LoaderFunc(func(ctx context.Context, content []byte) error {
+		if string(content) == "rejected" {
+			return fmt.Errorf("datastore rejected content")
+		}
+		return nil
+	}))
+	r.Register("unshadowed", Placeholder("unshadowed"))
+	if err := r.RegisterShadow("primary", LoaderFunc(func(ctx context.Context, content []byte) error {
+		if string(content) == "panic" {
+			panic("shadow bug")
+		}
+		return nil
+	})); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	var reported []ShadowResult
+	r.SetShadowReporter(func(result ShadowResult) { reported = append(reported, result) })
+
+	// act
+	unknownErr := r.RegisterShadow("unknown", Placeholder("unknown"))
+	var outcomes []map[string]error
+	for _, content := range []string{"{}", "rejected", "panic"} {
+		outcomes = append(outcomes, r.LoadAll(context.Background(), []string{"primary", "unshadowed"},
+			[]byte(content), nil))
+		r.WaitForShadows()
+	}
+
+	// assert
+	if unknownErr == nil {
+		t.Errorf("expected an error when shadowing an unknown target")
+	}
+	if outcomes[1]["primary"] == nil || outcomes[2]["primary"] != nil {
+		t.Errorf("expected only the target's loader to decide outcomes, got %v", outcomes)
+	}
+	if len(reported) != 3 || reported[0].Diverged() || !reported[1].Diverged() || !reported[2].Diverged() {
+		t.Errorf("unexpected shadow results: %+v", reported)
+	}
+	stats := r.ShadowStats()
+	if len(stats) != 1 || stats[0] != (ShadowStats{Target: "primary", Matches: 1, Divergences: 2}) {
+		t.Errorf("unexpected shadow stats: %+v", stats)
+	}
+}
+
+func TestActionTimings(t *testing.T) {
+	// arrange, the loader applies the actions of the RFC last to first
+	r := NewRegistry()
+	r.Register("primary", LoaderFunc(func(ctx context.Context, content []byte) error {
+		rfc := &models.RFC{}
+		if err := json.Unmarshal(content, rfc); err != nil {
+			return err
+		}
+		for i := len(rfc.Actions) - 1; i >= 0; i-- {
+			done := StartAction(ctx, i, rfc.Actions[i])
+			done(fmt.Sprintf("apply %d", i))
+		}
+		return nil
+	}))
+	r.Register("silent", LoaderFunc(func(ctx context.Context, content []byte) error { return nil }))
+	content := []byte(`{"actions": [{"actionType": "add", "target": {"targetType": "item", "targetDescriptor": "Event"}},
+		{"actionType": "delete", "target": {"targetType": "item", "targetDescriptor": "Show"}}]}`)
+
+	// act
+	timings := map[string][]models.ActionTiming{}
+	r.LoadAll(context.Background(), []string{"primary", "silent"}, content,
+		func(target string, err error, actionTimings []models.ActionTiming) { timings[target] = actionTimings })
+
+	// assert
+	primary := timings["primary"]
+	if len(primary) != 2 || primary[0].Index != 0 || primary[0].ActionType != models.AddAction ||
+		primary[0].TargetDescriptor != "Event" || primary[1].Index != 1 || primary[1].Statements[0] != "apply 1" {
+		t.Errorf("expected the timing of each action in the order of the RFC, got %+v", primary)
+	}
+	if len(timings["silent"]) != 0 {
+		t.Errorf("expected no timings for a loader that reports none, got %+v", timings["silent"])
+	}
+
+	// act & assert actions applied outside of a load are not timed
+	StartAction(context.Background(), 0, &models.Action{})()
+}
@@ -0,0 +1,36 @@
+// this holds the resolution of RFC action targets against the datastore of a load target, so the changes an RFC makes
+// can be previewed before it is loaded
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"harmonia-example.io/src/models"
+)
+
+// ErrCannotResolve is returned (wrapped) when the loader of a load target does not implement Resolver
+var ErrCannotResolve = errors.New("load target cannot resolve action targets")
+
+// Resolver is implemented by loaders that can read the current state of the items RFC actions target
+type Resolver interface {
+	// Resolve returns the current attributes of the item the given action target locates, nil if it does not exist
+	Resolve(ctx context.Context, target models.Target) (map[string]interface{}, error)
+}
+
+// Resolve returns the current attributes of the item the given action target locates in the datastore of the given
+// load target, nil if it does not exist
+func (r *Registry) Resolve(ctx context.Context, loadTarget string, target models.Target) (map[string]interface{},
+	error) {
+	loader, ok := r.Get(loadTarget)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrUnknownLoadTarget, loadTarget)
+	}
+	resolver, ok := loader.(Resolver)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCannotResolve, loadTarget)
+	}
+
+	return resolver.Resolve(ctx, target)
+}
@@ -0,0 +1,139 @@
+// this holds shadow mode, which runs a new loader implementation alongside the loader of a target so its outcomes can
+// be compared before it is trusted with the target's datastore
+package loader
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+// Planner is implemented by loaders that can tell what loading some content would change without changing anything,
+// e.g. by validating it against the datastore. Shadow loaders implementing it are planned rather than loaded
+type Planner interface {
+	// Plan returns a description of the changes loading the given JSON RFC content would make, or an error if the
+	// datastore would reject it
+	Plan(ctx context.Context, content []byte) (string, error)
+}
+
+// ShadowResult is the outcome of a shadow load compared to the outcome of the load it shadowed
+type ShadowResult struct {
+	Target string
+	// Primary and Shadow are the errors of the target's loader and of its shadow, nil if they succeeded
+	Primary error
+	Shadow  error
+	// Plan is the plan of a shadow implementing Planner
+	Plan string
+}
+
+// Diverged returns whether the shadow and the loader it shadowed disagree on whether the content could be loaded
+func (result ShadowResult) Diverged() bool {
+	return (result.Primary == nil) != (result.Shadow == nil)
+}
+
+// ShadowStats counts the shadow loads of a target
+type ShadowStats struct {
+	Target      string
+	Matches     int
+	Divergences int
+}
+
+// LogDivergence is the default shadow reporter, it logs the shadow loads that diverged
+func LogDivergence(result ShadowResult) {
+	if result.Diverged() {
+		logging.Default.Warn("shadow load diverged", "target", result.Target, "loaderError", result.Primary,
+			"shadowError", result.Shadow)
+	}
+}
+
+// RegisterShadow runs the given shadow loader alongside the loader of the given target, which must be registered, on
+// every load of the target. Only the target's loader decides the outcome of the load, so a shadow can safely be a
+// new implementation of the loader pointed at a staging datastore, or one implementing Planner
+func (r *Registry) RegisterShadow(target string, shadow Loader) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.loaders[target]; !ok {
+		return fmt.Errorf("cannot shadow load target %s, it is not configured", target)
+	}
+	r.shadows[target] = shadow
+
+	r.shadowMu.Lock()
+	defer r.shadowMu.Unlock()
+	if _, ok := r.shadowStats[target]; !ok {
+		r.shadowStats[target] = &ShadowStats{Target: target}
+	}
+
+	return nil
+}
+
+// SetShadowReporter sets the function every shadow result is reported to, LogDivergence by default
+// Reports are never concurrent
+func (r *Registry) SetShadowReporter(reporter func(result ShadowResult)) {
+	r.shadowMu.Lock()
+	defer r.shadowMu.Unlock()
+
+	r.reporter = reporter
+}
+
+// ShadowStats returns the shadow load counts of every target in shadow mode, sorted by target
+func (r *Registry) ShadowStats() []ShadowStats {
+	r.shadowMu.Lock()
+	defer r.shadowMu.Unlock()
+
+	stats := make([]ShadowStats, 0, len(r.shadowStats))
+	for _, s := range r.shadowStats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Target < stats[j].Target })
+
+	return stats
+}
+
+// WaitForShadows waits for the shadow loads in progress to complete, e.g. before shutting down
+func (r *Registry) WaitForShadows() {
+	r.shadowing.Wait()
+}
+
+// shadow hands the given content, loaded into the given target with the given outcome, to the target's shadow loader
+// in the background, if the target is in shadow mode. A shadow that panics is reported as failed
+func (r *Registry) shadow(ctx context.Context, target string, content []byte, primary error) {
+	r.mu.RLock()
+	shadow, ok := r.shadows[target]
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	r.shadowing.Add(1)
+	go func() {
+		defer r.shadowing.Done()
+		result := ShadowResult{Target: target, Primary: primary}
+		func() {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					result.Shadow = fmt.Errorf("shadow loader panicked: %v", recovered)
+				}
+			}()
+			if planner, ok := shadow.(Planner); ok {
+				result.Plan, result.Shadow = planner.Plan(ctx, content)
+			} else {
+				result.Shadow = shadow.Load(ctx, content)
+			}
+		}()
+
+		// hold the lock while reporting so reports are never concurrent
+		r.shadowMu.Lock()
+		defer r.shadowMu.Unlock()
+		if result.Diverged() {
+			r.shadowStats[target].Divergences++
+		} else {
+			r.shadowStats[target].Matches++
+		}
+		if r.reporter != nil {
+			r.reporter(result)
+		}
+	}()
+}
@@ -0,0 +1,57 @@
+// this holds the timing of the actions loaders apply, which loaders report through the context of the load so that the
+// Loader interface stays a single call
+package loader
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// timingsKey is the context key of the action timings of a load
+type timingsKey struct{}
+
+// timings collects the action timings reported during the load of a single target
+type timings struct {
+	mu      sync.Mutex
+	actions []models.ActionTiming
+}
+
+// withTimings returns a context collecting the action timings reported during a load, along with the collector
+func withTimings(ctx context.Context) (context.Context, *timings) {
+	collector := &timings{}
+	return context.WithValue(ctx, timingsKey{}, collector), collector
+}
+
+// StartAction starts timing the action of the given index in the RFC being loaded with the given context and returns
+// the function to call, with the statements run to apply it if any, once the action is applied
+// Loaders call it for each action they apply, loads whose loader does not report any action have no action timings
+func StartAction(ctx context.Context, index int, action *models.Action) func(statements ...string) {
+	start := time.Now()
+	return func(statements ...string) {
+		collector, ok := ctx.Value(timingsKey{}).(*timings)
+		if !ok {
+			return
+		}
+		timing := models.ActionTiming{Index: index, ActionType: action.ActionType,
+			TargetDescriptor: action.Target.TargetDescriptor, Seconds: time.Since(start).Seconds(),
+			Statements: statements}
+
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		collector.actions = append(collector.actions, timing)
+	}
+}
+
+// sorted returns the collected action timings in the order of the actions in the RFC
+func (t *timings) sorted() []models.ActionTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sorted := append([]models.ActionTiming(nil), t.actions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+	return sorted
+}
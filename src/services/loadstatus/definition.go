@@ -0,0 +1,56 @@
+// Package loadstatus holds the stores the load status of RFCs is recorded in, so a load need not commit every status
+// change to the RFC file
+// This is strictly to hold the Store interface definition and common constants used in store interactions
+package loadstatus
+
+import (
+	"context"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used across all Store implementations
+const (
+	RFC_BACKEND    string = "rfc"
+	MEMORY_BACKEND string = "memory"
+	FILE_BACKEND   string = "file"
+)
+
+// Store defines all methods necessary for recording and reading the load status of RFCs
+type Store interface {
+	// Save records the given load status, replacing any previously recorded for the same RFC
+	Save(ctx context.Context, record models.LoadRecord) error
+	// Get returns the load status recorded for the given RFC, nil if none was
+	Get(ctx context.Context, rfcIdentifier string) (*models.LoadRecord, error)
+}
+
+// Default is the store shared by the application
+var Default Store = RFCFileStore{}
+
+// inRFCFile holds whether load statuses are also recorded in the RFC file
+var inRFCFile = true
+
+// InRFCFile returns whether load statuses are also recorded in the RFC file, they always are unless a store other than
+// the RFC file is configured
+func InRFCFile() bool {
+	return inRFCFile
+}
+
+// SetInRFCFile sets whether load statuses are also recorded in the RFC file
+func SetInRFCFile(enabled bool) {
+	inRFCFile = enabled
+}
+
+// RFCFileStore type implements the Store interface by recording nothing, leaving the RFC file as the only record of
+// load statuses
+type RFCFileStore struct{}
+
+// Save does nothing, the load status is recorded in the RFC file
+func (RFCFileStore) Save(ctx context.Context, record models.LoadRecord) error {
+	return nil
+}
+
+// Get returns nil, the load status is read from the RFC file
+func (RFCFileStore) Get(ctx context.Context, rfcIdentifier string) (*models.LoadRecord, error) {
+	return nil, nil
+}
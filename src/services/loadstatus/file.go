@@ -0,0 +1,88 @@
+// This is the local file implementation of the Store interface found in definition.go
+package loadstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"harmonia-example.io/src/models"
+)
+
+// FileStore type implements the Store interface by keeping the load status of every RFC in memory and writing them all
+// to a JSON file on every change, so statuses survive restarts of a single instance
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore returns a FileStore writing to the given file, reading the statuses it already holds if it exists
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read load status file %s: %w", path, err)
+	}
+	if err = json.Unmarshal(content, &store.records); err != nil {
+		return nil, fmt.Errorf("malformed load status file %s: %w", path, err)
+	}
+	if store.records == nil {
+		store.records = map[string]models.LoadRecord{}
+	}
+
+	return store, nil
+}
+
+// Save records the given load status, replacing any previously recorded for the same RFC, and writes every status to
+// the file. The file is replaced at once so a failed write never leaves it half written
+func (s *FileStore) Save(ctx context.Context, record models.LoadRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.records[record.RFCIdentifier]
+	s.records[record.RFCIdentifier] = copyRecord(record)
+	if err := s.write(); err != nil {
+		// keep memory consistent with the file
+		if existed {
+			s.records[record.RFCIdentifier] = previous
+		} else {
+			delete(s.records, record.RFCIdentifier)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// write writes every status to a temporary file and moves it over the file, the caller must hold the lock
+func (s *FileStore) write() error {
+	content, err := json.Marshal(s.records)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write load status file %s: %w", s.path, err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("unable to write load status file %s: %w", s.path, err)
+	}
+	if err = temp.Close(); err != nil {
+		return fmt.Errorf("unable to write load status file %s: %w", s.path, err)
+	}
+	if err = os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to write load status file %s: %w", s.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package loadstatus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestFileStore(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "status.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	targets := map[string]string{"primary": "loading"}
+	updatedAt := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	// act
+	saveErr := store.Save(context.Background(), models.LoadRecord{RFCIdentifier: "123", Status: "loading",
+		Targets: targets, Requester: "tstark", UpdatedAt: updatedAt})
+	targets["primary"] = "successful"
+	reopened, reopenErr := NewFileStore(path)
+	record, getErr := reopened.Get(context.Background(), "123")
+	missing, missingErr := reopened.Get(context.Background(), "456")
+
+	// assert
+	if saveErr != nil || reopenErr != nil || getErr != nil || missingErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", saveErr, reopenErr, getErr, missingErr)
+	}
+	if record == nil || record.Status != "loading" || record.Targets["primary"] != "loading" ||
+		record.Requester != "tstark" || !record.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("unexpected record read back from the file: %+v", record)
+	}
+	if missing != nil {
+		t.Errorf("expected no record for an RFC that was never loaded, got %+v", missing)
+	}
+}
+
+func TestFileStoreMalformed(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "status.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// act
+	_, err := NewFileStore(path)
+
+	// assert
+	if err == nil {
+		t.Errorf("expected a malformed load status file to be rejected")
+	}
+}
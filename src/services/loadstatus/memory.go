@@ -0,0 +1,63 @@
+// This is the in-memory implementation of the Store interface found in definition.go
+package loadstatus
+
+import (
+	"context"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// MemoryStore type implements the Store interface by keeping the load status of every RFC in memory, statuses are lost
+// when the service stops
+type MemoryStore struct {
+	mu      sync.RWMutex
+	records map[string]models.LoadRecord
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]models.LoadRecord{}}
+}
+
+// Save records the given load status, replacing any previously recorded for the same RFC
+func (s *MemoryStore) Save(ctx context.Context, record models.LoadRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.RFCIdentifier] = copyRecord(record)
+	return nil
+}
+
+// Get returns the load status recorded for the given RFC, nil if none was
+func (s *MemoryStore) Get(ctx context.Context, rfcIdentifier string) (*models.LoadRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.records[rfcIdentifier]
+	if !ok {
+		return nil, nil
+	}
+	record = copyRecord(record)
+	return &record, nil
+}
+
+// copyRecord returns a copy of the given record that does not share its target statuses or action timings, loads keep
+// updating theirs
+func copyRecord(record models.LoadRecord) models.LoadRecord {
+	if record.Targets != nil {
+		targets := make(map[string]string, len(record.Targets))
+		for target, status := range record.Targets {
+			targets[target] = status
+		}
+		record.Targets = targets
+	}
+	if record.Timings != nil {
+		timings := make(map[string][]models.ActionTiming, len(record.Timings))
+		for target, targetTimings := range record.Timings {
+			timings[target] = targetTimings
+		}
+		record.Timings = timings
+	}
+	return record
+}
@@ -0,0 +1,97 @@
+// Package logging holds the structured logger of the application and the request-scoped loggers derived from it,
+// which carry the request ID, RFC identifier and user of the request they log for
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Common constants used to configure loggers and name the attributes of request-scoped loggers
+const (
+	JSON_FORMAT        string = "json"
+	TEXT_FORMAT        string = "text"
+	REQUEST_ID_KEY     string = "requestId"
+	TRACE_ID_KEY       string = "traceId"
+	RFC_IDENTIFIER_KEY string = "rfcIdentifier"
+	USER_KEY           string = "user"
+	TENANT_KEY         string = "tenant"
+	JOB_ID_KEY         string = "jobId"
+	ERROR_KEY          string = "error"
+)
+
+// scopeKey is the key the request scope is stored under in contexts
+type scopeKey struct{}
+
+// Default is the logger of the application, request-scoped loggers are derived from it
+var Default = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+// New returns a logger writing records of at least the given level ("debug", "info", "warn" or "error") to the given
+// writer in the given format, JSON_FORMAT or TEXT_FORMAT
+func New(w io.Writer, format string, level string) (*slog.Logger, error) {
+	var minimum slog.Level
+	if err := minimum.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("unknown log level %s, expected one of debug, info, warn or error", level)
+	}
+	options := &slog.HandlerOptions{Level: minimum}
+
+	switch strings.ToLower(format) {
+	case JSON_FORMAT:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case TEXT_FORMAT:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %s, expected %s or %s", format, JSON_FORMAT, TEXT_FORMAT)
+	}
+}
+
+// scope holds the logger of a request, it is replaced as the request is annotated so every context derived from the
+// request context logs with the annotations made through any of them
+type scope struct {
+	mu     sync.RWMutex
+	logger *slog.Logger
+}
+
+// NewContext returns a copy of the given context carrying the given logger, the logger of a new request scope
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{logger: logger})
+}
+
+// FromContext returns the logger of the request the given context belongs to, Default if it has none
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if s, ok := ctx.Value(scopeKey{}).(*scope); ok {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			return s.logger
+		}
+	}
+
+	return Default
+}
+
+// Annotate adds the given attributes, alternating keys and values, to the logger of the request the given context
+// belongs to, so every record logged for the rest of the request carries them
+// The call does nothing for contexts that do not belong to a request
+func Annotate(ctx context.Context, args ...any) {
+	if ctx == nil {
+		return
+	}
+	if s, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.logger = s.logger.With(args...)
+	}
+}
+
+// Detach returns a new context carrying the logger of the given context, but none of its deadline, cancellation or
+// other values. It is meant for work that outlives the request, e.g. an asynchronous load, which is given a request
+// scope of its own so its annotations do not leak into the request
+func Detach(ctx context.Context) context.Context {
+	return NewContext(context.Background(), FromContext(ctx))
+}
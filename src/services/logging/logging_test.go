@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	// arrange
+	var out bytes.Buffer
+
+	// act
+	logger, err := New(&out, JSON_FORMAT, "warn")
+	_, levelErr := New(&out, JSON_FORMAT, "loud")
+	_, formatErr := New(&out, "xml", "info")
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	logger.Info("ignored")
+	logger.Warn("kept", USER_KEY, "tstark")
+	var record map[string]any
+	if err = json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q", out.String())
+	}
+	if record["msg"] != "kept" || record[USER_KEY] != "tstark" {
+		t.Errorf("unexpected record: %v", record)
+	}
+	if levelErr == nil || formatErr == nil {
+		t.Errorf("expected errors for an unknown level and format, got %v and %v", levelErr, formatErr)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	// arrange
+	var out bytes.Buffer
+	logger, err := New(&out, JSON_FORMAT, "info")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	request := NewContext(context.Background(), logger)
+	derived, cancel := context.WithCancel(request)
+	defer cancel()
+
+	// act
+	Annotate(derived, RFC_IDENTIFIER_KEY, "123")
+	Annotate(context.Background(), USER_KEY, "ignored")
+	detached := Detach(request)
+	Annotate(detached, USER_KEY, "machine")
+	FromContext(request).Info("request")
+
+	// assert
+	var record map[string]any
+	if err = json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q", out.String())
+	}
+	if record[RFC_IDENTIFIER_KEY] != "123" {
+		t.Errorf("expected annotations of derived contexts to apply to the request, got %v", record)
+	}
+	if _, ok := record[USER_KEY]; ok {
+		t.Errorf("expected annotations of detached contexts not to leak into the request, got %v", record)
+	}
+	if FromContext(context.Background()) != Default {
+		t.Errorf("expected contexts without a logger to use the default logger")
+	}
+}
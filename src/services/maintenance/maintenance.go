@@ -0,0 +1,68 @@
+// Package maintenance holds the maintenance mode switch operators use to pause all mutating operations during incidents
+package maintenance
+
+import (
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// DEFAULT_MESSAGE is returned to rejected requests when maintenance mode is enabled without a message
+const DEFAULT_MESSAGE = "Harmonia is undergoing maintenance, changes are temporarily disabled. Please try again later."
+
+// Mode is a maintenance mode switch that is safe for concurrent use
+type Mode struct {
+	mu      sync.RWMutex
+	enabled bool
+	message string
+	since   time.Time
+}
+
+// Default is the maintenance mode shared by the application, it is disabled until switched on
+var Default = &Mode{}
+
+// Enable switches maintenance mode on, rejected requests are given the given message or DEFAULT_MESSAGE if it is empty
+// Enabling an already enabled mode only updates the message
+func (m *Mode) Enable(message string) {
+	if message == "" {
+		message = DEFAULT_MESSAGE
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.enabled {
+		m.enabled = true
+		m.since = time.Now().UTC()
+	}
+	m.message = message
+}
+
+// Disable switches maintenance mode off
+func (m *Mode) Disable() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.enabled = false
+	m.message = ""
+	m.since = time.Time{}
+}
+
+// Enabled returns whether maintenance mode is on and, if so, the message rejected requests are given
+func (m *Mode) Enabled() (bool, string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.enabled, m.message
+}
+
+// Status returns the current state of the maintenance mode
+func (m *Mode) Status() *models.Maintenance {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := &models.Maintenance{Enabled: m.enabled, Message: m.message}
+	if m.enabled {
+		since := m.since
+		status.Since = &since
+	}
+	return status
+}
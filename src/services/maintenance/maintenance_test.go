@@ -0,0 +1,38 @@
+package maintenance
+
+import (
+	"testing"
+)
+
+func TestMode(t *testing.T) {
+	// arrange
+	m := &Mode{}
+
+	// assert disabled by default
+	if enabled, _ := m.Enabled(); enabled {
+		t.Errorf("expected maintenance mode to be disabled by default")
+	}
+	if status := m.Status(); status.Enabled || status.Since != nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// act & assert enabling without a message uses the default
+	m.Enable("")
+	if enabled, message := m.Enabled(); !enabled || message != DEFAULT_MESSAGE {
+		t.Errorf("unexpected state. enabled: %v, message: %s", enabled, message)
+	}
+	since := m.Status().Since
+
+	// act & assert enabling again only updates the message
+	m.Enable("database failover in progress")
+	status := m.Status()
+	if !status.Enabled || status.Message != "database failover in progress" || !status.Since.Equal(*since) {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// act & assert disabling
+	m.Disable()
+	if enabled, message := m.Enabled(); enabled || message != "" {
+		t.Errorf("unexpected state. enabled: %v, message: %s", enabled, message)
+	}
+}
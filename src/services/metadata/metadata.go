@@ -0,0 +1,157 @@
+// Package metadata holds the metadata of a request (its ID, user, tenant and RFC identifier), carried by the request
+// context under typed keys so middleware, controllers and the Git service record and read it the same way
+// Recording metadata also annotates the logger and the span of the request with it
+package metadata
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/tracing"
+)
+
+// Key identifies a piece of request metadata
+type Key int
+
+// Keys of the metadata of a request
+const (
+	// REQUEST_ID is the ID of the request, given by the client or generated
+	REQUEST_ID Key = iota
+	// USER is the login of the user the request is made on behalf of
+	USER
+	// TENANT is the schema domain whose tracking repository the request operates on, empty for the default one
+	TENANT
+	// RFC_IDENTIFIER is the identifier of the RFC the request is for
+	RFC_IDENTIFIER
+)
+
+// logKeys holds the attribute each key is logged under
+var logKeys = map[Key]string{
+	REQUEST_ID:     logging.REQUEST_ID_KEY,
+	USER:           logging.USER_KEY,
+	TENANT:         logging.TENANT_KEY,
+	RFC_IDENTIFIER: logging.RFC_IDENTIFIER_KEY,
+}
+
+// spanKeys holds the attribute each key is traced under
+var spanKeys = map[Key]attribute.Key{
+	REQUEST_ID:     tracing.REQUEST_ID_KEY,
+	USER:           tracing.USER_KEY,
+	TENANT:         tracing.TENANT_KEY,
+	RFC_IDENTIFIER: tracing.RFC_IDENTIFIER_KEY,
+}
+
+// storeKey is the key the metadata of a request is stored under in contexts
+type storeKey struct{}
+
+// store holds the metadata of a request, it is filled in as the request is handled so every context derived from the
+// request context reads the metadata recorded through any of them
+type store struct {
+	mu     sync.RWMutex
+	values map[Key]string
+}
+
+// NewContext returns a copy of the given context carrying the metadata of a new request with the given ID
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, storeKey{}, &store{values: map[Key]string{REQUEST_ID: requestID}})
+}
+
+// Set records the given value of the given key for the request the given context belongs to, annotating the logger
+// and span of the request with it. Empty values are not recorded
+// The call does nothing for contexts that do not belong to a request
+func Set(ctx context.Context, key Key, value string) {
+	if ctx == nil || value == "" {
+		return
+	}
+	s, ok := ctx.Value(storeKey{}).(*store)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.values[key] = value
+	s.mu.Unlock()
+	logging.Annotate(ctx, logKeys[key], value)
+	trace.SpanFromContext(ctx).SetAttributes(spanKeys[key].String(value))
+}
+
+// Get returns the value of the given key recorded for the request the given context belongs to, empty if none was
+func Get(ctx context.Context, key Key) string {
+	if ctx == nil {
+		return ""
+	}
+	if s, ok := ctx.Value(storeKey{}).(*store); ok {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.values[key]
+	}
+
+	return ""
+}
+
+// RequestID returns the ID of the request the given context belongs to
+func RequestID(ctx context.Context) string {
+	return Get(ctx, REQUEST_ID)
+}
+
+// User returns the login of the user the request of the given context is made on behalf of
+func User(ctx context.Context) string {
+	return Get(ctx, USER)
+}
+
+// SetUser records the login of the user the request of the given context is made on behalf of
+func SetUser(ctx context.Context, user string) {
+	Set(ctx, USER, user)
+}
+
+// Tenant returns the schema domain the request of the given context operates on
+func Tenant(ctx context.Context) string {
+	return Get(ctx, TENANT)
+}
+
+// SetTenant records the schema domain the request of the given context operates on
+func SetTenant(ctx context.Context, tenant string) {
+	Set(ctx, TENANT, tenant)
+}
+
+// RFCIdentifier returns the identifier of the RFC the request of the given context is for
+func RFCIdentifier(ctx context.Context) string {
+	return Get(ctx, RFC_IDENTIFIER)
+}
+
+// SetRFCIdentifier records the identifier of the RFC the request of the given context is for
+func SetRFCIdentifier(ctx context.Context, rfcIdentifier string) {
+	Set(ctx, RFC_IDENTIFIER, rfcIdentifier)
+}
+
+// Detach returns a new context for work that outlives the request of the given context, e.g. an asynchronous load
+// It carries a copy of the metadata of the request along with its logger and span, see tracing.Detach, so metadata
+// recorded by the work does not leak into the request
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(tracing.Detach(ctx), storeKey{}, copyStore(ctx))
+}
+
+// Fork returns a new context for work done concurrently on behalf of the request of the given context, e.g. one of
+// the RFCs of a batch submission. Unlike Detach it keeps the deadline and cancellation of the request, but its
+// metadata and logger annotations are copies so those recorded by one piece of work do not leak into the others
+func Fork(ctx context.Context) context.Context {
+	scoped := logging.NewContext(ctx, logging.FromContext(ctx))
+	return context.WithValue(scoped, storeKey{}, copyStore(ctx))
+}
+
+// copyStore returns a copy of the metadata of the request the given context belongs to, empty if it has none
+func copyStore(ctx context.Context) *store {
+	copied := &store{values: map[Key]string{}}
+	if s, ok := ctx.Value(storeKey{}).(*store); ok {
+		s.mu.RLock()
+		for key, value := range s.values {
+			copied.values[key] = value
+		}
+		s.mu.RUnlock()
+	}
+
+	return copied
+}
@@ -0,0 +1,86 @@
+package metadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+func TestSet(t *testing.T) {
+	// arrange
+	var out bytes.Buffer
+	logger, err := logging.New(&out, logging.JSON_FORMAT, "info")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	request := NewContext(logging.NewContext(context.Background(), logger), "request-1")
+	derived, cancel := context.WithCancel(request)
+	defer cancel()
+
+	// act
+	SetUser(derived, "tstark")
+	SetTenant(derived, "")
+	SetRFCIdentifier(request, "123")
+	Set(context.Background(), USER, "ignored")
+	logging.FromContext(request).Info("request")
+
+	// assert
+	if RequestID(derived) != "request-1" || User(request) != "tstark" || RFCIdentifier(derived) != "123" {
+		t.Errorf("expected metadata recorded through any context of the request to apply to all of them")
+	}
+	if Tenant(request) != "" || User(context.Background()) != "" {
+		t.Errorf("expected empty values and contexts without a request not to record metadata")
+	}
+	var record map[string]any
+	if err = json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a single JSON record, got %q", out.String())
+	}
+	if record[logging.USER_KEY] != "tstark" || record[logging.RFC_IDENTIFIER_KEY] != "123" {
+		t.Errorf("expected the logger of the request to be annotated with its metadata, got %v", record)
+	}
+}
+
+func TestDetach(t *testing.T) {
+	// arrange
+	request, cancel := context.WithCancel(NewContext(context.Background(), "request-1"))
+	SetUser(request, "tstark")
+
+	// act
+	cancel()
+	detached := Detach(request)
+	SetUser(detached, "machine")
+
+	// assert
+	if detached.Err() != nil || RequestID(detached) != "request-1" || User(detached) != "machine" {
+		t.Errorf("expected detached contexts to carry a copy of the metadata of the request")
+	}
+	if User(request) != "tstark" {
+		t.Errorf("expected metadata recorded by detached contexts not to leak into the request, got %s", User(request))
+	}
+}
+
+func TestFork(t *testing.T) {
+	// arrange
+	request, cancel := context.WithCancel(NewContext(context.Background(), "request-1"))
+	SetUser(request, "tstark")
+
+	// act
+	forked := Fork(request)
+	SetRFCIdentifier(forked, "123")
+	cancel()
+
+	// assert
+	if forked.Err() == nil {
+		t.Errorf("expected forked contexts to be canceled along with the request")
+	}
+	if RequestID(forked) != "request-1" || User(forked) != "tstark" || RFCIdentifier(forked) != "123" {
+		t.Errorf("expected forked contexts to carry a copy of the metadata of the request")
+	}
+	if RFCIdentifier(request) != "" {
+		t.Errorf("expected metadata recorded by forked contexts not to leak into the request, got %s",
+			RFCIdentifier(request))
+	}
+}
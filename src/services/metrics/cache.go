@@ -0,0 +1,28 @@
+// This holds the collector exposing the usage of named caches
+package metrics
+
+import (
+	"harmonia-example.io/src/services/cache"
+)
+
+// CacheCollector exposes the hits, misses, evictions and size of every named cache, labelled by cache name
+func CacheCollector() []Family {
+	hits := Family{Name: NAMESPACE + "_cache_hits_total", Help: "Number of cache lookups that found a live entry.",
+		Type: CounterType}
+	misses := Family{Name: NAMESPACE + "_cache_misses_total",
+		Help: "Number of cache lookups that found no entry or an expired one.", Type: CounterType}
+	evictions := Family{Name: NAMESPACE + "_cache_evictions_total",
+		Help: "Number of cache entries removed because they expired.", Type: CounterType}
+	entries := Family{Name: NAMESPACE + "_cache_entries",
+		Help: "Number of cache entries, including expired entries not yet swept.", Type: GaugeType}
+
+	for _, stats := range cache.AllStats() {
+		labels := map[string]string{"cache": stats.Name}
+		hits.Samples = append(hits.Samples, Sample{Labels: labels, Value: float64(stats.Hits)})
+		misses.Samples = append(misses.Samples, Sample{Labels: labels, Value: float64(stats.Misses)})
+		evictions.Samples = append(evictions.Samples, Sample{Labels: labels, Value: float64(stats.Evictions)})
+		entries.Samples = append(entries.Samples, Sample{Labels: labels, Value: float64(stats.Size)})
+	}
+
+	return []Family{hits, misses, evictions, entries}
+}
@@ -0,0 +1,23 @@
+// This holds the instruments of the calls made to the Git provider, so throttling and slow calls can be alerted on
+package metrics
+
+// Outcomes of the calls made to the Git provider
+const (
+	SUCCESS_OUTCOME      string = "success"
+	ERROR_OUTCOME        string = "error"
+	RATE_LIMITED_OUTCOME string = "rate_limited"
+)
+
+// GitCalls counts the calls made to the Git provider, labelled by provider, Git method and outcome
+var GitCalls = NewCounter(NAMESPACE+"_git_calls_total",
+	"Number of calls made to the Git provider, by provider, method and outcome.", "provider", "method", "outcome")
+
+// GitCallDuration distributes the time taken by the calls made to the Git provider, labelled by provider and Git method
+var GitCallDuration = NewHistogram(NAMESPACE+"_git_call_duration_seconds",
+	"Time taken by calls made to the Git provider, by provider and method.", DurationBuckets, "provider", "method")
+
+// MergeabilityPollDuration distributes the time taken to determine the mergeability of pull requests, which polls the
+// Git provider until status checks complete, labelled by provider and outcome
+var MergeabilityPollDuration = NewHistogram(NAMESPACE+"_mergeability_poll_duration_seconds",
+	"Time taken to determine the mergeability of pull requests, by provider and outcome.", LongDurationBuckets,
+	"provider", "outcome")
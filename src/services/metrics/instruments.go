@@ -0,0 +1,178 @@
+// This holds the instruments whose values are recorded as events happen, as opposed to collectors that read the state
+// of another package on every scrape
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Buckets, in seconds, of the histograms instrumenting Harmonia
+var (
+	// DurationBuckets suit operations expected to take at most a few seconds, e.g. requests and Git API calls
+	DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	// LongDurationBuckets suit operations that poll or wait on other systems, e.g. loads and mergeability checks
+	LongDurationBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+)
+
+// series identifies the values of an instrument recorded for one combination of label values
+type series struct {
+	key    string
+	labels map[string]string
+}
+
+// newSeries pairs the given label names with the given values, which must be as many as there are names
+func newSeries(names []string, values []string) series {
+	if len(values) != len(names) {
+		panic(fmt.Sprintf("expected %d label values (%s), got %d", len(names), strings.Join(names, ", "), len(values)))
+	}
+
+	labels := make(map[string]string, len(names))
+	for i, name := range names {
+		labels[name] = values[i]
+	}
+	return series{key: strings.Join(values, "\xff"), labels: labels}
+}
+
+// withLabel returns a copy of the given labels along with the given label
+func withLabel(labels map[string]string, name string, value string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for labelName, labelValue := range labels {
+		copied[labelName] = labelValue
+	}
+	copied[name] = value
+	return copied
+}
+
+// sortedKeys returns the keys of the given series values, sorted so collected samples are in a stable order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Counter is a labelled counter, each combination of label values is counted separately
+type Counter struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+// counterSeries holds the count of one combination of label values
+type counterSeries struct {
+	series
+	value float64
+}
+
+// NewCounter returns a counter with the given name, help text and label names
+func NewCounter(name string, help string, labelNames ...string) *Counter {
+	return &Counter{name: name, help: help, labelNames: labelNames, series: map[string]*counterSeries{}}
+}
+
+// Inc increments the counter of the given label values, given in the order of the counter's label names
+func (c *Counter) Inc(labelValues ...string) {
+	s := newSeries(c.labelNames, labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counted, ok := c.series[s.key]
+	if !ok {
+		counted = &counterSeries{series: s}
+		c.series[s.key] = counted
+	}
+	counted.value++
+}
+
+// Collect returns the family of the counter, with a sample per combination of label values counted so far
+func (c *Counter) Collect() []Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family := Family{Name: c.name, Help: c.help, Type: CounterType}
+	for _, key := range sortedKeys(c.series) {
+		counted := c.series[key]
+		family.Samples = append(family.Samples, Sample{Labels: counted.labels, Value: counted.value})
+	}
+	return []Family{family}
+}
+
+// Histogram is a labelled histogram of observed values, each combination of label values is distributed separately
+// into the same buckets
+type Histogram struct {
+	name       string
+	help       string
+	buckets    []float64
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// histogramSeries holds the distribution of one combination of label values, the count of each bucket includes the
+// values of the buckets before it
+type histogramSeries struct {
+	series
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewHistogram returns a histogram with the given name, help text, upper bounds of its buckets and label names
+func NewHistogram(name string, help string, buckets []float64, labelNames ...string) *Histogram {
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{name: name, help: help, buckets: sorted, labelNames: labelNames,
+		series: map[string]*histogramSeries{}}
+}
+
+// Observe records the given value for the given label values, given in the order of the histogram's label names
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	s := newSeries(h.labelNames, labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	observed, ok := h.series[s.key]
+	if !ok {
+		observed = &histogramSeries{series: s, buckets: make([]uint64, len(h.buckets))}
+		h.series[s.key] = observed
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			observed.buckets[i]++
+		}
+	}
+	observed.count++
+	observed.sum += value
+}
+
+// Collect returns the family of the histogram, with the buckets, sum and count of every combination of label values
+// observed so far
+func (h *Histogram) Collect() []Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	family := Family{Name: h.name, Help: h.help, Type: HistogramType}
+	for _, key := range sortedKeys(h.series) {
+		observed := h.series[key]
+		for i, bound := range h.buckets {
+			family.Samples = append(family.Samples, Sample{Suffix: "_bucket",
+				Labels: withLabel(observed.labels, "le", strconv.FormatFloat(bound, 'g', -1, 64)),
+				Value:  float64(observed.buckets[i])})
+		}
+		family.Samples = append(family.Samples,
+			Sample{Suffix: "_bucket", Labels: withLabel(observed.labels, "le", "+Inf"), Value: float64(observed.count)},
+			Sample{Suffix: "_sum", Labels: observed.labels, Value: observed.sum},
+			Sample{Suffix: "_count", Labels: observed.labels, Value: float64(observed.count)},
+		)
+	}
+	return []Family{family}
+}
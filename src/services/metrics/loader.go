@@ -0,0 +1,38 @@
+// This holds the collector exposing how shadow loads compare to the loads they shadowed, and the instruments of loads
+package metrics
+
+import (
+	"harmonia-example.io/src/services/loader"
+)
+
+// ShadowLoadCollector exposes the number of shadow loads of every load target in shadow mode, labelled by target and by
+// whether the shadow loader's outcome matched the outcome of the target's loader
+func ShadowLoadCollector() []Family {
+	loads := Family{Name: NAMESPACE + "_shadow_loads_total",
+		Help: "Number of shadow loads, by whether their outcome matched the load they shadowed.", Type: CounterType}
+
+	for _, stats := range loader.Default.ShadowStats() {
+		loads.Samples = append(loads.Samples,
+			Sample{Labels: map[string]string{"target": stats.Target, "outcome": "match"}, Value: float64(stats.Matches)},
+			Sample{Labels: map[string]string{"target": stats.Target, "outcome": "divergence"},
+				Value: float64(stats.Divergences)},
+		)
+	}
+
+	return []Family{loads}
+}
+
+// Loads counts the loads of RFCs into load targets, labelled by target and by whether the load succeeded or failed
+var Loads = NewCounter(NAMESPACE+"_loads_total", "Number of RFC loads into load targets, by target and outcome.",
+	"target", "outcome")
+
+// LoadDuration distributes the time taken to load RFCs into all of their load targets, labelled by the resulting load
+// status
+var LoadDuration = NewHistogram(NAMESPACE+"_load_duration_seconds",
+	"Time taken to load RFCs into all of their load targets, by resulting load status.", LongDurationBuckets, "status")
+
+// ActionLoadDuration distributes the time taken to apply the actions of RFCs into load targets, labelled by target and
+// action type, as reported by the loaders of the targets
+var ActionLoadDuration = NewHistogram(NAMESPACE+"_action_load_duration_seconds",
+	"Time taken to apply RFC actions into load targets, by target and action type.", LongDurationBuckets, "target",
+	"action_type")
@@ -0,0 +1,131 @@
+// Package metrics holds a minimal registry of metrics exposed in the Prometheus text exposition format, so operators can
+// scrape Harmonia without it depending on a metrics client library
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Common constants used when exposing metrics
+const (
+	// CONTENT_TYPE is the content type of the Prometheus text exposition format written by Registry.Write
+	CONTENT_TYPE string = "text/plain; version=0.0.4; charset=utf-8"
+	// NAMESPACE prefixes the name of every Harmonia metric
+	NAMESPACE string = "harmonia"
+)
+
+// Type represents the Prometheus type of a metric family
+type Type string
+
+var CounterType Type = "counter"
+var GaugeType Type = "gauge"
+var HistogramType Type = "histogram"
+
+// Sample is a single labelled value of a metric family
+type Sample struct {
+	// Suffix is appended to the name of the family, e.g. "_bucket" for the buckets of a histogram
+	Suffix string
+	Labels map[string]string
+	Value  float64
+}
+
+// Family is a named group of samples sharing a type and help text
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Samples []Sample
+}
+
+// Collector returns the current value of a set of metric families, it is called on every scrape
+type Collector func() []Family
+
+// Registry holds the collectors whose metrics are exposed together
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default is the registry shared by the application, it exposes nothing until collectors are registered
+var Default = NewRegistry()
+
+// Register adds the given collector to the registry
+func (r *Registry) Register(collector Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collector)
+}
+
+// Gather calls every collector and returns the collected families sorted by name
+func (r *Registry) Gather() []Family {
+	r.mu.RLock()
+	collectors := append([]Collector{}, r.collectors...)
+	r.mu.RUnlock()
+
+	var families []Family
+	for _, collect := range collectors {
+		families = append(families, collect()...)
+	}
+	sort.SliceStable(families, func(i, j int) bool { return families[i].Name < families[j].Name })
+
+	return families
+}
+
+// Write writes every collected family to the given writer in the Prometheus text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	var b strings.Builder
+	for _, family := range r.Gather() {
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.Name, escape(family.Help, false))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", family.Name, family.Type)
+		for _, sample := range family.Samples {
+			b.WriteString(family.Name + sample.Suffix)
+			writeLabels(&b, sample.Labels)
+			fmt.Fprintf(&b, " %s\n", strconv.FormatFloat(sample.Value, 'g', -1, 64))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeLabels writes the given labels in braces, sorted by name, nothing is written if there are none
+func writeLabels(b *strings.Builder, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteString("{")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(b, `%s="%s"`, name, escape(labels[name], true))
+	}
+	b.WriteString("}")
+}
+
+// escape escapes the given help text or, if quoted, label value as required by the text exposition format
+func escape(value string, quoted bool) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	if quoted {
+		value = strings.ReplaceAll(value, `"`, `\"`)
+	}
+	return value
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/services/cache"
+)
+
+func TestWrite(t *testing.T) {
+	// arrange
+	r := NewRegistry()
+	r.Register(func() []Family {
+		return []Family{
+			{Name: "b_total", Help: "second", Type: CounterType, Samples: []Sample{{Value: 3}}},
+			{Name: "a", Help: "first\nline", Type: GaugeType, Samples: []Sample{
+				{Labels: map[string]string{"z": "1", "y": `quote"d`}, Value: 0.5},
+			}},
+		}
+	})
+
+	// act
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// assert
+	expected := `# HELP a first\nline
+# TYPE a gauge
+a{y="quote\"d",z="1"} 0.5
+# HELP b_total second
+# TYPE b_total counter
+b_total 3
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output. wanted:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestCacheCollector(t *testing.T) {
+	// arrange
+	c := cache.NewNamed[string, int]("metrics_test", time.Minute)
+	c.Set("a", 1)
+	c.Get("a")
+	c.Get("b")
+	r := NewRegistry()
+	r.Register(CacheCollector)
+
+	// act
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	// assert
+	for _, line := range []string{
+		`harmonia_cache_hits_total{cache="metrics_test"} 1`,
+		`harmonia_cache_misses_total{cache="metrics_test"} 1`,
+		`harmonia_cache_evictions_total{cache="metrics_test"} 0`,
+		`harmonia_cache_entries{cache="metrics_test"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %s in output:\n%s", line, b.String())
+		}
+	}
+}
+
+func TestCounter(t *testing.T) {
+	// arrange
+	c := NewCounter("calls_total", "calls", "method", "outcome")
+	r := NewRegistry()
+	r.Register(c.Collect)
+
+	// act
+	c.Inc("get", "success")
+	c.Inc("get", "success")
+	c.Inc("get", "error")
+
+	// assert
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := `# HELP calls_total calls
+# TYPE calls_total counter
+calls_total{method="get",outcome="error"} 1
+calls_total{method="get",outcome="success"} 2
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output. wanted:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	// arrange
+	h := NewHistogram("duration_seconds", "durations", []float64{1, 0.5}, "route")
+	r := NewRegistry()
+	r.Register(h.Collect)
+
+	// act
+	h.Observe(0.25, "/a")
+	h.Observe(0.75, "/a")
+	h.Observe(3, "/a")
+
+	// assert
+	var b strings.Builder
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := `# HELP duration_seconds durations
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.5",route="/a"} 1
+duration_seconds_bucket{le="1",route="/a"} 2
+duration_seconds_bucket{le="+Inf",route="/a"} 3
+duration_seconds_sum{route="/a"} 4
+duration_seconds_count{route="/a"} 3
+`
+	if b.String() != expected {
+		t.Errorf("unexpected output. wanted:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
@@ -0,0 +1,10 @@
+// This holds the instruments of the requests and gRPC calls served by Harmonia
+package metrics
+
+// RequestDuration distributes the time taken to serve each request, labelled by method, route and response status
+var RequestDuration = NewHistogram(NAMESPACE+"_http_request_duration_seconds",
+	"Time taken to serve requests, by method, route and response status.", DurationBuckets, "method", "route", "status")
+
+// RPCDuration distributes the time taken to serve each gRPC call, labelled by method and status code
+var RPCDuration = NewHistogram(NAMESPACE+"_grpc_request_duration_seconds",
+	"Time taken to serve gRPC calls, by method and status code.", DurationBuckets, "method", "code")
@@ -0,0 +1,193 @@
+// Package moderation holds the filters comments are checked by before they are added to an RFC, and the comments held
+// for moderation by them. Filters are pluggable: anything implementing Filter can be added to a Moderator
+package moderation
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Actions taken on comments a filter objects to
+const (
+	// the review holding the comment is rejected
+	REJECT_ACTION string = "reject"
+	// the comment is added to the RFC along with the reasons it was flagged for
+	FLAG_ACTION string = "flag"
+	// the comment is held until a moderator approves or discards it
+	HOLD_ACTION string = "hold"
+
+	// the window comments are counted over by a rate limit unless configured
+	DEFAULT_RATE_WINDOW time.Duration = time.Hour
+)
+
+// Filter checks a comment before it is added to an RFC
+type Filter interface {
+	// Check returns why the given comment of the given commenter is objectionable, nil if it is not
+	Check(commenter string, comment string, now time.Time) *string
+}
+
+// FilterFunc type is an adapter to allow the use of ordinary functions as filters
+type FilterFunc func(commenter string, comment string, now time.Time) *string
+
+// Check calls f(commenter, comment, now)
+func (f FilterFunc) Check(commenter string, comment string, now time.Time) *string {
+	return f(commenter, comment, now)
+}
+
+// Moderator checks comments with its filters and takes its action on those any filter objects to
+type Moderator struct {
+	Action  string
+	filters []Filter
+	now     func() time.Time
+
+	mu   sync.Mutex
+	held map[string]*models.HeldComment
+}
+
+// Default is the moderator of the application, nil if comments are not filtered
+var Default *Moderator
+
+// NewModerator returns a Moderator taking the given action, one of the *_ACTION constants, on the comments any of the
+// given filters objects to
+func NewModerator(action string, filters ...Filter) (*Moderator, error) {
+	if action != REJECT_ACTION && action != FLAG_ACTION && action != HOLD_ACTION {
+		return nil, fmt.Errorf("unknown comment filter action %s, expected one of %s, %s or %s", action, REJECT_ACTION,
+			FLAG_ACTION, HOLD_ACTION)
+	}
+
+	return &Moderator{Action: action, filters: filters, now: time.Now, held: map[string]*models.HeldComment{}}, nil
+}
+
+// AddFilter adds the given filter to those the moderator checks comments with
+func (m *Moderator) AddFilter(filter Filter) {
+	m.filters = append(m.filters, filter)
+}
+
+// Check returns the reasons the filters object to the given comment of the given commenter for, if any
+// Every filter sees every comment, so that a rate limit counts the comments other filters object to as well
+func (m *Moderator) Check(commenter string, comment string) []string {
+	now := m.now()
+	var reasons []string
+	for _, filter := range m.filters {
+		if reason := filter.Check(commenter, comment, now); reason != nil {
+			reasons = append(reasons, *reason)
+		}
+	}
+
+	return reasons
+}
+
+// Hold holds the given comment for moderation and returns its ID
+func (m *Moderator) Hold(comment models.HeldComment) (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	comment.ID = hex.EncodeToString(id)
+	comment.HeldAt = m.now().UTC()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.held[comment.ID] = &comment
+	return comment.ID, nil
+}
+
+// Held returns the comments held for moderation, oldest first
+func (m *Moderator) Held() []models.HeldComment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	held := make([]models.HeldComment, 0, len(m.held))
+	for _, comment := range m.held {
+		held = append(held, *comment)
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i].HeldAt.Before(held[j].HeldAt) })
+	return held
+}
+
+// Get returns the held comment with the given ID, models.ErrHeldCommentNotFound is returned (wrapped) if no comment is
+// held with it
+func (m *Moderator) Get(id string) (*models.HeldComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment, ok := m.held[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrHeldCommentNotFound, id)
+	}
+	held := *comment
+	return &held, nil
+}
+
+// Release stops holding the comment with the given ID, once it is approved or discarded
+func (m *Moderator) Release(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.held, id)
+}
+
+// NewWordList returns a filter objecting to comments holding any of the given words, regardless of case
+func NewWordList(words []string) Filter {
+	blocked := map[string]bool{}
+	for _, word := range words {
+		blocked[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+
+	return FilterFunc(func(commenter string, comment string, now time.Time) *string {
+		for _, word := range strings.FieldsFunc(strings.ToLower(comment), isSeparator) {
+			if blocked[word] {
+				reason := fmt.Sprintf("comment holds blocked word %s", word)
+				return &reason
+			}
+		}
+		return nil
+	})
+}
+
+// isSeparator returns true if the given rune separates words
+func isSeparator(r rune) bool {
+	return !(r == '-' || r == '_' || r == '\'' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r > 127)
+}
+
+// NewSizeCap returns a filter objecting to comments longer than the given number of characters
+func NewSizeCap(max int) Filter {
+	return FilterFunc(func(commenter string, comment string, now time.Time) *string {
+		if len([]rune(comment)) > max {
+			reason := fmt.Sprintf("comment exceeds %d characters", max)
+			return &reason
+		}
+		return nil
+	})
+}
+
+// NewRateLimit returns a filter objecting to the comments of a commenter past the given number of comments within
+// the given window. Comments are counted by the instance that checks them
+func NewRateLimit(limit int, window time.Duration) Filter {
+	var mu sync.Mutex
+	comments := map[string][]time.Time{}
+
+	return FilterFunc(func(commenter string, comment string, now time.Time) *string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		recent := []time.Time{}
+		for _, at := range comments[commenter] {
+			if now.Sub(at) < window {
+				recent = append(recent, at)
+			}
+		}
+		comments[commenter] = append(recent, now)
+		if len(recent) >= limit {
+			reason := fmt.Sprintf("more than %d comments within %s", limit, window)
+			return &reason
+		}
+		return nil
+	})
+}
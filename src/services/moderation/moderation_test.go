@@ -0,0 +1,69 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestCheck(t *testing.T) {
+	// arrange
+	now := time.Unix(1654074000, 0)
+	moderator, err := NewModerator(FLAG_ACTION, NewWordList([]string{"Spam"}), NewSizeCap(20),
+		NewRateLimit(2, time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	moderator.now = func() time.Time { return now }
+	testCases := []struct {
+		name      string
+		commenter string
+		comment   string
+		expected  int
+	}{
+		{name: "acceptable comment", commenter: "tstark", comment: "looks good", expected: 0},
+		{name: "blocked word", commenter: "tstark", comment: "buy SPAM now", expected: 1},
+		{name: "blocked word within a word", commenter: "pparker", comment: "spammer", expected: 0},
+		{name: "too long and too many", commenter: "tstark", comment: strings.Repeat("a", 21), expected: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			reasons := moderator.Check(tc.commenter, tc.comment)
+
+			// assert
+			if len(reasons) != tc.expected {
+				t.Errorf("expected %d reasons, got %v", tc.expected, reasons)
+			}
+		})
+	}
+	if _, err = NewModerator("shout"); err == nil {
+		t.Errorf("expected an unknown action to be rejected")
+	}
+}
+
+func TestHold(t *testing.T) {
+	// arrange
+	moderator, _ := NewModerator(HOLD_ACTION)
+
+	// act
+	id, err := moderator.Hold(models.HeldComment{RFCIdentifier: "123456", Commenter: "tstark", Comment: "spam"})
+	held := moderator.Held()
+	moderator.Release(id)
+	_, releasedErr := moderator.Get(id)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(held) != 1 || held[0].ID != id || held[0].HeldAt.IsZero() {
+		t.Errorf("expected the comment to be held, got %+v", held)
+	}
+	if !errors.Is(releasedErr, models.ErrHeldCommentNotFound) {
+		t.Errorf("expected a released comment not to be held anymore, got %v", releasedErr)
+	}
+}
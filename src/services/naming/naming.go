@@ -0,0 +1,167 @@
+// Package naming builds the titles of the pull requests of RFCs from their metadata, so pull requests can be told apart
+// by more than their opaque identifier, e.g. "RFC-1234: Add EventType X (team-data)"
+package naming
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+)
+
+// DEFAULT_TEMPLATE is the template pull requests are named with unless a deployment configures its own, it keeps the
+// titles of the pull requests opened before titles could be configured
+const DEFAULT_TEMPLATE = `RFC: {{.Identifier}}`
+
+// Subject is the metadata of an RFC its pull request title is built from
+type Subject struct {
+	Identifier string
+	// Title is the title of the RFC, a summary of its actions if it has none
+	Title    string
+	Domain   string
+	Priority models.Priority
+	// Teams are the teams owning the targets of the RFC, sorted
+	Teams []string
+	// Team is the first of the teams, empty if the RFC has no owner
+	Team string
+}
+
+// NewSubject returns the subject of the RFC of the given identifier and owning teams
+func NewSubject(identifier string, rfc *models.RFC, teams []string) Subject {
+	subject := Subject{
+		Identifier: identifier,
+		Title:      strings.TrimSpace(rfc.Title),
+		Domain:     rfc.Domain,
+		Priority:   rfc.Priority,
+		Teams:      teams,
+	}
+	if subject.Title == "" {
+		subject.Title = Summary(rfc)
+	}
+	if len(teams) > 0 {
+		subject.Team = teams[0]
+	}
+
+	return subject
+}
+
+// Summary returns a title summarizing the actions of the given RFC, e.g. "Add item Event (+2 more)"
+func Summary(rfc *models.RFC) string {
+	if len(rfc.Actions) == 0 {
+		return ""
+	}
+
+	first := rfc.Actions[0]
+	words := []string{}
+	for _, word := range []string{string(first.ActionType), string(first.Target.TargetType),
+		first.Target.TargetDescriptor} {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	summary := strings.Join(words, " ")
+	if summary != "" {
+		summary = strings.ToUpper(summary[:1]) + summary[1:]
+	}
+	if len(rfc.Actions) > 1 {
+		summary = fmt.Sprintf("%s (+%d more)", summary, len(rfc.Actions)-1)
+	}
+
+	return summary
+}
+
+// Strategy builds the title of the pull request of an RFC from its subject
+type Strategy interface {
+	Title(subject Subject) (string, error)
+}
+
+// Template type implements the Strategy interface by rendering a Go template of the subject
+// Whitespace is collapsed so optional parts of the template can be left out without leaving gaps
+type Template struct {
+	template *template.Template
+}
+
+// NewTemplate returns the Template strategy of the given Go template text
+func NewTemplate(text string) (*Template, error) {
+	parsed, err := template.New("title").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("malformed pull request title template: %w", err)
+	}
+
+	return &Template{template: parsed}, nil
+}
+
+// Title renders the template of the given subject, an error is returned if it renders an empty title
+func (t *Template) Title(subject Subject) (string, error) {
+	var rendered bytes.Buffer
+	if err := t.template.Execute(&rendered, subject); err != nil {
+		return "", err
+	}
+
+	title := strings.Join(strings.Fields(rendered.String()), " ")
+	if title == "" {
+		return "", fmt.Errorf("pull request title template rendered an empty title for RFC %s", subject.Identifier)
+	}
+	return title, nil
+}
+
+// Tenants type implements the Strategy interface by naming the pull requests of each schema domain with a strategy of
+// its own, falling back to a default strategy for the domains without one
+type Tenants struct {
+	fallback Strategy
+	tenants  map[string]Strategy
+}
+
+// NewTenants returns a Tenants strategy naming the pull requests of the given schema domains with the given strategies
+// and those of any other domain with the given fallback
+func NewTenants(fallback Strategy, tenants map[string]Strategy) *Tenants {
+	return &Tenants{fallback: fallback, tenants: tenants}
+}
+
+// Title builds the title of the given subject with the strategy of its schema domain
+func (t *Tenants) Title(subject Subject) (string, error) {
+	if strategy, ok := t.tenants[subject.Domain]; ok {
+		return strategy.Title(subject)
+	}
+	return t.fallback.Title(subject)
+}
+
+// LoadTenants returns a Tenants strategy with the templates of the given JSON file, an object of templates keyed by
+// schema domain, falling back to the given strategy
+func LoadTenants(file string, fallback Strategy) (*Tenants, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read pull request title templates file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var texts map[string]string
+	if err = json.Unmarshal(content, &texts); err != nil {
+		return nil, fmt.Errorf("malformed pull request title templates file %s: %w", file, err)
+	}
+	tenants := map[string]Strategy{}
+	for domain, text := range texts {
+		if tenants[domain], err = NewTemplate(text); err != nil {
+			return nil, fmt.Errorf("template of domain %s: %w", domain, err)
+		}
+	}
+
+	return NewTenants(fallback, tenants), nil
+}
+
+// Default is the strategy the pull requests of RFCs are named with
+var Default Strategy = mustTemplate(DEFAULT_TEMPLATE)
+
+// mustTemplate returns the Template strategy of the given built-in template text
+func mustTemplate(text string) *Template {
+	t, err := NewTemplate(text)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
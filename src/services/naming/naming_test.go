@@ -0,0 +1,50 @@
+package naming
+
+import (
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestTitle(t *testing.T) {
+	// arrange
+	rfc := &models.RFC{
+		Domain: "catalog",
+		Actions: models.Actions{
+			{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget,
+				TargetDescriptor: "EventType"}},
+			{ActionType: models.UpdateAction, Target: models.Target{TargetType: models.ItemTarget,
+				TargetDescriptor: "Event"}},
+		},
+	}
+	titled := &models.RFC{Title: "Add EventType X", Domain: "events"}
+	custom, err := NewTemplate(`RFC-{{.Identifier}}: {{.Title}} {{with .Team}}({{.}}){{end}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	strategy := NewTenants(Default, map[string]Strategy{"events": custom})
+
+	testCases := []struct {
+		name     string
+		subject  Subject
+		expected string
+	}{
+		{"default", NewSubject("1234", rfc, []string{"team-data"}), "RFC: 1234"},
+		{"tenant", NewSubject("1234", titled, []string{"team-data", "team-search"}),
+			"RFC-1234: Add EventType X (team-data)"},
+		{"unowned", NewSubject("1234", titled, nil), "RFC-1234: Add EventType X"},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		if actual, err := strategy.Title(test.subject); err != nil || actual != test.expected {
+			t.Errorf("%s: expected %q, got %q, err: %v", test.name, test.expected, actual, err)
+		}
+	}
+	if summary := NewSubject("1234", rfc, nil).Title; summary != "Add item EventType (+1 more)" {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+	if _, err = NewTemplate(`{{.Identifier`); err == nil {
+		t.Errorf("expected a malformed template to be rejected")
+	}
+}
@@ -0,0 +1,184 @@
+// This holds the built-in implementations of the Channel interface found in definition.go
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/directory"
+	"harmonia-example.io/src/services/logging"
+)
+
+// how long a webhook delivery may take before it is abandoned
+const webhookTimeout = 10 * time.Second
+
+// Delivery attempts of webhook notifications unless configured
+const (
+	// the number of deliveries made before a notification is given up on
+	DEFAULT_WEBHOOK_ATTEMPTS int = 3
+	// the wait before the second delivery, doubled before every delivery after it
+	DEFAULT_WEBHOOK_BACKOFF time.Duration = time.Second
+)
+
+// LogChannel type implements the Channel interface by printing notifications, which is useful when running locally
+type LogChannel struct{}
+
+// Name returns the name of the log channel
+func (l *LogChannel) Name() string {
+	return LOG_CHANNEL
+}
+
+// Send logs the given notification, urgent notifications are marked as such
+func (l *LogChannel) Send(ctx context.Context, notification Notification) error {
+	if notification.Urgent {
+		logging.FromContext(ctx).Warn("urgent notification", "body", notification.Body)
+		return nil
+	}
+	logging.FromContext(ctx).Info("notification", "body", notification.Body)
+	return nil
+}
+
+// WebhookChannel type implements the Channel interface by posting notifications as JSON to a URL
+// The payload carries the rendered text under "text", so it is accepted as-is by Slack and Teams incoming webhooks
+// Deliveries failing with a network error, a 429 or a 5xx are retried, and notifications are kept in DeadLetters, if
+// set, once given up on
+type WebhookChannel struct {
+	URL         string
+	Attempts    int
+	Backoff     time.Duration
+	DeadLetters DeadLetterStore
+	client      *http.Client
+}
+
+// webhookPayload is the JSON body posted by a WebhookChannel
+type webhookPayload struct {
+	Text      string          `json:"text"`
+	Recipient string          `json:"recipient,omitempty"`
+	Urgent    bool            `json:"urgent,omitempty"`
+	Event     models.Event    `json:"event"`
+	Actor     *directory.User `json:"actor,omitempty"`
+}
+
+// NewWebhookChannel returns a WebhookChannel that posts to the given URL
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{URL: url, Attempts: DEFAULT_WEBHOOK_ATTEMPTS, Backoff: DEFAULT_WEBHOOK_BACKOFF,
+		client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Name returns the name of the webhook channel
+func (w *WebhookChannel) Name() string {
+	return WEBHOOK_CHANNEL
+}
+
+// Send posts the given notification to the webhook URL, retrying failed deliveries with backoff. A notification that
+// could not be delivered is kept as a dead letter, if a dead letter store is set
+func (w *WebhookChannel) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		Text:      notification.Body,
+		Recipient: notification.Recipient,
+		Urgent:    notification.Urgent,
+		Event:     notification.Event,
+		Actor:     notification.Actor,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("json notification marshal error", logging.ERROR_KEY, err)
+		return err
+	}
+
+	attempts := 0
+	backoff := w.Backoff
+	for {
+		attempts++
+		retryable, deliveryErr := w.post(ctx, body)
+		if deliveryErr == nil {
+			return nil
+		}
+		if !retryable || attempts >= w.Attempts {
+			w.deadLetter(ctx, body, notification.Event, attempts, deliveryErr)
+			return deliveryErr
+		}
+
+		select {
+		case <-ctx.Done():
+			w.deadLetter(ctx, body, notification.Event, attempts, deliveryErr)
+			return deliveryErr
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// Redeliver posts the payload of the given dead letter to the webhook URL once, the dead letter is dropped if it is
+// delivered and records the attempt otherwise
+func (w *WebhookChannel) Redeliver(ctx context.Context, letter models.DeadLetter) error {
+	_, err := w.post(ctx, letter.Payload)
+	if w.DeadLetters == nil {
+		return err
+	}
+	if err == nil {
+		return w.DeadLetters.Delete(ctx, letter.ID)
+	}
+
+	letter.Attempts++
+	letter.LastError = err.Error()
+	letter.FailedAt = time.Now().UTC()
+	if saveErr := w.DeadLetters.Save(ctx, letter); saveErr != nil {
+		logging.FromContext(ctx).Error("unable to record redelivery of dead letter", "deadLetter", letter.ID,
+			logging.ERROR_KEY, saveErr)
+	}
+	return err
+}
+
+// post posts the given body to the webhook URL, whether a failed delivery is worth retrying is returned along with
+// the error
+func (w *WebhookChannel) post(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		logging.FromContext(ctx).Warn("webhook notification delivery error", logging.ERROR_KEY, err)
+		return true, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		retryable := response.StatusCode == http.StatusTooManyRequests ||
+			response.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("webhook notification rejected with status %d", response.StatusCode)
+	}
+
+	return false, nil
+}
+
+// deadLetter keeps the given payload of the given event as a dead letter, if a dead letter store is set. This is best
+// effort, failures are logged rather than returned
+func (w *WebhookChannel) deadLetter(ctx context.Context, body []byte, event models.Event, attempts int, err error) {
+	if w.DeadLetters == nil {
+		return
+	}
+
+	id, idErr := newDeadLetterID()
+	if idErr == nil {
+		idErr = w.DeadLetters.Save(context.WithoutCancel(ctx), models.DeadLetter{
+			ID:        id,
+			Channel:   w.Name(),
+			Payload:   body,
+			Event:     event,
+			Attempts:  attempts,
+			LastError: err.Error(),
+			FailedAt:  time.Now().UTC(),
+		})
+	}
+	if idErr != nil {
+		logging.FromContext(ctx).Error("unable to keep undelivered notification as a dead letter", "eventType", event.Type,
+			logging.RFC_IDENTIFIER_KEY, event.RFCIdentifier, logging.ERROR_KEY, idErr)
+	}
+}
@@ -0,0 +1,193 @@
+// This holds the stores dead letters are kept in, the notifications whose delivery was given up on, so they can be
+// inspected and delivered again once their consumer recovers
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// DeadLetterStore defines all methods necessary for keeping dead letters
+type DeadLetterStore interface {
+	// Save records the given dead letter, replacing any previously recorded with the same ID
+	Save(ctx context.Context, letter models.DeadLetter) error
+	// List returns every dead letter, oldest first
+	List(ctx context.Context) ([]models.DeadLetter, error)
+	// Get returns the dead letter with the given ID, models.ErrDeadLetterNotFound is returned (wrapped) if there is none
+	Get(ctx context.Context, id string) (*models.DeadLetter, error)
+	// Delete drops the dead letter with the given ID, once it was redelivered or discarded
+	Delete(ctx context.Context, id string) error
+}
+
+// Redeliverer is implemented by the channels dead letters can be delivered again on
+type Redeliverer interface {
+	// Redeliver delivers the payload of the given dead letter again, once
+	Redeliver(ctx context.Context, letter models.DeadLetter) error
+}
+
+// newDeadLetterID returns a random dead letter ID
+func newDeadLetterID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// MemoryDeadLetters type implements the DeadLetterStore interface by keeping dead letters in memory, they are lost
+// when the service stops
+type MemoryDeadLetters struct {
+	mu      sync.RWMutex
+	letters map[string]models.DeadLetter
+}
+
+// NewMemoryDeadLetters returns an empty MemoryDeadLetters
+func NewMemoryDeadLetters() *MemoryDeadLetters {
+	return &MemoryDeadLetters{letters: map[string]models.DeadLetter{}}
+}
+
+// Save records the given dead letter, replacing any previously recorded with the same ID
+func (s *MemoryDeadLetters) Save(ctx context.Context, letter models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+// List returns every dead letter, oldest first
+func (s *MemoryDeadLetters) List(ctx context.Context) ([]models.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]models.DeadLetter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i].FailedAt.Before(letters[j].FailedAt) })
+	return letters, nil
+}
+
+// Get returns the dead letter with the given ID, models.ErrDeadLetterNotFound is returned (wrapped) if there is none
+func (s *MemoryDeadLetters) Get(ctx context.Context, id string) (*models.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrDeadLetterNotFound, id)
+	}
+	return &letter, nil
+}
+
+// Delete drops the dead letter with the given ID
+func (s *MemoryDeadLetters) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.letters, id)
+	return nil
+}
+
+// FileDeadLetters type implements the DeadLetterStore interface by keeping dead letters in memory and writing them
+// all to a JSON file on every change, so they survive restarts of a single instance
+type FileDeadLetters struct {
+	*MemoryDeadLetters
+	path string
+}
+
+// NewFileDeadLetters returns a FileDeadLetters writing to the given file, reading the dead letters it already holds if
+// it exists
+func NewFileDeadLetters(path string) (*FileDeadLetters, error) {
+	store := &FileDeadLetters{MemoryDeadLetters: NewMemoryDeadLetters(), path: path}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dead letter file %s: %w", path, err)
+	}
+	if err = json.Unmarshal(content, &store.letters); err != nil {
+		return nil, fmt.Errorf("malformed dead letter file %s: %w", path, err)
+	}
+	if store.letters == nil {
+		store.letters = map[string]models.DeadLetter{}
+	}
+
+	return store, nil
+}
+
+// Save records the given dead letter and writes every dead letter to the file
+func (s *FileDeadLetters) Save(ctx context.Context, letter models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.letters[letter.ID]
+	s.letters[letter.ID] = letter
+	if err := s.write(); err != nil {
+		// keep memory consistent with the file
+		if existed {
+			s.letters[letter.ID] = previous
+		} else {
+			delete(s.letters, letter.ID)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete drops the dead letter with the given ID and writes every remaining dead letter to the file
+func (s *FileDeadLetters) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.letters[id]
+	if !existed {
+		return nil
+	}
+	delete(s.letters, id)
+	if err := s.write(); err != nil {
+		s.letters[id] = previous
+		return err
+	}
+
+	return nil
+}
+
+// write writes every dead letter to a temporary file and moves it over the file, the caller must hold the lock
+func (s *FileDeadLetters) write() error {
+	content, err := json.Marshal(s.letters)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write dead letter file %s: %w", s.path, err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("unable to write dead letter file %s: %w", s.path, err)
+	}
+	if err = temp.Close(); err != nil {
+		return fmt.Errorf("unable to write dead letter file %s: %w", s.path, err)
+	}
+	if err = os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to write dead letter file %s: %w", s.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package notify holds the notification channels RFC lifecycle events are delivered on and the templates used to
+// render them
+// This is strictly to hold the Channel interface definition and common constants used in notification interactions
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/directory"
+)
+
+// Common constants used across all Channel implementations
+const (
+	LOG_CHANNEL             string = "log"
+	WEBHOOK_CHANNEL         string = "webhook"
+	TEMPLATE_FILE_EXTENSION string = ".tmpl"
+)
+
+// ErrUnknownChannel is returned (wrapped) when a notification is requested on a channel that is not configured
+var ErrUnknownChannel = errors.New("unknown notification channel")
+
+// Notification is a rendered message ready to be delivered on a channel
+type Notification struct {
+	Channel string
+	// Recipient is the team the notification is addressed to, empty for broadcasts
+	Recipient string
+	Body      string
+	Event     models.Event
+	// Urgent is set for notifications about RFCs of a high priority or above, so channels can make them stand out
+	Urgent bool
+	// Actor is the directory entry of the event actor, nil if no directory is configured or the actor is not in it
+	Actor *directory.User
+}
+
+// Channel defines all methods necessary for delivering notifications
+// All channel types (webhook, log...) should implement this interface
+type Channel interface {
+	// Name returns the name templates and routing refer to the channel by
+	Name() string
+	// Send delivers the given notification
+	Send(ctx context.Context, notification Notification) error
+}
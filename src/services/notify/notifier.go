@@ -0,0 +1,308 @@
+// This holds the Notifier, which renders RFC lifecycle events and delivers them on the configured channels
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/directory"
+	"harmonia-example.io/src/services/events"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/tenants"
+)
+
+// Notifier renders events with its templates and delivers them on its channels
+type Notifier struct {
+	mu          sync.RWMutex
+	templates   *Templates
+	channels    map[string]Channel
+	directory   directory.Provider
+	router      *Router
+	deadLetters DeadLetterStore
+	// isolation restricts the channels the events of each schema domain are delivered on, nil if it does not
+	isolation *tenants.Registry
+}
+
+// NewNotifier returns a Notifier that renders with the given templates and delivers on the given channels
+func NewNotifier(templates *Templates, channels ...Channel) *Notifier {
+	n := &Notifier{templates: templates, channels: map[string]Channel{}}
+	for _, channel := range channels {
+		n.channels[channel.Name()] = channel
+	}
+
+	return n
+}
+
+// Default is the notifier shared by the application, it has no channels until configured
+var Default = NewNotifier(NewTemplates())
+
+// SetDirectory resolves the actor of each notified event with the given directory, actors are not resolved if it is
+// nil
+func (n *Notifier) SetDirectory(provider directory.Provider) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.directory = provider
+}
+
+// SetRouter routes every notified event with the given router, every event is broadcast on every channel if it is nil
+// An error is returned if the rules of the router deliver on a channel that is not configured
+func (n *Notifier) SetRouter(router *Router) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if router != nil {
+		for _, channel := range router.Channels() {
+			if _, ok := n.channels[channel]; !ok {
+				return fmt.Errorf("%w: '%s' is routed to but not configured", ErrUnknownChannel, channel)
+			}
+		}
+	}
+	n.router = router
+
+	return nil
+}
+
+// SetTenants delivers the events and digests of each schema domain on the channels the given tenants allow it, see
+// tenants.Registry.Channels. Channels are not restricted if it is nil
+func (n *Notifier) SetTenants(registry *tenants.Registry) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.isolation = registry
+}
+
+// SetDeadLetters sets the store the channels keep the notifications they gave up on in, nil if they keep none
+func (n *Notifier) SetDeadLetters(store DeadLetterStore) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.deadLetters = store
+}
+
+// DeadLetters returns the notifications whose delivery was given up on, oldest first
+func (n *Notifier) DeadLetters(ctx context.Context) ([]models.DeadLetter, error) {
+	n.mu.RLock()
+	store := n.deadLetters
+	n.mu.RUnlock()
+	if store == nil {
+		return []models.DeadLetter{}, nil
+	}
+
+	return store.List(ctx)
+}
+
+// Redeliver delivers the dead letter with the given ID again on the channel it was given up on, it is dropped once
+// delivered. models.ErrDeadLetterNotFound is returned (wrapped) if there is no dead letter with the given ID
+func (n *Notifier) Redeliver(ctx context.Context, id string) error {
+	letter, err := n.deadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	n.mu.RLock()
+	channel, ok := n.channels[letter.Channel]
+	n.mu.RUnlock()
+	redeliverer, redeliverable := channel.(Redeliverer)
+	if !ok || !redeliverable {
+		return fmt.Errorf("%w: '%s' cannot redeliver dead letter %s", ErrUnknownChannel, letter.Channel, id)
+	}
+
+	return redeliverer.Redeliver(ctx, *letter)
+}
+
+// Discard drops the dead letter with the given ID without delivering it
+// models.ErrDeadLetterNotFound is returned (wrapped) if there is no dead letter with the given ID
+func (n *Notifier) Discard(ctx context.Context, id string) error {
+	if _, err := n.deadLetter(ctx, id); err != nil {
+		return err
+	}
+
+	n.mu.RLock()
+	store := n.deadLetters
+	n.mu.RUnlock()
+	return store.Delete(ctx, id)
+}
+
+// deadLetter returns the dead letter with the given ID
+func (n *Notifier) deadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	n.mu.RLock()
+	store := n.deadLetters
+	n.mu.RUnlock()
+	if store == nil {
+		return nil, fmt.Errorf("%w: %s", models.ErrDeadLetterNotFound, id)
+	}
+
+	return store.Get(ctx, id)
+}
+
+// Channels returns the sorted names of the configured channels
+func (n *Notifier) Channels() []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	names := make([]string, 0, len(n.channels))
+	for name := range n.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// domainChannels returns the sorted names of the configured channels the given schema domain may use, every configured
+// channel if no tenants are set
+func (n *Notifier) domainChannels(domain string) []string {
+	n.mu.RLock()
+	registry := n.isolation
+	n.mu.RUnlock()
+
+	channels := n.Channels()
+	if registry != nil {
+		return registry.Channels(domain, channels)
+	}
+	return channels
+}
+
+// Send renders the given event for the given channel and delivers it there, the delivered notification is returned
+func (n *Notifier) Send(ctx context.Context, channelName string, event models.Event) (*Notification, error) {
+	return n.send(ctx, channelName, Notification{Channel: channelName, Event: event}, event)
+}
+
+// SendDigest renders the given digest and delivers it on every configured channel the schema domain of the digest may
+// use, addressed to the digest team
+func (n *Notifier) SendDigest(ctx context.Context, digest models.Digest) error {
+	event := models.Event{Type: models.DigestEvent, Message: digest.Team, Timestamp: time.Now().UTC()}
+
+	var errs []string
+	for _, channelName := range n.domainChannels(digest.Domain) {
+		notification := Notification{Channel: channelName, Recipient: digest.Team, Event: event}
+		if _, err := n.send(ctx, channelName, notification, digest); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", channelName, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to deliver digest for %s on channels %s", digest.Team, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// SendReviewReminder renders the given review reminder and delivers it on every configured channel the schema domain
+// of the reminder may use, addressed to the reminded reviewer or team
+func (n *Notifier) SendReviewReminder(ctx context.Context, reminder models.ReviewReminder) error {
+	event := models.Event{Type: models.ReviewReminderEvent, Message: reminder.Reviewer, Timestamp: time.Now().UTC()}
+
+	var errs []string
+	for _, channelName := range n.domainChannels(reminder.Domain) {
+		notification := Notification{Channel: channelName, Recipient: reminder.Reviewer, Event: event}
+		if _, err := n.send(ctx, channelName, notification, reminder); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", channelName, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to deliver review reminder for %s on channels %s", reminder.Reviewer,
+			strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// send renders the given data with the template of the notification event type and delivers the notification on the
+// given channel
+func (n *Notifier) send(ctx context.Context, channelName string, notification Notification,
+	data interface{}) (*Notification, error) {
+	n.mu.RLock()
+	channel, ok := n.channels[channelName]
+	users := n.directory
+	n.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownChannel, channelName)
+	}
+
+	// an actor missing from the directory does not prevent delivery
+	if users != nil && notification.Event.Actor != "" {
+		actor, err := users.Lookup(ctx, notification.Event.Actor)
+		if err != nil && !errors.Is(err, directory.ErrUserNotFound) {
+			logging.FromContext(ctx).Info("unable to resolve actor in the directory", "actor", notification.Event.Actor,
+				"directory", users.Name(), logging.ERROR_KEY, err)
+		}
+		notification.Actor = actor
+	}
+
+	if subject := notification.Event.Subject; subject != nil {
+		notification.Urgent = models.Priority(subject.Priority).Urgent()
+	}
+
+	body, err := n.templates.Render(channelName, notification.Event.Type, data)
+	if err != nil {
+		return nil, err
+	}
+	notification.Body = body
+	if err = channel.Send(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// Notify delivers the given event where the routing rules decide, or on every configured channel if no router is set,
+// failures are logged so that one channel never prevents delivery on another
+func (n *Notifier) Notify(ctx context.Context, event models.Event) {
+	for _, route := range n.Routes(event) {
+		notification := Notification{Channel: route.Channel, Recipient: route.Recipient, Event: event}
+		if _, err := n.send(ctx, route.Channel, notification, event); err != nil {
+			logging.FromContext(ctx).Error("unable to notify event", "event", event.Type,
+				logging.RFC_IDENTIFIER_KEY, event.RFCIdentifier, "channel", route.Channel, logging.ERROR_KEY, err)
+		}
+	}
+}
+
+// Routes returns where the given event is delivered, a broadcast on every configured channel if no router is set
+// Events are only delivered on the channels the schema domain of their RFC may use when tenants are set
+func (n *Notifier) Routes(event models.Event) []Route {
+	n.mu.RLock()
+	router := n.router
+	isolated := n.isolation != nil
+	n.mu.RUnlock()
+
+	domain := ""
+	if event.Subject != nil {
+		domain = event.Subject.Domain
+	}
+	channels := n.domainChannels(domain)
+	if router != nil {
+		routes := router.Route(event, channels)
+		if !isolated {
+			return routes
+		}
+		// rules may name channels of another schema domain
+		allowed := []Route{}
+		for _, route := range routes {
+			if containsAny(channels, route.Channel) {
+				allowed = append(allowed, route)
+			}
+		}
+		return allowed
+	}
+
+	routes := make([]Route, 0, len(channels))
+	for _, channel := range channels {
+		routes = append(routes, Route{Channel: channel})
+	}
+
+	return routes
+}
+
+// Subscribe delivers every event published on the given bus, the returned function removes the subscription
+func (n *Notifier) Subscribe(bus events.Bus) func() {
+	return bus.Subscribe(func(event models.Event) {
+		n.Notify(context.Background(), event)
+	})
+}
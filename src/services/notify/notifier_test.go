@@ -0,0 +1,279 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/directory"
+	"harmonia-example.io/src/services/events"
+	"harmonia-example.io/src/services/tenants"
+)
+
+// recordingChannel is a Channel that records the notifications it is sent
+type recordingChannel struct {
+	name string
+	sent chan Notification
+	err  error
+}
+
+func (r *recordingChannel) Name() string {
+	return r.name
+}
+
+func (r *recordingChannel) Send(ctx context.Context, notification Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent <- notification
+	return nil
+}
+
+func TestNotifierSend(t *testing.T) {
+	// arrange
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 1)}
+	notifier := NewNotifier(NewTemplates(), channel)
+	event := models.Event{Type: models.MergeEvent, RFCIdentifier: "1", Actor: "tstark"}
+
+	// act
+	notification, err := notifier.Send(context.Background(), "test", event)
+	_, unknownErr := notifier.Send(context.Background(), "missing", event)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if notification.Body != "RFC 1 was merged by tstark" || (<-channel.sent).Body != notification.Body {
+		t.Errorf("unexpected notification: %+v", notification)
+	}
+	if !errors.Is(unknownErr, ErrUnknownChannel) {
+		t.Errorf("expected an unknown channel error, got %v", unknownErr)
+	}
+}
+
+func TestNotifierSendResolvesActor(t *testing.T) {
+	// arrange
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 2)}
+	notifier := NewNotifier(NewTemplates(), channel)
+	notifier.SetDirectory(directory.NewStatic([]directory.User{{Login: "tstark", SlackHandle: "ironman"}}))
+
+	// act
+	known, err := notifier.Send(context.Background(), "test", models.Event{Type: models.MergeEvent, Actor: "tstark"})
+	unknown, unknownErr := notifier.Send(context.Background(), "test",
+		models.Event{Type: models.MergeEvent, Actor: "bbanner"})
+
+	// assert
+	if err != nil || unknownErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, unknownErr)
+	}
+	if known.Actor == nil || known.Actor.SlackHandle != "ironman" {
+		t.Errorf("expected the actor to be resolved, got %+v", known.Actor)
+	}
+	if unknown.Actor != nil {
+		t.Errorf("expected an unknown actor not to be resolved, got %+v", unknown.Actor)
+	}
+}
+
+func TestNotifierSendUrgent(t *testing.T) {
+	// arrange
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 3)}
+	notifier := NewNotifier(NewTemplates(), channel)
+	event := func(priority models.Priority) models.Event {
+		return models.Event{Type: models.SubmitEvent, RFCIdentifier: "1", Actor: "tstark",
+			Subject: &models.EventSubject{Priority: string(priority)}}
+	}
+
+	// act
+	urgent, urgentErr := notifier.Send(context.Background(), "test", event(models.UrgentPriority))
+	routine, routineErr := notifier.Send(context.Background(), "test", event(models.NormalPriority))
+	unknown, unknownErr := notifier.Send(context.Background(), "test", models.Event{Type: models.SubmitEvent})
+
+	// assert
+	if urgentErr != nil || routineErr != nil || unknownErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v", urgentErr, routineErr, unknownErr)
+	}
+	if !urgent.Urgent || routine.Urgent || unknown.Urgent {
+		t.Errorf("expected only the urgent RFC notification to be urgent, got %v, %v and %v", urgent.Urgent,
+			routine.Urgent, unknown.Urgent)
+	}
+}
+
+func TestNotifierSubscribe(t *testing.T) {
+	// arrange
+	failing := &recordingChannel{name: "failing", err: fmt.Errorf("delivery error")}
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 1)}
+	notifier := NewNotifier(NewTemplates(), failing, channel)
+	bus := events.NewMemoryBus(10)
+	unsubscribe := notifier.Subscribe(bus)
+	defer unsubscribe()
+
+	// act
+	bus.Publish(models.Event{Type: models.SubmitEvent, RFCIdentifier: "1", Actor: "tstark"})
+
+	// assert
+	select {
+	case notification := <-channel.sent:
+		if notification.Body != "tstark submitted RFC 1" {
+			t.Errorf("unexpected notification body: %s", notification.Body)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("event was not delivered despite a failing channel")
+	}
+}
+
+func TestWebhookChannelSend(t *testing.T) {
+	// arrange
+	statuses := []int{http.StatusOK, http.StatusInternalServerError}
+	for _, status := range statuses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		channel := NewWebhookChannel(server.URL)
+		channel.Backoff = time.Millisecond
+
+		// act
+		err := channel.Send(context.Background(), Notification{Channel: WEBHOOK_CHANNEL, Body: "hello"})
+
+		// assert
+		if (err == nil) != (status == http.StatusOK) {
+			t.Errorf("unexpected result for status %d: %v", status, err)
+		}
+		server.Close()
+	}
+}
+
+func TestWebhookChannelDeadLetters(t *testing.T) {
+	// arrange
+	failing := true
+	deliveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveries++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	store := NewMemoryDeadLetters()
+	channel := NewWebhookChannel(server.URL)
+	channel.Backoff = time.Millisecond
+	channel.DeadLetters = store
+	notifier := NewNotifier(NewTemplates(), channel)
+	notifier.SetDeadLetters(store)
+	event := models.Event{Type: models.MergeEvent, RFCIdentifier: "1", Actor: "tstark"}
+
+	// act
+	_, sendErr := notifier.Send(context.Background(), WEBHOOK_CHANNEL, event)
+	letters, _ := notifier.DeadLetters(context.Background())
+	failing = false
+	redeliverErr := notifier.Redeliver(context.Background(), letters[0].ID)
+	remaining, _ := notifier.DeadLetters(context.Background())
+	missingErr := notifier.Redeliver(context.Background(), letters[0].ID)
+
+	// assert
+	if sendErr == nil || deliveries != DEFAULT_WEBHOOK_ATTEMPTS+1 {
+		t.Errorf("expected %d failed deliveries, got %d: %v", DEFAULT_WEBHOOK_ATTEMPTS, deliveries-1, sendErr)
+	}
+	if len(letters) != 1 || letters[0].Attempts != DEFAULT_WEBHOOK_ATTEMPTS || letters[0].Event.RFCIdentifier != "1" {
+		t.Errorf("expected the notification to be kept as a dead letter, got %+v", letters)
+	}
+	if redeliverErr != nil || len(remaining) != 0 {
+		t.Errorf("expected the dead letter to be redelivered and dropped, got %v, %+v", redeliverErr, remaining)
+	}
+	if !errors.Is(missingErr, models.ErrDeadLetterNotFound) {
+		t.Errorf("expected a dead letter not found error, got %v", missingErr)
+	}
+}
+
+func TestNotifierSendDigest(t *testing.T) {
+	// arrange
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 1)}
+	notifier := NewNotifier(NewTemplates(), channel)
+	digest := models.Digest{
+		Team:           "avengers",
+		AwaitingReview: []models.RFCReference{{RFCIdentifier: "1", Title: "RFC: 1"}},
+		NewlyMerged:    []models.RFCReference{{RFCIdentifier: "2", Title: "RFC: 2"}},
+	}
+
+	// act
+	err := notifier.SendDigest(context.Background(), digest)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	notification := <-channel.sent
+	expected := "Daily RFC digest for avengers\nAwaiting review:\n  - 1: RFC: 1\nNewly merged:\n  - 2: RFC: 2"
+	if notification.Body != expected || notification.Recipient != "avengers" {
+		t.Errorf("unexpected digest notification. wanted %q, got %q to %s", expected, notification.Body,
+			notification.Recipient)
+	}
+}
+
+func TestNotifierSendReviewReminder(t *testing.T) {
+	// arrange
+	channel := &recordingChannel{name: "test", sent: make(chan Notification, 1)}
+	notifier := NewNotifier(NewTemplates(), channel)
+	requestedAt := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	reminder := models.ReviewReminder{
+		Reviewer:  "avengers",
+		Team:      true,
+		SLA:       "48h0m0s",
+		Overdue:   []models.ReviewRequest{{RFCIdentifier: "2", Reviewer: "avengers", RequestedAt: requestedAt}},
+		Escalated: []models.ReviewRequest{{RFCIdentifier: "1", Reviewer: "avengers", RequestedAt: requestedAt}},
+	}
+
+	// act
+	err := notifier.SendReviewReminder(context.Background(), reminder)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	notification := <-channel.sent
+	expected := "Reviews awaited from avengers past the 48h0m0s review SLA\n" +
+		"Escalated:\n  - 1, requested 2022-06-01 09:00 UTC\nOverdue:\n  - 2, requested 2022-06-01 09:00 UTC"
+	if notification.Body != expected || notification.Recipient != "avengers" {
+		t.Errorf("unexpected review reminder notification. wanted %q, got %q to %s", expected, notification.Body,
+			notification.Recipient)
+	}
+}
+
+// TestNotifierTenants tests that the events and digests of a tenant are only delivered on its channels, and those of
+// other schema domains on the channels of no tenant
+func TestNotifierTenants(t *testing.T) {
+	// arrange
+	catalog := &recordingChannel{name: "catalog", sent: make(chan Notification, 2)}
+	shared := &recordingChannel{name: "shared", sent: make(chan Notification, 2)}
+	notifier := NewNotifier(NewTemplates(), catalog, shared)
+	router, _ := NewRouter([]Rule{{Name: "everything", Channels: []string{"catalog", "shared"}}})
+	if err := notifier.SetRouter(router); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	registry, err := tenants.New(map[string]tenants.Tenant{
+		"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"},
+			Channels: []string{"catalog"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	notifier.SetTenants(registry)
+
+	// act
+	catalogEvent := models.Event{Type: models.MergeEvent, Subject: &models.EventSubject{Domain: "catalog"}}
+	catalogRoutes := notifier.Routes(catalogEvent)
+	defaultRoutes := notifier.Routes(models.Event{Type: models.MergeEvent})
+	err = notifier.SendDigest(context.Background(), models.Digest{Team: "avengers", Domain: "catalog"})
+
+	// assert
+	if fmt.Sprint(catalogRoutes) != "[{catalog }]" || fmt.Sprint(defaultRoutes) != "[{shared }]" {
+		t.Errorf("unexpected routes: %v and %v", catalogRoutes, defaultRoutes)
+	}
+	if err != nil || len(catalog.sent) != 1 || len(shared.sent) != 0 {
+		t.Errorf("expected the digest to be delivered on the channel of the tenant only, err: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+// This holds the routing rules deciding which channels and recipients each event is delivered to
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+)
+
+// OWNERS_RECIPIENT is the rule recipient standing for the teams that own the targets changed by the RFC of an event
+const OWNERS_RECIPIENT string = "@owners"
+
+// Rule routes the events it matches to channels and recipients
+// An event matches a rule if it matches every criterion of the rule, an empty criterion matches every event. Criteria
+// on the RFC of the event (action types, target descriptors, teams and priorities) match when any of its values does
+type Rule struct {
+	// Name identifies the rule in logs
+	Name       string             `json:"name"`
+	EventTypes []models.EventType `json:"eventTypes,omitempty"`
+	// ActionTypes match the type of any action of the RFC
+	ActionTypes []models.ActionType `json:"actionTypes,omitempty"`
+	// TargetDescriptors match the descriptor of any target of the RFC, they are path.Match patterns, e.g. "Entity*"
+	TargetDescriptors []string `json:"targetDescriptors,omitempty"`
+	// Teams match any team owning a target changed by the RFC
+	Teams      []string `json:"teams,omitempty"`
+	Priorities []string `json:"priorities,omitempty"`
+	// Channels are the channels matched events are delivered on, every configured channel if empty
+	Channels []string `json:"channels,omitempty"`
+	// Recipients are the teams matched events are addressed to, OWNERS_RECIPIENT expands to the owners of the RFC
+	// targets. Matched events are broadcast if empty
+	Recipients []string `json:"recipients,omitempty"`
+	// Stop prevents the rules following this one from being evaluated for the events it matches
+	Stop bool `json:"stop,omitempty"`
+}
+
+// Route is a single delivery decided by the routing rules
+type Route struct {
+	Channel string
+	// Recipient is the team the notification is addressed to, empty for broadcasts
+	Recipient string
+}
+
+// Router decides where events are delivered by evaluating its rules in order, every matching rule contributes its
+// routes until a matching rule stops the evaluation. Events matching no rule are not delivered
+type Router struct {
+	rules []Rule
+}
+
+// NewRouter returns a Router evaluating the given rules, the target descriptor patterns of which must be valid
+func NewRouter(rules []Rule) (*Router, error) {
+	for i, rule := range rules {
+		for _, pattern := range rule.TargetDescriptors {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("malformed target descriptor pattern '%s' in routing rule %d: %w", pattern, i, err)
+			}
+		}
+	}
+
+	return &Router{rules: rules}, nil
+}
+
+// LoadRouter returns a Router evaluating the rules of the given JSON file, a list of Rule objects
+func LoadRouter(file string) (*Router, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read notification routing file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var rules []Rule
+	if err = json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("malformed notification routing file %s: %w", file, err)
+	}
+
+	return NewRouter(rules)
+}
+
+// Channels returns the channels the rules of the router deliver on explicitly
+func (r *Router) Channels() []string {
+	channels := []string{}
+	for _, rule := range r.rules {
+		channels = append(channels, rule.Channels...)
+	}
+
+	return channels
+}
+
+// Route returns the deliveries of the given event on the given configured channels, without duplicates and in the
+// order of the rules deciding them
+func (r *Router) Route(event models.Event, channels []string) []Route {
+	routes := []Route{}
+	seen := map[Route]bool{}
+	for _, rule := range r.rules {
+		if !rule.matches(event) {
+			continue
+		}
+
+		ruleChannels := rule.Channels
+		if len(ruleChannels) == 0 {
+			ruleChannels = channels
+		}
+		for _, channel := range ruleChannels {
+			for _, recipient := range rule.recipients(event) {
+				route := Route{Channel: channel, Recipient: recipient}
+				if !seen[route] {
+					seen[route] = true
+					routes = append(routes, route)
+				}
+			}
+		}
+
+		if rule.Stop {
+			break
+		}
+	}
+
+	return routes
+}
+
+// matches returns whether the given event matches every criterion of the rule
+func (rule Rule) matches(event models.Event) bool {
+	subject := event.Subject
+	if subject == nil {
+		subject = &models.EventSubject{}
+	}
+
+	return (len(rule.EventTypes) == 0 || containsAny(rule.EventTypes, event.Type)) &&
+		(len(rule.ActionTypes) == 0 || containsAny(rule.ActionTypes, subject.ActionTypes...)) &&
+		(len(rule.TargetDescriptors) == 0 || matchesAny(rule.TargetDescriptors, subject.TargetDescriptors)) &&
+		(len(rule.Teams) == 0 || containsAny(rule.Teams, subject.Teams...)) &&
+		(len(rule.Priorities) == 0 || containsAny(rule.Priorities, subject.Priority))
+}
+
+// recipients returns the recipients of the given matched event, a single empty recipient for broadcasts
+func (rule Rule) recipients(event models.Event) []string {
+	if len(rule.Recipients) == 0 {
+		return []string{""}
+	}
+
+	recipients := []string{}
+	for _, recipient := range rule.Recipients {
+		if recipient != OWNERS_RECIPIENT {
+			recipients = append(recipients, recipient)
+		} else if event.Subject != nil {
+			recipients = append(recipients, event.Subject.Teams...)
+		}
+	}
+
+	return recipients
+}
+
+// containsAny returns whether any of the given values is in the given list
+func containsAny[T comparable](list []T, values ...T) bool {
+	for _, value := range values {
+		for _, candidate := range list {
+			if candidate == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesAny returns whether any of the given values matches any of the given path.Match patterns
+func matchesAny(patterns []string, values []string) bool {
+	for _, value := range values {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestRouterRoute(t *testing.T) {
+	// arrange
+	router, err := NewRouter([]Rule{
+		{Name: "urgent", Priorities: []string{"high"}, Channels: []string{"pager"}, Recipients: []string{OWNERS_RECIPIENT},
+			Stop: true},
+		{Name: "entities", EventTypes: []models.EventType{models.SubmitEvent},
+			TargetDescriptors: []string{"Entity*"}, Recipients: []string{"modelers", OWNERS_RECIPIENT}},
+		{Name: "everything"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	channels := []string{"log", "webhook"}
+	subject := &models.EventSubject{
+		ActionTypes:       []models.ActionType{models.AddAction},
+		TargetDescriptors: []string{"EntityType"},
+		Teams:             []string{"catalog"},
+	}
+	urgent := &models.EventSubject{Priority: "high", Teams: []string{"catalog"}}
+	cases := []struct {
+		name     string
+		event    models.Event
+		expected []Route
+	}{
+		{
+			name:     "urgent events stop at the first rule",
+			event:    models.Event{Type: models.SubmitEvent, Subject: urgent},
+			expected: []Route{{Channel: "pager", Recipient: "catalog"}},
+		},
+		{
+			name:  "matching events are routed by every matching rule",
+			event: models.Event{Type: models.SubmitEvent, Subject: subject},
+			expected: []Route{
+				{Channel: "log", Recipient: "modelers"}, {Channel: "log", Recipient: "catalog"},
+				{Channel: "webhook", Recipient: "modelers"}, {Channel: "webhook", Recipient: "catalog"},
+				{Channel: "log"}, {Channel: "webhook"},
+			},
+		},
+		{
+			name:     "events without a subject only match rules without subject criteria",
+			event:    models.Event{Type: models.SubmitEvent},
+			expected: []Route{{Channel: "log"}, {Channel: "webhook"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			routes := router.Route(c.event, channels)
+
+			// assert
+			if !reflect.DeepEqual(routes, c.expected) {
+				t.Errorf("unexpected routes. wanted %+v, got %+v", c.expected, routes)
+			}
+		})
+	}
+}
+
+func TestLoadRouter(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(valid, []byte(`[{"name": "all", "channels": ["test"]}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte(`[{"name": "bad", "targetDescriptors": ["["]}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	notifier := NewNotifier(NewTemplates(), &recordingChannel{name: "test"})
+
+	// act
+	router, err := LoadRouter(valid)
+	_, invalidErr := LoadRouter(invalid)
+	unknownErr := NewNotifier(NewTemplates()).SetRouter(router)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err = notifier.SetRouter(router); err != nil {
+		t.Errorf("unexpected error setting the router: %s", err.Error())
+	}
+	if routes := notifier.Routes(models.Event{Type: models.MergeEvent}); len(routes) != 1 || routes[0].Channel != "test" {
+		t.Errorf("unexpected routes: %+v", routes)
+	}
+	if invalidErr == nil {
+		t.Errorf("expected an error for a malformed target descriptor pattern")
+	}
+	if !errors.Is(unknownErr, ErrUnknownChannel) {
+		t.Errorf("expected an unknown channel error, got %v", unknownErr)
+	}
+}
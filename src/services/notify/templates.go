@@ -0,0 +1,152 @@
+// This holds the Go templates used to render RFC lifecycle events as notification text
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"harmonia-example.io/src/models"
+)
+
+// fallbackTemplate renders any event type that has no dedicated template
+const fallbackTemplate = `RFC {{.RFCIdentifier}}: {{.Type}}{{with .Actor}} by {{.}}{{end}}{{with .Message}} ({{.}}){{end}}`
+
+// defaultTemplates are the built-in templates for each event type, used unless a deployment overrides them
+var defaultTemplates = map[models.EventType]string{
+	models.SubmitEvent:     `{{.Actor}} submitted RFC {{.RFCIdentifier}}`,
+	models.UpdateEvent:     `{{.Actor}} updated RFC {{.RFCIdentifier}}`,
+	models.ReviewEvent:     `{{.Actor}} reviewed RFC {{.RFCIdentifier}}{{with .Message}}: {{.}}{{end}}`,
+	models.LoadEvent:       `RFC {{.RFCIdentifier}} load {{.Message}}{{with .Actor}} (requested by {{.}}){{end}}`,
+	models.MergeEvent:      `RFC {{.RFCIdentifier}} was merged{{with .Actor}} by {{.}}{{end}}`,
+	models.RebuildEvent:    `RFC {{.RFCIdentifier}} file was rebuilt{{with .Message}}: {{.}}{{end}}`,
+	models.CommentEvent:    `{{.Actor}} {{.Message}} on RFC {{.RFCIdentifier}}`,
+	models.AnnotateEvent:   `{{.Actor}} analyzer annotated RFC {{.RFCIdentifier}}{{with .Message}}: {{.}}{{end}}`,
+	models.WithdrawEvent:   `{{.Actor}} withdrew RFC {{.RFCIdentifier}}{{with .Message}}: {{.}}{{end}}`,
+	models.BreakGlassEvent: `BREAK-GLASS: {{.Actor}} forced RFC {{.RFCIdentifier}} live bypassing policy: {{.Message}}`,
+	models.DigestEvent: `Daily RFC digest for {{.Team}}
+{{- with .AwaitingReview}}
+Awaiting review:{{range .}}
+  - {{.RFCIdentifier}}: {{.Title}}{{end}}{{end}}
+{{- with .FailedLoads}}
+Failed loads:{{range .}}
+  - {{.RFCIdentifier}}: {{.Title}}{{end}}{{end}}
+{{- with .NewlyMerged}}
+Newly merged:{{range .}}
+  - {{.RFCIdentifier}}: {{.Title}}{{end}}{{end}}`,
+	models.ReviewReminderEvent: `Reviews awaited from {{.Reviewer}} past the {{.SLA}} review SLA
+{{- with .Escalated}}
+Escalated:{{range .}}
+  - {{.RFCIdentifier}}, requested {{.RequestedAt.Format "2006-01-02 15:04 MST"}}{{end}}{{end}}
+{{- with .Overdue}}
+Overdue:{{range .}}
+  - {{.RFCIdentifier}}, requested {{.RequestedAt.Format "2006-01-02 15:04 MST"}}{{end}}{{end}}`,
+}
+
+// Templates holds the notification template for each event type, optionally overridden per channel
+// Rendering looks templates up in the following order, using the first one found:
+//  1. the override for the channel and event type
+//  2. the override for the event type on all channels
+//  3. the built-in default for the event type
+//  4. a generic fallback
+type Templates struct {
+	mu        sync.RWMutex
+	defaults  map[models.EventType]*template.Template
+	overrides map[string]map[models.EventType]*template.Template
+	fallback  *template.Template
+}
+
+// NewTemplates returns Templates populated with the built-in defaults
+func NewTemplates() *Templates {
+	t := &Templates{
+		defaults:  map[models.EventType]*template.Template{},
+		overrides: map[string]map[models.EventType]*template.Template{},
+		fallback:  template.Must(template.New("fallback").Parse(fallbackTemplate)),
+	}
+	for eventType, text := range defaultTemplates {
+		t.defaults[eventType] = template.Must(template.New(string(eventType)).Parse(text))
+	}
+
+	return t
+}
+
+// Set overrides the template of the given event type on the given channel, an empty channel overrides the template
+// on all channels
+func (t *Templates) Set(channel string, eventType models.EventType, text string) error {
+	tmpl, err := template.New(fmt.Sprintf("%s/%s", channel, eventType)).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template for %s events on channel '%s': %w", eventType, channel, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.overrides[channel]; !ok {
+		t.overrides[channel] = map[models.EventType]*template.Template{}
+	}
+	t.overrides[channel][eventType] = tmpl
+
+	return nil
+}
+
+// LoadDir overrides templates using the files in the given directory
+// A file named <event-type>.tmpl overrides that event type on all channels, while a file named
+// <channel>/<event-type>.tmpl overrides it on a single channel
+func (t *Templates) LoadDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != TEMPLATE_FILE_EXTENSION {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		channel := ""
+		if parent := filepath.Dir(rel); parent != "." {
+			channel = parent
+		}
+		eventType := models.EventType(strings.TrimSuffix(filepath.Base(rel), TEMPLATE_FILE_EXTENSION))
+
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return t.Set(channel, eventType, string(text))
+	})
+}
+
+// Render renders the given data with the template of the given event type as notification text for the given channel
+// Event notifications render the models.Event itself, while digests render a models.Digest and review reminders a
+// models.ReviewReminder
+func (t *Templates) Render(channel string, eventType models.EventType, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.lookup(channel, eventType).Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to render %s notification for channel '%s': %w", eventType, channel, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// lookup returns the template used to render the given event type on the given channel
+func (t *Templates) lookup(channel string, eventType models.EventType) *template.Template {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, key := range []string{channel, ""} {
+		if tmpl, ok := t.overrides[key][eventType]; ok {
+			return tmpl
+		}
+	}
+	if tmpl, ok := t.defaults[eventType]; ok {
+		return tmpl
+	}
+
+	return t.fallback
+}
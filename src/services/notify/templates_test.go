@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestTemplatesRender(t *testing.T) {
+	// arrange
+	templates := NewTemplates()
+	if err := templates.Set("", models.ReviewEvent, `all: {{.RFCIdentifier}}`); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := templates.Set(WEBHOOK_CHANNEL, models.ReviewEvent, `webhook: {{.RFCIdentifier}}`); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	testCases := []struct {
+		channel  string
+		event    models.Event
+		expected string
+	}{
+		// channel override
+		{WEBHOOK_CHANNEL, models.Event{Type: models.ReviewEvent, RFCIdentifier: "1"}, "webhook: 1"},
+		// all channel override
+		{LOG_CHANNEL, models.Event{Type: models.ReviewEvent, RFCIdentifier: "1"}, "all: 1"},
+		// built-in default
+		{LOG_CHANNEL, models.Event{Type: models.SubmitEvent, RFCIdentifier: "1", Actor: "tstark"},
+			"tstark submitted RFC 1"},
+		// fallback
+		{LOG_CHANNEL, models.Event{Type: "custom", RFCIdentifier: "1", Actor: "tstark"}, "RFC 1: custom by tstark"},
+	}
+
+	for _, testCase := range testCases {
+		// act
+		actual, err := templates.Render(testCase.channel, testCase.event.Type, testCase.event)
+
+		// assert
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+		} else if actual != testCase.expected {
+			t.Errorf("unexpected render. wanted %v, got %v", testCase.expected, actual)
+		}
+	}
+}
+
+func TestTemplatesSetInvalid(t *testing.T) {
+	// act
+	err := NewTemplates().Set("", models.ReviewEvent, `{{.RFCIdentifier`)
+
+	// assert
+	if err == nil {
+		t.Errorf("expected an error for an invalid template")
+	}
+}
+
+func TestTemplatesLoadDir(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, WEBHOOK_CHANNEL), 0o755); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	files := map[string]string{
+		"merge.tmpl": "merged {{.RFCIdentifier}}\n",
+		filepath.Join(WEBHOOK_CHANNEL, "merge.tmpl"): ":tada: merged {{.RFCIdentifier}}",
+		"README.md": "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	templates := NewTemplates()
+
+	// act
+	err := templates.LoadDir(dir)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	event := models.Event{Type: models.MergeEvent, RFCIdentifier: "1"}
+	if actual, _ := templates.Render(LOG_CHANNEL, event.Type, event); actual != "merged 1" {
+		t.Errorf("unexpected render. wanted %v, got %v", "merged 1", actual)
+	}
+	if actual, _ := templates.Render(WEBHOOK_CHANNEL, event.Type, event); actual != ":tada: merged 1" {
+		t.Errorf("unexpected render. wanted %v, got %v", ":tada: merged 1", actual)
+	}
+}
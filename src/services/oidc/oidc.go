@@ -0,0 +1,307 @@
+// Package oidc holds the verification of the OpenID Connect ID tokens of an enterprise identity provider, and the
+// mapping of the identities they carry to Git logins, so Harmonia can authenticate users through SSO by itself
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"harmonia-example.io/src/services/logging"
+)
+
+const (
+	// DEFAULT_LOGIN_CLAIM is the claim of ID tokens Git logins are read from unless configured otherwise
+	DEFAULT_LOGIN_CLAIM = "preferred_username"
+	// discoveryPath is the path of the OpenID configuration of an issuer, relative to the issuer
+	discoveryPath = "/.well-known/openid-configuration"
+	// keyRefreshInterval is how often the signing keys of the issuer may be fetched again for a token signed with an
+	// unknown key, so rotated keys are picked up without forged key IDs hammering the issuer
+	keyRefreshInterval = time.Minute
+	// leeway is how far the clocks of the issuer and Harmonia may drift apart
+	leeway      = time.Minute
+	oidcTimeout = 10 * time.Second
+)
+
+// ErrInvalidToken is returned (wrapped) when an ID token is malformed, not signed by the issuer, not issued for
+// Harmonia, expired or does not identify a Git login
+var ErrInvalidToken = errors.New("invalid ID token")
+
+// Identity is the user an ID token was issued to
+type Identity struct {
+	Subject string
+	Email   string
+	// Login is the Git login the user is mapped to
+	Login string
+}
+
+// Verifier verifies the ID tokens of an issuer issued for an audience, the signing keys of the issuer are discovered
+// on first use and fetched again when a token is signed with a key they do not include
+type Verifier struct {
+	Issuer     string
+	Audience   string
+	LoginClaim string
+	client     *http.Client
+	now        func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	refreshedAt time.Time
+}
+
+// header is the JOSE header of an ID token
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// discovery is the part of the OpenID configuration of an issuer used to find its signing keys
+type discovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// jwks is the JSON web key set of an issuer, only RSA keys are used
+type jwks struct {
+	Keys []struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		Use     string `json:"use"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+	} `json:"keys"`
+}
+
+// NewVerifier returns a Verifier of the ID tokens of the given issuer issued for the given audience, the client ID of
+// Harmonia, mapping users to the Git login of the given claim, DEFAULT_LOGIN_CLAIM if empty
+func NewVerifier(issuer string, audience string, loginClaim string) *Verifier {
+	if loginClaim == "" {
+		loginClaim = DEFAULT_LOGIN_CLAIM
+	}
+
+	return &Verifier{
+		Issuer:     strings.TrimSuffix(issuer, "/"),
+		Audience:   audience,
+		LoginClaim: loginClaim,
+		client:     &http.Client{Timeout: oidcTimeout},
+		now:        time.Now,
+	}
+}
+
+// Default is the verifier of the ID tokens users authenticate with, nil if SSO is not enabled
+var Default *Verifier
+
+// Verify returns the identity of the given RS256 signed ID token, or ErrInvalidToken (wrapped) if it is not a valid
+// token of the issuer issued for the audience. The login of the identity is the value of the login claim, stripped of
+// its domain if it is an email address
+func (v *Verifier) Verify(ctx context.Context, token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JSON web token", ErrInvalidToken)
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return nil, err
+	}
+	if head.Algorithm != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported signing algorithm %s", ErrInvalidToken, head.Algorithm)
+	}
+	key, err := v.key(ctx, head.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrInvalidToken)
+	}
+
+	var claims map[string]interface{}
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if err = v.validate(claims); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	login, _ := claims[v.LoginClaim].(string)
+	if at := strings.Index(login, "@"); at >= 0 {
+		login = login[:at]
+	}
+	if identity.Login = strings.TrimSpace(login); identity.Login == "" {
+		return nil, fmt.Errorf("%w: no %s claim to map to a Git login", ErrInvalidToken, v.LoginClaim)
+	}
+
+	return identity, nil
+}
+
+// validate returns ErrInvalidToken (wrapped) unless the given claims were issued by the issuer for the audience and
+// are valid at present
+func (v *Verifier) validate(claims map[string]interface{}) error {
+	if issuer, _ := claims["iss"].(string); strings.TrimSuffix(issuer, "/") != v.Issuer {
+		return fmt.Errorf("%w: issued by %s", ErrInvalidToken, issuer)
+	}
+
+	// the audience is either a single client ID or a list of them
+	audiences := []string{}
+	switch audience := claims["aud"].(type) {
+	case string:
+		audiences = append(audiences, audience)
+	case []interface{}:
+		for _, value := range audience {
+			if s, ok := value.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	issuedFor := false
+	for _, audience := range audiences {
+		issuedFor = issuedFor || audience == v.Audience
+	}
+	if !issuedFor {
+		return fmt.Errorf("%w: not issued for %s", ErrInvalidToken, v.Audience)
+	}
+
+	now := v.now()
+	expiry, ok := claims["exp"].(float64)
+	if !ok || now.Add(-leeway).After(time.Unix(int64(expiry), 0)) {
+		return fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(leeway).Before(time.Unix(int64(notBefore), 0)) {
+		return fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// key returns the signing key of the issuer of the given ID, the only key of the issuer if no ID is given. The keys
+// are fetched again if none has the ID and they were not fetched within keyRefreshInterval
+func (v *Verifier) key(ctx context.Context, id string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key := v.lookup(id); key != nil {
+		return key, nil
+	}
+	if v.keys != nil && v.now().Sub(v.refreshedAt) < keyRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %s", ErrInvalidToken, id)
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys, v.refreshedAt = keys, v.now()
+
+	if key := v.lookup(id); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %s", ErrInvalidToken, id)
+}
+
+// lookup returns the cached signing key of the given ID, see key
+func (v *Verifier) lookup(id string) *rsa.PublicKey {
+	if id == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key
+		}
+	}
+	return v.keys[id]
+}
+
+// fetchKeys discovers the JSON web key set of the issuer and returns its RSA signing keys, keyed by ID
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var config discovery
+	if err := v.getJSON(ctx, v.Issuer+discoveryPath, &config); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(config.Issuer, "/") != v.Issuer || config.JWKSURI == "" {
+		return nil, fmt.Errorf("OpenID configuration of %s does not describe it", v.Issuer)
+	}
+
+	var set jwks
+	if err := v.getJSON(ctx, config.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, nErr := base64.RawURLEncoding.DecodeString(key.N)
+		e, eErr := base64.RawURLEncoding.DecodeString(key.E)
+		if nErr != nil || eErr != nil {
+			continue
+		}
+		keys[key.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	return keys, nil
+}
+
+// getJSON decodes the JSON response to a GET request of the given URL into the given value
+func (v *Verifier) getJSON(ctx context.Context, url string, value interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	response, err := v.client.Do(request)
+	if err != nil {
+		logging.FromContext(ctx).Error("OpenID provider request error", "url", url, logging.ERROR_KEY, err)
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("OpenID provider responded to %s with status %d", url, response.StatusCode)
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(value); err != nil {
+		logging.FromContext(ctx).Error("json OpenID provider response unmarshal error", "url", url, logging.ERROR_KEY, err)
+		return err
+	}
+	return nil
+}
+
+// decodeSegment decodes the given base64url encoded JSON segment of an ID token into the given value
+func decodeSegment(segment string, value interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	if err = json.Unmarshal(decoded, value); err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	return nil
+}
+
+// identityKey is the key the identity of the user making a request is stored under in its context
+type identityKey struct{}
+
+// NewContext returns a copy of the given context carrying the given identity of the user making the request
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of the user making the request of the given context, nil unless they
+// authenticated through SSO
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// sign returns an RS256 ID token of the given claims signed with the given key of the given ID
+func sign(t *testing.T, key *rsa.PrivateKey, id string, claims map[string]interface{}) string {
+	head, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": id})
+	body, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(head) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	// arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forger, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case discoveryPath:
+			fmt.Fprintf(w, `{"issuer": "%s", "jwks_uri": "%s/keys"}`, server.URL, server.URL)
+		case "/keys":
+			fmt.Fprintf(w, `{"keys": [{"kty": "RSA", "kid": "k1", "use": "sig", "n": "%s", "e": "%s"}]}`,
+				base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	now := time.Unix(1654074000, 0)
+	v := NewVerifier(server.URL+"/", "harmonia", "email")
+	v.now = func() time.Time { return now }
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{"iss": server.URL, "aud": []string{"harmonia", "other"}, "sub": "u-1",
+			"email": "tstark@starkindustries.com", "exp": now.Add(time.Hour).Unix()}
+		for claim, value := range overrides {
+			claims[claim] = value
+		}
+		return claims
+	}
+
+	testCases := []struct {
+		name        string
+		token       string
+		expectedErr error
+	}{
+		{"malformed", "not.a.token.at.all", ErrInvalidToken},
+		{"forged", sign(t, forger, "k1", claims(nil)), ErrInvalidToken},
+		{"unknown key", sign(t, key, "k2", claims(nil)), ErrInvalidToken},
+		{"other issuer", sign(t, key, "k1", claims(map[string]interface{}{"iss": "https://evil.com"})), ErrInvalidToken},
+		{"other audience", sign(t, key, "k1", claims(map[string]interface{}{"aud": "other"})), ErrInvalidToken},
+		{"expired", sign(t, key, "k1", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})),
+			ErrInvalidToken},
+		{"no login", sign(t, key, "k1", claims(map[string]interface{}{"email": ""})), ErrInvalidToken},
+		{"valid", sign(t, key, "k1", claims(nil)), nil},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		identity, err := v.Verify(context.Background(), test.token)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		} else if test.expectedErr == nil && (identity.Login != "tstark" || identity.Subject != "u-1") {
+			t.Errorf("%s: unexpected identity: %+v", test.name, identity)
+		}
+	}
+}
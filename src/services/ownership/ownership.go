@@ -0,0 +1,139 @@
+// Package ownership holds the mapping of RFC target descriptors to the teams that own them
+package ownership
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// Rule assigns the targets whose descriptor matches its pattern to the given teams, see path.Match for the syntax of
+// patterns. A rule without teams leaves the targets it matches unowned
+type Rule struct {
+	Pattern string
+	Teams   []string
+}
+
+// Ownership maps RFC target descriptors to the slugs of the teams that own them
+// As in a CODEOWNERS file, the last rule matching a descriptor decides its owners
+type Ownership struct {
+	rules []rule
+}
+
+// rule is a Rule with the set of its teams
+type rule struct {
+	pattern string
+	teams   set.Set[string]
+}
+
+// New returns an Ownership using the given mapping of target descriptor to team slugs
+func New(mapping map[string][]string) *Ownership {
+	return NewWithRules(nil, mapping)
+}
+
+// NewWithRules returns an Ownership using the given rules, then the given mapping of target descriptor to team slugs
+// so that the mapping overrides the rules
+func NewWithRules(rules []Rule, mapping map[string][]string) *Ownership {
+	o := &Ownership{}
+	for _, r := range rules {
+		o.rules = append(o.rules, rule{pattern: r.Pattern, teams: set.NewSetOf(r.Teams...)})
+	}
+
+	descriptors := make([]string, 0, len(mapping))
+	for descriptor := range mapping {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Strings(descriptors)
+	for _, descriptor := range descriptors {
+		o.rules = append(o.rules, rule{pattern: descriptor, teams: set.NewSetOf(mapping[descriptor]...)})
+	}
+
+	return o
+}
+
+// Default is the ownership shared by the application, nothing is owned until configured
+var Default = New(nil)
+
+// Load returns the rules of the given CODEOWNERS style file: a line per rule, made of a target descriptor pattern
+// followed by the owning teams, blank lines and lines starting with # being ignored. Teams may be written as GitHub
+// team handles, e.g. @org/team-data, only their slug is kept
+func Load(file string) ([]Rule, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		logging.Default.Error("unable to read target owners file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+	defer f.Close()
+
+	rules := []Rule{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if _, err := path.Match(fields[0], ""); err != nil {
+			return nil, fmt.Errorf("malformed pattern %s on line %d of target owners file %s", fields[0], line, file)
+		}
+
+		r := Rule{Pattern: fields[0], Teams: []string{}}
+		for _, team := range fields[1:] {
+			if strings.HasPrefix(team, "#") {
+				break
+			}
+			team = strings.TrimPrefix(team, "@")
+			r.Teams = append(r.Teams, team[strings.LastIndex(team, "/")+1:])
+		}
+		rules = append(rules, r)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Teams returns the set of all teams that own at least one target descriptor
+func (o *Ownership) Teams() set.Set[string] {
+	teams := set.NewSet[string]()
+	for _, r := range o.rules {
+		teams.Add(r.teams.Values()...)
+	}
+
+	return teams
+}
+
+// Owners returns the set of teams that own the given target descriptor
+func (o *Ownership) Owners(descriptor string) set.Set[string] {
+	teams := set.NewSet[string]()
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if matched, _ := path.Match(o.rules[i].pattern, descriptor); matched {
+			teams.Add(o.rules[i].teams.Values()...)
+			break
+		}
+	}
+
+	return teams
+}
+
+// OwnersOf returns the set of teams that own any target changed by the given RFC
+// Comments, annotations and loads are bookkeeping rather than changes, so their targets are ignored
+func (o *Ownership) OwnersOf(rfc *models.RFC) set.Set[string] {
+	teams := set.NewSet[string]()
+	for _, action := range rfc.Actions {
+		if action.ActionType == models.CommentAction || action.ActionType == models.AnnotationAction ||
+			action.ActionType == models.LoadAction {
+			continue
+		}
+		teams.Add(o.Owners(action.Target.TargetDescriptor).Values()...)
+	}
+
+	return teams
+}
@@ -0,0 +1,82 @@
+package ownership
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestOwnersOf(t *testing.T) {
+	// arrange
+	o := New(map[string][]string{
+		"EntityType": {"schema-admins"},
+		"Event":      {"events", "analytics"},
+		"Unused":     {"nobody"},
+	})
+	rfc := &models.RFC{Actions: models.Actions{
+		{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+		{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "Unowned"}},
+		{ActionType: models.CommentAction, Target: models.Target{TargetDescriptor: "Unused"}},
+		{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "Event"}},
+	}}
+
+	// act
+	owners := o.OwnersOf(rfc).Values()
+	sort.Strings(owners)
+
+	// assert
+	expected := []string{"analytics", "events", "schema-admins"}
+	if len(owners) != len(expected) {
+		t.Fatalf("unexpected owners. wanted %v, got %v", expected, owners)
+	}
+	for i := range expected {
+		if owners[i] != expected[i] {
+			t.Errorf("unexpected owners. wanted %v, got %v", expected, owners)
+		}
+	}
+	if o.Teams().Size() != 4 {
+		t.Errorf("unexpected teams. wanted %v, got %v", 4, o.Teams().Size())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// arrange
+	file := filepath.Join(t.TempDir(), "OWNERS")
+	content := `# catalog targets
+* @disney/schema-admins
+Event* @disney/events analytics # both teams review events
+EventDraft
+`
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	rules, err := Load(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	o := NewWithRules(rules, map[string][]string{"EventType": {"data"}})
+
+	// assert
+	testCases := map[string]string{
+		"EntityType": "[schema-admins]",
+		"Event":      "[analytics events]",
+		"EventType":  "[data]",
+		"EventDraft": "[]",
+	}
+	for descriptor, expected := range testCases {
+		owners := o.Owners(descriptor).Values()
+		sort.Strings(owners)
+		if fmt.Sprint(owners) != expected {
+			t.Errorf("unexpected owners of %s. wanted %v, got %v", descriptor, expected, owners)
+		}
+	}
+	if o.Teams().Size() != 4 {
+		t.Errorf("unexpected teams. wanted %v, got %v", 4, o.Teams().Size())
+	}
+}
@@ -0,0 +1,182 @@
+// Package quorum holds the approval policy, which requires a quorum of approvals of the RFCs changing each type of
+// target before they are merged, e.g. "2 approvals, at least one from team-data", whatever the branch protection of
+// the tracking repository requires
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// Rule requires a quorum of approvals of the RFCs changing a target of its type
+type Rule struct {
+	// TargetType is the type of the targets the rule applies to
+	TargetType models.TargetType `json:"targetType"`
+	// TargetDescriptor restricts the rule to the targets of the descriptor, the rule applies to every target of its
+	// type if empty
+	TargetDescriptor string `json:"targetDescriptor,omitempty"`
+	// Approvals is the number of approvals required
+	Approvals int `json:"approvals"`
+	// Teams is the number of approvals required of the members of each team, keyed by team slug. Approvals of members
+	// count towards Approvals too
+	Teams map[string]int `json:"teams,omitempty"`
+	// Systems are the external systems, e.g. change advisory board tools, whose approval is required. Approvals of
+	// external systems count towards Approvals too
+	Systems []string `json:"systems,omitempty"`
+}
+
+// Policy requires the quorum of every rule applying to an RFC, RFCs no rule applies to require no approval
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Default is the approval policy of the application, nil if merges only require what the branch protection requires
+var Default *Policy
+
+// NewPolicy returns the given policy once its rules are validated
+func NewPolicy(policy Policy) (*Policy, error) {
+	for i, rule := range policy.Rules {
+		switch rule.TargetType {
+		case models.ItemTarget, models.ActionTarget, models.RfcTarget:
+		default:
+			return nil, fmt.Errorf("rule %d applies to unknown target type %s", i, rule.TargetType)
+		}
+		if rule.Approvals < 0 {
+			return nil, fmt.Errorf("rule %d requires a negative number of approvals", i)
+		}
+		for team, approvals := range rule.Teams {
+			if approvals <= 0 {
+				return nil, fmt.Errorf("rule %d requires no approval of team %s", i, team)
+			}
+		}
+		for _, system := range rule.Systems {
+			if strings.TrimSpace(system) == "" {
+				return nil, fmt.Errorf("rule %d requires the approval of an unnamed external system", i)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// Load returns the policy of the given JSON file, a Policy object
+func Load(file string) (*Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read approval policy file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var policy Policy
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("malformed approval policy file %s: %w", file, err)
+	}
+
+	return NewPolicy(policy)
+}
+
+// RulesOf returns the rules applying to the given RFC, those of the type (and descriptor) of a target it changes
+// Comments, annotations, loads and external verdicts are bookkeeping rather than changes, so their targets are ignored
+func (p *Policy) RulesOf(rfc *models.RFC) []Rule {
+	rules := []Rule{}
+	for _, rule := range p.Rules {
+		for _, action := range rfc.Actions {
+			if action.ActionType == models.CommentAction || action.ActionType == models.AnnotationAction ||
+				action.ActionType == models.LoadAction || action.ActionType == models.ExternalApprovalAction {
+				continue
+			}
+			if action.Target.TargetType == rule.TargetType &&
+				(rule.TargetDescriptor == "" || action.Target.TargetDescriptor == rule.TargetDescriptor) {
+				rules = append(rules, rule)
+				break
+			}
+		}
+	}
+
+	return rules
+}
+
+// Systems returns the external systems the rules of the policy require approvals of
+func (p *Policy) Systems() set.Set[string] {
+	systems := set.NewSet[string]()
+	for _, rule := range p.Rules {
+		for _, system := range rule.Systems {
+			systems.Add(system)
+		}
+	}
+
+	return systems
+}
+
+// Teams returns the teams the given rules require approvals of
+func Teams(rules []Rule) set.Set[string] {
+	teams := set.NewSet[string]()
+	for _, rule := range rules {
+		for team := range rule.Teams {
+			teams.Add(team)
+		}
+	}
+
+	return teams
+}
+
+// Evaluate returns ErrQuorumNotMet (wrapped), naming every requirement that is not met, unless the given approvers
+// and approving external systems meet the quorum of each of the given rules. Members are the members of each team the
+// rules require approvals of
+func Evaluate(rules []Rule, approvers set.Set[string], systems set.Set[string],
+	members map[string]set.Set[string]) error {
+	unmet := []string{}
+	for _, rule := range rules {
+		if given := approvers.Size() + systems.Size(); given < rule.Approvals {
+			unmet = append(unmet, fmt.Sprintf("%d approvals required, %d given", rule.Approvals, given))
+		}
+		for _, system := range rule.Systems {
+			if !systems.Contains(system) {
+				unmet = append(unmet, fmt.Sprintf("approval of external system %s required", system))
+			}
+		}
+
+		teams := make([]string, 0, len(rule.Teams))
+		for team := range rule.Teams {
+			teams = append(teams, team)
+		}
+		sort.Strings(teams)
+		for _, team := range teams {
+			given := 0
+			if teamMembers, ok := members[team]; ok {
+				given = approvers.Intersect(teamMembers).Size()
+			}
+			if given < rule.Teams[team] {
+				unmet = append(unmet, fmt.Sprintf("%d approvals of team %s required, %d given", rule.Teams[team],
+					team, given))
+			}
+		}
+	}
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", models.ErrQuorumNotMet, strings.Join(dedupe(unmet), ", "))
+}
+
+// dedupe returns the given requirements without repetitions, rules applying to several targets of an RFC may require
+// the same quorum
+func dedupe(requirements []string) []string {
+	seen := set.NewSet[string]()
+	deduped := []string{}
+	for _, requirement := range requirements {
+		if !seen.Contains(requirement) {
+			seen.Add(requirement)
+			deduped = append(deduped, requirement)
+		}
+	}
+
+	return deduped
+}
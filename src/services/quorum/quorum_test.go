@@ -0,0 +1,106 @@
+package quorum
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/set"
+)
+
+// TestEvaluate tests that RFCs are required the quorum of every rule applying to the targets they change
+func TestEvaluate(t *testing.T) {
+	// arrange
+	policy, err := NewPolicy(Policy{Rules: []Rule{
+		{TargetType: models.ItemTarget, Approvals: 2, Teams: map[string]int{"team-data": 1}},
+		{TargetType: models.ItemTarget, TargetDescriptor: "EntityType", Approvals: 1,
+			Teams: map[string]int{"schema-admins": 1}},
+		{TargetType: models.ItemTarget, TargetDescriptor: "Table", Systems: []string{"cab"}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	members := map[string]set.Set[string]{
+		"team-data":     set.NewSetOf("pparker", "tstark"),
+		"schema-admins": set.NewSetOf("srogers"),
+	}
+	event := &models.RFC{Actions: models.Actions{
+		{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Event"}},
+	}}
+	entity := &models.RFC{Actions: models.Actions{
+		{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget,
+			TargetDescriptor: "EntityType"}},
+	}}
+	table := &models.RFC{Actions: models.Actions{
+		{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget, TargetDescriptor: "Table"}},
+	}}
+	comment := &models.RFC{Actions: models.Actions{
+		{ActionType: models.CommentAction, Target: models.Target{TargetType: models.ItemTarget,
+			TargetDescriptor: "EntityType"}},
+	}}
+
+	testCases := []struct {
+		name      string
+		rfc       *models.RFC
+		approvers set.Set[string]
+		systems   set.Set[string]
+		unmet     string
+	}{
+		{"met", event, set.NewSetOf("pparker", "nromanoff"), set.NewSet[string](), ""},
+		{"too few approvals", event, set.NewSetOf("pparker"), set.NewSet[string](), "2 approvals required, 1 given"},
+		{"no team approval", event, set.NewSetOf("nromanoff", "bbanner"), set.NewSet[string](),
+			"1 approvals of team team-data required"},
+		{"descriptor rule", entity, set.NewSetOf("pparker", "tstark"), set.NewSet[string](),
+			"1 approvals of team schema-admins required"},
+		{"every rule met", entity, set.NewSetOf("pparker", "srogers"), set.NewSet[string](), ""},
+		{"bookkeeping only", comment, set.NewSet[string](), set.NewSet[string](), ""},
+		{"external approval counts", table, set.NewSetOf("pparker"), set.NewSetOf("cab"), ""},
+		{"external approval required", table, set.NewSetOf("pparker", "tstark"), set.NewSetOf("itsm"),
+			"approval of external system cab required"},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		err := Evaluate(policy.RulesOf(test.rfc), test.approvers, test.systems, members)
+		if test.unmet == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if test.unmet != "" && (!errors.Is(err, models.ErrQuorumNotMet) ||
+			!strings.Contains(err.Error(), test.unmet)) {
+			t.Errorf("%s: expected %q to be unmet, got %v", test.name, test.unmet, err)
+		}
+	}
+	if teams := Teams(policy.RulesOf(entity)); teams.Size() != 2 {
+		t.Errorf("unexpected teams: %v", teams)
+	}
+}
+
+// TestLoad tests that policies are loaded from JSON files and invalid rules are rejected
+func TestLoad(t *testing.T) {
+	// arrange
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(valid, []byte(`{"rules": [{"targetType": "item", "approvals": 2,
+		"teams": {"team-data": 1}}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte(`{"rules": [{"targetType": "schema", "approvals": 2}]}`),
+		0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// act
+	policy, validErr := Load(valid)
+	_, invalidErr := Load(invalid)
+
+	// assert
+	if validErr != nil || len(policy.Rules) != 1 || policy.Rules[0].Teams["team-data"] != 1 {
+		t.Errorf("unexpected policy: %+v, err: %v", policy, validErr)
+	}
+	if invalidErr == nil {
+		t.Errorf("expected an unknown target type to be rejected")
+	}
+}
@@ -0,0 +1,328 @@
+// Package rendering renders RFCs as Markdown or HTML documents for human review: the proposed actions grouped by
+// action type, each followed by the comments and annotations made on it, threaded as they were replied to
+package rendering
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Formats RFCs can be rendered in
+const (
+	MARKDOWN_FORMAT string = "markdown"
+	HTML_FORMAT     string = "html"
+)
+
+// Note is a comment or an annotation made on an action, or on the RFC as a whole, along with the replies to it
+type Note struct {
+	// Author is the commenter of a comment, the analyzer of an annotation
+	Author string
+	// Text is the text of a comment, the severity and message of an annotation
+	Text string
+	// Flagged holds the reasons a comment was flagged for by the comment filters, empty if it was not
+	Flagged string
+	Deleted bool
+	Replies []Note
+}
+
+// Entry is a proposed action, its data being indented JSON, along with the notes made on it
+type Entry struct {
+	Target string
+	Data   string
+	Notes  []Note
+}
+
+// Group holds the proposed actions of an action type, in the order they appear in the RFC
+type Group struct {
+	ActionType string
+	Entries    []Entry
+}
+
+// Document is an RFC laid out for human review
+type Document struct {
+	Identifier   string
+	Title        string
+	Domain       string
+	Priority     string
+	EmbargoUntil string
+	LoadTargets  []string
+	LoadStatus   string
+	Groups       []Group
+	// Discussion holds the notes made on the RFC as a whole, or on actions it no longer holds
+	Discussion []Note
+	// Activity holds the withdrawals and break glass merges of the RFC, in the order they were recorded
+	Activity []string
+}
+
+// NewDocument lays the RFC of the given identifier out for human review
+func NewDocument(identifier string, rfc *models.RFC) Document {
+	document := Document{
+		Identifier:  identifier,
+		Title:       strings.TrimSpace(rfc.Title),
+		Domain:      rfc.Domain,
+		Priority:    string(rfc.Priority),
+		LoadTargets: rfc.LoadTargets,
+	}
+	if rfc.EmbargoUntil != nil {
+		document.EmbargoUntil = rfc.EmbargoUntil.UTC().Format(time.RFC3339)
+	}
+	if status := rfc.GetLoadStatus(); status != nil {
+		document.LoadStatus = *status
+	}
+
+	// notes are threaded under the action, or the comment, they target
+	replies := map[string][]*models.Action{}
+	for _, action := range rfc.Actions {
+		if action.ActionType == models.CommentAction || action.ActionType == models.AnnotationAction {
+			replies[action.Target.LookupValue] = append(replies[action.Target.LookupValue], action)
+		}
+	}
+	threaded := map[*models.Action]bool{}
+	var thread func(signature string) []Note
+	thread = func(signature string) []Note {
+		var notes []Note
+		for _, action := range replies[signature] {
+			if threaded[action] {
+				continue
+			}
+			threaded[action] = true
+			note := newNote(action)
+			if action.Signature != "" {
+				note.Replies = thread(action.Signature)
+			}
+			notes = append(notes, note)
+		}
+		return notes
+	}
+
+	groups := map[models.ActionType]int{}
+	for _, action := range rfc.Actions {
+		switch {
+		case action.IsProposal():
+			i, ok := groups[action.ActionType]
+			if !ok {
+				i = len(document.Groups)
+				groups[action.ActionType] = i
+				document.Groups = append(document.Groups, Group{ActionType: string(action.ActionType)})
+			}
+			entry := Entry{Target: target(action.Target)}
+			if len(action.Data) > 0 {
+				if data, err := json.MarshalIndent(action.Data, "", "  "); err == nil {
+					entry.Data = string(data)
+				}
+			}
+			if action.Signature != "" {
+				entry.Notes = thread(action.Signature)
+			}
+			document.Groups[i].Entries = append(document.Groups[i].Entries, entry)
+		case action.ActionType == models.WithdrawnAction:
+			activity := fmt.Sprintf("Withdrawn by %v at %v", action.Data[string(models.WithdrawerData)],
+				action.Data[string(models.WithdrawnAtData)])
+			if reason, ok := action.Data[string(models.ReasonData)]; ok {
+				activity += fmt.Sprintf(": %v", reason)
+			}
+			document.Activity = append(document.Activity, activity)
+		case action.ActionType == models.BreakGlassAction:
+			document.Activity = append(document.Activity, fmt.Sprintf("Break glass by %v at %v: %v",
+				action.Data[string(models.ForcedByData)], action.Data[string(models.ForcedAtData)],
+				action.Data[string(models.JustificationData)]))
+		}
+	}
+
+	// whatever is left targets the RFC as a whole, or actions it no longer holds
+	for _, action := range rfc.Actions {
+		if (action.ActionType == models.CommentAction || action.ActionType == models.AnnotationAction) &&
+			!threaded[action] {
+			threaded[action] = true
+			note := newNote(action)
+			if action.Signature != "" {
+				note.Replies = thread(action.Signature)
+			}
+			document.Discussion = append(document.Discussion, note)
+		}
+	}
+
+	return document
+}
+
+// newNote returns the note of the given comment or annotation action, without its replies
+func newNote(action *models.Action) Note {
+	if action.ActionType == models.AnnotationAction {
+		return Note{
+			Author: fmt.Sprint(action.Data[string(models.AnalyzerData)]),
+			Text: fmt.Sprintf("[%v] %v", action.Data[string(models.SeverityData)],
+				action.Data[string(models.MessageData)]),
+		}
+	}
+
+	note := Note{Author: fmt.Sprint(action.Data[string(models.CommenterData)])}
+	if deleted, _ := action.Data[string(models.DeletedData)].(bool); deleted {
+		note.Deleted = true
+		return note
+	}
+	note.Text = fmt.Sprint(action.Data[string(models.CommentData)])
+	if flagged, ok := action.Data[string(models.FlaggedData)]; ok {
+		note.Flagged = fmt.Sprint(flagged)
+	}
+	return note
+}
+
+// target returns a one line description of the given target, e.g. "item Event (name=MyNewEvent)"
+func target(target models.Target) string {
+	description := strings.TrimSpace(fmt.Sprintf("%s %s", target.TargetType, target.TargetDescriptor))
+	if target.LookupKey != "" {
+		description += fmt.Sprintf(" (%s=%s)", target.LookupKey, target.LookupValue)
+	}
+	return description
+}
+
+// Render returns the RFC of the given identifier rendered in the given format, one of the *_FORMAT constants
+func Render(identifier string, rfc *models.RFC, format string) (string, error) {
+	switch format {
+	case MARKDOWN_FORMAT:
+		return Markdown(NewDocument(identifier, rfc)), nil
+	case HTML_FORMAT:
+		return HTML(NewDocument(identifier, rfc))
+	default:
+		return "", fmt.Errorf("unknown render format %s, expected %s or %s", format, MARKDOWN_FORMAT, HTML_FORMAT)
+	}
+}
+
+// Markdown returns the given document in Markdown
+func Markdown(document Document) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# RFC %s\n", document.Identifier)
+	if document.Title != "" {
+		fmt.Fprintf(&b, "\n**%s**\n", document.Title)
+	}
+
+	var details []string
+	for _, detail := range [][2]string{{"Domain", document.Domain}, {"Priority", document.Priority},
+		{"Embargoed until", document.EmbargoUntil}, {"Load targets", strings.Join(document.LoadTargets, ", ")},
+		{"Load status", document.LoadStatus}} {
+		if detail[1] != "" {
+			details = append(details, fmt.Sprintf("- %s: %s\n", detail[0], detail[1]))
+		}
+	}
+	if len(details) > 0 {
+		b.WriteString("\n" + strings.Join(details, ""))
+	}
+
+	for _, group := range document.Groups {
+		fmt.Fprintf(&b, "\n## %s\n", group.ActionType)
+		for _, entry := range group.Entries {
+			fmt.Fprintf(&b, "\n### %s\n", entry.Target)
+			if entry.Data != "" {
+				fmt.Fprintf(&b, "\n```json\n%s\n```\n", entry.Data)
+			}
+			if len(entry.Notes) > 0 {
+				b.WriteString("\n")
+				writeNotes(&b, entry.Notes, "")
+			}
+		}
+	}
+
+	if len(document.Discussion) > 0 {
+		b.WriteString("\n## Discussion\n\n")
+		writeNotes(&b, document.Discussion, "")
+	}
+	if len(document.Activity) > 0 {
+		b.WriteString("\n## Activity\n\n")
+		for _, activity := range document.Activity {
+			fmt.Fprintf(&b, "- %s\n", activity)
+		}
+	}
+
+	return b.String()
+}
+
+// writeNotes writes the given notes as a Markdown list at the given indentation, replies being nested under the note
+// they reply to
+func writeNotes(b *strings.Builder, notes []Note, indent string) {
+	for _, note := range notes {
+		text := note.Text
+		if note.Deleted {
+			text = "_deleted_"
+		}
+		text = strings.ReplaceAll(strings.TrimSpace(text), "\n", "\n"+indent+"  ")
+		fmt.Fprintf(b, "%s- **%s**: %s\n", indent, note.Author, text)
+		if note.Flagged != "" {
+			fmt.Fprintf(b, "%s  _flagged: %s_\n", indent, note.Flagged)
+		}
+		writeNotes(b, note.Replies, indent+"  ")
+	}
+}
+
+// htmlTemplate lays documents out in HTML, escaping their contents
+var htmlTemplate = template.Must(template.New("rfc").Parse(`{{define "notes"}}<ul>
+{{- range .}}
+<li><strong>{{.Author}}</strong>: {{if .Deleted}}<em>deleted</em>{{else}}{{.Text}}{{end}}
+{{- with .Flagged}} <em>(flagged: {{.}})</em>{{end}}
+{{- with .Replies}}{{template "notes" .}}{{end}}</li>
+{{- end}}
+</ul>{{end -}}
+<article>
+<h1>RFC {{.Identifier}}</h1>
+{{- with .Title}}
+<p><strong>{{.}}</strong></p>
+{{- end}}
+{{- if or .Domain .Priority .EmbargoUntil .LoadTargets .LoadStatus}}
+<ul>
+{{- with .Domain}}
+<li>Domain: {{.}}</li>
+{{- end}}
+{{- with .Priority}}
+<li>Priority: {{.}}</li>
+{{- end}}
+{{- with .EmbargoUntil}}
+<li>Embargoed until: {{.}}</li>
+{{- end}}
+{{- range .LoadTargets}}
+<li>Load target: {{.}}</li>
+{{- end}}
+{{- with .LoadStatus}}
+<li>Load status: {{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range .Groups}}
+<h2>{{.ActionType}}</h2>
+{{- range .Entries}}
+<h3>{{.Target}}</h3>
+{{- with .Data}}
+<pre><code>{{.}}</code></pre>
+{{- end}}
+{{- with .Notes}}
+{{template "notes" .}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Discussion}}
+<h2>Discussion</h2>
+{{template "notes" .}}
+{{- end}}
+{{- with .Activity}}
+<h2>Activity</h2>
+<ul>
+{{- range .}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</article>
+`))
+
+// HTML returns the given document in HTML, as an article element to be embedded in a page
+func HTML(document Document) (string, error) {
+	var rendered bytes.Buffer
+	if err := htmlTemplate.Execute(&rendered, document); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,52 @@
+package rendering
+
+import (
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestRender(t *testing.T) {
+	// arrange
+	rfc := &models.RFC{
+		Title:    "Add EventType <X>",
+		Priority: models.HighPriority,
+		Actions: models.Actions{
+			{ActionType: models.AddAction, Target: models.Target{TargetType: models.ItemTarget,
+				TargetDescriptor: "EventType", LookupKey: "name", LookupValue: "X"},
+				Data: map[string]interface{}{"id": "X"}, Signature: "add-sha"},
+			{ActionType: models.CommentAction, Target: models.Target{TargetType: models.ActionTarget,
+				LookupKey: models.SignatureLookupKey, LookupValue: "add-sha"}, Signature: "comment-sha",
+				Data: map[string]interface{}{"commenter": "tstark", "comment": "why X?"}},
+			{ActionType: models.CommentAction, Target: models.Target{TargetType: models.ActionTarget,
+				LookupKey: models.SignatureLookupKey, LookupValue: "comment-sha"}, Signature: "reply-sha",
+				Data: map[string]interface{}{"commenter": "pparker", "comment": "because"}},
+			{ActionType: models.CommentAction, Target: models.Target{TargetType: models.RfcTarget,
+				LookupKey: models.SignatureLookupKey, LookupValue: "rfc-sha"}, Signature: "general-sha",
+				Data: map[string]interface{}{"commenter": "tstark", "comment": "ship it"}},
+		},
+	}
+	expected := "# RFC 1234\n\n**Add EventType <X>**\n\n- Priority: high\n\n## add\n\n### item EventType (name=X)\n\n" +
+		"```json\n{\n  \"id\": \"X\"\n}\n```\n\n- **tstark**: why X?\n  - **pparker**: because\n\n" +
+		"## Discussion\n\n- **tstark**: ship it\n"
+
+	// act
+	markdown, markdownErr := Render("1234", rfc, MARKDOWN_FORMAT)
+	html, htmlErr := Render("1234", rfc, HTML_FORMAT)
+	_, unknownErr := Render("1234", rfc, "pdf")
+
+	// assert
+	if markdownErr != nil || htmlErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", markdownErr, htmlErr)
+	}
+	if markdown != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, markdown)
+	}
+	if !strings.Contains(html, "Add EventType &lt;X&gt;") || !strings.Contains(html, "<strong>pparker</strong>") {
+		t.Errorf("expected escaped HTML with threaded comments, got:\n%s", html)
+	}
+	if unknownErr == nil {
+		t.Errorf("expected an unknown format to be rejected")
+	}
+}
@@ -0,0 +1,39 @@
+// Package reservations holds the stores the reservations of target descriptors are kept in, the claims reserve actions
+// make on the descriptors and namespaces of a schema domain once their RFC is loaded
+// This is strictly to hold the Store interface definition and common constants used in store interactions
+package reservations
+
+import (
+	"context"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used across all Store implementations
+const (
+	// DEFAULT_TTL is how long reservations last unless their reserve action sets their expiry
+	DEFAULT_TTL = 90 * 24 * time.Hour
+)
+
+// Store defines all methods necessary for keeping reservations
+type Store interface {
+	// Save records the given reservation, replacing any previously recorded for the same target descriptor of the same
+	// schema domain
+	Save(ctx context.Context, reservation models.Reservation) error
+	// List returns every reservation, expired ones included, oldest first
+	List(ctx context.Context) ([]models.Reservation, error)
+	// Delete drops the reservation of the given target descriptor of the given schema domain, once it was released
+	Delete(ctx context.Context, domain string, descriptor string) error
+}
+
+// Default is the store shared by the application
+var Default Store = NewMemoryStore()
+
+// TTL is how long reservations last unless their reserve action sets their expiry
+var TTL = DEFAULT_TTL
+
+// key returns the key of the reservation of the given target descriptor of the given schema domain
+func key(domain string, descriptor string) string {
+	return domain + "/" + descriptor
+}
@@ -0,0 +1,108 @@
+// This is the local file implementation of the Store interface found in definition.go
+package reservations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"harmonia-example.io/src/models"
+)
+
+// FileStore type implements the Store interface by keeping reservations in memory and writing them all to a JSON file
+// on every change, so they survive restarts of a single instance
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore returns a FileStore writing to the given file, reading the reservations it already holds if it exists
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read reservation file %s: %w", path, err)
+	}
+	if err = json.Unmarshal(content, &store.reservations); err != nil {
+		return nil, fmt.Errorf("malformed reservation file %s: %w", path, err)
+	}
+	if store.reservations == nil {
+		store.reservations = map[string]models.Reservation{}
+	}
+
+	return store, nil
+}
+
+// Save records the given reservation and writes every reservation to the file
+func (s *FileStore) Save(ctx context.Context, reservation models.Reservation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(reservation.Domain, reservation.TargetDescriptor)
+	previous, existed := s.reservations[k]
+	s.reservations[k] = reservation
+	if err := s.write(); err != nil {
+		// keep memory consistent with the file
+		if existed {
+			s.reservations[k] = previous
+		} else {
+			delete(s.reservations, k)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete drops the reservation of the given target descriptor of the given schema domain and writes every remaining
+// reservation to the file
+func (s *FileStore) Delete(ctx context.Context, domain string, descriptor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(domain, descriptor)
+	previous, existed := s.reservations[k]
+	if !existed {
+		return nil
+	}
+	delete(s.reservations, k)
+	if err := s.write(); err != nil {
+		s.reservations[k] = previous
+		return err
+	}
+
+	return nil
+}
+
+// write writes every reservation to a temporary file and moves it over the file, the caller must hold the lock
+func (s *FileStore) write() error {
+	content, err := json.Marshal(s.reservations)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write reservation file %s: %w", s.path, err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("unable to write reservation file %s: %w", s.path, err)
+	}
+	if err = temp.Close(); err != nil {
+		return fmt.Errorf("unable to write reservation file %s: %w", s.path, err)
+	}
+	if err = os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to write reservation file %s: %w", s.path, err)
+	}
+
+	return nil
+}
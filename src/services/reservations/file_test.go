@@ -0,0 +1,42 @@
+package reservations
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestFileStore(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "reservations.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	reservedAt := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	playback := models.Reservation{Domain: "catalog", TargetDescriptor: "playback.*", Team: "playback",
+		RFCIdentifier: "123", ReservedAt: reservedAt, ExpiresAt: reservedAt.Add(DEFAULT_TTL)}
+	search := models.Reservation{TargetDescriptor: "Search", Team: "search", RFCIdentifier: "456",
+		ReservedAt: reservedAt.Add(time.Hour), ExpiresAt: reservedAt.Add(DEFAULT_TTL)}
+
+	// act
+	saveErr := store.Save(context.Background(), search)
+	if err = store.Save(context.Background(), playback); saveErr == nil {
+		saveErr = err
+	}
+	deleteErr := store.Delete(context.Background(), "", "Search")
+	reopened, reopenErr := NewFileStore(path)
+	reservations, listErr := reopened.List(context.Background())
+
+	// assert
+	if saveErr != nil || deleteErr != nil || reopenErr != nil || listErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", saveErr, deleteErr, reopenErr, listErr)
+	}
+	if len(reservations) != 1 || reservations[0].TargetDescriptor != "playback.*" ||
+		reservations[0].Domain != "catalog" || !reservations[0].ExpiresAt.Equal(playback.ExpiresAt) {
+		t.Errorf("unexpected reservations read back from the file: %+v", reservations)
+	}
+}
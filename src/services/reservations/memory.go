@@ -0,0 +1,55 @@
+// This is the in-memory implementation of the Store interface found in definition.go
+package reservations
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// MemoryStore type implements the Store interface by keeping reservations in memory, they are lost when the service
+// stops
+type MemoryStore struct {
+	mu           sync.RWMutex
+	reservations map[string]models.Reservation
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{reservations: map[string]models.Reservation{}}
+}
+
+// Save records the given reservation, replacing any previously recorded for the same target descriptor
+func (s *MemoryStore) Save(ctx context.Context, reservation models.Reservation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reservations[key(reservation.Domain, reservation.TargetDescriptor)] = reservation
+	return nil
+}
+
+// List returns every reservation, expired ones included, oldest first
+func (s *MemoryStore) List(ctx context.Context) ([]models.Reservation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reservations := make([]models.Reservation, 0, len(s.reservations))
+	for _, reservation := range s.reservations {
+		reservations = append(reservations, reservation)
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].ReservedAt.Before(reservations[j].ReservedAt)
+	})
+	return reservations, nil
+}
+
+// Delete drops the reservation of the given target descriptor of the given schema domain
+func (s *MemoryStore) Delete(ctx context.Context, domain string, descriptor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.reservations, key(domain, descriptor))
+	return nil
+}
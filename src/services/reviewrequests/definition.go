@@ -0,0 +1,44 @@
+// Package reviewrequests holds the stores the review requests of RFCs are kept in, along with the first response of
+// their reviewers, so review workload and response times can be reported against the review SLA
+// This is strictly to hold the Store interface definition and common constants used in store interactions
+package reviewrequests
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used across all Store implementations
+const (
+	// DEFAULT_SLA is how long reviewers have to first respond to a review request unless configured otherwise
+	DEFAULT_SLA = 48 * time.Hour
+	// ESCALATION_FACTOR is how many SLAs a review request is awaited for before it is escalated
+	ESCALATION_FACTOR = 2
+	// WINDOW is the period review requests are reported over, older ones are dropped once responded to
+	WINDOW = 30 * 24 * time.Hour
+)
+
+// Store defines all methods necessary for keeping review requests
+type Store interface {
+	// Save records the given review request, replacing any previously recorded for the same reviewer or team of the
+	// same RFC
+	Save(ctx context.Context, request models.ReviewRequest) error
+	// List returns every review request, oldest first
+	List(ctx context.Context) ([]models.ReviewRequest, error)
+	// Delete drops the given review request, once it no longer needs to be reported
+	Delete(ctx context.Context, request models.ReviewRequest) error
+}
+
+// Default is the store shared by the application
+var Default Store = NewMemoryStore()
+
+// SLA is how long reviewers have to first respond to a review request
+var SLA = DEFAULT_SLA
+
+// key returns the key of the given review request, reviewers and teams may share a name
+func key(request models.ReviewRequest) string {
+	return request.Domain + "/" + request.RFCIdentifier + "/" + strconv.FormatBool(request.Team) + "/" + request.Reviewer
+}
@@ -0,0 +1,107 @@
+// This is the local file implementation of the Store interface found in definition.go
+package reviewrequests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"harmonia-example.io/src/models"
+)
+
+// FileStore type implements the Store interface by keeping review requests in memory and writing them all to a JSON
+// file on every change, so they survive restarts of a single instance
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore returns a FileStore writing to the given file, reading the review requests it already holds if it exists
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read review request file %s: %w", path, err)
+	}
+	if err = json.Unmarshal(content, &store.requests); err != nil {
+		return nil, fmt.Errorf("malformed review request file %s: %w", path, err)
+	}
+	if store.requests == nil {
+		store.requests = map[string]models.ReviewRequest{}
+	}
+
+	return store, nil
+}
+
+// Save records the given review request and writes every review request to the file
+func (s *FileStore) Save(ctx context.Context, request models.ReviewRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(request)
+	previous, existed := s.requests[k]
+	s.requests[k] = request
+	if err := s.write(); err != nil {
+		// keep memory consistent with the file
+		if existed {
+			s.requests[k] = previous
+		} else {
+			delete(s.requests, k)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Delete drops the given review request and writes every remaining review request to the file
+func (s *FileStore) Delete(ctx context.Context, request models.ReviewRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(request)
+	previous, existed := s.requests[k]
+	if !existed {
+		return nil
+	}
+	delete(s.requests, k)
+	if err := s.write(); err != nil {
+		s.requests[k] = previous
+		return err
+	}
+
+	return nil
+}
+
+// write writes every review request to a temporary file and moves it over the file, the caller must hold the lock
+func (s *FileStore) write() error {
+	content, err := json.Marshal(s.requests)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write review request file %s: %w", s.path, err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("unable to write review request file %s: %w", s.path, err)
+	}
+	if err = temp.Close(); err != nil {
+		return fmt.Errorf("unable to write review request file %s: %w", s.path, err)
+	}
+	if err = os.Rename(temp.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to write review request file %s: %w", s.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package reviewrequests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestFileStore(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "review-requests.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	requestedAt := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	respondedAt := requestedAt.Add(time.Hour)
+	reviewer := models.ReviewRequest{Domain: "catalog", RFCIdentifier: "123", Reviewer: "playback",
+		RequestedAt: requestedAt, RespondedAt: &respondedAt, Responder: "playback"}
+	team := models.ReviewRequest{Domain: "catalog", RFCIdentifier: "123", Reviewer: "playback", Team: true,
+		RequestedAt: requestedAt}
+	other := models.ReviewRequest{RFCIdentifier: "456", Reviewer: "tstark", RequestedAt: requestedAt.Add(time.Hour)}
+
+	// act
+	var saveErr error
+	for _, request := range []models.ReviewRequest{other, team, reviewer} {
+		if err = store.Save(context.Background(), request); saveErr == nil {
+			saveErr = err
+		}
+	}
+	deleteErr := store.Delete(context.Background(), other)
+	reopened, reopenErr := NewFileStore(path)
+	requests, listErr := reopened.List(context.Background())
+
+	// assert
+	if saveErr != nil || deleteErr != nil || reopenErr != nil || listErr != nil {
+		t.Fatalf("unexpected errors: %v, %v, %v, %v", saveErr, deleteErr, reopenErr, listErr)
+	}
+	if len(requests) != 2 || requests[0].Team || requests[0].RespondedAt == nil ||
+		!requests[0].RespondedAt.Equal(respondedAt) || !requests[1].Team || requests[1].RespondedAt != nil {
+		t.Errorf("unexpected review requests read back from the file: %+v", requests)
+	}
+}
@@ -0,0 +1,58 @@
+// This is the in-memory implementation of the Store interface found in definition.go
+package reviewrequests
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"harmonia-example.io/src/models"
+)
+
+// MemoryStore type implements the Store interface by keeping review requests in memory, they are lost when the service
+// stops
+type MemoryStore struct {
+	mu       sync.RWMutex
+	requests map[string]models.ReviewRequest
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{requests: map[string]models.ReviewRequest{}}
+}
+
+// Save records the given review request, replacing any previously recorded for the same reviewer of the same RFC
+func (s *MemoryStore) Save(ctx context.Context, request models.ReviewRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[key(request)] = request
+	return nil
+}
+
+// List returns every review request, oldest first
+func (s *MemoryStore) List(ctx context.Context) ([]models.ReviewRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	requests := make([]models.ReviewRequest, 0, len(s.requests))
+	for _, request := range s.requests {
+		requests = append(requests, request)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if !requests[i].RequestedAt.Equal(requests[j].RequestedAt) {
+			return requests[i].RequestedAt.Before(requests[j].RequestedAt)
+		}
+		return key(requests[i]) < key(requests[j])
+	})
+	return requests, nil
+}
+
+// Delete drops the given review request
+func (s *MemoryStore) Delete(ctx context.Context, request models.ReviewRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.requests, key(request))
+	return nil
+}
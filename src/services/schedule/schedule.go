@@ -0,0 +1,59 @@
+// Package schedule holds helpers for running recurring background jobs
+package schedule
+
+import (
+	"time"
+)
+
+// Daily runs the given job every day at the given offset from midnight UTC until the returned function is called
+// Jobs run one at a time, a run that overlaps the next occurrence delays it rather than running concurrently
+func Daily(offset time.Duration, job func()) func() {
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(nextDaily(time.Now(), offset)))
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+				job()
+			}
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+// Every runs the given job every given interval, starting one interval from now, until the returned function is called
+// Jobs run one at a time, a run that overlaps the next occurrence delays it rather than running concurrently
+func Every(interval time.Duration, job func()) func() {
+	stop := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				job()
+			}
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+// nextDaily returns the first time strictly after now that is the given offset from midnight UTC
+func nextDaily(now time.Time, offset time.Duration) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(offset)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
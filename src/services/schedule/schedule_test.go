@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDaily(t *testing.T) {
+	// arrange
+	offset := 9 * time.Hour
+	testCases := []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		// later today
+		{time.Date(2022, 6, 1, 8, 0, 0, 0, time.UTC), time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)},
+		// exactly now runs tomorrow
+		{time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC), time.Date(2022, 6, 2, 9, 0, 0, 0, time.UTC)},
+		// across month boundaries
+		{time.Date(2022, 6, 30, 10, 0, 0, 0, time.UTC), time.Date(2022, 7, 1, 9, 0, 0, 0, time.UTC)},
+		// non UTC times are normalized
+		{time.Date(2022, 6, 1, 8, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+			time.Date(2022, 6, 2, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		// act
+		actual := nextDaily(testCase.now, offset)
+
+		// assert
+		if !actual.Equal(testCase.expected) {
+			t.Errorf("unexpected next run for %v. wanted %v, got %v", testCase.now, testCase.expected, actual)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	// arrange
+	runs := make(chan struct{}, 10)
+
+	// act
+	stop := Every(time.Millisecond, func() { runs <- struct{}{} })
+	for i := 0; i < 2; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("expected the job to run every interval")
+		}
+	}
+	stop()
+	time.Sleep(5 * time.Millisecond)
+	for len(runs) > 0 {
+		<-runs
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// assert
+	if len(runs) != 0 {
+		t.Errorf("expected the job not to run once stopped, ran %d more times", len(runs))
+	}
+}
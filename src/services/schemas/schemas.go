@@ -0,0 +1,147 @@
+// Package schemas holds the registry of the JSON Schemas the data of RFC actions is validated against. Schemas are
+// files of the schema directory of a tracking repository, named after the action type and target type of the actions
+// they apply to, e.g. "add.item.json". Other files of the directory can be referenced from them with $ref
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"harmonia-example.io/src/models"
+)
+
+// Common constants used by registries
+const (
+	// the extension of schema files
+	SCHEMA_FILE_EXTENSION string = ".json"
+	// the base URL schema files are resolved against, so they can reference each other by file name
+	baseURL string = "harmonia:///schemas/"
+)
+
+// Directory is the directory of the tracking repositories holding the schemas, empty if action data is not validated
+var Directory string
+
+// Registry holds the compiled schemas of a tracking repository, keyed by action type and target type
+type Registry struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// key returns the key of the schema of the given action type and target type, its file name without extension
+func key(actionType models.ActionType, targetType models.TargetType) string {
+	return fmt.Sprintf("%s.%s", actionType, targetType)
+}
+
+// Compile returns the Registry of the given schema files, keyed by file name. Files that cannot be compiled are
+// left out of the registry, and the errors they failed with are returned along with it
+func Compile(files map[string]string) (*Registry, map[string]error) {
+	compiler := jsonschema.NewCompiler()
+	// schemas may only reference files of the schema directory
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("%s is not a file of the schema directory", url)
+	}
+
+	failures := map[string]error{}
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		if err := compiler.AddResource(baseURL+name, strings.NewReader(content)); err != nil {
+			failures[name] = err
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	registry := &Registry{schemas: map[string]*jsonschema.Schema{}}
+	for _, name := range names {
+		schema, err := compiler.Compile(baseURL + name)
+		if err != nil {
+			failures[name] = err
+			continue
+		}
+		registry.schemas[strings.TrimSuffix(name, SCHEMA_FILE_EXTENSION)] = schema
+	}
+
+	return registry, failures
+}
+
+// Size returns the number of schemas of the registry, referenced files included
+func (r *Registry) Size() int {
+	return len(r.schemas)
+}
+
+// Validate returns an error for each field of the data of the proposed actions of the given RFC that does not conform
+// to the schema of the action type and target type of its action. Actions without a schema are not validated, and
+// actions without data are validated as an empty object
+func (r *Registry) Validate(rfc *models.RFC) []models.ValidationError {
+	var errs []models.ValidationError
+	for i, action := range rfc.Actions {
+		if action == nil || !action.IsProposal() {
+			continue
+		}
+		schema, ok := r.schemas[key(action.ActionType, action.Target.TargetType)]
+		if !ok {
+			continue
+		}
+
+		// the schema sees the data as it is serialized in the RFC file
+		data := interface{}(map[string]interface{}{})
+		if len(action.Data) > 0 {
+			content, err := json.Marshal(action.Data)
+			if err == nil {
+				err = json.Unmarshal(content, &data)
+			}
+			if err != nil {
+				errs = append(errs, models.ValidationError{Field: fmt.Sprintf("actions[%d].data", i),
+					Message: err.Error()})
+				continue
+			}
+		}
+
+		err := schema.Validate(data)
+		if validationErr, ok := err.(*jsonschema.ValidationError); ok {
+			var actionErrs []models.ValidationError
+			for _, leaf := range leaves(validationErr) {
+				actionErrs = append(actionErrs, models.ValidationError{
+					Field:   fmt.Sprintf("actions[%d].data%s", i, field(leaf.InstanceLocation)),
+					Message: leaf.Message,
+				})
+			}
+			sort.SliceStable(actionErrs, func(a, b int) bool { return actionErrs[a].Field < actionErrs[b].Field })
+			errs = append(errs, actionErrs...)
+		} else if err != nil {
+			errs = append(errs, models.ValidationError{Field: fmt.Sprintf("actions[%d].data", i),
+				Message: err.Error()})
+		}
+	}
+
+	return errs
+}
+
+// leaves returns the validation errors without causes of the given validation error, which explain why it failed
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var found []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		found = append(found, leaves(cause)...)
+	}
+	return found
+}
+
+// field returns the given JSON pointer as a field path, e.g. "/tags/0" as ".tags.0"
+func field(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return "." + strings.Join(segments, ".")
+}
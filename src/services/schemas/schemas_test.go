@@ -0,0 +1,53 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestValidate(t *testing.T) {
+	// arrange
+	registry, failures := Compile(map[string]string{
+		"add.item.json": `{"type": "object", "required": ["id"], "properties": {"id": {"$ref": "common.json#/$defs/id"},
+			"tags": {"type": "array", "items": {"type": "string"}}}}`,
+		"common.json":      `{"$defs": {"id": {"type": "string", "pattern": "^[A-Z]"}}}`,
+		"update.item.json": `{"type": "object", "properties": {"id": {"$ref": "https://example.com/id.json"}}}`,
+	})
+	testCases := []struct {
+		name     string
+		action   *models.Action
+		expected []string
+	}{
+		{name: "conforming data", action: &models.Action{ActionType: models.AddAction,
+			Target: models.Target{TargetType: models.ItemTarget}, Data: map[string]interface{}{"id": "Event"}}},
+		{name: "missing data", action: &models.Action{ActionType: models.AddAction,
+			Target: models.Target{TargetType: models.ItemTarget}}, expected: []string{"actions[0].data"}},
+		{name: "offending fields", action: &models.Action{ActionType: models.AddAction,
+			Target: models.Target{TargetType: models.ItemTarget},
+			Data:   map[string]interface{}{"id": "event", "tags": []interface{}{"ok", 1}}},
+			expected: []string{"actions[0].data.id", "actions[0].data.tags.1"}},
+		{name: "no schema", action: &models.Action{ActionType: models.UpdateAction,
+			Target: models.Target{TargetType: models.ItemTarget}, Data: map[string]interface{}{"id": 1}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			errs := registry.Validate(&models.RFC{Actions: models.Actions{tc.action}})
+
+			// assert
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected errors on %v, got %+v", tc.expected, errs)
+			}
+		})
+	}
+	if _, ok := failures["update.item.json"]; !ok || len(failures) != 1 {
+		t.Errorf("expected the schema referencing a remote file to fail, got %v", failures)
+	}
+}
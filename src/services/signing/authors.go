@@ -0,0 +1,106 @@
+// This holds the verification of the signatures authors make over their RFCs, against the public keys registered to
+// them
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errors returned (wrapped) when an author signature fails verification
+var (
+	ErrUnknownAuthorKey = errors.New("no matching key is registered to the author")
+	ErrRevokedAuthorKey = errors.New("the author key has been revoked")
+)
+
+// AuthorKeys holds the Ed25519 public keys registered to each author, keyed by Git login then key ID
+type AuthorKeys struct {
+	keys    map[string]map[string]ed25519.PublicKey
+	revoked map[string]bool
+}
+
+// authorKeysFile is the format of the author keys file: the base64 encoded public keys of each author keyed by Git
+// login, and the IDs of the keys that must no longer be trusted
+type authorKeysFile struct {
+	Authors map[string][]string `json:"authors"`
+	Revoked []string            `json:"revoked"`
+}
+
+// Authors is the author keys shared by the application, nil if no key is registered
+var Authors *AuthorKeys
+
+// AuthorSignaturesRequired is whether RFCs must be signed by their author to be submitted, updated and merged
+var AuthorSignaturesRequired bool
+
+// NewAuthorKeys returns AuthorKeys holding the given base64 encoded Ed25519 public keys of each author, keyed by Git
+// login, but the keys of the given revoked key IDs
+func NewAuthorKeys(authors map[string][]string, revoked []string) (*AuthorKeys, error) {
+	keys := &AuthorKeys{keys: map[string]map[string]ed25519.PublicKey{}, revoked: map[string]bool{}}
+	for _, keyID := range revoked {
+		keys.revoked[strings.TrimSpace(keyID)] = true
+	}
+	for author, encodedKeys := range authors {
+		login := strings.ToLower(strings.TrimSpace(author))
+		if keys.keys[login] == nil {
+			keys.keys[login] = map[string]ed25519.PublicKey{}
+		}
+		for _, encoded := range encodedKeys {
+			key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+			if err != nil || len(key) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("malformed key of author %s, expected a base64 encoded %d byte Ed25519 public "+
+					"key", author, ed25519.PublicKeySize)
+			}
+			keys.keys[login][KeyID(key)] = key
+		}
+	}
+
+	return keys, nil
+}
+
+// LoadAuthorKeys returns the AuthorKeys of the given JSON file, see authorKeysFile
+func LoadAuthorKeys(file string) (*AuthorKeys, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read author keys file %s: %w", file, err)
+	}
+	var decoded authorKeysFile
+	if err = json.Unmarshal(content, &decoded); err != nil {
+		return nil, fmt.Errorf("malformed author keys file %s: %w", file, err)
+	}
+
+	return NewAuthorKeys(decoded.Authors, decoded.Revoked)
+}
+
+// KeyID returns the ID of the given public key, the base64 encoded SHA-256 of the key prefixed with "SHA256:"
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// Verify returns the ID of the key of the given author the given base64 encoded signature of the given content was
+// made with, only the key of the given ID is tried unless it is empty
+// ErrUnknownAuthorKey is returned unless a key of the author verifies the signature, ErrRevokedAuthorKey if the key
+// of the given ID has been revoked
+func (k *AuthorKeys) Verify(author string, keyID string, content []byte, signature string) (string, error) {
+	if keyID != "" && k.revoked[keyID] {
+		return "", fmt.Errorf("%w: %s", ErrRevokedAuthorKey, keyID)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", fmt.Errorf("%w: the signature is not base64 encoded", ErrInvalidSignature)
+	}
+
+	for id, key := range k.keys[strings.ToLower(author)] {
+		if (keyID == "" || id == keyID) && !k.revoked[id] && ed25519.Verify(key, content, decoded) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownAuthorKey, author)
+}
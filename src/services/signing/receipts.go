@@ -0,0 +1,58 @@
+// This holds the signing of submission receipts, which lets clients prove what Harmonia accepted to third parties
+// holding nothing but its public key
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+)
+
+// RECEIPT_ALGORITHM is the algorithm submission receipts are signed with
+const RECEIPT_ALGORITHM string = "ed25519"
+
+// ReceiptSigner signs submission receipts with an Ed25519 private key
+type ReceiptSigner struct {
+	key ed25519.PrivateKey
+}
+
+// NewReceiptSigner returns a ReceiptSigner using the private key of the given base64 encoded 32 byte Ed25519 seed
+func NewReceiptSigner(seed string) (*ReceiptSigner, error) {
+	decoded, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil || len(decoded) != ed25519.SeedSize {
+		return nil, fmt.Errorf("malformed receipt signing key, expected a base64 encoded %d byte Ed25519 seed",
+			ed25519.SeedSize)
+	}
+
+	return &ReceiptSigner{key: ed25519.NewKeyFromSeed(decoded)}, nil
+}
+
+// Receipts is the receipt signer shared by the application, nil if receipts are not signed
+var Receipts *ReceiptSigner
+
+// Sign returns the base64 encoded signature of the given content
+func (s *ReceiptSigner) Sign(content []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, content))
+}
+
+// PublicKey returns the base64 encoded public key receipt signatures are verified with
+func (s *ReceiptSigner) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey))
+}
+
+// VerifyReceipt returns ErrInvalidSignature unless the given base64 encoded signature of the given content was made
+// with the private key of the given base64 encoded public key
+func VerifyReceipt(publicKey string, content []byte, signature string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("malformed receipt public key, expected a base64 encoded %d byte Ed25519 public key",
+			ed25519.PublicKeySize)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(ed25519.PublicKey(key), content, decoded) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// This holds the sealing of the records Harmonia makes in RFC files on behalf of users authenticated through SSO, who
+// all act on the Git provider as the account of the shared token
+
+package signing
+
+import (
+	"crypto/hmac"
+)
+
+// RecordSealer seals records with a secret of Harmonia's own, so that records written to an RFC file by anyone who can
+// push to its branch are told apart from the records Harmonia made
+type RecordSealer struct {
+	secret string
+}
+
+// Records is the sealer of the records made on behalf of users authenticated through SSO, nil if SSO is not enabled
+var Records *RecordSealer
+
+// NewRecordSealer returns a RecordSealer sealing with the given secret
+func NewRecordSealer(secret string) *RecordSealer {
+	return &RecordSealer{secret: secret}
+}
+
+// Seal returns the HMAC-SHA256 of the given content keyed with the secret of the sealer
+func (s *RecordSealer) Seal(content []byte) string {
+	return SignBody(s.secret, content)
+}
+
+// CheckSeal returns whether the given seal is the seal of the given content, see Seal
+func (s *RecordSealer) CheckSeal(content []byte, seal string) bool {
+	return hmac.Equal([]byte(s.Seal(content)), []byte(seal))
+}
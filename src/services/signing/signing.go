@@ -0,0 +1,157 @@
+// Package signing holds the verification of signed requests, which protects state changing endpoints from forged and
+// replayed requests
+// A signed request carries a timestamp, a single use nonce and a hex encoded HMAC-SHA256 of
+// "<timestamp>.<nonce>.<body>" keyed with a secret shared between the caller and Harmonia
+// GitHub webhook deliveries are verified the same way, their signature being an HMAC-SHA256 of the body alone keyed
+// with the webhook secret and their delivery ID serving as the nonce
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"harmonia-example.io/src/services/cache"
+)
+
+// Common constants used in request signing
+const (
+	TIMESTAMP_HEADER string = "X-Harmonia-Timestamp"
+	NONCE_HEADER     string = "X-Harmonia-Nonce"
+	SIGNATURE_HEADER string = "X-Harmonia-Signature"
+	// SIGNATURE_PREFIX may optionally precede the hex encoded signature, as it does in GitHub webhook signatures
+	SIGNATURE_PREFIX string = "sha256="
+	// DEFAULT_TOLERANCE is how far a request timestamp may be from the current time before it is rejected as stale
+	DEFAULT_TOLERANCE = 5 * time.Minute
+
+	GITHUB_SIGNATURE_HEADER string = "X-Hub-Signature-256"
+	GITHUB_DELIVERY_HEADER  string = "X-GitHub-Delivery"
+	GITHUB_EVENT_HEADER     string = "X-GitHub-Event"
+	// DEFAULT_DELIVERY_TTL is how long webhook delivery IDs are remembered, deliveries carry no timestamp
+	DEFAULT_DELIVERY_TTL = 24 * time.Hour
+)
+
+// errors returned (wrapped) when a request fails verification
+var (
+	ErrMissingSignature = errors.New("request is not signed")
+	ErrStaleRequest     = errors.New("request timestamp is outside the accepted window")
+	ErrInvalidSignature = errors.New("request signature is invalid")
+	ErrReplayedRequest  = errors.New("request nonce has already been used")
+)
+
+// Verifier verifies signed requests and remembers their nonces for as long as their timestamps are accepted, so that
+// a captured request cannot be replayed
+type Verifier struct {
+	secret    []byte
+	tolerance time.Duration
+	nonces    *cache.Cache[string, bool]
+	now       func() time.Time
+}
+
+// NewVerifier returns a Verifier using the given shared secret that accepts timestamps within the given tolerance
+func NewVerifier(secret string, tolerance time.Duration) *Verifier {
+	return newVerifier("request_nonces", secret, tolerance)
+}
+
+// newVerifier returns a Verifier like NewVerifier whose nonces are remembered in the cache of the given name
+func newVerifier(name string, secret string, tolerance time.Duration) *Verifier {
+	return &Verifier{
+		secret:    []byte(secret),
+		tolerance: tolerance,
+		// a nonce only needs to be remembered until its timestamp falls outside the window on either side
+		nonces: cache.NewNamed[string, bool](name, 2*tolerance),
+		now:    time.Now,
+	}
+}
+
+// Default is the verifier shared by the application, nil if request signing is not enabled
+var Default *Verifier
+
+// Sign returns the hex encoded signature of the given request parts using the given shared secret
+func Sign(secret string, timestamp string, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%s.%s.", timestamp, nonce)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the given request timestamp (unix seconds), nonce and signature against the given body
+// The nonce is only consumed once the signature is verified, so forged requests cannot exhaust legitimate nonces
+func (v *Verifier) Verify(timestamp string, nonce string, signature string, body []byte) error {
+	if timestamp == "" || nonce == "" || signature == "" {
+		return fmt.Errorf("%w: the %s, %s and %s headers are required", ErrMissingSignature, TIMESTAMP_HEADER,
+			NONCE_HEADER, SIGNATURE_HEADER)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp '%s'", ErrStaleRequest, timestamp)
+	}
+	if skew := v.now().Sub(time.Unix(seconds, 0)); skew > v.tolerance || skew < -v.tolerance {
+		return fmt.Errorf("%w: timestamp is %s from the current time", ErrStaleRequest, skew.Round(time.Second))
+	}
+
+	expected := Sign(string(v.secret), timestamp, nonce, body)
+	actual := strings.TrimPrefix(strings.ToLower(signature), SIGNATURE_PREFIX)
+	if !hmac.Equal([]byte(expected), []byte(actual)) {
+		return ErrInvalidSignature
+	}
+
+	if !v.nonces.SetIfAbsent(nonce, true) {
+		return fmt.Errorf("%w: '%s'", ErrReplayedRequest, nonce)
+	}
+
+	return nil
+}
+
+// WebhookVerifier verifies GitHub webhook deliveries and remembers their delivery IDs, so that a captured delivery
+// cannot be replayed
+type WebhookVerifier struct {
+	secret     []byte
+	deliveries *cache.Cache[string, bool]
+}
+
+// NewWebhookVerifier returns a WebhookVerifier using the given webhook secret that remembers delivery IDs for the given
+// duration
+func NewWebhookVerifier(secret string, ttl time.Duration) *WebhookVerifier {
+	return &WebhookVerifier{
+		secret:     []byte(secret),
+		deliveries: cache.NewNamed[string, bool]("webhook_deliveries", ttl),
+	}
+}
+
+// Webhooks is the webhook verifier shared by the application, nil if no webhook secret is configured
+var Webhooks *WebhookVerifier
+
+// SignBody returns the hex encoded signature of the given webhook body using the given webhook secret
+func SignBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the given delivery ID and signature against the given body
+// The delivery ID is only consumed once the signature is verified, so forged deliveries cannot exhaust legitimate ones
+func (v *WebhookVerifier) Verify(delivery string, signature string, body []byte) error {
+	if delivery == "" || signature == "" {
+		return fmt.Errorf("%w: the %s and %s headers are required", ErrMissingSignature, GITHUB_DELIVERY_HEADER,
+			GITHUB_SIGNATURE_HEADER)
+	}
+
+	expected := SignBody(string(v.secret), body)
+	actual := strings.TrimPrefix(strings.ToLower(signature), SIGNATURE_PREFIX)
+	if !hmac.Equal([]byte(expected), []byte(actual)) {
+		return ErrInvalidSignature
+	}
+
+	if !v.deliveries.SetIfAbsent(delivery, true) {
+		return fmt.Errorf("%w: delivery '%s'", ErrReplayedRequest, delivery)
+	}
+
+	return nil
+}
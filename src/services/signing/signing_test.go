@@ -0,0 +1,254 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	// arrange
+	now := time.Unix(1654074000, 0)
+	v := NewVerifier("shh", DEFAULT_TOLERANCE)
+	v.now = func() time.Time { return now }
+	body := []byte(`{"rfcIdentifier":"123456"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	stale := strconv.FormatInt(now.Add(-DEFAULT_TOLERANCE-time.Second).Unix(), 10)
+
+	testCases := []struct {
+		name        string
+		timestamp   string
+		nonce       string
+		signature   string
+		body        []byte
+		expectedErr error
+	}{
+		{"unsigned", "", "", "", body, ErrMissingSignature},
+		{"stale", stale, "n1", Sign("shh", stale, "n1", body), body, ErrStaleRequest},
+		{"malformed timestamp", "yesterday", "n1", Sign("shh", "yesterday", "n1", body), body, ErrStaleRequest},
+		{"wrong secret", timestamp, "n1", Sign("guess", timestamp, "n1", body), body, ErrInvalidSignature},
+		{"tampered body", timestamp, "n1", Sign("shh", timestamp, "n1", body), []byte(`{}`), ErrInvalidSignature},
+		{"valid", timestamp, "n1", SIGNATURE_PREFIX + Sign("shh", timestamp, "n1", body), body, nil},
+		{"replayed", timestamp, "n1", Sign("shh", timestamp, "n1", body), body, ErrReplayedRequest},
+		{"new nonce", timestamp, "n2", Sign("shh", timestamp, "n2", body), body, nil},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		err := v.Verify(test.timestamp, test.nonce, test.signature, test.body)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+	}
+}
+
+func TestWebhookVerify(t *testing.T) {
+	// arrange
+	v := NewWebhookVerifier("shh", DEFAULT_DELIVERY_TTL)
+	body := []byte(`{"action":"submitted"}`)
+
+	testCases := []struct {
+		name        string
+		delivery    string
+		signature   string
+		body        []byte
+		expectedErr error
+	}{
+		{"unsigned", "d1", "", body, ErrMissingSignature},
+		{"wrong secret", "d1", SIGNATURE_PREFIX + SignBody("guess", body), body, ErrInvalidSignature},
+		{"tampered body", "d1", SIGNATURE_PREFIX + SignBody("shh", body), []byte(`{}`), ErrInvalidSignature},
+		{"valid", "d1", SIGNATURE_PREFIX + SignBody("shh", body), body, nil},
+		{"replayed", "d1", SIGNATURE_PREFIX + SignBody("shh", body), body, ErrReplayedRequest},
+		{"new delivery", "d2", SignBody("shh", body), body, nil},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		err := v.Verify(test.delivery, test.signature, test.body)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+	}
+}
+
+func TestVerifyReceipt(t *testing.T) {
+	// arrange
+	seed := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	signer, err := NewReceiptSigner(seed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	other, _ := NewReceiptSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, 32)))
+	content := []byte("123456\n123456\n\n9f86d081")
+
+	testCases := []struct {
+		name        string
+		publicKey   string
+		signature   string
+		content     []byte
+		expectedErr error
+	}{
+		{"unsigned", signer.PublicKey(), "", content, ErrInvalidSignature},
+		{"wrong key", other.PublicKey(), signer.Sign(content), content, ErrInvalidSignature},
+		{"tampered content", signer.PublicKey(), signer.Sign(content), []byte("123456"), ErrInvalidSignature},
+		{"valid", signer.PublicKey(), signer.Sign(content), content, nil},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		err := VerifyReceipt(test.publicKey, test.content, test.signature)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+	}
+	if _, err = NewReceiptSigner("c2hvcnQ="); err == nil {
+		t.Errorf("expected a short seed to be rejected")
+	}
+}
+
+func TestVerifyAuthor(t *testing.T) {
+	// arrange, receipt signers stand in for the private keys of authors
+	alice, _ := NewReceiptSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	laptop, _ := NewReceiptSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))
+	bob, _ := NewReceiptSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{3}, 32)))
+	publicKey := func(signer *ReceiptSigner) string { return signer.PublicKey() }
+	keyID := func(signer *ReceiptSigner) string {
+		key, _ := base64.StdEncoding.DecodeString(signer.PublicKey())
+		return KeyID(key)
+	}
+	keys, err := NewAuthorKeys(map[string][]string{"Alice": {publicKey(alice), publicKey(laptop)},
+		"bob": {publicKey(bob)}}, []string{keyID(laptop)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	content := []byte(`{"actions":[]}`)
+
+	testCases := []struct {
+		name        string
+		author      string
+		keyID       string
+		signature   string
+		expectedID  string
+		expectedErr error
+	}{
+		{"valid", "alice", "", alice.Sign(content), keyID(alice), nil},
+		{"valid with key ID", "alice", keyID(alice), alice.Sign(content), keyID(alice), nil},
+		{"other key ID", "alice", keyID(bob), alice.Sign(content), "", ErrUnknownAuthorKey},
+		{"key of another author", "alice", "", bob.Sign(content), "", ErrUnknownAuthorKey},
+		{"unknown author", "carol", "", alice.Sign(content), "", ErrUnknownAuthorKey},
+		{"revoked key", "alice", keyID(laptop), laptop.Sign(content), "", ErrRevokedAuthorKey},
+		{"revoked key without ID", "alice", "", laptop.Sign(content), "", ErrUnknownAuthorKey},
+		{"tampered content", "bob", "", bob.Sign([]byte(`{}`)), "", ErrUnknownAuthorKey},
+		{"malformed signature", "bob", "", "not base64!", "", ErrInvalidSignature},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		id, err := keys.Verify(test.author, test.keyID, content, test.signature)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+		if id != test.expectedID {
+			t.Errorf("%s: expected key %q, got %q", test.name, test.expectedID, id)
+		}
+	}
+	if _, err = NewAuthorKeys(map[string][]string{"alice": {"c2hvcnQ="}}, nil); err == nil {
+		t.Errorf("expected a short key to be rejected")
+	}
+}
+
+func TestVerifySystem(t *testing.T) {
+	// arrange
+	v, err := NewSystemVerifier(map[string]string{"cab": "cab-secret", "itsm": "itsm-secret"}, DEFAULT_TOLERANCE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, emptyErr := NewSystemVerifier(map[string]string{"cab": ""}, DEFAULT_TOLERANCE)
+	body := []byte(`{"rfcIdentifier":"123456","verdict":"approved"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	testCases := []struct {
+		name        string
+		system      string
+		nonce       string
+		secret      string
+		expectedErr error
+	}{
+		{"unknown system", "jira", "n1", "cab-secret", ErrUnknownSystem},
+		{"secret of another system", "cab", "n1", "itsm-secret", ErrInvalidSignature},
+		{"valid", "cab", "n1", "cab-secret", nil},
+		{"replayed", "cab", "n1", "cab-secret", ErrReplayedRequest},
+		{"nonce of another system", "itsm", "n1", "itsm-secret", nil},
+	}
+
+	// act & assert
+	for _, test := range testCases {
+		err := v.Verify(test.system, timestamp, test.nonce, Sign(test.secret, timestamp, test.nonce, body), body)
+		if test.expectedErr == nil && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err.Error())
+		} else if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expectedErr, err)
+		}
+	}
+	if emptyErr == nil {
+		t.Errorf("expected systems without a secret to be rejected")
+	}
+	if !v.Has("cab") || v.Has("jira") {
+		t.Errorf("expected only registered systems to be known")
+	}
+}
+
+func TestSealSystem(t *testing.T) {
+	// arrange
+	v, err := NewSystemVerifier(map[string]string{"cab": "cab-secret", "itsm": "itsm-secret"}, DEFAULT_TOLERANCE)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := []byte(`{"system":"cab","verdict":"approved"}`)
+
+	// act
+	seal, sealErr := v.Seal("cab", content)
+	_, unknownErr := v.Seal("jira", content)
+
+	// assert
+	if sealErr != nil || !v.CheckSeal("cab", content, seal) {
+		t.Errorf("expected the seal of cab to check, got %v", sealErr)
+	}
+	if v.CheckSeal("itsm", content, seal) || v.CheckSeal("cab", []byte(`{"system":"cab"}`), seal) {
+		t.Errorf("expected the seal to only check for the content and system it was made for")
+	}
+	if !errors.Is(unknownErr, ErrUnknownSystem) || v.CheckSeal("jira", content, seal) {
+		t.Errorf("expected unknown systems not to seal, got %v", unknownErr)
+	}
+}
+
+func TestSealRecord(t *testing.T) {
+	// arrange
+	sealer := NewRecordSealer("record-secret")
+	content := []byte(`{"reviewer":"tstark","reviewType":"APPROVE"}`)
+
+	// act
+	seal := sealer.Seal(content)
+
+	// assert
+	if !sealer.CheckSeal(content, seal) {
+		t.Errorf("expected the seal to check")
+	}
+	if sealer.CheckSeal([]byte(`{"reviewer":"bbanner","reviewType":"APPROVE"}`), seal) {
+		t.Errorf("expected the seal to only check for the content it was made for")
+	}
+	if NewRecordSealer("other-secret").CheckSeal(content, seal) {
+		t.Errorf("expected the seal to only check with the secret it was made with")
+	}
+}
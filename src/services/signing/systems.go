@@ -0,0 +1,76 @@
+// This holds the verification of the requests of external systems, e.g. change advisory board tools, which approve
+// or reject RFCs from outside the Git provider
+
+package signing
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SYSTEM_HEADER is the header naming the external system a request comes from
+const SYSTEM_HEADER string = "X-Harmonia-System"
+
+// ErrUnknownSystem is returned (wrapped) when a request comes from an external system that is not registered
+var ErrUnknownSystem = errors.New("unknown external system")
+
+// SystemVerifier verifies the requests of external systems, each signed like signed requests (see Verifier) but with
+// a secret shared with that system alone, so that a system cannot act as another
+type SystemVerifier struct {
+	systems map[string]*Verifier
+}
+
+// Systems is the verifier of the external systems allowed to approve RFCs, nil if none is registered
+var Systems *SystemVerifier
+
+// NewSystemVerifier returns a SystemVerifier of the external systems of the given shared secrets, keyed by system
+// name, that accepts timestamps within the given tolerance
+func NewSystemVerifier(secrets map[string]string, tolerance time.Duration) (*SystemVerifier, error) {
+	verifier := &SystemVerifier{systems: map[string]*Verifier{}}
+	for system, secret := range secrets {
+		if system == "" || secret == "" {
+			return nil, fmt.Errorf("external system '%s' needs a name and a secret", system)
+		}
+		verifier.systems[system] = newVerifier("system_nonces_"+system, secret, tolerance)
+	}
+
+	return verifier, nil
+}
+
+// Has returns whether the given external system is registered
+func (v *SystemVerifier) Has(system string) bool {
+	_, ok := v.systems[system]
+	return ok
+}
+
+// Seal returns the HMAC-SHA256 of the given content keyed with the secret of the given external system, which records
+// made on behalf of the system are sealed with so they cannot be forged without the secret. ErrUnknownSystem is
+// returned (wrapped) if the system is not registered
+func (v *SystemVerifier) Seal(system string, content []byte) (string, error) {
+	verifier, ok := v.systems[system]
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownSystem, system)
+	}
+
+	return SignBody(string(verifier.secret), content), nil
+}
+
+// CheckSeal returns whether the given seal is the seal of the given content by the given external system, see Seal
+func (v *SystemVerifier) CheckSeal(system string, content []byte, seal string) bool {
+	expected, err := v.Seal(system, content)
+	return err == nil && hmac.Equal([]byte(expected), []byte(seal))
+}
+
+// Verify checks the given request timestamp, nonce and signature of the given external system against the given
+// body, see Verifier.Verify. ErrUnknownSystem is returned (wrapped) if the system is not registered
+func (v *SystemVerifier) Verify(system string, timestamp string, nonce string, signature string, body []byte) error {
+	verifier, ok := v.systems[system]
+	if !ok {
+		return fmt.Errorf("%w: '%s', the %s header names the system a request comes from", ErrUnknownSystem, system,
+			SYSTEM_HEADER)
+	}
+
+	return verifier.Verify(timestamp, nonce, signature, body)
+}
@@ -0,0 +1,209 @@
+// Package tenants holds the tenants of the multi-tenant mode, schema domains whose credentials, load targets and
+// notification channels are isolated from those of every other schema domain
+package tenants
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// ErrCrossTenant is returned (wrapped) when a request of a schema domain reaches for the RFCs, load targets or
+// notification channels of another schema domain that is a tenant, or the other way around
+var ErrCrossTenant = models.NewError(models.ErrUnauthorized, models.CrossTenantCode, "cross-tenant access denied")
+
+// Tenant holds the settings a schema domain does not share with any other schema domain
+// Tokens are not held by the tenant itself, it names the settings holding them so they are kept in the environment
+// along with the other tokens of the service rather than in the tenants file
+type Tenant struct {
+	// Token names the setting holding the Git access token shared by the requests of the tenant that do not carry the
+	// token of their user, GIT_TOKEN is never used for the tenant. Requests must carry a token if it is empty
+	Token string `json:"token,omitempty"`
+	// Members are the Git teams whose members the shared token of the tenant is used for, see CheckMember. Requests
+	// of users in none of them, e.g. users of another tenant naming the schema domain of the tenant, must carry a token
+	Members []string `json:"members,omitempty"`
+	// MachineToken names the setting holding the Git access token of the machine identity of the tenant, which is used
+	// instead of GIT_MACHINE_TOKEN or the GitHub App
+	MachineToken string `json:"machineToken"`
+	// ReadToken names the setting holding the read-only Git access token the read endpoints of the tenant are served
+	// with, instead of its machine token. The read endpoints are anonymous if it is empty and GIT_READ_ANONYMOUS is set
+	ReadToken string `json:"readToken,omitempty"`
+	// LoadTargets are the load targets the RFCs of the tenant may be loaded into, and are loaded into if they declare
+	// none. No other schema domain may load into them
+	LoadTargets []string `json:"loadTargets"`
+	// Channels are the notification channels the events of the tenant are delivered on, no event of another schema
+	// domain is delivered on them. Events of a tenant without channels are delivered on the channels of no tenant
+	Channels []string `json:"channels,omitempty"`
+}
+
+// Registry holds the tenants of the multi-tenant mode, keyed by schema domain
+type Registry struct {
+	tenants map[string]Tenant
+	// loadTargets and channels hold the schema domain owning each load target and notification channel of a tenant
+	loadTargets map[string]string
+	channels    map[string]string
+}
+
+// Default is the registry of tenants of the application, nil unless the multi-tenant mode is enabled
+var Default *Registry
+
+// New returns the Registry of the given tenants, keyed by schema domain. Every tenant must name its machine token and
+// at least one load target, and no load target or notification channel may be shared by two tenants
+func New(tenants map[string]Tenant) (*Registry, error) {
+	registry := &Registry{tenants: tenants, loadTargets: map[string]string{}, channels: map[string]string{}}
+	for _, domain := range sortedDomains(tenants) {
+		tenant := tenants[domain]
+		if domain == "" {
+			return nil, fmt.Errorf("tenants must be schema domains, the default tracking repository cannot be a tenant")
+		}
+		if tenant.MachineToken == "" {
+			return nil, fmt.Errorf("no machine token specified for tenant %s", domain)
+		}
+		if len(tenant.LoadTargets) == 0 {
+			return nil, fmt.Errorf("no load target specified for tenant %s", domain)
+		}
+		for _, target := range tenant.LoadTargets {
+			if owner, ok := registry.loadTargets[target]; ok && owner != domain {
+				return nil, fmt.Errorf("load target %s is shared by tenants %s and %s", target, owner, domain)
+			}
+			registry.loadTargets[target] = domain
+		}
+		for _, channel := range tenant.Channels {
+			if owner, ok := registry.channels[channel]; ok && owner != domain {
+				return nil, fmt.Errorf("notification channel %s is shared by tenants %s and %s", channel, owner, domain)
+			}
+			registry.channels[channel] = domain
+		}
+	}
+
+	return registry, nil
+}
+
+// Load returns the Registry of the tenants of the given JSON file, an object of Tenant objects keyed by schema domain
+func Load(file string) (*Registry, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read tenants file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var tenants map[string]Tenant
+	if err = json.Unmarshal(content, &tenants); err != nil {
+		return nil, fmt.Errorf("malformed tenants file %s: %w", file, err)
+	}
+
+	return New(tenants)
+}
+
+// sortedDomains returns the schema domains of the given tenants, sorted so errors are reported deterministically
+func sortedDomains(tenants map[string]Tenant) []string {
+	domains := make([]string, 0, len(tenants))
+	for domain := range tenants {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
+	return domains
+}
+
+// Domains returns the sorted schema domains of the tenants
+func (r *Registry) Domains() []string {
+	return sortedDomains(r.tenants)
+}
+
+// Get returns the tenant of the given schema domain, false is returned if the domain is not a tenant
+func (r *Registry) Get(domain string) (Tenant, bool) {
+	tenant, ok := r.tenants[domain]
+	return tenant, ok
+}
+
+// CheckDomain returns ErrCrossTenant (wrapped) if an RFC of the given schema domain cannot be handled in the tracking
+// repository of the other given schema domain, which is the case whenever they differ and either is a tenant
+func (r *Registry) CheckDomain(domain string, repositoryDomain string) error {
+	if domain == repositoryDomain {
+		return nil
+	}
+	_, isTenant := r.tenants[domain]
+	_, isRepositoryTenant := r.tenants[repositoryDomain]
+	if !isTenant && !isRepositoryTenant {
+		return nil
+	}
+
+	return fmt.Errorf("%w: the RFCs of %s cannot be handled in the tracking repository of %s", ErrCrossTenant,
+		describe(domain), describe(repositoryDomain))
+}
+
+// CheckMember returns ErrCrossTenant (wrapped) unless the given teams of a user include one of the member teams of the
+// tenant of the given schema domain. Every user is a member of schema domains that are not tenants
+func (r *Registry) CheckMember(domain string, teams set.Set[string]) error {
+	tenant, ok := r.tenants[domain]
+	if !ok {
+		return nil
+	}
+	for _, team := range tenant.Members {
+		if teams.Contains(team) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: the user is not a member of %s", ErrCrossTenant, describe(domain))
+}
+
+// LoadTargets returns those of the given configured load targets the RFCs of the given schema domain may be loaded
+// into: the load targets of its tenant, or those of no tenant if it is not a tenant
+func (r *Registry) LoadTargets(domain string, configured []string) []string {
+	return r.available(domain, configured, r.loadTargets)
+}
+
+// CheckLoadTarget returns ErrCrossTenant (wrapped) unless the RFCs of the given schema domain may be loaded into the
+// given load target
+func (r *Registry) CheckLoadTarget(domain string, target string) error {
+	if owner := r.loadTargets[target]; owner != domain && (owner != "" || r.isTenant(domain)) {
+		return fmt.Errorf("%w: the RFCs of %s cannot be loaded into %s", ErrCrossTenant, describe(domain), target)
+	}
+	return nil
+}
+
+// Channels returns those of the given configured notification channels the events of the given schema domain are
+// delivered on: the channels of its tenant if it has any, or those of no tenant otherwise
+func (r *Registry) Channels(domain string, configured []string) []string {
+	if tenant, ok := r.tenants[domain]; ok && len(tenant.Channels) == 0 {
+		return r.available("", configured, r.channels)
+	}
+	return r.available(domain, configured, r.channels)
+}
+
+// available returns the given configured resources owned by the given schema domain, according to the given owners,
+// or those owned by no schema domain if it is not a tenant
+func (r *Registry) available(domain string, configured []string, owners map[string]string) []string {
+	if !r.isTenant(domain) {
+		domain = ""
+	}
+	available := []string{}
+	for _, resource := range configured {
+		if owners[resource] == domain {
+			available = append(available, resource)
+		}
+	}
+
+	return available
+}
+
+// isTenant returns true if the given schema domain is a tenant
+func (r *Registry) isTenant(domain string) bool {
+	_, ok := r.tenants[domain]
+	return ok
+}
+
+// describe returns a description of the given schema domain for errors
+func describe(domain string) string {
+	if domain == "" {
+		return "the default schema domain"
+	}
+	return fmt.Sprintf("schema domain %s", domain)
+}
@@ -0,0 +1,124 @@
+package tenants
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"harmonia-example.io/src/services/set"
+)
+
+func TestNew(t *testing.T) {
+	// arrange
+	testCases := []struct {
+		name     string
+		tenants  map[string]Tenant
+		expected string
+	}{
+		{name: "isolated tenants", tenants: map[string]Tenant{
+			"catalog":  {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"}},
+			"playback": {MachineToken: "PLAYBACK_MACHINE_TOKEN", LoadTargets: []string{"playback-db"}},
+		}},
+		{name: "read token", tenants: map[string]Tenant{
+			"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", ReadToken: "CATALOG_READ_TOKEN",
+				LoadTargets: []string{"catalog-db"}},
+		}},
+		{name: "default domain", tenants: map[string]Tenant{
+			"": {MachineToken: "GIT_MACHINE_TOKEN", LoadTargets: []string{"primary"}},
+		}, expected: "default tracking repository cannot be a tenant"},
+		{name: "no machine token", tenants: map[string]Tenant{
+			"catalog": {LoadTargets: []string{"catalog-db"}},
+		}, expected: "no machine token specified for tenant catalog"},
+		{name: "no load target", tenants: map[string]Tenant{
+			"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN"},
+		}, expected: "no load target specified for tenant catalog"},
+		{name: "shared load target", tenants: map[string]Tenant{
+			"catalog":  {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"primary"}},
+			"playback": {MachineToken: "PLAYBACK_MACHINE_TOKEN", LoadTargets: []string{"primary"}},
+		}, expected: "load target primary is shared by tenants catalog and playback"},
+		{name: "shared channel", tenants: map[string]Tenant{
+			"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"},
+				Channels: []string{"slack"}},
+			"playback": {MachineToken: "PLAYBACK_MACHINE_TOKEN", LoadTargets: []string{"playback-db"},
+				Channels: []string{"slack"}},
+		}, expected: "notification channel slack is shared by tenants catalog and playback"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			_, err := New(tc.tenants)
+
+			// assert
+			if tc.expected == "" && err != nil {
+				t.Errorf("unexpected error: %s", err.Error())
+			} else if tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestIsolation(t *testing.T) {
+	// arrange
+	registry, err := New(map[string]Tenant{
+		"catalog": {MachineToken: "CATALOG_MACHINE_TOKEN", LoadTargets: []string{"catalog-db"},
+			Channels: []string{"catalog-slack"}, Members: []string{"catalog-team"}},
+		"playback": {MachineToken: "PLAYBACK_MACHINE_TOKEN", LoadTargets: []string{"playback-db"},
+			Members: []string{"playback-team"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	loadTargets := []string{"catalog-db", "playback-db", "primary"}
+	channels := []string{"catalog-slack", "webhook"}
+
+	// act & assert RFCs are only handled in the tracking repository of their tenant
+	if err = registry.CheckDomain("catalog", "catalog"); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if err = registry.CheckDomain("search", ""); err != nil {
+		t.Errorf("expected domains that are not tenants to share repositories, got %v", err)
+	}
+	for _, domains := range [][2]string{{"catalog", "playback"}, {"", "catalog"}, {"catalog", ""}} {
+		if err = registry.CheckDomain(domains[0], domains[1]); !errors.Is(err, ErrCrossTenant) {
+			t.Errorf("expected a cross-tenant error for %v, got %v", domains, err)
+		}
+	}
+
+	// act & assert the shared token of a tenant is only used for the members of its teams
+	if err = registry.CheckMember("catalog", set.NewSetOf("catalog-team", "reviewers")); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	if err = registry.CheckMember("search", set.NewSet[string]()); err != nil {
+		t.Errorf("expected domains that are not tenants to admit every user, got %v", err)
+	}
+	if err = registry.CheckMember("catalog", set.NewSetOf("playback-team")); !errors.Is(err, ErrCrossTenant) {
+		t.Errorf("expected a cross-tenant error for a member of another tenant, got %v", err)
+	}
+
+	// act & assert RFCs are only loaded into the load targets of their tenant, or of no tenant
+	for domain, expected := range map[string]string{"catalog": "[catalog-db]", "playback": "[playback-db]",
+		"": "[primary]", "search": "[primary]"} {
+		if actual := fmt.Sprint(registry.LoadTargets(domain, loadTargets)); actual != expected {
+			t.Errorf("unexpected load targets of %q. expected: %s\n actual: %s", domain, expected, actual)
+		}
+	}
+	if err = registry.CheckLoadTarget("catalog", "catalog-db"); err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+	for _, check := range [][2]string{{"catalog", "playback-db"}, {"catalog", "primary"}, {"", "catalog-db"}} {
+		if err = registry.CheckLoadTarget(check[0], check[1]); !errors.Is(err, ErrCrossTenant) {
+			t.Errorf("expected a cross-tenant error for %v, got %v", check, err)
+		}
+	}
+
+	// act & assert events are only delivered on the channels of their tenant, or of no tenant
+	for domain, expected := range map[string]string{"catalog": "[catalog-slack]", "playback": "[webhook]",
+		"": "[webhook]"} {
+		if actual := fmt.Sprint(registry.Channels(domain, channels)); actual != expected {
+			t.Errorf("unexpected channels of %q. expected: %s\n actual: %s", domain, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Package tracing holds the OpenTelemetry tracing of Harmonia, spans are started for every request, controller function
+// and call to the Git provider so the lifecycle of an RFC (review, load, merge...) can be traced end to end
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"harmonia-example.io/src/services/logging"
+)
+
+// Common constants used to configure tracing and name span attributes
+const (
+	// NONE_EXPORTER disables the export of spans, they are still propagated to and from other services
+	NONE_EXPORTER string = "none"
+	// STDOUT_EXPORTER exports spans as JSON to stdout, for a collector to pick up along with the logs
+	STDOUT_EXPORTER string = "stdout"
+	// SERVICE_NAME is the name Harmonia's spans are reported under
+	SERVICE_NAME string = "harmonia"
+	// TRACER_NAME is the name of the tracer starting Harmonia's spans
+	TRACER_NAME string = "harmonia-example.io/src"
+
+	RFC_IDENTIFIER_KEY attribute.Key = "harmonia.rfc.identifier"
+	BRANCH_KEY         attribute.Key = "harmonia.git.branch"
+	PR_NUMBER_KEY      attribute.Key = "harmonia.git.pr_number"
+	PROVIDER_KEY       attribute.Key = "harmonia.git.provider"
+	REQUEST_ID_KEY     attribute.Key = "harmonia.request_id"
+	USER_KEY           attribute.Key = "harmonia.user"
+	TENANT_KEY         attribute.Key = "harmonia.tenant"
+	PERMISSION_KEY     attribute.Key = "harmonia.permission"
+)
+
+// NewProvider returns a tracer provider sampling the given ratio of traces, unless their parent was sampled, and
+// exporting spans with the given exporter, NONE_EXPORTER or STDOUT_EXPORTER. Spans exported to stdout are written to
+// the given writer
+func NewProvider(exporter string, ratio float64, w io.Writer) (*sdktrace.TracerProvider, error) {
+	if ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("trace sample ratio must be between 0 and 1, got %g", ratio)
+	}
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(SERVICE_NAME))),
+	}
+
+	switch strings.ToLower(exporter) {
+	case NONE_EXPORTER:
+	case STDOUT_EXPORTER:
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %s, expected %s or %s", exporter, NONE_EXPORTER, STDOUT_EXPORTER)
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// Register makes the given tracer provider the one spans are started with, trace context is propagated through the
+// W3C traceparent and tracestate headers
+func Register(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// Start starts a span with the given name and attributes as a child of the span of the given context, if any
+// The returned context carries the span, which must be ended by the caller
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TRACER_NAME).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartRequest starts the server span of the given request to the given route, continuing the trace propagated by the
+// caller, if any. The returned context is the request context carrying the span, which must be ended with EndRequest
+func StartRequest(r *http.Request, route string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	attributes = append(attributes, semconv.HTTPRequestMethodKey.String(r.Method), semconv.HTTPRoute(route))
+	return otel.Tracer(TRACER_NAME).Start(ctx, strings.TrimSpace(r.Method+" "+route),
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+}
+
+// EndRequest records the given response status and error, if any, on the given server span and ends it
+// The span is failed if the request failed with an error or a server error status
+func EndRequest(span trace.Span, status int, err error) {
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if err == nil && status >= http.StatusInternalServerError {
+		err = errors.New(http.StatusText(status))
+	}
+	End(span, err)
+}
+
+// StartRPC starts the server span of the given call to the given gRPC service and method, continuing the trace
+// propagated by the caller through the given carrier of the call metadata, if any. The returned context carries the
+// span, which must be ended with EndRPC
+func StartRPC(ctx context.Context, carrier propagation.TextMapCarrier, service string, method string,
+	attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+	attributes = append(attributes, semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(method))
+	return otel.Tracer(TRACER_NAME).Start(ctx, service+"/"+method,
+		trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+}
+
+// EndRPC records the given gRPC status code and error, if any, on the given server span and ends it
+func EndRPC(span trace.Span, code int, err error) {
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(code))
+	End(span, err)
+}
+
+// TraceID returns the ID of the trace of the given context, empty if it is not traced
+func TraceID(ctx context.Context) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+	return ""
+}
+
+// End records the given error, if any, on the given span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a new context for work that outlives the request of the given context, e.g. an asynchronous load
+// It carries the logger and span of the given context, so the work is logged for and traced as part of the request,
+// but none of its deadline, cancellation or other values, see logging.Detach
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(logging.Detach(ctx), trace.SpanContextFromContext(ctx))
+}
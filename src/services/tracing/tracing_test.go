@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"harmonia-example.io/src/services/logging"
+)
+
+func TestNewProvider(t *testing.T) {
+	// act
+	provider, err := NewProvider(STDOUT_EXPORTER, 0.5, io.Discard)
+	_, exporterErr := NewProvider("zipkin", 1, io.Discard)
+	_, ratioErr := NewProvider(NONE_EXPORTER, 1.5, io.Discard)
+
+	// assert
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	defer provider.Shutdown(context.Background())
+	if exporterErr == nil || ratioErr == nil {
+		t.Errorf("expected errors for an unknown exporter and ratio, got %v and %v", exporterErr, ratioErr)
+	}
+}
+
+func TestDetach(t *testing.T) {
+	// arrange
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	request, cancel := context.WithCancel(logging.NewContext(context.Background(), logging.Default))
+	request, span := provider.Tracer(TRACER_NAME).Start(request, "request")
+	defer span.End()
+
+	// act
+	cancel()
+	detached := Detach(request)
+
+	// assert
+	if detached.Err() != nil {
+		t.Errorf("expected detached contexts not to be cancelled with the request")
+	}
+	if TraceID(detached) == "" || TraceID(detached) != TraceID(request) {
+		t.Errorf("expected detached contexts to be traced as part of the request, got %q and %q",
+			TraceID(detached), TraceID(request))
+	}
+	if TraceID(context.Background()) != "" {
+		t.Errorf("expected contexts without a span to have no trace ID")
+	}
+}
@@ -0,0 +1,119 @@
+// Package triage holds the triage policy, which derives the labels, assignees and reviewers of the pull request of an
+// RFC from the actions it holds, so the pull requests of the tracking repository are triaged as they are opened
+package triage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"harmonia-example.io/src/models"
+	"harmonia-example.io/src/services/logging"
+	"harmonia-example.io/src/services/set"
+)
+
+// Triage is what a pull request is triaged with, reviewers are Git logins and team reviewers team slugs
+type Triage struct {
+	Labels        []string `json:"labels,omitempty"`
+	Assignees     []string `json:"assignees,omitempty"`
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"teamReviewers,omitempty"`
+}
+
+// Rule triages the pull requests of the RFCs holding an action it matches, an empty criterion matches any action
+type Rule struct {
+	ActionType models.ActionType `json:"actionType,omitempty"`
+	TargetType models.TargetType `json:"targetType,omitempty"`
+	// TargetDescriptor is a pattern of the target descriptors matched, see path.Match for its syntax
+	TargetDescriptor string `json:"targetDescriptor,omitempty"`
+	Triage
+}
+
+// Policy triages every pull request with its defaults, along with the triage of every rule matching an action of its
+// RFC
+type Policy struct {
+	Defaults Triage `json:"defaults"`
+	Rules    []Rule `json:"rules,omitempty"`
+}
+
+// Default is the triage policy of the application, nil if pull requests are not triaged
+var Default *Policy
+
+// NewPolicy returns the given policy once the patterns of its rules are validated
+func NewPolicy(policy Policy) (*Policy, error) {
+	for i, rule := range policy.Rules {
+		if _, err := path.Match(rule.TargetDescriptor, ""); err != nil {
+			return nil, fmt.Errorf("rule %d has a malformed target descriptor pattern %s", i, rule.TargetDescriptor)
+		}
+	}
+
+	return &policy, nil
+}
+
+// Load returns the policy of the given JSON file, a Policy object
+func Load(file string) (*Policy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		logging.Default.Error("unable to read pull request triage file", "file", file, logging.ERROR_KEY, err)
+		return nil, err
+	}
+
+	var policy Policy
+	if err = json.Unmarshal(content, &policy); err != nil {
+		return nil, fmt.Errorf("malformed pull request triage file %s: %w", file, err)
+	}
+
+	return NewPolicy(policy)
+}
+
+// Of returns the triage of the pull request of the given RFC, the defaults along with the triage of every rule
+// matching one of its actions, each list sorted and without repetitions
+func (p *Policy) Of(rfc *models.RFC) Triage {
+	labels, assignees := set.NewSetOf(p.Defaults.Labels...), set.NewSetOf(p.Defaults.Assignees...)
+	reviewers, teamReviewers := set.NewSetOf(p.Defaults.Reviewers...), set.NewSetOf(p.Defaults.TeamReviewers...)
+	for _, rule := range p.Rules {
+		for _, action := range rfc.Actions {
+			if rule.matches(action) {
+				labels.Add(rule.Labels...)
+				assignees.Add(rule.Assignees...)
+				reviewers.Add(rule.Reviewers...)
+				teamReviewers.Add(rule.TeamReviewers...)
+				break
+			}
+		}
+	}
+
+	return Triage{
+		Labels:        sorted(labels),
+		Assignees:     sorted(assignees),
+		Reviewers:     sorted(reviewers),
+		TeamReviewers: sorted(teamReviewers),
+	}
+}
+
+// matches returns true if the given action meets every criterion of the rule
+func (r *Rule) matches(action *models.Action) bool {
+	if r.ActionType != "" && action.ActionType != r.ActionType {
+		return false
+	}
+	if r.TargetType != "" && action.Target.TargetType != r.TargetType {
+		return false
+	}
+	if r.TargetDescriptor == "" {
+		return true
+	}
+	matched, _ := path.Match(r.TargetDescriptor, action.Target.TargetDescriptor)
+	return matched
+}
+
+// sorted returns the values of the given set sorted, nil if it is empty
+func sorted(values set.Set[string]) []string {
+	if values.Size() == 0 {
+		return nil
+	}
+	sortedValues := values.Values()
+	sort.Strings(sortedValues)
+	return sortedValues
+}
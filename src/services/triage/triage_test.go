@@ -0,0 +1,75 @@
+package triage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"harmonia-example.io/src/models"
+)
+
+func TestOf(t *testing.T) {
+	// arrange
+	policy, err := NewPolicy(Policy{
+		Defaults: Triage{Labels: []string{"rfc"}, TeamReviewers: []string{"platform"}},
+		Rules: []Rule{
+			{ActionType: models.AddAction, Triage: Triage{Labels: []string{"addition"}}},
+			{TargetDescriptor: "Entity*", Triage: Triage{Labels: []string{"schema"}, Assignees: []string{"tstark"}}},
+			{TargetType: models.RfcTarget, Triage: Triage{Reviewers: []string{"pparker"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testCases := []struct {
+		name     string
+		actions  models.Actions
+		expected Triage
+	}{
+		{
+			name:     "defaults only",
+			actions:  models.Actions{},
+			expected: Triage{Labels: []string{"rfc"}, TeamReviewers: []string{"platform"}},
+		},
+		{
+			name: "matching rules",
+			actions: models.Actions{
+				&models.Action{ActionType: models.AddAction, Target: models.Target{TargetDescriptor: "EntityType"}},
+				&models.Action{ActionType: models.UpdateAction, Target: models.Target{TargetDescriptor: "EntityKey"}},
+			},
+			expected: Triage{
+				Labels:        []string{"addition", "rfc", "schema"},
+				Assignees:     []string{"tstark"},
+				TeamReviewers: []string{"platform"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			triage := policy.Of(&models.RFC{Actions: tc.actions})
+
+			// assert
+			if !reflect.DeepEqual(triage, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, triage)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	// arrange
+	file := filepath.Join(t.TempDir(), "triage.json")
+	os.WriteFile(file, []byte(`{"defaults": {"labels": ["rfc"]}, "rules": [{"targetDescriptor": "[", "labels": ["x"]}]}`),
+		0600)
+
+	// act
+	_, err := Load(file)
+
+	// assert
+	if err == nil {
+		t.Errorf("expected a malformed pattern to be rejected")
+	}
+}